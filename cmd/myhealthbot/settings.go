@@ -11,12 +11,15 @@ import (
 )
 
 const (
-	_defaultToken       = ""
-	_defaultPollTimeout = 10 * time.Second
-	_defaultDBFilePath  = ""
-	_defaultLogLevel    = "INFO"
-	_defaultTZ          = "Europe/Moscow"
-	_defaultDebugMode   = false
+	_defaultToken           = ""
+	_defaultPollTimeout     = 10 * time.Second
+	_defaultDBFilePath      = ""
+	_defaultDBDSN           = ""
+	_defaultLogLevel        = "INFO"
+	_defaultTZ              = "Europe/Moscow"
+	_defaultDebugMode       = false
+	_defaultReportAssets    = "cdn"
+	_defaultReportAssetsURL = ""
 )
 
 type IDList []int64
@@ -35,13 +38,16 @@ func (r *IDList) Set(v string) error {
 }
 
 type Config struct {
-	Token          string
-	PollTimeOut    time.Duration
-	DBFilePath     string
-	LogLevel       string
-	TZ             string
-	AllowedUserIDs IDList
-	DebugMode      bool
+	Token           string
+	PollTimeOut     time.Duration
+	DBFilePath      string
+	DBDSN           string
+	LogLevel        string
+	TZ              string
+	AllowedUserIDs  IDList
+	DebugMode       bool
+	ReportAssets    string
+	ReportAssetsURL string
 }
 
 func LoadConfig(flagSet flag.FlagSet, flags []string) (*Config, error) {
@@ -49,11 +55,14 @@ func LoadConfig(flagSet flag.FlagSet, flags []string) (*Config, error) {
 
 	flagSet.StringVar(&config.Token, "t", _defaultToken, "Telegram API token (required)")
 	flagSet.StringVar(&config.DBFilePath, "d", _defaultDBFilePath, "DB file path")
+	flagSet.StringVar(&config.DBDSN, "pg", _defaultDBDSN, "PostgreSQL DSN, used instead of DB file")
 	flagSet.StringVar(&config.LogLevel, "l", _defaultLogLevel, "Log level")
 	flagSet.StringVar(&config.TZ, "z", _defaultTZ, "Timezone")
 	flagSet.DurationVar(&config.PollTimeOut, "p", _defaultPollTimeout, "Telegram API poll timeout")
 	flagSet.Var(&config.AllowedUserIDs, "u", "Allowed User ID")
 	flagSet.BoolVar(&config.DebugMode, "b", _defaultDebugMode, "Debug mode")
+	flagSet.StringVar(&config.ReportAssets, "r", _defaultReportAssets, "Report assets mode (cdn, inline, server)")
+	flagSet.StringVar(&config.ReportAssetsURL, "ra", _defaultReportAssetsURL, "Report assets base URL for server mode")
 
 	flagSet.Usage = func() {
 		fmt.Fprintf(os.Stderr, "Usage of %s:\n", os.Args[0])
@@ -69,8 +78,8 @@ func LoadConfig(flagSet flag.FlagSet, flags []string) (*Config, error) {
 		return nil, fmt.Errorf("invalid token")
 	}
 
-	if config.DBFilePath == _defaultDBFilePath {
-		return nil, fmt.Errorf("invalid DB file path")
+	if (config.DBFilePath == _defaultDBFilePath) == (config.DBDSN == _defaultDBDSN) {
+		return nil, fmt.Errorf("invalid DB: set either file path or PostgreSQL DSN")
 	}
 
 	return config, nil
@@ -81,8 +90,11 @@ func ServiceSettingsAdapt(config *Config, buildCommit string) (*bot.ServiceSetti
 		config.Token,
 		config.PollTimeOut,
 		config.DBFilePath,
+		config.DBDSN,
 		config.AllowedUserIDs,
 		config.TZ,
 		buildCommit,
-		config.DebugMode)
+		config.DebugMode,
+		config.ReportAssets,
+		config.ReportAssetsURL)
 }
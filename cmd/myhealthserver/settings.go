@@ -14,18 +14,22 @@ const (
 	_defaultShutdownTimeout = 15 * time.Second
 	_defaultLogLevel        = "INFO"
 	_defaultDBFilePath      = ""
+	_defaultDBDSN           = ""
 	_defaultUserID          = -1
 	_defaultTLSCertFile     = ""
 	_defaultTLSKeyFile      = ""
 	_defaultTZ              = "Europe/Moscow"
 	_defaultFileStorafePath = ""
 	_defaultDebugMode       = false
+	_defaultReportAssets    = "cdn"
+	_defaultReportAssetsURL = ""
 )
 
 type Config struct {
 	RunAddress      string
 	ShutdownTimeout time.Duration
 	DBFilePath      string
+	DBDSN           string
 	LogLevel        string
 	UserID          int64
 	TLSCertFile     string
@@ -33,6 +37,8 @@ type Config struct {
 	TZ              string
 	FileStoragePath string
 	DebugMode       bool
+	ReportAssets    string
+	ReportAssetsURL string
 }
 
 func LoadConfig(flagSet flag.FlagSet, flags []string) (*Config, error) {
@@ -40,6 +46,7 @@ func LoadConfig(flagSet flag.FlagSet, flags []string) (*Config, error) {
 
 	flagSet.StringVar(&config.RunAddress, "a", _defaultRunAddress, "Server run address")
 	flagSet.StringVar(&config.DBFilePath, "d", _defaultDBFilePath, "DB file path")
+	flagSet.StringVar(&config.DBDSN, "pg", _defaultDBDSN, "PostgreSQL DSN, used instead of DB file")
 	flagSet.StringVar(&config.LogLevel, "l", _defaultLogLevel, "Log level")
 	flagSet.Int64Var(&config.UserID, "u", _defaultUserID, "User ID")
 	flagSet.DurationVar(&config.ShutdownTimeout, "t", _defaultShutdownTimeout, "Server shutdown timeout")
@@ -48,6 +55,8 @@ func LoadConfig(flagSet flag.FlagSet, flags []string) (*Config, error) {
 	flagSet.StringVar(&config.TZ, "z", _defaultTZ, "Timezone")
 	flagSet.StringVar(&config.FileStoragePath, "s", _defaultFileStorafePath, "File storage path")
 	flagSet.BoolVar(&config.DebugMode, "b", _defaultDebugMode, "Debug mode")
+	flagSet.StringVar(&config.ReportAssets, "r", _defaultReportAssets, "Report assets mode (cdn, inline, server)")
+	flagSet.StringVar(&config.ReportAssetsURL, "ra", _defaultReportAssetsURL, "Report assets base URL for server mode (default /static/report)")
 
 	flagSet.Usage = func() {
 		fmt.Fprintf(os.Stderr, "Usage of %s:\n", os.Args[0])
@@ -59,8 +68,8 @@ func LoadConfig(flagSet flag.FlagSet, flags []string) (*Config, error) {
 		return nil, err
 	}
 
-	if config.DBFilePath == _defaultDBFilePath {
-		return nil, fmt.Errorf("invalid DB file path")
+	if (config.DBFilePath == _defaultDBFilePath) == (config.DBDSN == _defaultDBDSN) {
+		return nil, fmt.Errorf("invalid DB: set either file path or PostgreSQL DSN")
 	}
 
 	if config.UserID == _defaultUserID {
@@ -86,11 +95,14 @@ func ServiceSettingsAdapt(config *Config) (*srv.ServerSettings, error) {
 	return srv.NewServerSettings(
 		config.RunAddress,
 		config.DBFilePath,
+		config.DBDSN,
 		config.ShutdownTimeout,
 		config.UserID,
 		config.TLSCertFile,
 		config.TLSKeyFile,
 		config.TZ,
 		config.FileStoragePath,
-		config.DebugMode)
+		config.DebugMode,
+		config.ReportAssets,
+		config.ReportAssetsURL)
 }
@@ -0,0 +1,211 @@
+package cmdproc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	m "github.com/devldavydov/myhealth/internal/common/messages"
+	"github.com/devldavydov/myhealth/internal/storage"
+	"go.uber.org/zap"
+)
+
+const (
+	_batchMaxLines   = 100
+	_batchLineMaxLen = 40
+
+	_batchModeTx       = "!tx"
+	_batchModeContinue = "!cont"
+	_batchComment      = "#"
+)
+
+type batchMode int
+
+const (
+	// batchModeTx runs all commands in single transaction, it is rolled
+	// back on first failed command.
+	batchModeTx batchMode = iota
+	// batchModeContinue runs all commands, failed commands are skipped.
+	batchModeContinue
+)
+
+var errBatchFailed = errors.New("batch command failed")
+
+// batchLineResult is result of batch line for summary.
+type batchLineResult struct {
+	line   string
+	status string
+	resp   []CmdResponse
+}
+
+// parseBatch splits command to lines of batch, ok is false for
+// single command. Empty lines and comments are skipped, first line can
+// set batch mode.
+func parseBatch(cmd string) (mode batchMode, lines []string, ok bool) {
+	// Line break inside quotes is part of argument
+	parts, err := splitArgs(cmd, '\n')
+	if err != nil {
+		parts = strings.Split(cmd, "\n")
+	}
+
+	hasMode := false
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" || strings.HasPrefix(part, _batchComment) {
+			continue
+		}
+
+		if len(lines) == 0 && !hasMode {
+			switch part {
+			case _batchModeTx:
+				mode, hasMode = batchModeTx, true
+				continue
+			case _batchModeContinue:
+				mode, hasMode = batchModeContinue, true
+				continue
+			}
+		}
+
+		lines = append(lines, part)
+	}
+
+	return mode, lines, hasMode || len(lines) > 1
+}
+
+func (r *CmdProcessor) processBatch(mode batchMode, lines []string, userID int64) []CmdResponse {
+	if len(lines) == 0 {
+		return NewErrCmdResponse(m.MsgErrInvalidCommand)
+	}
+
+	if len(lines) > _batchMaxLines {
+		return NewErrCmdResponse(fmt.Sprintf("%s: %d > %d", m.MsgErrBatchTooLarge, len(lines), _batchMaxLines))
+	}
+
+	results := make([]batchLineResult, 0, len(lines))
+	for _, line := range lines {
+		results = append(results, batchLineResult{line: line, status: "не выполнено"})
+	}
+
+	var done int
+	var failed bool
+	switch mode {
+	case batchModeTx:
+		done, failed = r.processBatchTx(results, userID)
+	case batchModeContinue:
+		done, failed = r.processBatchContinue(results, userID)
+	}
+
+	var sb strings.Builder
+	if mode == batchModeTx && failed {
+		sb.WriteString("Пакет команд отменен\n")
+	} else {
+		sb.WriteString(fmt.Sprintf("Пакет команд: выполнено %d из %d\n", done, len(lines)))
+	}
+
+	for i, res := range results {
+		sb.WriteString(fmt.Sprintf("%d. %s - %s\n", i+1, shortBatchLine(res.line), res.status))
+	}
+
+	resp := NewSingleCmdResponse(sb.String())
+	if mode == batchModeTx && failed {
+		resp[0].err = true
+		return resp
+	}
+
+	// Output of commands except plain OK
+	for _, res := range results {
+		if isErrResponse(res.resp) {
+			continue
+		}
+		for _, rItem := range res.resp {
+			if rItem.what != m.MsgOK {
+				resp = append(resp, rItem)
+			}
+		}
+	}
+
+	return resp
+}
+
+func (r *CmdProcessor) processBatchTx(results []batchLineResult, userID int64) (int, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageBatchTimeout)
+	defer cancel()
+
+	done := 0
+	err := r.stg.InTx(ctx, func(stg storage.Storage) error {
+		txProc := *r
+		txProc.stg = stg
+
+		for i := range results {
+			results[i].resp = txProc.processCmd(results[i].line, userID)
+			if isErrResponse(results[i].resp) {
+				results[i].status = batchErrStatus(results[i].resp)
+				return errBatchFailed
+			}
+
+			results[i].status = m.MsgOK
+			done++
+		}
+
+		return nil
+	})
+
+	if err == nil {
+		return done, false
+	}
+
+	if !errors.Is(err, errBatchFailed) {
+		r.logger.Error(
+			"batch command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+	}
+
+	// Nothing is saved after rollback
+	for i := range results {
+		switch {
+		case i < done:
+			results[i].status = "отменено"
+		case i == done && !errors.Is(err, errBatchFailed):
+			results[i].status = m.MsgErrInternal
+		}
+	}
+
+	return 0, true
+}
+
+func (r *CmdProcessor) processBatchContinue(results []batchLineResult, userID int64) (int, bool) {
+	done, failed := 0, false
+	for i := range results {
+		results[i].resp = r.processCmd(results[i].line, userID)
+		if isErrResponse(results[i].resp) {
+			results[i].status = batchErrStatus(results[i].resp)
+			failed = true
+			continue
+		}
+
+		results[i].status = m.MsgOK
+		done++
+	}
+
+	return done, failed
+}
+
+func batchErrStatus(resp []CmdResponse) string {
+	for _, rItem := range resp {
+		if s, ok := rItem.what.(string); ok && rItem.err {
+			return strings.ReplaceAll(s, "\n", " ")
+		}
+	}
+	return m.MsgErrInternal
+}
+
+func shortBatchLine(line string) string {
+	rs := []rune(line)
+	if len(rs) <= _batchLineMaxLen {
+		return line
+	}
+	return string(rs[:_batchLineMaxLen]) + "..."
+}
@@ -0,0 +1,111 @@
+package cmdproc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/devldavydov/myhealth/internal/common/html"
+	m "github.com/devldavydov/myhealth/internal/common/messages"
+	"github.com/devldavydov/myhealth/internal/storage"
+	"github.com/devldavydov/myhealth/internal/storage/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type testCmdProcess struct {
+	sent []any
+}
+
+func (r *testCmdProcess) Send(what any, opts ...any) error {
+	r.sent = append(r.sent, what)
+	return nil
+}
+
+func TestParseBatch(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		cmd   string
+		mode  batchMode
+		lines []string
+		ok    bool
+	}{
+		{name: "single", cmd: "w,set,01.01.2025,90", lines: []string{"w,set,01.01.2025,90"}},
+		{name: "single with line break", cmd: "w,set,01.01.2025,90\n\n", lines: []string{"w,set,01.01.2025,90"}},
+		{
+			name:  "several",
+			cmd:   "w,set,01.01.2025,90\r\n# comment\n  w,set,02.01.2025,91",
+			lines: []string{"w,set,01.01.2025,90", "w,set,02.01.2025,91"},
+			ok:    true,
+		},
+		{name: "mode", cmd: "!cont\nw,h", mode: batchModeContinue, lines: []string{"w,h"}, ok: true},
+		{name: "mode tx", cmd: "!tx\nw,h\n!cont", mode: batchModeTx, lines: []string{"w,h", "!cont"}, ok: true},
+		{name: "quoted line break", cmd: "f,set,a,\"b\nc\"", lines: []string{"f,set,a,\"b\nc\""}},
+		{name: "unterminated quote", cmd: "w,h\nf,set,\"a", lines: []string{"w,h", "f,set,\"a"}, ok: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			mode, lines, ok := parseBatch(tt.cmd)
+			assert.Equal(t, tt.mode, mode)
+			assert.Equal(t, tt.lines, lines)
+			assert.Equal(t, tt.ok, ok)
+		})
+	}
+}
+
+func TestProcessBatch(t *testing.T) {
+	stg := memory.NewStorageMemory()
+	r := NewCmdProcessor(stg, nil, time.UTC, html.Assets{}, false, zap.NewNop())
+	weights := func() []storage.Weight {
+		res, err := stg.GetWeightList(context.Background(), 1, 0, storage.NewTimestamp(time.Now()), false)
+		if err != nil {
+			return nil
+		}
+		return res
+	}
+
+	t.Run("tx rollback", func(t *testing.T) {
+		c := &testCmdProcess{}
+		require.NoError(t, r.Process(c, "w,set,01.01.2025,90\nw,set,bad,91\nw,set,03.01.2025,92", 1))
+		assert.Empty(t, weights())
+		assert.Equal(t, []any{
+			"Пакет команд отменен\n" +
+				"1. w,set,01.01.2025,90 - отменено\n" +
+				"2. w,set,bad,91 - " + m.MsgErrInvalidArg + ": Дата\n" +
+				"3. w,set,03.01.2025,92 - не выполнено\n",
+		}, c.sent)
+	})
+
+	t.Run("tx commit", func(t *testing.T) {
+		c := &testCmdProcess{}
+		require.NoError(t, r.Process(c, "!tx\nw,set,01.01.2025,90\nw,set,02.01.2025,91", 1))
+		assert.Len(t, weights(), 2)
+		assert.Equal(t, []any{
+			"Пакет команд: выполнено 2 из 2\n" +
+				"1. w,set,01.01.2025,90 - OK\n" +
+				"2. w,set,02.01.2025,91 - OK\n",
+		}, c.sent)
+	})
+
+	t.Run("continue on error", func(t *testing.T) {
+		c := &testCmdProcess{}
+		require.NoError(t, r.Process(c, "!cont\nw,del,01.01.2025\nww,h\nw,del,02.01.2025", 1))
+		assert.Empty(t, weights())
+		assert.Equal(t, []any{
+			"Пакет команд: выполнено 2 из 3\n" +
+				"1. w,del,01.01.2025 - OK\n" +
+				"2. ww,h - " + m.MsgErrInvalidCommand + " " + m.MsgDidYouMean + ": w\n" +
+				"3. w,del,02.01.2025 - OK\n",
+		}, c.sent)
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		c := &testCmdProcess{}
+		cmd := "!cont"
+		for i := 0; i <= _batchMaxLines; i++ {
+			cmd += "\nw,h"
+		}
+		require.NoError(t, r.Process(c, cmd, 1))
+		assert.Equal(t, []any{m.MsgErrBatchTooLarge + ": 101 > 100"}, c.sent)
+	})
+}
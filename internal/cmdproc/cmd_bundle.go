@@ -1,10 +1,11 @@
 package cmdproc
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -22,12 +23,12 @@ func (r *CmdProcessor) bundleSetCommand(userID int64, key string, bndlParts []st
 			// Add dependant food
 			parts := strings.Split(bndlPart, ":")
 			if len(parts) > 2 {
-				return NewSingleCmdResponse(m.MsgErrInvalidCommand)
+				return NewErrCmdResponse(m.MsgErrInvalidCommand)
 			}
 
 			weight, err := strconv.ParseFloat(parts[1], 64)
 			if err != nil {
-				return NewSingleCmdResponse(m.MsgErrInvalidCommand)
+				return NewErrCmdResponse(m.MsgErrInvalidCommand)
 			}
 
 			bndlData[parts[0]] = weight
@@ -43,16 +44,16 @@ func (r *CmdProcessor) bundleSetCommand(userID int64, key string, bndlParts []st
 
 	if err := r.stg.SetBundle(ctx, userID, &storage.Bundle{Key: key, Data: bndlData}, true); err != nil {
 		if errors.Is(err, storage.ErrBundleInvalid) {
-			return NewSingleCmdResponse(m.MsgErrInvalidCommand)
+			return NewErrCmdResponse(m.MsgErrInvalidCommand)
 		}
 		if errors.Is(err, storage.ErrBundleDepBundleNotFound) {
-			return NewSingleCmdResponse(m.MsgErrBundleDepBundleNotFound)
+			return NewErrCmdResponse(m.MsgErrBundleDepBundleNotFound)
 		}
 		if errors.Is(err, storage.ErrBundleDepRecursive) {
-			return NewSingleCmdResponse(m.MsgErrBundleDepBundleRecursive)
+			return NewErrCmdResponse(m.MsgErrBundleDepBundleRecursive)
 		}
 		if errors.Is(err, storage.ErrBundleDepFoodNotFound) {
-			return NewSingleCmdResponse(m.MsgErrBundleDepFoodNotFound)
+			return NewErrCmdResponse(m.MsgErrBundleDepFoodNotFound)
 		}
 
 		r.logger.Error(
@@ -62,7 +63,7 @@ func (r *CmdProcessor) bundleSetCommand(userID int64, key string, bndlParts []st
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
 	return NewSingleCmdResponse(m.MsgOK)
@@ -76,7 +77,7 @@ func (r *CmdProcessor) bundleSetTemplateCommand(userID int64, key string) []CmdR
 	bndl, err := r.stg.GetBundle(ctx, userID, key)
 	if err != nil {
 		if errors.Is(err, storage.ErrBundleNotFound) {
-			return NewSingleCmdResponse(m.MsgErrBundleNotFound)
+			return NewErrCmdResponse(m.MsgErrBundleNotFound)
 		}
 
 		r.logger.Error(
@@ -85,18 +86,18 @@ func (r *CmdProcessor) bundleSetTemplateCommand(userID int64, key string) []CmdR
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
 	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("b,set,%s,", bndl.Key))
+	sb.WriteString(fmt.Sprintf("b,set,%s,", quoteArg(bndl.Key)))
 
 	items := make([]string, 0, len(bndl.Data))
-	for k, v := range bndl.Data {
-		if v > 0 {
-			items = append(items, fmt.Sprintf("%s:%1.f", k, v))
+	for _, k := range slices.Sorted(maps.Keys(bndl.Data)) {
+		if v := bndl.Data[k]; v > 0 {
+			items = append(items, fmt.Sprintf("%s:%1.f", quoteArrItem(k), v))
 		} else {
-			items = append(items, k)
+			items = append(items, quoteArrItem(k))
 		}
 	}
 	sb.WriteString(strings.Join(items, "/"))
@@ -104,7 +105,7 @@ func (r *CmdProcessor) bundleSetTemplateCommand(userID int64, key string) []CmdR
 	return NewSingleCmdResponse(sb.String())
 }
 
-func (r *CmdProcessor) bundleListCommand(userID int64) []CmdResponse {
+func (r *CmdProcessor) bundleListCommand(userID int64, format storage.ReportFormat) []CmdResponse {
 	// Get in DB
 	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
 	defer cancel()
@@ -112,7 +113,7 @@ func (r *CmdProcessor) bundleListCommand(userID int64) []CmdResponse {
 	lst, err := r.stg.GetBundleList(ctx, userID)
 	if err != nil {
 		if errors.Is(err, storage.ErrEmptyResult) {
-			return NewSingleCmdResponse(m.MsgErrEmptyResult)
+			return NewErrCmdResponse(m.MsgErrEmptyResult)
 		}
 
 		r.logger.Error(
@@ -121,11 +122,11 @@ func (r *CmdProcessor) bundleListCommand(userID int64) []CmdResponse {
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
 	// Build html
-	htmlBuilder := html.NewBuilder("Список бандлов")
+	htmlBuilder := html.NewBuilder("Список бандлов", r.assets)
 
 	// Table
 	tbl := html.NewTable([]string{
@@ -134,7 +135,8 @@ func (r *CmdProcessor) bundleListCommand(userID int64) []CmdResponse {
 
 	for _, bndl := range lst {
 		i := 0
-		for k, v := range bndl.Data {
+		for _, k := range slices.Sorted(maps.Keys(bndl.Data)) {
+			v := bndl.Data[k]
 			tr := html.NewTr(nil)
 			if i == 0 {
 				tr.AddTd(html.NewTd(html.NewS(bndl.Key), html.Attrs{"rowspan": strconv.Itoa(len(bndl.Data))}))
@@ -162,11 +164,7 @@ func (r *CmdProcessor) bundleListCommand(userID int64) []CmdResponse {
 			tbl))
 
 	// Response
-	return NewSingleCmdResponse(
-		r.typeAdapter.File(
-			bytes.NewBufferString(htmlBuilder.Build()),
-			"text/html",
-			"bundles.html"))
+	return r.reportResponse(userID, htmlBuilder, "bundles", format)
 }
 
 func (r *CmdProcessor) bundleDelCommand(userID int64, key string) []CmdResponse {
@@ -176,7 +174,7 @@ func (r *CmdProcessor) bundleDelCommand(userID int64, key string) []CmdResponse
 
 	if err := r.stg.DeleteBundle(ctx, userID, key); err != nil {
 		if errors.Is(err, storage.ErrBundleIsUsed) {
-			return NewSingleCmdResponse(m.MsgErrBundleIsUsed)
+			return NewErrCmdResponse(m.MsgErrBundleIsUsed)
 		}
 
 		r.logger.Error(
@@ -185,7 +183,7 @@ func (r *CmdProcessor) bundleDelCommand(userID int64, key string) []CmdResponse
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
 	return NewSingleCmdResponse(m.MsgOK)
@@ -1,37 +1,171 @@
 package cmdproc
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"math"
 	"strings"
+
+	m "github.com/devldavydov/myhealth/internal/common/messages"
+	"github.com/devldavydov/myhealth/internal/storage"
+	"go.uber.org/zap"
 )
 
+var _activityLevels = []storage.ActivityLevel{1, 2, 3, 4, 5}
+
 func (r *CmdProcessor) calcCalCalcCommand(userID int64, gender string, weight float64, height float64, age float64) []CmdResponse {
-	ubm := 10*weight + 6.25*height - 5*age
-	if gender == "m" {
-		ubm += 5
-	} else {
-		ubm -= 161
-	}
+	ubm := calcBMRMifflinStJeor(storage.Sex(gender), weight, height, age)
 
 	var sb strings.Builder
 	sb.WriteString("<b>Уровень Базального Метаболизма (УБМ)</b>\n")
-	sb.WriteString(fmt.Sprintf("%d ккал\n\n", int64(ubm)))
+	sb.WriteString(fmt.Sprintf("%d ккал\n", int64(ubm)))
+	sb.WriteString(fmt.Sprintf(
+		"Харрис-Бенедикт: %d ккал\n\n",
+		int64(calcBMRHarrisBenedict(storage.Sex(gender), weight, height, age)),
+	))
 
 	sb.WriteString("<b>Усредненные значения по активностям</b>\n")
-	for _, i := range []struct {
-		name string
-		k    float64
-	}{
-		{name: "\u2022 Сидячая активность", k: 1.2},
-		{name: "\u2022 Легкая активность", k: 1.375},
-		{name: "\u2022 Средняя активность", k: 1.55},
-		{name: "\u2022 Полноценная активность", k: 1.725},
-		{name: "\u2022 Супер активность", k: 1.9},
-	} {
-		sb.WriteString(fmt.Sprintf("<b>%s</b>\n", i.name))
-		sb.WriteString(fmt.Sprintf("ККал: %d\n", int64(ubm*i.k)))
+	for _, a := range _activityLevels {
+		sb.WriteString(fmt.Sprintf("<b>\u2022 %s</b>\n", a.MustToString()))
+		sb.WriteString(fmt.Sprintf("ККал: %d\n", int64(ubm*a.Factor())))
 		sb.WriteString("\n")
 	}
 
 	return NewSingleCmdResponse(sb.String(), r.typeAdapter.OptsHTML())
 }
+
+func (r *CmdProcessor) calcCalProfileCommand(userID int64) []CmdResponse {
+	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
+	defer cancel()
+
+	us, err := r.stg.GetUserSettings(ctx, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrUserSettingsNotFound) {
+			return NewErrCmdResponse(m.MsgErrUserProfileNotFound)
+		}
+
+		r.logger.Error(
+			"calc cal profile command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	if us.Profile == nil {
+		return NewErrCmdResponse(m.MsgErrUserProfileNotFound)
+	}
+
+	now := timeNow().In(r.tz)
+	w, err := r.stg.GetLastWeight(ctx, userID, storage.NewTimestamp(now))
+	if err != nil {
+		if errors.Is(err, storage.ErrWeightNotFound) {
+			return NewErrCmdResponse(m.MsgErrWeightNotFound)
+		}
+
+		r.logger.Error(
+			"calc cal profile command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	p := us.Profile
+	age := float64(p.Age(r.tz, now))
+	bmrMSJ := calcBMRMifflinStJeor(p.Sex, w.Value, p.Height, age)
+	bmrHB := calcBMRHarrisBenedict(p.Sex, w.Value, p.Height, age)
+	bmr := calcProfileBMR(p, w.Value, age)
+
+	var sb strings.Builder
+	sb.WriteString("<b>Исходные данные</b>\n")
+	sb.WriteString(fmt.Sprintf("Вес: %.1f кг (%s)\n", w.Value, formatTimestamp(w.Timestamp.ToTime(r.tz))))
+	sb.WriteString(fmt.Sprintf("Рост: %.0f см\n", p.Height))
+	sb.WriteString(fmt.Sprintf("Возраст: %d\n", int64(age)))
+	sb.WriteString(fmt.Sprintf("Активность: %s\n\n", p.ActivityLevel.MustToString()))
+
+	sb.WriteString("<b>Уровень Базального Метаболизма (УБМ)</b>\n")
+	sb.WriteString(fmt.Sprintf("Миффлин-Сан Жеор: %d ккал\n", int64(bmrMSJ)))
+	sb.WriteString(fmt.Sprintf("Харрис-Бенедикт: %d ккал\n", int64(bmrHB)))
+	if p.BodyFat > 0 {
+		sb.WriteString(fmt.Sprintf("Кэтч-МакАрдл: %d ккал\n", int64(bmr)))
+	}
+	sb.WriteString("\n")
+
+	tdee := bmr * p.ActivityLevel.Factor()
+	calLimit := calcGoalCalLimit(p.Goal, p.GoalRate, bmr, tdee)
+	prot, fat, carb := calcGoalMacro(p.Goal, w.Value, calLimit)
+
+	sb.WriteString(fmt.Sprintf("<b>Цель: %s (%.2f кг/нед)</b>\n", p.Goal.MustToString(), p.GoalRate))
+	sb.WriteString(fmt.Sprintf("Расход ккал: %d\n", int64(tdee)))
+	sb.WriteString(fmt.Sprintf("Рекомендуемый лимит ккал: %d\n", int64(calLimit)))
+	sb.WriteString(fmt.Sprintf("Б: %d г, Ж: %d г, У: %d г\n\n", int64(prot), int64(fat), int64(carb)))
+	sb.WriteString("<b>Команда установки лимита</b>\n")
+	sb.WriteString(fmt.Sprintf("u,set,%.2f", calLimit))
+
+	return NewSingleCmdResponse(sb.String(), r.typeAdapter.OptsHTML())
+}
+
+// calcProfileBMR uses Katch-McArdle formula when body fat is known,
+// otherwise Mifflin-St Jeor.
+func calcProfileBMR(p *storage.UserProfile, weight, age float64) float64 {
+	if p.BodyFat > 0 {
+		return calcBMRKatchMcArdle(weight, p.BodyFat)
+	}
+	return calcBMRMifflinStJeor(p.Sex, weight, p.Height, age)
+}
+
+func calcBMRMifflinStJeor(sex storage.Sex, weight, height, age float64) float64 {
+	bmr := 10*weight + 6.25*height - 5*age
+	if sex == storage.SexMale {
+		return bmr + 5
+	}
+	return bmr - 161
+}
+
+func calcBMRHarrisBenedict(sex storage.Sex, weight, height, age float64) float64 {
+	// Revised by Roza and Shizgal (1984)
+	if sex == storage.SexMale {
+		return 88.362 + 13.397*weight + 4.799*height - 5.677*age
+	}
+	return 447.593 + 9.247*weight + 3.098*height - 4.330*age
+}
+
+func calcBMRKatchMcArdle(weight, bodyFat float64) float64 {
+	return 370 + 21.6*weight*(1-bodyFat/100)
+}
+
+// calcGoalCalLimit returns daily calorie limit for goal with rate in kg per week,
+// limit is never set below BMR.
+func calcGoalCalLimit(goal storage.Goal, rate, bmr, tdee float64) float64 {
+	delta := rate * _calPerKg / 7
+	switch goal {
+	case storage.GoalLose:
+		return math.Round(max(tdee-delta, bmr))
+	case storage.GoalGain:
+		return math.Round(tdee + delta)
+	default:
+		return math.Round(tdee)
+	}
+}
+
+// calcGoalMacro returns protein, fat and carbs in grams for calorie limit:
+// protein by body weight depending on goal, fat is 25% of calories, carbs are the rest.
+func calcGoalMacro(goal storage.Goal, weight, calLimit float64) (float64, float64, float64) {
+	protPerKg := 1.6
+	switch goal {
+	case storage.GoalLose:
+		protPerKg = 2.0
+	case storage.GoalGain:
+		protPerKg = 1.8
+	}
+
+	prot := protPerKg * weight
+	fat := calLimit * 0.25 / 9
+	carb := max((calLimit-prot*4-fat*9)/4, 0)
+
+	return prot, fat, carb
+}
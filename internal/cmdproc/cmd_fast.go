@@ -0,0 +1,437 @@
+package cmdproc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/devldavydov/myhealth/internal/common/html"
+	m "github.com/devldavydov/myhealth/internal/common/messages"
+	"github.com/devldavydov/myhealth/internal/storage"
+	"go.uber.org/zap"
+)
+
+func (r *CmdProcessor) fastStartNowCommand(userID int64) []CmdResponse {
+	return r.fastStartCommand(userID, timeNow().In(r.tz).Truncate(time.Minute))
+}
+
+func (r *CmdProcessor) fastStartCommand(userID int64, ts time.Time) []CmdResponse {
+	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
+	defer cancel()
+
+	if err := r.stg.AddFast(ctx, userID, &storage.Fast{
+		Start: storage.NewTimestamp(ts),
+	}); err != nil {
+		switch {
+		case errors.Is(err, storage.ErrFastActive):
+			return NewErrCmdResponse(m.MsgErrFastActive)
+		case errors.Is(err, storage.ErrFastConflict):
+			return NewErrCmdResponse(m.MsgErrFastConflict)
+		case errors.Is(err, storage.ErrFastInvalid):
+			return NewErrCmdResponse(m.MsgErrInvalidCommand)
+		}
+
+		r.logger.Error(
+			"fast start command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	return NewSingleCmdResponse(fmt.Sprintf("Голодание начато: %s", formatDateTime(ts)))
+}
+
+func (r *CmdProcessor) fastStopNowCommand(userID int64) []CmdResponse {
+	return r.fastStopCommand(userID, timeNow().In(r.tz).Truncate(time.Minute))
+}
+
+func (r *CmdProcessor) fastStopCommand(userID int64, ts time.Time) []CmdResponse {
+	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
+	defer cancel()
+
+	f, err := r.stg.GetActiveFast(ctx, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrFastNotFound) {
+			return NewErrCmdResponse(m.MsgErrFastNotFound)
+		}
+
+		r.logger.Error(
+			"fast stop command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	f.End = storage.NewTimestamp(ts)
+	if err := r.stg.StopFast(ctx, userID, f.Start, f.End); err != nil {
+		switch {
+		case errors.Is(err, storage.ErrFastNotFound):
+			return NewErrCmdResponse(m.MsgErrFastNotFound)
+		case errors.Is(err, storage.ErrFastInvalid):
+			return NewErrCmdResponse(m.MsgErrInvalidCommand)
+		}
+
+		r.logger.Error(
+			"fast stop command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	return NewSingleCmdResponse(fmt.Sprintf(
+		"Голодание завершено: %s - %s, длительность %s",
+		formatDateTime(f.Start.ToTime(r.tz)),
+		formatDateTime(ts),
+		formatDurationHM(f.Duration(f.End)),
+	))
+}
+
+func (r *CmdProcessor) fastCurrentCommand(userID int64) []CmdResponse {
+	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
+	defer cancel()
+
+	f, err := r.stg.GetActiveFast(ctx, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrFastNotFound) {
+			return NewErrCmdResponse(m.MsgErrFastNotFound)
+		}
+
+		r.logger.Error(
+			"fast current command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	return NewSingleCmdResponse(fmt.Sprintf(
+		"Голодание идет с %s, прошло %s",
+		formatDateTime(f.Start.ToTime(r.tz)),
+		formatDurationHM(f.Duration(storage.NewTimestamp(timeNow()))),
+	))
+}
+
+func (r *CmdProcessor) fastDelCommand(userID int64, ts time.Time) []CmdResponse {
+	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
+	defer cancel()
+
+	if err := r.stg.DeleteFast(ctx, userID,
+		storage.NewTimestamp(storage.DayStart(ts)),
+		storage.NewTimestamp(storage.DayEnd(ts)),
+	); err != nil {
+		r.logger.Error(
+			"fast del command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	return NewSingleCmdResponse(m.MsgOK)
+}
+
+// fastDay is eating window of a day, bounded by first and last meal
+// with time of day.
+type fastDay struct {
+	day       time.Time
+	firstMeal time.Time
+	lastMeal  time.Time
+}
+
+func (r fastDay) window() float64 {
+	return r.lastMeal.Sub(r.firstMeal).Hours()
+}
+
+func (r *CmdProcessor) fastReportCommand(
+	userID int64,
+	tsFrom, tsTo time.Time,
+	targetWindow float64,
+	format storage.ReportFormat,
+) []CmdResponse {
+	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
+	defer cancel()
+
+	// Previous day is requested to calculate fasting before first day
+	jrnl, err := r.stg.GetJournalReport(ctx, userID,
+		storage.NewTimestamp(storage.DayStart(tsFrom).AddDate(0, 0, -1)),
+		storage.NewTimestamp(storage.DayEnd(tsTo)))
+	if err != nil && !errors.Is(err, storage.ErrEmptyResult) {
+		r.logger.Error(
+			"fast report command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	fasts, err := r.stg.GetFastList(ctx, userID, storage.NewTimestamp(storage.DayStart(tsFrom)), storage.NewTimestamp(storage.DayEnd(tsTo)))
+	if err != nil && !errors.Is(err, storage.ErrEmptyResult) {
+		r.logger.Error(
+			"fast report command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	days := calcFastDays(jrnl, r.tz)
+
+	var prevDay *fastDay
+	if len(days) > 0 && days[0].day.Before(storage.DayStart(tsFrom)) {
+		prevDay = &days[0]
+		days = days[1:]
+	}
+
+	if len(days) == 0 && len(fasts) == 0 {
+		return NewErrCmdResponse(m.MsgErrEmptyResult)
+	}
+
+	// Build html
+	tsFromStr, tsToStr := formatTimestamp(tsFrom), formatTimestamp(tsTo)
+	htmlBuilder := html.NewBuilder("Голодание за период", r.assets)
+	accordion := html.NewAccordion("accordionFast")
+
+	// Eating window table
+	tbl := html.NewTable([]string{"Дата", "Первый прием", "Последний прием", "Окно питания, ч", "Голодание до первого приема, ч", "В цели"})
+
+	xlabels := make([]string, 0, len(days))
+	dataFirstMeal := make([]float64, 0, len(days))
+	dataLastMeal := make([]float64, 0, len(days))
+	dataWindow := make([]float64, 0, len(days))
+	var dataFasting []float64
+	daysInTarget, curStreak, maxStreak := calcFastStreaks(days, targetWindow)
+
+	for i, d := range days {
+		window := d.window()
+
+		fastingStr := "-"
+		if prevDay != nil && prevDay.day.AddDate(0, 0, 1).Equal(d.day) {
+			fasting := d.firstMeal.Sub(prevDay.lastMeal).Hours()
+			fastingStr = fmt.Sprintf("%.1f", fasting)
+			dataFasting = append(dataFasting, fasting)
+		}
+		prevDay = &days[i]
+
+		inTargetStr := "-"
+		if window <= targetWindow {
+			inTargetStr = "+"
+		}
+
+		firstMeal := d.firstMeal.Sub(d.day).Hours()
+		lastMeal := d.lastMeal.Sub(d.day).Hours()
+
+		tbl.AddRow(
+			html.NewTr(nil).
+				AddTd(html.NewTd(html.NewS(formatTimestamp(d.day)), nil)).
+				AddTd(html.NewTd(html.NewS(formatClockHours(firstMeal)), nil)).
+				AddTd(html.NewTd(html.NewS(formatClockHours(lastMeal)), nil)).
+				AddTd(html.NewTd(html.NewS(fmt.Sprintf("%.1f", window)), nil)).
+				AddTd(html.NewTd(html.NewS(fastingStr), nil)).
+				AddTd(html.NewTd(html.NewS(inTargetStr), nil)),
+		)
+
+		xlabels = append(xlabels, formatTimestamp(d.day))
+		dataFirstMeal = append(dataFirstMeal, firstMeal)
+		dataLastMeal = append(dataLastMeal, lastMeal)
+		dataWindow = append(dataWindow, window)
+	}
+
+	// Fast sessions table
+	tblFast := html.NewTable([]string{"Начало", "Окончание", "Длительность"})
+	now := storage.NewTimestamp(timeNow())
+	var fastDurations []float64
+
+	for _, f := range fasts {
+		endStr := "идет"
+		if !f.Active() {
+			endStr = formatDateTime(f.End.ToTime(r.tz))
+			fastDurations = append(fastDurations, f.Duration(now).Hours())
+		}
+
+		tblFast.AddRow(
+			html.NewTr(nil).
+				AddTd(html.NewTd(html.NewS(formatDateTime(f.Start.ToTime(r.tz))), nil)).
+				AddTd(html.NewTd(html.NewS(endStr), nil)).
+				AddTd(html.NewTd(html.NewS(formatDurationHM(f.Duration(now))), nil)),
+		)
+	}
+
+	// Summary
+	tblSummary := html.NewTable([]string{"Показатель", "Значение"})
+	addRow := func(name, val string) {
+		tblSummary.AddRow(html.NewTr(nil).
+			AddTd(html.NewTd(html.NewS(name), nil)).
+			AddTd(html.NewTd(html.NewS(val), nil)))
+	}
+
+	windowMean, _ := calcMeanStdDev(dataWindow)
+	fastingMean, _ := calcMeanStdDev(dataFasting)
+	fastMean, _ := calcMeanStdDev(fastDurations)
+
+	addRow("Дней с записями", fmt.Sprintf("%d", len(days)))
+	addRow("Целевое окно питания, ч", fmt.Sprintf("%.1f", targetWindow))
+	addRow("Среднее окно питания, ч", fmt.Sprintf("%.1f", windowMean))
+	addRow("Среднее голодание между днями, ч", fmt.Sprintf("%.1f", fastingMean))
+	addRow("Дней в цели", fmt.Sprintf("%d", daysInTarget))
+	addRow("Текущая серия дней в цели", fmt.Sprintf("%d", curStreak))
+	addRow("Максимальная серия дней в цели", fmt.Sprintf("%d", maxStreak))
+	addRow("Завершенных голоданий", fmt.Sprintf("%d", len(fastDurations)))
+	addRow("Средняя длительность голодания, ч", fmt.Sprintf("%.1f", fastMean))
+
+	accordion.AddItem(html.HewAccordionItem(
+		"summary",
+		"Итоги",
+		tblSummary,
+	))
+	accordion.AddItem(html.HewAccordionItem(
+		"tbl",
+		"Таблица окна питания",
+		tbl,
+	))
+	accordion.AddItem(html.HewAccordionItem(
+		"tblFast",
+		"Таблица голоданий",
+		tblFast,
+	))
+
+	// Charts
+	charts := []struct {
+		id    string
+		title string
+		data  *ChartData
+	}{
+		{
+			id:    "chartMealTime",
+			title: "График времени первого и последнего приема",
+			data: &ChartData{
+				XLabels: xlabels,
+				Type:    "line",
+				Datasets: []ChartDataset{
+					{Data: dataFirstMeal, Label: "Первый прием, ч от полуночи", Color: ChartColorGreen},
+					{Data: dataLastMeal, Label: "Последний прием, ч от полуночи", Color: ChartColorRed},
+				},
+			},
+		},
+		{
+			id:    "chartWindow",
+			title: "График окна питания",
+			data: &ChartData{
+				XLabels: xlabels,
+				Type:    "bar",
+				Datasets: []ChartDataset{
+					{Data: dataWindow, Label: "Окно питания, ч", Color: ChartColorBlue},
+				},
+			},
+		},
+	}
+
+	var chartSnippets []html.IELement
+	for i, c := range charts {
+		accordion.AddItem(html.HewAccordionItem(
+			fmt.Sprintf("graph%d", i),
+			c.title,
+			html.NewCanvas(c.id),
+		))
+
+		c.data.PlotFunc = fmt.Sprintf("plot%d", i)
+		c.data.ElemID = c.id
+		snippet, err := html.NewChartScript(c.data)
+		if err != nil {
+			r.logger.Error(
+				"fast report command chart error",
+				zap.Int64("userID", userID),
+				zap.Error(err),
+			)
+
+			return NewErrCmdResponse(m.MsgErrInternal)
+		}
+		chartSnippets = append(chartSnippets, snippet)
+	}
+
+	// Doc
+	totalElements := []html.IELement{
+		html.NewH(
+			fmt.Sprintf("Голодание за %s - %s", tsFromStr, tsToStr),
+			5,
+			html.Attrs{"align": "center"},
+		),
+		accordion,
+		r.assets.Script(html.AssetBootstrapJS),
+		r.assets.Script(html.AssetChartJS),
+		html.NewS(GetStartPlotSnippet()),
+	}
+	totalElements = append(totalElements, chartSnippets...)
+	totalElements = append(totalElements, html.NewS(GetEndPlotSnippet()))
+	htmlBuilder.Add(
+		html.NewContainer().Add(totalElements...),
+	)
+
+	// Response
+	return r.reportResponse(userID, htmlBuilder, fmt.Sprintf("fast_%s_%s", tsFromStr, tsToStr), format)
+}
+
+// calcFastDays groups journal records by day. Journal records without
+// time of day are not used. Window is bounded by calendar day, so meal
+// after midnight starts window of next day.
+func calcFastDays(jrnl []storage.JournalReport, tz *time.Location) []fastDay {
+	var days []fastDay
+	for _, j := range jrnl {
+		t := j.Timestamp.ToTime(tz)
+		day := storage.DayStart(t)
+		if t.Equal(day) {
+			continue
+		}
+
+		if len(days) == 0 || !days[len(days)-1].day.Equal(day) {
+			days = append(days, fastDay{day: day, firstMeal: t, lastMeal: t})
+			continue
+		}
+
+		last := &days[len(days)-1]
+		if t.Before(last.firstMeal) {
+			last.firstMeal = t
+		}
+		if t.After(last.lastMeal) {
+			last.lastMeal = t
+		}
+	}
+
+	return days
+}
+
+// calcFastStreaks counts days with window in target. Streak is a sequence
+// of consecutive days in target, day without records breaks it.
+func calcFastStreaks(days []fastDay, targetWindow float64) (inTarget, curStreak, maxStreak int) {
+	for i, d := range days {
+		switch {
+		case d.window() > targetWindow:
+			curStreak = 0
+			continue
+		case i > 0 && days[i-1].day.AddDate(0, 0, 1).Equal(d.day):
+			curStreak++
+		default:
+			curStreak = 1
+		}
+		inTarget++
+		maxStreak = max(maxStreak, curStreak)
+	}
+
+	return inTarget, curStreak, maxStreak
+}
+
+// formatDurationHM formats duration as hours and minutes.
+func formatDurationHM(d time.Duration) string {
+	minutes := int(d.Round(time.Minute).Minutes())
+	return fmt.Sprintf("%dч %02dм", minutes/60, minutes%60)
+}
@@ -0,0 +1,135 @@
+package cmdproc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/devldavydov/myhealth/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalcFastDays(t *testing.T) {
+	at := func(d, h, min int) time.Time { return time.Date(2025, 3, d, h, min, 0, 0, time.UTC) }
+
+	for _, tt := range []struct {
+		name  string
+		meals []time.Time
+		days  []fastDay
+	}{
+		{
+			name:  "one day",
+			meals: []time.Time{at(1, 8, 0), at(1, 13, 0), at(1, 19, 30)},
+			days: []fastDay{
+				{day: at(1, 0, 0), firstMeal: at(1, 8, 0), lastMeal: at(1, 19, 30)},
+			},
+		},
+		{
+			name:  "records without time of day",
+			meals: []time.Time{at(1, 0, 0), at(1, 8, 0), at(2, 0, 0)},
+			days: []fastDay{
+				{day: at(1, 0, 0), firstMeal: at(1, 8, 0), lastMeal: at(1, 8, 0)},
+			},
+		},
+		{
+			name:  "window crosses midnight",
+			meals: []time.Time{at(1, 18, 0), at(1, 23, 30), at(2, 0, 30), at(2, 10, 0)},
+			days: []fastDay{
+				{day: at(1, 0, 0), firstMeal: at(1, 18, 0), lastMeal: at(1, 23, 30)},
+				{day: at(2, 0, 0), firstMeal: at(2, 0, 30), lastMeal: at(2, 10, 0)},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			jrnl := make([]storage.JournalReport, 0, len(tt.meals))
+			for _, meal := range tt.meals {
+				jrnl = append(jrnl, storage.JournalReport{Timestamp: storage.NewTimestamp(meal)})
+			}
+
+			assert.Equal(t, tt.days, calcFastDays(jrnl, time.UTC))
+		})
+	}
+}
+
+func TestCalcFastStreaks(t *testing.T) {
+	// window returns day of March with eating window of hours from 10:00.
+	window := func(d int, hours int) fastDay {
+		day := time.Date(2025, 3, d, 0, 0, 0, 0, time.UTC)
+		return fastDay{
+			day:       day,
+			firstMeal: day.Add(10 * time.Hour),
+			lastMeal:  day.Add(time.Duration(10+hours) * time.Hour),
+		}
+	}
+
+	for _, tt := range []struct {
+		name      string
+		days      []fastDay
+		inTarget  int
+		curStreak int
+		maxStreak int
+	}{
+		{
+			name:     "consecutive days",
+			days:     []fastDay{window(1, 6), window(2, 8), window(3, 4)},
+			inTarget: 3, curStreak: 3, maxStreak: 3,
+		},
+		{
+			name:     "broken by window out of target",
+			days:     []fastDay{window(1, 6), window(2, 6), window(3, 10), window(4, 6)},
+			inTarget: 3, curStreak: 1, maxStreak: 2,
+		},
+		{
+			name:     "broken by day without records",
+			days:     []fastDay{window(1, 6), window(2, 6), window(3, 6), window(5, 6), window(6, 6)},
+			inTarget: 5, curStreak: 2, maxStreak: 3,
+		},
+		{
+			name:     "last day out of target",
+			days:     []fastDay{window(1, 6), window(2, 9)},
+			inTarget: 1, curStreak: 0, maxStreak: 1,
+		},
+		{
+			name: "empty",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			inTarget, curStreak, maxStreak := calcFastStreaks(tt.days, 8)
+			assert.Equal(t, tt.inTarget, inTarget)
+			assert.Equal(t, tt.curStreak, curStreak)
+			assert.Equal(t, tt.maxStreak, maxStreak)
+		})
+	}
+}
+
+func TestFastReportCommand(t *testing.T) {
+	r := newTestCmdProcessor(t, []string{
+		"f,set,a,A,,100,0,0,0",
+		"j,set,28.02.2025 20:00,ужин,a,100",
+		"j,set,01.03.2025 10:00,завтрак,a,100",
+		"j,set,01.03.2025 23:30,ужин,a,100",
+		"j,set,02.03.2025 00:30,ужин,a,100",
+		"j,set,02.03.2025 08:30,завтрак,a,100",
+	})
+
+	resp := r.fastReportCommand(1,
+		time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC),
+		8,
+		storage.ReportFormatHTML)
+	require.Len(t, resp, 1)
+	require.False(t, isErrResponse(resp))
+	f, ok := resp[0].what.(goldenFile)
+	require.True(t, ok)
+
+	for _, row := range []string{
+		// Fasting before first day uses previous day
+		"<td >01.03.2025</td><td >10:00</td><td >23:30</td><td >13.5</td><td >14.0</td><td >-</td>",
+		// Meal after midnight starts window of next day
+		"<td >02.03.2025</td><td >00:30</td><td >08:30</td><td >8.0</td><td >1.0</td><td >+</td>",
+		"<td >Текущая серия дней в цели</td><td >1</td>",
+		"<td >Максимальная серия дней в цели</td><td >1</td>",
+	} {
+		assert.Contains(t, f.buf.String(), row)
+	}
+}
@@ -1,11 +1,11 @@
 package cmdproc
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"math"
+	"strconv"
 	"strings"
 
 	"github.com/devldavydov/myhealth/internal/common/html"
@@ -24,6 +24,7 @@ func (r *CmdProcessor) foodSetCommand(
 	fat100 float64,
 	carb100 float64,
 	comment string,
+	nutrients100 storage.Nutrients,
 ) []CmdResponse {
 	food := &storage.Food{
 		Key:     key,
@@ -40,9 +41,26 @@ func (r *CmdProcessor) foodSetCommand(
 	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
 	defer cancel()
 
+	// Beverage flag, portions and density are set by separate commands,
+	// nutrients are merged with existing ones
+	existing, err := r.getExistingFood(ctx, userID, key)
+	if err != nil {
+		r.logger.Error(
+			"food set command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+	food.Beverage = existing.Beverage
+	food.Portions = existing.Portions
+	food.Density = existing.Density
+	food.Nutrients = existing.Nutrients.Merge(nutrients100)
+
 	if err := r.stg.SetFood(ctx, userID, food); err != nil {
 		if errors.Is(err, storage.ErrFoodInvalid) {
-			return NewSingleCmdResponse(m.MsgErrInvalidCommand)
+			return NewErrCmdResponse(m.MsgErrInvalidCommand)
 		}
 
 		r.logger.Error(
@@ -51,7 +69,7 @@ func (r *CmdProcessor) foodSetCommand(
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
 	return NewSingleCmdResponse(m.MsgOK)
@@ -68,11 +86,16 @@ func (r *CmdProcessor) foodSetWeightCommand(
 	fat float64,
 	carb float64,
 	comment string,
+	nutrients storage.Nutrients,
 ) []CmdResponse {
 	cal100 := math.Round((cal/weight*100)*100) / 100
 	prot100 := math.Round((prot/weight*100)*100) / 100
 	fat100 := math.Round((fat/weight*100)*100) / 100
 	carb100 := math.Round((carb/weight*100)*100) / 100
+	nutrients100 := storage.Nutrients{}
+	for n, v := range nutrients {
+		nutrients100[n] = math.Round((v/weight*100)*100) / 100
+	}
 
 	food := &storage.Food{
 		Key:     key,
@@ -89,9 +112,26 @@ func (r *CmdProcessor) foodSetWeightCommand(
 	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
 	defer cancel()
 
+	// Beverage flag, portions and density are set by separate commands,
+	// nutrients are merged with existing ones
+	existing, err := r.getExistingFood(ctx, userID, key)
+	if err != nil {
+		r.logger.Error(
+			"food set weight command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+	food.Beverage = existing.Beverage
+	food.Portions = existing.Portions
+	food.Density = existing.Density
+	food.Nutrients = existing.Nutrients.Merge(nutrients100)
+
 	if err := r.stg.SetFood(ctx, userID, food); err != nil {
 		if errors.Is(err, storage.ErrFoodInvalid) {
-			return NewSingleCmdResponse(m.MsgErrInvalidCommand)
+			return NewErrCmdResponse(m.MsgErrInvalidCommand)
 		}
 
 		r.logger.Error(
@@ -100,7 +140,7 @@ func (r *CmdProcessor) foodSetWeightCommand(
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
 	return NewSingleCmdResponse(m.MsgOK)
@@ -114,7 +154,7 @@ func (r *CmdProcessor) foodSetTemplateCommand(userID int64, key string) []CmdRes
 	food, err := r.stg.GetFood(ctx, userID, key)
 	if err != nil {
 		if errors.Is(err, storage.ErrFoodNotFound) {
-			return NewSingleCmdResponse(m.MsgErrFoodNotFound)
+			return NewErrCmdResponse(m.MsgErrFoodNotFound)
 		}
 
 		r.logger.Error(
@@ -123,24 +163,129 @@ func (r *CmdProcessor) foodSetTemplateCommand(userID int64, key string) []CmdRes
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
 	foodSetTemplate := fmt.Sprintf(
 		"f,set,%s,%s,%s,%.2f,%.2f,%.2f,%.2f,%s",
-		food.Key,
-		food.Name,
-		food.Brand,
+		quoteArg(food.Key),
+		quoteArg(food.Name),
+		quoteArg(food.Brand),
 		food.Cal100,
 		food.Prot100,
 		food.Fat100,
 		food.Carb100,
-		food.Comment,
+		quoteArg(food.Comment),
 	)
-	return NewSingleCmdResponse(foodSetTemplate, r.typeAdapter.OptsHTML())
+	if len(food.Nutrients) > 0 {
+		foodSetTemplate += "," + food.Nutrients.String()
+	}
+	resp := NewSingleCmdResponse(foodSetTemplate, r.typeAdapter.OptsHTML())
+	if food.Beverage {
+		resp = append(resp, NewCmdResponse(fmt.Sprintf("f,bev,%s,%s", quoteArg(food.Key), formatBool(food.Beverage))))
+	}
+	if len(food.Portions) > 0 || food.Density > 0 {
+		resp = append(resp, NewCmdResponse(fmt.Sprintf(
+			"f,por,%s,%s,%s",
+			quoteArg(food.Key),
+			storage.FormatFoodPortions(food.Portions),
+			strconv.FormatFloat(food.Density, 'f', -1, 64),
+		)))
+	}
+
+	return resp
+}
+
+func (r *CmdProcessor) foodSetBeverageCommand(userID int64, key string, beverage bool) []CmdResponse {
+	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
+	defer cancel()
+
+	food, err := r.stg.GetFood(ctx, userID, key)
+	if err != nil {
+		if errors.Is(err, storage.ErrFoodNotFound) {
+			return NewErrCmdResponse(m.MsgErrFoodNotFound)
+		}
+
+		r.logger.Error(
+			"food set beverage command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	food.Beverage = beverage
+	if err := r.stg.SetFood(ctx, userID, food); err != nil {
+		r.logger.Error(
+			"food set beverage command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	return NewSingleCmdResponse(m.MsgOK)
 }
 
-func (r *CmdProcessor) foodFindCommand(userID int64, pattern string) []CmdResponse {
+func (r *CmdProcessor) foodSetPortionsCommand(
+	userID int64,
+	key string,
+	portions []storage.FoodPortion,
+	density float64,
+) []CmdResponse {
+	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
+	defer cancel()
+
+	food, err := r.stg.GetFood(ctx, userID, key)
+	if err != nil {
+		if errors.Is(err, storage.ErrFoodNotFound) {
+			return NewErrCmdResponse(m.MsgErrFoodNotFound)
+		}
+
+		r.logger.Error(
+			"food set portions command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	food.Portions = portions
+	food.Density = density
+	if err := r.stg.SetFood(ctx, userID, food); err != nil {
+		if errors.Is(err, storage.ErrFoodInvalid) {
+			return NewErrCmdResponse(m.MsgErrInvalidCommand)
+		}
+
+		r.logger.Error(
+			"food set portions command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	return NewSingleCmdResponse(m.MsgOK)
+}
+
+// getExistingFood returns food from DB, empty food for new one.
+func (r *CmdProcessor) getExistingFood(ctx context.Context, userID int64, key string) (*storage.Food, error) {
+	food, err := r.stg.GetFood(ctx, userID, key)
+	if err != nil {
+		if errors.Is(err, storage.ErrFoodNotFound) {
+			return &storage.Food{}, nil
+		}
+		return nil, err
+	}
+
+	return food, nil
+}
+
+func (r *CmdProcessor) foodFindCommand(userID int64, pattern string, format storage.ReportFormat) []CmdResponse {
 	// Get in DB
 	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
 	defer cancel()
@@ -148,7 +293,7 @@ func (r *CmdProcessor) foodFindCommand(userID int64, pattern string) []CmdRespon
 	foodList, err := r.stg.FindFood(ctx, userID, pattern)
 	if err != nil {
 		if errors.Is(err, storage.ErrEmptyResult) {
-			return NewSingleCmdResponse(m.MsgErrEmptyResult)
+			return NewErrCmdResponse(m.MsgErrEmptyResult)
 		}
 
 		r.logger.Error(
@@ -157,13 +302,13 @@ func (r *CmdProcessor) foodFindCommand(userID int64, pattern string) []CmdRespon
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
-	return r.getFoodListPage(foodList)
+	return r.getFoodListPage(userID, foodList, format)
 }
 
-func (r *CmdProcessor) foodCalcCommand(userID int64, key string, foodWeight float64) []CmdResponse {
+func (r *CmdProcessor) foodCalcCommand(userID int64, key string, amount storage.FoodAmount) []CmdResponse {
 	// Get in DB
 	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
 	defer cancel()
@@ -171,7 +316,7 @@ func (r *CmdProcessor) foodCalcCommand(userID int64, key string, foodWeight floa
 	food, err := r.stg.GetFood(ctx, userID, key)
 	if err != nil {
 		if errors.Is(err, storage.ErrFoodNotFound) {
-			return NewSingleCmdResponse(m.MsgErrFoodNotFound)
+			return NewErrCmdResponse(m.MsgErrFoodNotFound)
 		}
 
 		r.logger.Error(
@@ -180,22 +325,36 @@ func (r *CmdProcessor) foodCalcCommand(userID int64, key string, foodWeight floa
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	foodWeight, err := food.Grams(amount)
+	if err != nil {
+		return NewErrCmdResponse(m.MsgErrFoodPortionNotFound)
 	}
 
 	var sb strings.Builder
 	sb.WriteString(fmt.Sprintf("<b>Наименование:</b> %s\n", food.Name))
 	sb.WriteString(fmt.Sprintf("<b>Бренд:</b> %s\n", food.Brand))
-	sb.WriteString(fmt.Sprintf("<b>Вес:</b> %.1f\n", foodWeight))
+	if amount.IsGrams() {
+		sb.WriteString(fmt.Sprintf("<b>Вес:</b> %.1f\n", foodWeight))
+	} else {
+		sb.WriteString(fmt.Sprintf("<b>Вес:</b> %.1f (%s)\n", foodWeight, amount))
+	}
 	sb.WriteString(fmt.Sprintf("<b>ККал:</b> %.2f\n", foodWeight/100*food.Cal100))
 	sb.WriteString(fmt.Sprintf("<b>Бел:</b> %.2f\n", foodWeight/100*food.Prot100))
 	sb.WriteString(fmt.Sprintf("<b>Жир:</b> %.2f\n", foodWeight/100*food.Fat100))
 	sb.WriteString(fmt.Sprintf("<b>Угл:</b> %.2f\n", foodWeight/100*food.Carb100))
+	for _, n := range storage.AllNutrients {
+		if v, ok := food.Nutrients[n]; ok {
+			sb.WriteString(fmt.Sprintf("<b>%s:</b> %.2f\n", n.MustToString(), foodWeight/100*v))
+		}
+	}
 
 	return NewSingleCmdResponse(sb.String(), r.typeAdapter.OptsHTML())
 }
 
-func (r *CmdProcessor) foodListCommand(userID int64) []CmdResponse {
+func (r *CmdProcessor) foodListCommand(userID int64, format storage.ReportFormat) []CmdResponse {
 	// Get from DB
 	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
 	defer cancel()
@@ -203,7 +362,7 @@ func (r *CmdProcessor) foodListCommand(userID int64) []CmdResponse {
 	foodList, err := r.stg.GetFoodList(ctx, userID)
 	if err != nil {
 		if errors.Is(err, storage.ErrEmptyResult) {
-			return NewSingleCmdResponse(m.MsgErrEmptyResult)
+			return NewErrCmdResponse(m.MsgErrEmptyResult)
 		}
 
 		r.logger.Error(
@@ -212,10 +371,10 @@ func (r *CmdProcessor) foodListCommand(userID int64) []CmdResponse {
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
-	return r.getFoodListPage(foodList)
+	return r.getFoodListPage(userID, foodList, format)
 }
 
 func (r *CmdProcessor) foodDelCommand(userID int64, key string) []CmdResponse {
@@ -225,7 +384,7 @@ func (r *CmdProcessor) foodDelCommand(userID int64, key string) []CmdResponse {
 
 	if err := r.stg.DeleteFood(ctx, userID, key); err != nil {
 		if errors.Is(err, storage.ErrFoodIsUsed) {
-			return NewSingleCmdResponse(m.MsgErrFoodIsUsed)
+			return NewErrCmdResponse(m.MsgErrFoodIsUsed)
 		}
 
 		r.logger.Error(
@@ -234,20 +393,20 @@ func (r *CmdProcessor) foodDelCommand(userID int64, key string) []CmdResponse {
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
 	return NewSingleCmdResponse(m.MsgOK)
 }
 
-func (r *CmdProcessor) getFoodListPage(foodList []storage.Food) []CmdResponse {
+func (r *CmdProcessor) getFoodListPage(userID int64, foodList []storage.Food, format storage.ReportFormat) []CmdResponse {
 	// Build html
-	htmlBuilder := html.NewBuilder("Список продуктов")
+	htmlBuilder := html.NewBuilder("Список продуктов", r.assets)
 
 	// Table
 	tbl := html.NewTable([]string{
 		"Ключ", "Наименование", "Бренд", "ККал в 100г.", "Белки в 100г.",
-		"Жиры в 100г.", "Углеводы в 100г.", "Комментарий",
+		"Жиры в 100г.", "Углеводы в 100г.", "Нутриенты в 100г.", "Комментарий",
 	})
 
 	for _, item := range foodList {
@@ -260,6 +419,7 @@ func (r *CmdProcessor) getFoodListPage(foodList []storage.Food) []CmdResponse {
 			AddTd(html.NewTd(html.NewS(fmt.Sprintf("%.2f", item.Prot100)), nil)).
 			AddTd(html.NewTd(html.NewS(fmt.Sprintf("%.2f", item.Fat100)), nil)).
 			AddTd(html.NewTd(html.NewS(fmt.Sprintf("%.2f", item.Carb100)), nil)).
+			AddTd(html.NewTd(html.NewS(formatNutrients(item.Nutrients)), nil)).
 			AddTd(html.NewTd(html.NewS(item.Comment), nil))
 		tbl.AddRow(tr)
 	}
@@ -275,9 +435,17 @@ func (r *CmdProcessor) getFoodListPage(foodList []storage.Food) []CmdResponse {
 			tbl))
 
 	// Response
-	return NewSingleCmdResponse(r.typeAdapter.File(
-		bytes.NewBufferString(htmlBuilder.Build()),
-		"text/html",
-		"food.html",
-	))
+	return r.reportResponse(userID, htmlBuilder, "food", format)
+}
+
+// formatNutrients formats nutrients in display order, i.e.
+// "Клетчатка: 2.00г, Сахар: 10.00г".
+func formatNutrients(nutrients storage.Nutrients) string {
+	parts := []string{}
+	for _, n := range storage.AllNutrients {
+		if v, ok := nutrients[n]; ok {
+			parts = append(parts, fmt.Sprintf("%s: %.2fг", n.MustToString(), v))
+		}
+	}
+	return strings.Join(parts, ", ")
 }
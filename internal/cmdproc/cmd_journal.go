@@ -1,11 +1,11 @@
 package cmdproc
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"math"
+	"slices"
 	"strings"
 	"time"
 
@@ -15,29 +15,58 @@ import (
 	"go.uber.org/zap"
 )
 
+const _journalSuggestionsLimit = 10
+
 func (r *CmdProcessor) journalSetCommand(
 	userID int64,
 	ts time.Time,
 	meal storage.Meal,
 	foodKey string,
-	foodWeight float64,
+	amount storage.FoodAmount,
 ) []CmdResponse {
 	// Save in DB
 	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
 	defer cancel()
 
+	// Resolve amount to grams
+	food, err := r.stg.GetFood(ctx, userID, foodKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrFoodNotFound) {
+			return NewErrCmdResponse(m.MsgErrFoodNotFound)
+		}
+
+		r.logger.Error(
+			"journal set command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	foodWeight, err := food.Grams(amount)
+	if err != nil {
+		return NewErrCmdResponse(m.MsgErrFoodPortionNotFound)
+	}
+
+	var portion string
+	if !amount.IsGrams() {
+		portion = amount.String()
+	}
+
 	if err := r.stg.SetJournal(ctx, userID, &storage.Journal{
 		Timestamp:  storage.NewTimestamp(ts),
 		Meal:       meal,
 		FoodKey:    foodKey,
 		FoodWeight: foodWeight,
+		Portion:    portion,
 	}); err != nil {
 		if errors.Is(err, storage.ErrJournalInvalid) {
-			return NewSingleCmdResponse(m.MsgErrInvalidCommand)
+			return NewErrCmdResponse(m.MsgErrInvalidCommand)
 		}
 
 		if errors.Is(err, storage.ErrFoodNotFound) {
-			return NewSingleCmdResponse(m.MsgErrFoodNotFound)
+			return NewErrCmdResponse(m.MsgErrFoodNotFound)
 		}
 
 		r.logger.Error(
@@ -46,7 +75,7 @@ func (r *CmdProcessor) journalSetCommand(
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
 	return NewSingleCmdResponse(m.MsgOK)
@@ -64,11 +93,11 @@ func (r *CmdProcessor) journalSetBundleCommand(
 
 	if err := r.stg.SetJournalBundle(ctx, userID, storage.NewTimestamp(ts), meal, bndlKey); err != nil {
 		if errors.Is(err, storage.ErrFoodNotFound) {
-			return NewSingleCmdResponse(m.MsgErrFoodNotFound)
+			return NewErrCmdResponse(m.MsgErrFoodNotFound)
 		}
 
 		if errors.Is(err, storage.ErrBundleNotFound) {
-			return NewSingleCmdResponse(m.MsgErrBundleNotFound)
+			return NewErrCmdResponse(m.MsgErrBundleNotFound)
 		}
 
 		r.logger.Error(
@@ -77,7 +106,7 @@ func (r *CmdProcessor) journalSetBundleCommand(
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
 	return NewSingleCmdResponse(m.MsgOK)
@@ -93,14 +122,19 @@ func (r *CmdProcessor) journalDelCommand(
 	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
 	defer cancel()
 
-	if err := r.stg.DeleteJournal(ctx, userID, storage.NewTimestamp(ts), meal, foodKey); err != nil {
+	if err := r.stg.DeleteJournal(ctx, userID,
+		storage.NewTimestamp(storage.DayStart(ts)),
+		storage.NewTimestamp(storage.DayEnd(ts)),
+		meal,
+		foodKey,
+	); err != nil {
 		r.logger.Error(
 			"journal del command DB error",
 			zap.Int64("userID", userID),
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
 	return NewSingleCmdResponse(m.MsgOK)
@@ -111,14 +145,18 @@ func (r *CmdProcessor) journalDelMealCommand(userID int64, ts time.Time, meal st
 	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
 	defer cancel()
 
-	if err := r.stg.DeleteJournalMeal(ctx, userID, storage.NewTimestamp(ts), meal); err != nil {
+	if err := r.stg.DeleteJournalMeal(ctx, userID,
+		storage.NewTimestamp(storage.DayStart(ts)),
+		storage.NewTimestamp(storage.DayEnd(ts)),
+		meal,
+	); err != nil {
 		r.logger.Error(
 			"journal del meal command DB error",
 			zap.Int64("userID", userID),
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
 	return NewSingleCmdResponse(m.MsgOK)
@@ -134,13 +172,18 @@ func (r *CmdProcessor) journalDelBundleCommand(
 	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
 	defer cancel()
 
-	if err := r.stg.DelJournalBundle(ctx, userID, storage.NewTimestamp(ts), meal, bndlKey); err != nil {
+	if err := r.stg.DelJournalBundle(ctx, userID,
+		storage.NewTimestamp(storage.DayStart(ts)),
+		storage.NewTimestamp(storage.DayEnd(ts)),
+		meal,
+		bndlKey,
+	); err != nil {
 		if errors.Is(err, storage.ErrFoodNotFound) {
-			return NewSingleCmdResponse(m.MsgErrFoodNotFound)
+			return NewErrCmdResponse(m.MsgErrFoodNotFound)
 		}
 
 		if errors.Is(err, storage.ErrBundleNotFound) {
-			return NewSingleCmdResponse(m.MsgErrBundleNotFound)
+			return NewErrCmdResponse(m.MsgErrBundleNotFound)
 		}
 
 		r.logger.Error(
@@ -149,7 +192,7 @@ func (r *CmdProcessor) journalDelBundleCommand(
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
 	return NewSingleCmdResponse(m.MsgOK)
@@ -166,11 +209,13 @@ func (r *CmdProcessor) journalCopyCommand(
 	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
 	defer cancel()
 
+	// Records of day are copied with their time of day
 	cnt, err := r.stg.CopyJournal(ctx,
 		userID,
-		storage.NewTimestamp(tsFrom),
+		storage.NewTimestamp(storage.DayStart(tsFrom)),
+		storage.NewTimestamp(storage.DayEnd(tsFrom)),
 		mealFrom,
-		storage.NewTimestamp(tsTo),
+		storage.NewTimestamp(storage.DayStart(tsTo)),
 		mealTo)
 	if err != nil {
 		r.logger.Error(
@@ -179,13 +224,13 @@ func (r *CmdProcessor) journalCopyCommand(
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
 	return NewSingleCmdResponse(fmt.Sprintf("Скопировано записей: %d", cnt))
 }
 
-func (r *CmdProcessor) journalReportDayCommand(userID int64, ts time.Time) []CmdResponse {
+func (r *CmdProcessor) journalReportDayCommand(userID int64, ts time.Time, format storage.ReportFormat) []CmdResponse {
 	tsStr := formatTimestamp(ts)
 
 	// Get list from DB, user settings
@@ -202,32 +247,25 @@ func (r *CmdProcessor) journalReportDayCommand(userID int64, ts time.Time) []Cmd
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
-	burnedCal, err := r.stg.GetTotalBurnedCal(ctx, userID, storage.NewTimestamp(ts))
-	if err != nil && !errors.Is(err, storage.ErrTotalBurnedCalNotFound) {
+	totalBurnedCal, err := r.getDayBurnedCal(ctx, userID, us, ts)
+	if err != nil {
 		r.logger.Error(
 			"total burned cal get command DB error",
 			zap.Int64("userID", userID),
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
-	}
-
-	var totalBurnedCal float64
-	if burnedCal != 0 {
-		totalBurnedCal = burnedCal
-	} else if us != nil {
-		totalBurnedCal = us.CalLimit
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
 	// Generate report
-	lst, err := r.stg.GetJournalReport(ctx, userID, storage.NewTimestamp(ts), storage.NewTimestamp(ts))
+	lst, err := r.stg.GetJournalReport(ctx, userID, storage.NewTimestamp(storage.DayStart(ts)), storage.NewTimestamp(storage.DayEnd(ts)))
 	if err != nil {
 		if errors.Is(err, storage.ErrEmptyResult) {
-			return NewSingleCmdResponse(m.MsgErrEmptyResult)
+			return NewErrCmdResponse(m.MsgErrEmptyResult)
 		}
 
 		r.logger.Error(
@@ -236,17 +274,32 @@ func (r *CmdProcessor) journalReportDayCommand(userID int64, ts time.Time) []Cmd
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
+	totalWater, err := r.getDayWater(ctx, userID, ts, lst)
+	if err != nil {
+		r.logger.Error(
+			"water get command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	// Keep meals grouped, when records have time of day
+	slices.SortStableFunc(lst, func(a, b storage.JournalReport) int { return int(a.Meal - b.Meal) })
+
 	// Report table
-	htmlBuilder := html.NewBuilder("Журнал приема пищи")
+	htmlBuilder := html.NewBuilder("Журнал приема пищи", r.assets)
 	tbl := html.NewTable([]string{
 		"Наименование", "Вес", "ККал", "Белки", "Жиры", "Углеводы",
 	})
 
 	var totalCal, totalProt, totalFat, totalCarb float64
 	var subTotalCal, subTotalProt, subTotalFat, subTotalCarb float64
+	totalNutrients := storage.Nutrients{}
 	lastMeal := storage.Meal(-1)
 	for i := 0; i < len(lst); i++ {
 		j := lst[i]
@@ -269,11 +322,19 @@ func (r *CmdProcessor) journalReportDayCommand(userID int64, ts time.Time) []Cmd
 			foodLbl = fmt.Sprintf("%s - %s", foodLbl, j.FoodBrand)
 		}
 		foodLbl = fmt.Sprintf("%s [%s]", foodLbl, j.FoodKey)
+		if t := j.Timestamp.ToTime(r.tz); !t.Equal(storage.DayStart(t)) {
+			foodLbl = fmt.Sprintf("%s %s", t.Format("15:04"), foodLbl)
+		}
+
+		weightLbl := fmt.Sprintf("%.1f", j.FoodWeight)
+		if j.Portion != "" {
+			weightLbl = fmt.Sprintf("%s (%s)", weightLbl, j.Portion)
+		}
 
 		tbl.AddRow(
 			html.NewTr(nil).
 				AddTd(html.NewTd(html.NewS(foodLbl), nil)).
-				AddTd(html.NewTd(html.NewS(fmt.Sprintf("%.1f", j.FoodWeight)), nil)).
+				AddTd(html.NewTd(html.NewS(weightLbl), nil)).
 				AddTd(html.NewTd(html.NewS(fmt.Sprintf("%.2f", j.Cal)), nil)).
 				AddTd(html.NewTd(html.NewS(fmt.Sprintf("%.2f", j.Prot)), nil)).
 				AddTd(html.NewTd(html.NewS(fmt.Sprintf("%.2f", j.Fat)), nil)).
@@ -283,6 +344,7 @@ func (r *CmdProcessor) journalReportDayCommand(userID int64, ts time.Time) []Cmd
 		totalProt += j.Prot
 		totalFat += j.Fat
 		totalCarb += j.Carb
+		totalNutrients.Add(j.Nutrients)
 
 		subTotalCal += j.Cal
 		subTotalProt += j.Prot
@@ -360,6 +422,37 @@ func (r *CmdProcessor) journalReportDayCommand(userID int64, ts time.Time) []Cmd
 					),
 					html.Attrs{"colspan": "6"})))
 
+	// Nutrients with limits
+	var nutrientLimits storage.Nutrients
+	if us != nil {
+		nutrientLimits = us.NutrientLimits
+	}
+	for _, n := range storage.AllNutrients {
+		total, limit := totalNutrients[n], nutrientLimits[n]
+		if total == 0 && limit == 0 {
+			continue
+		}
+
+		tbl.
+			AddFooterElement(
+				html.NewTr(nil).
+					AddTd(html.NewTd(
+						html.NewSpan(
+							html.NewB(fmt.Sprintf("%s, г: ", n.MustToString()), nil),
+							nutrientSnippet(total, limit),
+						),
+						html.Attrs{"colspan": "6"})))
+	}
+
+	if waterGoal := getUserWaterGoal(us); totalWater != 0 || waterGoal != 0 {
+		tbl.
+			AddFooterElement(
+				html.NewTr(nil).
+					AddTd(html.NewTd(
+						html.NewB(formatWaterProgress(totalWater, waterGoal), nil),
+						html.Attrs{"colspan": "6"})))
+	}
+
 	// Doc
 	htmlBuilder.Add(
 		html.NewContainer().Add(
@@ -373,11 +466,7 @@ func (r *CmdProcessor) journalReportDayCommand(userID int64, ts time.Time) []Cmd
 	)
 
 	// Response
-	return NewSingleCmdResponse(r.typeAdapter.File(
-		bytes.NewBufferString(htmlBuilder.Build()),
-		"text/html",
-		fmt.Sprintf("report_%s.html", tsStr),
-	))
+	return r.reportResponse(userID, htmlBuilder, fmt.Sprintf("report_%s", tsStr), format)
 }
 
 func (r *CmdProcessor) journalReportDayCalloriesCommand(userID int64, ts time.Time) []CmdResponse {
@@ -395,32 +484,25 @@ func (r *CmdProcessor) journalReportDayCalloriesCommand(userID int64, ts time.Ti
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
-	burnedCal, err := r.stg.GetTotalBurnedCal(ctx, userID, storage.NewTimestamp(ts))
-	if err != nil && !errors.Is(err, storage.ErrTotalBurnedCalNotFound) {
+	totalBurnedCal, err := r.getDayBurnedCal(ctx, userID, us, ts)
+	if err != nil {
 		r.logger.Error(
 			"total burned cal get command DB error",
 			zap.Int64("userID", userID),
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
-	}
-
-	var totalBurnedCal float64
-	if burnedCal != 0 {
-		totalBurnedCal = burnedCal
-	} else if us != nil {
-		totalBurnedCal = us.CalLimit
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
 	// Generate report
-	lst, err := r.stg.GetJournalReport(ctx, userID, storage.NewTimestamp(ts), storage.NewTimestamp(ts))
+	lst, err := r.stg.GetJournalReport(ctx, userID, storage.NewTimestamp(storage.DayStart(ts)), storage.NewTimestamp(storage.DayEnd(ts)))
 	if err != nil {
 		if errors.Is(err, storage.ErrEmptyResult) {
-			return NewSingleCmdResponse(m.MsgErrEmptyResult)
+			return NewErrCmdResponse(m.MsgErrEmptyResult)
 		}
 
 		r.logger.Error(
@@ -429,7 +511,18 @@ func (r *CmdProcessor) journalReportDayCalloriesCommand(userID int64, ts time.Ti
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	totalWater, err := r.getDayWater(ctx, userID, ts, lst)
+	if err != nil {
+		r.logger.Error(
+			"water get command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
 	var sb strings.Builder
@@ -458,6 +551,9 @@ func (r *CmdProcessor) journalReportDayCalloriesCommand(userID int64, ts time.Ti
 		sb.WriteString(fmt.Sprintf("Потрачено, ккал: %.2f\n", totalBurnedCal))
 		sb.WriteString(fmt.Sprintf("Разница, ккал: <b>%+.2f</b>\n", totalBurnedCal-totalCal))
 	}
+	if waterGoal := getUserWaterGoal(us); totalWater != 0 || waterGoal != 0 {
+		sb.WriteString(formatWaterProgress(totalWater, waterGoal) + "\n")
+	}
 
 	return NewSingleCmdResponse(sb.String(), r.typeAdapter.OptsHTML())
 }
@@ -467,10 +563,10 @@ func (r *CmdProcessor) journalTemplateMealCommand(userID int64, ts time.Time, me
 	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
 	defer cancel()
 
-	rep, err := r.stg.GetJournalReport(ctx, userID, storage.NewTimestamp(ts), storage.NewTimestamp(ts))
+	rep, err := r.stg.GetJournalReport(ctx, userID, storage.NewTimestamp(storage.DayStart(ts)), storage.NewTimestamp(storage.DayEnd(ts)))
 	if err != nil {
 		if errors.Is(err, storage.ErrEmptyResult) {
-			return NewSingleCmdResponse(m.MsgErrEmptyResult)
+			return NewErrCmdResponse(m.MsgErrEmptyResult)
 		}
 
 		r.logger.Error(
@@ -479,10 +575,9 @@ func (r *CmdProcessor) journalTemplateMealCommand(userID int64, ts time.Time, me
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
-	tsStr := formatTimestamp(ts)
 	resp := make([]CmdResponse, 0)
 
 	resp = append(resp, NewCmdResponse("<b>Изменение еды</b>", r.typeAdapter.OptsHTML()))
@@ -491,8 +586,13 @@ func (r *CmdProcessor) journalTemplateMealCommand(userID int64, ts time.Time, me
 			continue
 		}
 
+		amount := fmt.Sprintf("%.1f", item.FoodWeight)
+		if item.Portion != "" {
+			amount = item.Portion
+		}
+
 		resp = append(resp, NewCmdResponse(
-			fmt.Sprintf("j,set,%s,%s,%s,%.1f", tsStr, item.Meal.MustToString(), item.FoodKey, item.FoodWeight),
+			fmt.Sprintf("j,set,%s,%s,%s,%s", formatDateTime(item.Timestamp.ToTime(r.tz)), item.Meal.MustToString(), quoteArg(item.FoodKey), amount),
 		))
 	}
 	resp = append(resp, NewCmdResponse("<b>Удаление еды</b>", r.typeAdapter.OptsHTML()))
@@ -502,13 +602,78 @@ func (r *CmdProcessor) journalTemplateMealCommand(userID int64, ts time.Time, me
 		}
 
 		resp = append(resp, NewCmdResponse(
-			fmt.Sprintf("j,del,%s,%s,%s", tsStr, item.Meal.MustToString(), item.FoodKey),
+			fmt.Sprintf("j,del,%s,%s,%s", formatDateTime(item.Timestamp.ToTime(r.tz)), item.Meal.MustToString(), quoteArg(item.FoodKey)),
 		))
 	}
 
 	return resp
 }
 
+func (r *CmdProcessor) journalSuggestMealCommand(userID int64, ts time.Time, meal storage.Meal, days int) []CmdResponse {
+	// Call DB
+	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
+	defer cancel()
+
+	tsFrom := ts.AddDate(0, 0, -days)
+	lst, err := r.stg.GetJournalMealSuggestions(ctx,
+		userID,
+		meal,
+		storage.NewTimestamp(storage.DayStart(tsFrom)),
+		storage.NewTimestamp(storage.DayEnd(ts)),
+		_journalSuggestionsLimit,
+	)
+	if err != nil {
+		if errors.Is(err, storage.ErrEmptyResult) {
+			return NewErrCmdResponse(m.MsgErrEmptyResult)
+		}
+
+		r.logger.Error(
+			"journal suggest meal command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	tsStr := formatTimestamp(ts)
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("<b>Рекомендации: %s</b>\n", meal.MustToString()))
+
+	cmds := make([]CmdResponse, 0, len(lst))
+	for i, item := range lst {
+		foodStat, err := r.stg.GetJournalFoodStat(ctx, userID, item.FoodKey)
+		if err != nil {
+			r.logger.Error(
+				"journal suggest meal command DB error",
+				zap.Int64("userID", userID),
+				zap.Error(err),
+			)
+
+			return NewErrCmdResponse(m.MsgErrInternal)
+		}
+
+		foodLbl := item.FoodName
+		if item.FoodBrand != "" {
+			foodLbl = fmt.Sprintf("%s - %s", foodLbl, item.FoodBrand)
+		}
+		sb.WriteString(fmt.Sprintf(
+			"%d. %s [%s], обычно %.1fг., раз: %d\n",
+			i+1,
+			foodLbl,
+			item.FoodKey,
+			foodStat.AvgWeight,
+			item.Count,
+		))
+
+		cmds = append(cmds, NewCmdResponse(
+			fmt.Sprintf("j,set,%s,%s,%s,%.1f", tsStr, meal.MustToString(), quoteArg(item.FoodKey), foodStat.AvgWeight),
+		))
+	}
+
+	return append([]CmdResponse{NewCmdResponse(sb.String(), r.typeAdapter.OptsHTML())}, cmds...)
+}
+
 func (r *CmdProcessor) journalFoodStatCommand(userID int64, foodKey string) []CmdResponse {
 	// Call DB
 	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
@@ -517,7 +682,7 @@ func (r *CmdProcessor) journalFoodStatCommand(userID int64, foodKey string) []Cm
 	food, err := r.stg.GetFood(ctx, userID, foodKey)
 	if err != nil {
 		if errors.Is(err, storage.ErrFoodNotFound) {
-			return NewSingleCmdResponse(m.MsgErrFoodNotFound)
+			return NewErrCmdResponse(m.MsgErrFoodNotFound)
 		}
 
 		r.logger.Error(
@@ -525,7 +690,7 @@ func (r *CmdProcessor) journalFoodStatCommand(userID int64, foodKey string) []Cm
 			zap.Int64("userID", userID),
 			zap.Error(err),
 		)
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
 	foodStat, err := r.stg.GetJournalFoodStat(ctx,
@@ -534,7 +699,7 @@ func (r *CmdProcessor) journalFoodStatCommand(userID int64, foodKey string) []Cm
 	)
 	if err != nil {
 		if errors.Is(err, storage.ErrEmptyResult) {
-			return NewSingleCmdResponse(m.MsgErrEmptyResult)
+			return NewErrCmdResponse(m.MsgErrEmptyResult)
 		}
 
 		r.logger.Error(
@@ -542,7 +707,7 @@ func (r *CmdProcessor) journalFoodStatCommand(userID int64, foodKey string) []Cm
 			zap.Int64("userID", userID),
 			zap.Error(err),
 		)
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
 	var sb strings.Builder
@@ -565,9 +730,9 @@ func (r *CmdProcessor) journalSetDayTotalCal(userID int64, ts time.Time, totalCa
 	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
 	defer cancel()
 
-	if err := r.stg.SetTotalBurnedCal(ctx, userID, storage.NewTimestamp(ts), totalCal); err != nil {
+	if err := r.stg.SetTotalBurnedCal(ctx, userID, storage.NewTimestamp(storage.DayStart(ts)), totalCal); err != nil {
 		if errors.Is(err, storage.ErrDayTotalCalInvalid) {
-			return NewSingleCmdResponse(m.MsgErrInvalidCommand)
+			return NewErrCmdResponse(m.MsgErrInvalidCommand)
 		}
 
 		r.logger.Error(
@@ -576,7 +741,7 @@ func (r *CmdProcessor) journalSetDayTotalCal(userID int64, ts time.Time, totalCa
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
 	return NewSingleCmdResponse(m.MsgOK)
@@ -587,14 +752,14 @@ func (r *CmdProcessor) journalDeleteDayTotalCal(userID int64, ts time.Time) []Cm
 	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
 	defer cancel()
 
-	if err := r.stg.DeleteTotalBurnedCal(ctx, userID, storage.NewTimestamp(ts)); err != nil {
+	if err := r.stg.DeleteTotalBurnedCal(ctx, userID, storage.NewTimestamp(storage.DayStart(ts))); err != nil {
 		r.logger.Error(
 			"journal del total burned cal command DB error",
 			zap.Int64("userID", userID),
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
 	return NewSingleCmdResponse(m.MsgOK)
@@ -612,6 +777,19 @@ func pfcSnippet(val, totalVal float64) html.IELement {
 	return html.NewS(s)
 }
 
+// nutrientSnippet shows nutrient total with daily limit, exceeded
+// limit is highlighted.
+func nutrientSnippet(total, limit float64) html.IELement {
+	switch {
+	case limit == 0:
+		return html.NewS(fmt.Sprintf("%.2f", total))
+	case total > limit:
+		return html.NewB(fmt.Sprintf("%.2f / %.2f", total, limit), html.Attrs{"class": "text-danger"})
+	default:
+		return html.NewS(fmt.Sprintf("%.2f / %.2f", total, limit))
+	}
+}
+
 func calDiffSnippet(diff float64) html.IELement {
 	switch {
 	case diff < 0 && math.Abs(diff) > 0.01:
@@ -626,3 +804,48 @@ func calDiffSnippet(diff float64) html.IELement {
 		return html.NewS(fmt.Sprintf("%.2f", diff))
 	}
 }
+
+// getDayBurnedCal returns burned calories for day: explicitly set total,
+// or BMR from user profile with sedentary activity factor plus sport
+// activity calories, or user calorie limit as the last resort.
+func (r *CmdProcessor) getDayBurnedCal(
+	ctx context.Context,
+	userID int64,
+	us *storage.UserSettings,
+	ts time.Time,
+) (float64, error) {
+	burnedCal, err := r.stg.GetTotalBurnedCal(ctx, userID, storage.NewTimestamp(storage.DayStart(ts)))
+	if err != nil && !errors.Is(err, storage.ErrTotalBurnedCalNotFound) {
+		return 0, err
+	}
+
+	if burnedCal != 0 {
+		return burnedCal, nil
+	}
+
+	if us == nil {
+		return 0, nil
+	}
+
+	if us.Profile != nil {
+		w, err := r.stg.GetLastWeight(ctx, userID, storage.NewTimestamp(storage.DayEnd(ts)))
+		if err != nil && !errors.Is(err, storage.ErrWeightNotFound) {
+			return 0, err
+		}
+
+		if w != nil {
+			actCal, err := r.stg.GetSportActivityCal(ctx, userID,
+				storage.NewTimestamp(storage.DayStart(ts)),
+				storage.NewTimestamp(storage.DayEnd(ts)))
+			if err != nil {
+				return 0, err
+			}
+
+			age := float64(us.Profile.Age(r.tz, ts))
+			bmr := calcProfileBMR(us.Profile, w.Value, age)
+			return bmr*storage.ActivityLevel(1).Factor() + actCal, nil
+		}
+	}
+
+	return us.CalLimit, nil
+}
@@ -0,0 +1,357 @@
+package cmdproc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/devldavydov/myhealth/internal/common/html"
+	m "github.com/devldavydov/myhealth/internal/common/messages"
+	"github.com/devldavydov/myhealth/internal/storage"
+	"go.uber.org/zap"
+)
+
+const (
+	_calPerKg         = 7700
+	_weightAvgWindow  = 7
+	_trendWeekDaysCnt = 7
+	// Burned calories are queried per day, so period is limited.
+	_trendMaxDays = 366
+)
+
+type trendDay struct {
+	ts         storage.Timestamp
+	intake     float64
+	hasIntake  bool
+	burned     float64
+	weight     float64
+	hasWeight  bool
+	weightAvg  float64
+	cumDeficit float64
+}
+
+func (r *CmdProcessor) journalTrendReportCommand(userID int64, tsFrom, tsTo time.Time, format storage.ReportFormat) []CmdResponse {
+	if tsTo.Before(tsFrom) {
+		return NewErrCmdResponse(m.MsgErrInvalidCommand)
+	}
+
+	if daysCnt := periodDays(tsFrom, tsTo); daysCnt > _trendMaxDays {
+		return NewErrCmdResponse(fmt.Sprintf("%s: %d > %d", m.MsgErrPeriodTooLarge, daysCnt, _trendMaxDays))
+	}
+
+	// Call DB
+	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
+	defer cancel()
+
+	us, err := r.stg.GetUserSettings(ctx, userID)
+	if err != nil && !errors.Is(err, storage.ErrUserSettingsNotFound) {
+		r.logger.Error(
+			"journal trend report command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	jrnl, err := r.stg.GetJournalReport(ctx, userID, storage.NewTimestamp(storage.DayStart(tsFrom)), storage.NewTimestamp(storage.DayEnd(tsTo)))
+	if err != nil && !errors.Is(err, storage.ErrEmptyResult) {
+		r.logger.Error(
+			"journal trend report command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	weights, err := r.stg.GetWeightList(ctx, userID, storage.NewTimestamp(storage.DayStart(tsFrom)), storage.NewTimestamp(storage.DayEnd(tsTo)), false)
+	if err != nil && !errors.Is(err, storage.ErrEmptyResult) {
+		r.logger.Error(
+			"journal trend report command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	if len(jrnl) == 0 && len(weights) == 0 {
+		return NewErrCmdResponse(m.MsgErrEmptyResult)
+	}
+
+	// Collect days
+	intakeData := make(map[storage.Timestamp]float64)
+	for _, j := range jrnl {
+		intakeData[dayKey(j.Timestamp, r.tz)] += j.Cal
+	}
+
+	weightData := make(map[storage.Timestamp]float64, len(weights))
+	for _, w := range weights {
+		weightData[dayKey(w.Timestamp, r.tz)] = w.Value
+	}
+
+	days := []*trendDay{}
+	for t := storage.DayStart(tsFrom); !t.After(tsTo); t = t.AddDate(0, 0, 1) {
+		ts := storage.NewTimestamp(t)
+		d := &trendDay{ts: ts}
+		d.intake, d.hasIntake = intakeData[ts]
+		d.weight, d.hasWeight = weightData[ts]
+
+		d.burned, err = r.getDayBurnedCal(ctx, userID, us, t)
+		if err != nil {
+			r.logger.Error(
+				"journal trend report command DB error",
+				zap.Int64("userID", userID),
+				zap.Error(err),
+			)
+
+			return NewErrCmdResponse(m.MsgErrInternal)
+		}
+
+		days = append(days, d)
+	}
+
+	calcTrendDays(days)
+
+	// Build html
+	tsFromStr, tsToStr := formatTimestamp(tsFrom), formatTimestamp(tsTo)
+	htmlBuilder := html.NewBuilder("Тренды питания и веса", r.assets)
+	accordion := html.NewAccordion("accordionTrend")
+
+	// Summary
+	accordion.AddItem(html.HewAccordionItem(
+		"summary",
+		"Итоги",
+		trendSummaryTable(days),
+	))
+
+	// Weekly table
+	tblWeek := html.NewTable([]string{
+		"Неделя", "Ср. потреблено, ккал", "Ср. потрачено, ккал", "Ср. разница, ккал", "Ср. вес",
+	})
+	for i := 0; i < len(days); i += _trendWeekDaysCnt {
+		week := days[i:min(i+_trendWeekDaysCnt, len(days))]
+
+		var intake, burned, weight float64
+		var intakeCnt, weightCnt int
+		for _, d := range week {
+			if d.hasIntake {
+				intake += d.intake
+				burned += d.burned
+				intakeCnt++
+			}
+			if d.hasWeight {
+				weight += d.weight
+				weightCnt++
+			}
+		}
+
+		tr := html.NewTr(nil).
+			AddTd(html.NewTd(html.NewS(fmt.Sprintf(
+				"%s - %s",
+				formatTimestamp(week[0].ts.ToTime(r.tz)),
+				formatTimestamp(week[len(week)-1].ts.ToTime(r.tz)),
+			)), nil))
+
+		if intakeCnt > 0 {
+			tr.
+				AddTd(html.NewTd(html.NewS(fmt.Sprintf("%.2f", intake/float64(intakeCnt))), nil)).
+				AddTd(html.NewTd(html.NewS(fmt.Sprintf("%.2f", burned/float64(intakeCnt))), nil)).
+				AddTd(html.NewTd(calDiffSnippet((burned-intake)/float64(intakeCnt)), nil))
+		} else {
+			tr.
+				AddTd(html.NewTd(html.NewS("-"), nil)).
+				AddTd(html.NewTd(html.NewS("-"), nil)).
+				AddTd(html.NewTd(html.NewS("-"), nil))
+		}
+
+		if weightCnt > 0 {
+			tr.AddTd(html.NewTd(html.NewS(fmt.Sprintf("%.1f", weight/float64(weightCnt))), nil))
+		} else {
+			tr.AddTd(html.NewTd(html.NewS("-"), nil))
+		}
+
+		tblWeek.AddRow(tr)
+	}
+	accordion.AddItem(html.HewAccordionItem(
+		"tblWeek",
+		"Таблица по неделям",
+		tblWeek,
+	))
+
+	// Charts
+	xlabels := make([]string, 0, len(days))
+	var weightLabels []string
+	var weightRaw, weightAvg []float64
+	var intake, burned, deficit []float64
+	for _, d := range days {
+		lbl := formatTimestamp(d.ts.ToTime(r.tz))
+		xlabels = append(xlabels, lbl)
+		intake = append(intake, d.intake)
+		burned = append(burned, d.burned)
+		deficit = append(deficit, d.cumDeficit)
+
+		if d.hasWeight {
+			weightLabels = append(weightLabels, lbl)
+			weightRaw = append(weightRaw, d.weight)
+			weightAvg = append(weightAvg, d.weightAvg)
+		}
+	}
+
+	charts := []struct {
+		id    string
+		title string
+		data  *ChartData
+	}{
+		{
+			id:    "chartWeight",
+			title: "График веса",
+			data: &ChartData{
+				XLabels: weightLabels,
+				Type:    "line",
+				Datasets: []ChartDataset{
+					{Data: weightRaw, Label: "Вес", Color: ChartColorGrey},
+					{Data: weightAvg, Label: fmt.Sprintf("Вес, среднее за %d дней", _weightAvgWindow), Color: ChartColorBlue},
+				},
+			},
+		},
+		{
+			id:    "chartCal",
+			title: "График потребления и расхода ккал",
+			data: &ChartData{
+				XLabels: xlabels,
+				Type:    "bar",
+				Datasets: []ChartDataset{
+					{Data: intake, Label: "Потреблено", Color: ChartColorOrange},
+					{Data: burned, Label: "Потрачено", Color: ChartColorGreen},
+				},
+			},
+		},
+		{
+			id:    "chartDeficit",
+			title: "График накопленного дефицита ккал",
+			data: &ChartData{
+				XLabels: xlabels,
+				Type:    "line",
+				Datasets: []ChartDataset{
+					{Data: deficit, Label: "Накопленный дефицит", Color: ChartColorRed},
+				},
+			},
+		},
+	}
+
+	var chartSnippets []html.IELement
+	for i, c := range charts {
+		accordion.AddItem(html.HewAccordionItem(
+			fmt.Sprintf("graph%d", i),
+			c.title,
+			html.NewCanvas(c.id),
+		))
+
+		c.data.PlotFunc = fmt.Sprintf("plot%d", i)
+		c.data.ElemID = c.id
+		snippet, err := html.NewChartScript(c.data)
+		if err != nil {
+			r.logger.Error(
+				"journal trend report command chart error",
+				zap.Int64("userID", userID),
+				zap.Error(err),
+			)
+
+			return NewErrCmdResponse(m.MsgErrInternal)
+		}
+		chartSnippets = append(chartSnippets, snippet)
+	}
+
+	// Doc
+	totalElements := []html.IELement{
+		html.NewH(
+			fmt.Sprintf("Тренды питания и веса за %s - %s", tsFromStr, tsToStr),
+			5,
+			html.Attrs{"align": "center"},
+		),
+		accordion,
+		r.assets.Script(html.AssetBootstrapJS),
+		r.assets.Script(html.AssetChartJS),
+		html.NewS(GetStartPlotSnippet()),
+	}
+	totalElements = append(totalElements, chartSnippets...)
+	totalElements = append(totalElements, html.NewS(GetEndPlotSnippet()))
+	htmlBuilder.Add(
+		html.NewContainer().Add(totalElements...),
+	)
+
+	// Response
+	return r.reportResponse(userID, htmlBuilder, fmt.Sprintf("trend_%s_%s", tsFromStr, tsToStr), format)
+}
+
+// calcTrendDays fills moving average of weight and cumulative deficit of
+// days. Days without weight are skipped in average and days without
+// intake don't change deficit.
+func calcTrendDays(days []*trendDay) {
+	var cumDeficit float64
+	for i, d := range days {
+		var wSum float64
+		var wCnt int
+		for j := max(0, i-_weightAvgWindow+1); j <= i; j++ {
+			if days[j].hasWeight {
+				wSum += days[j].weight
+				wCnt++
+			}
+		}
+		if wCnt > 0 {
+			d.weightAvg = wSum / float64(wCnt)
+		}
+
+		if d.hasIntake {
+			cumDeficit += d.burned - d.intake
+		}
+		d.cumDeficit = cumDeficit
+	}
+}
+
+func trendSummaryTable(days []*trendDay) *html.Table {
+	var intakeDays int
+	for _, d := range days {
+		if d.hasIntake {
+			intakeDays++
+		}
+	}
+
+	var firstAvg, lastAvg *trendDay
+	for _, d := range days {
+		if !d.hasWeight {
+			continue
+		}
+		if firstAvg == nil {
+			firstAvg = d
+		}
+		lastAvg = d
+	}
+
+	cumDeficit := days[len(days)-1].cumDeficit
+	tbl := html.NewTable([]string{"Показатель", "Значение"})
+	addRow := func(name string, val html.IELement) {
+		tbl.AddRow(html.NewTr(nil).
+			AddTd(html.NewTd(html.NewS(name), nil)).
+			AddTd(html.NewTd(val, nil)))
+	}
+
+	addRow("Дней с записями в журнале", html.NewS(fmt.Sprintf("%d из %d", intakeDays, len(days))))
+	addRow("Накопленный дефицит, ккал", calDiffSnippet(cumDeficit))
+	addRow("Ожидаемое изменение веса, кг", html.NewS(fmt.Sprintf("%+.2f", -cumDeficit/_calPerKg)))
+	if firstAvg != nil && firstAvg != lastAvg {
+		addRow("Фактическое изменение веса (среднее), кг", html.NewS(fmt.Sprintf(
+			"%+.2f (%.1f → %.1f)",
+			lastAvg.weightAvg-firstAvg.weightAvg,
+			firstAvg.weightAvg,
+			lastAvg.weightAvg,
+		)))
+	} else {
+		addRow("Фактическое изменение веса (среднее), кг", html.NewS("-"))
+	}
+
+	return tbl
+}
@@ -0,0 +1,199 @@
+package cmdproc
+
+import (
+	"testing"
+	"time"
+
+	m "github.com/devldavydov/myhealth/internal/common/messages"
+	"github.com/devldavydov/myhealth/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalcTrendDays(t *testing.T) {
+	type day struct {
+		intake, burned, weight float64
+		hasIntake, hasWeight   bool
+	}
+
+	for _, tt := range []struct {
+		name       string
+		days       []day
+		weightAvg  []float64
+		cumDeficit []float64
+	}{
+		{
+			name: "full data",
+			days: []day{
+				{intake: 1500, burned: 2000, hasIntake: true, weight: 80, hasWeight: true},
+				{intake: 2500, burned: 2000, hasIntake: true, weight: 81, hasWeight: true},
+			},
+			weightAvg:  []float64{80, 80.5},
+			cumDeficit: []float64{500, 0},
+		},
+		{
+			name: "gaps in intake and weight",
+			days: []day{
+				{intake: 1500, burned: 2000, hasIntake: true, weight: 80, hasWeight: true},
+				{burned: 2000},
+				{intake: 1800, burned: 2000, hasIntake: true},
+				{burned: 2000, weight: 79, hasWeight: true},
+			},
+			weightAvg:  []float64{80, 80, 80, 79.5},
+			cumDeficit: []float64{500, 500, 700, 700},
+		},
+		{
+			name: "no weight at start",
+			days: []day{
+				{intake: 2200, burned: 2000, hasIntake: true},
+				{intake: 2000, burned: 2000, hasIntake: true, weight: 80, hasWeight: true},
+			},
+			weightAvg:  []float64{0, 80},
+			cumDeficit: []float64{-200, -200},
+		},
+		{
+			name: "weight leaves average window",
+			days: []day{
+				{weight: 90, hasWeight: true},
+				{}, {}, {}, {}, {}, {},
+				{weight: 80, hasWeight: true},
+			},
+			weightAvg:  []float64{90, 90, 90, 90, 90, 90, 90, 80},
+			cumDeficit: []float64{0, 0, 0, 0, 0, 0, 0, 0},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			days := make([]*trendDay, 0, len(tt.days))
+			for _, d := range tt.days {
+				days = append(days, &trendDay{
+					intake:    d.intake,
+					hasIntake: d.hasIntake,
+					burned:    d.burned,
+					weight:    d.weight,
+					hasWeight: d.hasWeight,
+				})
+			}
+
+			calcTrendDays(days)
+
+			for i, d := range days {
+				assert.InDelta(t, tt.weightAvg[i], d.weightAvg, 0.001, "weight avg of day %d", i)
+				assert.InDelta(t, tt.cumDeficit[i], d.cumDeficit, 0.001, "deficit of day %d", i)
+			}
+		})
+	}
+}
+
+func TestJournalTrendReportCommand(t *testing.T) {
+	day := func(d, mon, y int) time.Time { return time.Date(y, time.Month(mon), d, 0, 0, 0, 0, time.UTC) }
+
+	// Food of 100 kcal per 100 g, so weight of intake is calories.
+	fixture := []string{
+		"u,set,2000",
+		"f,set,a,A,,100,0,0,0",
+	}
+
+	for _, tt := range []struct {
+		name     string
+		fixture  []string
+		from, to time.Time
+		err      string
+		rows     []string
+	}{
+		{
+			name: "gaps in intake and weight",
+			fixture: []string{
+				"j,set,01.03.2025,обед,a,1500",
+				"j,set,03.03.2025 08:00,завтрак,a,800",
+				"j,set,03.03.2025 19:30,ужин,a,1000",
+				"w,set,01.03.2025,80",
+				"w,set,04.03.2025,79",
+			},
+			from: day(1, 3, 2025),
+			to:   day(4, 3, 2025),
+			rows: []string{
+				"<td >Дней с записями в журнале</td><td >2 из 4</td>",
+				`<td >Накопленный дефицит, ккал</td><td ><span><b class="text-success">+700.00</b></span></td>`,
+				"<td >Ожидаемое изменение веса, кг</td><td >-0.09</td>",
+				"<td >Фактическое изменение веса (среднее), кг</td><td >-0.50 (80.0 → 79.5)</td>",
+				"<td >01.03.2025 - 04.03.2025</td><td >1650.00</td><td >2000.00</td>",
+			},
+		},
+		{
+			name: "no weight",
+			fixture: []string{
+				"j,set,01.03.2025,обед,a,2300",
+			},
+			from: day(1, 3, 2025),
+			to:   day(2, 3, 2025),
+			rows: []string{
+				"<td >Дней с записями в журнале</td><td >1 из 2</td>",
+				`<td >Накопленный дефицит, ккал</td><td ><span><b class="text-danger">-300.00</b></span></td>`,
+				"<td >Фактическое изменение веса (среднее), кг</td><td >-</td>",
+			},
+		},
+		{
+			name: "no intake",
+			fixture: []string{
+				"w,set,01.03.2025,80",
+				"w,set,09.03.2025,78",
+			},
+			from: day(1, 3, 2025),
+			to:   day(9, 3, 2025),
+			rows: []string{
+				"<td >Дней с записями в журнале</td><td >0 из 9</td>",
+				"<td >Накопленный дефицит, ккал</td><td >0.00</td>",
+				"<td >Фактическое изменение веса (среднее), кг</td><td >-2.00 (80.0 → 78.0)</td>",
+				"<td >08.03.2025 - 09.03.2025</td><td >-</td><td >-</td><td >-</td><td >78.0</td>",
+			},
+		},
+		{
+			name: "empty",
+			from: day(1, 3, 2025),
+			to:   day(9, 3, 2025),
+			err:  m.MsgErrEmptyResult,
+		},
+		{
+			name: "invalid period",
+			from: day(9, 3, 2025),
+			to:   day(1, 3, 2025),
+			err:  m.MsgErrInvalidCommand,
+		},
+		{
+			name: "max period",
+			fixture: []string{
+				"w,set,01.03.2025,80",
+			},
+			from: day(1, 3, 2024),
+			to:   day(1, 3, 2025),
+			rows: []string{
+				"<td >Дней с записями в журнале</td><td >0 из 366</td>",
+			},
+		},
+		{
+			name: "too large period",
+			from: day(1, 3, 2024),
+			to:   day(2, 3, 2025),
+			err:  m.MsgErrPeriodTooLarge + ": 367 > 366",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestCmdProcessor(t, append(append([]string{}, fixture...), tt.fixture...))
+
+			resp := r.journalTrendReportCommand(1, tt.from, tt.to, storage.ReportFormatHTML)
+			require.Len(t, resp, 1)
+			if tt.err != "" {
+				assert.True(t, isErrResponse(resp))
+				assert.Equal(t, tt.err, resp[0].what)
+				return
+			}
+
+			require.False(t, isErrResponse(resp))
+			f, ok := resp[0].what.(goldenFile)
+			require.True(t, ok)
+			for _, row := range tt.rows {
+				assert.Contains(t, f.buf.String(), row)
+			}
+		})
+	}
+}
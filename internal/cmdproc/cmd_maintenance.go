@@ -6,7 +6,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"time"
 
 	m "github.com/devldavydov/myhealth/internal/common/messages"
 
@@ -27,7 +26,7 @@ func (r *CmdProcessor) maintenanceBackupCommand(userID int64) []CmdResponse {
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
 	// Generate response.
@@ -39,7 +38,7 @@ func (r *CmdProcessor) maintenanceBackupCommand(userID int64) []CmdResponse {
 			zap.Int64("userID", userID),
 			zap.Error(err),
 		)
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
 	if err := zw.Close(); err != nil {
@@ -48,12 +47,12 @@ func (r *CmdProcessor) maintenanceBackupCommand(userID int64) []CmdResponse {
 			zap.Int64("userID", userID),
 			zap.Error(err),
 		)
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
 	return NewSingleCmdResponse(r.typeAdapter.File(
 		&buf,
 		"application/x-gzip-compressed",
-		fmt.Sprintf("backup_%s.json.gz", formatTimestamp(time.Now().In(r.tz))),
+		fmt.Sprintf("backup_%s.json.gz", formatTimestamp(timeNow().In(r.tz))),
 	))
 }
@@ -1,7 +1,6 @@
 package cmdproc
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -27,7 +26,7 @@ func (r *CmdProcessor) medSetCommand(userID int64, key, name, unit, comment stri
 		Comment: comment,
 	}); err != nil {
 		if errors.Is(err, storage.ErrMedicineInvalid) {
-			return NewSingleCmdResponse(m.MsgErrInvalidCommand)
+			return NewErrCmdResponse(m.MsgErrInvalidCommand)
 		}
 
 		r.logger.Error(
@@ -36,7 +35,7 @@ func (r *CmdProcessor) medSetCommand(userID int64, key, name, unit, comment stri
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
 	return NewSingleCmdResponse(m.MsgOK)
@@ -50,7 +49,7 @@ func (r *CmdProcessor) medSetTemplateCommand(userID int64, key string) []CmdResp
 	med, err := r.stg.GetMedicine(ctx, userID, key)
 	if err != nil {
 		if errors.Is(err, storage.ErrMedicineNotFound) {
-			return NewSingleCmdResponse(m.MsgErrMedicineNotFound)
+			return NewErrCmdResponse(m.MsgErrMedicineNotFound)
 		}
 
 		r.logger.Error(
@@ -59,10 +58,10 @@ func (r *CmdProcessor) medSetTemplateCommand(userID int64, key string) []CmdResp
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
-	return NewSingleCmdResponse(fmt.Sprintf("m,set,%s,%s,%s,%s", med.Key, med.Name, med.Unit, med.Comment))
+	return NewSingleCmdResponse(fmt.Sprintf("m,set,%s,%s,%s,%s", quoteArg(med.Key), quoteArg(med.Name), quoteArg(med.Unit), quoteArg(med.Comment)))
 }
 
 func (r *CmdProcessor) medDelCommand(userID int64, key string) []CmdResponse {
@@ -72,7 +71,7 @@ func (r *CmdProcessor) medDelCommand(userID int64, key string) []CmdResponse {
 
 	if err := r.stg.DeleteMedicine(ctx, userID, key); err != nil {
 		if errors.Is(err, storage.ErrMedicineIsUsed) {
-			return NewSingleCmdResponse(m.MsgErrMedicineIsUsed)
+			return NewErrCmdResponse(m.MsgErrMedicineIsUsed)
 		}
 
 		r.logger.Error(
@@ -81,13 +80,13 @@ func (r *CmdProcessor) medDelCommand(userID int64, key string) []CmdResponse {
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
 	return NewSingleCmdResponse(m.MsgOK)
 }
 
-func (r *CmdProcessor) medListCommand(userID int64) []CmdResponse {
+func (r *CmdProcessor) medListCommand(userID int64, format storage.ReportFormat) []CmdResponse {
 	// Call storage
 	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
 	defer cancel()
@@ -95,7 +94,7 @@ func (r *CmdProcessor) medListCommand(userID int64) []CmdResponse {
 	sportList, err := r.stg.GetMedicineList(ctx, userID)
 	if err != nil {
 		if errors.Is(err, storage.ErrEmptyResult) {
-			return NewSingleCmdResponse(m.MsgErrEmptyResult)
+			return NewErrCmdResponse(m.MsgErrEmptyResult)
 		}
 
 		r.logger.Error(
@@ -104,11 +103,11 @@ func (r *CmdProcessor) medListCommand(userID int64) []CmdResponse {
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
 	// Build html
-	htmlBuilder := html.NewBuilder("Список медицины")
+	htmlBuilder := html.NewBuilder("Список медицины", r.assets)
 
 	// Table
 	tbl := html.NewTable([]string{"Ключ", "Наименование", "Единица измерения", "Комментарий"})
@@ -134,11 +133,7 @@ func (r *CmdProcessor) medListCommand(userID int64) []CmdResponse {
 			tbl))
 
 	// Response
-	return NewSingleCmdResponse(r.typeAdapter.File(
-		bytes.NewBufferString(htmlBuilder.Build()),
-		"text/html",
-		"medicine.html",
-	))
+	return r.reportResponse(userID, htmlBuilder, "medicine", format)
 }
 
 func (r *CmdProcessor) medIndicatorSetCommand(
@@ -157,11 +152,11 @@ func (r *CmdProcessor) medIndicatorSetCommand(
 		Value:       value,
 	}); err != nil {
 		if errors.Is(err, storage.ErrMedicineIndicatorInvalid) {
-			return NewSingleCmdResponse(m.MsgErrInvalidCommand)
+			return NewErrCmdResponse(m.MsgErrInvalidCommand)
 		}
 
 		if errors.Is(err, storage.ErrMedicineNotFound) {
-			return NewSingleCmdResponse(m.MsgErrMedicineNotFound)
+			return NewErrCmdResponse(m.MsgErrMedicineNotFound)
 		}
 
 		r.logger.Error(
@@ -170,7 +165,7 @@ func (r *CmdProcessor) medIndicatorSetCommand(
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
 	return NewSingleCmdResponse(m.MsgOK)
@@ -181,28 +176,32 @@ func (r *CmdProcessor) medIndicatorDelCommand(userID int64, ts time.Time, medKey
 	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
 	defer cancel()
 
-	if err := r.stg.DeleteMedicineIndicator(ctx, userID, storage.NewTimestamp(ts), medKey); err != nil {
+	if err := r.stg.DeleteMedicineIndicator(ctx, userID,
+		storage.NewTimestamp(storage.DayStart(ts)),
+		storage.NewTimestamp(storage.DayEnd(ts)),
+		medKey,
+	); err != nil {
 		r.logger.Error(
 			"medicine indicator del command DB error",
 			zap.Int64("userID", userID),
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
 	return NewSingleCmdResponse(m.MsgOK)
 }
 
-func (r *CmdProcessor) medIndicatorReportCommand(userID int64, tsFrom, tsTo time.Time) []CmdResponse {
+func (r *CmdProcessor) medIndicatorReportCommand(userID int64, tsFrom, tsTo time.Time, format storage.ReportFormat) []CmdResponse {
 	// Call storage
 	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
 	defer cancel()
 
-	dbRes, err := r.stg.GetMedicineIndicatorReport(ctx, userID, storage.NewTimestamp(tsFrom), storage.NewTimestamp(tsTo))
+	dbRes, err := r.stg.GetMedicineIndicatorReport(ctx, userID, storage.NewTimestamp(storage.DayStart(tsFrom)), storage.NewTimestamp(storage.DayEnd(tsTo)))
 	if err != nil {
 		if errors.Is(err, storage.ErrEmptyResult) {
-			return NewSingleCmdResponse(m.MsgErrEmptyResult)
+			return NewErrCmdResponse(m.MsgErrEmptyResult)
 		}
 
 		r.logger.Error(
@@ -211,7 +210,7 @@ func (r *CmdProcessor) medIndicatorReportCommand(userID int64, tsFrom, tsTo time
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
 	type grpItem struct {
@@ -241,7 +240,7 @@ func (r *CmdProcessor) medIndicatorReportCommand(userID int64, tsFrom, tsTo time
 	slices.Sort(keys)
 
 	// Build html
-	htmlBuilder := html.NewBuilder("Медицинские показатели за период")
+	htmlBuilder := html.NewBuilder("Медицинские показатели за период", r.assets)
 	tsFromStr, tsToStr := formatTimestamp(tsFrom), formatTimestamp(tsTo)
 	accordion := html.NewAccordion("accordionMI")
 
@@ -257,7 +256,7 @@ func (r *CmdProcessor) medIndicatorReportCommand(userID int64, tsFrom, tsTo time
 			if first {
 				tr.AddTd(
 					html.NewTd(
-						html.NewS(formatTimestamp(key.ToTime(r.tz))),
+						html.NewS(formatDateTime(key.ToTime(r.tz))),
 						html.Attrs{"rowspan": strconv.Itoa(len(rows))},
 					))
 				first = false
@@ -297,7 +296,7 @@ func (r *CmdProcessor) medIndicatorReportCommand(userID int64, tsFrom, tsTo time
 		data := make([]float64, 0, len(sportTs))
 
 		for _, k := range sportTs {
-			xlabels = append(xlabels, formatTimestamp(k.ToTime(r.tz)))
+			xlabels = append(xlabels, formatDateTime(k.ToTime(r.tz)))
 			data = append(data, float64(graphData[medName][k]))
 		}
 
@@ -309,7 +308,7 @@ func (r *CmdProcessor) medIndicatorReportCommand(userID int64, tsFrom, tsTo time
 			chart,
 		))
 
-		snippet, err := GetChartSnippet(&ChartData{
+		snippet, err := html.NewChartScript(&ChartData{
 			PlotFunc: fmt.Sprintf("plot%d", i),
 			ElemID:   chartID,
 			XLabels:  xlabels,
@@ -329,9 +328,9 @@ func (r *CmdProcessor) medIndicatorReportCommand(userID int64, tsFrom, tsTo time
 				zap.Error(err),
 			)
 
-			return NewSingleCmdResponse(m.MsgErrInternal)
+			return NewErrCmdResponse(m.MsgErrInternal)
 		}
-		chartSnippets = append(chartSnippets, html.NewS(snippet))
+		chartSnippets = append(chartSnippets, snippet)
 	}
 
 	// Doc
@@ -342,8 +341,8 @@ func (r *CmdProcessor) medIndicatorReportCommand(userID int64, tsFrom, tsTo time
 			html.Attrs{"align": "center"},
 		),
 		accordion,
-		html.NewScript(_jsBootstrapURL),
-		html.NewScript(_jsChartURL),
+		r.assets.Script(html.AssetBootstrapJS),
+		r.assets.Script(html.AssetChartJS),
 		html.NewS(GetStartPlotSnippet()),
 	}
 	totalElements = append(totalElements, chartSnippets...)
@@ -354,9 +353,5 @@ func (r *CmdProcessor) medIndicatorReportCommand(userID int64, tsFrom, tsTo time
 		)
 
 	// Response
-	return NewSingleCmdResponse(r.typeAdapter.File(
-		bytes.NewBufferString(htmlBuilder.Build()),
-		"text/html",
-		fmt.Sprintf("medicine_ind_%s_%s.html", tsFromStr, tsToStr),
-	))
+	return r.reportResponse(userID, htmlBuilder, fmt.Sprintf("medicine_ind_%s_%s", tsFromStr, tsToStr), format)
 }
@@ -0,0 +1,341 @@
+package cmdproc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/devldavydov/myhealth/internal/common/html"
+	m "github.com/devldavydov/myhealth/internal/common/messages"
+	"github.com/devldavydov/myhealth/internal/storage"
+	"go.uber.org/zap"
+)
+
+const _sleepCorrMinPoints = 3
+
+func (r *CmdProcessor) sleepSetCommand(userID int64, bedTime, wakeTime time.Time, quality int, notes string) []CmdResponse {
+	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
+	defer cancel()
+
+	if err := r.stg.SetSleep(ctx, userID, &storage.Sleep{
+		Timestamp: storage.NewTimestamp(storage.DayStart(wakeTime)),
+		BedTime:   storage.NewTimestamp(bedTime),
+		WakeTime:  storage.NewTimestamp(wakeTime),
+		Quality:   int64(quality),
+		Notes:     notes,
+	}); err != nil {
+		if errors.Is(err, storage.ErrSleepInvalid) {
+			return NewErrCmdResponse(m.MsgErrInvalidCommand)
+		}
+
+		r.logger.Error(
+			"sleep set command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	return NewSingleCmdResponse(m.MsgOK)
+}
+
+func (r *CmdProcessor) sleepSetTemplateCommand(userID int64, ts time.Time) []CmdResponse {
+	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
+	defer cancel()
+
+	sl, err := r.stg.GetSleep(ctx, userID, storage.NewTimestamp(storage.DayStart(ts)))
+	if err != nil {
+		if errors.Is(err, storage.ErrSleepNotFound) {
+			return NewErrCmdResponse(m.MsgErrSleepNotFound)
+		}
+
+		r.logger.Error(
+			"sleep get command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	return NewSingleCmdResponse(fmt.Sprintf(
+		"sl,set,%s,%s,%d,%s",
+		formatDateTime(sl.BedTime.ToTime(r.tz)),
+		formatDateTime(sl.WakeTime.ToTime(r.tz)),
+		sl.Quality,
+		quoteArg(sl.Notes),
+	))
+}
+
+func (r *CmdProcessor) sleepDelCommand(userID int64, ts time.Time) []CmdResponse {
+	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
+	defer cancel()
+
+	if err := r.stg.DeleteSleep(ctx, userID, storage.NewTimestamp(storage.DayStart(ts))); err != nil {
+		r.logger.Error(
+			"sleep del command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	return NewSingleCmdResponse(m.MsgOK)
+}
+
+func (r *CmdProcessor) sleepReportCommand(userID int64, tsFrom, tsTo time.Time, format storage.ReportFormat) []CmdResponse {
+	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
+	defer cancel()
+
+	lst, err := r.stg.GetSleepList(ctx, userID, storage.NewTimestamp(storage.DayStart(tsFrom)), storage.NewTimestamp(storage.DayEnd(tsTo)))
+	if err != nil {
+		if errors.Is(err, storage.ErrEmptyResult) {
+			return NewErrCmdResponse(m.MsgErrEmptyResult)
+		}
+
+		r.logger.Error(
+			"sleep report command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	// Sleep is stored on day of wake up, so intake of the same day
+	// is the next day intake after the night
+	jrnl, err := r.stg.GetJournalReport(ctx, userID, storage.NewTimestamp(storage.DayStart(tsFrom)), storage.NewTimestamp(storage.DayEnd(tsTo)))
+	if err != nil && !errors.Is(err, storage.ErrEmptyResult) {
+		r.logger.Error(
+			"sleep report command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	intakeData := make(map[storage.Timestamp]float64)
+	for _, j := range jrnl {
+		intakeData[dayKey(j.Timestamp, r.tz)] += j.Cal
+	}
+
+	// Build html
+	tsFromStr, tsToStr := formatTimestamp(tsFrom), formatTimestamp(tsTo)
+	htmlBuilder := html.NewBuilder("Сон за период", r.assets)
+	accordion := html.NewAccordion("accordionSleep")
+
+	// Table
+	tbl := html.NewTable([]string{"Дата", "Отбой", "Подъем", "Длительность, ч", "Качество", "Потреблено, ккал", "Заметки"})
+
+	xlabels := make([]string, 0, len(lst))
+	dataDuration := make([]float64, 0, len(lst))
+	dataQuality := make([]float64, 0, len(lst))
+	dataBedTime := make([]float64, 0, len(lst))
+	dataWakeTime := make([]float64, 0, len(lst))
+	var corrDuration, corrQuality, corrIntake []float64
+
+	for _, sl := range lst {
+		day := sl.Timestamp.ToTime(r.tz)
+		duration := sl.Duration().Hours()
+		bedTime := sl.BedTime.ToTime(r.tz).Sub(day).Hours()
+		wakeTime := sl.WakeTime.ToTime(r.tz).Sub(day).Hours()
+
+		intakeStr := "-"
+		if intake, ok := intakeData[sl.Timestamp]; ok {
+			intakeStr = fmt.Sprintf("%.2f", intake)
+			corrDuration = append(corrDuration, duration)
+			corrQuality = append(corrQuality, float64(sl.Quality))
+			corrIntake = append(corrIntake, intake)
+		}
+
+		tbl.AddRow(
+			html.NewTr(nil).
+				AddTd(html.NewTd(html.NewS(formatTimestamp(day)), nil)).
+				AddTd(html.NewTd(html.NewS(formatClockHours(bedTime)), nil)).
+				AddTd(html.NewTd(html.NewS(formatClockHours(wakeTime)), nil)).
+				AddTd(html.NewTd(html.NewS(fmt.Sprintf("%.1f", duration)), nil)).
+				AddTd(html.NewTd(html.NewS(fmt.Sprintf("%d", sl.Quality)), nil)).
+				AddTd(html.NewTd(html.NewS(intakeStr), nil)).
+				AddTd(html.NewTd(html.NewS(sl.Notes), nil)),
+		)
+
+		xlabels = append(xlabels, formatTimestamp(day))
+		dataDuration = append(dataDuration, duration)
+		dataQuality = append(dataQuality, float64(sl.Quality))
+		dataBedTime = append(dataBedTime, bedTime)
+		dataWakeTime = append(dataWakeTime, wakeTime)
+	}
+
+	// Summary
+	tblSummary := html.NewTable([]string{"Показатель", "Значение"})
+	addRow := func(name, val string) {
+		tblSummary.AddRow(html.NewTr(nil).
+			AddTd(html.NewTd(html.NewS(name), nil)).
+			AddTd(html.NewTd(html.NewS(val), nil)))
+	}
+	addCorrRow := func(name string, x []float64) {
+		if corr, ok := calcCorrelation(x, corrIntake); ok {
+			addRow(name, fmt.Sprintf("%+.2f (дней: %d)", corr, len(x)))
+		} else {
+			addRow(name, "-")
+		}
+	}
+
+	bedMean, bedStdDev := calcMeanStdDev(dataBedTime)
+	wakeMean, wakeStdDev := calcMeanStdDev(dataWakeTime)
+	durationMean, _ := calcMeanStdDev(dataDuration)
+	qualityMean, _ := calcMeanStdDev(dataQuality)
+
+	addRow("Ночей с записями", fmt.Sprintf("%d", len(lst)))
+	addRow("Средняя длительность, ч", fmt.Sprintf("%.1f", durationMean))
+	addRow("Среднее качество", fmt.Sprintf("%.1f", qualityMean))
+	addRow("Среднее время отбоя", formatClockHours(bedMean))
+	addRow("Среднее время подъема", formatClockHours(wakeMean))
+	addRow("Разброс времени отбоя, мин", fmt.Sprintf("%.0f", bedStdDev*60))
+	addRow("Разброс времени подъема, мин", fmt.Sprintf("%.0f", wakeStdDev*60))
+	addCorrRow("Корреляция длительности и ккал следующего дня", corrDuration)
+	addCorrRow("Корреляция качества и ккал следующего дня", corrQuality)
+
+	accordion.AddItem(html.HewAccordionItem(
+		"summary",
+		"Итоги",
+		tblSummary,
+	))
+	accordion.AddItem(html.HewAccordionItem(
+		"tbl",
+		"Таблица сна",
+		tbl,
+	))
+
+	// Charts
+	charts := []struct {
+		id    string
+		title string
+		data  *ChartData
+	}{
+		{
+			id:    "chartDuration",
+			title: "График длительности и качества сна",
+			data: &ChartData{
+				XLabels: xlabels,
+				Type:    "bar",
+				Datasets: []ChartDataset{
+					{Data: dataDuration, Label: "Длительность, ч", Color: ChartColorBlue},
+					{Data: dataQuality, Label: "Качество", Color: ChartColorOrange},
+				},
+			},
+		},
+		{
+			id:    "chartRegularity",
+			title: "График регулярности сна",
+			data: &ChartData{
+				XLabels: xlabels,
+				Type:    "line",
+				Datasets: []ChartDataset{
+					{Data: dataBedTime, Label: "Отбой, ч от полуночи", Color: ChartColorRed},
+					{Data: dataWakeTime, Label: "Подъем, ч от полуночи", Color: ChartColorGreen},
+				},
+			},
+		},
+	}
+
+	var chartSnippets []html.IELement
+	for i, c := range charts {
+		accordion.AddItem(html.HewAccordionItem(
+			fmt.Sprintf("graph%d", i),
+			c.title,
+			html.NewCanvas(c.id),
+		))
+
+		c.data.PlotFunc = fmt.Sprintf("plot%d", i)
+		c.data.ElemID = c.id
+		snippet, err := html.NewChartScript(c.data)
+		if err != nil {
+			r.logger.Error(
+				"sleep report command chart error",
+				zap.Int64("userID", userID),
+				zap.Error(err),
+			)
+
+			return NewErrCmdResponse(m.MsgErrInternal)
+		}
+		chartSnippets = append(chartSnippets, snippet)
+	}
+
+	// Doc
+	totalElements := []html.IELement{
+		html.NewH(
+			fmt.Sprintf("Сон за %s - %s", tsFromStr, tsToStr),
+			5,
+			html.Attrs{"align": "center"},
+		),
+		accordion,
+		r.assets.Script(html.AssetBootstrapJS),
+		r.assets.Script(html.AssetChartJS),
+		html.NewS(GetStartPlotSnippet()),
+	}
+	totalElements = append(totalElements, chartSnippets...)
+	totalElements = append(totalElements, html.NewS(GetEndPlotSnippet()))
+	htmlBuilder.Add(
+		html.NewContainer().Add(totalElements...),
+	)
+
+	// Response
+	return r.reportResponse(userID, htmlBuilder, fmt.Sprintf("sleep_%s_%s", tsFromStr, tsToStr), format)
+}
+
+// formatClockHours formats hours from midnight as clock time,
+// negative hours are the time of previous day.
+func formatClockHours(h float64) string {
+	minutes := int(math.Round(h * 60))
+	minutes = ((minutes % (24 * 60)) + 24*60) % (24 * 60)
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// calcMeanStdDev returns mean and population standard deviation of values.
+func calcMeanStdDev(values []float64) (float64, float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+
+	n := float64(len(values))
+	var mean float64
+	for _, v := range values {
+		mean += v
+	}
+	mean /= n
+
+	var variance float64
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+
+	return mean, math.Sqrt(variance / n)
+}
+
+// calcCorrelation returns Pearson correlation coefficient of x and y,
+// false if there are not enough points or values are constant.
+func calcCorrelation(x, y []float64) (float64, bool) {
+	if len(x) != len(y) || len(x) < _sleepCorrMinPoints {
+		return 0, false
+	}
+
+	xMean, xStdDev := calcMeanStdDev(x)
+	yMean, yStdDev := calcMeanStdDev(y)
+	if xStdDev == 0 || yStdDev == 0 {
+		return 0, false
+	}
+
+	var cov float64
+	for i := range x {
+		cov += (x[i] - xMean) * (y[i] - yMean)
+	}
+	cov /= float64(len(x))
+
+	return cov / (xStdDev * yStdDev), true
+}
@@ -1,7 +1,6 @@
 package cmdproc
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -22,14 +21,27 @@ func (r *CmdProcessor) sportSetCommand(userID int64, key, name, unit, comment st
 	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
 	defer cancel()
 
-	if err := r.stg.SetSport(ctx, userID, &storage.Sport{
-		Key:     key,
-		Name:    name,
-		Unit:    unit,
-		Comment: comment,
-	}); err != nil {
+	// Keep calories coefficients and set kind of existing sport
+	sport, err := r.stg.GetSport(ctx, userID, key)
+	if err != nil {
+		if !errors.Is(err, storage.ErrSportNotFound) {
+			r.logger.Error(
+				"sport set command DB error",
+				zap.Int64("userID", userID),
+				zap.Error(err),
+			)
+
+			return NewErrCmdResponse(m.MsgErrInternal)
+		}
+		sport = &storage.Sport{Key: key, SetKind: storage.SportSetKindValue}
+	}
+
+	sport.Name = name
+	sport.Unit = unit
+	sport.Comment = comment
+	if err := r.stg.SetSport(ctx, userID, sport); err != nil {
 		if errors.Is(err, storage.ErrSportInvalid) {
-			return NewSingleCmdResponse(m.MsgErrInvalidCommand)
+			return NewErrCmdResponse(m.MsgErrInvalidCommand)
 		}
 
 		r.logger.Error(
@@ -38,7 +50,7 @@ func (r *CmdProcessor) sportSetCommand(userID int64, key, name, unit, comment st
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
 	return NewSingleCmdResponse(m.MsgOK)
@@ -52,7 +64,7 @@ func (r *CmdProcessor) sportSetTemplateCommand(userID int64, key string) []CmdRe
 	sport, err := r.stg.GetSport(ctx, userID, key)
 	if err != nil {
 		if errors.Is(err, storage.ErrSportNotFound) {
-			return NewSingleCmdResponse(m.MsgErrSportNotFound)
+			return NewErrCmdResponse(m.MsgErrSportNotFound)
 		}
 
 		r.logger.Error(
@@ -61,10 +73,93 @@ func (r *CmdProcessor) sportSetTemplateCommand(userID int64, key string) []CmdRe
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
-	return NewSingleCmdResponse(fmt.Sprintf("s,set,%s,%s,%s,%s", sport.Key, sport.Name, sport.Unit, sport.Comment))
+	resp := NewSingleCmdResponse(fmt.Sprintf("s,set,%s,%s,%s,%s", quoteArg(sport.Key), quoteArg(sport.Name), quoteArg(sport.Unit), quoteArg(sport.Comment)))
+	if sport.SetKind != storage.SportSetKindValue {
+		resp = append(resp, NewCmdResponse(fmt.Sprintf("s,kind,%s,%s", quoteArg(sport.Key), sport.SetKind)))
+	}
+	if sport.MET > 0 || sport.CalPerUnit > 0 {
+		resp = append(resp, NewCmdResponse(fmt.Sprintf("s,cal,%s,%g,%g", quoteArg(sport.Key), sport.MET, sport.CalPerUnit)))
+	}
+
+	return resp
+}
+
+func (r *CmdProcessor) sportSetCalCommand(userID int64, key string, met, calPerUnit float64) []CmdResponse {
+	// Get from DB
+	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
+	defer cancel()
+
+	sport, err := r.stg.GetSport(ctx, userID, key)
+	if err != nil {
+		if errors.Is(err, storage.ErrSportNotFound) {
+			return NewErrCmdResponse(m.MsgErrSportNotFound)
+		}
+
+		r.logger.Error(
+			"sport set cal command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	// Save in DB
+	sport.MET = met
+	sport.CalPerUnit = calPerUnit
+	if err := r.stg.SetSport(ctx, userID, sport); err != nil {
+		if errors.Is(err, storage.ErrSportInvalid) {
+			return NewErrCmdResponse(m.MsgErrInvalidCommand)
+		}
+
+		r.logger.Error(
+			"sport set cal command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	return NewSingleCmdResponse(m.MsgOK)
+}
+
+func (r *CmdProcessor) sportSetKindCommand(userID int64, key string, setKind storage.SportSetKind) []CmdResponse {
+	// Get from DB
+	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
+	defer cancel()
+
+	sport, err := r.stg.GetSport(ctx, userID, key)
+	if err != nil {
+		if errors.Is(err, storage.ErrSportNotFound) {
+			return NewErrCmdResponse(m.MsgErrSportNotFound)
+		}
+
+		r.logger.Error(
+			"sport set kind command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	// Save in DB
+	sport.SetKind = setKind
+	if err := r.stg.SetSport(ctx, userID, sport); err != nil {
+		r.logger.Error(
+			"sport set kind command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	return NewSingleCmdResponse(m.MsgOK)
 }
 
 func (r *CmdProcessor) sportDelCommand(userID int64, key string) []CmdResponse {
@@ -74,7 +169,7 @@ func (r *CmdProcessor) sportDelCommand(userID int64, key string) []CmdResponse {
 
 	if err := r.stg.DeleteSport(ctx, userID, key); err != nil {
 		if errors.Is(err, storage.ErrSportIsUsed) {
-			return NewSingleCmdResponse(m.MsgErrSportIsUsed)
+			return NewErrCmdResponse(m.MsgErrSportIsUsed)
 		}
 
 		r.logger.Error(
@@ -83,13 +178,13 @@ func (r *CmdProcessor) sportDelCommand(userID int64, key string) []CmdResponse {
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
 	return NewSingleCmdResponse(m.MsgOK)
 }
 
-func (r *CmdProcessor) sportListCommand(userID int64) []CmdResponse {
+func (r *CmdProcessor) sportListCommand(userID int64, format storage.ReportFormat) []CmdResponse {
 	// Call storage
 	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
 	defer cancel()
@@ -97,7 +192,7 @@ func (r *CmdProcessor) sportListCommand(userID int64) []CmdResponse {
 	sportList, err := r.stg.GetSportList(ctx, userID)
 	if err != nil {
 		if errors.Is(err, storage.ErrEmptyResult) {
-			return NewSingleCmdResponse(m.MsgErrEmptyResult)
+			return NewErrCmdResponse(m.MsgErrEmptyResult)
 		}
 
 		r.logger.Error(
@@ -106,14 +201,16 @@ func (r *CmdProcessor) sportListCommand(userID int64) []CmdResponse {
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
 	// Build html
-	htmlBuilder := html.NewBuilder("Список спорта")
+	htmlBuilder := html.NewBuilder("Список спорта", r.assets)
 
 	// Table
-	tbl := html.NewTable([]string{"Ключ", "Наименование", "Единица измерения", "Комментарий"})
+	tbl := html.NewTable([]string{
+		"Ключ", "Наименование", "Единица измерения", "Подходы", "MET", "ККал на единицу", "Комментарий",
+	})
 
 	for _, item := range sportList {
 		tr := html.NewTr(nil)
@@ -121,6 +218,9 @@ func (r *CmdProcessor) sportListCommand(userID int64) []CmdResponse {
 			AddTd(html.NewTd(html.NewS(item.Key), nil)).
 			AddTd(html.NewTd(html.NewS(item.Name), nil)).
 			AddTd(html.NewTd(html.NewS(item.Unit), nil)).
+			AddTd(html.NewTd(html.NewS(item.SetKind.MustToString()), nil)).
+			AddTd(html.NewTd(html.NewS(strconv.FormatFloat(item.MET, 'g', -1, 64)), nil)).
+			AddTd(html.NewTd(html.NewS(strconv.FormatFloat(item.CalPerUnit, 'g', -1, 64)), nil)).
 			AddTd(html.NewTd(html.NewS(item.Comment), nil))
 		tbl.AddRow(tr)
 	}
@@ -136,36 +236,68 @@ func (r *CmdProcessor) sportListCommand(userID int64) []CmdResponse {
 			tbl))
 
 	// Response
-	return NewSingleCmdResponse(r.typeAdapter.File(
-		bytes.NewBufferString(htmlBuilder.Build()),
-		"text/html",
-		"sport.html",
-	))
+	return r.reportResponse(userID, htmlBuilder, "sport", format)
 }
 
 func (r *CmdProcessor) sportActivitySetCommand(
 	userID int64,
 	ts time.Time,
 	sportKey string,
-	sets []float64,
+	sets []storage.SportSet,
+	comment string,
+) []CmdResponse {
+	return r.sportActivitySet(userID, ts, sportKey, sets, 0, comment)
+}
+
+func (r *CmdProcessor) sportActivitySetDurationCommand(
+	userID int64,
+	ts time.Time,
+	sportKey string,
+	sets []storage.SportSet,
+	duration float64,
+	comment string,
+) []CmdResponse {
+	return r.sportActivitySet(userID, ts, sportKey, sets, duration, comment)
+}
+
+func (r *CmdProcessor) sportActivitySet(
+	userID int64,
+	ts time.Time,
+	sportKey string,
+	sets []storage.SportSet,
+	duration float64,
 	comment string,
 ) []CmdResponse {
 	// Call storage
 	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
 	defer cancel()
 
+	// Records before new session
+	sTs := storage.NewTimestamp(ts)
+	historyBefore, err := r.getSportActivityHistory(ctx, userID, sportKey)
+	if err != nil {
+		r.logger.Error(
+			"sport activity set command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
 	if err := r.stg.SetSportActivity(ctx, userID, &storage.SportActivity{
 		SportKey:  sportKey,
-		Timestamp: storage.NewTimestamp(ts),
+		Timestamp: sTs,
 		Sets:      sets,
+		Duration:  duration,
 		Comment:   comment,
 	}); err != nil {
 		if errors.Is(err, storage.ErrSportActivityInvalid) {
-			return NewSingleCmdResponse(m.MsgErrInvalidCommand)
+			return NewErrCmdResponse(m.MsgErrInvalidCommand)
 		}
 
 		if errors.Is(err, storage.ErrSportNotFound) {
-			return NewSingleCmdResponse(m.MsgErrSportNotFound)
+			return NewErrCmdResponse(m.MsgErrSportNotFound)
 		}
 
 		r.logger.Error(
@@ -174,10 +306,47 @@ func (r *CmdProcessor) sportActivitySetCommand(
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
-	return NewSingleCmdResponse(m.MsgOK)
+	// First activity of sport is not a record
+	if len(historyBefore) == 0 {
+		return NewSingleCmdResponse(m.MsgOK)
+	}
+
+	sport, err := r.stg.GetSport(ctx, userID, sportKey)
+	if err != nil {
+		r.logger.Error(
+			"sport activity set command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	historyAfter, err := r.getSportActivityHistory(ctx, userID, sportKey)
+	if err != nil {
+		r.logger.Error(
+			"sport activity set command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	resp := NewSingleCmdResponse(m.MsgOK)
+	if msg := newSportRecordsMessage(
+		calcSportRecords(sport.SetKind, historyBefore, r.tz),
+		calcSportRecords(sport.SetKind, historyAfter, r.tz),
+		sTs,
+		r.tz,
+	); msg != "" {
+		resp = append(resp, NewCmdResponse(msg, r.typeAdapter.OptsHTML()))
+	}
+
+	return resp
 }
 
 func (r *CmdProcessor) sportActivityDelCommand(userID int64, ts time.Time, sportKey string) []CmdResponse {
@@ -185,28 +354,91 @@ func (r *CmdProcessor) sportActivityDelCommand(userID int64, ts time.Time, sport
 	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
 	defer cancel()
 
-	if err := r.stg.DeleteSportActivity(ctx, userID, storage.NewTimestamp(ts), sportKey); err != nil {
+	if err := r.stg.DeleteSportActivity(ctx, userID,
+		storage.NewTimestamp(storage.DayStart(ts)),
+		storage.NewTimestamp(storage.DayEnd(ts)),
+		sportKey); err != nil {
 		r.logger.Error(
 			"sport activity del command DB error",
 			zap.Int64("userID", userID),
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	return NewSingleCmdResponse(m.MsgOK)
+}
+
+func (r *CmdProcessor) sportActivityDelByIDCommand(userID int64, id int) []CmdResponse {
+	// Call storage
+	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
+	defer cancel()
+
+	if err := r.stg.DeleteSportActivityByID(ctx, userID, int64(id)); err != nil {
+		if errors.Is(err, storage.ErrSportActivityNotFound) {
+			return NewErrCmdResponse(m.MsgErrSportActivityNotFound)
+		}
+
+		r.logger.Error(
+			"sport activity del by id command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
 	return NewSingleCmdResponse(m.MsgOK)
 }
 
-func (r *CmdProcessor) sportActivityReportCommand(userID int64, tsFrom, tsTo time.Time) []CmdResponse {
+func (r *CmdProcessor) sportActivityListCommand(userID int64, ts time.Time) []CmdResponse {
 	// Call storage
 	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
 	defer cancel()
 
-	dbRes, err := r.stg.GetSportActivityReport(ctx, userID, storage.NewTimestamp(tsFrom), storage.NewTimestamp(tsTo))
+	dbRes, err := r.stg.GetSportActivityReport(ctx, userID, storage.NewTimestamp(storage.DayStart(ts)), storage.NewTimestamp(storage.DayEnd(ts)))
 	if err != nil {
 		if errors.Is(err, storage.ErrEmptyResult) {
-			return NewSingleCmdResponse(m.MsgErrEmptyResult)
+			return NewErrCmdResponse(m.MsgErrEmptyResult)
+		}
+
+		r.logger.Error(
+			"sport activity list command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("<b>Активность за %s</b>\n", formatTimestamp(ts)))
+	for _, d := range dbRes {
+		sb.WriteString(fmt.Sprintf("\n<b>\u2022 ID %d: %s</b>\n", d.ID, d.SportName))
+		if t := d.Timestamp.ToTime(r.tz); !t.Equal(storage.DayStart(t)) {
+			sb.WriteString(fmt.Sprintf("Время: %s\n", t.Format("15:04")))
+		}
+		sb.WriteString(fmt.Sprintf("Подходы: %s\n", formatSportSets(d.Sets)))
+		sb.WriteString(fmt.Sprintf("Итого: %s\n", formatSportSetsTotal(storage.NewSportSetsTotal(d.Sets))))
+		sb.WriteString(fmt.Sprintf("Длительность: %.0f мин, ККал: %.2f\n", d.Duration, d.Cal))
+		if d.Comment != "" {
+			sb.WriteString(fmt.Sprintf("Комментарий: %s\n", d.Comment))
+		}
+	}
+
+	return NewSingleCmdResponse(sb.String(), r.typeAdapter.OptsHTML())
+}
+
+func (r *CmdProcessor) sportActivityReportCommand(userID int64, tsFrom, tsTo time.Time, format storage.ReportFormat) []CmdResponse {
+	// Call storage
+	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
+	defer cancel()
+
+	dbRes, err := r.stg.GetSportActivityReport(ctx, userID, storage.NewTimestamp(storage.DayStart(tsFrom)), storage.NewTimestamp(storage.DayEnd(tsTo)))
+	if err != nil {
+		if errors.Is(err, storage.ErrEmptyResult) {
+			return NewErrCmdResponse(m.MsgErrEmptyResult)
 		}
 
 		r.logger.Error(
@@ -215,42 +447,49 @@ func (r *CmdProcessor) sportActivityReportCommand(userID int64, tsFrom, tsTo tim
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
 	type grpItem struct {
 		sportName string
 		sets      string
-		total     float64
+		total     storage.SportSetsTotal
+		duration  float64
+		cal       float64
 		comment   string
 	}
 	grpData := make(map[storage.Timestamp][]grpItem, len(dbRes))
-	graphData := make(map[string]map[storage.Timestamp]float64, len(dbRes))
-	totalData := make(map[string]float64, len(dbRes))
+	graphData := make(map[string]map[storage.Timestamp]storage.SportSetsTotal, len(dbRes))
+	totalData := make(map[string]storage.SportSetsTotal, len(dbRes))
+	totalCalData := make(map[string]float64, len(dbRes))
 
 	for _, d := range dbRes {
-		total := float64(0)
+		total := storage.NewSportSetsTotal(d.Sets)
 		sets := make([]string, 0, len(d.Sets))
 
 		for _, item := range d.Sets {
-			total += item
-			sets = append(sets, strconv.FormatFloat(item, 'g', -1, 64))
+			sets = append(sets, item.String())
 		}
 
 		grpData[d.Timestamp] = append(grpData[d.Timestamp], grpItem{
 			sportName: d.SportName,
 			sets:      strings.Join(sets, ","),
 			total:     total,
+			duration:  d.Duration,
+			cal:       d.Cal,
 			comment:   d.Comment,
 		})
 
+		// Sessions of the same day are summed up
 		_, ok := graphData[d.SportName]
 		if !ok {
-			graphData[d.SportName] = make(map[storage.Timestamp]float64)
+			graphData[d.SportName] = make(map[storage.Timestamp]storage.SportSetsTotal)
 		}
-		graphData[d.SportName][d.Timestamp] = total
+		day := dayKey(d.Timestamp, r.tz)
+		graphData[d.SportName][day] = graphData[d.SportName][day].Add(total)
 
-		totalData[d.SportName] = totalData[d.SportName] + total
+		totalData[d.SportName] = totalData[d.SportName].Add(total)
+		totalCalData[d.SportName] = totalCalData[d.SportName] + d.Cal
 	}
 
 	keys := make([]storage.Timestamp, 0, len(grpData))
@@ -260,12 +499,12 @@ func (r *CmdProcessor) sportActivityReportCommand(userID int64, tsFrom, tsTo tim
 	slices.Sort(keys)
 
 	// Build html
-	htmlBuilder := html.NewBuilder("Спортивная активность за период")
+	htmlBuilder := html.NewBuilder("Спортивная активность за период", r.assets)
 	tsFromStr, tsToStr := formatTimestamp(tsFrom), formatTimestamp(tsTo)
 	accordion := html.NewAccordion("accordionSA")
 
 	// Table
-	tbl := html.NewTable([]string{"Дата", "Спорт", "Подходы", "Итого", "Комментарий"})
+	tbl := html.NewTable([]string{"Дата", "Спорт", "Подходы", "Итого", "Длительность, мин", "ККал", "Комментарий"})
 
 	for _, key := range keys {
 		first := true
@@ -276,7 +515,7 @@ func (r *CmdProcessor) sportActivityReportCommand(userID int64, tsFrom, tsTo tim
 			if first {
 				tr.AddTd(
 					html.NewTd(
-						html.NewS(formatTimestamp(key.ToTime(r.tz))),
+						html.NewS(formatDateTime(key.ToTime(r.tz))),
 						html.Attrs{"rowspan": strconv.Itoa(len(rows))},
 					))
 				first = false
@@ -285,7 +524,9 @@ func (r *CmdProcessor) sportActivityReportCommand(userID int64, tsFrom, tsTo tim
 			tr.
 				AddTd(html.NewTd(html.NewS(row.sportName), nil)).
 				AddTd(html.NewTd(html.NewS(row.sets), nil)).
-				AddTd(html.NewTd(html.NewS(fmt.Sprintf("%.2f", row.total)), nil)).
+				AddTd(html.NewTd(html.NewS(formatSportSetsTotal(row.total)), nil)).
+				AddTd(html.NewTd(html.NewS(fmt.Sprintf("%.0f", row.duration)), nil)).
+				AddTd(html.NewTd(html.NewS(fmt.Sprintf("%.2f", row.cal)), nil)).
 				AddTd(html.NewTd(html.NewS(row.comment), nil))
 
 			tbl.AddRow(tr)
@@ -305,12 +546,13 @@ func (r *CmdProcessor) sportActivityReportCommand(userID int64, tsFrom, tsTo tim
 	slices.Sort(sportNames)
 
 	// Total table
-	tblTotal := html.NewTable([]string{"Спорт", "Итого"})
+	tblTotal := html.NewTable([]string{"Спорт", "Итого", "ККал"})
 	for _, sportName := range sportNames {
 		tblTotal.AddRow(html.
 			NewTr(nil).
 			AddTd(html.NewTd(html.NewS(sportName), nil)).
-			AddTd(html.NewTd(html.NewS(fmt.Sprintf("%.2f", totalData[sportName])), nil)))
+			AddTd(html.NewTd(html.NewS(formatSportSetsTotal(totalData[sportName])), nil)).
+			AddTd(html.NewTd(html.NewS(fmt.Sprintf("%.2f", totalCalData[sportName])), nil)))
 	}
 	accordion.AddItem(html.HewAccordionItem(
 		"tblTotal",
@@ -333,7 +575,7 @@ func (r *CmdProcessor) sportActivityReportCommand(userID int64, tsFrom, tsTo tim
 
 		for _, k := range sportTs {
 			xlabels = append(xlabels, formatTimestamp(k.ToTime(r.tz)))
-			data = append(data, float64(graphData[sportName][k]))
+			data = append(data, graphData[sportName][k].Main())
 		}
 
 		chartID := fmt.Sprintf("chart%d", i)
@@ -344,7 +586,7 @@ func (r *CmdProcessor) sportActivityReportCommand(userID int64, tsFrom, tsTo tim
 			chart,
 		))
 
-		snippet, err := GetChartSnippet(&ChartData{
+		snippet, err := html.NewChartScript(&ChartData{
 			PlotFunc: fmt.Sprintf("plot%d", i),
 			ElemID:   chartID,
 			XLabels:  xlabels,
@@ -364,9 +606,9 @@ func (r *CmdProcessor) sportActivityReportCommand(userID int64, tsFrom, tsTo tim
 				zap.Error(err),
 			)
 
-			return NewSingleCmdResponse(m.MsgErrInternal)
+			return NewErrCmdResponse(m.MsgErrInternal)
 		}
-		chartSnippets = append(chartSnippets, html.NewS(snippet))
+		chartSnippets = append(chartSnippets, snippet)
 	}
 
 	// Doc
@@ -377,8 +619,8 @@ func (r *CmdProcessor) sportActivityReportCommand(userID int64, tsFrom, tsTo tim
 			html.Attrs{"align": "center"},
 		),
 		accordion,
-		html.NewScript(_jsBootstrapURL),
-		html.NewScript(_jsChartURL),
+		r.assets.Script(html.AssetBootstrapJS),
+		r.assets.Script(html.AssetChartJS),
 		html.NewS(GetStartPlotSnippet()),
 	}
 	totalElements = append(totalElements, chartSnippets...)
@@ -389,9 +631,26 @@ func (r *CmdProcessor) sportActivityReportCommand(userID int64, tsFrom, tsTo tim
 		)
 
 	// Response
-	return NewSingleCmdResponse(r.typeAdapter.File(
-		bytes.NewBufferString(htmlBuilder.Build()),
-		"text/html",
-		fmt.Sprintf("sport_act_%s_%s.html", tsFromStr, tsToStr),
-	))
+	return r.reportResponse(userID, htmlBuilder, fmt.Sprintf("sport_act_%s_%s", tsFromStr, tsToStr), format)
+}
+
+func formatSportSetsTotal(t storage.SportSetsTotal) string {
+	var parts []string
+	if t.Value > 0 {
+		parts = append(parts, fmt.Sprintf("%.2f", t.Value))
+	}
+	if t.Reps > 0 {
+		parts = append(parts, fmt.Sprintf("Объем: %.0f повт.", t.Reps))
+	}
+	if t.Tonnage > 0 {
+		parts = append(parts, fmt.Sprintf("Тоннаж: %.2f", t.Tonnage))
+	}
+	if t.Distance > 0 {
+		parts = append(parts, fmt.Sprintf("Дистанция: %.2f", t.Distance))
+	}
+	if t.Duration > 0 {
+		parts = append(parts, fmt.Sprintf("Время: %s", storage.FormatSportSetDuration(t.Duration)))
+	}
+
+	return strings.Join(parts, ", ")
 }
@@ -0,0 +1,354 @@
+package cmdproc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/devldavydov/myhealth/internal/common/html"
+	m "github.com/devldavydov/myhealth/internal/common/messages"
+	"github.com/devldavydov/myhealth/internal/storage"
+	"go.uber.org/zap"
+)
+
+type sportRecord struct {
+	Value     float64
+	Timestamp storage.Timestamp
+	Desc      string
+}
+
+type sportRecords struct {
+	MaxSet     sportRecord
+	MaxDay     sportRecord
+	E1RM       sportRecord
+	StreakDays int
+	StreakFrom storage.Timestamp
+	StreakTo   storage.Timestamp
+}
+
+type sportDayProgress struct {
+	Timestamp storage.Timestamp
+	MaxSet    float64
+	Total     float64
+	E1RM      float64
+}
+
+func (r *CmdProcessor) sportRecordsCommand(userID int64, sportKey string, format storage.ReportFormat) []CmdResponse {
+	// Call storage
+	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
+	defer cancel()
+
+	sport, err := r.stg.GetSport(ctx, userID, sportKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrSportNotFound) {
+			return NewErrCmdResponse(m.MsgErrSportNotFound)
+		}
+
+		r.logger.Error(
+			"sport records command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	history, err := r.stg.GetSportActivityHistory(ctx, userID, sportKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrEmptyResult) {
+			return NewErrCmdResponse(m.MsgErrEmptyResult)
+		}
+
+		r.logger.Error(
+			"sport records command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	rec := calcSportRecords(sport.SetKind, history, r.tz)
+	progress := calcSportProgress(sport.SetKind, history, r.tz)
+
+	// Build html
+	htmlBuilder := html.NewBuilder("Рекорды спорта", r.assets)
+	accordion := html.NewAccordion("accordionPR")
+
+	// Records table
+	tbl := html.NewTable([]string{"Рекорд", "Значение", "Дата"})
+	addRecord := func(name string, rec sportRecord) {
+		if rec.Value == 0 {
+			return
+		}
+		tbl.AddRow(html.
+			NewTr(nil).
+			AddTd(html.NewTd(html.NewS(name), nil)).
+			AddTd(html.NewTd(html.NewS(rec.Desc), nil)).
+			AddTd(html.NewTd(html.NewS(formatTimestamp(rec.Timestamp.ToTime(r.tz))), nil)))
+	}
+	addRecord("Лучший подход", rec.MaxSet)
+	addRecord("Лучший день", rec.MaxDay)
+	addRecord("Расчетный 1ПМ", rec.E1RM)
+	tbl.AddRow(html.
+		NewTr(nil).
+		AddTd(html.NewTd(html.NewS("Серия дней подряд"), nil)).
+		AddTd(html.NewTd(html.NewS(fmt.Sprintf("%d", rec.StreakDays)), nil)).
+		AddTd(html.NewTd(html.NewS(fmt.Sprintf(
+			"%s - %s",
+			formatTimestamp(rec.StreakFrom.ToTime(r.tz)),
+			formatTimestamp(rec.StreakTo.ToTime(r.tz)),
+		)), nil)))
+	accordion.AddItem(html.HewAccordionItem(
+		"tblPR",
+		"Таблица рекордов",
+		tbl,
+	))
+
+	// Progress graph
+	xlabels := make([]string, 0, len(progress))
+	dataMaxSet := make([]float64, 0, len(progress))
+	dataTotal := make([]float64, 0, len(progress))
+	dataE1RM := make([]float64, 0, len(progress))
+	for _, p := range progress {
+		xlabels = append(xlabels, formatTimestamp(p.Timestamp.ToTime(r.tz)))
+		dataMaxSet = append(dataMaxSet, p.MaxSet)
+		dataTotal = append(dataTotal, p.Total)
+		dataE1RM = append(dataE1RM, p.E1RM)
+	}
+
+	datasets := []ChartDataset{
+		{
+			Data:  dataMaxSet,
+			Label: "Лучший подход",
+			Color: ChartColorBlue,
+		},
+	}
+	if sport.SetKind == storage.SportSetKindRepsLoad {
+		datasets = append(datasets, ChartDataset{
+			Data:  dataE1RM,
+			Label: "Расчетный 1ПМ",
+			Color: ChartColorRed,
+		})
+	}
+
+	chart := html.NewCanvas("chartMaxSet")
+	accordion.AddItem(html.HewAccordionItem(
+		"graphMaxSet",
+		"График лучшего подхода",
+		chart,
+	))
+	snippetMaxSet, err := html.NewChartScript(&ChartData{
+		PlotFunc: "plotMaxSet",
+		ElemID:   "chartMaxSet",
+		XLabels:  xlabels,
+		Type:     "line",
+		Datasets: datasets,
+	})
+	if err != nil {
+		r.logger.Error(
+			"sport records command error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	chartTotal := html.NewCanvas("chartTotal")
+	accordion.AddItem(html.HewAccordionItem(
+		"graphTotal",
+		"График итога дня",
+		chartTotal,
+	))
+	snippetTotal, err := html.NewChartScript(&ChartData{
+		PlotFunc: "plotTotal",
+		ElemID:   "chartTotal",
+		XLabels:  xlabels,
+		Type:     "line",
+		Datasets: []ChartDataset{
+			{
+				Data:  dataTotal,
+				Label: "Итого",
+				Color: ChartColorGreen,
+			},
+		},
+	})
+	if err != nil {
+		r.logger.Error(
+			"sport records command error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	// Doc
+	htmlBuilder.Add(
+		html.NewContainer().Add(
+			html.NewH(
+				fmt.Sprintf("Рекорды спорта: %s", sport.Name),
+				5,
+				html.Attrs{"align": "center"},
+			),
+			accordion,
+			r.assets.Script(html.AssetBootstrapJS),
+			r.assets.Script(html.AssetChartJS),
+			html.NewS(GetStartPlotSnippet()),
+			snippetMaxSet,
+			snippetTotal,
+			html.NewS(GetEndPlotSnippet()),
+		),
+	)
+
+	// Response
+	return r.reportResponse(userID, htmlBuilder, fmt.Sprintf("sport_pr_%s", sportKey), format)
+}
+
+// getSportActivityHistory returns sport history, empty history is not an error.
+func (r *CmdProcessor) getSportActivityHistory(ctx context.Context, userID int64, sportKey string) ([]storage.SportActivity, error) {
+	history, err := r.stg.GetSportActivityHistory(ctx, userID, sportKey)
+	if err != nil && !errors.Is(err, storage.ErrEmptyResult) {
+		return nil, err
+	}
+	return history, nil
+}
+
+// newSportRecordsMessage returns message about records set at day of timestamp,
+// comparing records before and after activity change.
+func newSportRecordsMessage(before, after sportRecords, ts storage.Timestamp, tz *time.Location) string {
+	day := formatTimestamp(ts.ToTime(tz))
+	isDay := func(t storage.Timestamp) bool {
+		return formatTimestamp(t.ToTime(tz)) == day
+	}
+
+	var sb strings.Builder
+	addRecord := func(name string, b, a sportRecord) {
+		if a.Value > b.Value && isDay(a.Timestamp) {
+			sb.WriteString(fmt.Sprintf("\n%s: %s", name, a.Desc))
+		}
+	}
+	addRecord("Лучший подход", before.MaxSet, after.MaxSet)
+	addRecord("Лучший день", before.MaxDay, after.MaxDay)
+	addRecord("Расчетный 1ПМ", before.E1RM, after.E1RM)
+	if after.StreakDays > before.StreakDays && isDay(after.StreakTo) {
+		sb.WriteString(fmt.Sprintf("\nСерия дней подряд: %d", after.StreakDays))
+	}
+
+	if sb.Len() == 0 {
+		return ""
+	}
+
+	return "<b>Новый рекорд!</b>" + sb.String()
+}
+
+// calcSportRecords returns records of sport history sorted by timestamp,
+// sessions of the same day are merged. Ties keep the earliest record.
+func calcSportRecords(kind storage.SportSetKind, history []storage.SportActivity, tz *time.Location) sportRecords {
+	var rec sportRecords
+
+	streak := 0
+	var streakFrom storage.Timestamp
+	var prevDay time.Time
+
+	for _, sa := range groupSportActivityByDay(history, tz) {
+		for _, s := range sa.Sets {
+			if v := sportSetScore(kind, s); v > rec.MaxSet.Value {
+				rec.MaxSet = sportRecord{Value: v, Timestamp: sa.Timestamp, Desc: s.String()}
+			}
+			if v := calcE1RM(s); v > rec.E1RM.Value {
+				rec.E1RM = sportRecord{Value: v, Timestamp: sa.Timestamp, Desc: fmt.Sprintf("%.1f (%s)", v, s.String())}
+			}
+		}
+
+		total := storage.NewSportSetsTotal(sa.Sets)
+		if v := total.Main(); v > rec.MaxDay.Value {
+			rec.MaxDay = sportRecord{Value: v, Timestamp: sa.Timestamp, Desc: formatSportSetsTotal(total)}
+		}
+
+		day := sa.Timestamp.ToTime(tz)
+		day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, tz)
+		switch {
+		case streak > 0 && day.Equal(prevDay.AddDate(0, 0, 1)):
+			streak++
+		default:
+			streak, streakFrom = 1, sa.Timestamp
+		}
+		prevDay = day
+
+		if streak > rec.StreakDays {
+			rec.StreakDays, rec.StreakFrom, rec.StreakTo = streak, streakFrom, sa.Timestamp
+		}
+	}
+
+	return rec
+}
+
+// calcSportProgress returns per day progress of sport history sorted by timestamp.
+func calcSportProgress(kind storage.SportSetKind, history []storage.SportActivity, tz *time.Location) []sportDayProgress {
+	res := make([]sportDayProgress, 0, len(history))
+	for _, sa := range groupSportActivityByDay(history, tz) {
+		p := sportDayProgress{
+			Timestamp: sa.Timestamp,
+			Total:     storage.NewSportSetsTotal(sa.Sets).Main(),
+		}
+		for _, s := range sa.Sets {
+			p.MaxSet = max(p.MaxSet, sportSetScore(kind, s))
+			p.E1RM = max(p.E1RM, calcE1RM(s))
+		}
+		res = append(res, p)
+	}
+
+	return res
+}
+
+// groupSportActivityByDay merges sets of sessions of the same day, history
+// is sorted by timestamp. Day gets timestamp of its first session.
+func groupSportActivityByDay(history []storage.SportActivity, tz *time.Location) []storage.SportActivity {
+	res := make([]storage.SportActivity, 0, len(history))
+	var prevDay string
+	for _, sa := range history {
+		day := formatTimestamp(sa.Timestamp.ToTime(tz))
+		if len(res) > 0 && day == prevDay {
+			last := &res[len(res)-1]
+			last.Sets = append(slices.Clone(last.Sets), sa.Sets...)
+			continue
+		}
+
+		res = append(res, storage.SportActivity{SportKey: sa.SportKey, Timestamp: sa.Timestamp, Sets: sa.Sets})
+		prevDay = day
+	}
+
+	return res
+}
+
+// sportSetScore returns the main value of single set for sport set kind.
+func sportSetScore(kind storage.SportSetKind, s storage.SportSet) float64 {
+	switch kind {
+	case storage.SportSetKindReps:
+		return s.Reps
+	case storage.SportSetKindRepsLoad:
+		return s.Load
+	case storage.SportSetKindDuration:
+		return s.Duration
+	case storage.SportSetKindDistance:
+		return s.Distance
+	default:
+		return s.Value
+	}
+}
+
+// calcE1RM returns estimated one-rep max by Epley formula.
+func calcE1RM(s storage.SportSet) float64 {
+	if s.Reps == 0 || s.Load == 0 {
+		return 0
+	}
+	if s.Reps == 1 {
+		return s.Load
+	}
+	return s.Load * (1 + s.Reps/30)
+}
@@ -0,0 +1,124 @@
+package cmdproc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/devldavydov/myhealth/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalcE1RM(t *testing.T) {
+	for _, tt := range []struct {
+		set  string
+		e1rm float64
+	}{
+		{set: "1x100", e1rm: 100},
+		{set: "5x80", e1rm: 93.333},
+		{set: "10x100", e1rm: 133.333},
+		{set: "30x50", e1rm: 100},
+		{set: "12", e1rm: 0},
+		{set: "5x27:30", e1rm: 0},
+	} {
+		t.Run(tt.set, func(t *testing.T) {
+			s, err := storage.ParseSportSet(tt.set)
+			require.NoError(t, err)
+			assert.InDelta(t, tt.e1rm, calcE1RM(s), 0.001)
+		})
+	}
+}
+
+func TestCalcSportRecords(t *testing.T) {
+	ts := func(day, hour int) storage.Timestamp {
+		return storage.NewTimestamp(time.Date(2025, 3, day, hour, 0, 0, 0, time.UTC))
+	}
+	activity := func(kind storage.SportSetKind, ts storage.Timestamp, args ...string) storage.SportActivity {
+		var sets []storage.SportSet
+		for _, arg := range args {
+			s, err := storage.ParseSportSet(arg)
+			require.NoError(t, err)
+			sets = append(sets, s)
+		}
+		sets, err := kind.Normalize(sets)
+		require.NoError(t, err)
+		return storage.SportActivity{SportKey: "sport", Timestamp: ts, Sets: sets}
+	}
+	repsLoad, reps, distance := storage.SportSetKindRepsLoad, storage.SportSetKindReps, storage.SportSetKindDistance
+
+	for _, tt := range []struct {
+		name    string
+		kind    storage.SportSetKind
+		history []storage.SportActivity
+		rec     sportRecords
+	}{
+		{
+			name: "reps and load with ties",
+			kind: repsLoad,
+			history: []storage.SportActivity{
+				activity(repsLoad, ts(1, 10), "5x100", "5x100"),
+				activity(repsLoad, ts(2, 8), "5x100"),
+				activity(repsLoad, ts(2, 19), "8x90"),
+				activity(repsLoad, ts(3, 10), "3x100"),
+				activity(repsLoad, ts(5, 10), "1x105"),
+			},
+			rec: sportRecords{
+				MaxSet:     sportRecord{Value: 105, Timestamp: ts(5, 10), Desc: "1x105"},
+				MaxDay:     sportRecord{Value: 1220, Timestamp: ts(2, 8), Desc: "Объем: 13 повт., Тоннаж: 1220.00"},
+				E1RM:       sportRecord{Value: 116.667, Timestamp: ts(1, 10), Desc: "116.7 (5x100)"},
+				StreakDays: 3,
+				StreakFrom: ts(1, 10),
+				StreakTo:   ts(3, 10),
+			},
+		},
+		{
+			name: "reps tie keeps earliest",
+			kind: reps,
+			history: []storage.SportActivity{
+				activity(reps, ts(1, 10), "20", "10"),
+				activity(reps, ts(4, 10), "20", "10"),
+				activity(reps, ts(5, 10), "15"),
+				activity(reps, ts(6, 10), "12"),
+			},
+			rec: sportRecords{
+				MaxSet:     sportRecord{Value: 20, Timestamp: ts(1, 10), Desc: "20"},
+				MaxDay:     sportRecord{Value: 30, Timestamp: ts(1, 10), Desc: "Объем: 30 повт."},
+				StreakDays: 3,
+				StreakFrom: ts(4, 10),
+				StreakTo:   ts(6, 10),
+			},
+		},
+		{
+			name: "distance",
+			kind: distance,
+			history: []storage.SportActivity{
+				activity(distance, ts(1, 10), "5x27:30"),
+				activity(distance, ts(3, 10), "3x15:00", "3x16:00"),
+			},
+			rec: sportRecords{
+				MaxSet:     sportRecord{Value: 5, Timestamp: ts(1, 10), Desc: "5x27:30"},
+				MaxDay:     sportRecord{Value: 6, Timestamp: ts(3, 10), Desc: "Дистанция: 6.00, Время: 31:00"},
+				StreakDays: 1,
+				StreakFrom: ts(1, 10),
+				StreakTo:   ts(1, 10),
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := calcSportRecords(tt.kind, tt.history, time.UTC)
+
+			for _, pair := range [][2]sportRecord{
+				{tt.rec.MaxSet, rec.MaxSet},
+				{tt.rec.MaxDay, rec.MaxDay},
+				{tt.rec.E1RM, rec.E1RM},
+			} {
+				assert.InDelta(t, pair[0].Value, pair[1].Value, 0.001)
+				assert.Equal(t, pair[0].Timestamp, pair[1].Timestamp)
+				assert.Equal(t, pair[0].Desc, pair[1].Desc)
+			}
+			assert.Equal(t, tt.rec.StreakDays, rec.StreakDays)
+			assert.Equal(t, tt.rec.StreakFrom, rec.StreakFrom)
+			assert.Equal(t, tt.rec.StreakTo, rec.StreakTo)
+		})
+	}
+}
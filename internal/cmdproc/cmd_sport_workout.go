@@ -0,0 +1,471 @@
+package cmdproc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/devldavydov/myhealth/internal/common/html"
+	m "github.com/devldavydov/myhealth/internal/common/messages"
+	"github.com/devldavydov/myhealth/internal/storage"
+	"go.uber.org/zap"
+)
+
+var _weekdays = []time.Weekday{
+	time.Monday,
+	time.Tuesday,
+	time.Wednesday,
+	time.Thursday,
+	time.Friday,
+	time.Saturday,
+	time.Sunday,
+}
+
+func (r *CmdProcessor) sportWorkoutSetCommand(
+	userID int64,
+	key, name string,
+	items []storage.WorkoutItem,
+	comment string,
+) []CmdResponse {
+	// Save in DB
+	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
+	defer cancel()
+
+	if err := r.stg.SetWorkout(ctx, userID, &storage.Workout{
+		Key:     key,
+		Name:    name,
+		Items:   items,
+		Comment: comment,
+	}); err != nil {
+		if errors.Is(err, storage.ErrWorkoutInvalid) {
+			return NewErrCmdResponse(m.MsgErrInvalidCommand)
+		}
+
+		if errors.Is(err, storage.ErrSportNotFound) {
+			return NewErrCmdResponse(m.MsgErrSportNotFound)
+		}
+
+		r.logger.Error(
+			"sport workout set command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	return NewSingleCmdResponse(m.MsgOK)
+}
+
+func (r *CmdProcessor) sportWorkoutSetTemplateCommand(userID int64, key string) []CmdResponse {
+	// Get from DB
+	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
+	defer cancel()
+
+	w, err := r.stg.GetWorkout(ctx, userID, key)
+	if err != nil {
+		if errors.Is(err, storage.ErrWorkoutNotFound) {
+			return NewErrCmdResponse(m.MsgErrWorkoutNotFound)
+		}
+
+		r.logger.Error(
+			"sport workout get command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	return NewSingleCmdResponse(fmt.Sprintf("s,wset,%s,%s,%s,%s", quoteArg(w.Key), quoteArg(w.Name), formatWorkoutItems(w.Items), quoteArg(w.Comment)))
+}
+
+func (r *CmdProcessor) sportWorkoutDelCommand(userID int64, key string) []CmdResponse {
+	// Call storage
+	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
+	defer cancel()
+
+	if err := r.stg.DeleteWorkout(ctx, userID, key); err != nil {
+		if errors.Is(err, storage.ErrWorkoutIsUsed) {
+			return NewErrCmdResponse(m.MsgErrWorkoutIsUsed)
+		}
+
+		r.logger.Error(
+			"sport workout del command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	return NewSingleCmdResponse(m.MsgOK)
+}
+
+func (r *CmdProcessor) sportWorkoutListCommand(userID int64, format storage.ReportFormat) []CmdResponse {
+	// Call storage
+	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
+	defer cancel()
+
+	workoutList, err := r.stg.GetWorkoutList(ctx, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrEmptyResult) {
+			return NewErrCmdResponse(m.MsgErrEmptyResult)
+		}
+
+		r.logger.Error(
+			"sport workout list command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	// Build html
+	htmlBuilder := html.NewBuilder("Список тренировок", r.assets)
+
+	// Table
+	tbl := html.NewTable([]string{"Ключ", "Наименование", "Упражнения", "Комментарий"})
+
+	for _, item := range workoutList {
+		tr := html.NewTr(nil)
+		tr.
+			AddTd(html.NewTd(html.NewS(item.Key), nil)).
+			AddTd(html.NewTd(html.NewS(item.Name), nil)).
+			AddTd(html.NewTd(html.NewS(formatWorkoutItems(item.Items)), nil)).
+			AddTd(html.NewTd(html.NewS(item.Comment), nil))
+		tbl.AddRow(tr)
+	}
+
+	// Doc
+	htmlBuilder.Add(
+		html.NewContainer().Add(
+			html.NewH(
+				"Список тренировок",
+				5,
+				html.Attrs{"align": "center"},
+			),
+			tbl))
+
+	// Response
+	return r.reportResponse(userID, htmlBuilder, "workout", format)
+}
+
+func (r *CmdProcessor) sportPlanSetCommand(userID int64, weekday time.Weekday, workoutKey string) []CmdResponse {
+	// Save in DB
+	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
+	defer cancel()
+
+	if err := r.stg.SetWorkoutPlanDay(ctx, userID, &storage.WorkoutPlanDay{
+		Weekday:    weekday,
+		WorkoutKey: workoutKey,
+	}); err != nil {
+		if errors.Is(err, storage.ErrWorkoutNotFound) {
+			return NewErrCmdResponse(m.MsgErrWorkoutNotFound)
+		}
+
+		r.logger.Error(
+			"sport plan set command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	return NewSingleCmdResponse(m.MsgOK)
+}
+
+func (r *CmdProcessor) sportPlanDelCommand(userID int64, weekday time.Weekday) []CmdResponse {
+	// Call storage
+	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
+	defer cancel()
+
+	if err := r.stg.DeleteWorkoutPlanDay(ctx, userID, weekday); err != nil {
+		r.logger.Error(
+			"sport plan del command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	return NewSingleCmdResponse(m.MsgOK)
+}
+
+func (r *CmdProcessor) sportPlanListCommand(userID int64) []CmdResponse {
+	// Call storage
+	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
+	defer cancel()
+
+	plan, workouts, err := r.getWorkoutPlan(ctx, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrEmptyResult) {
+			return NewErrCmdResponse(m.MsgErrEmptyResult)
+		}
+
+		r.logger.Error(
+			"sport plan list command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<b>План тренировок</b>\n")
+	for _, wd := range _weekdays {
+		key, ok := plan[wd]
+		if !ok {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("<b>• %s</b>: %s (%s)\n", formatWeekday(wd), workouts[key].Name, key))
+	}
+
+	return NewSingleCmdResponse(sb.String(), r.typeAdapter.OptsHTML())
+}
+
+func (r *CmdProcessor) sportTodayCommand(userID int64) []CmdResponse {
+	// Call storage
+	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
+	defer cancel()
+
+	plan, workouts, err := r.getWorkoutPlan(ctx, userID)
+	if err != nil && !errors.Is(err, storage.ErrEmptyResult) {
+		r.logger.Error(
+			"sport today command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	wd := timeNow().In(r.tz).Weekday()
+	key, ok := plan[wd]
+	if !ok {
+		return NewSingleCmdResponse(fmt.Sprintf("%s: тренировок по плану нет", formatWeekday(wd)))
+	}
+
+	w := workouts[key]
+	resp := NewSingleCmdResponse(fmt.Sprintf("<b>%s: %s</b>", formatWeekday(wd), w.Name), r.typeAdapter.OptsHTML())
+	for _, item := range w.Items {
+		resp = append(resp, NewCmdResponse(fmt.Sprintf("s,as,,%s,%s,", quoteArg(item.SportKey), formatSportSets(item.Sets))))
+	}
+
+	return resp
+}
+
+func (r *CmdProcessor) sportPlanComplianceCommand(userID int64, tsFrom, tsTo time.Time, format storage.ReportFormat) []CmdResponse {
+	// Call storage
+	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
+	defer cancel()
+
+	plan, workouts, err := r.getWorkoutPlan(ctx, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrEmptyResult) {
+			return NewErrCmdResponse(m.MsgErrEmptyResult)
+		}
+
+		r.logger.Error(
+			"sport plan compliance command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	sportList, err := r.stg.GetSportList(ctx, userID)
+	if err != nil {
+		r.logger.Error(
+			"sport plan compliance command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	sports := make(map[string]storage.Sport, len(sportList))
+	for _, sp := range sportList {
+		sports[sp.Key] = sp
+	}
+
+	dbRes, err := r.stg.GetSportActivityReport(ctx, userID, storage.NewTimestamp(storage.DayStart(tsFrom)), storage.NewTimestamp(storage.DayEnd(tsTo)))
+	if err != nil && !errors.Is(err, storage.ErrEmptyResult) {
+		r.logger.Error(
+			"sport plan compliance command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	// Actual totals by day and sport
+	actual := make(map[string]map[string]storage.SportSetsTotal)
+	for _, d := range dbRes {
+		day := formatTimestamp(d.Timestamp.ToTime(r.tz))
+		if _, ok := actual[day]; !ok {
+			actual[day] = make(map[string]storage.SportSetsTotal)
+		}
+		actual[day][d.SportKey] = actual[day][d.SportKey].Add(storage.NewSportSetsTotal(d.Sets))
+	}
+
+	// Build html
+	htmlBuilder := html.NewBuilder("Выполнение плана тренировок", r.assets)
+	tsFromStr, tsToStr := formatTimestamp(tsFrom), formatTimestamp(tsTo)
+
+	tbl := html.NewTable([]string{"Дата", "Тренировка", "Спорт", "План", "Факт", "Выполнение, %"})
+
+	var plannedDays, completedDays int
+	var totalCompliance float64
+
+	for t := storage.DayStart(tsFrom); !t.After(tsTo); t = t.AddDate(0, 0, 1) {
+		key, ok := plan[t.Weekday()]
+		if !ok {
+			continue
+		}
+
+		w := workouts[key]
+		day := formatTimestamp(t)
+		dayCompliance := 0.0
+
+		for i, item := range w.Items {
+			planned := storage.NewSportSetsTotal(item.Sets)
+			fact := actual[day][item.SportKey]
+			compliance := calcWorkoutItemCompliance(planned, fact)
+			dayCompliance += compliance / float64(len(w.Items))
+
+			tr := html.NewTr(nil)
+			if i == 0 {
+				rowspan := html.Attrs{"rowspan": fmt.Sprintf("%d", len(w.Items))}
+				tr.
+					AddTd(html.NewTd(html.NewS(day), rowspan)).
+					AddTd(html.NewTd(html.NewS(w.Name), rowspan))
+			}
+
+			sportName := item.SportKey
+			if sp, ok := sports[item.SportKey]; ok {
+				sportName = fmt.Sprintf("%s [%s]", sp.Name, sp.Unit)
+			}
+
+			tr.
+				AddTd(html.NewTd(html.NewS(sportName), nil)).
+				AddTd(html.NewTd(html.NewS(formatSportSetsTotal(planned)), nil)).
+				AddTd(html.NewTd(html.NewS(formatSportSetsTotal(fact)), nil)).
+				AddTd(html.NewTd(html.NewS(fmt.Sprintf("%.0f", compliance*100)), nil))
+			tbl.AddRow(tr)
+		}
+
+		plannedDays++
+		totalCompliance += dayCompliance
+		if dayCompliance >= 1 {
+			completedDays++
+		}
+	}
+
+	if plannedDays == 0 {
+		return NewErrCmdResponse(m.MsgErrEmptyResult)
+	}
+
+	// Doc
+	htmlBuilder.Add(
+		html.NewContainer().Add(
+			html.NewH(
+				fmt.Sprintf("Выполнение плана тренировок за %s - %s", tsFromStr, tsToStr),
+				5,
+				html.Attrs{"align": "center"},
+			),
+			html.NewH(
+				fmt.Sprintf(
+					"Выполнение: %.0f%%, тренировок выполнено полностью: %d из %d",
+					totalCompliance/float64(plannedDays)*100,
+					completedDays,
+					plannedDays,
+				),
+				6,
+				html.Attrs{"align": "center"},
+			),
+			tbl))
+
+	// Response
+	return r.reportResponse(userID, htmlBuilder, fmt.Sprintf("sport_plan_%s_%s", tsFromStr, tsToStr), format)
+}
+
+// getWorkoutPlan returns workout keys by weekday and workouts by key.
+func (r *CmdProcessor) getWorkoutPlan(
+	ctx context.Context,
+	userID int64,
+) (map[time.Weekday]string, map[string]storage.Workout, error) {
+	planDays, err := r.stg.GetWorkoutPlan(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	workoutList, err := r.stg.GetWorkoutList(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	workouts := make(map[string]storage.Workout, len(workoutList))
+	for _, w := range workoutList {
+		workouts[w.Key] = w
+	}
+
+	plan := make(map[time.Weekday]string, len(planDays))
+	for _, pd := range planDays {
+		plan[pd.Weekday] = pd.WorkoutKey
+	}
+
+	return plan, workouts, nil
+}
+
+// calcWorkoutItemCompliance returns share of planned total done, capped by 1.
+func calcWorkoutItemCompliance(planned, fact storage.SportSetsTotal) float64 {
+	if planned.Main() == 0 {
+		return 0
+	}
+	return min(fact.Main()/planned.Main(), 1)
+}
+
+func formatWorkoutItems(items []storage.WorkoutItem) string {
+	parts := make([]string, 0, len(items))
+	for _, item := range items {
+		parts = append(parts, fmt.Sprintf("%s=%s", item.SportKey, formatSportSets(item.Sets)))
+	}
+	return strings.Join(parts, ";")
+}
+
+func formatSportSets(sets []storage.SportSet) string {
+	parts := make([]string, 0, len(sets))
+	for _, s := range sets {
+		parts = append(parts, s.String())
+	}
+	return strings.Join(parts, "/")
+}
+
+func formatWeekday(wd time.Weekday) string {
+	switch wd {
+	case time.Monday:
+		return "Понедельник"
+	case time.Tuesday:
+		return "Вторник"
+	case time.Wednesday:
+		return "Среда"
+	case time.Thursday:
+		return "Четверг"
+	case time.Friday:
+		return "Пятница"
+	case time.Saturday:
+		return "Суббота"
+	default:
+		return "Воскресенье"
+	}
+}
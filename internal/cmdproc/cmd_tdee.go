@@ -0,0 +1,401 @@
+package cmdproc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/devldavydov/myhealth/internal/common/html"
+	m "github.com/devldavydov/myhealth/internal/common/messages"
+	"github.com/devldavydov/myhealth/internal/storage"
+	"go.uber.org/zap"
+)
+
+const (
+	_tdeeMinIntakeDays   = 7
+	_tdeeMinWeightPoints = 3
+	_tdeeConfZ           = 1.96
+	_tdeeAutoWeeks       = 4
+	_tdeeAutoPeriod      = 7 * 24 * time.Hour
+)
+
+var errTDEENotEnoughData = errors.New("not enough data for tdee estimate")
+
+func (r *CmdProcessor) calcCalTDEECommand(userID int64, ts time.Time, weeks int) []CmdResponse {
+	return r.calcCalTDEE(userID, ts, weeks, false)
+}
+
+func (r *CmdProcessor) calcCalTDEEApplyCommand(userID int64, ts time.Time, weeks int) []CmdResponse {
+	return r.calcCalTDEE(userID, ts, weeks, true)
+}
+
+func (r *CmdProcessor) calcCalTDEE(userID int64, ts time.Time, weeks int, apply bool) []CmdResponse {
+	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
+	defer cancel()
+
+	est, err := r.estimateTDEE(ctx, userID, ts, weeks)
+	if err != nil {
+		if errors.Is(err, errTDEENotEnoughData) {
+			return NewErrCmdResponse(m.MsgErrNotEnoughData)
+		}
+
+		r.logger.Error(
+			"calc cal tdee command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	if apply {
+		err = r.applyTDEEEstimate(ctx, userID, est)
+	} else {
+		err = r.saveTDEEEstimate(ctx, userID, est)
+	}
+	if err != nil {
+		r.logger.Error(
+			"calc cal tdee command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("<b>Оценка TDEE за %d нед. до %s</b>\n", weeks, formatTimestamp(ts)))
+	sb.WriteString(fmt.Sprintf("ККал: %.0f\n", est.TDEE))
+	sb.WriteString(fmt.Sprintf("Доверительный интервал 95%%: %.0f - %.0f\n", est.ConfLow, est.ConfHigh))
+	if apply {
+		sb.WriteString(fmt.Sprintf("\nЛимит калорий установлен: %.0f\n", math.Round(est.TDEE)))
+	}
+
+	return NewSingleCmdResponse(sb.String(), r.typeAdapter.OptsHTML())
+}
+
+func (r *CmdProcessor) calcCalTDEEHistoryCommand(userID int64, tsFrom, tsTo time.Time, format storage.ReportFormat) []CmdResponse {
+	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
+	defer cancel()
+
+	lst, err := r.stg.GetTDEEEstimateList(ctx, userID, storage.NewTimestamp(storage.DayStart(tsFrom)), storage.NewTimestamp(storage.DayEnd(tsTo)))
+	if err != nil {
+		if errors.Is(err, storage.ErrEmptyResult) {
+			return NewErrCmdResponse(m.MsgErrEmptyResult)
+		}
+
+		r.logger.Error(
+			"calc cal tdee history command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	// Build html
+	tsFromStr, tsToStr := formatTimestamp(tsFrom), formatTimestamp(tsTo)
+	htmlBuilder := html.NewBuilder("История оценок TDEE", r.assets)
+	accordion := html.NewAccordion("accordionTDEE")
+
+	tbl := html.NewTable([]string{"Дата", "Недель", "TDEE", "Интервал 95%", "Применено"})
+	xlabels := make([]string, 0, len(lst))
+	tdeeData := make([]float64, 0, len(lst))
+	lowData := make([]float64, 0, len(lst))
+	highData := make([]float64, 0, len(lst))
+	for _, est := range lst {
+		applied := "Нет"
+		if est.Applied {
+			applied = "Да"
+		}
+
+		tsStr := formatTimestamp(est.Timestamp.ToTime(r.tz))
+		tbl.AddRow(html.NewTr(nil).
+			AddTd(html.NewTd(html.NewS(tsStr), nil)).
+			AddTd(html.NewTd(html.NewS(fmt.Sprintf("%d", est.Weeks)), nil)).
+			AddTd(html.NewTd(html.NewS(fmt.Sprintf("%.0f", est.TDEE)), nil)).
+			AddTd(html.NewTd(html.NewS(fmt.Sprintf("%.0f - %.0f", est.ConfLow, est.ConfHigh)), nil)).
+			AddTd(html.NewTd(html.NewS(applied), nil)))
+
+		xlabels = append(xlabels, tsStr)
+		tdeeData = append(tdeeData, math.Round(est.TDEE))
+		lowData = append(lowData, math.Round(est.ConfLow))
+		highData = append(highData, math.Round(est.ConfHigh))
+	}
+
+	accordion.AddItem(html.HewAccordionItem(
+		"tbl",
+		fmt.Sprintf("Таблица оценок за %s - %s", tsFromStr, tsToStr),
+		tbl,
+	))
+
+	accordion.AddItem(html.HewAccordionItem(
+		"graph",
+		fmt.Sprintf("График оценок за %s - %s", tsFromStr, tsToStr),
+		html.NewCanvas("chart"),
+	))
+
+	chartSnip, err := html.NewChartScript(&ChartData{
+		PlotFunc: "plot",
+		ElemID:   "chart",
+		XLabels:  xlabels,
+		Type:     "line",
+		Datasets: []ChartDataset{
+			{Data: tdeeData, Label: "TDEE", Color: ChartColorBlue},
+			{Data: lowData, Label: "Нижняя граница", Color: ChartColorGrey},
+			{Data: highData, Label: "Верхняя граница", Color: ChartColorGrey},
+		},
+	})
+	if err != nil {
+		r.logger.Error(
+			"calc cal tdee history command chart error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	// Doc
+	htmlBuilder.Add(
+		html.NewContainer().Add(
+			accordion,
+		),
+		r.assets.Script(html.AssetBootstrapJS),
+		r.assets.Script(html.AssetChartJS),
+		html.NewS(GetStartPlotSnippet()),
+		chartSnip,
+		html.NewS(GetEndPlotSnippet()),
+	)
+
+	// Response
+	return r.reportResponse(userID, htmlBuilder, fmt.Sprintf("tdee_%s_%s", tsFromStr, tsToStr), format)
+}
+
+func (r *CmdProcessor) calcCalTDEEAutoCommand(userID int64, enabled bool) []CmdResponse {
+	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
+	defer cancel()
+
+	us, err := r.stg.GetUserSettings(ctx, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrUserSettingsNotFound) {
+			return NewErrCmdResponse(m.MsgErrUserSettingsNotFound)
+		}
+
+		r.logger.Error(
+			"calc cal tdee auto command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	us.TDEEAutoUpdate = enabled
+	if err := r.stg.SetUserSettings(ctx, userID, us); err != nil {
+		r.logger.Error(
+			"calc cal tdee auto command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	return NewSingleCmdResponse(m.MsgOK)
+}
+
+// TDEEAutoUpdate estimates TDEE and applies it to user calorie limit,
+// if user enabled auto update and last estimate is older than a week.
+func (r *CmdProcessor) TDEEAutoUpdate(userID int64) {
+	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
+	defer cancel()
+
+	us, err := r.stg.GetUserSettings(ctx, userID)
+	if err != nil {
+		if !errors.Is(err, storage.ErrUserSettingsNotFound) {
+			r.logger.Error("tdee auto update DB error", zap.Int64("userID", userID), zap.Error(err))
+		}
+		return
+	}
+
+	if !us.TDEEAutoUpdate {
+		return
+	}
+
+	now := timeNow().In(r.tz)
+	ts := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, r.tz)
+
+	last, err := r.stg.GetLastTDEEEstimate(ctx, userID)
+	switch {
+	case err == nil:
+		if ts.Sub(last.Timestamp.ToTime(r.tz)) < _tdeeAutoPeriod {
+			return
+		}
+	case !errors.Is(err, storage.ErrTDEEEstimateNotFound):
+		r.logger.Error("tdee auto update DB error", zap.Int64("userID", userID), zap.Error(err))
+		return
+	}
+
+	est, err := r.estimateTDEE(ctx, userID, ts, _tdeeAutoWeeks)
+	if err != nil {
+		if errors.Is(err, errTDEENotEnoughData) {
+			r.logger.Info("tdee auto update skipped: not enough data", zap.Int64("userID", userID))
+		} else {
+			r.logger.Error("tdee auto update DB error", zap.Int64("userID", userID), zap.Error(err))
+		}
+		return
+	}
+
+	if err := r.applyTDEEEstimate(ctx, userID, est); err != nil {
+		r.logger.Error("tdee auto update DB error", zap.Int64("userID", userID), zap.Error(err))
+		return
+	}
+
+	r.logger.Info("tdee auto update applied", zap.Int64("userID", userID), zap.Float64("tdee", est.TDEE))
+}
+
+func (r *CmdProcessor) estimateTDEE(ctx context.Context, userID int64, ts time.Time, weeks int) (*storage.TDEEEstimate, error) {
+	ts = storage.DayStart(ts)
+	tsFrom := ts.AddDate(0, 0, -7*weeks+1)
+
+	jrnl, err := r.stg.GetJournalReport(ctx, userID, storage.NewTimestamp(tsFrom), storage.NewTimestamp(storage.DayEnd(ts)))
+	if err != nil {
+		if errors.Is(err, storage.ErrEmptyResult) {
+			return nil, errTDEENotEnoughData
+		}
+		return nil, err
+	}
+
+	weights, err := r.stg.GetWeightList(ctx, userID, storage.NewTimestamp(tsFrom), storage.NewTimestamp(storage.DayEnd(ts)), false)
+	if err != nil {
+		if errors.Is(err, storage.ErrEmptyResult) {
+			return nil, errTDEENotEnoughData
+		}
+		return nil, err
+	}
+
+	intakeData := make(map[storage.Timestamp]float64)
+	for _, j := range jrnl {
+		intakeData[dayKey(j.Timestamp, r.tz)] += j.Cal
+	}
+	intake := make([]float64, 0, len(intakeData))
+	for _, v := range intakeData {
+		intake = append(intake, v)
+	}
+
+	days := make([]float64, 0, len(weights))
+	values := make([]float64, 0, len(weights))
+	for _, w := range weights {
+		days = append(days, w.Timestamp.ToTime(r.tz).Sub(tsFrom).Hours()/24)
+		values = append(values, w.Value)
+	}
+
+	tdee, confDelta, err := calcTDEE(intake, days, values)
+	if err != nil {
+		return nil, err
+	}
+
+	return &storage.TDEEEstimate{
+		Timestamp: storage.NewTimestamp(ts),
+		Weeks:     int64(weeks),
+		TDEE:      tdee,
+		ConfLow:   max(tdee-confDelta, 0),
+		ConfHigh:  tdee + confDelta,
+	}, nil
+}
+
+func (r *CmdProcessor) applyTDEEEstimate(ctx context.Context, userID int64, est *storage.TDEEEstimate) error {
+	us, err := r.stg.GetUserSettings(ctx, userID)
+	if err != nil {
+		if !errors.Is(err, storage.ErrUserSettingsNotFound) {
+			return err
+		}
+		us = &storage.UserSettings{}
+	}
+
+	us.CalLimit = math.Round(est.TDEE)
+	if err := r.stg.SetUserSettings(ctx, userID, us); err != nil {
+		return err
+	}
+
+	est.Applied = true
+	return r.stg.SetTDEEEstimate(ctx, userID, est)
+}
+
+// saveTDEEEstimate saves estimate which is not applied, applied estimate
+// of the same day is kept to not lose applied flag.
+func (r *CmdProcessor) saveTDEEEstimate(ctx context.Context, userID int64, est *storage.TDEEEstimate) error {
+	lst, err := r.stg.GetTDEEEstimateList(ctx, userID, est.Timestamp, est.Timestamp)
+	if err != nil && !errors.Is(err, storage.ErrEmptyResult) {
+		return err
+	}
+
+	if len(lst) > 0 && lst[0].Applied {
+		return nil
+	}
+
+	return r.stg.SetTDEEEstimate(ctx, userID, est)
+}
+
+// calcTDEE estimates daily energy expenditure from energy balance:
+// mean intake minus weight trend (linear regression slope, kg/day) in kcal.
+// Returns estimate and half-width of its 95% confidence interval.
+func calcTDEE(intake []float64, days, weights []float64) (float64, float64, error) {
+	if len(intake) < _tdeeMinIntakeDays || len(weights) < _tdeeMinWeightPoints {
+		return 0, 0, errTDEENotEnoughData
+	}
+
+	// Intake mean and its standard error
+	n := float64(len(intake))
+	var intakeMean float64
+	for _, v := range intake {
+		intakeMean += v
+	}
+	intakeMean /= n
+
+	var intakeVar float64
+	for _, v := range intake {
+		intakeVar += (v - intakeMean) * (v - intakeMean)
+	}
+	intakeVar /= n - 1
+	intakeSE := math.Sqrt(intakeVar / n)
+
+	// Weight slope and its standard error
+	k := float64(len(weights))
+	var dayMean, weightMean float64
+	for i := range weights {
+		dayMean += days[i]
+		weightMean += weights[i]
+	}
+	dayMean /= k
+	weightMean /= k
+
+	var sxx, sxy float64
+	for i := range weights {
+		sxx += (days[i] - dayMean) * (days[i] - dayMean)
+		sxy += (days[i] - dayMean) * (weights[i] - weightMean)
+	}
+	if sxx == 0 {
+		return 0, 0, errTDEENotEnoughData
+	}
+	slope := sxy / sxx
+
+	var sse float64
+	for i := range weights {
+		res := weights[i] - (weightMean + slope*(days[i]-dayMean))
+		sse += res * res
+	}
+	slopeSE := math.Sqrt(sse / (k - 2) / sxx)
+
+	tdee := intakeMean - slope*_calPerKg
+	se := math.Sqrt(intakeSE*intakeSE + (slopeSE*_calPerKg)*(slopeSE*_calPerKg))
+	if tdee <= 0 {
+		return 0, 0, errTDEENotEnoughData
+	}
+
+	return tdee, _tdeeConfZ * se, nil
+}
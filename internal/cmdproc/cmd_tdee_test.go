@@ -0,0 +1,108 @@
+package cmdproc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/devldavydov/myhealth/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalcTDEE(t *testing.T) {
+	week := func(v ...float64) []float64 { return v }
+
+	for _, tt := range []struct {
+		name      string
+		intake    []float64
+		days      []float64
+		weights   []float64
+		tdee      float64
+		confDelta float64
+		err       error
+	}{
+		{
+			name:    "exact loss",
+			intake:  week(2000, 2000, 2000, 2000, 2000, 2000, 2000),
+			days:    []float64{0, 7, 14},
+			weights: []float64{80, 79.3, 78.6},
+			tdee:    2770,
+		},
+		{
+			name:    "exact gain",
+			intake:  week(3000, 3000, 3000, 3000, 3000, 3000, 3000),
+			days:    []float64{0, 7, 14},
+			weights: []float64{80, 80.7, 81.4},
+			tdee:    2230,
+		},
+		{
+			name:      "noisy data",
+			intake:    week(2100, 1900, 2000, 2300, 1700, 2050, 1950),
+			days:      []float64{0, 3, 7, 10, 14},
+			weights:   []float64{80, 79.9, 79.5, 79.4, 79.1},
+			tdee:      2509.153,
+			confDelta: 159.993,
+		},
+		{
+			name:      "flat weight",
+			intake:    week(1800, 2200, 1800, 2200, 1800, 2200, 2000),
+			days:      []float64{0, 7, 14},
+			weights:   []float64{80, 80, 80},
+			tdee:      2000,
+			confDelta: 148.162,
+		},
+		{
+			name:    "too few intake days",
+			intake:  week(2000, 2000, 2000, 2000, 2000, 2000),
+			days:    []float64{0, 7, 14},
+			weights: []float64{80, 79.3, 78.6},
+			err:     errTDEENotEnoughData,
+		},
+		{
+			name:    "too few weights",
+			intake:  week(2000, 2000, 2000, 2000, 2000, 2000, 2000),
+			days:    []float64{0, 14},
+			weights: []float64{80, 78.6},
+			err:     errTDEENotEnoughData,
+		},
+		{
+			name:    "weights of one day",
+			intake:  week(2000, 2000, 2000, 2000, 2000, 2000, 2000),
+			days:    []float64{7, 7, 7},
+			weights: []float64{80, 79.3, 78.6},
+			err:     errTDEENotEnoughData,
+		},
+		{
+			name:    "non positive estimate",
+			intake:  week(500, 500, 500, 500, 500, 500, 500),
+			days:    []float64{0, 7, 14},
+			weights: []float64{80, 81, 82},
+			err:     errTDEENotEnoughData,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			tdee, confDelta, err := calcTDEE(tt.intake, tt.days, tt.weights)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.InDelta(t, tt.tdee, tdee, 0.001)
+			assert.InDelta(t, tt.confDelta, confDelta, 0.001)
+		})
+	}
+}
+
+func TestCalcCalTDEEKeepsApplied(t *testing.T) {
+	r := newTestCmdProcessor(t, _goldenFixture)
+	ts := _goldenNow
+
+	require.False(t, isErrResponse(r.calcCalTDEEApplyCommand(1, ts, 2)))
+	require.False(t, isErrResponse(r.calcCalTDEECommand(1, ts, 2)))
+
+	lst, err := r.stg.GetTDEEEstimateList(context.Background(), 1, storage.NewTimestamp(storage.DayStart(ts)), storage.NewTimestamp(storage.DayStart(ts)))
+	require.NoError(t, err)
+	require.Len(t, lst, 1)
+	assert.True(t, lst[0].Applied)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	m "github.com/devldavydov/myhealth/internal/common/messages"
 	"github.com/devldavydov/myhealth/internal/storage"
@@ -15,11 +17,24 @@ func (r *CmdProcessor) userSettingsSetCommand(userID int64, calLimit float64) []
 	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
 	defer cancel()
 
-	if err := r.stg.SetUserSettings(ctx, userID, &storage.UserSettings{
-		CalLimit: calLimit,
-	}); err != nil {
+	us, err := r.stg.GetUserSettings(ctx, userID)
+	if err != nil {
+		if !errors.Is(err, storage.ErrUserSettingsNotFound) {
+			r.logger.Error(
+				"user settings set command DB error",
+				zap.Int64("userID", userID),
+				zap.Error(err),
+			)
+
+			return NewErrCmdResponse(m.MsgErrInternal)
+		}
+		us = &storage.UserSettings{}
+	}
+
+	us.CalLimit = calLimit
+	if err := r.stg.SetUserSettings(ctx, userID, us); err != nil {
 		if errors.Is(err, storage.ErrUserSettingsInvalid) {
-			return NewSingleCmdResponse(m.MsgErrInvalidCommand)
+			return NewErrCmdResponse(m.MsgErrInvalidCommand)
 		}
 
 		r.logger.Error(
@@ -28,7 +43,7 @@ func (r *CmdProcessor) userSettingsSetCommand(userID int64, calLimit float64) []
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
 	return NewSingleCmdResponse(m.MsgOK)
@@ -42,7 +57,7 @@ func (r *CmdProcessor) userSettingsGetCommand(userID int64) []CmdResponse {
 	us, err := r.stg.GetUserSettings(ctx, userID)
 	if err != nil {
 		if errors.Is(err, storage.ErrUserSettingsNotFound) {
-			return NewSingleCmdResponse(m.MsgErrUserSettingsNotFound)
+			return NewErrCmdResponse(m.MsgErrUserSettingsNotFound)
 		}
 
 		r.logger.Error(
@@ -51,10 +66,45 @@ func (r *CmdProcessor) userSettingsGetCommand(userID int64) []CmdResponse {
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	tdeeAuto := "Выключено"
+	if us.TDEEAutoUpdate {
+		tdeeAuto = "Включено"
 	}
 
-	return NewSingleCmdResponse(fmt.Sprintf("<b>Лимит калорий:</b> %.2f", us.CalLimit), r.typeAdapter.OptsHTML())
+	waterReminder := "Выключены"
+	if us.WaterReminder {
+		waterReminder = "Включены"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("<b>Лимит калорий:</b> %.2f\n", us.CalLimit))
+	sb.WriteString(fmt.Sprintf("<b>Автообновление TDEE:</b> %s", tdeeAuto))
+	if us.WaterGoal > 0 {
+		sb.WriteString(fmt.Sprintf("\n<b>Цель воды, мл:</b> %.0f\n", us.WaterGoal))
+		sb.WriteString(fmt.Sprintf("<b>Напоминания о воде:</b> %s", waterReminder))
+	}
+	if len(us.NutrientLimits) > 0 {
+		sb.WriteString(fmt.Sprintf("\n<b>Лимиты нутриентов:</b> %s", formatNutrients(us.NutrientLimits)))
+	}
+	if us.ReportFormat != "" {
+		sb.WriteString(fmt.Sprintf("\n<b>Формат отчетов:</b> %s", us.ReportFormat))
+	}
+	if p := us.Profile; p != nil {
+		sb.WriteString("\n\n<b>Профиль</b>\n")
+		sb.WriteString(fmt.Sprintf("<b>Пол:</b> %s\n", p.Sex))
+		sb.WriteString(fmt.Sprintf("<b>Дата рождения:</b> %s\n", formatTimestamp(p.BirthDate.ToTime(r.tz))))
+		sb.WriteString(fmt.Sprintf("<b>Рост:</b> %.0f\n", p.Height))
+		sb.WriteString(fmt.Sprintf("<b>Активность:</b> %s\n", p.ActivityLevel.MustToString()))
+		if p.BodyFat > 0 {
+			sb.WriteString(fmt.Sprintf("<b>Процент жира:</b> %.1f\n", p.BodyFat))
+		}
+		sb.WriteString(fmt.Sprintf("<b>Цель:</b> %s (%.2f кг/нед)", p.Goal.MustToString(), p.GoalRate))
+	}
+
+	return NewSingleCmdResponse(sb.String(), r.typeAdapter.OptsHTML())
 }
 
 func (r *CmdProcessor) userSettingsSetTemplateCommand(userID int64) []CmdResponse {
@@ -65,7 +115,7 @@ func (r *CmdProcessor) userSettingsSetTemplateCommand(userID int64) []CmdRespons
 	us, err := r.stg.GetUserSettings(ctx, userID)
 	if err != nil {
 		if errors.Is(err, storage.ErrUserSettingsNotFound) {
-			return NewSingleCmdResponse(m.MsgErrUserSettingsNotFound)
+			return NewErrCmdResponse(m.MsgErrUserSettingsNotFound)
 		}
 
 		r.logger.Error(
@@ -74,8 +124,199 @@ func (r *CmdProcessor) userSettingsSetTemplateCommand(userID int64) []CmdRespons
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	resp := NewSingleCmdResponse(fmt.Sprintf("u,set,%.2f", us.CalLimit))
+	if us.WaterGoal > 0 {
+		resp = append(resp, NewCmdResponse(fmt.Sprintf(
+			"u,water,%.0f,%s",
+			us.WaterGoal,
+			formatBool(us.WaterReminder),
+		)))
+	}
+	if len(us.NutrientLimits) > 0 {
+		resp = append(resp, NewCmdResponse(fmt.Sprintf("u,nut,%s", us.NutrientLimits.String())))
+	}
+	if p := us.Profile; p != nil {
+		resp = append(resp, NewCmdResponse(fmt.Sprintf(
+			"u,prof,%s,%s,%.0f,%d,%.1f,%s,%.2f",
+			p.Sex,
+			formatTimestamp(p.BirthDate.ToTime(r.tz)),
+			p.Height,
+			p.ActivityLevel,
+			p.BodyFat,
+			p.Goal,
+			p.GoalRate,
+		)))
+	}
+
+	return resp
+}
+
+func (r *CmdProcessor) userProfileSetCommand(
+	userID int64,
+	gender string,
+	birthDate time.Time,
+	height float64,
+	activityLevel storage.ActivityLevel,
+	bodyFat float64,
+	goal storage.Goal,
+	goalRate float64,
+) []CmdResponse {
+	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
+	defer cancel()
+
+	us, err := r.stg.GetUserSettings(ctx, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrUserSettingsNotFound) {
+			return NewErrCmdResponse(m.MsgErrUserSettingsNotFound)
+		}
+
+		r.logger.Error(
+			"user profile set command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	us.Profile = &storage.UserProfile{
+		Sex:           storage.Sex(gender),
+		BirthDate:     storage.NewTimestamp(storage.DayStart(birthDate)),
+		Height:        height,
+		ActivityLevel: activityLevel,
+		BodyFat:       bodyFat,
+		Goal:          goal,
+		GoalRate:      goalRate,
+	}
+
+	if err := r.stg.SetUserSettings(ctx, userID, us); err != nil {
+		if errors.Is(err, storage.ErrUserSettingsInvalid) {
+			return NewErrCmdResponse(m.MsgErrInvalidCommand)
+		}
+
+		r.logger.Error(
+			"user profile set command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	return NewSingleCmdResponse(m.MsgOK)
+}
+
+func (r *CmdProcessor) userWaterGoalSetCommand(userID int64, waterGoal float64, reminder bool) []CmdResponse {
+	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
+	defer cancel()
+
+	us, err := r.stg.GetUserSettings(ctx, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrUserSettingsNotFound) {
+			return NewErrCmdResponse(m.MsgErrUserSettingsNotFound)
+		}
+
+		r.logger.Error(
+			"user water goal set command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
-	return NewSingleCmdResponse(fmt.Sprintf("u,set,%.2f", us.CalLimit))
+	us.WaterGoal = waterGoal
+	us.WaterReminder = reminder
+	if err := r.stg.SetUserSettings(ctx, userID, us); err != nil {
+		if errors.Is(err, storage.ErrUserSettingsInvalid) {
+			return NewErrCmdResponse(m.MsgErrInvalidCommand)
+		}
+
+		r.logger.Error(
+			"user water goal set command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	return NewSingleCmdResponse(m.MsgOK)
+}
+
+func (r *CmdProcessor) userReportFormatSetCommand(userID int64, format storage.ReportFormat) []CmdResponse {
+	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
+	defer cancel()
+
+	us, err := r.stg.GetUserSettings(ctx, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrUserSettingsNotFound) {
+			return NewErrCmdResponse(m.MsgErrUserSettingsNotFound)
+		}
+
+		r.logger.Error(
+			"user report format set command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	us.ReportFormat = format
+	if err := r.stg.SetUserSettings(ctx, userID, us); err != nil {
+		if errors.Is(err, storage.ErrUserSettingsInvalid) {
+			return NewErrCmdResponse(m.MsgErrInvalidCommand)
+		}
+
+		r.logger.Error(
+			"user report format set command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	return NewSingleCmdResponse(m.MsgOK)
+}
+
+func (r *CmdProcessor) userNutrientLimitsSetCommand(userID int64, limits storage.Nutrients) []CmdResponse {
+	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
+	defer cancel()
+
+	us, err := r.stg.GetUserSettings(ctx, userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrUserSettingsNotFound) {
+			return NewErrCmdResponse(m.MsgErrUserSettingsNotFound)
+		}
+
+		r.logger.Error(
+			"user nutrient limits set command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	us.NutrientLimits = us.NutrientLimits.Merge(limits)
+	if err := r.stg.SetUserSettings(ctx, userID, us); err != nil {
+		if errors.Is(err, storage.ErrUserSettingsInvalid) {
+			return NewErrCmdResponse(m.MsgErrInvalidCommand)
+		}
+
+		r.logger.Error(
+			"user nutrient limits set command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	return NewSingleCmdResponse(m.MsgOK)
 }
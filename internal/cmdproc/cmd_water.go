@@ -0,0 +1,220 @@
+package cmdproc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	m "github.com/devldavydov/myhealth/internal/common/messages"
+	"github.com/devldavydov/myhealth/internal/storage"
+	"go.uber.org/zap"
+)
+
+const (
+	_waterDayStartHour = 8
+	_waterDayEndHour   = 22
+)
+
+// Hours to check water intake and remind, if it lags behind goal.
+var _waterReminderHours = []int{12, 15, 18, 21}
+
+func (r *CmdProcessor) waterAddCommand(userID int64, ts time.Time, volumes []float64) []CmdResponse {
+	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
+	defer cancel()
+
+	var volume float64
+	for _, v := range volumes {
+		volume += v
+	}
+
+	if err := r.stg.AddWater(ctx, userID, &storage.Water{
+		Timestamp: storage.NewTimestamp(ts),
+		Volume:    volume,
+	}); err != nil {
+		if errors.Is(err, storage.ErrWaterInvalid) {
+			return NewErrCmdResponse(m.MsgErrInvalidCommand)
+		}
+
+		r.logger.Error(
+			"water add command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	return NewSingleCmdResponse(m.MsgOK)
+}
+
+func (r *CmdProcessor) waterDelCommand(userID int64, ts time.Time) []CmdResponse {
+	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
+	defer cancel()
+
+	if err := r.stg.DeleteWater(ctx, userID, storage.NewTimestamp(storage.DayStart(ts)), storage.NewTimestamp(storage.DayEnd(ts))); err != nil {
+		r.logger.Error(
+			"water del command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	return NewSingleCmdResponse(m.MsgOK)
+}
+
+func (r *CmdProcessor) waterListCommand(userID int64, ts time.Time) []CmdResponse {
+	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
+	defer cancel()
+
+	us, err := r.stg.GetUserSettings(ctx, userID)
+	if err != nil && !errors.Is(err, storage.ErrUserSettingsNotFound) {
+		r.logger.Error(
+			"water list command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	lst, err := r.stg.GetWaterList(ctx, userID, storage.NewTimestamp(storage.DayStart(ts)), storage.NewTimestamp(storage.DayEnd(ts)))
+	if err != nil && !errors.Is(err, storage.ErrEmptyResult) {
+		r.logger.Error(
+			"water list command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	jrnl, err := r.stg.GetJournalReport(ctx, userID, storage.NewTimestamp(storage.DayStart(ts)), storage.NewTimestamp(storage.DayEnd(ts)))
+	if err != nil && !errors.Is(err, storage.ErrEmptyResult) {
+		r.logger.Error(
+			"water list command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	beverages := slices.DeleteFunc(jrnl, func(j storage.JournalReport) bool { return !j.FoodBeverage })
+	if len(lst) == 0 && len(beverages) == 0 {
+		return NewErrCmdResponse(m.MsgErrEmptyResult)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("<b>Вода за %s</b>\n", formatTimestamp(ts)))
+
+	var total float64
+	for _, w := range lst {
+		sb.WriteString(fmt.Sprintf("%s: %.0f мл\n", formatDateTime(w.Timestamp.ToTime(r.tz)), w.Volume))
+		total += w.Volume
+	}
+	for _, j := range beverages {
+		sb.WriteString(fmt.Sprintf("%s (%s): %.0f мл\n", j.FoodName, j.Meal.MustToString(), j.FoodWeight))
+		total += j.FoodWeight
+	}
+
+	sb.WriteString(fmt.Sprintf("\n<b>%s</b>", formatWaterProgress(total, getUserWaterGoal(us))))
+
+	return NewSingleCmdResponse(sb.String(), r.typeAdapter.OptsHTML())
+}
+
+// WaterReminder returns reminder message, if user enabled water reminders
+// and water drunk today lags behind goal at reminder hour.
+func (r *CmdProcessor) WaterReminder(userID int64) string {
+	now := timeNow().In(r.tz)
+	if !slices.Contains(_waterReminderHours, now.Hour()) {
+		return ""
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
+	defer cancel()
+
+	us, err := r.stg.GetUserSettings(ctx, userID)
+	if err != nil {
+		if !errors.Is(err, storage.ErrUserSettingsNotFound) {
+			r.logger.Error("water reminder DB error", zap.Int64("userID", userID), zap.Error(err))
+		}
+		return ""
+	}
+
+	if !us.WaterReminder || us.WaterGoal == 0 {
+		return ""
+	}
+
+	jrnl, err := r.stg.GetJournalReport(ctx, userID, storage.NewTimestamp(storage.DayStart(now)), storage.NewTimestamp(storage.DayEnd(now)))
+	if err != nil && !errors.Is(err, storage.ErrEmptyResult) {
+		r.logger.Error("water reminder DB error", zap.Int64("userID", userID), zap.Error(err))
+		return ""
+	}
+
+	volume, err := r.getDayWater(ctx, userID, now, jrnl)
+	if err != nil {
+		r.logger.Error("water reminder DB error", zap.Int64("userID", userID), zap.Error(err))
+		return ""
+	}
+
+	expected := calcWaterExpected(us.WaterGoal, now)
+	if volume >= expected {
+		return ""
+	}
+
+	return fmt.Sprintf(
+		"Напоминание: пора выпить воды!\n%s, к %02d:00 по плану %.0f мл",
+		formatWaterProgress(volume, us.WaterGoal),
+		now.Hour(),
+		expected,
+	)
+}
+
+// getDayWater returns water volume of day: water drunk and beverages from
+// day journal report, beverage weight in grams is counted as volume in ml.
+func (r *CmdProcessor) getDayWater(ctx context.Context, userID int64, ts time.Time, jrnl []storage.JournalReport) (float64, error) {
+	lst, err := r.stg.GetWaterList(ctx, userID, storage.NewTimestamp(storage.DayStart(ts)), storage.NewTimestamp(storage.DayEnd(ts)))
+	if err != nil && !errors.Is(err, storage.ErrEmptyResult) {
+		return 0, err
+	}
+
+	var volume float64
+	for _, w := range lst {
+		volume += w.Volume
+	}
+	for _, j := range jrnl {
+		if j.FoodBeverage {
+			volume += j.FoodWeight
+		}
+	}
+
+	return volume, nil
+}
+
+// getUserWaterGoal returns user water goal, zero if settings not set.
+func getUserWaterGoal(us *storage.UserSettings) float64 {
+	if us == nil {
+		return 0
+	}
+	return us.WaterGoal
+}
+
+// calcWaterExpected returns water volume expected to be drunk by time,
+// goal is spread evenly over active hours of day.
+func calcWaterExpected(goal float64, t time.Time) float64 {
+	hours := float64(t.Hour()-_waterDayStartHour) + float64(t.Minute())/60
+	part := hours / float64(_waterDayEndHour-_waterDayStartHour)
+	return goal * min(max(part, 0), 1)
+}
+
+func formatWaterProgress(volume, goal float64) string {
+	if goal == 0 {
+		return fmt.Sprintf("Вода: %.0f мл", volume)
+	}
+	return fmt.Sprintf("Вода: %.0f / %.0f мл (%.0f%%)", volume, goal, volume/goal*100)
+}
@@ -1,7 +1,6 @@
 package cmdproc
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -25,7 +24,7 @@ func (r *CmdProcessor) weightSetCommand(userID int64, ts time.Time, weight float
 		},
 	); err != nil {
 		if errors.Is(err, storage.ErrWeightInvalid) {
-			return NewSingleCmdResponse(m.MsgErrInvalidCommand)
+			return NewErrCmdResponse(m.MsgErrInvalidCommand)
 		}
 
 		r.logger.Error(
@@ -34,7 +33,7 @@ func (r *CmdProcessor) weightSetCommand(userID int64, ts time.Time, weight float
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
 	return NewSingleCmdResponse(m.MsgOK)
@@ -46,7 +45,8 @@ func (r *CmdProcessor) weightDelCommand(userID int64, ts time.Time) []CmdRespons
 
 	if err := r.stg.DeleteWeight(ctx,
 		userID,
-		storage.NewTimestamp(ts),
+		storage.NewTimestamp(storage.DayStart(ts)),
+		storage.NewTimestamp(storage.DayEnd(ts)),
 	); err != nil {
 		r.logger.Error(
 			"weight del command DB error",
@@ -54,25 +54,25 @@ func (r *CmdProcessor) weightDelCommand(userID int64, ts time.Time) []CmdRespons
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
 	return NewSingleCmdResponse(m.MsgOK)
 }
 
-func (r *CmdProcessor) weightListCommand(userID int64, tsFrom, tsTo time.Time) []CmdResponse {
+func (r *CmdProcessor) weightListCommand(userID int64, tsFrom, tsTo time.Time, format storage.ReportFormat) []CmdResponse {
 	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
 	defer cancel()
 
 	lst, err := r.stg.GetWeightList(ctx,
 		userID,
-		storage.NewTimestamp(tsFrom),
-		storage.NewTimestamp(tsTo),
+		storage.NewTimestamp(storage.DayStart(tsFrom)),
+		storage.NewTimestamp(storage.DayEnd(tsTo)),
 		false,
 	)
 	if err != nil {
 		if errors.Is(err, storage.ErrEmptyResult) {
-			return NewSingleCmdResponse(m.MsgErrEmptyResult)
+			return NewErrCmdResponse(m.MsgErrEmptyResult)
 		}
 
 		r.logger.Error(
@@ -81,13 +81,13 @@ func (r *CmdProcessor) weightListCommand(userID int64, tsFrom, tsTo time.Time) [
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
 	// Report table
 	tsFromStr, tsToStr := formatTimestamp(tsFrom), formatTimestamp(tsTo)
 
-	htmlBuilder := html.NewBuilder("Таблица веса")
+	htmlBuilder := html.NewBuilder("Таблица веса", r.assets)
 	accordion := html.NewAccordion("accordionWeight")
 
 	// Table
@@ -98,10 +98,10 @@ func (r *CmdProcessor) weightListCommand(userID int64, tsFrom, tsTo time.Time) [
 	for _, w := range lst {
 		tbl.AddRow(
 			html.NewTr(nil).
-				AddTd(html.NewTd(html.NewS(formatTimestamp(w.Timestamp.ToTime(r.tz))), nil)).
+				AddTd(html.NewTd(html.NewS(formatDateTime(w.Timestamp.ToTime(r.tz))), nil)).
 				AddTd(html.NewTd(html.NewS(fmt.Sprintf("%.1f", w.Value)), nil)),
 		)
-		xlabels = append(xlabels, formatTimestamp(w.Timestamp.ToTime(r.tz)))
+		xlabels = append(xlabels, formatDateTime(w.Timestamp.ToTime(r.tz)))
 		data = append(data, w.Value)
 	}
 
@@ -119,7 +119,7 @@ func (r *CmdProcessor) weightListCommand(userID int64, tsFrom, tsTo time.Time) [
 			fmt.Sprintf("График веса за %s - %s", tsFromStr, tsToStr),
 			chart))
 
-	chartSnip, err := GetChartSnippet(&ChartData{
+	chartSnip, err := html.NewChartScript(&ChartData{
 		PlotFunc: "plot",
 		ElemID:   "chart",
 		XLabels:  xlabels,
@@ -139,7 +139,7 @@ func (r *CmdProcessor) weightListCommand(userID int64, tsFrom, tsTo time.Time) [
 			zap.Error(err),
 		)
 
-		return NewSingleCmdResponse(m.MsgErrInternal)
+		return NewErrCmdResponse(m.MsgErrInternal)
 	}
 
 	// Doc
@@ -147,17 +147,68 @@ func (r *CmdProcessor) weightListCommand(userID int64, tsFrom, tsTo time.Time) [
 		html.NewContainer().Add(
 			accordion,
 		),
-		html.NewScript(_jsBootstrapURL),
-		html.NewScript(_jsChartURL),
+		r.assets.Script(html.AssetBootstrapJS),
+		r.assets.Script(html.AssetChartJS),
 		html.NewS(GetStartPlotSnippet()),
-		html.NewS(chartSnip),
+		chartSnip,
 		html.NewS(GetEndPlotSnippet()),
 	)
 
 	// Response
-	return NewSingleCmdResponse(r.typeAdapter.File(
-		bytes.NewBufferString(htmlBuilder.Build()),
-		"text/html",
-		fmt.Sprintf("weight_%s_%s.html", tsFromStr, tsToStr),
-	))
+	return r.reportResponse(userID, htmlBuilder, fmt.Sprintf("weight_%s_%s", tsFromStr, tsToStr), format)
+}
+
+func (r *CmdProcessor) weightGraphCommand(userID int64, days int, format string) []CmdResponse {
+	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
+	defer cancel()
+
+	tsTo := timeNow().In(r.tz)
+	tsFrom := tsTo.AddDate(0, 0, 1-days)
+
+	lst, err := r.stg.GetWeightList(ctx,
+		userID,
+		storage.NewTimestamp(storage.DayStart(tsFrom)),
+		storage.NewTimestamp(storage.DayEnd(tsTo)),
+		false,
+	)
+	if err != nil {
+		if errors.Is(err, storage.ErrEmptyResult) {
+			return NewErrCmdResponse(m.MsgErrEmptyResult)
+		}
+
+		r.logger.Error(
+			"weight graph command DB error",
+			zap.Int64("userID", userID),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	xlabels := make([]string, 0, len(lst))
+	data := make([]float64, 0, len(lst))
+	for _, w := range lst {
+		xlabels = append(xlabels, formatTimestamp(w.Timestamp.ToTime(r.tz)))
+		data = append(data, w.Value)
+	}
+
+	tsFromStr, tsToStr := formatTimestamp(tsFrom), formatTimestamp(tsTo)
+
+	return r.chartResponse(
+		userID,
+		&ChartData{
+			XLabels: xlabels,
+			Type:    "line",
+			Datasets: []ChartDataset{
+				{
+					Data:  data,
+					Label: "Вес",
+					Color: ChartColorBlue,
+				},
+			},
+		},
+		fmt.Sprintf("График веса за %s - %s", tsFromStr, tsToStr),
+		fmt.Sprintf("weight_%s_%s", tsFromStr, tsToStr),
+		html.ChartImageFormat(format),
+	)
 }
@@ -1,4 +1,4 @@
-//go:generate go run ./gen/gen.go -in commands.yaml -out cmdproc_generated.go
+//go:generate go run ./gen/gen.go -in commands.yaml -out cmdproc_generated.go -test-out cmdproc_generated_test.go
 
 package cmdproc
 
@@ -6,16 +6,21 @@ import (
 	"bytes"
 	"time"
 
+	"github.com/devldavydov/myhealth/internal/common/html"
 	"github.com/devldavydov/myhealth/internal/storage"
 	"go.uber.org/zap"
 )
 
+// timeNow returns current time, it is replaced in tests for stable output.
+var timeNow = time.Now
+
 type ICmdProcess interface {
 	Send(what any, opts ...any) error
 }
 
 type ITypeAdapter interface {
 	File(buf *bytes.Buffer, mime string, fileName string) any
+	Photo(buf *bytes.Buffer, caption string) any
 	OptsHTML() any
 }
 
@@ -23,6 +28,7 @@ type CmdProcessor struct {
 	stg         storage.Storage
 	typeAdapter ITypeAdapter
 	tz          *time.Location
+	assets      html.Assets
 	logger      *zap.Logger
 	debugMode   bool
 }
@@ -31,10 +37,11 @@ func NewCmdProcessor(
 	stg storage.Storage,
 	typeAdapter ITypeAdapter,
 	tz *time.Location,
+	assets html.Assets,
 	debugMode bool,
 	logger *zap.Logger,
 ) *CmdProcessor {
-	return &CmdProcessor{stg: stg, typeAdapter: typeAdapter, tz: tz, debugMode: debugMode, logger: logger}
+	return &CmdProcessor{stg: stg, typeAdapter: typeAdapter, tz: tz, assets: assets, debugMode: debugMode, logger: logger}
 }
 
 func (r *CmdProcessor) Stop() {
@@ -44,12 +51,32 @@ func (r *CmdProcessor) Stop() {
 }
 
 func (r *CmdProcessor) Process(c ICmdProcess, cmd string, userID int64) error {
-	return r.process(c, cmd, userID)
+	var resp []CmdResponse
+	if mode, lines, ok := parseBatch(cmd); ok {
+		resp = r.processBatch(mode, lines, userID)
+	} else {
+		resp = r.processCmd(cmd, userID)
+	}
+
+	if r.debugMode {
+		if err := c.Send("!!! ОТЛАДОЧНЫЙ РЕЖИМ !!!"); err != nil {
+			return err
+		}
+	}
+
+	for _, rItem := range resp {
+		if err := c.Send(rItem.what, rItem.opts...); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 type CmdResponse struct {
 	what any
 	opts []any
+	err  bool
 }
 
 func NewCmdResponse(what any, opts ...any) CmdResponse {
@@ -61,3 +88,20 @@ func NewSingleCmdResponse(what any, opts ...any) []CmdResponse {
 		{what: what, opts: opts},
 	}
 }
+
+// NewErrCmdResponse returns response of failed command.
+func NewErrCmdResponse(what any, opts ...any) []CmdResponse {
+	return []CmdResponse{
+		{what: what, opts: opts, err: true},
+	}
+}
+
+// isErrResponse reports whether command failed.
+func isErrResponse(resp []CmdResponse) bool {
+	for _, rItem := range resp {
+		if rItem.err {
+			return true
+		}
+	}
+	return false
+}
@@ -14,42 +14,44 @@ import (
 	"go.uber.org/zap"
 )	
 
-func (r *CmdProcessor) process(c ICmdProcess, cmd string, userID int64) error {
-	cmdParts := []string{}
-	for _, part := range strings.Split(cmd, ",") {
-		cmdParts = append(cmdParts, strings.Trim(part, " "))
-	}
-
-	if len(cmdParts) == 0 {
+func (r *CmdProcessor) processCmd(cmd string, userID int64) []CmdResponse {
+	cmdParts, err := splitArgs(cmd, ',')
+	if err != nil || len(cmdParts) == 0 {
 		r.logger.Error(
 			"invalid command",
 			zap.String("command", cmd),
 			zap.Int64("userID", userID),
 		)
-		return c.Send(m.MsgErrInvalidCommand)
+		return NewErrCmdResponse(m.MsgErrInvalidCommand)
 	}
 
 	var resp []CmdResponse
 
-	switch cmdParts[0] {
-	case "w":
+	switch strings.TrimSpace(cmdParts[0]) {
+	case "w", "weight":
 		resp = r.process_w("w", cmdParts[1:], userID)
-	case "u":
+	case "u", "user":
 		resp = r.process_u("u", cmdParts[1:], userID)
-	case "f":
+	case "f", "food":
 		resp = r.process_f("f", cmdParts[1:], userID)
 	case "x":
 		resp = r.process_x("x", cmdParts[1:], userID)
-	case "c":
+	case "c", "calc":
 		resp = r.process_c("c", cmdParts[1:], userID)
-	case "b":
+	case "b", "bundle":
 		resp = r.process_b("b", cmdParts[1:], userID)
-	case "j":
+	case "j", "journal":
 		resp = r.process_j("j", cmdParts[1:], userID)
-	case "s":
+	case "s", "sport":
 		resp = r.process_s("s", cmdParts[1:], userID)
-	case "m":
+	case "m", "med":
 		resp = r.process_m("m", cmdParts[1:], userID)
+	case "wa", "water":
+		resp = r.process_wa("wa", cmdParts[1:], userID)
+	case "sl", "sleep":
+		resp = r.process_sl("sl", cmdParts[1:], userID)
+	case "fa", "fast":
+		resp = r.process_fa("fa", cmdParts[1:], userID)
 	case "h":
 		resp = r.processHelp()
 	default:
@@ -58,22 +60,10 @@ func (r *CmdProcessor) process(c ICmdProcess, cmd string, userID int64) error {
 			zap.String("command", cmd),
 			zap.Int64("userID", userID),
 		)
-		resp = NewSingleCmdResponse(m.MsgErrInvalidCommand)
+		resp = suggestCommand(strings.TrimSpace(cmdParts[0]))
 	}	
 
-	if r.debugMode {
-		if err := c.Send("!!! ОТЛАДОЧНЫЙ РЕЖИМ !!!"); err != nil {
-			return err
-		}
-	}
-
-	for _, rItem := range resp {
-		if err := c.Send(rItem.what, rItem.opts...); err != nil {
-			return err
-		}
-	}
-
-	return nil	
+	return resp
 }
 
 func (r *CmdProcessor) process_w(baseCmd string, cmdParts []string, userID int64) []CmdResponse {
@@ -83,73 +73,58 @@ func (r *CmdProcessor) process_w(baseCmd string, cmdParts []string, userID int64
 			zap.Strings("cmdParts", cmdParts),
 			zap.Int64("userID", userID),
 		)
-		return NewSingleCmdResponse(m.MsgErrInvalidCommand)
+		return NewErrCmdResponse(m.MsgErrInvalidCommand)
 	}
 
 	var resp []CmdResponse
 
-	switch cmdParts[0] {
+	switch strings.TrimSpace(cmdParts[0]) {
 	case "set":
-		if len(cmdParts[1:]) != 2 {
-			return NewSingleCmdResponse(m.MsgErrInvalidArgsCount)
-		}
-		
-		cmdParts = cmdParts[1:]
-		
-		val0, err := parseTimestamp(r.tz, cmdParts[0])
-		if err != nil {
-			return argError("Дата")
-		}
-		
-		val1, err := parseFloatG0(cmdParts[1])
-		if err != nil {
-			return argError("Значение")
+		args, errResp := r.parseArgs_w_set(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		
+
 		resp = r.weightSetCommand(
 			userID,
-			val0,
-			val1,
+			args.val0,
+			args.val1,
 			)
 				
 	case "del":
-		if len(cmdParts[1:]) != 1 {
-			return NewSingleCmdResponse(m.MsgErrInvalidArgsCount)
-		}
-		
-		cmdParts = cmdParts[1:]
-		
-		val0, err := parseTimestamp(r.tz, cmdParts[0])
-		if err != nil {
-			return argError("Дата")
+		args, errResp := r.parseArgs_w_del(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		
+
 		resp = r.weightDelCommand(
 			userID,
-			val0,
+			args.val0,
 			)
 				
 	case "list":
-		if len(cmdParts[1:]) != 2 {
-			return NewSingleCmdResponse(m.MsgErrInvalidArgsCount)
-		}
-		
-		cmdParts = cmdParts[1:]
-		
-		val0, err := parseTimestamp(r.tz, cmdParts[0])
-		if err != nil {
-			return argError("С")
-		}
-		
-		val1, err := parseTimestamp(r.tz, cmdParts[1])
-		if err != nil {
-			return argError("По")
+		args, errResp := r.parseArgs_w_list(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		
+
 		resp = r.weightListCommand(
 			userID,
-			val0,
-			val1,
+			args.val0,
+			args.val1,
+			args.val2,
+			)
+				
+	case "g":
+		args, errResp := r.parseArgs_w_g(cmdParts[1:])
+		if errResp != nil {
+			return errResp
+		}
+
+		resp = r.weightGraphCommand(
+			userID,
+			args.val0,
+			args.val1,
 			)
 				
 	case "h":
@@ -171,6 +146,13 @@ func (r *CmdProcessor) process_w(baseCmd string, cmdParts []string, userID int64
 				"list",
 				"С [Дата]",
 				"По [Дата]",
+				"Формат [Формат] (необязательно)",
+				).
+			addCmd(
+				"График за последние дни",
+				"g",
+				"Дней [Целое>0] (необязательно, по умолчанию 30)",
+				"Формат [Формат графика] (необязательно, по умолчанию png)",
 				).
 			build(),
 		r.typeAdapter.OptsHTML())
@@ -181,12 +163,128 @@ func (r *CmdProcessor) process_w(baseCmd string, cmdParts []string, userID int64
 			zap.Strings("cmdParts", cmdParts),
 			zap.Int64("userID", userID),
 		)
-		resp = NewSingleCmdResponse(m.MsgErrInvalidCommand)
+		resp = suggestSubCommand(baseCmd, strings.TrimSpace(cmdParts[0]))
 	}
 
 	return resp
 }
 
+type args_w_set struct {
+	val0 time.Time
+	val1 float64
+	}
+
+func (r *CmdProcessor) parseArgs_w_set(parts []string) (*args_w_set, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "дата"},
+		{key: "значение"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_w_set
+	
+	res.val0, err = parseTimestamp(r.tz, unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Дата")
+	}
+	
+	res.val1, err = parseFloatG0(unquoteArg(args[1]))
+	if err != nil {
+		return nil, argError("Значение")
+	}
+
+	return &res, nil
+}
+
+type args_w_del struct {
+	val0 time.Time
+	}
+
+func (r *CmdProcessor) parseArgs_w_del(parts []string) (*args_w_del, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "дата"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_w_del
+	
+	res.val0, err = parseTimestamp(r.tz, unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Дата")
+	}
+
+	return &res, nil
+}
+
+type args_w_list struct {
+	val0 time.Time
+	val1 time.Time
+	val2 storage.ReportFormat
+	}
+
+func (r *CmdProcessor) parseArgs_w_list(parts []string) (*args_w_list, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "с"},
+		{key: "по"},
+		{key: "формат", optional: true, def: ""},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_w_list
+	
+	res.val0, err = parseTimestamp(r.tz, unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("С")
+	}
+	
+	res.val1, err = parseTimestamp(r.tz, unquoteArg(args[1]))
+	if err != nil {
+		return nil, argError("По")
+	}
+	
+	res.val2, err = parseReportFormat(unquoteArg(args[2]))
+	if err != nil {
+		return nil, argError("Формат")
+	}
+
+	return &res, nil
+}
+
+type args_w_g struct {
+	val0 int
+	val1 string
+	}
+
+func (r *CmdProcessor) parseArgs_w_g(parts []string) (*args_w_g, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "дней", optional: true, def: "30"},
+		{key: "формат", optional: true, def: "png"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_w_g
+	
+	res.val0, err = parseIntG0(unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Дней")
+	}
+	
+	res.val1, err = parseEnum(unquoteArg(args[1]), []string{"png", "svg"})
+	if err != nil {
+		return nil, argError("Формат")
+	}
+
+	return &res, nil
+}
+
 func (r *CmdProcessor) process_u(baseCmd string, cmdParts []string, userID int64) []CmdResponse {
 	if len(cmdParts) == 0 {
 		r.logger.Error(
@@ -194,27 +292,21 @@ func (r *CmdProcessor) process_u(baseCmd string, cmdParts []string, userID int64
 			zap.Strings("cmdParts", cmdParts),
 			zap.Int64("userID", userID),
 		)
-		return NewSingleCmdResponse(m.MsgErrInvalidCommand)
+		return NewErrCmdResponse(m.MsgErrInvalidCommand)
 	}
 
 	var resp []CmdResponse
 
-	switch cmdParts[0] {
+	switch strings.TrimSpace(cmdParts[0]) {
 	case "set":
-		if len(cmdParts[1:]) != 1 {
-			return NewSingleCmdResponse(m.MsgErrInvalidArgsCount)
-		}
-		
-		cmdParts = cmdParts[1:]
-		
-		val0, err := parseFloatG0(cmdParts[0])
-		if err != nil {
-			return argError("Лимит калорий")
+		args, errResp := r.parseArgs_u_set(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		
+
 		resp = r.userSettingsSetCommand(
 			userID,
-			val0,
+			args.val0,
 			)
 				
 	case "st":
@@ -223,6 +315,57 @@ func (r *CmdProcessor) process_u(baseCmd string, cmdParts []string, userID int64
 	case "get":
 		resp = r.userSettingsGetCommand(userID)
 				
+	case "prof":
+		args, errResp := r.parseArgs_u_prof(cmdParts[1:])
+		if errResp != nil {
+			return errResp
+		}
+
+		resp = r.userProfileSetCommand(
+			userID,
+			args.val0,
+			args.val1,
+			args.val2,
+			args.val3,
+			args.val4,
+			args.val5,
+			args.val6,
+			)
+				
+	case "water":
+		args, errResp := r.parseArgs_u_water(cmdParts[1:])
+		if errResp != nil {
+			return errResp
+		}
+
+		resp = r.userWaterGoalSetCommand(
+			userID,
+			args.val0,
+			args.val1,
+			)
+				
+	case "nut":
+		args, errResp := r.parseArgs_u_nut(cmdParts[1:])
+		if errResp != nil {
+			return errResp
+		}
+
+		resp = r.userNutrientLimitsSetCommand(
+			userID,
+			args.val0,
+			)
+				
+	case "rep":
+		args, errResp := r.parseArgs_u_rep(cmdParts[1:])
+		if errResp != nil {
+			return errResp
+		}
+
+		resp = r.userReportFormatSetCommand(
+			userID,
+			args.val0,
+			)
+				
 	case "h":
 		return NewSingleCmdResponse(
 			newCmdHelpBuilder(baseCmd, "Управление настройками пользователя").
@@ -239,6 +382,37 @@ func (r *CmdProcessor) process_u(baseCmd string, cmdParts []string, userID int64
 				"Получение",
 				"get",
 				).
+			addCmdWithComment(
+				"Установка профиля",
+				"prof",
+				"Процент жира 0 - неизвестен",
+				"Пол [Пол]",
+				"Дата рождения [Дата]",
+				"Рост [Дробное>0]",
+				"Активность [Активность]",
+				"Процент жира [Дробное>=0]",
+				"Цель [Цель]",
+				"Темп кг/нед [Дробное>=0]",
+				).	
+			addCmdWithComment(
+				"Установка дневной цели воды",
+				"water",
+				"Цель 0 - без цели, напоминания приходят днем при отставании от цели",
+				"Цель, мл [Дробное>=0]",
+				"Напоминания [Да/Нет]",
+				).	
+			addCmdWithComment(
+				"Установка дневных лимитов нутриентов",
+				"nut",
+				"Не указанные лимиты сохраняются, значение 0 удаляет лимит",
+				"Лимиты, г [Нутриенты]",
+				).	
+			addCmdWithComment(
+				"Установка формата отчетов по умолчанию",
+				"rep",
+				"Формат можно указать и в команде отчета последним аргументом",
+				"Формат [Формат]",
+				).	
 			build(),
 		r.typeAdapter.OptsHTML())
 
@@ -248,12 +422,171 @@ func (r *CmdProcessor) process_u(baseCmd string, cmdParts []string, userID int64
 			zap.Strings("cmdParts", cmdParts),
 			zap.Int64("userID", userID),
 		)
-		resp = NewSingleCmdResponse(m.MsgErrInvalidCommand)
+		resp = suggestSubCommand(baseCmd, strings.TrimSpace(cmdParts[0]))
 	}
 
 	return resp
 }
 
+type args_u_set struct {
+	val0 float64
+	}
+
+func (r *CmdProcessor) parseArgs_u_set(parts []string) (*args_u_set, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "лимит_калорий"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_u_set
+	
+	res.val0, err = parseFloatG0(unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Лимит калорий")
+	}
+
+	return &res, nil
+}
+
+type args_u_prof struct {
+	val0 string
+	val1 time.Time
+	val2 float64
+	val3 storage.ActivityLevel
+	val4 float64
+	val5 storage.Goal
+	val6 float64
+	}
+
+func (r *CmdProcessor) parseArgs_u_prof(parts []string) (*args_u_prof, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "пол"},
+		{key: "дата_рождения"},
+		{key: "рост"},
+		{key: "активность"},
+		{key: "процент_жира"},
+		{key: "цель"},
+		{key: "темп_кг_нед"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_u_prof
+	
+	res.val0, err = parseEnum(unquoteArg(args[0]), []string{"m", "f"})
+	if err != nil {
+		return nil, argError("Пол")
+	}
+	
+	res.val1, err = parseTimestamp(r.tz, unquoteArg(args[1]))
+	if err != nil {
+		return nil, argError("Дата рождения")
+	}
+	
+	res.val2, err = parseFloatG0(unquoteArg(args[2]))
+	if err != nil {
+		return nil, argError("Рост")
+	}
+	
+	res.val3, err = parseActivityLevel(unquoteArg(args[3]))
+	if err != nil {
+		return nil, argError("Активность")
+	}
+	
+	res.val4, err = parseFloatGE0(unquoteArg(args[4]))
+	if err != nil {
+		return nil, argError("Процент жира")
+	}
+	
+	res.val5, err = parseGoal(unquoteArg(args[5]))
+	if err != nil {
+		return nil, argError("Цель")
+	}
+	
+	res.val6, err = parseFloatGE0(unquoteArg(args[6]))
+	if err != nil {
+		return nil, argError("Темп кг/нед")
+	}
+
+	return &res, nil
+}
+
+type args_u_water struct {
+	val0 float64
+	val1 bool
+	}
+
+func (r *CmdProcessor) parseArgs_u_water(parts []string) (*args_u_water, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "цель_мл"},
+		{key: "напоминания"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_u_water
+	
+	res.val0, err = parseFloatGE0(unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Цель, мл")
+	}
+	
+	res.val1, err = parseBool(unquoteArg(args[1]))
+	if err != nil {
+		return nil, argError("Напоминания")
+	}
+
+	return &res, nil
+}
+
+type args_u_nut struct {
+	val0 storage.Nutrients
+	}
+
+func (r *CmdProcessor) parseArgs_u_nut(parts []string) (*args_u_nut, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "лимиты_г"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_u_nut
+	
+	res.val0, err = parseNutrients(unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Лимиты, г")
+	}
+
+	return &res, nil
+}
+
+type args_u_rep struct {
+	val0 storage.ReportFormat
+	}
+
+func (r *CmdProcessor) parseArgs_u_rep(parts []string) (*args_u_rep, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "формат"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_u_rep
+	
+	res.val0, err = parseReportFormat(unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Формат")
+	}
+
+	return &res, nil
+}
+
 func (r *CmdProcessor) process_f(baseCmd string, cmdParts []string, userID int64) []CmdResponse {
 	if len(cmdParts) == 0 {
 		r.logger.Error(
@@ -261,219 +594,140 @@ func (r *CmdProcessor) process_f(baseCmd string, cmdParts []string, userID int64
 			zap.Strings("cmdParts", cmdParts),
 			zap.Int64("userID", userID),
 		)
-		return NewSingleCmdResponse(m.MsgErrInvalidCommand)
+		return NewErrCmdResponse(m.MsgErrInvalidCommand)
 	}
 
 	var resp []CmdResponse
 
-	switch cmdParts[0] {
+	switch strings.TrimSpace(cmdParts[0]) {
 	case "set":
-		if len(cmdParts[1:]) != 8 {
-			return NewSingleCmdResponse(m.MsgErrInvalidArgsCount)
-		}
-		
-		cmdParts = cmdParts[1:]
-		
-		val0, err := parseStringG0(cmdParts[0])
-		if err != nil {
-			return argError("Ключ")
-		}
-		
-		val1, err := parseStringG0(cmdParts[1])
-		if err != nil {
-			return argError("Наименование")
-		}
-		
-		val2, err := parseStringGE0(cmdParts[2])
-		if err != nil {
-			return argError("Бренд")
-		}
-		
-		val3, err := parseFloatGE0(cmdParts[3])
-		if err != nil {
-			return argError("ККал 100г")
-		}
-		
-		val4, err := parseFloatGE0(cmdParts[4])
-		if err != nil {
-			return argError("Б 100г")
-		}
-		
-		val5, err := parseFloatGE0(cmdParts[5])
-		if err != nil {
-			return argError("Ж 100г")
-		}
-		
-		val6, err := parseFloatGE0(cmdParts[6])
-		if err != nil {
-			return argError("У 100г")
+		args, errResp := r.parseArgs_f_set(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		
-		val7, err := parseStringGE0(cmdParts[7])
-		if err != nil {
-			return argError("Комментарий")
-		}
-		
+
 		resp = r.foodSetCommand(
 			userID,
-			val0,
-			val1,
-			val2,
-			val3,
-			val4,
-			val5,
-			val6,
-			val7,
+			args.val0,
+			args.val1,
+			args.val2,
+			args.val3,
+			args.val4,
+			args.val5,
+			args.val6,
+			args.val7,
+			args.val8,
 			)
 				
 	case "setw":
-		if len(cmdParts[1:]) != 9 {
-			return NewSingleCmdResponse(m.MsgErrInvalidArgsCount)
-		}
-		
-		cmdParts = cmdParts[1:]
-		
-		val0, err := parseStringG0(cmdParts[0])
-		if err != nil {
-			return argError("Ключ")
+		args, errResp := r.parseArgs_f_setw(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		
-		val1, err := parseStringG0(cmdParts[1])
-		if err != nil {
-			return argError("Наименование")
+
+		resp = r.foodSetWeightCommand(
+			userID,
+			args.val0,
+			args.val1,
+			args.val2,
+			args.val3,
+			args.val4,
+			args.val5,
+			args.val6,
+			args.val7,
+			args.val8,
+			args.val9,
+			)
+				
+	case "bev":
+		args, errResp := r.parseArgs_f_bev(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		
-		val2, err := parseStringGE0(cmdParts[2])
-		if err != nil {
-			return argError("Бренд")
+
+		resp = r.foodSetBeverageCommand(
+			userID,
+			args.val0,
+			args.val1,
+			)
+				
+	case "por":
+		args, errResp := r.parseArgs_f_por(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		
-		val3, err := parseFloatG0(cmdParts[3])
-		if err != nil {
-			return argError("Вес, г.")
-		}
-		
-		val4, err := parseFloatGE0(cmdParts[4])
-		if err != nil {
-			return argError("ККал на вес")
-		}
-		
-		val5, err := parseFloatGE0(cmdParts[5])
-		if err != nil {
-			return argError("Б на вес")
-		}
-		
-		val6, err := parseFloatGE0(cmdParts[6])
-		if err != nil {
-			return argError("Ж на вес")
-		}
-		
-		val7, err := parseFloatGE0(cmdParts[7])
-		if err != nil {
-			return argError("У на вес")
-		}
-		
-		val8, err := parseStringGE0(cmdParts[8])
-		if err != nil {
-			return argError("Комментарий")
-		}
-		
-		resp = r.foodSetWeightCommand(
+
+		resp = r.foodSetPortionsCommand(
 			userID,
-			val0,
-			val1,
-			val2,
-			val3,
-			val4,
-			val5,
-			val6,
-			val7,
-			val8,
+			args.val0,
+			args.val1,
+			args.val2,
 			)
 				
 	case "st":
-		if len(cmdParts[1:]) != 1 {
-			return NewSingleCmdResponse(m.MsgErrInvalidArgsCount)
-		}
-		
-		cmdParts = cmdParts[1:]
-		
-		val0, err := parseStringG0(cmdParts[0])
-		if err != nil {
-			return argError("Ключ")
+		args, errResp := r.parseArgs_f_st(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		
+
 		resp = r.foodSetTemplateCommand(
 			userID,
-			val0,
+			args.val0,
 			)
 				
 	case "find":
-		if len(cmdParts[1:]) != 1 {
-			return NewSingleCmdResponse(m.MsgErrInvalidArgsCount)
-		}
-		
-		cmdParts = cmdParts[1:]
-		
-		val0, err := parseStringGE0(cmdParts[0])
-		if err != nil {
-			return argError("Подстрока")
+		args, errResp := r.parseArgs_f_find(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		
+
 		resp = r.foodFindCommand(
 			userID,
-			val0,
+			args.val0,
+			args.val1,
 			)
 				
 	case "calc":
-		if len(cmdParts[1:]) != 2 {
-			return NewSingleCmdResponse(m.MsgErrInvalidArgsCount)
-		}
-		
-		cmdParts = cmdParts[1:]
-		
-		val0, err := parseStringG0(cmdParts[0])
-		if err != nil {
-			return argError("Ключ")
-		}
-		
-		val1, err := parseFloatGE0(cmdParts[1])
-		if err != nil {
-			return argError("Вес")
+		args, errResp := r.parseArgs_f_calc(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		
+
 		resp = r.foodCalcCommand(
 			userID,
-			val0,
-			val1,
+			args.val0,
+			args.val1,
 			)
 				
 	case "list":
-		resp = r.foodListCommand(userID)
+		args, errResp := r.parseArgs_f_list(cmdParts[1:])
+		if errResp != nil {
+			return errResp
+		}
+
+		resp = r.foodListCommand(
+			userID,
+			args.val0,
+			)
 				
 	case "del":
-		if len(cmdParts[1:]) != 1 {
-			return NewSingleCmdResponse(m.MsgErrInvalidArgsCount)
-		}
-		
-		cmdParts = cmdParts[1:]
-		
-		val0, err := parseStringG0(cmdParts[0])
-		if err != nil {
-			return argError("Ключ")
+		args, errResp := r.parseArgs_f_del(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		
+
 		resp = r.foodDelCommand(
 			userID,
-			val0,
+			args.val0,
 			)
 				
 	case "h":
 		return NewSingleCmdResponse(
 			newCmdHelpBuilder(baseCmd, "Управление едой").
-			addCmd(
+			addCmdWithComment(
 				"Установка",
 				"set",
+				"Пример нутриентов - fiber=2.5;sugar=10, не указанные нутриенты сохраняются, значение 0 удаляет нутриент",
 				"Ключ [Строка>0]",
 				"Наименование [Строка>0]",
 				"Бренд [Строка>=0]",
@@ -481,11 +735,13 @@ func (r *CmdProcessor) process_f(baseCmd string, cmdParts []string, userID int64
 				"Б 100г [Дробное>=0]",
 				"Ж 100г [Дробное>=0]",
 				"У 100г [Дробное>=0]",
-				"Комментарий [Строка>=0]",
-				).
-			addCmd(
+				"Комментарий [Строка>=0] (необязательно)",
+				"Нутриенты 100г [Нутриенты] (необязательно)",
+				).	
+			addCmdWithComment(
 				"Установка по весу",
 				"setw",
+				"Нутриенты задаются на вес и пересчитываются на 100г",
 				"Ключ [Строка>0]",
 				"Наименование [Строка>0]",
 				"Бренд [Строка>=0]",
@@ -494,8 +750,24 @@ func (r *CmdProcessor) process_f(baseCmd string, cmdParts []string, userID int64
 				"Б на вес [Дробное>=0]",
 				"Ж на вес [Дробное>=0]",
 				"У на вес [Дробное>=0]",
-				"Комментарий [Строка>=0]",
-				).
+				"Комментарий [Строка>=0] (необязательно)",
+				"Нутриенты на вес [Нутриенты] (необязательно)",
+				).	
+			addCmdWithComment(
+				"Установка признака напитка",
+				"bev",
+				"Вес напитка в журнале учитывается как объем воды в мл",
+				"Ключ [Строка>0]",
+				"Напиток [Да/Нет]",
+				).	
+			addCmdWithComment(
+				"Установка порций и плотности",
+				"por",
+				"Пример порций - egg=55g;cup=240ml, порции в мл и объем пересчитываются в граммы по плотности, плотность 0 - 1 г/мл",
+				"Ключ [Строка>0]",
+				"Порции [Порции]",
+				"Плотность, г/мл [Дробное>=0]",
+				).	
 			addCmd(
 				"Шаблон команды установки",
 				"st",
@@ -505,16 +777,18 @@ func (r *CmdProcessor) process_f(baseCmd string, cmdParts []string, userID int64
 				"Поиск",
 				"find",
 				"Подстрока [Строка>=0]",
+				"Формат [Формат] (необязательно)",
 				).
 			addCmd(
 				"Расчет КБЖУ",
 				"calc",
 				"Ключ [Строка>0]",
-				"Вес [Дробное>=0]",
+				"Количество [Количество]",
 				).
 			addCmd(
 				"Список",
 				"list",
+				"Формат [Формат] (необязательно)",
 				).
 			addCmd(
 				"Удаление",
@@ -530,12 +804,364 @@ func (r *CmdProcessor) process_f(baseCmd string, cmdParts []string, userID int64
 			zap.Strings("cmdParts", cmdParts),
 			zap.Int64("userID", userID),
 		)
-		resp = NewSingleCmdResponse(m.MsgErrInvalidCommand)
+		resp = suggestSubCommand(baseCmd, strings.TrimSpace(cmdParts[0]))
 	}
 
 	return resp
 }
 
+type args_f_set struct {
+	val0 string
+	val1 string
+	val2 string
+	val3 float64
+	val4 float64
+	val5 float64
+	val6 float64
+	val7 string
+	val8 storage.Nutrients
+	}
+
+func (r *CmdProcessor) parseArgs_f_set(parts []string) (*args_f_set, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "ключ"},
+		{key: "наименование"},
+		{key: "бренд"},
+		{key: "ккал_100г"},
+		{key: "б_100г"},
+		{key: "ж_100г"},
+		{key: "у_100г"},
+		{key: "комментарий", optional: true, def: ""},
+		{key: "нутриенты_100г", optional: true, def: ""},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_f_set
+	
+	res.val0, err = parseStringG0(unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Ключ")
+	}
+	
+	res.val1, err = parseStringG0(unquoteArg(args[1]))
+	if err != nil {
+		return nil, argError("Наименование")
+	}
+	
+	res.val2, err = parseStringGE0(unquoteArg(args[2]))
+	if err != nil {
+		return nil, argError("Бренд")
+	}
+	
+	res.val3, err = parseFloatGE0(unquoteArg(args[3]))
+	if err != nil {
+		return nil, argError("ККал 100г")
+	}
+	
+	res.val4, err = parseFloatGE0(unquoteArg(args[4]))
+	if err != nil {
+		return nil, argError("Б 100г")
+	}
+	
+	res.val5, err = parseFloatGE0(unquoteArg(args[5]))
+	if err != nil {
+		return nil, argError("Ж 100г")
+	}
+	
+	res.val6, err = parseFloatGE0(unquoteArg(args[6]))
+	if err != nil {
+		return nil, argError("У 100г")
+	}
+	
+	res.val7, err = parseStringGE0(unquoteArg(args[7]))
+	if err != nil {
+		return nil, argError("Комментарий")
+	}
+	
+	res.val8, err = parseNutrients(unquoteArg(args[8]))
+	if err != nil {
+		return nil, argError("Нутриенты 100г")
+	}
+
+	return &res, nil
+}
+
+type args_f_setw struct {
+	val0 string
+	val1 string
+	val2 string
+	val3 float64
+	val4 float64
+	val5 float64
+	val6 float64
+	val7 float64
+	val8 string
+	val9 storage.Nutrients
+	}
+
+func (r *CmdProcessor) parseArgs_f_setw(parts []string) (*args_f_setw, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "ключ"},
+		{key: "наименование"},
+		{key: "бренд"},
+		{key: "вес_г"},
+		{key: "ккал_на_вес"},
+		{key: "б_на_вес"},
+		{key: "ж_на_вес"},
+		{key: "у_на_вес"},
+		{key: "комментарий", optional: true, def: ""},
+		{key: "нутриенты_на_вес", optional: true, def: ""},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_f_setw
+	
+	res.val0, err = parseStringG0(unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Ключ")
+	}
+	
+	res.val1, err = parseStringG0(unquoteArg(args[1]))
+	if err != nil {
+		return nil, argError("Наименование")
+	}
+	
+	res.val2, err = parseStringGE0(unquoteArg(args[2]))
+	if err != nil {
+		return nil, argError("Бренд")
+	}
+	
+	res.val3, err = parseFloatG0(unquoteArg(args[3]))
+	if err != nil {
+		return nil, argError("Вес, г.")
+	}
+	
+	res.val4, err = parseFloatGE0(unquoteArg(args[4]))
+	if err != nil {
+		return nil, argError("ККал на вес")
+	}
+	
+	res.val5, err = parseFloatGE0(unquoteArg(args[5]))
+	if err != nil {
+		return nil, argError("Б на вес")
+	}
+	
+	res.val6, err = parseFloatGE0(unquoteArg(args[6]))
+	if err != nil {
+		return nil, argError("Ж на вес")
+	}
+	
+	res.val7, err = parseFloatGE0(unquoteArg(args[7]))
+	if err != nil {
+		return nil, argError("У на вес")
+	}
+	
+	res.val8, err = parseStringGE0(unquoteArg(args[8]))
+	if err != nil {
+		return nil, argError("Комментарий")
+	}
+	
+	res.val9, err = parseNutrients(unquoteArg(args[9]))
+	if err != nil {
+		return nil, argError("Нутриенты на вес")
+	}
+
+	return &res, nil
+}
+
+type args_f_bev struct {
+	val0 string
+	val1 bool
+	}
+
+func (r *CmdProcessor) parseArgs_f_bev(parts []string) (*args_f_bev, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "ключ"},
+		{key: "напиток"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_f_bev
+	
+	res.val0, err = parseStringG0(unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Ключ")
+	}
+	
+	res.val1, err = parseBool(unquoteArg(args[1]))
+	if err != nil {
+		return nil, argError("Напиток")
+	}
+
+	return &res, nil
+}
+
+type args_f_por struct {
+	val0 string
+	val1 []storage.FoodPortion
+	val2 float64
+	}
+
+func (r *CmdProcessor) parseArgs_f_por(parts []string) (*args_f_por, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "ключ"},
+		{key: "порции"},
+		{key: "плотность_г_мл"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_f_por
+	
+	res.val0, err = parseStringG0(unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Ключ")
+	}
+	
+	res.val1, err = parseFoodPortions(unquoteArg(args[1]))
+	if err != nil {
+		return nil, argError("Порции")
+	}
+	
+	res.val2, err = parseFloatGE0(unquoteArg(args[2]))
+	if err != nil {
+		return nil, argError("Плотность, г/мл")
+	}
+
+	return &res, nil
+}
+
+type args_f_st struct {
+	val0 string
+	}
+
+func (r *CmdProcessor) parseArgs_f_st(parts []string) (*args_f_st, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "ключ"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_f_st
+	
+	res.val0, err = parseStringG0(unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Ключ")
+	}
+
+	return &res, nil
+}
+
+type args_f_find struct {
+	val0 string
+	val1 storage.ReportFormat
+	}
+
+func (r *CmdProcessor) parseArgs_f_find(parts []string) (*args_f_find, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "подстрока"},
+		{key: "формат", optional: true, def: ""},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_f_find
+	
+	res.val0, err = parseStringGE0(unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Подстрока")
+	}
+	
+	res.val1, err = parseReportFormat(unquoteArg(args[1]))
+	if err != nil {
+		return nil, argError("Формат")
+	}
+
+	return &res, nil
+}
+
+type args_f_calc struct {
+	val0 string
+	val1 storage.FoodAmount
+	}
+
+func (r *CmdProcessor) parseArgs_f_calc(parts []string) (*args_f_calc, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "ключ"},
+		{key: "количество"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_f_calc
+	
+	res.val0, err = parseStringG0(unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Ключ")
+	}
+	
+	res.val1, err = parseFoodAmount(unquoteArg(args[1]))
+	if err != nil {
+		return nil, argError("Количество")
+	}
+
+	return &res, nil
+}
+
+type args_f_list struct {
+	val0 storage.ReportFormat
+	}
+
+func (r *CmdProcessor) parseArgs_f_list(parts []string) (*args_f_list, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "формат", optional: true, def: ""},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_f_list
+	
+	res.val0, err = parseReportFormat(unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Формат")
+	}
+
+	return &res, nil
+}
+
+type args_f_del struct {
+	val0 string
+	}
+
+func (r *CmdProcessor) parseArgs_f_del(parts []string) (*args_f_del, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "ключ"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_f_del
+	
+	res.val0, err = parseStringG0(unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Ключ")
+	}
+
+	return &res, nil
+}
+
 func (r *CmdProcessor) process_x(baseCmd string, cmdParts []string, userID int64) []CmdResponse {
 	if len(cmdParts) == 0 {
 		r.logger.Error(
@@ -543,12 +1169,12 @@ func (r *CmdProcessor) process_x(baseCmd string, cmdParts []string, userID int64
 			zap.Strings("cmdParts", cmdParts),
 			zap.Int64("userID", userID),
 		)
-		return NewSingleCmdResponse(m.MsgErrInvalidCommand)
+		return NewErrCmdResponse(m.MsgErrInvalidCommand)
 	}
 
 	var resp []CmdResponse
 
-	switch cmdParts[0] {
+	switch strings.TrimSpace(cmdParts[0]) {
 	case "backup":
 		resp = r.maintenanceBackupCommand(userID)
 				
@@ -568,7 +1194,7 @@ func (r *CmdProcessor) process_x(baseCmd string, cmdParts []string, userID int64
 			zap.Strings("cmdParts", cmdParts),
 			zap.Int64("userID", userID),
 		)
-		resp = NewSingleCmdResponse(m.MsgErrInvalidCommand)
+		resp = suggestSubCommand(baseCmd, strings.TrimSpace(cmdParts[0]))
 	}
 
 	return resp
@@ -581,57 +1207,115 @@ func (r *CmdProcessor) process_c(baseCmd string, cmdParts []string, userID int64
 			zap.Strings("cmdParts", cmdParts),
 			zap.Int64("userID", userID),
 		)
-		return NewSingleCmdResponse(m.MsgErrInvalidCommand)
+		return NewErrCmdResponse(m.MsgErrInvalidCommand)
 	}
 
 	var resp []CmdResponse
 
-	switch cmdParts[0] {
+	switch strings.TrimSpace(cmdParts[0]) {
 	case "c":
-		if len(cmdParts[1:]) != 4 {
-			return NewSingleCmdResponse(m.MsgErrInvalidArgsCount)
+		if len(cmdParts[1:]) == 0 {
+			resp = r.calcCalProfileCommand(userID)
+			break
 		}
 		
-		cmdParts = cmdParts[1:]
-		
-		val0, err := parseGender(cmdParts[0])
-		if err != nil {
-			return argError("Пол")
+		args, errResp := r.parseArgs_c_c(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		
-		val1, err := parseFloatG0(cmdParts[1])
-		if err != nil {
-			return argError("Вес")
+
+		resp = r.calcCalCalcCommand(
+			userID,
+			args.val0,
+			args.val1,
+			args.val2,
+			args.val3,
+			)
+				
+	case "tdee":
+		args, errResp := r.parseArgs_c_tdee(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		
-		val2, err := parseFloatG0(cmdParts[2])
-		if err != nil {
-			return argError("Рост")
+
+		resp = r.calcCalTDEECommand(
+			userID,
+			args.val0,
+			args.val1,
+			)
+				
+	case "tdeea":
+		args, errResp := r.parseArgs_c_tdeea(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		
-		val3, err := parseFloatG0(cmdParts[3])
-		if err != nil {
-			return argError("Возраст")
+
+		resp = r.calcCalTDEEApplyCommand(
+			userID,
+			args.val0,
+			args.val1,
+			)
+				
+	case "tdeeh":
+		args, errResp := r.parseArgs_c_tdeeh(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		
-		resp = r.calcCalCalcCommand(
+
+		resp = r.calcCalTDEEHistoryCommand(
 			userID,
-			val0,
-			val1,
-			val2,
-			val3,
+			args.val0,
+			args.val1,
+			args.val2,
 			)
 				
-	case "h":
+	case "tdeeauto":
+		args, errResp := r.parseArgs_c_tdeeauto(cmdParts[1:])
+		if errResp != nil {
+			return errResp
+		}
+
+		resp = r.calcCalTDEEAutoCommand(
+			userID,
+			args.val0,
+			)
+				
+	case "h":
 		return NewSingleCmdResponse(
 			newCmdHelpBuilder(baseCmd, "Расчет лимита калорий").
-			addCmd(
+			addCmdWithComment(
 				"Расчет",
 				"c",
+				"Без аргументов - расчет по профилю пользователя и последнему весу",
 				"Пол [Пол]",
 				"Вес [Дробное>0]",
 				"Рост [Дробное>0]",
 				"Возраст [Дробное>0]",
+				).	
+			addCmdWithComment(
+				"Оценка фактического расхода ккал (TDEE)",
+				"tdee",
+				"Оценка по журналу приема пищи и тренду веса за указанное количество недель до даты",
+				"Дата [Дата]",
+				"Недель [Целое>0]",
+				).	
+			addCmd(
+				"Оценка TDEE с установкой лимита калорий",
+				"tdeea",
+				"Дата [Дата]",
+				"Недель [Целое>0]",
+				).
+			addCmd(
+				"История оценок TDEE",
+				"tdeeh",
+				"С [Дата]",
+				"По [Дата]",
+				"Формат [Формат] (необязательно)",
+				).
+			addCmd(
+				"Еженедельное автообновление лимита калорий по TDEE",
+				"tdeeauto",
+				"Включено [Да/Нет]",
 				).
 			build(),
 		r.typeAdapter.OptsHTML())
@@ -642,12 +1326,171 @@ func (r *CmdProcessor) process_c(baseCmd string, cmdParts []string, userID int64
 			zap.Strings("cmdParts", cmdParts),
 			zap.Int64("userID", userID),
 		)
-		resp = NewSingleCmdResponse(m.MsgErrInvalidCommand)
+		resp = suggestSubCommand(baseCmd, strings.TrimSpace(cmdParts[0]))
 	}
 
 	return resp
 }
 
+type args_c_c struct {
+	val0 string
+	val1 float64
+	val2 float64
+	val3 float64
+	}
+
+func (r *CmdProcessor) parseArgs_c_c(parts []string) (*args_c_c, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "пол"},
+		{key: "вес"},
+		{key: "рост"},
+		{key: "возраст"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_c_c
+	
+	res.val0, err = parseEnum(unquoteArg(args[0]), []string{"m", "f"})
+	if err != nil {
+		return nil, argError("Пол")
+	}
+	
+	res.val1, err = parseFloatG0(unquoteArg(args[1]))
+	if err != nil {
+		return nil, argError("Вес")
+	}
+	
+	res.val2, err = parseFloatG0(unquoteArg(args[2]))
+	if err != nil {
+		return nil, argError("Рост")
+	}
+	
+	res.val3, err = parseFloatG0(unquoteArg(args[3]))
+	if err != nil {
+		return nil, argError("Возраст")
+	}
+
+	return &res, nil
+}
+
+type args_c_tdee struct {
+	val0 time.Time
+	val1 int
+	}
+
+func (r *CmdProcessor) parseArgs_c_tdee(parts []string) (*args_c_tdee, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "дата"},
+		{key: "недель"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_c_tdee
+	
+	res.val0, err = parseTimestamp(r.tz, unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Дата")
+	}
+	
+	res.val1, err = parseIntG0(unquoteArg(args[1]))
+	if err != nil {
+		return nil, argError("Недель")
+	}
+
+	return &res, nil
+}
+
+type args_c_tdeea struct {
+	val0 time.Time
+	val1 int
+	}
+
+func (r *CmdProcessor) parseArgs_c_tdeea(parts []string) (*args_c_tdeea, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "дата"},
+		{key: "недель"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_c_tdeea
+	
+	res.val0, err = parseTimestamp(r.tz, unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Дата")
+	}
+	
+	res.val1, err = parseIntG0(unquoteArg(args[1]))
+	if err != nil {
+		return nil, argError("Недель")
+	}
+
+	return &res, nil
+}
+
+type args_c_tdeeh struct {
+	val0 time.Time
+	val1 time.Time
+	val2 storage.ReportFormat
+	}
+
+func (r *CmdProcessor) parseArgs_c_tdeeh(parts []string) (*args_c_tdeeh, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "с"},
+		{key: "по"},
+		{key: "формат", optional: true, def: ""},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_c_tdeeh
+	
+	res.val0, err = parseTimestamp(r.tz, unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("С")
+	}
+	
+	res.val1, err = parseTimestamp(r.tz, unquoteArg(args[1]))
+	if err != nil {
+		return nil, argError("По")
+	}
+	
+	res.val2, err = parseReportFormat(unquoteArg(args[2]))
+	if err != nil {
+		return nil, argError("Формат")
+	}
+
+	return &res, nil
+}
+
+type args_c_tdeeauto struct {
+	val0 bool
+	}
+
+func (r *CmdProcessor) parseArgs_c_tdeeauto(parts []string) (*args_c_tdeeauto, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "включено"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_c_tdeeauto
+	
+	res.val0, err = parseBool(unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Включено")
+	}
+
+	return &res, nil
+}
+
 func (r *CmdProcessor) process_b(baseCmd string, cmdParts []string, userID int64) []CmdResponse {
 	if len(cmdParts) == 0 {
 		r.logger.Error(
@@ -655,70 +1498,55 @@ func (r *CmdProcessor) process_b(baseCmd string, cmdParts []string, userID int64
 			zap.Strings("cmdParts", cmdParts),
 			zap.Int64("userID", userID),
 		)
-		return NewSingleCmdResponse(m.MsgErrInvalidCommand)
+		return NewErrCmdResponse(m.MsgErrInvalidCommand)
 	}
 
 	var resp []CmdResponse
 
-	switch cmdParts[0] {
+	switch strings.TrimSpace(cmdParts[0]) {
 	case "set":
-		if len(cmdParts[1:]) != 2 {
-			return NewSingleCmdResponse(m.MsgErrInvalidArgsCount)
+		args, errResp := r.parseArgs_b_set(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		
-		cmdParts = cmdParts[1:]
-		
-		val0, err := parseStringG0(cmdParts[0])
-		if err != nil {
-			return argError("Ключ")
-		}
-		
-		val1, err := parseStringArr(cmdParts[1])
-		if err != nil {
-			return argError("Состав бандла")
-		}
-		
+
 		resp = r.bundleSetCommand(
 			userID,
-			val0,
-			val1,
+			args.val0,
+			args.val1,
 			)
 				
 	case "st":
-		if len(cmdParts[1:]) != 1 {
-			return NewSingleCmdResponse(m.MsgErrInvalidArgsCount)
-		}
-		
-		cmdParts = cmdParts[1:]
-		
-		val0, err := parseStringG0(cmdParts[0])
-		if err != nil {
-			return argError("Ключ")
+		args, errResp := r.parseArgs_b_st(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		
+
 		resp = r.bundleSetTemplateCommand(
 			userID,
-			val0,
+			args.val0,
 			)
 				
 	case "list":
-		resp = r.bundleListCommand(userID)
+		args, errResp := r.parseArgs_b_list(cmdParts[1:])
+		if errResp != nil {
+			return errResp
+		}
+
+		resp = r.bundleListCommand(
+			userID,
+			args.val0,
+			)
 				
 	case "del":
-		if len(cmdParts[1:]) != 1 {
-			return NewSingleCmdResponse(m.MsgErrInvalidArgsCount)
-		}
-		
-		cmdParts = cmdParts[1:]
-		
-		val0, err := parseStringG0(cmdParts[0])
-		if err != nil {
-			return argError("Ключ")
+		args, errResp := r.parseArgs_b_del(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		
+
 		resp = r.bundleDelCommand(
 			userID,
-			val0,
+			args.val0,
 			)
 				
 	case "h":
@@ -739,6 +1567,7 @@ func (r *CmdProcessor) process_b(baseCmd string, cmdParts []string, userID int64
 			addCmd(
 				"Список",
 				"list",
+				"Формат [Формат] (необязательно)",
 				).
 			addCmd(
 				"Удаление",
@@ -754,12 +1583,107 @@ func (r *CmdProcessor) process_b(baseCmd string, cmdParts []string, userID int64
 			zap.Strings("cmdParts", cmdParts),
 			zap.Int64("userID", userID),
 		)
-		resp = NewSingleCmdResponse(m.MsgErrInvalidCommand)
+		resp = suggestSubCommand(baseCmd, strings.TrimSpace(cmdParts[0]))
 	}
 
 	return resp
 }
 
+type args_b_set struct {
+	val0 string
+	val1 []string
+	}
+
+func (r *CmdProcessor) parseArgs_b_set(parts []string) (*args_b_set, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "ключ"},
+		{key: "состав_бандла"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_b_set
+	
+	res.val0, err = parseStringG0(unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Ключ")
+	}
+	
+	res.val1, err = parseStringArr(args[1])
+	if err != nil {
+		return nil, argError("Состав бандла")
+	}
+
+	return &res, nil
+}
+
+type args_b_st struct {
+	val0 string
+	}
+
+func (r *CmdProcessor) parseArgs_b_st(parts []string) (*args_b_st, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "ключ"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_b_st
+	
+	res.val0, err = parseStringG0(unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Ключ")
+	}
+
+	return &res, nil
+}
+
+type args_b_list struct {
+	val0 storage.ReportFormat
+	}
+
+func (r *CmdProcessor) parseArgs_b_list(parts []string) (*args_b_list, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "формат", optional: true, def: ""},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_b_list
+	
+	res.val0, err = parseReportFormat(unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Формат")
+	}
+
+	return &res, nil
+}
+
+type args_b_del struct {
+	val0 string
+	}
+
+func (r *CmdProcessor) parseArgs_b_del(parts []string) (*args_b_del, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "ключ"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_b_del
+	
+	res.val0, err = parseStringG0(unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Ключ")
+	}
+
+	return &res, nil
+}
+
 func (r *CmdProcessor) process_j(baseCmd string, cmdParts []string, userID int64) []CmdResponse {
 	if len(cmdParts) == 0 {
 		r.logger.Error(
@@ -767,317 +1691,198 @@ func (r *CmdProcessor) process_j(baseCmd string, cmdParts []string, userID int64
 			zap.Strings("cmdParts", cmdParts),
 			zap.Int64("userID", userID),
 		)
-		return NewSingleCmdResponse(m.MsgErrInvalidCommand)
+		return NewErrCmdResponse(m.MsgErrInvalidCommand)
 	}
 
 	var resp []CmdResponse
 
-	switch cmdParts[0] {
+	switch strings.TrimSpace(cmdParts[0]) {
 	case "set":
-		if len(cmdParts[1:]) != 4 {
-			return NewSingleCmdResponse(m.MsgErrInvalidArgsCount)
-		}
-		
-		cmdParts = cmdParts[1:]
-		
-		val0, err := parseTimestamp(r.tz, cmdParts[0])
-		if err != nil {
-			return argError("Дата")
-		}
-		
-		val1, err := parseMeal(cmdParts[1])
-		if err != nil {
-			return argError("Прием пищи")
-		}
-		
-		val2, err := parseStringG0(cmdParts[2])
-		if err != nil {
-			return argError("Ключ еды")
-		}
-		
-		val3, err := parseFloatG0(cmdParts[3])
-		if err != nil {
-			return argError("Вес")
+		args, errResp := r.parseArgs_j_set(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		
+
 		resp = r.journalSetCommand(
 			userID,
-			val0,
-			val1,
-			val2,
-			val3,
+			args.val0,
+			args.val1,
+			args.val2,
+			args.val3,
 			)
 				
 	case "sb":
-		if len(cmdParts[1:]) != 3 {
-			return NewSingleCmdResponse(m.MsgErrInvalidArgsCount)
-		}
-		
-		cmdParts = cmdParts[1:]
-		
-		val0, err := parseTimestamp(r.tz, cmdParts[0])
-		if err != nil {
-			return argError("Дата")
-		}
-		
-		val1, err := parseMeal(cmdParts[1])
-		if err != nil {
-			return argError("Прием пищи")
-		}
-		
-		val2, err := parseStringG0(cmdParts[2])
-		if err != nil {
-			return argError("Ключ бандла")
+		args, errResp := r.parseArgs_j_sb(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		
+
 		resp = r.journalSetBundleCommand(
 			userID,
-			val0,
-			val1,
-			val2,
+			args.val0,
+			args.val1,
+			args.val2,
 			)
 				
 	case "del":
-		if len(cmdParts[1:]) != 3 {
-			return NewSingleCmdResponse(m.MsgErrInvalidArgsCount)
-		}
-		
-		cmdParts = cmdParts[1:]
-		
-		val0, err := parseTimestamp(r.tz, cmdParts[0])
-		if err != nil {
-			return argError("Дата")
-		}
-		
-		val1, err := parseMeal(cmdParts[1])
-		if err != nil {
-			return argError("Прием пищи")
-		}
-		
-		val2, err := parseStringG0(cmdParts[2])
-		if err != nil {
-			return argError("Ключ еды")
+		args, errResp := r.parseArgs_j_del(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		
+
 		resp = r.journalDelCommand(
 			userID,
-			val0,
-			val1,
-			val2,
+			args.val0,
+			args.val1,
+			args.val2,
 			)
 				
 	case "dm":
-		if len(cmdParts[1:]) != 2 {
-			return NewSingleCmdResponse(m.MsgErrInvalidArgsCount)
-		}
-		
-		cmdParts = cmdParts[1:]
-		
-		val0, err := parseTimestamp(r.tz, cmdParts[0])
-		if err != nil {
-			return argError("Дата")
-		}
-		
-		val1, err := parseMeal(cmdParts[1])
-		if err != nil {
-			return argError("Прием пищи")
+		args, errResp := r.parseArgs_j_dm(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		
+
 		resp = r.journalDelMealCommand(
 			userID,
-			val0,
-			val1,
+			args.val0,
+			args.val1,
 			)
 				
 	case "db":
-		if len(cmdParts[1:]) != 3 {
-			return NewSingleCmdResponse(m.MsgErrInvalidArgsCount)
-		}
-		
-		cmdParts = cmdParts[1:]
-		
-		val0, err := parseTimestamp(r.tz, cmdParts[0])
-		if err != nil {
-			return argError("Дата")
-		}
-		
-		val1, err := parseMeal(cmdParts[1])
-		if err != nil {
-			return argError("Прием пищи")
-		}
-		
-		val2, err := parseStringG0(cmdParts[2])
-		if err != nil {
-			return argError("Ключ бандла")
+		args, errResp := r.parseArgs_j_db(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		
+
 		resp = r.journalDelBundleCommand(
 			userID,
-			val0,
-			val1,
-			val2,
+			args.val0,
+			args.val1,
+			args.val2,
 			)
 				
 	case "cp":
-		if len(cmdParts[1:]) != 4 {
-			return NewSingleCmdResponse(m.MsgErrInvalidArgsCount)
-		}
-		
-		cmdParts = cmdParts[1:]
-		
-		val0, err := parseTimestamp(r.tz, cmdParts[0])
-		if err != nil {
-			return argError("Откуда")
-		}
-		
-		val1, err := parseMeal(cmdParts[1])
-		if err != nil {
-			return argError("Откуда")
-		}
-		
-		val2, err := parseTimestamp(r.tz, cmdParts[2])
-		if err != nil {
-			return argError("Куда")
-		}
-		
-		val3, err := parseMeal(cmdParts[3])
-		if err != nil {
-			return argError("Куда")
+		args, errResp := r.parseArgs_j_cp(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		
+
 		resp = r.journalCopyCommand(
 			userID,
-			val0,
-			val1,
-			val2,
-			val3,
+			args.val0,
+			args.val1,
+			args.val2,
+			args.val3,
 			)
 				
 	case "rd":
-		if len(cmdParts[1:]) != 1 {
-			return NewSingleCmdResponse(m.MsgErrInvalidArgsCount)
-		}
-		
-		cmdParts = cmdParts[1:]
-		
-		val0, err := parseTimestamp(r.tz, cmdParts[0])
-		if err != nil {
-			return argError("Дата")
+		args, errResp := r.parseArgs_j_rd(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		
+
 		resp = r.journalReportDayCommand(
 			userID,
-			val0,
+			args.val0,
+			args.val1,
 			)
 				
 	case "rdc":
-		if len(cmdParts[1:]) != 1 {
-			return NewSingleCmdResponse(m.MsgErrInvalidArgsCount)
-		}
-		
-		cmdParts = cmdParts[1:]
-		
-		val0, err := parseTimestamp(r.tz, cmdParts[0])
-		if err != nil {
-			return argError("Дата")
+		args, errResp := r.parseArgs_j_rdc(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		
+
 		resp = r.journalReportDayCalloriesCommand(
 			userID,
-			val0,
+			args.val0,
 			)
 				
-	case "tm":
-		if len(cmdParts[1:]) != 2 {
-			return NewSingleCmdResponse(m.MsgErrInvalidArgsCount)
+	case "tr":
+		args, errResp := r.parseArgs_j_tr(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		
-		cmdParts = cmdParts[1:]
-		
-		val0, err := parseTimestamp(r.tz, cmdParts[0])
-		if err != nil {
-			return argError("Дата")
-		}
-		
-		val1, err := parseMeal(cmdParts[1])
-		if err != nil {
-			return argError("Прием пищи")
+
+		resp = r.journalTrendReportCommand(
+			userID,
+			args.val0,
+			args.val1,
+			args.val2,
+			)
+				
+	case "tm":
+		args, errResp := r.parseArgs_j_tm(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		
+
 		resp = r.journalTemplateMealCommand(
 			userID,
-			val0,
-			val1,
+			args.val0,
+			args.val1,
 			)
 				
-	case "fs":
-		if len(cmdParts[1:]) != 1 {
-			return NewSingleCmdResponse(m.MsgErrInvalidArgsCount)
+	case "sug":
+		args, errResp := r.parseArgs_j_sug(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		
-		cmdParts = cmdParts[1:]
-		
-		val0, err := parseStringG0(cmdParts[0])
-		if err != nil {
-			return argError("Ключ еды")
+
+		resp = r.journalSuggestMealCommand(
+			userID,
+			args.val0,
+			args.val1,
+			args.val2,
+			)
+				
+	case "fs":
+		args, errResp := r.parseArgs_j_fs(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		
+
 		resp = r.journalFoodStatCommand(
 			userID,
-			val0,
+			args.val0,
 			)
 				
 	case "sc":
-		if len(cmdParts[1:]) != 2 {
-			return NewSingleCmdResponse(m.MsgErrInvalidArgsCount)
+		args, errResp := r.parseArgs_j_sc(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		
-		cmdParts = cmdParts[1:]
-		
-		val0, err := parseTimestamp(r.tz, cmdParts[0])
-		if err != nil {
-			return argError("Дата")
-		}
-		
-		val1, err := parseFloatG0(cmdParts[1])
-		if err != nil {
-			return argError("ККал")
-		}
-		
+
 		resp = r.journalSetDayTotalCal(
 			userID,
-			val0,
-			val1,
+			args.val0,
+			args.val1,
 			)
 				
 	case "dc":
-		if len(cmdParts[1:]) != 1 {
-			return NewSingleCmdResponse(m.MsgErrInvalidArgsCount)
-		}
-		
-		cmdParts = cmdParts[1:]
-		
-		val0, err := parseTimestamp(r.tz, cmdParts[0])
-		if err != nil {
-			return argError("Дата")
+		args, errResp := r.parseArgs_j_dc(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		
+
 		resp = r.journalDeleteDayTotalCal(
 			userID,
-			val0,
+			args.val0,
 			)
 				
 	case "h":
 		return NewSingleCmdResponse(
 			newCmdHelpBuilder(baseCmd, "Управление журналом приема пищи").
-			addCmd(
+			addCmdWithComment(
 				"Установка",
 				"set",
+				"Количество задается весом 150 (150g), объемом 250ml или порциями еды 2egg",
 				"Дата [Дата]",
 				"Прием пищи [Прием пищи]",
 				"Ключ еды [Строка>0]",
-				"Вес [Дробное>0]",
-				).
+				"Количество [Количество]",
+				).	
 			addCmd(
 				"Установка бандлом",
 				"sb",
@@ -1117,18 +1922,34 @@ func (r *CmdProcessor) process_j(baseCmd string, cmdParts []string, userID int64
 				"Отчет за день",
 				"rd",
 				"Дата [Дата]",
+				"Формат [Формат] (необязательно)",
 				).
 			addCmd(
 				"Отчет за день по ккал",
 				"rdc",
 				"Дата [Дата]",
 				).
+			addCmd(
+				"Отчет по трендам питания и веса",
+				"tr",
+				"С [Дата]",
+				"По [Дата]",
+				"Формат [Формат] (необязательно)",
+				).
 			addCmd(
 				"Шаблоны команд приема пищи",
 				"tm",
 				"Дата [Дата]",
 				"Прием пищи [Прием пищи]",
 				).
+			addCmdWithComment(
+				"Рекомендации для приема пищи",
+				"sug",
+				"Еда, которую чаще и недавно ели в этот прием пищи за указанное количество дней до даты",
+				"Дата [Дата]",
+				"Прием пищи [Прием пищи]",
+				"Дней [Целое>0]",
+				).	
 			addCmd(
 				"Статистика по еде",
 				"fs",
@@ -1154,442 +1975,2346 @@ func (r *CmdProcessor) process_j(baseCmd string, cmdParts []string, userID int64
 			zap.Strings("cmdParts", cmdParts),
 			zap.Int64("userID", userID),
 		)
-		resp = NewSingleCmdResponse(m.MsgErrInvalidCommand)
+		resp = suggestSubCommand(baseCmd, strings.TrimSpace(cmdParts[0]))
 	}
 
 	return resp
 }
 
-func (r *CmdProcessor) process_s(baseCmd string, cmdParts []string, userID int64) []CmdResponse {
-	if len(cmdParts) == 0 {
-		r.logger.Error(
-			"invalid command",
-			zap.Strings("cmdParts", cmdParts),
-			zap.Int64("userID", userID),
-		)
-		return NewSingleCmdResponse(m.MsgErrInvalidCommand)
+type args_j_set struct {
+	val0 time.Time
+	val1 storage.Meal
+	val2 string
+	val3 storage.FoodAmount
 	}
 
-	var resp []CmdResponse
+func (r *CmdProcessor) parseArgs_j_set(parts []string) (*args_j_set, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "дата"},
+		{key: "прием_пищи"},
+		{key: "ключ_еды"},
+		{key: "количество"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
 
-	switch cmdParts[0] {
-	case "set":
-		if len(cmdParts[1:]) != 4 {
-			return NewSingleCmdResponse(m.MsgErrInvalidArgsCount)
-		}
-		
-		cmdParts = cmdParts[1:]
-		
-		val0, err := parseStringG0(cmdParts[0])
-		if err != nil {
-			return argError("Ключ")
-		}
-		
-		val1, err := parseStringG0(cmdParts[1])
-		if err != nil {
-			return argError("Наименование")
-		}
-		
-		val2, err := parseStringG0(cmdParts[2])
-		if err != nil {
-			return argError("Единица измерения")
-		}
-		
-		val3, err := parseStringGE0(cmdParts[3])
-		if err != nil {
-			return argError("Комментарий")
-		}
-		
-		resp = r.sportSetCommand(
-			userID,
-			val0,
-			val1,
-			val2,
-			val3,
-			)
-				
-	case "st":
-		if len(cmdParts[1:]) != 1 {
-			return NewSingleCmdResponse(m.MsgErrInvalidArgsCount)
-		}
-		
-		cmdParts = cmdParts[1:]
-		
-		val0, err := parseStringG0(cmdParts[0])
-		if err != nil {
-			return argError("Ключ")
-		}
-		
-		resp = r.sportSetTemplateCommand(
-			userID,
-			val0,
-			)
-				
-	case "del":
-		if len(cmdParts[1:]) != 1 {
-			return NewSingleCmdResponse(m.MsgErrInvalidArgsCount)
-		}
-		
-		cmdParts = cmdParts[1:]
-		
-		val0, err := parseStringG0(cmdParts[0])
-		if err != nil {
-			return argError("Ключ")
-		}
-		
-		resp = r.sportDelCommand(
-			userID,
-			val0,
-			)
-				
-	case "list":
-		resp = r.sportListCommand(userID)
-				
-	case "as":
-		if len(cmdParts[1:]) != 4 {
-			return NewSingleCmdResponse(m.MsgErrInvalidArgsCount)
-		}
-		
-		cmdParts = cmdParts[1:]
-		
-		val0, err := parseTimestamp(r.tz, cmdParts[0])
-		if err != nil {
-			return argError("Дата")
-		}
-		
-		val1, err := parseStringG0(cmdParts[1])
-		if err != nil {
-			return argError("Ключ спорта")
-		}
-		
-		val2, err := parseFloatArr(cmdParts[2])
-		if err != nil {
-			return argError("Подходы")
-		}
-		
-		val3, err := parseStringGE0(cmdParts[3])
-		if err != nil {
-			return argError("Комментарий")
-		}
-		
-		resp = r.sportActivitySetCommand(
-			userID,
-			val0,
-			val1,
-			val2,
-			val3,
-			)
-				
-	case "ad":
-		if len(cmdParts[1:]) != 2 {
-			return NewSingleCmdResponse(m.MsgErrInvalidArgsCount)
-		}
-		
-		cmdParts = cmdParts[1:]
-		
-		val0, err := parseTimestamp(r.tz, cmdParts[0])
-		if err != nil {
-			return argError("Дата")
-		}
-		
-		val1, err := parseStringG0(cmdParts[1])
-		if err != nil {
-			return argError("Ключ спорта")
-		}
-		
-		resp = r.sportActivityDelCommand(
-			userID,
-			val0,
-			val1,
-			)
-				
-	case "ar":
-		if len(cmdParts[1:]) != 2 {
-			return NewSingleCmdResponse(m.MsgErrInvalidArgsCount)
-		}
-		
-		cmdParts = cmdParts[1:]
-		
-		val0, err := parseTimestamp(r.tz, cmdParts[0])
-		if err != nil {
-			return argError("С")
-		}
-		
-		val1, err := parseTimestamp(r.tz, cmdParts[1])
-		if err != nil {
-			return argError("По")
-		}
-		
-		resp = r.sportActivityReportCommand(
-			userID,
-			val0,
-			val1,
-			)
-				
-	case "h":
-		return NewSingleCmdResponse(
-			newCmdHelpBuilder(baseCmd, "Управление спортом").
-			addCmd(
-				"Установка",
-				"set",
-				"Ключ [Строка>0]",
-				"Наименование [Строка>0]",
-				"Единица измерения [Строка>0]",
-				"Комментарий [Строка>=0]",
-				).
-			addCmd(
-				"Шаблон команды установки",
-				"st",
-				"Ключ [Строка>0]",
-				).
-			addCmd(
-				"Удаление",
-				"del",
-				"Ключ [Строка>0]",
-				).
-			addCmd(
-				"Список",
-				"list",
-				).
-			addCmd(
-				"Установка активности",
-				"as",
-				"Дата [Дата]",
-				"Ключ спорта [Строка>0]",
-				"Подходы [Массив дробных чисел]",
-				"Комментарий [Строка>=0]",
-				).
-			addCmd(
-				"Удаление активности",
-				"ad",
-				"Дата [Дата]",
-				"Ключ спорта [Строка>0]",
-				).
-			addCmd(
-				"Отчет по активности",
-				"ar",
-				"С [Дата]",
-				"По [Дата]",
-				).
-			build(),
-		r.typeAdapter.OptsHTML())
+	var res args_j_set
+	
+	res.val0, err = parseTimestamp(r.tz, unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Дата")
+	}
+	
+	res.val1, err = parseMeal(unquoteArg(args[1]))
+	if err != nil {
+		return nil, argError("Прием пищи")
+	}
+	
+	res.val2, err = parseStringG0(unquoteArg(args[2]))
+	if err != nil {
+		return nil, argError("Ключ еды")
+	}
+	
+	res.val3, err = parseFoodAmount(unquoteArg(args[3]))
+	if err != nil {
+		return nil, argError("Количество")
+	}
 
-	default:
-		r.logger.Error(
-			"invalid command",
-			zap.Strings("cmdParts", cmdParts),
-			zap.Int64("userID", userID),
-		)
-		resp = NewSingleCmdResponse(m.MsgErrInvalidCommand)
+	return &res, nil
+}
+
+type args_j_sb struct {
+	val0 time.Time
+	val1 storage.Meal
+	val2 string
 	}
 
-	return resp
+func (r *CmdProcessor) parseArgs_j_sb(parts []string) (*args_j_sb, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "дата"},
+		{key: "прием_пищи"},
+		{key: "ключ_бандла"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_j_sb
+	
+	res.val0, err = parseTimestamp(r.tz, unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Дата")
+	}
+	
+	res.val1, err = parseMeal(unquoteArg(args[1]))
+	if err != nil {
+		return nil, argError("Прием пищи")
+	}
+	
+	res.val2, err = parseStringG0(unquoteArg(args[2]))
+	if err != nil {
+		return nil, argError("Ключ бандла")
+	}
+
+	return &res, nil
 }
 
-func (r *CmdProcessor) process_m(baseCmd string, cmdParts []string, userID int64) []CmdResponse {
-	if len(cmdParts) == 0 {
-		r.logger.Error(
-			"invalid command",
-			zap.Strings("cmdParts", cmdParts),
-			zap.Int64("userID", userID),
-		)
-		return NewSingleCmdResponse(m.MsgErrInvalidCommand)
+type args_j_del struct {
+	val0 time.Time
+	val1 storage.Meal
+	val2 string
 	}
 
-	var resp []CmdResponse
+func (r *CmdProcessor) parseArgs_j_del(parts []string) (*args_j_del, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "дата"},
+		{key: "прием_пищи"},
+		{key: "ключ_еды"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
 
-	switch cmdParts[0] {
-	case "set":
-		if len(cmdParts[1:]) != 4 {
-			return NewSingleCmdResponse(m.MsgErrInvalidArgsCount)
-		}
-		
-		cmdParts = cmdParts[1:]
-		
-		val0, err := parseStringG0(cmdParts[0])
-		if err != nil {
-			return argError("Ключ")
-		}
-		
-		val1, err := parseStringG0(cmdParts[1])
-		if err != nil {
-			return argError("Наименование")
-		}
-		
-		val2, err := parseStringG0(cmdParts[2])
-		if err != nil {
-			return argError("Единица измерения")
-		}
-		
-		val3, err := parseStringGE0(cmdParts[3])
-		if err != nil {
-			return argError("Комментарий")
+	var res args_j_del
+	
+	res.val0, err = parseTimestamp(r.tz, unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Дата")
+	}
+	
+	res.val1, err = parseMeal(unquoteArg(args[1]))
+	if err != nil {
+		return nil, argError("Прием пищи")
+	}
+	
+	res.val2, err = parseStringG0(unquoteArg(args[2]))
+	if err != nil {
+		return nil, argError("Ключ еды")
+	}
+
+	return &res, nil
+}
+
+type args_j_dm struct {
+	val0 time.Time
+	val1 storage.Meal
+	}
+
+func (r *CmdProcessor) parseArgs_j_dm(parts []string) (*args_j_dm, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "дата"},
+		{key: "прием_пищи"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_j_dm
+	
+	res.val0, err = parseTimestamp(r.tz, unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Дата")
+	}
+	
+	res.val1, err = parseMeal(unquoteArg(args[1]))
+	if err != nil {
+		return nil, argError("Прием пищи")
+	}
+
+	return &res, nil
+}
+
+type args_j_db struct {
+	val0 time.Time
+	val1 storage.Meal
+	val2 string
+	}
+
+func (r *CmdProcessor) parseArgs_j_db(parts []string) (*args_j_db, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "дата"},
+		{key: "прием_пищи"},
+		{key: "ключ_бандла"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_j_db
+	
+	res.val0, err = parseTimestamp(r.tz, unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Дата")
+	}
+	
+	res.val1, err = parseMeal(unquoteArg(args[1]))
+	if err != nil {
+		return nil, argError("Прием пищи")
+	}
+	
+	res.val2, err = parseStringG0(unquoteArg(args[2]))
+	if err != nil {
+		return nil, argError("Ключ бандла")
+	}
+
+	return &res, nil
+}
+
+type args_j_cp struct {
+	val0 time.Time
+	val1 storage.Meal
+	val2 time.Time
+	val3 storage.Meal
+	}
+
+func (r *CmdProcessor) parseArgs_j_cp(parts []string) (*args_j_cp, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "откуда_дата"},
+		{key: "откуда_прием"},
+		{key: "куда_дата"},
+		{key: "куда_прием"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_j_cp
+	
+	res.val0, err = parseTimestamp(r.tz, unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Откуда")
+	}
+	
+	res.val1, err = parseMeal(unquoteArg(args[1]))
+	if err != nil {
+		return nil, argError("Откуда")
+	}
+	
+	res.val2, err = parseTimestamp(r.tz, unquoteArg(args[2]))
+	if err != nil {
+		return nil, argError("Куда")
+	}
+	
+	res.val3, err = parseMeal(unquoteArg(args[3]))
+	if err != nil {
+		return nil, argError("Куда")
+	}
+
+	return &res, nil
+}
+
+type args_j_rd struct {
+	val0 time.Time
+	val1 storage.ReportFormat
+	}
+
+func (r *CmdProcessor) parseArgs_j_rd(parts []string) (*args_j_rd, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "дата"},
+		{key: "формат", optional: true, def: ""},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_j_rd
+	
+	res.val0, err = parseTimestamp(r.tz, unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Дата")
+	}
+	
+	res.val1, err = parseReportFormat(unquoteArg(args[1]))
+	if err != nil {
+		return nil, argError("Формат")
+	}
+
+	return &res, nil
+}
+
+type args_j_rdc struct {
+	val0 time.Time
+	}
+
+func (r *CmdProcessor) parseArgs_j_rdc(parts []string) (*args_j_rdc, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "дата"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_j_rdc
+	
+	res.val0, err = parseTimestamp(r.tz, unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Дата")
+	}
+
+	return &res, nil
+}
+
+type args_j_tr struct {
+	val0 time.Time
+	val1 time.Time
+	val2 storage.ReportFormat
+	}
+
+func (r *CmdProcessor) parseArgs_j_tr(parts []string) (*args_j_tr, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "с"},
+		{key: "по"},
+		{key: "формат", optional: true, def: ""},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_j_tr
+	
+	res.val0, err = parseTimestamp(r.tz, unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("С")
+	}
+	
+	res.val1, err = parseTimestamp(r.tz, unquoteArg(args[1]))
+	if err != nil {
+		return nil, argError("По")
+	}
+	
+	res.val2, err = parseReportFormat(unquoteArg(args[2]))
+	if err != nil {
+		return nil, argError("Формат")
+	}
+
+	return &res, nil
+}
+
+type args_j_tm struct {
+	val0 time.Time
+	val1 storage.Meal
+	}
+
+func (r *CmdProcessor) parseArgs_j_tm(parts []string) (*args_j_tm, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "дата"},
+		{key: "прием_пищи"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_j_tm
+	
+	res.val0, err = parseTimestamp(r.tz, unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Дата")
+	}
+	
+	res.val1, err = parseMeal(unquoteArg(args[1]))
+	if err != nil {
+		return nil, argError("Прием пищи")
+	}
+
+	return &res, nil
+}
+
+type args_j_sug struct {
+	val0 time.Time
+	val1 storage.Meal
+	val2 int
+	}
+
+func (r *CmdProcessor) parseArgs_j_sug(parts []string) (*args_j_sug, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "дата"},
+		{key: "прием_пищи"},
+		{key: "дней"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_j_sug
+	
+	res.val0, err = parseTimestamp(r.tz, unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Дата")
+	}
+	
+	res.val1, err = parseMeal(unquoteArg(args[1]))
+	if err != nil {
+		return nil, argError("Прием пищи")
+	}
+	
+	res.val2, err = parseIntG0(unquoteArg(args[2]))
+	if err != nil {
+		return nil, argError("Дней")
+	}
+
+	return &res, nil
+}
+
+type args_j_fs struct {
+	val0 string
+	}
+
+func (r *CmdProcessor) parseArgs_j_fs(parts []string) (*args_j_fs, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "ключ_еды"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_j_fs
+	
+	res.val0, err = parseStringG0(unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Ключ еды")
+	}
+
+	return &res, nil
+}
+
+type args_j_sc struct {
+	val0 time.Time
+	val1 float64
+	}
+
+func (r *CmdProcessor) parseArgs_j_sc(parts []string) (*args_j_sc, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "дата"},
+		{key: "ккал"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_j_sc
+	
+	res.val0, err = parseTimestamp(r.tz, unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Дата")
+	}
+	
+	res.val1, err = parseFloatG0(unquoteArg(args[1]))
+	if err != nil {
+		return nil, argError("ККал")
+	}
+
+	return &res, nil
+}
+
+type args_j_dc struct {
+	val0 time.Time
+	}
+
+func (r *CmdProcessor) parseArgs_j_dc(parts []string) (*args_j_dc, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "дата"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_j_dc
+	
+	res.val0, err = parseTimestamp(r.tz, unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Дата")
+	}
+
+	return &res, nil
+}
+
+func (r *CmdProcessor) process_s(baseCmd string, cmdParts []string, userID int64) []CmdResponse {
+	if len(cmdParts) == 0 {
+		r.logger.Error(
+			"invalid command",
+			zap.Strings("cmdParts", cmdParts),
+			zap.Int64("userID", userID),
+		)
+		return NewErrCmdResponse(m.MsgErrInvalidCommand)
+	}
+
+	var resp []CmdResponse
+
+	switch strings.TrimSpace(cmdParts[0]) {
+	case "set":
+		args, errResp := r.parseArgs_s_set(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		
-		resp = r.medSetCommand(
+
+		resp = r.sportSetCommand(
 			userID,
-			val0,
-			val1,
-			val2,
-			val3,
+			args.val0,
+			args.val1,
+			args.val2,
+			args.val3,
 			)
 				
 	case "st":
-		if len(cmdParts[1:]) != 1 {
-			return NewSingleCmdResponse(m.MsgErrInvalidArgsCount)
-		}
-		
-		cmdParts = cmdParts[1:]
-		
-		val0, err := parseStringG0(cmdParts[0])
-		if err != nil {
-			return argError("Ключ")
+		args, errResp := r.parseArgs_s_st(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		
-		resp = r.medSetTemplateCommand(
+
+		resp = r.sportSetTemplateCommand(
 			userID,
-			val0,
+			args.val0,
 			)
 				
 	case "del":
-		if len(cmdParts[1:]) != 1 {
-			return NewSingleCmdResponse(m.MsgErrInvalidArgsCount)
+		args, errResp := r.parseArgs_s_del(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		
-		cmdParts = cmdParts[1:]
-		
-		val0, err := parseStringG0(cmdParts[0])
-		if err != nil {
-			return argError("Ключ")
+
+		resp = r.sportDelCommand(
+			userID,
+			args.val0,
+			)
+				
+	case "kind":
+		args, errResp := r.parseArgs_s_kind(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		
-		resp = r.medDelCommand(
+
+		resp = r.sportSetKindCommand(
+			userID,
+			args.val0,
+			args.val1,
+			)
+				
+	case "cal":
+		args, errResp := r.parseArgs_s_cal(cmdParts[1:])
+		if errResp != nil {
+			return errResp
+		}
+
+		resp = r.sportSetCalCommand(
 			userID,
-			val0,
+			args.val0,
+			args.val1,
+			args.val2,
 			)
 				
 	case "list":
-		resp = r.medListCommand(userID)
+		args, errResp := r.parseArgs_s_list(cmdParts[1:])
+		if errResp != nil {
+			return errResp
+		}
+
+		resp = r.sportListCommand(
+			userID,
+			args.val0,
+			)
 				
-	case "is":
-		if len(cmdParts[1:]) != 3 {
-			return NewSingleCmdResponse(m.MsgErrInvalidArgsCount)
+	case "as":
+		args, errResp := r.parseArgs_s_as(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		
-		cmdParts = cmdParts[1:]
-		
-		val0, err := parseTimestamp(r.tz, cmdParts[0])
-		if err != nil {
-			return argError("Дата")
+
+		resp = r.sportActivitySetCommand(
+			userID,
+			args.val0,
+			args.val1,
+			args.val2,
+			args.val3,
+			)
+				
+	case "asd":
+		args, errResp := r.parseArgs_s_asd(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		
-		val1, err := parseStringG0(cmdParts[1])
-		if err != nil {
-			return argError("Ключ медицины")
+
+		resp = r.sportActivitySetDurationCommand(
+			userID,
+			args.val0,
+			args.val1,
+			args.val2,
+			args.val3,
+			args.val4,
+			)
+				
+	case "al":
+		args, errResp := r.parseArgs_s_al(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		
-		val2, err := parseFloatGE0(cmdParts[2])
-		if err != nil {
-			return argError("Значениe")
+
+		resp = r.sportActivityListCommand(
+			userID,
+			args.val0,
+			)
+				
+	case "ad":
+		args, errResp := r.parseArgs_s_ad(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		
-		resp = r.medIndicatorSetCommand(
+
+		resp = r.sportActivityDelCommand(
 			userID,
-			val0,
-			val1,
-			val2,
+			args.val0,
+			args.val1,
 			)
 				
-	case "id":
-		if len(cmdParts[1:]) != 2 {
-			return NewSingleCmdResponse(m.MsgErrInvalidArgsCount)
+	case "adi":
+		args, errResp := r.parseArgs_s_adi(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		
-		cmdParts = cmdParts[1:]
-		
-		val0, err := parseTimestamp(r.tz, cmdParts[0])
-		if err != nil {
-			return argError("Дата")
+
+		resp = r.sportActivityDelByIDCommand(
+			userID,
+			args.val0,
+			)
+				
+	case "pr":
+		args, errResp := r.parseArgs_s_pr(cmdParts[1:])
+		if errResp != nil {
+			return errResp
+		}
+
+		resp = r.sportRecordsCommand(
+			userID,
+			args.val0,
+			args.val1,
+			)
+				
+	case "ar":
+		args, errResp := r.parseArgs_s_ar(cmdParts[1:])
+		if errResp != nil {
+			return errResp
+		}
+
+		resp = r.sportActivityReportCommand(
+			userID,
+			args.val0,
+			args.val1,
+			args.val2,
+			)
+				
+	case "wset":
+		args, errResp := r.parseArgs_s_wset(cmdParts[1:])
+		if errResp != nil {
+			return errResp
+		}
+
+		resp = r.sportWorkoutSetCommand(
+			userID,
+			args.val0,
+			args.val1,
+			args.val2,
+			args.val3,
+			)
+				
+	case "wst":
+		args, errResp := r.parseArgs_s_wst(cmdParts[1:])
+		if errResp != nil {
+			return errResp
+		}
+
+		resp = r.sportWorkoutSetTemplateCommand(
+			userID,
+			args.val0,
+			)
+				
+	case "wdel":
+		args, errResp := r.parseArgs_s_wdel(cmdParts[1:])
+		if errResp != nil {
+			return errResp
+		}
+
+		resp = r.sportWorkoutDelCommand(
+			userID,
+			args.val0,
+			)
+				
+	case "wlist":
+		args, errResp := r.parseArgs_s_wlist(cmdParts[1:])
+		if errResp != nil {
+			return errResp
+		}
+
+		resp = r.sportWorkoutListCommand(
+			userID,
+			args.val0,
+			)
+				
+	case "pset":
+		args, errResp := r.parseArgs_s_pset(cmdParts[1:])
+		if errResp != nil {
+			return errResp
+		}
+
+		resp = r.sportPlanSetCommand(
+			userID,
+			args.val0,
+			args.val1,
+			)
+				
+	case "pdel":
+		args, errResp := r.parseArgs_s_pdel(cmdParts[1:])
+		if errResp != nil {
+			return errResp
+		}
+
+		resp = r.sportPlanDelCommand(
+			userID,
+			args.val0,
+			)
+				
+	case "plist":
+		resp = r.sportPlanListCommand(userID)
+				
+	case "today":
+		resp = r.sportTodayCommand(userID)
+				
+	case "pc":
+		args, errResp := r.parseArgs_s_pc(cmdParts[1:])
+		if errResp != nil {
+			return errResp
+		}
+
+		resp = r.sportPlanComplianceCommand(
+			userID,
+			args.val0,
+			args.val1,
+			args.val2,
+			)
+				
+	case "h":
+		return NewSingleCmdResponse(
+			newCmdHelpBuilder(baseCmd, "Управление спортом").
+			addCmd(
+				"Установка",
+				"set",
+				"Ключ [Строка>0]",
+				"Наименование [Строка>0]",
+				"Единица измерения [Строка>0]",
+				"Комментарий [Строка>=0]",
+				).
+			addCmd(
+				"Шаблон команды установки",
+				"st",
+				"Ключ [Строка>0]",
+				).
+			addCmd(
+				"Удаление",
+				"del",
+				"Ключ [Строка>0]",
+				).
+			addCmd(
+				"Установка вида подходов",
+				"kind",
+				"Ключ [Строка>0]",
+				"Вид подходов [Вид подходов]",
+				).
+			addCmdWithComment(
+				"Установка коэффициентов расхода ккал",
+				"cal",
+				"ККал на единицу имеет приоритет над MET, для расчета по MET нужна длительность активности и вес",
+				"Ключ [Строка>0]",
+				"MET [Дробное>=0]",
+				"ККал на единицу [Дробное>=0]",
+				).	
+			addCmd(
+				"Список",
+				"list",
+				"Формат [Формат] (необязательно)",
+				).
+			addCmdWithComment(
+				"Добавление активности",
+				"as",
+				"Каждая активность добавляется отдельно, ID для удаления выводит команда al",
+				"Дата [Дата]",
+				"Ключ спорта [Строка>0]",
+				"Подходы [Подходы]",
+				"Комментарий [Строка>=0]",
+				).	
+			addCmd(
+				"Добавление активности с длительностью",
+				"asd",
+				"Дата [Дата]",
+				"Ключ спорта [Строка>0]",
+				"Подходы [Подходы]",
+				"Длительность мин [Дробное>=0]",
+				"Комментарий [Строка>=0]",
+				).
+			addCmd(
+				"Список активностей за день",
+				"al",
+				"Дата [Дата]",
+				).
+			addCmd(
+				"Удаление всех активностей спорта за день",
+				"ad",
+				"Дата [Дата]",
+				"Ключ спорта [Строка>0]",
+				).
+			addCmd(
+				"Удаление активности по ID",
+				"adi",
+				"ID [Целое>0]",
+				).
+			addCmdWithComment(
+				"Рекорды и прогресс",
+				"pr",
+				"Расчетный 1ПМ по формуле Эпли для подходов повторения x вес",
+				"Ключ спорта [Строка>0]",
+				"Формат [Формат] (необязательно)",
+				).	
+			addCmd(
+				"Отчет по активности",
+				"ar",
+				"С [Дата]",
+				"По [Дата]",
+				"Формат [Формат] (необязательно)",
+				).
+			addCmdWithComment(
+				"Установка тренировки",
+				"wset",
+				"Пример упражнений - bench=8x60/8x60;pushup=20/20",
+				"Ключ [Строка>0]",
+				"Наименование [Строка>0]",
+				"Упражнения [Упражнения]",
+				"Комментарий [Строка>=0]",
+				).	
+			addCmd(
+				"Шаблон команды установки тренировки",
+				"wst",
+				"Ключ [Строка>0]",
+				).
+			addCmd(
+				"Удаление тренировки",
+				"wdel",
+				"Ключ [Строка>0]",
+				).
+			addCmd(
+				"Список тренировок",
+				"wlist",
+				"Формат [Формат] (необязательно)",
+				).
+			addCmd(
+				"Установка тренировки в план на день недели",
+				"pset",
+				"День недели [День недели]",
+				"Ключ тренировки [Строка>0]",
+				).
+			addCmd(
+				"Удаление дня недели из плана",
+				"pdel",
+				"День недели [День недели]",
+				).
+			addCmd(
+				"План тренировок",
+				"plist",
+				).
+			addCmdWithComment(
+				"Тренировка на сегодня",
+				"today",
+				"Выводит готовые команды установки активности по плану",
+				).	
+			addCmd(
+				"Отчет о выполнении плана",
+				"pc",
+				"С [Дата]",
+				"По [Дата]",
+				"Формат [Формат] (необязательно)",
+				).
+			build(),
+		r.typeAdapter.OptsHTML())
+
+	default:
+		r.logger.Error(
+			"invalid command",
+			zap.Strings("cmdParts", cmdParts),
+			zap.Int64("userID", userID),
+		)
+		resp = suggestSubCommand(baseCmd, strings.TrimSpace(cmdParts[0]))
+	}
+
+	return resp
+}
+
+type args_s_set struct {
+	val0 string
+	val1 string
+	val2 string
+	val3 string
+	}
+
+func (r *CmdProcessor) parseArgs_s_set(parts []string) (*args_s_set, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "ключ"},
+		{key: "наименование"},
+		{key: "единица_измерения"},
+		{key: "комментарий"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_s_set
+	
+	res.val0, err = parseStringG0(unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Ключ")
+	}
+	
+	res.val1, err = parseStringG0(unquoteArg(args[1]))
+	if err != nil {
+		return nil, argError("Наименование")
+	}
+	
+	res.val2, err = parseStringG0(unquoteArg(args[2]))
+	if err != nil {
+		return nil, argError("Единица измерения")
+	}
+	
+	res.val3, err = parseStringGE0(unquoteArg(args[3]))
+	if err != nil {
+		return nil, argError("Комментарий")
+	}
+
+	return &res, nil
+}
+
+type args_s_st struct {
+	val0 string
+	}
+
+func (r *CmdProcessor) parseArgs_s_st(parts []string) (*args_s_st, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "ключ"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_s_st
+	
+	res.val0, err = parseStringG0(unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Ключ")
+	}
+
+	return &res, nil
+}
+
+type args_s_del struct {
+	val0 string
+	}
+
+func (r *CmdProcessor) parseArgs_s_del(parts []string) (*args_s_del, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "ключ"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_s_del
+	
+	res.val0, err = parseStringG0(unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Ключ")
+	}
+
+	return &res, nil
+}
+
+type args_s_kind struct {
+	val0 string
+	val1 storage.SportSetKind
+	}
+
+func (r *CmdProcessor) parseArgs_s_kind(parts []string) (*args_s_kind, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "ключ"},
+		{key: "вид_подходов"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_s_kind
+	
+	res.val0, err = parseStringG0(unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Ключ")
+	}
+	
+	res.val1, err = parseSportSetKind(unquoteArg(args[1]))
+	if err != nil {
+		return nil, argError("Вид подходов")
+	}
+
+	return &res, nil
+}
+
+type args_s_cal struct {
+	val0 string
+	val1 float64
+	val2 float64
+	}
+
+func (r *CmdProcessor) parseArgs_s_cal(parts []string) (*args_s_cal, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "ключ"},
+		{key: "met"},
+		{key: "ккал_на_единицу"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_s_cal
+	
+	res.val0, err = parseStringG0(unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Ключ")
+	}
+	
+	res.val1, err = parseFloatGE0(unquoteArg(args[1]))
+	if err != nil {
+		return nil, argError("MET")
+	}
+	
+	res.val2, err = parseFloatGE0(unquoteArg(args[2]))
+	if err != nil {
+		return nil, argError("ККал на единицу")
+	}
+
+	return &res, nil
+}
+
+type args_s_list struct {
+	val0 storage.ReportFormat
+	}
+
+func (r *CmdProcessor) parseArgs_s_list(parts []string) (*args_s_list, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "формат", optional: true, def: ""},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_s_list
+	
+	res.val0, err = parseReportFormat(unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Формат")
+	}
+
+	return &res, nil
+}
+
+type args_s_as struct {
+	val0 time.Time
+	val1 string
+	val2 []storage.SportSet
+	val3 string
+	}
+
+func (r *CmdProcessor) parseArgs_s_as(parts []string) (*args_s_as, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "дата"},
+		{key: "ключ_спорта"},
+		{key: "подходы"},
+		{key: "комментарий"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_s_as
+	
+	res.val0, err = parseTimestamp(r.tz, unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Дата")
+	}
+	
+	res.val1, err = parseStringG0(unquoteArg(args[1]))
+	if err != nil {
+		return nil, argError("Ключ спорта")
+	}
+	
+	res.val2, err = parseSportSets(unquoteArg(args[2]))
+	if err != nil {
+		return nil, argError("Подходы")
+	}
+	
+	res.val3, err = parseStringGE0(unquoteArg(args[3]))
+	if err != nil {
+		return nil, argError("Комментарий")
+	}
+
+	return &res, nil
+}
+
+type args_s_asd struct {
+	val0 time.Time
+	val1 string
+	val2 []storage.SportSet
+	val3 float64
+	val4 string
+	}
+
+func (r *CmdProcessor) parseArgs_s_asd(parts []string) (*args_s_asd, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "дата"},
+		{key: "ключ_спорта"},
+		{key: "подходы"},
+		{key: "длительность_мин"},
+		{key: "комментарий"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_s_asd
+	
+	res.val0, err = parseTimestamp(r.tz, unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Дата")
+	}
+	
+	res.val1, err = parseStringG0(unquoteArg(args[1]))
+	if err != nil {
+		return nil, argError("Ключ спорта")
+	}
+	
+	res.val2, err = parseSportSets(unquoteArg(args[2]))
+	if err != nil {
+		return nil, argError("Подходы")
+	}
+	
+	res.val3, err = parseFloatGE0(unquoteArg(args[3]))
+	if err != nil {
+		return nil, argError("Длительность мин")
+	}
+	
+	res.val4, err = parseStringGE0(unquoteArg(args[4]))
+	if err != nil {
+		return nil, argError("Комментарий")
+	}
+
+	return &res, nil
+}
+
+type args_s_al struct {
+	val0 time.Time
+	}
+
+func (r *CmdProcessor) parseArgs_s_al(parts []string) (*args_s_al, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "дата"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_s_al
+	
+	res.val0, err = parseTimestamp(r.tz, unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Дата")
+	}
+
+	return &res, nil
+}
+
+type args_s_ad struct {
+	val0 time.Time
+	val1 string
+	}
+
+func (r *CmdProcessor) parseArgs_s_ad(parts []string) (*args_s_ad, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "дата"},
+		{key: "ключ_спорта"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_s_ad
+	
+	res.val0, err = parseTimestamp(r.tz, unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Дата")
+	}
+	
+	res.val1, err = parseStringG0(unquoteArg(args[1]))
+	if err != nil {
+		return nil, argError("Ключ спорта")
+	}
+
+	return &res, nil
+}
+
+type args_s_adi struct {
+	val0 int
+	}
+
+func (r *CmdProcessor) parseArgs_s_adi(parts []string) (*args_s_adi, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "id"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_s_adi
+	
+	res.val0, err = parseIntG0(unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("ID")
+	}
+
+	return &res, nil
+}
+
+type args_s_pr struct {
+	val0 string
+	val1 storage.ReportFormat
+	}
+
+func (r *CmdProcessor) parseArgs_s_pr(parts []string) (*args_s_pr, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "ключ_спорта"},
+		{key: "формат", optional: true, def: ""},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_s_pr
+	
+	res.val0, err = parseStringG0(unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Ключ спорта")
+	}
+	
+	res.val1, err = parseReportFormat(unquoteArg(args[1]))
+	if err != nil {
+		return nil, argError("Формат")
+	}
+
+	return &res, nil
+}
+
+type args_s_ar struct {
+	val0 time.Time
+	val1 time.Time
+	val2 storage.ReportFormat
+	}
+
+func (r *CmdProcessor) parseArgs_s_ar(parts []string) (*args_s_ar, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "с"},
+		{key: "по"},
+		{key: "формат", optional: true, def: ""},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_s_ar
+	
+	res.val0, err = parseTimestamp(r.tz, unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("С")
+	}
+	
+	res.val1, err = parseTimestamp(r.tz, unquoteArg(args[1]))
+	if err != nil {
+		return nil, argError("По")
+	}
+	
+	res.val2, err = parseReportFormat(unquoteArg(args[2]))
+	if err != nil {
+		return nil, argError("Формат")
+	}
+
+	return &res, nil
+}
+
+type args_s_wset struct {
+	val0 string
+	val1 string
+	val2 []storage.WorkoutItem
+	val3 string
+	}
+
+func (r *CmdProcessor) parseArgs_s_wset(parts []string) (*args_s_wset, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "ключ"},
+		{key: "наименование"},
+		{key: "упражнения"},
+		{key: "комментарий"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_s_wset
+	
+	res.val0, err = parseStringG0(unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Ключ")
+	}
+	
+	res.val1, err = parseStringG0(unquoteArg(args[1]))
+	if err != nil {
+		return nil, argError("Наименование")
+	}
+	
+	res.val2, err = parseWorkoutItems(unquoteArg(args[2]))
+	if err != nil {
+		return nil, argError("Упражнения")
+	}
+	
+	res.val3, err = parseStringGE0(unquoteArg(args[3]))
+	if err != nil {
+		return nil, argError("Комментарий")
+	}
+
+	return &res, nil
+}
+
+type args_s_wst struct {
+	val0 string
+	}
+
+func (r *CmdProcessor) parseArgs_s_wst(parts []string) (*args_s_wst, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "ключ"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_s_wst
+	
+	res.val0, err = parseStringG0(unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Ключ")
+	}
+
+	return &res, nil
+}
+
+type args_s_wdel struct {
+	val0 string
+	}
+
+func (r *CmdProcessor) parseArgs_s_wdel(parts []string) (*args_s_wdel, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "ключ"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_s_wdel
+	
+	res.val0, err = parseStringG0(unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Ключ")
+	}
+
+	return &res, nil
+}
+
+type args_s_wlist struct {
+	val0 storage.ReportFormat
+	}
+
+func (r *CmdProcessor) parseArgs_s_wlist(parts []string) (*args_s_wlist, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "формат", optional: true, def: ""},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_s_wlist
+	
+	res.val0, err = parseReportFormat(unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Формат")
+	}
+
+	return &res, nil
+}
+
+type args_s_pset struct {
+	val0 time.Weekday
+	val1 string
+	}
+
+func (r *CmdProcessor) parseArgs_s_pset(parts []string) (*args_s_pset, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "день_недели"},
+		{key: "ключ_тренировки"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_s_pset
+	
+	res.val0, err = parseWeekday(unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("День недели")
+	}
+	
+	res.val1, err = parseStringG0(unquoteArg(args[1]))
+	if err != nil {
+		return nil, argError("Ключ тренировки")
+	}
+
+	return &res, nil
+}
+
+type args_s_pdel struct {
+	val0 time.Weekday
+	}
+
+func (r *CmdProcessor) parseArgs_s_pdel(parts []string) (*args_s_pdel, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "день_недели"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_s_pdel
+	
+	res.val0, err = parseWeekday(unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("День недели")
+	}
+
+	return &res, nil
+}
+
+type args_s_pc struct {
+	val0 time.Time
+	val1 time.Time
+	val2 storage.ReportFormat
+	}
+
+func (r *CmdProcessor) parseArgs_s_pc(parts []string) (*args_s_pc, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "с"},
+		{key: "по"},
+		{key: "формат", optional: true, def: ""},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_s_pc
+	
+	res.val0, err = parseTimestamp(r.tz, unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("С")
+	}
+	
+	res.val1, err = parseTimestamp(r.tz, unquoteArg(args[1]))
+	if err != nil {
+		return nil, argError("По")
+	}
+	
+	res.val2, err = parseReportFormat(unquoteArg(args[2]))
+	if err != nil {
+		return nil, argError("Формат")
+	}
+
+	return &res, nil
+}
+
+func (r *CmdProcessor) process_m(baseCmd string, cmdParts []string, userID int64) []CmdResponse {
+	if len(cmdParts) == 0 {
+		r.logger.Error(
+			"invalid command",
+			zap.Strings("cmdParts", cmdParts),
+			zap.Int64("userID", userID),
+		)
+		return NewErrCmdResponse(m.MsgErrInvalidCommand)
+	}
+
+	var resp []CmdResponse
+
+	switch strings.TrimSpace(cmdParts[0]) {
+	case "set":
+		args, errResp := r.parseArgs_m_set(cmdParts[1:])
+		if errResp != nil {
+			return errResp
+		}
+
+		resp = r.medSetCommand(
+			userID,
+			args.val0,
+			args.val1,
+			args.val2,
+			args.val3,
+			)
+				
+	case "st":
+		args, errResp := r.parseArgs_m_st(cmdParts[1:])
+		if errResp != nil {
+			return errResp
+		}
+
+		resp = r.medSetTemplateCommand(
+			userID,
+			args.val0,
+			)
+				
+	case "del":
+		args, errResp := r.parseArgs_m_del(cmdParts[1:])
+		if errResp != nil {
+			return errResp
+		}
+
+		resp = r.medDelCommand(
+			userID,
+			args.val0,
+			)
+				
+	case "list":
+		args, errResp := r.parseArgs_m_list(cmdParts[1:])
+		if errResp != nil {
+			return errResp
+		}
+
+		resp = r.medListCommand(
+			userID,
+			args.val0,
+			)
+				
+	case "is":
+		args, errResp := r.parseArgs_m_is(cmdParts[1:])
+		if errResp != nil {
+			return errResp
+		}
+
+		resp = r.medIndicatorSetCommand(
+			userID,
+			args.val0,
+			args.val1,
+			args.val2,
+			)
+				
+	case "id":
+		args, errResp := r.parseArgs_m_id(cmdParts[1:])
+		if errResp != nil {
+			return errResp
+		}
+
+		resp = r.medIndicatorDelCommand(
+			userID,
+			args.val0,
+			args.val1,
+			)
+				
+	case "ir":
+		args, errResp := r.parseArgs_m_ir(cmdParts[1:])
+		if errResp != nil {
+			return errResp
+		}
+
+		resp = r.medIndicatorReportCommand(
+			userID,
+			args.val0,
+			args.val1,
+			args.val2,
+			)
+				
+	case "h":
+		return NewSingleCmdResponse(
+			newCmdHelpBuilder(baseCmd, "Управление медициной").
+			addCmd(
+				"Установка",
+				"set",
+				"Ключ [Строка>0]",
+				"Наименование [Строка>0]",
+				"Единица измерения [Строка>0]",
+				"Комментарий [Строка>=0]",
+				).
+			addCmd(
+				"Шаблон команды установки",
+				"st",
+				"Ключ [Строка>0]",
+				).
+			addCmd(
+				"Удаление",
+				"del",
+				"Ключ [Строка>0]",
+				).
+			addCmd(
+				"Список",
+				"list",
+				"Формат [Формат] (необязательно)",
+				).
+			addCmd(
+				"Установка показателя",
+				"is",
+				"Дата [Дата]",
+				"Ключ медицины [Строка>0]",
+				"Значениe [Дробное>=0]",
+				).
+			addCmd(
+				"Удаление показателя",
+				"id",
+				"Дата [Дата]",
+				"Ключ спорта [Строка>0]",
+				).
+			addCmd(
+				"Отчет по показателям",
+				"ir",
+				"С [Дата]",
+				"По [Дата]",
+				"Формат [Формат] (необязательно)",
+				).
+			build(),
+		r.typeAdapter.OptsHTML())
+
+	default:
+		r.logger.Error(
+			"invalid command",
+			zap.Strings("cmdParts", cmdParts),
+			zap.Int64("userID", userID),
+		)
+		resp = suggestSubCommand(baseCmd, strings.TrimSpace(cmdParts[0]))
+	}
+
+	return resp
+}
+
+type args_m_set struct {
+	val0 string
+	val1 string
+	val2 string
+	val3 string
+	}
+
+func (r *CmdProcessor) parseArgs_m_set(parts []string) (*args_m_set, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "ключ"},
+		{key: "наименование"},
+		{key: "единица_измерения"},
+		{key: "комментарий"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_m_set
+	
+	res.val0, err = parseStringG0(unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Ключ")
+	}
+	
+	res.val1, err = parseStringG0(unquoteArg(args[1]))
+	if err != nil {
+		return nil, argError("Наименование")
+	}
+	
+	res.val2, err = parseStringG0(unquoteArg(args[2]))
+	if err != nil {
+		return nil, argError("Единица измерения")
+	}
+	
+	res.val3, err = parseStringGE0(unquoteArg(args[3]))
+	if err != nil {
+		return nil, argError("Комментарий")
+	}
+
+	return &res, nil
+}
+
+type args_m_st struct {
+	val0 string
+	}
+
+func (r *CmdProcessor) parseArgs_m_st(parts []string) (*args_m_st, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "ключ"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_m_st
+	
+	res.val0, err = parseStringG0(unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Ключ")
+	}
+
+	return &res, nil
+}
+
+type args_m_del struct {
+	val0 string
+	}
+
+func (r *CmdProcessor) parseArgs_m_del(parts []string) (*args_m_del, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "ключ"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_m_del
+	
+	res.val0, err = parseStringG0(unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Ключ")
+	}
+
+	return &res, nil
+}
+
+type args_m_list struct {
+	val0 storage.ReportFormat
+	}
+
+func (r *CmdProcessor) parseArgs_m_list(parts []string) (*args_m_list, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "формат", optional: true, def: ""},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_m_list
+	
+	res.val0, err = parseReportFormat(unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Формат")
+	}
+
+	return &res, nil
+}
+
+type args_m_is struct {
+	val0 time.Time
+	val1 string
+	val2 float64
+	}
+
+func (r *CmdProcessor) parseArgs_m_is(parts []string) (*args_m_is, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "дата"},
+		{key: "ключ_медицины"},
+		{key: "значениe"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_m_is
+	
+	res.val0, err = parseTimestamp(r.tz, unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Дата")
+	}
+	
+	res.val1, err = parseStringG0(unquoteArg(args[1]))
+	if err != nil {
+		return nil, argError("Ключ медицины")
+	}
+	
+	res.val2, err = parseFloatGE0(unquoteArg(args[2]))
+	if err != nil {
+		return nil, argError("Значениe")
+	}
+
+	return &res, nil
+}
+
+type args_m_id struct {
+	val0 time.Time
+	val1 string
+	}
+
+func (r *CmdProcessor) parseArgs_m_id(parts []string) (*args_m_id, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "дата"},
+		{key: "ключ_спорта"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_m_id
+	
+	res.val0, err = parseTimestamp(r.tz, unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Дата")
+	}
+	
+	res.val1, err = parseStringG0(unquoteArg(args[1]))
+	if err != nil {
+		return nil, argError("Ключ спорта")
+	}
+
+	return &res, nil
+}
+
+type args_m_ir struct {
+	val0 time.Time
+	val1 time.Time
+	val2 storage.ReportFormat
+	}
+
+func (r *CmdProcessor) parseArgs_m_ir(parts []string) (*args_m_ir, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "с"},
+		{key: "по"},
+		{key: "формат", optional: true, def: ""},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_m_ir
+	
+	res.val0, err = parseTimestamp(r.tz, unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("С")
+	}
+	
+	res.val1, err = parseTimestamp(r.tz, unquoteArg(args[1]))
+	if err != nil {
+		return nil, argError("По")
+	}
+	
+	res.val2, err = parseReportFormat(unquoteArg(args[2]))
+	if err != nil {
+		return nil, argError("Формат")
+	}
+
+	return &res, nil
+}
+
+func (r *CmdProcessor) process_wa(baseCmd string, cmdParts []string, userID int64) []CmdResponse {
+	if len(cmdParts) == 0 {
+		r.logger.Error(
+			"invalid command",
+			zap.Strings("cmdParts", cmdParts),
+			zap.Int64("userID", userID),
+		)
+		return NewErrCmdResponse(m.MsgErrInvalidCommand)
+	}
+
+	var resp []CmdResponse
+
+	switch strings.TrimSpace(cmdParts[0]) {
+	case "add", "a":
+		args, errResp := r.parseArgs_wa_add(cmdParts[1:])
+		if errResp != nil {
+			return errResp
+		}
+
+		resp = r.waterAddCommand(
+			userID,
+			args.val0,
+			args.val1,
+			)
+				
+	case "del":
+		args, errResp := r.parseArgs_wa_del(cmdParts[1:])
+		if errResp != nil {
+			return errResp
+		}
+
+		resp = r.waterDelCommand(
+			userID,
+			args.val0,
+			)
+				
+	case "list":
+		args, errResp := r.parseArgs_wa_list(cmdParts[1:])
+		if errResp != nil {
+			return errResp
+		}
+
+		resp = r.waterListCommand(
+			userID,
+			args.val0,
+			)
+				
+	case "h":
+		return NewSingleCmdResponse(
+			newCmdHelpBuilder(baseCmd, "Управление водой").
+			addCmdWithComment(
+				"Добавление",
+				"add",
+				"Несколько объемов складываются",
+				"Дата [Дата]",
+				"Объем, мл [Дробное>0] (одно или несколько значений)",
+				).	
+			addCmd(
+				"Удаление за день",
+				"del",
+				"Дата [Дата]",
+				).
+			addCmd(
+				"Отчет за день",
+				"list",
+				"Дата [Дата]",
+				).
+			build(),
+		r.typeAdapter.OptsHTML())
+
+	default:
+		r.logger.Error(
+			"invalid command",
+			zap.Strings("cmdParts", cmdParts),
+			zap.Int64("userID", userID),
+		)
+		resp = suggestSubCommand(baseCmd, strings.TrimSpace(cmdParts[0]))
+	}
+
+	return resp
+}
+
+type args_wa_add struct {
+	val0 time.Time
+	val1 []float64
+	}
+
+func (r *CmdProcessor) parseArgs_wa_add(parts []string) (*args_wa_add, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "дата"},
+		{key: "объем_мл", variadic: true},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_wa_add
+	
+	res.val0, err = parseTimestamp(r.tz, unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Дата")
+	}
+	
+	for _, arg := range args[1:] {
+		val, err := parseFloatG0(unquoteArg(arg))
+		if err != nil {
+			return nil, argError("Объем, мл")
+		}
+		res.val1 = append(res.val1, val)
+	}
+
+	return &res, nil
+}
+
+type args_wa_del struct {
+	val0 time.Time
+	}
+
+func (r *CmdProcessor) parseArgs_wa_del(parts []string) (*args_wa_del, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "дата"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_wa_del
+	
+	res.val0, err = parseTimestamp(r.tz, unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Дата")
+	}
+
+	return &res, nil
+}
+
+type args_wa_list struct {
+	val0 time.Time
+	}
+
+func (r *CmdProcessor) parseArgs_wa_list(parts []string) (*args_wa_list, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "дата"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_wa_list
+	
+	res.val0, err = parseTimestamp(r.tz, unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Дата")
+	}
+
+	return &res, nil
+}
+
+func (r *CmdProcessor) process_sl(baseCmd string, cmdParts []string, userID int64) []CmdResponse {
+	if len(cmdParts) == 0 {
+		r.logger.Error(
+			"invalid command",
+			zap.Strings("cmdParts", cmdParts),
+			zap.Int64("userID", userID),
+		)
+		return NewErrCmdResponse(m.MsgErrInvalidCommand)
+	}
+
+	var resp []CmdResponse
+
+	switch strings.TrimSpace(cmdParts[0]) {
+	case "set":
+		args, errResp := r.parseArgs_sl_set(cmdParts[1:])
+		if errResp != nil {
+			return errResp
+		}
+
+		resp = r.sleepSetCommand(
+			userID,
+			args.val0,
+			args.val1,
+			args.val2,
+			args.val3,
+			)
+				
+	case "st":
+		args, errResp := r.parseArgs_sl_st(cmdParts[1:])
+		if errResp != nil {
+			return errResp
+		}
+
+		resp = r.sleepSetTemplateCommand(
+			userID,
+			args.val0,
+			)
+				
+	case "del":
+		args, errResp := r.parseArgs_sl_del(cmdParts[1:])
+		if errResp != nil {
+			return errResp
+		}
+
+		resp = r.sleepDelCommand(
+			userID,
+			args.val0,
+			)
+				
+	case "r":
+		args, errResp := r.parseArgs_sl_r(cmdParts[1:])
+		if errResp != nil {
+			return errResp
+		}
+
+		resp = r.sleepReportCommand(
+			userID,
+			args.val0,
+			args.val1,
+			args.val2,
+			)
+				
+	case "h":
+		return NewSingleCmdResponse(
+			newCmdHelpBuilder(baseCmd, "Управление сном").
+			addCmdWithComment(
+				"Установка",
+				"set",
+				"Сон записывается на дату подъема",
+				"Отбой [Дата]",
+				"Подъем [Дата]",
+				"Качество [Целое>0] (от 1, до 5)",
+				"Заметки [Строка>=0] (необязательно)",
+				).	
+			addCmd(
+				"Шаблон команды установки",
+				"st",
+				"Дата подъема [Дата]",
+				).
+			addCmd(
+				"Удаление",
+				"del",
+				"Дата подъема [Дата]",
+				).
+			addCmd(
+				"Отчет",
+				"r",
+				"С [Дата]",
+				"По [Дата]",
+				"Формат [Формат] (необязательно)",
+				).
+			build(),
+		r.typeAdapter.OptsHTML())
+
+	default:
+		r.logger.Error(
+			"invalid command",
+			zap.Strings("cmdParts", cmdParts),
+			zap.Int64("userID", userID),
+		)
+		resp = suggestSubCommand(baseCmd, strings.TrimSpace(cmdParts[0]))
+	}
+
+	return resp
+}
+
+type args_sl_set struct {
+	val0 time.Time
+	val1 time.Time
+	val2 int
+	val3 string
+	}
+
+func (r *CmdProcessor) parseArgs_sl_set(parts []string) (*args_sl_set, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "отбой"},
+		{key: "подъем"},
+		{key: "качество"},
+		{key: "заметки", optional: true, def: ""},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_sl_set
+	
+	res.val0, err = parseTimestamp(r.tz, unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Отбой")
+	}
+	
+	res.val1, err = parseTimestamp(r.tz, unquoteArg(args[1]))
+	if err != nil {
+		return nil, argError("Подъем")
+	}
+	
+	res.val2, err = parseIntG0(unquoteArg(args[2]))
+	if err == nil {
+		err = checkMin(float64(res.val2), 1)
+	}
+	if err == nil {
+		err = checkMax(float64(res.val2), 5)
+	}
+	if err != nil {
+		return nil, argError("Качество")
+	}
+	
+	res.val3, err = parseStringGE0(unquoteArg(args[3]))
+	if err != nil {
+		return nil, argError("Заметки")
+	}
+
+	return &res, nil
+}
+
+type args_sl_st struct {
+	val0 time.Time
+	}
+
+func (r *CmdProcessor) parseArgs_sl_st(parts []string) (*args_sl_st, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "дата_подъема"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_sl_st
+	
+	res.val0, err = parseTimestamp(r.tz, unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Дата подъема")
+	}
+
+	return &res, nil
+}
+
+type args_sl_del struct {
+	val0 time.Time
+	}
+
+func (r *CmdProcessor) parseArgs_sl_del(parts []string) (*args_sl_del, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "дата_подъема"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_sl_del
+	
+	res.val0, err = parseTimestamp(r.tz, unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Дата подъема")
+	}
+
+	return &res, nil
+}
+
+type args_sl_r struct {
+	val0 time.Time
+	val1 time.Time
+	val2 storage.ReportFormat
+	}
+
+func (r *CmdProcessor) parseArgs_sl_r(parts []string) (*args_sl_r, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "с"},
+		{key: "по"},
+		{key: "формат", optional: true, def: ""},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_sl_r
+	
+	res.val0, err = parseTimestamp(r.tz, unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("С")
+	}
+	
+	res.val1, err = parseTimestamp(r.tz, unquoteArg(args[1]))
+	if err != nil {
+		return nil, argError("По")
+	}
+	
+	res.val2, err = parseReportFormat(unquoteArg(args[2]))
+	if err != nil {
+		return nil, argError("Формат")
+	}
+
+	return &res, nil
+}
+
+func (r *CmdProcessor) process_fa(baseCmd string, cmdParts []string, userID int64) []CmdResponse {
+	if len(cmdParts) == 0 {
+		r.logger.Error(
+			"invalid command",
+			zap.Strings("cmdParts", cmdParts),
+			zap.Int64("userID", userID),
+		)
+		return NewErrCmdResponse(m.MsgErrInvalidCommand)
+	}
+
+	var resp []CmdResponse
+
+	switch strings.TrimSpace(cmdParts[0]) {
+	case "start":
+		if len(cmdParts[1:]) == 0 {
+			resp = r.fastStartNowCommand(userID)
+			break
+		}
+		
+		args, errResp := r.parseArgs_fa_start(cmdParts[1:])
+		if errResp != nil {
+			return errResp
+		}
+
+		resp = r.fastStartCommand(
+			userID,
+			args.val0,
+			)
+				
+	case "stop":
+		if len(cmdParts[1:]) == 0 {
+			resp = r.fastStopNowCommand(userID)
+			break
 		}
 		
-		val1, err := parseStringG0(cmdParts[1])
-		if err != nil {
-			return argError("Ключ спорта")
+		args, errResp := r.parseArgs_fa_stop(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		
-		resp = r.medIndicatorDelCommand(
+
+		resp = r.fastStopCommand(
 			userID,
-			val0,
-			val1,
+			args.val0,
 			)
 				
-	case "ir":
-		if len(cmdParts[1:]) != 2 {
-			return NewSingleCmdResponse(m.MsgErrInvalidArgsCount)
-		}
-		
-		cmdParts = cmdParts[1:]
-		
-		val0, err := parseTimestamp(r.tz, cmdParts[0])
-		if err != nil {
-			return argError("С")
+	case "cur", "status":
+		resp = r.fastCurrentCommand(userID)
+				
+	case "del":
+		args, errResp := r.parseArgs_fa_del(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		
-		val1, err := parseTimestamp(r.tz, cmdParts[1])
-		if err != nil {
-			return argError("По")
+
+		resp = r.fastDelCommand(
+			userID,
+			args.val0,
+			)
+				
+	case "r":
+		args, errResp := r.parseArgs_fa_r(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		
-		resp = r.medIndicatorReportCommand(
+
+		resp = r.fastReportCommand(
 			userID,
-			val0,
-			val1,
+			args.val0,
+			args.val1,
+			args.val2,
+			args.val3,
 			)
 				
 	case "h":
 		return NewSingleCmdResponse(
-			newCmdHelpBuilder(baseCmd, "Управление медициной").
-			addCmd(
-				"Установка",
-				"set",
-				"Ключ [Строка>0]",
-				"Наименование [Строка>0]",
-				"Единица измерения [Строка>0]",
-				"Комментарий [Строка>=0]",
-				).
+			newCmdHelpBuilder(baseCmd, "Управление голоданием").
+			addCmdWithComment(
+				"Начало",
+				"start",
+				"Без аргументов - начало с текущего времени",
+				"Начало [Дата]",
+				).	
+			addCmdWithComment(
+				"Завершение",
+				"stop",
+				"Без аргументов - завершение текущим временем",
+				"Окончание [Дата]",
+				).	
 			addCmd(
-				"Шаблон команды установки",
-				"st",
-				"Ключ [Строка>0]",
+				"Текущее голодание",
+				"cur",
 				).
-			addCmd(
+			addCmdWithComment(
 				"Удаление",
 				"del",
-				"Ключ [Строка>0]",
-				).
-			addCmd(
-				"Список",
-				"list",
-				).
-			addCmd(
-				"Установка показателя",
-				"is",
-				"Дата [Дата]",
-				"Ключ медицины [Строка>0]",
-				"Значениe [Дробное>=0]",
-				).
-			addCmd(
-				"Удаление показателя",
-				"id",
-				"Дата [Дата]",
-				"Ключ спорта [Строка>0]",
-				).
-			addCmd(
-				"Отчет по показателям",
-				"ir",
+				"Удаляются голодания, начатые в указанный день",
+				"Начало [Дата]",
+				).	
+			addCmdWithComment(
+				"Отчет по окну питания и голоданию",
+				"r",
+				"Учитываются только приемы пищи с указанным временем",
 				"С [Дата]",
 				"По [Дата]",
-				).
+				"Окно питания, ч [Дробное>0] (до 24, необязательно, по умолчанию 8)",
+				"Формат [Формат] (необязательно)",
+				).	
 			build(),
 		r.typeAdapter.OptsHTML())
 
@@ -1599,51 +4324,994 @@ func (r *CmdProcessor) process_m(baseCmd string, cmdParts []string, userID int64
 			zap.Strings("cmdParts", cmdParts),
 			zap.Int64("userID", userID),
 		)
-		resp = NewSingleCmdResponse(m.MsgErrInvalidCommand)
+		resp = suggestSubCommand(baseCmd, strings.TrimSpace(cmdParts[0]))
 	}
 
 	return resp
 }
 
+type args_fa_start struct {
+	val0 time.Time
+	}
+
+func (r *CmdProcessor) parseArgs_fa_start(parts []string) (*args_fa_start, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "начало"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_fa_start
+	
+	res.val0, err = parseTimestamp(r.tz, unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Начало")
+	}
+
+	return &res, nil
+}
+
+type args_fa_stop struct {
+	val0 time.Time
+	}
+
+func (r *CmdProcessor) parseArgs_fa_stop(parts []string) (*args_fa_stop, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "окончание"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_fa_stop
+	
+	res.val0, err = parseTimestamp(r.tz, unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Окончание")
+	}
+
+	return &res, nil
+}
+
+type args_fa_del struct {
+	val0 time.Time
+	}
+
+func (r *CmdProcessor) parseArgs_fa_del(parts []string) (*args_fa_del, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "начало"},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_fa_del
+	
+	res.val0, err = parseTimestamp(r.tz, unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("Начало")
+	}
+
+	return &res, nil
+}
+
+type args_fa_r struct {
+	val0 time.Time
+	val1 time.Time
+	val2 float64
+	val3 storage.ReportFormat
+	}
+
+func (r *CmdProcessor) parseArgs_fa_r(parts []string) (*args_fa_r, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{key: "с"},
+		{key: "по"},
+		{key: "окно_питания_ч", optional: true, def: "8"},
+		{key: "формат", optional: true, def: ""},
+		}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_fa_r
+	
+	res.val0, err = parseTimestamp(r.tz, unquoteArg(args[0]))
+	if err != nil {
+		return nil, argError("С")
+	}
+	
+	res.val1, err = parseTimestamp(r.tz, unquoteArg(args[1]))
+	if err != nil {
+		return nil, argError("По")
+	}
+	
+	res.val2, err = parseFloatG0(unquoteArg(args[2]))
+	if err == nil {
+		err = checkMax(float64(res.val2), 24)
+	}
+	if err != nil {
+		return nil, argError("Окно питания, ч")
+	}
+	
+	res.val3, err = parseReportFormat(unquoteArg(args[3]))
+	if err != nil {
+		return nil, argError("Формат")
+	}
+
+	return &res, nil
+}
+
 func (r *CmdProcessor) processHelp() []CmdResponse {
 	var sb strings.Builder
 	sb.WriteString("<b>Команды помощи по разделам:</b>\n")
-	sb.WriteString("<b>\u2022 w,h</b> - Вес\n")
-	sb.WriteString("<b>\u2022 u,h</b> - Настройки пользователя\n")
-	sb.WriteString("<b>\u2022 f,h</b> - Еда\n")
+	sb.WriteString("<b>\u2022 w,h</b> - Вес (синонимы: weight)\n")
+	sb.WriteString("<b>\u2022 u,h</b> - Настройки пользователя (синонимы: user)\n")
+	sb.WriteString("<b>\u2022 f,h</b> - Еда (синонимы: food)\n")
 	sb.WriteString("<b>\u2022 x,h</b> - Cлужебные настройки\n")
-	sb.WriteString("<b>\u2022 c,h</b> - Расчет лимита калорий\n")
-	sb.WriteString("<b>\u2022 b,h</b> - Бандлы\n")
-	sb.WriteString("<b>\u2022 j,h</b> - Журнал приема пищи\n")
-	sb.WriteString("<b>\u2022 s,h</b> - Спорт\n")
-	sb.WriteString("<b>\u2022 m,h</b> - Медицина\n")
+	sb.WriteString("<b>\u2022 c,h</b> - Расчет лимита калорий (синонимы: calc)\n")
+	sb.WriteString("<b>\u2022 b,h</b> - Бандлы (синонимы: bundle)\n")
+	sb.WriteString("<b>\u2022 j,h</b> - Журнал приема пищи (синонимы: journal)\n")
+	sb.WriteString("<b>\u2022 s,h</b> - Спорт (синонимы: sport)\n")
+	sb.WriteString("<b>\u2022 m,h</b> - Медицина (синонимы: med)\n")
+	sb.WriteString("<b>\u2022 wa,h</b> - Вода (синонимы: water)\n")
+	sb.WriteString("<b>\u2022 sl,h</b> - Сон (синонимы: sleep)\n")
+	sb.WriteString("<b>\u2022 fa,h</b> - Голодание (синонимы: fast)\n")
+	sb.WriteString("\n<b>Синтаксис:</b>\n")
+	sb.WriteString("<b>\u2022 Кавычки</b> - аргумент в двойных кавычках может содержать , и /, например \"Творог 5%, пачка\"\n")
+	sb.WriteString("<b>\u2022 Экранирование</b> - символ после \\ используется как есть, например \\, или \\\"\n")
+	sb.WriteString("<b>\u2022 Именованные аргументы</b> - после позиционных в виде имя=значение, имя - название аргумента в нижнем регистре с _ вместо пробелов и знаков препинания, например комментарий=текст или вес_г=100\n")
+	sb.WriteString("<b>\u2022 Необязательные аргументы</b> - можно не указывать, используется значение по умолчанию\n")
+	sb.WriteString("<b>\u2022 Пакет команд</b> - несколько команд по одной на строке или текстовый файл .txt, строки с # пропускаются. Первая строка !tx - выполнить все или ничего (по умолчанию), !cont - продолжать при ошибках\n")
 	sb.WriteString("\n<b>Типы данных:</b>\n")
-	sb.WriteString("<b>\u2022 Дата</b> - Дата в формате DD.MM.YYYY|пустая строка для текущей даты|целая дельта дней ± относительно текущей даты\n")
+	sb.WriteString("<b>\u2022 Дата</b> - Дата в формате DD.MM.YYYY|пустая строка для текущей даты|целая дельта дней ± относительно текущей даты|с необязательным временем HH:MM через пробел\n")
 	sb.WriteString("<b>\u2022 Дробное>0</b> - Дробное число >0\n")
+	sb.WriteString("<b>\u2022 Целое>0</b> - Целое число >0\n")
 	sb.WriteString("<b>\u2022 Дробное>=0</b> - Дробное число >=0\n")
 	sb.WriteString("<b>\u2022 Строка>0</b> - Строка длиной >0\n")
 	sb.WriteString("<b>\u2022 Строка>=0</b> - Строка длиной >=0\n")
 	sb.WriteString("<b>\u2022 Пол</b> - Пол - одно из значений m|f\n")
+	sb.WriteString("<b>\u2022 Активность</b> - Уровень активности - одно из значений 1 (сидячая)|2 (легкая)|3 (средняя)|4 (полноценная)|5 (супер)\n")
+	sb.WriteString("<b>\u2022 Цель</b> - Цель - одно из значений lose (снижение)|keep (поддержание)|gain (набор)\n")
+	sb.WriteString("<b>\u2022 Да/Нет</b> - Логическое значение - одно из значений 1|0\n")
 	sb.WriteString("<b>\u2022 Прием пищи</b> - Прием пищи - одно из значений завтрак|до обеда|обед|полдник|до ужина|ужин\n")
 	sb.WriteString("<b>\u2022 Массив строк</b> - Массив строк (разделитель /, длина > 0)\n")
-	sb.WriteString("<b>\u2022 Массив дробных чисел</b> - Массив дробных чисел (разделитель /, длина > 0)\n")
+	sb.WriteString("<b>\u2022 Подходы</b> - Подходы (разделитель /) в виде 10 (значение), 8x60 (повторения x вес), 27:30 (время), 5x27:30 (дистанция x время), с необязательной оценкой нагрузки @RPE\n")
+	sb.WriteString("<b>\u2022 Вид подходов</b> - Вид подходов - одно из значений value (значение)|reps (повторения)|reps_load (повторения x вес)|duration (время)|distance (дистанция x время)\n")
+	sb.WriteString("<b>\u2022 Упражнения</b> - Упражнения тренировки (разделитель ;) в виде ключ_спорта=подходы\n")
+	sb.WriteString("<b>\u2022 День недели</b> - День недели - число от 1 (понедельник) до 7 (воскресенье)\n")
+	sb.WriteString("<b>\u2022 Количество</b> - Количество еды - число с необязательной единицей g (граммы, по умолчанию)|ml (миллилитры)|имя порции еды\n")
+	sb.WriteString("<b>\u2022 Порции</b> - Порции еды (разделитель ;) в виде имя=количество, количество в g или ml, пустая строка - без порций\n")
+	sb.WriteString("<b>\u2022 Нутриенты</b> - Нутриенты в граммах (разделитель ;) в виде ключ=значение, ключи fiber (клетчатка)|sugar (сахар)|salt (соль)|satfat (насыщенные жиры)\n")
+	sb.WriteString("<b>\u2022 Формат</b> - Формат отчета - одно из значений html|pdf, пустая строка - формат пользователя по умолчанию\n")
+	sb.WriteString("<b>\u2022 Формат графика</b> - Формат графика - одно из значений png (фото)|svg (файл)\n")
 	return NewSingleCmdResponse(sb.String(), r.typeAdapter.OptsHTML())
 }
 
+// CommandInfo describes command in registry of commands.
+type CommandInfo struct {
+	Name             string
+	Aliases          []string
+	DescriptionShort string
+	SubCommands      []SubCommandInfo
+}
+
+// SubCommandInfo describes subcommand in registry of commands.
+type SubCommandInfo struct {
+	Name        string
+	Aliases     []string
+	Description string
+	Args        []ArgInfo
+}
+
+// ArgInfo describes subcommand arg in registry of commands, Complete is
+// source of arg values completion, Values are completions of enum arg.
+type ArgInfo struct {
+	Name     string
+	Key      string
+	Complete string
+	Values   []string
+}
+
+var _commandRegistry = []CommandInfo{
+	{
+		Name:             "w",
+		Aliases:          []string{"weight"},
+		DescriptionShort: "Вес",
+		SubCommands: []SubCommandInfo{
+			{
+				Name:        "set",
+				Description: "Установка",
+				Args: []ArgInfo{
+					{Name: "Дата", Key: "дата"},
+					{Name: "Значение", Key: "значение"},
+				},
+			},
+			{
+				Name:        "del",
+				Description: "Удаление",
+				Args: []ArgInfo{
+					{Name: "Дата", Key: "дата"},
+				},
+			},
+			{
+				Name:        "list",
+				Description: "Отчет",
+				Args: []ArgInfo{
+					{Name: "С", Key: "с"},
+					{Name: "По", Key: "по"},
+					{Name: "Формат", Key: "формат", Values: []string{"html", "pdf"}},
+				},
+			},
+			{
+				Name:        "g",
+				Description: "График за последние дни",
+				Args: []ArgInfo{
+					{Name: "Дней", Key: "дней"},
+					{Name: "Формат", Key: "формат", Values: []string{"png", "svg"}},
+				},
+			},
+		},
+	},
+	{
+		Name:             "u",
+		Aliases:          []string{"user"},
+		DescriptionShort: "Настройки пользователя",
+		SubCommands: []SubCommandInfo{
+			{
+				Name:        "set",
+				Description: "Установка",
+				Args: []ArgInfo{
+					{Name: "Лимит калорий", Key: "лимит_калорий"},
+				},
+			},
+			{
+				Name:        "st",
+				Description: "Шаблон команды установки",
+			},
+			{
+				Name:        "get",
+				Description: "Получение",
+			},
+			{
+				Name:        "prof",
+				Description: "Установка профиля",
+				Args: []ArgInfo{
+					{Name: "Пол", Key: "пол", Values: []string{"m", "f"}},
+					{Name: "Дата рождения", Key: "дата_рождения"},
+					{Name: "Рост", Key: "рост"},
+					{Name: "Активность", Key: "активность"},
+					{Name: "Процент жира", Key: "процент_жира"},
+					{Name: "Цель", Key: "цель", Values: []string{"lose", "keep", "gain"}},
+					{Name: "Темп кг/нед", Key: "темп_кг_нед"},
+				},
+			},
+			{
+				Name:        "water",
+				Description: "Установка дневной цели воды",
+				Args: []ArgInfo{
+					{Name: "Цель, мл", Key: "цель_мл"},
+					{Name: "Напоминания", Key: "напоминания"},
+				},
+			},
+			{
+				Name:        "nut",
+				Description: "Установка дневных лимитов нутриентов",
+				Args: []ArgInfo{
+					{Name: "Лимиты, г", Key: "лимиты_г"},
+				},
+			},
+			{
+				Name:        "rep",
+				Description: "Установка формата отчетов по умолчанию",
+				Args: []ArgInfo{
+					{Name: "Формат", Key: "формат", Values: []string{"html", "pdf"}},
+				},
+			},
+		},
+	},
+	{
+		Name:             "f",
+		Aliases:          []string{"food"},
+		DescriptionShort: "Еда",
+		SubCommands: []SubCommandInfo{
+			{
+				Name:        "set",
+				Description: "Установка",
+				Args: []ArgInfo{
+					{Name: "Ключ", Key: "ключ", Complete: "food"},
+					{Name: "Наименование", Key: "наименование"},
+					{Name: "Бренд", Key: "бренд"},
+					{Name: "ККал 100г", Key: "ккал_100г"},
+					{Name: "Б 100г", Key: "б_100г"},
+					{Name: "Ж 100г", Key: "ж_100г"},
+					{Name: "У 100г", Key: "у_100г"},
+					{Name: "Комментарий", Key: "комментарий"},
+					{Name: "Нутриенты 100г", Key: "нутриенты_100г"},
+				},
+			},
+			{
+				Name:        "setw",
+				Description: "Установка по весу",
+				Args: []ArgInfo{
+					{Name: "Ключ", Key: "ключ", Complete: "food"},
+					{Name: "Наименование", Key: "наименование"},
+					{Name: "Бренд", Key: "бренд"},
+					{Name: "Вес, г.", Key: "вес_г"},
+					{Name: "ККал на вес", Key: "ккал_на_вес"},
+					{Name: "Б на вес", Key: "б_на_вес"},
+					{Name: "Ж на вес", Key: "ж_на_вес"},
+					{Name: "У на вес", Key: "у_на_вес"},
+					{Name: "Комментарий", Key: "комментарий"},
+					{Name: "Нутриенты на вес", Key: "нутриенты_на_вес"},
+				},
+			},
+			{
+				Name:        "bev",
+				Description: "Установка признака напитка",
+				Args: []ArgInfo{
+					{Name: "Ключ", Key: "ключ", Complete: "food"},
+					{Name: "Напиток", Key: "напиток"},
+				},
+			},
+			{
+				Name:        "por",
+				Description: "Установка порций и плотности",
+				Args: []ArgInfo{
+					{Name: "Ключ", Key: "ключ", Complete: "food"},
+					{Name: "Порции", Key: "порции"},
+					{Name: "Плотность, г/мл", Key: "плотность_г_мл"},
+				},
+			},
+			{
+				Name:        "st",
+				Description: "Шаблон команды установки",
+				Args: []ArgInfo{
+					{Name: "Ключ", Key: "ключ", Complete: "food"},
+				},
+			},
+			{
+				Name:        "find",
+				Description: "Поиск",
+				Args: []ArgInfo{
+					{Name: "Подстрока", Key: "подстрока"},
+					{Name: "Формат", Key: "формат", Values: []string{"html", "pdf"}},
+				},
+			},
+			{
+				Name:        "calc",
+				Description: "Расчет КБЖУ",
+				Args: []ArgInfo{
+					{Name: "Ключ", Key: "ключ", Complete: "food"},
+					{Name: "Количество", Key: "количество"},
+				},
+			},
+			{
+				Name:        "list",
+				Description: "Список",
+				Args: []ArgInfo{
+					{Name: "Формат", Key: "формат", Values: []string{"html", "pdf"}},
+				},
+			},
+			{
+				Name:        "del",
+				Description: "Удаление",
+				Args: []ArgInfo{
+					{Name: "Ключ", Key: "ключ", Complete: "food"},
+				},
+			},
+		},
+	},
+	{
+		Name:             "x",
+		DescriptionShort: "Cлужебные настройки",
+		SubCommands: []SubCommandInfo{
+			{
+				Name:        "backup",
+				Description: "Бэкап",
+			},
+		},
+	},
+	{
+		Name:             "c",
+		Aliases:          []string{"calc"},
+		DescriptionShort: "Расчет лимита калорий",
+		SubCommands: []SubCommandInfo{
+			{
+				Name:        "c",
+				Description: "Расчет",
+				Args: []ArgInfo{
+					{Name: "Пол", Key: "пол", Values: []string{"m", "f"}},
+					{Name: "Вес", Key: "вес"},
+					{Name: "Рост", Key: "рост"},
+					{Name: "Возраст", Key: "возраст"},
+				},
+			},
+			{
+				Name:        "tdee",
+				Description: "Оценка фактического расхода ккал (TDEE)",
+				Args: []ArgInfo{
+					{Name: "Дата", Key: "дата"},
+					{Name: "Недель", Key: "недель"},
+				},
+			},
+			{
+				Name:        "tdeea",
+				Description: "Оценка TDEE с установкой лимита калорий",
+				Args: []ArgInfo{
+					{Name: "Дата", Key: "дата"},
+					{Name: "Недель", Key: "недель"},
+				},
+			},
+			{
+				Name:        "tdeeh",
+				Description: "История оценок TDEE",
+				Args: []ArgInfo{
+					{Name: "С", Key: "с"},
+					{Name: "По", Key: "по"},
+					{Name: "Формат", Key: "формат", Values: []string{"html", "pdf"}},
+				},
+			},
+			{
+				Name:        "tdeeauto",
+				Description: "Еженедельное автообновление лимита калорий по TDEE",
+				Args: []ArgInfo{
+					{Name: "Включено", Key: "включено"},
+				},
+			},
+		},
+	},
+	{
+		Name:             "b",
+		Aliases:          []string{"bundle"},
+		DescriptionShort: "Бандлы",
+		SubCommands: []SubCommandInfo{
+			{
+				Name:        "set",
+				Description: "Установка",
+				Args: []ArgInfo{
+					{Name: "Ключ", Key: "ключ", Complete: "bundle"},
+					{Name: "Состав бандла", Key: "состав_бандла"},
+				},
+			},
+			{
+				Name:        "st",
+				Description: "Шаблон команды установки",
+				Args: []ArgInfo{
+					{Name: "Ключ", Key: "ключ", Complete: "bundle"},
+				},
+			},
+			{
+				Name:        "list",
+				Description: "Список",
+				Args: []ArgInfo{
+					{Name: "Формат", Key: "формат", Values: []string{"html", "pdf"}},
+				},
+			},
+			{
+				Name:        "del",
+				Description: "Удаление",
+				Args: []ArgInfo{
+					{Name: "Ключ", Key: "ключ", Complete: "bundle"},
+				},
+			},
+		},
+	},
+	{
+		Name:             "j",
+		Aliases:          []string{"journal"},
+		DescriptionShort: "Журнал приема пищи",
+		SubCommands: []SubCommandInfo{
+			{
+				Name:        "set",
+				Description: "Установка",
+				Args: []ArgInfo{
+					{Name: "Дата", Key: "дата"},
+					{Name: "Прием пищи", Key: "прием_пищи", Values: []string{"завтрак", "до обеда", "обед", "полдник", "до ужина", "ужин"}},
+					{Name: "Ключ еды", Key: "ключ_еды", Complete: "food"},
+					{Name: "Количество", Key: "количество"},
+				},
+			},
+			{
+				Name:        "sb",
+				Description: "Установка бандлом",
+				Args: []ArgInfo{
+					{Name: "Дата", Key: "дата"},
+					{Name: "Прием пищи", Key: "прием_пищи", Values: []string{"завтрак", "до обеда", "обед", "полдник", "до ужина", "ужин"}},
+					{Name: "Ключ бандла", Key: "ключ_бандла", Complete: "bundle"},
+				},
+			},
+			{
+				Name:        "del",
+				Description: "Удаление",
+				Args: []ArgInfo{
+					{Name: "Дата", Key: "дата"},
+					{Name: "Прием пищи", Key: "прием_пищи", Values: []string{"завтрак", "до обеда", "обед", "полдник", "до ужина", "ужин"}},
+					{Name: "Ключ еды", Key: "ключ_еды", Complete: "food"},
+				},
+			},
+			{
+				Name:        "dm",
+				Description: "Удаление приема пищи",
+				Args: []ArgInfo{
+					{Name: "Дата", Key: "дата"},
+					{Name: "Прием пищи", Key: "прием_пищи", Values: []string{"завтрак", "до обеда", "обед", "полдник", "до ужина", "ужин"}},
+				},
+			},
+			{
+				Name:        "db",
+				Description: "Удаление бандла из журнала",
+				Args: []ArgInfo{
+					{Name: "Дата", Key: "дата"},
+					{Name: "Прием пищи", Key: "прием_пищи", Values: []string{"завтрак", "до обеда", "обед", "полдник", "до ужина", "ужин"}},
+					{Name: "Ключ бандла", Key: "ключ_бандла", Complete: "bundle"},
+				},
+			},
+			{
+				Name:        "cp",
+				Description: "Копирование",
+				Args: []ArgInfo{
+					{Name: "Откуда", Key: "откуда_дата"},
+					{Name: "Откуда", Key: "откуда_прием", Values: []string{"завтрак", "до обеда", "обед", "полдник", "до ужина", "ужин"}},
+					{Name: "Куда", Key: "куда_дата"},
+					{Name: "Куда", Key: "куда_прием", Values: []string{"завтрак", "до обеда", "обед", "полдник", "до ужина", "ужин"}},
+				},
+			},
+			{
+				Name:        "rd",
+				Description: "Отчет за день",
+				Args: []ArgInfo{
+					{Name: "Дата", Key: "дата"},
+					{Name: "Формат", Key: "формат", Values: []string{"html", "pdf"}},
+				},
+			},
+			{
+				Name:        "rdc",
+				Description: "Отчет за день по ккал",
+				Args: []ArgInfo{
+					{Name: "Дата", Key: "дата"},
+				},
+			},
+			{
+				Name:        "tr",
+				Description: "Отчет по трендам питания и веса",
+				Args: []ArgInfo{
+					{Name: "С", Key: "с"},
+					{Name: "По", Key: "по"},
+					{Name: "Формат", Key: "формат", Values: []string{"html", "pdf"}},
+				},
+			},
+			{
+				Name:        "tm",
+				Description: "Шаблоны команд приема пищи",
+				Args: []ArgInfo{
+					{Name: "Дата", Key: "дата"},
+					{Name: "Прием пищи", Key: "прием_пищи", Values: []string{"завтрак", "до обеда", "обед", "полдник", "до ужина", "ужин"}},
+				},
+			},
+			{
+				Name:        "sug",
+				Description: "Рекомендации для приема пищи",
+				Args: []ArgInfo{
+					{Name: "Дата", Key: "дата"},
+					{Name: "Прием пищи", Key: "прием_пищи", Values: []string{"завтрак", "до обеда", "обед", "полдник", "до ужина", "ужин"}},
+					{Name: "Дней", Key: "дней"},
+				},
+			},
+			{
+				Name:        "fs",
+				Description: "Статистика по еде",
+				Args: []ArgInfo{
+					{Name: "Ключ еды", Key: "ключ_еды", Complete: "food"},
+				},
+			},
+			{
+				Name:        "sc",
+				Description: "Установка значения потраченных ккал",
+				Args: []ArgInfo{
+					{Name: "Дата", Key: "дата"},
+					{Name: "ККал", Key: "ккал"},
+				},
+			},
+			{
+				Name:        "dc",
+				Description: "Удаление значения потраченных ккал",
+				Args: []ArgInfo{
+					{Name: "Дата", Key: "дата"},
+				},
+			},
+		},
+	},
+	{
+		Name:             "s",
+		Aliases:          []string{"sport"},
+		DescriptionShort: "Спорт",
+		SubCommands: []SubCommandInfo{
+			{
+				Name:        "set",
+				Description: "Установка",
+				Args: []ArgInfo{
+					{Name: "Ключ", Key: "ключ", Complete: "sport"},
+					{Name: "Наименование", Key: "наименование"},
+					{Name: "Единица измерения", Key: "единица_измерения"},
+					{Name: "Комментарий", Key: "комментарий"},
+				},
+			},
+			{
+				Name:        "st",
+				Description: "Шаблон команды установки",
+				Args: []ArgInfo{
+					{Name: "Ключ", Key: "ключ", Complete: "sport"},
+				},
+			},
+			{
+				Name:        "del",
+				Description: "Удаление",
+				Args: []ArgInfo{
+					{Name: "Ключ", Key: "ключ", Complete: "sport"},
+				},
+			},
+			{
+				Name:        "kind",
+				Description: "Установка вида подходов",
+				Args: []ArgInfo{
+					{Name: "Ключ", Key: "ключ", Complete: "sport"},
+					{Name: "Вид подходов", Key: "вид_подходов", Values: []string{"value", "reps", "reps_load", "duration", "distance"}},
+				},
+			},
+			{
+				Name:        "cal",
+				Description: "Установка коэффициентов расхода ккал",
+				Args: []ArgInfo{
+					{Name: "Ключ", Key: "ключ", Complete: "sport"},
+					{Name: "MET", Key: "met"},
+					{Name: "ККал на единицу", Key: "ккал_на_единицу"},
+				},
+			},
+			{
+				Name:        "list",
+				Description: "Список",
+				Args: []ArgInfo{
+					{Name: "Формат", Key: "формат", Values: []string{"html", "pdf"}},
+				},
+			},
+			{
+				Name:        "as",
+				Description: "Добавление активности",
+				Args: []ArgInfo{
+					{Name: "Дата", Key: "дата"},
+					{Name: "Ключ спорта", Key: "ключ_спорта", Complete: "sport"},
+					{Name: "Подходы", Key: "подходы"},
+					{Name: "Комментарий", Key: "комментарий"},
+				},
+			},
+			{
+				Name:        "asd",
+				Description: "Добавление активности с длительностью",
+				Args: []ArgInfo{
+					{Name: "Дата", Key: "дата"},
+					{Name: "Ключ спорта", Key: "ключ_спорта", Complete: "sport"},
+					{Name: "Подходы", Key: "подходы"},
+					{Name: "Длительность мин", Key: "длительность_мин"},
+					{Name: "Комментарий", Key: "комментарий"},
+				},
+			},
+			{
+				Name:        "al",
+				Description: "Список активностей за день",
+				Args: []ArgInfo{
+					{Name: "Дата", Key: "дата"},
+				},
+			},
+			{
+				Name:        "ad",
+				Description: "Удаление всех активностей спорта за день",
+				Args: []ArgInfo{
+					{Name: "Дата", Key: "дата"},
+					{Name: "Ключ спорта", Key: "ключ_спорта", Complete: "sport"},
+				},
+			},
+			{
+				Name:        "adi",
+				Description: "Удаление активности по ID",
+				Args: []ArgInfo{
+					{Name: "ID", Key: "id"},
+				},
+			},
+			{
+				Name:        "pr",
+				Description: "Рекорды и прогресс",
+				Args: []ArgInfo{
+					{Name: "Ключ спорта", Key: "ключ_спорта", Complete: "sport"},
+					{Name: "Формат", Key: "формат", Values: []string{"html", "pdf"}},
+				},
+			},
+			{
+				Name:        "ar",
+				Description: "Отчет по активности",
+				Args: []ArgInfo{
+					{Name: "С", Key: "с"},
+					{Name: "По", Key: "по"},
+					{Name: "Формат", Key: "формат", Values: []string{"html", "pdf"}},
+				},
+			},
+			{
+				Name:        "wset",
+				Description: "Установка тренировки",
+				Args: []ArgInfo{
+					{Name: "Ключ", Key: "ключ"},
+					{Name: "Наименование", Key: "наименование"},
+					{Name: "Упражнения", Key: "упражнения"},
+					{Name: "Комментарий", Key: "комментарий"},
+				},
+			},
+			{
+				Name:        "wst",
+				Description: "Шаблон команды установки тренировки",
+				Args: []ArgInfo{
+					{Name: "Ключ", Key: "ключ"},
+				},
+			},
+			{
+				Name:        "wdel",
+				Description: "Удаление тренировки",
+				Args: []ArgInfo{
+					{Name: "Ключ", Key: "ключ"},
+				},
+			},
+			{
+				Name:        "wlist",
+				Description: "Список тренировок",
+				Args: []ArgInfo{
+					{Name: "Формат", Key: "формат", Values: []string{"html", "pdf"}},
+				},
+			},
+			{
+				Name:        "pset",
+				Description: "Установка тренировки в план на день недели",
+				Args: []ArgInfo{
+					{Name: "День недели", Key: "день_недели"},
+					{Name: "Ключ тренировки", Key: "ключ_тренировки"},
+				},
+			},
+			{
+				Name:        "pdel",
+				Description: "Удаление дня недели из плана",
+				Args: []ArgInfo{
+					{Name: "День недели", Key: "день_недели"},
+				},
+			},
+			{
+				Name:        "plist",
+				Description: "План тренировок",
+			},
+			{
+				Name:        "today",
+				Description: "Тренировка на сегодня",
+			},
+			{
+				Name:        "pc",
+				Description: "Отчет о выполнении плана",
+				Args: []ArgInfo{
+					{Name: "С", Key: "с"},
+					{Name: "По", Key: "по"},
+					{Name: "Формат", Key: "формат", Values: []string{"html", "pdf"}},
+				},
+			},
+		},
+	},
+	{
+		Name:             "m",
+		Aliases:          []string{"med"},
+		DescriptionShort: "Медицина",
+		SubCommands: []SubCommandInfo{
+			{
+				Name:        "set",
+				Description: "Установка",
+				Args: []ArgInfo{
+					{Name: "Ключ", Key: "ключ"},
+					{Name: "Наименование", Key: "наименование"},
+					{Name: "Единица измерения", Key: "единица_измерения"},
+					{Name: "Комментарий", Key: "комментарий"},
+				},
+			},
+			{
+				Name:        "st",
+				Description: "Шаблон команды установки",
+				Args: []ArgInfo{
+					{Name: "Ключ", Key: "ключ"},
+				},
+			},
+			{
+				Name:        "del",
+				Description: "Удаление",
+				Args: []ArgInfo{
+					{Name: "Ключ", Key: "ключ"},
+				},
+			},
+			{
+				Name:        "list",
+				Description: "Список",
+				Args: []ArgInfo{
+					{Name: "Формат", Key: "формат", Values: []string{"html", "pdf"}},
+				},
+			},
+			{
+				Name:        "is",
+				Description: "Установка показателя",
+				Args: []ArgInfo{
+					{Name: "Дата", Key: "дата"},
+					{Name: "Ключ медицины", Key: "ключ_медицины"},
+					{Name: "Значениe", Key: "значениe"},
+				},
+			},
+			{
+				Name:        "id",
+				Description: "Удаление показателя",
+				Args: []ArgInfo{
+					{Name: "Дата", Key: "дата"},
+					{Name: "Ключ спорта", Key: "ключ_спорта"},
+				},
+			},
+			{
+				Name:        "ir",
+				Description: "Отчет по показателям",
+				Args: []ArgInfo{
+					{Name: "С", Key: "с"},
+					{Name: "По", Key: "по"},
+					{Name: "Формат", Key: "формат", Values: []string{"html", "pdf"}},
+				},
+			},
+		},
+	},
+	{
+		Name:             "wa",
+		Aliases:          []string{"water"},
+		DescriptionShort: "Вода",
+		SubCommands: []SubCommandInfo{
+			{
+				Name:        "add",
+				Aliases:     []string{"a"},
+				Description: "Добавление",
+				Args: []ArgInfo{
+					{Name: "Дата", Key: "дата"},
+					{Name: "Объем, мл", Key: "объем_мл"},
+				},
+			},
+			{
+				Name:        "del",
+				Description: "Удаление за день",
+				Args: []ArgInfo{
+					{Name: "Дата", Key: "дата"},
+				},
+			},
+			{
+				Name:        "list",
+				Description: "Отчет за день",
+				Args: []ArgInfo{
+					{Name: "Дата", Key: "дата"},
+				},
+			},
+		},
+	},
+	{
+		Name:             "sl",
+		Aliases:          []string{"sleep"},
+		DescriptionShort: "Сон",
+		SubCommands: []SubCommandInfo{
+			{
+				Name:        "set",
+				Description: "Установка",
+				Args: []ArgInfo{
+					{Name: "Отбой", Key: "отбой"},
+					{Name: "Подъем", Key: "подъем"},
+					{Name: "Качество", Key: "качество"},
+					{Name: "Заметки", Key: "заметки"},
+				},
+			},
+			{
+				Name:        "st",
+				Description: "Шаблон команды установки",
+				Args: []ArgInfo{
+					{Name: "Дата подъема", Key: "дата_подъема"},
+				},
+			},
+			{
+				Name:        "del",
+				Description: "Удаление",
+				Args: []ArgInfo{
+					{Name: "Дата подъема", Key: "дата_подъема"},
+				},
+			},
+			{
+				Name:        "r",
+				Description: "Отчет",
+				Args: []ArgInfo{
+					{Name: "С", Key: "с"},
+					{Name: "По", Key: "по"},
+					{Name: "Формат", Key: "формат", Values: []string{"html", "pdf"}},
+				},
+			},
+		},
+	},
+	{
+		Name:             "fa",
+		Aliases:          []string{"fast"},
+		DescriptionShort: "Голодание",
+		SubCommands: []SubCommandInfo{
+			{
+				Name:        "start",
+				Description: "Начало",
+				Args: []ArgInfo{
+					{Name: "Начало", Key: "начало"},
+				},
+			},
+			{
+				Name:        "stop",
+				Description: "Завершение",
+				Args: []ArgInfo{
+					{Name: "Окончание", Key: "окончание"},
+				},
+			},
+			{
+				Name:        "cur",
+				Aliases:     []string{"status"},
+				Description: "Текущее голодание",
+			},
+			{
+				Name:        "del",
+				Description: "Удаление",
+				Args: []ArgInfo{
+					{Name: "Начало", Key: "начало"},
+				},
+			},
+			{
+				Name:        "r",
+				Description: "Отчет по окну питания и голоданию",
+				Args: []ArgInfo{
+					{Name: "С", Key: "с"},
+					{Name: "По", Key: "по"},
+					{Name: "Окно питания, ч", Key: "окно_питания_ч"},
+					{Name: "Формат", Key: "формат", Values: []string{"html", "pdf"}},
+				},
+			},
+		},
+	},
+}
+
+// Commands returns registry of commands.
+func Commands() []CommandInfo {
+	return _commandRegistry
+}
+
 func parseTimestamp(tz *time.Location, arg string) (time.Time, error) {
 	var t time.Time
 
+	// Arg may end with optional time of day "HH:MM" separated by space,
+	// or be the time only for current date
+	var hour, minute int
+	if i := strings.LastIndex(arg, " "); i != -1 || strings.Contains(arg, ":") {
+		timePart := arg[i+1:]
+		arg = strings.TrimSpace(arg[:max(i, 0)])
+
+		tm, err := time.Parse("15:04", timePart)
+		if err != nil {
+			return time.Time{}, err
+		}
+		hour, minute = tm.Hour(), tm.Minute()
+	}
+
 	// Arg empty string - now
 	// Arg integer - add delta to now
 	// Arg in date format
 
 	if arg == "" {
-		t = time.Now().In(tz)
+		t = timeNow().In(tz)
 	} else {
         delta, err := strconv.Atoi(arg)
 
 		if err == nil {
-			t = time.Now().In(tz)
+			t = timeNow().In(tz)
 			t = t.Add(time.Duration(delta) * 24 * time.Hour)
 		} else {
 			t, err = time.Parse("02.01.2006", arg)
@@ -1653,7 +5321,7 @@ func parseTimestamp(tz *time.Location, arg string) (time.Time, error) {
 		}
 	}
 
-	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, tz), nil
+	return time.Date(t.Year(), t.Month(), t.Day(), hour, minute, 0, 0, tz), nil
 }
 
 func parseFloatG0(arg string) (float64, error) {
@@ -1682,6 +5350,19 @@ func parseFloatGE0(arg string) (float64, error) {
 	return val, nil
 }
 
+func parseIntG0(arg string) (int, error) {
+	val, err := strconv.Atoi(arg)
+	if err != nil {
+		return 0, err
+	}
+
+	if val <= 0 {
+		return 0, fmt.Errorf("not above zero")
+	}
+
+	return val, nil
+}
+
 func parseStringG0(arg string) (string, error) {
 	if len(arg) == 0 {
 		return "", fmt.Errorf("empty string")
@@ -1694,12 +5375,52 @@ func parseStringGE0(arg string) (string, error) {
 	return arg, nil
 }
 
-func parseGender(arg string) (string, error) {
+func parseEnum(arg string, values []string) (string, error) {
+	for _, v := range values {
+		if arg == v {
+			return arg, nil
+		}
+	}
+	return "", fmt.Errorf("wrong enum value")
+}
+
+func checkMin(val, limit float64) error {
+	if val < limit {
+		return fmt.Errorf("less than %g", limit)
+	}
+	return nil
+}
+
+func checkMax(val, limit float64) error {
+	if val > limit {
+		return fmt.Errorf("greater than %g", limit)
+	}
+	return nil
+}
+
+func parseActivityLevel(arg string) (storage.ActivityLevel, error) {
+	return storage.NewActivityLevelFromString(arg)
+}
+
+func parseGoal(arg string) (storage.Goal, error) {
+	return storage.NewGoalFromString(arg)
+}
+
+func parseReportFormat(arg string) (storage.ReportFormat, error) {
+	if f := storage.ReportFormat(strings.ToLower(arg)); f.Validate() {
+		return f, nil
+	}
+	return "", fmt.Errorf("wrong report format")
+}
+
+func parseBool(arg string) (bool, error) {
 	switch arg {
-	case "m", "f":
-		return arg, nil
+	case "1":
+		return true, nil
+	case "0":
+		return false, nil
 	default:
-		return "", fmt.Errorf("wrong gender")
+		return false, fmt.Errorf("wrong bool")
 	}
 }
 
@@ -1708,9 +5429,14 @@ func parseMeal(arg string) (storage.Meal, error) {
 }
 
 func parseStringArr(arg string) ([]string, error) {
+	items, err := splitArgs(arg, '/')
+	if err != nil {
+		return nil, err
+	}
+
 	parts := []string{}
-	for _, part := range strings.Split(arg, "/") {
-		parts = append(parts, strings.Trim(part, " "))
+	for _, item := range items {
+		parts = append(parts, unquoteArg(item))
 	}
 
 	if len(parts) == 0 {
@@ -1721,10 +5447,14 @@ func parseStringArr(arg string) ([]string, error) {
 }
 
 func parseFloatArr(arg string) ([]float64, error) {
+	items, err := splitArgs(arg, '/')
+	if err != nil {
+		return nil, err
+	}
+
 	parts := []float64{}
-	for _, part := range strings.Split(arg, "/") {
-		part = strings.Trim(part, " ")
-		val, err := strconv.ParseFloat(part, 64)
+	for _, item := range items {
+		val, err := strconv.ParseFloat(unquoteArg(item), 64)
 		if err != nil {
 			return nil, err
 		}
@@ -1739,14 +5469,249 @@ func parseFloatArr(arg string) ([]float64, error) {
 	return parts, nil
 }
 
+func parseSportSets(arg string) ([]storage.SportSet, error) {
+	sets := []storage.SportSet{}
+	for _, part := range strings.Split(arg, "/") {
+		set, err := storage.ParseSportSet(part)
+		if err != nil {
+			return nil, err
+		}
+
+		sets = append(sets, set)
+	}
+
+	return sets, nil
+}
+
+func parseSportSetKind(arg string) (storage.SportSetKind, error) {
+	return storage.NewSportSetKindFromString(arg)
+}
+
+func parseWorkoutItems(arg string) ([]storage.WorkoutItem, error) {
+	items := []storage.WorkoutItem{}
+	for _, part := range strings.Split(arg, ";") {
+		key, sets, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("wrong workout item")
+		}
+
+		val, err := parseSportSets(sets)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, storage.WorkoutItem{SportKey: strings.Trim(key, " "), Sets: val})
+	}
+
+	return items, nil
+}
+
+func parseFoodAmount(arg string) (storage.FoodAmount, error) {
+	return storage.ParseFoodAmount(arg)
+}
+
+func parseFoodPortions(arg string) ([]storage.FoodPortion, error) {
+	return storage.ParseFoodPortions(arg)
+}
+
+func parseNutrients(arg string) (storage.Nutrients, error) {
+	return storage.ParseNutrients(arg)
+}
+
+func parseWeekday(arg string) (time.Weekday, error) {
+	val, err := strconv.Atoi(arg)
+	if err != nil {
+		return 0, err
+	}
+
+	if val < 1 || val > 7 {
+		return 0, fmt.Errorf("wrong weekday")
+	}
+
+	// Monday is 1, Sunday is 7
+	return time.Weekday(val % 7), nil
+}
+
+// splitArgs splits command by separator outside of double quotes and
+// escapes. Parts keep quotes and escapes, they are removed by unquoteArg.
+func splitArgs(cmd string, sep rune) ([]string, error) {
+	parts := []string{}
+	var sb strings.Builder
+	inQuotes, escaped := false, false
+	for _, c := range cmd {
+		switch {
+		case escaped:
+			escaped = false
+		case c == '\\':
+			escaped = true
+		case c == '"':
+			inQuotes = !inQuotes
+		case c == sep && !inQuotes:
+			parts = append(parts, sb.String())
+			sb.Reset()
+			continue
+		}
+		sb.WriteRune(c)
+	}
+
+	if inQuotes || escaped {
+		return nil, fmt.Errorf("unterminated quote or escape")
+	}
+
+	return append(parts, sb.String()), nil
+}
+
+// unquoteArg removes quotes and escapes from arg,
+// spaces around arg outside of quotes are trimmed.
+func unquoteArg(arg string) string {
+	var sb strings.Builder
+	inQuotes, escaped := false, false
+	// Length of value without trailing spaces to trim
+	keep := 0
+	for _, c := range arg {
+		switch {
+		case escaped:
+			escaped = false
+		case c == '\\':
+			escaped = true
+			continue
+		case c == '"':
+			inQuotes = !inQuotes
+			keep = sb.Len()
+			continue
+		case !inQuotes && c == ' ':
+			if sb.Len() != 0 {
+				sb.WriteRune(c)
+			}
+			continue
+		}
+		sb.WriteRune(c)
+		keep = sb.Len()
+	}
+
+	return sb.String()[:keep]
+}
+
+// quoteArg returns arg in double quotes, if it can't be parsed back
+// unchanged as command arg.
+func quoteArg(arg string) string {
+	return quoteArgWith(arg, ",=")
+}
+
+// quoteArrItem returns array item in double quotes, if it can't be parsed
+// back unchanged as item of array arg.
+func quoteArrItem(item string) string {
+	return quoteArgWith(item, ",=/")
+}
+
+func quoteArgWith(arg, specials string) string {
+	if !strings.ContainsAny(arg, specials+"\"\\") &&
+		strings.TrimSpace(arg) == arg {
+		return arg
+	}
+
+	r := strings.NewReplacer("\\", "\\\\", "\"", "\\\"")
+	return "\"" + r.Replace(arg) + "\""
+}
+
+// argSpec describes subcommand arg: key for named form key=value
+// and default value of omitted optional arg. Variadic arg is the last
+// one and takes all rest positional args.
+type argSpec struct {
+	key      string
+	optional bool
+	variadic bool
+	def      string
+}
+
+// bindArgs binds positional args, followed by named args key=value,
+// to specs. Omitted optional args get default value, values of variadic
+// arg are at the end of result.
+func bindArgs(specs []argSpec, parts []string) ([]string, error) {
+	args := make([]string, len(specs))
+	set := make([]bool, len(specs))
+	variadic := len(specs) > 0 && specs[len(specs)-1].variadic
+	pos, named := 0, false
+	for _, part := range parts {
+		if i := namedArgIndex(specs, part); i != -1 {
+			if set[i] {
+				return nil, fmt.Errorf("duplicate arg %s", specs[i].key)
+			}
+			_, args[i], _ = strings.Cut(part, "=")
+			set[i], named = true, true
+			continue
+		}
+
+		switch {
+		case named:
+			return nil, fmt.Errorf("positional arg after named")
+		case pos < len(specs):
+			args[pos], set[pos] = part, true
+			pos++
+		case variadic:
+			args = append(args, part)
+		default:
+			return nil, fmt.Errorf("unexpected positional arg")
+		}
+	}
+
+	for i, spec := range specs {
+		if set[i] {
+			continue
+		}
+		if !spec.optional {
+			return nil, fmt.Errorf("missing arg %s", spec.key)
+		}
+		// Omitted variadic arg without default has no values
+		if spec.variadic && spec.def == "" {
+			return args[:i], nil
+		}
+		args[i] = spec.def
+	}
+
+	return args, nil
+}
+
+func namedArgIndex(specs []argSpec, part string) int {
+	key, _, ok := strings.Cut(part, "=")
+	if !ok {
+		return -1
+	}
+
+	key = strings.ToLower(strings.TrimSpace(key))
+	for i, spec := range specs {
+		if spec.key == key && !spec.variadic {
+			return i
+		}
+	}
+
+	return -1
+}
+
 func argError(argName string) []CmdResponse {
-	return NewSingleCmdResponse(fmt.Sprintf("%s: %s", m.MsgErrInvalidArg, argName))
+	return NewErrCmdResponse(fmt.Sprintf("%s: %s", m.MsgErrInvalidArg, argName))
 }
 
 func formatTimestamp(ts time.Time) string {
 	return ts.Format("02.01.2006")
 }
 
+func formatBool(v bool) string {
+	if v {
+		return "1"
+	}
+	return "0"
+}
+
+// formatDateTime formats timestamp with time of day, timestamps without
+// time (midnight) are formatted as date only.
+func formatDateTime(ts time.Time) string {
+	if ts.Hour() == 0 && ts.Minute() == 0 {
+		return formatTimestamp(ts)
+	}
+	return ts.Format("02.01.2006 15:04")
+}
+
 type cmdHelpItem struct {
 	label   string
 	cmd     string
@@ -0,0 +1,3414 @@
+package cmdproc
+
+// Code generated by "go generate". DO NOT EDIT!
+
+import (
+	"testing"
+	"time"
+
+	m "github.com/devldavydov/myhealth/internal/common/messages"
+	"github.com/devldavydov/myhealth/internal/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseArgs(t *testing.T) {
+	r := &CmdProcessor{tz: time.UTC}
+
+	parsers := map[string]func([]string) (any, []CmdResponse){
+		"w,set": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_w_set(parts)
+			return args, resp
+		},
+		"w,del": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_w_del(parts)
+			return args, resp
+		},
+		"w,list": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_w_list(parts)
+			return args, resp
+		},
+		"w,g": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_w_g(parts)
+			return args, resp
+		},
+		"u,set": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_u_set(parts)
+			return args, resp
+		},
+		"u,prof": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_u_prof(parts)
+			return args, resp
+		},
+		"u,water": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_u_water(parts)
+			return args, resp
+		},
+		"u,nut": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_u_nut(parts)
+			return args, resp
+		},
+		"u,rep": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_u_rep(parts)
+			return args, resp
+		},
+		"f,set": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_f_set(parts)
+			return args, resp
+		},
+		"f,setw": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_f_setw(parts)
+			return args, resp
+		},
+		"f,bev": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_f_bev(parts)
+			return args, resp
+		},
+		"f,por": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_f_por(parts)
+			return args, resp
+		},
+		"f,st": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_f_st(parts)
+			return args, resp
+		},
+		"f,find": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_f_find(parts)
+			return args, resp
+		},
+		"f,calc": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_f_calc(parts)
+			return args, resp
+		},
+		"f,list": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_f_list(parts)
+			return args, resp
+		},
+		"f,del": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_f_del(parts)
+			return args, resp
+		},
+		"c,c": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_c_c(parts)
+			return args, resp
+		},
+		"c,tdee": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_c_tdee(parts)
+			return args, resp
+		},
+		"c,tdeea": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_c_tdeea(parts)
+			return args, resp
+		},
+		"c,tdeeh": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_c_tdeeh(parts)
+			return args, resp
+		},
+		"c,tdeeauto": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_c_tdeeauto(parts)
+			return args, resp
+		},
+		"b,set": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_b_set(parts)
+			return args, resp
+		},
+		"b,st": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_b_st(parts)
+			return args, resp
+		},
+		"b,list": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_b_list(parts)
+			return args, resp
+		},
+		"b,del": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_b_del(parts)
+			return args, resp
+		},
+		"j,set": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_j_set(parts)
+			return args, resp
+		},
+		"j,sb": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_j_sb(parts)
+			return args, resp
+		},
+		"j,del": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_j_del(parts)
+			return args, resp
+		},
+		"j,dm": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_j_dm(parts)
+			return args, resp
+		},
+		"j,db": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_j_db(parts)
+			return args, resp
+		},
+		"j,cp": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_j_cp(parts)
+			return args, resp
+		},
+		"j,rd": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_j_rd(parts)
+			return args, resp
+		},
+		"j,rdc": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_j_rdc(parts)
+			return args, resp
+		},
+		"j,tr": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_j_tr(parts)
+			return args, resp
+		},
+		"j,tm": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_j_tm(parts)
+			return args, resp
+		},
+		"j,sug": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_j_sug(parts)
+			return args, resp
+		},
+		"j,fs": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_j_fs(parts)
+			return args, resp
+		},
+		"j,sc": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_j_sc(parts)
+			return args, resp
+		},
+		"j,dc": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_j_dc(parts)
+			return args, resp
+		},
+		"s,set": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_s_set(parts)
+			return args, resp
+		},
+		"s,st": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_s_st(parts)
+			return args, resp
+		},
+		"s,del": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_s_del(parts)
+			return args, resp
+		},
+		"s,kind": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_s_kind(parts)
+			return args, resp
+		},
+		"s,cal": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_s_cal(parts)
+			return args, resp
+		},
+		"s,list": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_s_list(parts)
+			return args, resp
+		},
+		"s,as": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_s_as(parts)
+			return args, resp
+		},
+		"s,asd": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_s_asd(parts)
+			return args, resp
+		},
+		"s,al": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_s_al(parts)
+			return args, resp
+		},
+		"s,ad": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_s_ad(parts)
+			return args, resp
+		},
+		"s,adi": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_s_adi(parts)
+			return args, resp
+		},
+		"s,pr": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_s_pr(parts)
+			return args, resp
+		},
+		"s,ar": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_s_ar(parts)
+			return args, resp
+		},
+		"s,wset": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_s_wset(parts)
+			return args, resp
+		},
+		"s,wst": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_s_wst(parts)
+			return args, resp
+		},
+		"s,wdel": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_s_wdel(parts)
+			return args, resp
+		},
+		"s,wlist": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_s_wlist(parts)
+			return args, resp
+		},
+		"s,pset": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_s_pset(parts)
+			return args, resp
+		},
+		"s,pdel": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_s_pdel(parts)
+			return args, resp
+		},
+		"s,pc": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_s_pc(parts)
+			return args, resp
+		},
+		"m,set": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_m_set(parts)
+			return args, resp
+		},
+		"m,st": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_m_st(parts)
+			return args, resp
+		},
+		"m,del": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_m_del(parts)
+			return args, resp
+		},
+		"m,list": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_m_list(parts)
+			return args, resp
+		},
+		"m,is": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_m_is(parts)
+			return args, resp
+		},
+		"m,id": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_m_id(parts)
+			return args, resp
+		},
+		"m,ir": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_m_ir(parts)
+			return args, resp
+		},
+		"wa,add": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_wa_add(parts)
+			return args, resp
+		},
+		"wa,del": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_wa_del(parts)
+			return args, resp
+		},
+		"wa,list": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_wa_list(parts)
+			return args, resp
+		},
+		"sl,set": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_sl_set(parts)
+			return args, resp
+		},
+		"sl,st": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_sl_st(parts)
+			return args, resp
+		},
+		"sl,del": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_sl_del(parts)
+			return args, resp
+		},
+		"sl,r": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_sl_r(parts)
+			return args, resp
+		},
+		"fa,start": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_fa_start(parts)
+			return args, resp
+		},
+		"fa,stop": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_fa_stop(parts)
+			return args, resp
+		},
+		"fa,del": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_fa_del(parts)
+			return args, resp
+		},
+		"fa,r": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_fa_r(parts)
+			return args, resp
+		},
+	}
+
+	for _, tt := range []struct {
+		name  string
+		cmd   string
+		parts []string
+		want  []CmdResponse
+		args  any
+	}{
+		{
+			name:  "w,set valid",
+			cmd:   "w,set",
+			parts: []string{"19.10.2026 08:30", "1.5"},
+			args:  &args_w_set{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: 1.5},
+		},
+		{
+			name:  "w,set named",
+			cmd:   "w,set",
+			parts: []string{"дата=19.10.2026 08:30", "значение=1.5"},
+			args:  &args_w_set{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: 1.5},
+		},
+		{
+			name:  "w,set too many args",
+			cmd:   "w,set",
+			parts: []string{"19.10.2026 08:30", "1.5", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "w,set too few args",
+			cmd:   "w,set",
+			parts: []string{"19.10.2026 08:30"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "w,set invalid дата",
+			cmd:   "w,set",
+			parts: []string{"32.13.2026", "1.5"},
+			want:  argError("Дата"),
+		},
+		{
+			name:  "w,set invalid значение",
+			cmd:   "w,set",
+			parts: []string{"19.10.2026 08:30", "0"},
+			want:  argError("Значение"),
+		},
+		{
+			name:  "w,del valid",
+			cmd:   "w,del",
+			parts: []string{"19.10.2026 08:30"},
+			args:  &args_w_del{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC)},
+		},
+		{
+			name:  "w,del named",
+			cmd:   "w,del",
+			parts: []string{"дата=19.10.2026 08:30"},
+			args:  &args_w_del{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC)},
+		},
+		{
+			name:  "w,del too many args",
+			cmd:   "w,del",
+			parts: []string{"19.10.2026 08:30", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "w,del too few args",
+			cmd:   "w,del",
+			parts: []string{},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "w,del invalid дата",
+			cmd:   "w,del",
+			parts: []string{"32.13.2026"},
+			want:  argError("Дата"),
+		},
+		{
+			name:  "w,list valid",
+			cmd:   "w,list",
+			parts: []string{"19.10.2026 08:30", "19.10.2026 08:30", "pdf"},
+			args:  &args_w_list{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val2: storage.ReportFormatPDF},
+		},
+		{
+			name:  "w,list required only",
+			cmd:   "w,list",
+			parts: []string{"19.10.2026 08:30", "19.10.2026 08:30"},
+		},
+		{
+			name:  "w,list named",
+			cmd:   "w,list",
+			parts: []string{"с=19.10.2026 08:30", "по=19.10.2026 08:30", "формат=pdf"},
+			args:  &args_w_list{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val2: storage.ReportFormatPDF},
+		},
+		{
+			name:  "w,list too many args",
+			cmd:   "w,list",
+			parts: []string{"19.10.2026 08:30", "19.10.2026 08:30", "pdf", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "w,list too few args",
+			cmd:   "w,list",
+			parts: []string{"19.10.2026 08:30"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "w,list invalid с",
+			cmd:   "w,list",
+			parts: []string{"32.13.2026", "19.10.2026 08:30", "pdf"},
+			want:  argError("С"),
+		},
+		{
+			name:  "w,list invalid по",
+			cmd:   "w,list",
+			parts: []string{"19.10.2026 08:30", "32.13.2026", "pdf"},
+			want:  argError("По"),
+		},
+		{
+			name:  "w,list invalid формат",
+			cmd:   "w,list",
+			parts: []string{"19.10.2026 08:30", "19.10.2026 08:30", "doc"},
+			want:  argError("Формат"),
+		},
+		{
+			name:  "w,g valid",
+			cmd:   "w,g",
+			parts: []string{"3", "png"},
+			args:  &args_w_g{val0: 3, val1: "png"},
+		},
+		{
+			name:  "w,g required only",
+			cmd:   "w,g",
+			parts: []string{},
+		},
+		{
+			name:  "w,g named",
+			cmd:   "w,g",
+			parts: []string{"дней=3", "формат=png"},
+			args:  &args_w_g{val0: 3, val1: "png"},
+		},
+		{
+			name:  "w,g too many args",
+			cmd:   "w,g",
+			parts: []string{"3", "png", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "w,g invalid дней",
+			cmd:   "w,g",
+			parts: []string{"0", "png"},
+			want:  argError("Дней"),
+		},
+		{
+			name:  "w,g invalid формат",
+			cmd:   "w,g",
+			parts: []string{"3", ""},
+			want:  argError("Формат"),
+		},
+		{
+			name:  "u,set valid",
+			cmd:   "u,set",
+			parts: []string{"1.5"},
+			args:  &args_u_set{val0: 1.5},
+		},
+		{
+			name:  "u,set named",
+			cmd:   "u,set",
+			parts: []string{"лимит_калорий=1.5"},
+			args:  &args_u_set{val0: 1.5},
+		},
+		{
+			name:  "u,set too many args",
+			cmd:   "u,set",
+			parts: []string{"1.5", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "u,set too few args",
+			cmd:   "u,set",
+			parts: []string{},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "u,set invalid лимит_калорий",
+			cmd:   "u,set",
+			parts: []string{"0"},
+			want:  argError("Лимит калорий"),
+		},
+		{
+			name:  "u,prof valid",
+			cmd:   "u,prof",
+			parts: []string{"m", "19.10.2026 08:30", "1.5", "3", "0", "keep", "0"},
+			args:  &args_u_prof{val0: "m", val1: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val2: 1.5, val3: storage.ActivityLevel(3), val4: 0, val5: storage.GoalMaintain, val6: 0},
+		},
+		{
+			name:  "u,prof named",
+			cmd:   "u,prof",
+			parts: []string{"пол=m", "дата_рождения=19.10.2026 08:30", "рост=1.5", "активность=3", "процент_жира=0", "цель=keep", "темп_кг_нед=0"},
+			args:  &args_u_prof{val0: "m", val1: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val2: 1.5, val3: storage.ActivityLevel(3), val4: 0, val5: storage.GoalMaintain, val6: 0},
+		},
+		{
+			name:  "u,prof too many args",
+			cmd:   "u,prof",
+			parts: []string{"m", "19.10.2026 08:30", "1.5", "3", "0", "keep", "0", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "u,prof too few args",
+			cmd:   "u,prof",
+			parts: []string{"m", "19.10.2026 08:30", "1.5", "3", "0", "keep"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "u,prof invalid пол",
+			cmd:   "u,prof",
+			parts: []string{"", "19.10.2026 08:30", "1.5", "3", "0", "keep", "0"},
+			want:  argError("Пол"),
+		},
+		{
+			name:  "u,prof invalid дата_рождения",
+			cmd:   "u,prof",
+			parts: []string{"m", "32.13.2026", "1.5", "3", "0", "keep", "0"},
+			want:  argError("Дата рождения"),
+		},
+		{
+			name:  "u,prof invalid рост",
+			cmd:   "u,prof",
+			parts: []string{"m", "19.10.2026 08:30", "0", "3", "0", "keep", "0"},
+			want:  argError("Рост"),
+		},
+		{
+			name:  "u,prof invalid активность",
+			cmd:   "u,prof",
+			parts: []string{"m", "19.10.2026 08:30", "1.5", "9", "0", "keep", "0"},
+			want:  argError("Активность"),
+		},
+		{
+			name:  "u,prof invalid процент_жира",
+			cmd:   "u,prof",
+			parts: []string{"m", "19.10.2026 08:30", "1.5", "3", "-1", "keep", "0"},
+			want:  argError("Процент жира"),
+		},
+		{
+			name:  "u,prof invalid цель",
+			cmd:   "u,prof",
+			parts: []string{"m", "19.10.2026 08:30", "1.5", "3", "0", "x", "0"},
+			want:  argError("Цель"),
+		},
+		{
+			name:  "u,prof invalid темп_кг_нед",
+			cmd:   "u,prof",
+			parts: []string{"m", "19.10.2026 08:30", "1.5", "3", "0", "keep", "-1"},
+			want:  argError("Темп кг/нед"),
+		},
+		{
+			name:  "u,water valid",
+			cmd:   "u,water",
+			parts: []string{"0", "1"},
+			args:  &args_u_water{val0: 0, val1: true},
+		},
+		{
+			name:  "u,water named",
+			cmd:   "u,water",
+			parts: []string{"цель_мл=0", "напоминания=1"},
+			args:  &args_u_water{val0: 0, val1: true},
+		},
+		{
+			name:  "u,water too many args",
+			cmd:   "u,water",
+			parts: []string{"0", "1", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "u,water too few args",
+			cmd:   "u,water",
+			parts: []string{"0"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "u,water invalid цель_мл",
+			cmd:   "u,water",
+			parts: []string{"-1", "1"},
+			want:  argError("Цель, мл"),
+		},
+		{
+			name:  "u,water invalid напоминания",
+			cmd:   "u,water",
+			parts: []string{"0", "2"},
+			want:  argError("Напоминания"),
+		},
+		{
+			name:  "u,nut valid",
+			cmd:   "u,nut",
+			parts: []string{"fiber=3;sugar=1"},
+			args:  &args_u_nut{val0: storage.Nutrients{storage.NutrientFiber: 3, storage.NutrientSugar: 1}},
+		},
+		{
+			name:  "u,nut named",
+			cmd:   "u,nut",
+			parts: []string{"лимиты_г=fiber=3;sugar=1"},
+			args:  &args_u_nut{val0: storage.Nutrients{storage.NutrientFiber: 3, storage.NutrientSugar: 1}},
+		},
+		{
+			name:  "u,nut too many args",
+			cmd:   "u,nut",
+			parts: []string{"fiber=3;sugar=1", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "u,nut too few args",
+			cmd:   "u,nut",
+			parts: []string{},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "u,nut invalid лимиты_г",
+			cmd:   "u,nut",
+			parts: []string{"iron=1"},
+			want:  argError("Лимиты, г"),
+		},
+		{
+			name:  "u,rep valid",
+			cmd:   "u,rep",
+			parts: []string{"pdf"},
+			args:  &args_u_rep{val0: storage.ReportFormatPDF},
+		},
+		{
+			name:  "u,rep named",
+			cmd:   "u,rep",
+			parts: []string{"формат=pdf"},
+			args:  &args_u_rep{val0: storage.ReportFormatPDF},
+		},
+		{
+			name:  "u,rep too many args",
+			cmd:   "u,rep",
+			parts: []string{"pdf", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "u,rep too few args",
+			cmd:   "u,rep",
+			parts: []string{},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "u,rep invalid формат",
+			cmd:   "u,rep",
+			parts: []string{"doc"},
+			want:  argError("Формат"),
+		},
+		{
+			name:  "f,set valid",
+			cmd:   "f,set",
+			parts: []string{"key", "key", "comment", "0", "0", "0", "0", "comment", "fiber=3;sugar=1"},
+			args:  &args_f_set{val0: "key", val1: "key", val2: "comment", val3: 0, val4: 0, val5: 0, val6: 0, val7: "comment", val8: storage.Nutrients{storage.NutrientFiber: 3, storage.NutrientSugar: 1}},
+		},
+		{
+			name:  "f,set required only",
+			cmd:   "f,set",
+			parts: []string{"key", "key", "comment", "0", "0", "0", "0"},
+		},
+		{
+			name:  "f,set named",
+			cmd:   "f,set",
+			parts: []string{"ключ=key", "наименование=key", "бренд=comment", "ккал_100г=0", "б_100г=0", "ж_100г=0", "у_100г=0", "комментарий=comment", "нутриенты_100г=fiber=3;sugar=1"},
+			args:  &args_f_set{val0: "key", val1: "key", val2: "comment", val3: 0, val4: 0, val5: 0, val6: 0, val7: "comment", val8: storage.Nutrients{storage.NutrientFiber: 3, storage.NutrientSugar: 1}},
+		},
+		{
+			name:  "f,set too many args",
+			cmd:   "f,set",
+			parts: []string{"key", "key", "comment", "0", "0", "0", "0", "comment", "fiber=3;sugar=1", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "f,set too few args",
+			cmd:   "f,set",
+			parts: []string{"key", "key", "comment", "0", "0", "0"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "f,set invalid ключ",
+			cmd:   "f,set",
+			parts: []string{"", "key", "comment", "0", "0", "0", "0", "comment", "fiber=3;sugar=1"},
+			want:  argError("Ключ"),
+		},
+		{
+			name:  "f,set invalid наименование",
+			cmd:   "f,set",
+			parts: []string{"key", "", "comment", "0", "0", "0", "0", "comment", "fiber=3;sugar=1"},
+			want:  argError("Наименование"),
+		},
+		{
+			name:  "f,set invalid ккал_100г",
+			cmd:   "f,set",
+			parts: []string{"key", "key", "comment", "-1", "0", "0", "0", "comment", "fiber=3;sugar=1"},
+			want:  argError("ККал 100г"),
+		},
+		{
+			name:  "f,set invalid б_100г",
+			cmd:   "f,set",
+			parts: []string{"key", "key", "comment", "0", "-1", "0", "0", "comment", "fiber=3;sugar=1"},
+			want:  argError("Б 100г"),
+		},
+		{
+			name:  "f,set invalid ж_100г",
+			cmd:   "f,set",
+			parts: []string{"key", "key", "comment", "0", "0", "-1", "0", "comment", "fiber=3;sugar=1"},
+			want:  argError("Ж 100г"),
+		},
+		{
+			name:  "f,set invalid у_100г",
+			cmd:   "f,set",
+			parts: []string{"key", "key", "comment", "0", "0", "0", "-1", "comment", "fiber=3;sugar=1"},
+			want:  argError("У 100г"),
+		},
+		{
+			name:  "f,set invalid нутриенты_100г",
+			cmd:   "f,set",
+			parts: []string{"key", "key", "comment", "0", "0", "0", "0", "comment", "iron=1"},
+			want:  argError("Нутриенты 100г"),
+		},
+		{
+			name:  "f,setw valid",
+			cmd:   "f,setw",
+			parts: []string{"key", "key", "comment", "1.5", "0", "0", "0", "0", "comment", "fiber=3;sugar=1"},
+			args:  &args_f_setw{val0: "key", val1: "key", val2: "comment", val3: 1.5, val4: 0, val5: 0, val6: 0, val7: 0, val8: "comment", val9: storage.Nutrients{storage.NutrientFiber: 3, storage.NutrientSugar: 1}},
+		},
+		{
+			name:  "f,setw required only",
+			cmd:   "f,setw",
+			parts: []string{"key", "key", "comment", "1.5", "0", "0", "0", "0"},
+		},
+		{
+			name:  "f,setw named",
+			cmd:   "f,setw",
+			parts: []string{"ключ=key", "наименование=key", "бренд=comment", "вес_г=1.5", "ккал_на_вес=0", "б_на_вес=0", "ж_на_вес=0", "у_на_вес=0", "комментарий=comment", "нутриенты_на_вес=fiber=3;sugar=1"},
+			args:  &args_f_setw{val0: "key", val1: "key", val2: "comment", val3: 1.5, val4: 0, val5: 0, val6: 0, val7: 0, val8: "comment", val9: storage.Nutrients{storage.NutrientFiber: 3, storage.NutrientSugar: 1}},
+		},
+		{
+			name:  "f,setw too many args",
+			cmd:   "f,setw",
+			parts: []string{"key", "key", "comment", "1.5", "0", "0", "0", "0", "comment", "fiber=3;sugar=1", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "f,setw too few args",
+			cmd:   "f,setw",
+			parts: []string{"key", "key", "comment", "1.5", "0", "0", "0"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "f,setw invalid ключ",
+			cmd:   "f,setw",
+			parts: []string{"", "key", "comment", "1.5", "0", "0", "0", "0", "comment", "fiber=3;sugar=1"},
+			want:  argError("Ключ"),
+		},
+		{
+			name:  "f,setw invalid наименование",
+			cmd:   "f,setw",
+			parts: []string{"key", "", "comment", "1.5", "0", "0", "0", "0", "comment", "fiber=3;sugar=1"},
+			want:  argError("Наименование"),
+		},
+		{
+			name:  "f,setw invalid вес_г",
+			cmd:   "f,setw",
+			parts: []string{"key", "key", "comment", "0", "0", "0", "0", "0", "comment", "fiber=3;sugar=1"},
+			want:  argError("Вес, г."),
+		},
+		{
+			name:  "f,setw invalid ккал_на_вес",
+			cmd:   "f,setw",
+			parts: []string{"key", "key", "comment", "1.5", "-1", "0", "0", "0", "comment", "fiber=3;sugar=1"},
+			want:  argError("ККал на вес"),
+		},
+		{
+			name:  "f,setw invalid б_на_вес",
+			cmd:   "f,setw",
+			parts: []string{"key", "key", "comment", "1.5", "0", "-1", "0", "0", "comment", "fiber=3;sugar=1"},
+			want:  argError("Б на вес"),
+		},
+		{
+			name:  "f,setw invalid ж_на_вес",
+			cmd:   "f,setw",
+			parts: []string{"key", "key", "comment", "1.5", "0", "0", "-1", "0", "comment", "fiber=3;sugar=1"},
+			want:  argError("Ж на вес"),
+		},
+		{
+			name:  "f,setw invalid у_на_вес",
+			cmd:   "f,setw",
+			parts: []string{"key", "key", "comment", "1.5", "0", "0", "0", "-1", "comment", "fiber=3;sugar=1"},
+			want:  argError("У на вес"),
+		},
+		{
+			name:  "f,setw invalid нутриенты_на_вес",
+			cmd:   "f,setw",
+			parts: []string{"key", "key", "comment", "1.5", "0", "0", "0", "0", "comment", "iron=1"},
+			want:  argError("Нутриенты на вес"),
+		},
+		{
+			name:  "f,bev valid",
+			cmd:   "f,bev",
+			parts: []string{"key", "1"},
+			args:  &args_f_bev{val0: "key", val1: true},
+		},
+		{
+			name:  "f,bev named",
+			cmd:   "f,bev",
+			parts: []string{"ключ=key", "напиток=1"},
+			args:  &args_f_bev{val0: "key", val1: true},
+		},
+		{
+			name:  "f,bev too many args",
+			cmd:   "f,bev",
+			parts: []string{"key", "1", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "f,bev too few args",
+			cmd:   "f,bev",
+			parts: []string{"key"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "f,bev invalid ключ",
+			cmd:   "f,bev",
+			parts: []string{"", "1"},
+			want:  argError("Ключ"),
+		},
+		{
+			name:  "f,bev invalid напиток",
+			cmd:   "f,bev",
+			parts: []string{"key", "2"},
+			want:  argError("Напиток"),
+		},
+		{
+			name:  "f,por valid",
+			cmd:   "f,por",
+			parts: []string{"key", "egg=55g;cup=240ml", "0"},
+			args:  &args_f_por{val0: "key", val1: []storage.FoodPortion{{Name: "egg", Amount: 55, Unit: storage.PortionUnitGram}, {Name: "cup", Amount: 240, Unit: storage.PortionUnitMl}}, val2: 0},
+		},
+		{
+			name:  "f,por named",
+			cmd:   "f,por",
+			parts: []string{"ключ=key", "порции=egg=55g;cup=240ml", "плотность_г_мл=0"},
+			args:  &args_f_por{val0: "key", val1: []storage.FoodPortion{{Name: "egg", Amount: 55, Unit: storage.PortionUnitGram}, {Name: "cup", Amount: 240, Unit: storage.PortionUnitMl}}, val2: 0},
+		},
+		{
+			name:  "f,por too many args",
+			cmd:   "f,por",
+			parts: []string{"key", "egg=55g;cup=240ml", "0", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "f,por too few args",
+			cmd:   "f,por",
+			parts: []string{"key", "egg=55g;cup=240ml"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "f,por invalid ключ",
+			cmd:   "f,por",
+			parts: []string{"", "egg=55g;cup=240ml", "0"},
+			want:  argError("Ключ"),
+		},
+		{
+			name:  "f,por invalid порции",
+			cmd:   "f,por",
+			parts: []string{"key", "egg", "0"},
+			want:  argError("Порции"),
+		},
+		{
+			name:  "f,por invalid плотность_г_мл",
+			cmd:   "f,por",
+			parts: []string{"key", "egg=55g;cup=240ml", "-1"},
+			want:  argError("Плотность, г/мл"),
+		},
+		{
+			name:  "f,st valid",
+			cmd:   "f,st",
+			parts: []string{"key"},
+			args:  &args_f_st{val0: "key"},
+		},
+		{
+			name:  "f,st named",
+			cmd:   "f,st",
+			parts: []string{"ключ=key"},
+			args:  &args_f_st{val0: "key"},
+		},
+		{
+			name:  "f,st too many args",
+			cmd:   "f,st",
+			parts: []string{"key", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "f,st too few args",
+			cmd:   "f,st",
+			parts: []string{},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "f,st invalid ключ",
+			cmd:   "f,st",
+			parts: []string{""},
+			want:  argError("Ключ"),
+		},
+		{
+			name:  "f,find valid",
+			cmd:   "f,find",
+			parts: []string{"comment", "pdf"},
+			args:  &args_f_find{val0: "comment", val1: storage.ReportFormatPDF},
+		},
+		{
+			name:  "f,find required only",
+			cmd:   "f,find",
+			parts: []string{"comment"},
+		},
+		{
+			name:  "f,find named",
+			cmd:   "f,find",
+			parts: []string{"подстрока=comment", "формат=pdf"},
+			args:  &args_f_find{val0: "comment", val1: storage.ReportFormatPDF},
+		},
+		{
+			name:  "f,find too many args",
+			cmd:   "f,find",
+			parts: []string{"comment", "pdf", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "f,find too few args",
+			cmd:   "f,find",
+			parts: []string{},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "f,find invalid формат",
+			cmd:   "f,find",
+			parts: []string{"comment", "doc"},
+			want:  argError("Формат"),
+		},
+		{
+			name:  "f,calc valid",
+			cmd:   "f,calc",
+			parts: []string{"key", "2egg"},
+			args:  &args_f_calc{val0: "key", val1: storage.FoodAmount{Value: 2, Unit: "egg"}},
+		},
+		{
+			name:  "f,calc named",
+			cmd:   "f,calc",
+			parts: []string{"ключ=key", "количество=2egg"},
+			args:  &args_f_calc{val0: "key", val1: storage.FoodAmount{Value: 2, Unit: "egg"}},
+		},
+		{
+			name:  "f,calc too many args",
+			cmd:   "f,calc",
+			parts: []string{"key", "2egg", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "f,calc too few args",
+			cmd:   "f,calc",
+			parts: []string{"key"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "f,calc invalid ключ",
+			cmd:   "f,calc",
+			parts: []string{"", "2egg"},
+			want:  argError("Ключ"),
+		},
+		{
+			name:  "f,calc invalid количество",
+			cmd:   "f,calc",
+			parts: []string{"key", "0"},
+			want:  argError("Количество"),
+		},
+		{
+			name:  "f,list valid",
+			cmd:   "f,list",
+			parts: []string{"pdf"},
+			args:  &args_f_list{val0: storage.ReportFormatPDF},
+		},
+		{
+			name:  "f,list required only",
+			cmd:   "f,list",
+			parts: []string{},
+		},
+		{
+			name:  "f,list named",
+			cmd:   "f,list",
+			parts: []string{"формат=pdf"},
+			args:  &args_f_list{val0: storage.ReportFormatPDF},
+		},
+		{
+			name:  "f,list too many args",
+			cmd:   "f,list",
+			parts: []string{"pdf", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "f,list invalid формат",
+			cmd:   "f,list",
+			parts: []string{"doc"},
+			want:  argError("Формат"),
+		},
+		{
+			name:  "f,del valid",
+			cmd:   "f,del",
+			parts: []string{"key"},
+			args:  &args_f_del{val0: "key"},
+		},
+		{
+			name:  "f,del named",
+			cmd:   "f,del",
+			parts: []string{"ключ=key"},
+			args:  &args_f_del{val0: "key"},
+		},
+		{
+			name:  "f,del too many args",
+			cmd:   "f,del",
+			parts: []string{"key", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "f,del too few args",
+			cmd:   "f,del",
+			parts: []string{},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "f,del invalid ключ",
+			cmd:   "f,del",
+			parts: []string{""},
+			want:  argError("Ключ"),
+		},
+		{
+			name:  "c,c valid",
+			cmd:   "c,c",
+			parts: []string{"m", "1.5", "1.5", "1.5"},
+			args:  &args_c_c{val0: "m", val1: 1.5, val2: 1.5, val3: 1.5},
+		},
+		{
+			name:  "c,c named",
+			cmd:   "c,c",
+			parts: []string{"пол=m", "вес=1.5", "рост=1.5", "возраст=1.5"},
+			args:  &args_c_c{val0: "m", val1: 1.5, val2: 1.5, val3: 1.5},
+		},
+		{
+			name:  "c,c too many args",
+			cmd:   "c,c",
+			parts: []string{"m", "1.5", "1.5", "1.5", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "c,c too few args",
+			cmd:   "c,c",
+			parts: []string{"m", "1.5", "1.5"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "c,c invalid пол",
+			cmd:   "c,c",
+			parts: []string{"", "1.5", "1.5", "1.5"},
+			want:  argError("Пол"),
+		},
+		{
+			name:  "c,c invalid вес",
+			cmd:   "c,c",
+			parts: []string{"m", "0", "1.5", "1.5"},
+			want:  argError("Вес"),
+		},
+		{
+			name:  "c,c invalid рост",
+			cmd:   "c,c",
+			parts: []string{"m", "1.5", "0", "1.5"},
+			want:  argError("Рост"),
+		},
+		{
+			name:  "c,c invalid возраст",
+			cmd:   "c,c",
+			parts: []string{"m", "1.5", "1.5", "0"},
+			want:  argError("Возраст"),
+		},
+		{
+			name:  "c,tdee valid",
+			cmd:   "c,tdee",
+			parts: []string{"19.10.2026 08:30", "3"},
+			args:  &args_c_tdee{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: 3},
+		},
+		{
+			name:  "c,tdee named",
+			cmd:   "c,tdee",
+			parts: []string{"дата=19.10.2026 08:30", "недель=3"},
+			args:  &args_c_tdee{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: 3},
+		},
+		{
+			name:  "c,tdee too many args",
+			cmd:   "c,tdee",
+			parts: []string{"19.10.2026 08:30", "3", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "c,tdee too few args",
+			cmd:   "c,tdee",
+			parts: []string{"19.10.2026 08:30"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "c,tdee invalid дата",
+			cmd:   "c,tdee",
+			parts: []string{"32.13.2026", "3"},
+			want:  argError("Дата"),
+		},
+		{
+			name:  "c,tdee invalid недель",
+			cmd:   "c,tdee",
+			parts: []string{"19.10.2026 08:30", "0"},
+			want:  argError("Недель"),
+		},
+		{
+			name:  "c,tdeea valid",
+			cmd:   "c,tdeea",
+			parts: []string{"19.10.2026 08:30", "3"},
+			args:  &args_c_tdeea{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: 3},
+		},
+		{
+			name:  "c,tdeea named",
+			cmd:   "c,tdeea",
+			parts: []string{"дата=19.10.2026 08:30", "недель=3"},
+			args:  &args_c_tdeea{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: 3},
+		},
+		{
+			name:  "c,tdeea too many args",
+			cmd:   "c,tdeea",
+			parts: []string{"19.10.2026 08:30", "3", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "c,tdeea too few args",
+			cmd:   "c,tdeea",
+			parts: []string{"19.10.2026 08:30"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "c,tdeea invalid дата",
+			cmd:   "c,tdeea",
+			parts: []string{"32.13.2026", "3"},
+			want:  argError("Дата"),
+		},
+		{
+			name:  "c,tdeea invalid недель",
+			cmd:   "c,tdeea",
+			parts: []string{"19.10.2026 08:30", "0"},
+			want:  argError("Недель"),
+		},
+		{
+			name:  "c,tdeeh valid",
+			cmd:   "c,tdeeh",
+			parts: []string{"19.10.2026 08:30", "19.10.2026 08:30", "pdf"},
+			args:  &args_c_tdeeh{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val2: storage.ReportFormatPDF},
+		},
+		{
+			name:  "c,tdeeh required only",
+			cmd:   "c,tdeeh",
+			parts: []string{"19.10.2026 08:30", "19.10.2026 08:30"},
+		},
+		{
+			name:  "c,tdeeh named",
+			cmd:   "c,tdeeh",
+			parts: []string{"с=19.10.2026 08:30", "по=19.10.2026 08:30", "формат=pdf"},
+			args:  &args_c_tdeeh{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val2: storage.ReportFormatPDF},
+		},
+		{
+			name:  "c,tdeeh too many args",
+			cmd:   "c,tdeeh",
+			parts: []string{"19.10.2026 08:30", "19.10.2026 08:30", "pdf", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "c,tdeeh too few args",
+			cmd:   "c,tdeeh",
+			parts: []string{"19.10.2026 08:30"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "c,tdeeh invalid с",
+			cmd:   "c,tdeeh",
+			parts: []string{"32.13.2026", "19.10.2026 08:30", "pdf"},
+			want:  argError("С"),
+		},
+		{
+			name:  "c,tdeeh invalid по",
+			cmd:   "c,tdeeh",
+			parts: []string{"19.10.2026 08:30", "32.13.2026", "pdf"},
+			want:  argError("По"),
+		},
+		{
+			name:  "c,tdeeh invalid формат",
+			cmd:   "c,tdeeh",
+			parts: []string{"19.10.2026 08:30", "19.10.2026 08:30", "doc"},
+			want:  argError("Формат"),
+		},
+		{
+			name:  "c,tdeeauto valid",
+			cmd:   "c,tdeeauto",
+			parts: []string{"1"},
+			args:  &args_c_tdeeauto{val0: true},
+		},
+		{
+			name:  "c,tdeeauto named",
+			cmd:   "c,tdeeauto",
+			parts: []string{"включено=1"},
+			args:  &args_c_tdeeauto{val0: true},
+		},
+		{
+			name:  "c,tdeeauto too many args",
+			cmd:   "c,tdeeauto",
+			parts: []string{"1", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "c,tdeeauto too few args",
+			cmd:   "c,tdeeauto",
+			parts: []string{},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "c,tdeeauto invalid включено",
+			cmd:   "c,tdeeauto",
+			parts: []string{"2"},
+			want:  argError("Включено"),
+		},
+		{
+			name:  "b,set valid",
+			cmd:   "b,set",
+			parts: []string{"key", "\"a/b\":100/c"},
+			args:  &args_b_set{val0: "key", val1: []string{"a/b:100", "c"}},
+		},
+		{
+			name:  "b,set named",
+			cmd:   "b,set",
+			parts: []string{"ключ=key", "состав_бандла=\"a/b\":100/c"},
+			args:  &args_b_set{val0: "key", val1: []string{"a/b:100", "c"}},
+		},
+		{
+			name:  "b,set too many args",
+			cmd:   "b,set",
+			parts: []string{"key", "\"a/b\":100/c", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "b,set too few args",
+			cmd:   "b,set",
+			parts: []string{"key"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "b,set invalid ключ",
+			cmd:   "b,set",
+			parts: []string{"", "\"a/b\":100/c"},
+			want:  argError("Ключ"),
+		},
+		{
+			name:  "b,set invalid состав_бандла",
+			cmd:   "b,set",
+			parts: []string{"key", "\"a"},
+			want:  argError("Состав бандла"),
+		},
+		{
+			name:  "b,st valid",
+			cmd:   "b,st",
+			parts: []string{"key"},
+			args:  &args_b_st{val0: "key"},
+		},
+		{
+			name:  "b,st named",
+			cmd:   "b,st",
+			parts: []string{"ключ=key"},
+			args:  &args_b_st{val0: "key"},
+		},
+		{
+			name:  "b,st too many args",
+			cmd:   "b,st",
+			parts: []string{"key", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "b,st too few args",
+			cmd:   "b,st",
+			parts: []string{},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "b,st invalid ключ",
+			cmd:   "b,st",
+			parts: []string{""},
+			want:  argError("Ключ"),
+		},
+		{
+			name:  "b,list valid",
+			cmd:   "b,list",
+			parts: []string{"pdf"},
+			args:  &args_b_list{val0: storage.ReportFormatPDF},
+		},
+		{
+			name:  "b,list required only",
+			cmd:   "b,list",
+			parts: []string{},
+		},
+		{
+			name:  "b,list named",
+			cmd:   "b,list",
+			parts: []string{"формат=pdf"},
+			args:  &args_b_list{val0: storage.ReportFormatPDF},
+		},
+		{
+			name:  "b,list too many args",
+			cmd:   "b,list",
+			parts: []string{"pdf", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "b,list invalid формат",
+			cmd:   "b,list",
+			parts: []string{"doc"},
+			want:  argError("Формат"),
+		},
+		{
+			name:  "b,del valid",
+			cmd:   "b,del",
+			parts: []string{"key"},
+			args:  &args_b_del{val0: "key"},
+		},
+		{
+			name:  "b,del named",
+			cmd:   "b,del",
+			parts: []string{"ключ=key"},
+			args:  &args_b_del{val0: "key"},
+		},
+		{
+			name:  "b,del too many args",
+			cmd:   "b,del",
+			parts: []string{"key", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "b,del too few args",
+			cmd:   "b,del",
+			parts: []string{},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "b,del invalid ключ",
+			cmd:   "b,del",
+			parts: []string{""},
+			want:  argError("Ключ"),
+		},
+		{
+			name:  "j,set valid",
+			cmd:   "j,set",
+			parts: []string{"19.10.2026 08:30", "обед", "key", "2egg"},
+			args:  &args_j_set{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: storage.Meal(2), val2: "key", val3: storage.FoodAmount{Value: 2, Unit: "egg"}},
+		},
+		{
+			name:  "j,set named",
+			cmd:   "j,set",
+			parts: []string{"дата=19.10.2026 08:30", "прием_пищи=обед", "ключ_еды=key", "количество=2egg"},
+			args:  &args_j_set{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: storage.Meal(2), val2: "key", val3: storage.FoodAmount{Value: 2, Unit: "egg"}},
+		},
+		{
+			name:  "j,set too many args",
+			cmd:   "j,set",
+			parts: []string{"19.10.2026 08:30", "обед", "key", "2egg", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "j,set too few args",
+			cmd:   "j,set",
+			parts: []string{"19.10.2026 08:30", "обед", "key"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "j,set invalid дата",
+			cmd:   "j,set",
+			parts: []string{"32.13.2026", "обед", "key", "2egg"},
+			want:  argError("Дата"),
+		},
+		{
+			name:  "j,set invalid прием_пищи",
+			cmd:   "j,set",
+			parts: []string{"19.10.2026 08:30", "x", "key", "2egg"},
+			want:  argError("Прием пищи"),
+		},
+		{
+			name:  "j,set invalid ключ_еды",
+			cmd:   "j,set",
+			parts: []string{"19.10.2026 08:30", "обед", "", "2egg"},
+			want:  argError("Ключ еды"),
+		},
+		{
+			name:  "j,set invalid количество",
+			cmd:   "j,set",
+			parts: []string{"19.10.2026 08:30", "обед", "key", "0"},
+			want:  argError("Количество"),
+		},
+		{
+			name:  "j,sb valid",
+			cmd:   "j,sb",
+			parts: []string{"19.10.2026 08:30", "обед", "key"},
+			args:  &args_j_sb{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: storage.Meal(2), val2: "key"},
+		},
+		{
+			name:  "j,sb named",
+			cmd:   "j,sb",
+			parts: []string{"дата=19.10.2026 08:30", "прием_пищи=обед", "ключ_бандла=key"},
+			args:  &args_j_sb{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: storage.Meal(2), val2: "key"},
+		},
+		{
+			name:  "j,sb too many args",
+			cmd:   "j,sb",
+			parts: []string{"19.10.2026 08:30", "обед", "key", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "j,sb too few args",
+			cmd:   "j,sb",
+			parts: []string{"19.10.2026 08:30", "обед"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "j,sb invalid дата",
+			cmd:   "j,sb",
+			parts: []string{"32.13.2026", "обед", "key"},
+			want:  argError("Дата"),
+		},
+		{
+			name:  "j,sb invalid прием_пищи",
+			cmd:   "j,sb",
+			parts: []string{"19.10.2026 08:30", "x", "key"},
+			want:  argError("Прием пищи"),
+		},
+		{
+			name:  "j,sb invalid ключ_бандла",
+			cmd:   "j,sb",
+			parts: []string{"19.10.2026 08:30", "обед", ""},
+			want:  argError("Ключ бандла"),
+		},
+		{
+			name:  "j,del valid",
+			cmd:   "j,del",
+			parts: []string{"19.10.2026 08:30", "обед", "key"},
+			args:  &args_j_del{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: storage.Meal(2), val2: "key"},
+		},
+		{
+			name:  "j,del named",
+			cmd:   "j,del",
+			parts: []string{"дата=19.10.2026 08:30", "прием_пищи=обед", "ключ_еды=key"},
+			args:  &args_j_del{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: storage.Meal(2), val2: "key"},
+		},
+		{
+			name:  "j,del too many args",
+			cmd:   "j,del",
+			parts: []string{"19.10.2026 08:30", "обед", "key", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "j,del too few args",
+			cmd:   "j,del",
+			parts: []string{"19.10.2026 08:30", "обед"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "j,del invalid дата",
+			cmd:   "j,del",
+			parts: []string{"32.13.2026", "обед", "key"},
+			want:  argError("Дата"),
+		},
+		{
+			name:  "j,del invalid прием_пищи",
+			cmd:   "j,del",
+			parts: []string{"19.10.2026 08:30", "x", "key"},
+			want:  argError("Прием пищи"),
+		},
+		{
+			name:  "j,del invalid ключ_еды",
+			cmd:   "j,del",
+			parts: []string{"19.10.2026 08:30", "обед", ""},
+			want:  argError("Ключ еды"),
+		},
+		{
+			name:  "j,dm valid",
+			cmd:   "j,dm",
+			parts: []string{"19.10.2026 08:30", "обед"},
+			args:  &args_j_dm{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: storage.Meal(2)},
+		},
+		{
+			name:  "j,dm named",
+			cmd:   "j,dm",
+			parts: []string{"дата=19.10.2026 08:30", "прием_пищи=обед"},
+			args:  &args_j_dm{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: storage.Meal(2)},
+		},
+		{
+			name:  "j,dm too many args",
+			cmd:   "j,dm",
+			parts: []string{"19.10.2026 08:30", "обед", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "j,dm too few args",
+			cmd:   "j,dm",
+			parts: []string{"19.10.2026 08:30"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "j,dm invalid дата",
+			cmd:   "j,dm",
+			parts: []string{"32.13.2026", "обед"},
+			want:  argError("Дата"),
+		},
+		{
+			name:  "j,dm invalid прием_пищи",
+			cmd:   "j,dm",
+			parts: []string{"19.10.2026 08:30", "x"},
+			want:  argError("Прием пищи"),
+		},
+		{
+			name:  "j,db valid",
+			cmd:   "j,db",
+			parts: []string{"19.10.2026 08:30", "обед", "key"},
+			args:  &args_j_db{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: storage.Meal(2), val2: "key"},
+		},
+		{
+			name:  "j,db named",
+			cmd:   "j,db",
+			parts: []string{"дата=19.10.2026 08:30", "прием_пищи=обед", "ключ_бандла=key"},
+			args:  &args_j_db{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: storage.Meal(2), val2: "key"},
+		},
+		{
+			name:  "j,db too many args",
+			cmd:   "j,db",
+			parts: []string{"19.10.2026 08:30", "обед", "key", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "j,db too few args",
+			cmd:   "j,db",
+			parts: []string{"19.10.2026 08:30", "обед"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "j,db invalid дата",
+			cmd:   "j,db",
+			parts: []string{"32.13.2026", "обед", "key"},
+			want:  argError("Дата"),
+		},
+		{
+			name:  "j,db invalid прием_пищи",
+			cmd:   "j,db",
+			parts: []string{"19.10.2026 08:30", "x", "key"},
+			want:  argError("Прием пищи"),
+		},
+		{
+			name:  "j,db invalid ключ_бандла",
+			cmd:   "j,db",
+			parts: []string{"19.10.2026 08:30", "обед", ""},
+			want:  argError("Ключ бандла"),
+		},
+		{
+			name:  "j,cp valid",
+			cmd:   "j,cp",
+			parts: []string{"19.10.2026 08:30", "обед", "19.10.2026 08:30", "обед"},
+			args:  &args_j_cp{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: storage.Meal(2), val2: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val3: storage.Meal(2)},
+		},
+		{
+			name:  "j,cp named",
+			cmd:   "j,cp",
+			parts: []string{"откуда_дата=19.10.2026 08:30", "откуда_прием=обед", "куда_дата=19.10.2026 08:30", "куда_прием=обед"},
+			args:  &args_j_cp{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: storage.Meal(2), val2: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val3: storage.Meal(2)},
+		},
+		{
+			name:  "j,cp too many args",
+			cmd:   "j,cp",
+			parts: []string{"19.10.2026 08:30", "обед", "19.10.2026 08:30", "обед", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "j,cp too few args",
+			cmd:   "j,cp",
+			parts: []string{"19.10.2026 08:30", "обед", "19.10.2026 08:30"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "j,cp invalid откуда_дата",
+			cmd:   "j,cp",
+			parts: []string{"32.13.2026", "обед", "19.10.2026 08:30", "обед"},
+			want:  argError("Откуда"),
+		},
+		{
+			name:  "j,cp invalid откуда_прием",
+			cmd:   "j,cp",
+			parts: []string{"19.10.2026 08:30", "x", "19.10.2026 08:30", "обед"},
+			want:  argError("Откуда"),
+		},
+		{
+			name:  "j,cp invalid куда_дата",
+			cmd:   "j,cp",
+			parts: []string{"19.10.2026 08:30", "обед", "32.13.2026", "обед"},
+			want:  argError("Куда"),
+		},
+		{
+			name:  "j,cp invalid куда_прием",
+			cmd:   "j,cp",
+			parts: []string{"19.10.2026 08:30", "обед", "19.10.2026 08:30", "x"},
+			want:  argError("Куда"),
+		},
+		{
+			name:  "j,rd valid",
+			cmd:   "j,rd",
+			parts: []string{"19.10.2026 08:30", "pdf"},
+			args:  &args_j_rd{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: storage.ReportFormatPDF},
+		},
+		{
+			name:  "j,rd required only",
+			cmd:   "j,rd",
+			parts: []string{"19.10.2026 08:30"},
+		},
+		{
+			name:  "j,rd named",
+			cmd:   "j,rd",
+			parts: []string{"дата=19.10.2026 08:30", "формат=pdf"},
+			args:  &args_j_rd{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: storage.ReportFormatPDF},
+		},
+		{
+			name:  "j,rd too many args",
+			cmd:   "j,rd",
+			parts: []string{"19.10.2026 08:30", "pdf", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "j,rd too few args",
+			cmd:   "j,rd",
+			parts: []string{},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "j,rd invalid дата",
+			cmd:   "j,rd",
+			parts: []string{"32.13.2026", "pdf"},
+			want:  argError("Дата"),
+		},
+		{
+			name:  "j,rd invalid формат",
+			cmd:   "j,rd",
+			parts: []string{"19.10.2026 08:30", "doc"},
+			want:  argError("Формат"),
+		},
+		{
+			name:  "j,rdc valid",
+			cmd:   "j,rdc",
+			parts: []string{"19.10.2026 08:30"},
+			args:  &args_j_rdc{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC)},
+		},
+		{
+			name:  "j,rdc named",
+			cmd:   "j,rdc",
+			parts: []string{"дата=19.10.2026 08:30"},
+			args:  &args_j_rdc{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC)},
+		},
+		{
+			name:  "j,rdc too many args",
+			cmd:   "j,rdc",
+			parts: []string{"19.10.2026 08:30", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "j,rdc too few args",
+			cmd:   "j,rdc",
+			parts: []string{},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "j,rdc invalid дата",
+			cmd:   "j,rdc",
+			parts: []string{"32.13.2026"},
+			want:  argError("Дата"),
+		},
+		{
+			name:  "j,tr valid",
+			cmd:   "j,tr",
+			parts: []string{"19.10.2026 08:30", "19.10.2026 08:30", "pdf"},
+			args:  &args_j_tr{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val2: storage.ReportFormatPDF},
+		},
+		{
+			name:  "j,tr required only",
+			cmd:   "j,tr",
+			parts: []string{"19.10.2026 08:30", "19.10.2026 08:30"},
+		},
+		{
+			name:  "j,tr named",
+			cmd:   "j,tr",
+			parts: []string{"с=19.10.2026 08:30", "по=19.10.2026 08:30", "формат=pdf"},
+			args:  &args_j_tr{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val2: storage.ReportFormatPDF},
+		},
+		{
+			name:  "j,tr too many args",
+			cmd:   "j,tr",
+			parts: []string{"19.10.2026 08:30", "19.10.2026 08:30", "pdf", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "j,tr too few args",
+			cmd:   "j,tr",
+			parts: []string{"19.10.2026 08:30"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "j,tr invalid с",
+			cmd:   "j,tr",
+			parts: []string{"32.13.2026", "19.10.2026 08:30", "pdf"},
+			want:  argError("С"),
+		},
+		{
+			name:  "j,tr invalid по",
+			cmd:   "j,tr",
+			parts: []string{"19.10.2026 08:30", "32.13.2026", "pdf"},
+			want:  argError("По"),
+		},
+		{
+			name:  "j,tr invalid формат",
+			cmd:   "j,tr",
+			parts: []string{"19.10.2026 08:30", "19.10.2026 08:30", "doc"},
+			want:  argError("Формат"),
+		},
+		{
+			name:  "j,tm valid",
+			cmd:   "j,tm",
+			parts: []string{"19.10.2026 08:30", "обед"},
+			args:  &args_j_tm{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: storage.Meal(2)},
+		},
+		{
+			name:  "j,tm named",
+			cmd:   "j,tm",
+			parts: []string{"дата=19.10.2026 08:30", "прием_пищи=обед"},
+			args:  &args_j_tm{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: storage.Meal(2)},
+		},
+		{
+			name:  "j,tm too many args",
+			cmd:   "j,tm",
+			parts: []string{"19.10.2026 08:30", "обед", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "j,tm too few args",
+			cmd:   "j,tm",
+			parts: []string{"19.10.2026 08:30"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "j,tm invalid дата",
+			cmd:   "j,tm",
+			parts: []string{"32.13.2026", "обед"},
+			want:  argError("Дата"),
+		},
+		{
+			name:  "j,tm invalid прием_пищи",
+			cmd:   "j,tm",
+			parts: []string{"19.10.2026 08:30", "x"},
+			want:  argError("Прием пищи"),
+		},
+		{
+			name:  "j,sug valid",
+			cmd:   "j,sug",
+			parts: []string{"19.10.2026 08:30", "обед", "3"},
+			args:  &args_j_sug{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: storage.Meal(2), val2: 3},
+		},
+		{
+			name:  "j,sug named",
+			cmd:   "j,sug",
+			parts: []string{"дата=19.10.2026 08:30", "прием_пищи=обед", "дней=3"},
+			args:  &args_j_sug{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: storage.Meal(2), val2: 3},
+		},
+		{
+			name:  "j,sug too many args",
+			cmd:   "j,sug",
+			parts: []string{"19.10.2026 08:30", "обед", "3", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "j,sug too few args",
+			cmd:   "j,sug",
+			parts: []string{"19.10.2026 08:30", "обед"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "j,sug invalid дата",
+			cmd:   "j,sug",
+			parts: []string{"32.13.2026", "обед", "3"},
+			want:  argError("Дата"),
+		},
+		{
+			name:  "j,sug invalid прием_пищи",
+			cmd:   "j,sug",
+			parts: []string{"19.10.2026 08:30", "x", "3"},
+			want:  argError("Прием пищи"),
+		},
+		{
+			name:  "j,sug invalid дней",
+			cmd:   "j,sug",
+			parts: []string{"19.10.2026 08:30", "обед", "0"},
+			want:  argError("Дней"),
+		},
+		{
+			name:  "j,fs valid",
+			cmd:   "j,fs",
+			parts: []string{"key"},
+			args:  &args_j_fs{val0: "key"},
+		},
+		{
+			name:  "j,fs named",
+			cmd:   "j,fs",
+			parts: []string{"ключ_еды=key"},
+			args:  &args_j_fs{val0: "key"},
+		},
+		{
+			name:  "j,fs too many args",
+			cmd:   "j,fs",
+			parts: []string{"key", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "j,fs too few args",
+			cmd:   "j,fs",
+			parts: []string{},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "j,fs invalid ключ_еды",
+			cmd:   "j,fs",
+			parts: []string{""},
+			want:  argError("Ключ еды"),
+		},
+		{
+			name:  "j,sc valid",
+			cmd:   "j,sc",
+			parts: []string{"19.10.2026 08:30", "1.5"},
+			args:  &args_j_sc{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: 1.5},
+		},
+		{
+			name:  "j,sc named",
+			cmd:   "j,sc",
+			parts: []string{"дата=19.10.2026 08:30", "ккал=1.5"},
+			args:  &args_j_sc{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: 1.5},
+		},
+		{
+			name:  "j,sc too many args",
+			cmd:   "j,sc",
+			parts: []string{"19.10.2026 08:30", "1.5", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "j,sc too few args",
+			cmd:   "j,sc",
+			parts: []string{"19.10.2026 08:30"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "j,sc invalid дата",
+			cmd:   "j,sc",
+			parts: []string{"32.13.2026", "1.5"},
+			want:  argError("Дата"),
+		},
+		{
+			name:  "j,sc invalid ккал",
+			cmd:   "j,sc",
+			parts: []string{"19.10.2026 08:30", "0"},
+			want:  argError("ККал"),
+		},
+		{
+			name:  "j,dc valid",
+			cmd:   "j,dc",
+			parts: []string{"19.10.2026 08:30"},
+			args:  &args_j_dc{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC)},
+		},
+		{
+			name:  "j,dc named",
+			cmd:   "j,dc",
+			parts: []string{"дата=19.10.2026 08:30"},
+			args:  &args_j_dc{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC)},
+		},
+		{
+			name:  "j,dc too many args",
+			cmd:   "j,dc",
+			parts: []string{"19.10.2026 08:30", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "j,dc too few args",
+			cmd:   "j,dc",
+			parts: []string{},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "j,dc invalid дата",
+			cmd:   "j,dc",
+			parts: []string{"32.13.2026"},
+			want:  argError("Дата"),
+		},
+		{
+			name:  "s,set valid",
+			cmd:   "s,set",
+			parts: []string{"key", "key", "key", "comment"},
+			args:  &args_s_set{val0: "key", val1: "key", val2: "key", val3: "comment"},
+		},
+		{
+			name:  "s,set named",
+			cmd:   "s,set",
+			parts: []string{"ключ=key", "наименование=key", "единица_измерения=key", "комментарий=comment"},
+			args:  &args_s_set{val0: "key", val1: "key", val2: "key", val3: "comment"},
+		},
+		{
+			name:  "s,set too many args",
+			cmd:   "s,set",
+			parts: []string{"key", "key", "key", "comment", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "s,set too few args",
+			cmd:   "s,set",
+			parts: []string{"key", "key", "key"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "s,set invalid ключ",
+			cmd:   "s,set",
+			parts: []string{"", "key", "key", "comment"},
+			want:  argError("Ключ"),
+		},
+		{
+			name:  "s,set invalid наименование",
+			cmd:   "s,set",
+			parts: []string{"key", "", "key", "comment"},
+			want:  argError("Наименование"),
+		},
+		{
+			name:  "s,set invalid единица_измерения",
+			cmd:   "s,set",
+			parts: []string{"key", "key", "", "comment"},
+			want:  argError("Единица измерения"),
+		},
+		{
+			name:  "s,st valid",
+			cmd:   "s,st",
+			parts: []string{"key"},
+			args:  &args_s_st{val0: "key"},
+		},
+		{
+			name:  "s,st named",
+			cmd:   "s,st",
+			parts: []string{"ключ=key"},
+			args:  &args_s_st{val0: "key"},
+		},
+		{
+			name:  "s,st too many args",
+			cmd:   "s,st",
+			parts: []string{"key", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "s,st too few args",
+			cmd:   "s,st",
+			parts: []string{},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "s,st invalid ключ",
+			cmd:   "s,st",
+			parts: []string{""},
+			want:  argError("Ключ"),
+		},
+		{
+			name:  "s,del valid",
+			cmd:   "s,del",
+			parts: []string{"key"},
+			args:  &args_s_del{val0: "key"},
+		},
+		{
+			name:  "s,del named",
+			cmd:   "s,del",
+			parts: []string{"ключ=key"},
+			args:  &args_s_del{val0: "key"},
+		},
+		{
+			name:  "s,del too many args",
+			cmd:   "s,del",
+			parts: []string{"key", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "s,del too few args",
+			cmd:   "s,del",
+			parts: []string{},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "s,del invalid ключ",
+			cmd:   "s,del",
+			parts: []string{""},
+			want:  argError("Ключ"),
+		},
+		{
+			name:  "s,kind valid",
+			cmd:   "s,kind",
+			parts: []string{"key", "reps"},
+			args:  &args_s_kind{val0: "key", val1: storage.SportSetKindReps},
+		},
+		{
+			name:  "s,kind named",
+			cmd:   "s,kind",
+			parts: []string{"ключ=key", "вид_подходов=reps"},
+			args:  &args_s_kind{val0: "key", val1: storage.SportSetKindReps},
+		},
+		{
+			name:  "s,kind too many args",
+			cmd:   "s,kind",
+			parts: []string{"key", "reps", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "s,kind too few args",
+			cmd:   "s,kind",
+			parts: []string{"key"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "s,kind invalid ключ",
+			cmd:   "s,kind",
+			parts: []string{"", "reps"},
+			want:  argError("Ключ"),
+		},
+		{
+			name:  "s,kind invalid вид_подходов",
+			cmd:   "s,kind",
+			parts: []string{"key", "x"},
+			want:  argError("Вид подходов"),
+		},
+		{
+			name:  "s,cal valid",
+			cmd:   "s,cal",
+			parts: []string{"key", "0", "0"},
+			args:  &args_s_cal{val0: "key", val1: 0, val2: 0},
+		},
+		{
+			name:  "s,cal named",
+			cmd:   "s,cal",
+			parts: []string{"ключ=key", "met=0", "ккал_на_единицу=0"},
+			args:  &args_s_cal{val0: "key", val1: 0, val2: 0},
+		},
+		{
+			name:  "s,cal too many args",
+			cmd:   "s,cal",
+			parts: []string{"key", "0", "0", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "s,cal too few args",
+			cmd:   "s,cal",
+			parts: []string{"key", "0"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "s,cal invalid ключ",
+			cmd:   "s,cal",
+			parts: []string{"", "0", "0"},
+			want:  argError("Ключ"),
+		},
+		{
+			name:  "s,cal invalid met",
+			cmd:   "s,cal",
+			parts: []string{"key", "-1", "0"},
+			want:  argError("MET"),
+		},
+		{
+			name:  "s,cal invalid ккал_на_единицу",
+			cmd:   "s,cal",
+			parts: []string{"key", "0", "-1"},
+			want:  argError("ККал на единицу"),
+		},
+		{
+			name:  "s,list valid",
+			cmd:   "s,list",
+			parts: []string{"pdf"},
+			args:  &args_s_list{val0: storage.ReportFormatPDF},
+		},
+		{
+			name:  "s,list required only",
+			cmd:   "s,list",
+			parts: []string{},
+		},
+		{
+			name:  "s,list named",
+			cmd:   "s,list",
+			parts: []string{"формат=pdf"},
+			args:  &args_s_list{val0: storage.ReportFormatPDF},
+		},
+		{
+			name:  "s,list too many args",
+			cmd:   "s,list",
+			parts: []string{"pdf", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "s,list invalid формат",
+			cmd:   "s,list",
+			parts: []string{"doc"},
+			want:  argError("Формат"),
+		},
+		{
+			name:  "s,as valid",
+			cmd:   "s,as",
+			parts: []string{"19.10.2026 08:30", "key", "8x60/10", "comment"},
+			args:  &args_s_as{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: "key", val2: []storage.SportSet{{Reps: 8, Load: 60}, {Value: 10}}, val3: "comment"},
+		},
+		{
+			name:  "s,as named",
+			cmd:   "s,as",
+			parts: []string{"дата=19.10.2026 08:30", "ключ_спорта=key", "подходы=8x60/10", "комментарий=comment"},
+			args:  &args_s_as{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: "key", val2: []storage.SportSet{{Reps: 8, Load: 60}, {Value: 10}}, val3: "comment"},
+		},
+		{
+			name:  "s,as too many args",
+			cmd:   "s,as",
+			parts: []string{"19.10.2026 08:30", "key", "8x60/10", "comment", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "s,as too few args",
+			cmd:   "s,as",
+			parts: []string{"19.10.2026 08:30", "key", "8x60/10"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "s,as invalid дата",
+			cmd:   "s,as",
+			parts: []string{"32.13.2026", "key", "8x60/10", "comment"},
+			want:  argError("Дата"),
+		},
+		{
+			name:  "s,as invalid ключ_спорта",
+			cmd:   "s,as",
+			parts: []string{"19.10.2026 08:30", "", "8x60/10", "comment"},
+			want:  argError("Ключ спорта"),
+		},
+		{
+			name:  "s,as invalid подходы",
+			cmd:   "s,as",
+			parts: []string{"19.10.2026 08:30", "key", "x", "comment"},
+			want:  argError("Подходы"),
+		},
+		{
+			name:  "s,asd valid",
+			cmd:   "s,asd",
+			parts: []string{"19.10.2026 08:30", "key", "8x60/10", "0", "comment"},
+			args:  &args_s_asd{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: "key", val2: []storage.SportSet{{Reps: 8, Load: 60}, {Value: 10}}, val3: 0, val4: "comment"},
+		},
+		{
+			name:  "s,asd named",
+			cmd:   "s,asd",
+			parts: []string{"дата=19.10.2026 08:30", "ключ_спорта=key", "подходы=8x60/10", "длительность_мин=0", "комментарий=comment"},
+			args:  &args_s_asd{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: "key", val2: []storage.SportSet{{Reps: 8, Load: 60}, {Value: 10}}, val3: 0, val4: "comment"},
+		},
+		{
+			name:  "s,asd too many args",
+			cmd:   "s,asd",
+			parts: []string{"19.10.2026 08:30", "key", "8x60/10", "0", "comment", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "s,asd too few args",
+			cmd:   "s,asd",
+			parts: []string{"19.10.2026 08:30", "key", "8x60/10", "0"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "s,asd invalid дата",
+			cmd:   "s,asd",
+			parts: []string{"32.13.2026", "key", "8x60/10", "0", "comment"},
+			want:  argError("Дата"),
+		},
+		{
+			name:  "s,asd invalid ключ_спорта",
+			cmd:   "s,asd",
+			parts: []string{"19.10.2026 08:30", "", "8x60/10", "0", "comment"},
+			want:  argError("Ключ спорта"),
+		},
+		{
+			name:  "s,asd invalid подходы",
+			cmd:   "s,asd",
+			parts: []string{"19.10.2026 08:30", "key", "x", "0", "comment"},
+			want:  argError("Подходы"),
+		},
+		{
+			name:  "s,asd invalid длительность_мин",
+			cmd:   "s,asd",
+			parts: []string{"19.10.2026 08:30", "key", "8x60/10", "-1", "comment"},
+			want:  argError("Длительность мин"),
+		},
+		{
+			name:  "s,al valid",
+			cmd:   "s,al",
+			parts: []string{"19.10.2026 08:30"},
+			args:  &args_s_al{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC)},
+		},
+		{
+			name:  "s,al named",
+			cmd:   "s,al",
+			parts: []string{"дата=19.10.2026 08:30"},
+			args:  &args_s_al{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC)},
+		},
+		{
+			name:  "s,al too many args",
+			cmd:   "s,al",
+			parts: []string{"19.10.2026 08:30", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "s,al too few args",
+			cmd:   "s,al",
+			parts: []string{},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "s,al invalid дата",
+			cmd:   "s,al",
+			parts: []string{"32.13.2026"},
+			want:  argError("Дата"),
+		},
+		{
+			name:  "s,ad valid",
+			cmd:   "s,ad",
+			parts: []string{"19.10.2026 08:30", "key"},
+			args:  &args_s_ad{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: "key"},
+		},
+		{
+			name:  "s,ad named",
+			cmd:   "s,ad",
+			parts: []string{"дата=19.10.2026 08:30", "ключ_спорта=key"},
+			args:  &args_s_ad{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: "key"},
+		},
+		{
+			name:  "s,ad too many args",
+			cmd:   "s,ad",
+			parts: []string{"19.10.2026 08:30", "key", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "s,ad too few args",
+			cmd:   "s,ad",
+			parts: []string{"19.10.2026 08:30"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "s,ad invalid дата",
+			cmd:   "s,ad",
+			parts: []string{"32.13.2026", "key"},
+			want:  argError("Дата"),
+		},
+		{
+			name:  "s,ad invalid ключ_спорта",
+			cmd:   "s,ad",
+			parts: []string{"19.10.2026 08:30", ""},
+			want:  argError("Ключ спорта"),
+		},
+		{
+			name:  "s,adi valid",
+			cmd:   "s,adi",
+			parts: []string{"3"},
+			args:  &args_s_adi{val0: 3},
+		},
+		{
+			name:  "s,adi named",
+			cmd:   "s,adi",
+			parts: []string{"id=3"},
+			args:  &args_s_adi{val0: 3},
+		},
+		{
+			name:  "s,adi too many args",
+			cmd:   "s,adi",
+			parts: []string{"3", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "s,adi too few args",
+			cmd:   "s,adi",
+			parts: []string{},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "s,adi invalid id",
+			cmd:   "s,adi",
+			parts: []string{"0"},
+			want:  argError("ID"),
+		},
+		{
+			name:  "s,pr valid",
+			cmd:   "s,pr",
+			parts: []string{"key", "pdf"},
+			args:  &args_s_pr{val0: "key", val1: storage.ReportFormatPDF},
+		},
+		{
+			name:  "s,pr required only",
+			cmd:   "s,pr",
+			parts: []string{"key"},
+		},
+		{
+			name:  "s,pr named",
+			cmd:   "s,pr",
+			parts: []string{"ключ_спорта=key", "формат=pdf"},
+			args:  &args_s_pr{val0: "key", val1: storage.ReportFormatPDF},
+		},
+		{
+			name:  "s,pr too many args",
+			cmd:   "s,pr",
+			parts: []string{"key", "pdf", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "s,pr too few args",
+			cmd:   "s,pr",
+			parts: []string{},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "s,pr invalid ключ_спорта",
+			cmd:   "s,pr",
+			parts: []string{"", "pdf"},
+			want:  argError("Ключ спорта"),
+		},
+		{
+			name:  "s,pr invalid формат",
+			cmd:   "s,pr",
+			parts: []string{"key", "doc"},
+			want:  argError("Формат"),
+		},
+		{
+			name:  "s,ar valid",
+			cmd:   "s,ar",
+			parts: []string{"19.10.2026 08:30", "19.10.2026 08:30", "pdf"},
+			args:  &args_s_ar{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val2: storage.ReportFormatPDF},
+		},
+		{
+			name:  "s,ar required only",
+			cmd:   "s,ar",
+			parts: []string{"19.10.2026 08:30", "19.10.2026 08:30"},
+		},
+		{
+			name:  "s,ar named",
+			cmd:   "s,ar",
+			parts: []string{"с=19.10.2026 08:30", "по=19.10.2026 08:30", "формат=pdf"},
+			args:  &args_s_ar{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val2: storage.ReportFormatPDF},
+		},
+		{
+			name:  "s,ar too many args",
+			cmd:   "s,ar",
+			parts: []string{"19.10.2026 08:30", "19.10.2026 08:30", "pdf", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "s,ar too few args",
+			cmd:   "s,ar",
+			parts: []string{"19.10.2026 08:30"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "s,ar invalid с",
+			cmd:   "s,ar",
+			parts: []string{"32.13.2026", "19.10.2026 08:30", "pdf"},
+			want:  argError("С"),
+		},
+		{
+			name:  "s,ar invalid по",
+			cmd:   "s,ar",
+			parts: []string{"19.10.2026 08:30", "32.13.2026", "pdf"},
+			want:  argError("По"),
+		},
+		{
+			name:  "s,ar invalid формат",
+			cmd:   "s,ar",
+			parts: []string{"19.10.2026 08:30", "19.10.2026 08:30", "doc"},
+			want:  argError("Формат"),
+		},
+		{
+			name:  "s,wset valid",
+			cmd:   "s,wset",
+			parts: []string{"key", "key", "squat=8x60/8x60;press=10", "comment"},
+			args:  &args_s_wset{val0: "key", val1: "key", val2: []storage.WorkoutItem{{SportKey: "squat", Sets: []storage.SportSet{{Reps: 8, Load: 60}, {Reps: 8, Load: 60}}}, {SportKey: "press", Sets: []storage.SportSet{{Value: 10}}}}, val3: "comment"},
+		},
+		{
+			name:  "s,wset named",
+			cmd:   "s,wset",
+			parts: []string{"ключ=key", "наименование=key", "упражнения=squat=8x60/8x60;press=10", "комментарий=comment"},
+			args:  &args_s_wset{val0: "key", val1: "key", val2: []storage.WorkoutItem{{SportKey: "squat", Sets: []storage.SportSet{{Reps: 8, Load: 60}, {Reps: 8, Load: 60}}}, {SportKey: "press", Sets: []storage.SportSet{{Value: 10}}}}, val3: "comment"},
+		},
+		{
+			name:  "s,wset too many args",
+			cmd:   "s,wset",
+			parts: []string{"key", "key", "squat=8x60/8x60;press=10", "comment", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "s,wset too few args",
+			cmd:   "s,wset",
+			parts: []string{"key", "key", "squat=8x60/8x60;press=10"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "s,wset invalid ключ",
+			cmd:   "s,wset",
+			parts: []string{"", "key", "squat=8x60/8x60;press=10", "comment"},
+			want:  argError("Ключ"),
+		},
+		{
+			name:  "s,wset invalid наименование",
+			cmd:   "s,wset",
+			parts: []string{"key", "", "squat=8x60/8x60;press=10", "comment"},
+			want:  argError("Наименование"),
+		},
+		{
+			name:  "s,wset invalid упражнения",
+			cmd:   "s,wset",
+			parts: []string{"key", "key", "squat", "comment"},
+			want:  argError("Упражнения"),
+		},
+		{
+			name:  "s,wst valid",
+			cmd:   "s,wst",
+			parts: []string{"key"},
+			args:  &args_s_wst{val0: "key"},
+		},
+		{
+			name:  "s,wst named",
+			cmd:   "s,wst",
+			parts: []string{"ключ=key"},
+			args:  &args_s_wst{val0: "key"},
+		},
+		{
+			name:  "s,wst too many args",
+			cmd:   "s,wst",
+			parts: []string{"key", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "s,wst too few args",
+			cmd:   "s,wst",
+			parts: []string{},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "s,wst invalid ключ",
+			cmd:   "s,wst",
+			parts: []string{""},
+			want:  argError("Ключ"),
+		},
+		{
+			name:  "s,wdel valid",
+			cmd:   "s,wdel",
+			parts: []string{"key"},
+			args:  &args_s_wdel{val0: "key"},
+		},
+		{
+			name:  "s,wdel named",
+			cmd:   "s,wdel",
+			parts: []string{"ключ=key"},
+			args:  &args_s_wdel{val0: "key"},
+		},
+		{
+			name:  "s,wdel too many args",
+			cmd:   "s,wdel",
+			parts: []string{"key", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "s,wdel too few args",
+			cmd:   "s,wdel",
+			parts: []string{},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "s,wdel invalid ключ",
+			cmd:   "s,wdel",
+			parts: []string{""},
+			want:  argError("Ключ"),
+		},
+		{
+			name:  "s,wlist valid",
+			cmd:   "s,wlist",
+			parts: []string{"pdf"},
+			args:  &args_s_wlist{val0: storage.ReportFormatPDF},
+		},
+		{
+			name:  "s,wlist required only",
+			cmd:   "s,wlist",
+			parts: []string{},
+		},
+		{
+			name:  "s,wlist named",
+			cmd:   "s,wlist",
+			parts: []string{"формат=pdf"},
+			args:  &args_s_wlist{val0: storage.ReportFormatPDF},
+		},
+		{
+			name:  "s,wlist too many args",
+			cmd:   "s,wlist",
+			parts: []string{"pdf", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "s,wlist invalid формат",
+			cmd:   "s,wlist",
+			parts: []string{"doc"},
+			want:  argError("Формат"),
+		},
+		{
+			name:  "s,pset valid",
+			cmd:   "s,pset",
+			parts: []string{"1", "key"},
+			args:  &args_s_pset{val0: time.Monday, val1: "key"},
+		},
+		{
+			name:  "s,pset named",
+			cmd:   "s,pset",
+			parts: []string{"день_недели=1", "ключ_тренировки=key"},
+			args:  &args_s_pset{val0: time.Monday, val1: "key"},
+		},
+		{
+			name:  "s,pset too many args",
+			cmd:   "s,pset",
+			parts: []string{"1", "key", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "s,pset too few args",
+			cmd:   "s,pset",
+			parts: []string{"1"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "s,pset invalid день_недели",
+			cmd:   "s,pset",
+			parts: []string{"8", "key"},
+			want:  argError("День недели"),
+		},
+		{
+			name:  "s,pset invalid ключ_тренировки",
+			cmd:   "s,pset",
+			parts: []string{"1", ""},
+			want:  argError("Ключ тренировки"),
+		},
+		{
+			name:  "s,pdel valid",
+			cmd:   "s,pdel",
+			parts: []string{"1"},
+			args:  &args_s_pdel{val0: time.Monday},
+		},
+		{
+			name:  "s,pdel named",
+			cmd:   "s,pdel",
+			parts: []string{"день_недели=1"},
+			args:  &args_s_pdel{val0: time.Monday},
+		},
+		{
+			name:  "s,pdel too many args",
+			cmd:   "s,pdel",
+			parts: []string{"1", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "s,pdel too few args",
+			cmd:   "s,pdel",
+			parts: []string{},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "s,pdel invalid день_недели",
+			cmd:   "s,pdel",
+			parts: []string{"8"},
+			want:  argError("День недели"),
+		},
+		{
+			name:  "s,pc valid",
+			cmd:   "s,pc",
+			parts: []string{"19.10.2026 08:30", "19.10.2026 08:30", "pdf"},
+			args:  &args_s_pc{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val2: storage.ReportFormatPDF},
+		},
+		{
+			name:  "s,pc required only",
+			cmd:   "s,pc",
+			parts: []string{"19.10.2026 08:30", "19.10.2026 08:30"},
+		},
+		{
+			name:  "s,pc named",
+			cmd:   "s,pc",
+			parts: []string{"с=19.10.2026 08:30", "по=19.10.2026 08:30", "формат=pdf"},
+			args:  &args_s_pc{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val2: storage.ReportFormatPDF},
+		},
+		{
+			name:  "s,pc too many args",
+			cmd:   "s,pc",
+			parts: []string{"19.10.2026 08:30", "19.10.2026 08:30", "pdf", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "s,pc too few args",
+			cmd:   "s,pc",
+			parts: []string{"19.10.2026 08:30"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "s,pc invalid с",
+			cmd:   "s,pc",
+			parts: []string{"32.13.2026", "19.10.2026 08:30", "pdf"},
+			want:  argError("С"),
+		},
+		{
+			name:  "s,pc invalid по",
+			cmd:   "s,pc",
+			parts: []string{"19.10.2026 08:30", "32.13.2026", "pdf"},
+			want:  argError("По"),
+		},
+		{
+			name:  "s,pc invalid формат",
+			cmd:   "s,pc",
+			parts: []string{"19.10.2026 08:30", "19.10.2026 08:30", "doc"},
+			want:  argError("Формат"),
+		},
+		{
+			name:  "m,set valid",
+			cmd:   "m,set",
+			parts: []string{"key", "key", "key", "comment"},
+			args:  &args_m_set{val0: "key", val1: "key", val2: "key", val3: "comment"},
+		},
+		{
+			name:  "m,set named",
+			cmd:   "m,set",
+			parts: []string{"ключ=key", "наименование=key", "единица_измерения=key", "комментарий=comment"},
+			args:  &args_m_set{val0: "key", val1: "key", val2: "key", val3: "comment"},
+		},
+		{
+			name:  "m,set too many args",
+			cmd:   "m,set",
+			parts: []string{"key", "key", "key", "comment", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "m,set too few args",
+			cmd:   "m,set",
+			parts: []string{"key", "key", "key"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "m,set invalid ключ",
+			cmd:   "m,set",
+			parts: []string{"", "key", "key", "comment"},
+			want:  argError("Ключ"),
+		},
+		{
+			name:  "m,set invalid наименование",
+			cmd:   "m,set",
+			parts: []string{"key", "", "key", "comment"},
+			want:  argError("Наименование"),
+		},
+		{
+			name:  "m,set invalid единица_измерения",
+			cmd:   "m,set",
+			parts: []string{"key", "key", "", "comment"},
+			want:  argError("Единица измерения"),
+		},
+		{
+			name:  "m,st valid",
+			cmd:   "m,st",
+			parts: []string{"key"},
+			args:  &args_m_st{val0: "key"},
+		},
+		{
+			name:  "m,st named",
+			cmd:   "m,st",
+			parts: []string{"ключ=key"},
+			args:  &args_m_st{val0: "key"},
+		},
+		{
+			name:  "m,st too many args",
+			cmd:   "m,st",
+			parts: []string{"key", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "m,st too few args",
+			cmd:   "m,st",
+			parts: []string{},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "m,st invalid ключ",
+			cmd:   "m,st",
+			parts: []string{""},
+			want:  argError("Ключ"),
+		},
+		{
+			name:  "m,del valid",
+			cmd:   "m,del",
+			parts: []string{"key"},
+			args:  &args_m_del{val0: "key"},
+		},
+		{
+			name:  "m,del named",
+			cmd:   "m,del",
+			parts: []string{"ключ=key"},
+			args:  &args_m_del{val0: "key"},
+		},
+		{
+			name:  "m,del too many args",
+			cmd:   "m,del",
+			parts: []string{"key", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "m,del too few args",
+			cmd:   "m,del",
+			parts: []string{},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "m,del invalid ключ",
+			cmd:   "m,del",
+			parts: []string{""},
+			want:  argError("Ключ"),
+		},
+		{
+			name:  "m,list valid",
+			cmd:   "m,list",
+			parts: []string{"pdf"},
+			args:  &args_m_list{val0: storage.ReportFormatPDF},
+		},
+		{
+			name:  "m,list required only",
+			cmd:   "m,list",
+			parts: []string{},
+		},
+		{
+			name:  "m,list named",
+			cmd:   "m,list",
+			parts: []string{"формат=pdf"},
+			args:  &args_m_list{val0: storage.ReportFormatPDF},
+		},
+		{
+			name:  "m,list too many args",
+			cmd:   "m,list",
+			parts: []string{"pdf", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "m,list invalid формат",
+			cmd:   "m,list",
+			parts: []string{"doc"},
+			want:  argError("Формат"),
+		},
+		{
+			name:  "m,is valid",
+			cmd:   "m,is",
+			parts: []string{"19.10.2026 08:30", "key", "0"},
+			args:  &args_m_is{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: "key", val2: 0},
+		},
+		{
+			name:  "m,is named",
+			cmd:   "m,is",
+			parts: []string{"дата=19.10.2026 08:30", "ключ_медицины=key", "значениe=0"},
+			args:  &args_m_is{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: "key", val2: 0},
+		},
+		{
+			name:  "m,is too many args",
+			cmd:   "m,is",
+			parts: []string{"19.10.2026 08:30", "key", "0", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "m,is too few args",
+			cmd:   "m,is",
+			parts: []string{"19.10.2026 08:30", "key"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "m,is invalid дата",
+			cmd:   "m,is",
+			parts: []string{"32.13.2026", "key", "0"},
+			want:  argError("Дата"),
+		},
+		{
+			name:  "m,is invalid ключ_медицины",
+			cmd:   "m,is",
+			parts: []string{"19.10.2026 08:30", "", "0"},
+			want:  argError("Ключ медицины"),
+		},
+		{
+			name:  "m,is invalid значениe",
+			cmd:   "m,is",
+			parts: []string{"19.10.2026 08:30", "key", "-1"},
+			want:  argError("Значениe"),
+		},
+		{
+			name:  "m,id valid",
+			cmd:   "m,id",
+			parts: []string{"19.10.2026 08:30", "key"},
+			args:  &args_m_id{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: "key"},
+		},
+		{
+			name:  "m,id named",
+			cmd:   "m,id",
+			parts: []string{"дата=19.10.2026 08:30", "ключ_спорта=key"},
+			args:  &args_m_id{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: "key"},
+		},
+		{
+			name:  "m,id too many args",
+			cmd:   "m,id",
+			parts: []string{"19.10.2026 08:30", "key", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "m,id too few args",
+			cmd:   "m,id",
+			parts: []string{"19.10.2026 08:30"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "m,id invalid дата",
+			cmd:   "m,id",
+			parts: []string{"32.13.2026", "key"},
+			want:  argError("Дата"),
+		},
+		{
+			name:  "m,id invalid ключ_спорта",
+			cmd:   "m,id",
+			parts: []string{"19.10.2026 08:30", ""},
+			want:  argError("Ключ спорта"),
+		},
+		{
+			name:  "m,ir valid",
+			cmd:   "m,ir",
+			parts: []string{"19.10.2026 08:30", "19.10.2026 08:30", "pdf"},
+			args:  &args_m_ir{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val2: storage.ReportFormatPDF},
+		},
+		{
+			name:  "m,ir required only",
+			cmd:   "m,ir",
+			parts: []string{"19.10.2026 08:30", "19.10.2026 08:30"},
+		},
+		{
+			name:  "m,ir named",
+			cmd:   "m,ir",
+			parts: []string{"с=19.10.2026 08:30", "по=19.10.2026 08:30", "формат=pdf"},
+			args:  &args_m_ir{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val2: storage.ReportFormatPDF},
+		},
+		{
+			name:  "m,ir too many args",
+			cmd:   "m,ir",
+			parts: []string{"19.10.2026 08:30", "19.10.2026 08:30", "pdf", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "m,ir too few args",
+			cmd:   "m,ir",
+			parts: []string{"19.10.2026 08:30"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "m,ir invalid с",
+			cmd:   "m,ir",
+			parts: []string{"32.13.2026", "19.10.2026 08:30", "pdf"},
+			want:  argError("С"),
+		},
+		{
+			name:  "m,ir invalid по",
+			cmd:   "m,ir",
+			parts: []string{"19.10.2026 08:30", "32.13.2026", "pdf"},
+			want:  argError("По"),
+		},
+		{
+			name:  "m,ir invalid формат",
+			cmd:   "m,ir",
+			parts: []string{"19.10.2026 08:30", "19.10.2026 08:30", "doc"},
+			want:  argError("Формат"),
+		},
+		{
+			name:  "wa,add valid",
+			cmd:   "wa,add",
+			parts: []string{"19.10.2026 08:30", "1.5", "1.5"},
+			args:  &args_wa_add{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: []float64{1.5, 1.5}},
+		},
+		{
+			name:  "wa,add too few args",
+			cmd:   "wa,add",
+			parts: []string{"19.10.2026 08:30"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "wa,add invalid дата",
+			cmd:   "wa,add",
+			parts: []string{"32.13.2026", "1.5", "1.5"},
+			want:  argError("Дата"),
+		},
+		{
+			name:  "wa,add invalid объем_мл",
+			cmd:   "wa,add",
+			parts: []string{"19.10.2026 08:30", "0", "1.5"},
+			want:  argError("Объем, мл"),
+		},
+		{
+			name:  "wa,del valid",
+			cmd:   "wa,del",
+			parts: []string{"19.10.2026 08:30"},
+			args:  &args_wa_del{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC)},
+		},
+		{
+			name:  "wa,del named",
+			cmd:   "wa,del",
+			parts: []string{"дата=19.10.2026 08:30"},
+			args:  &args_wa_del{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC)},
+		},
+		{
+			name:  "wa,del too many args",
+			cmd:   "wa,del",
+			parts: []string{"19.10.2026 08:30", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "wa,del too few args",
+			cmd:   "wa,del",
+			parts: []string{},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "wa,del invalid дата",
+			cmd:   "wa,del",
+			parts: []string{"32.13.2026"},
+			want:  argError("Дата"),
+		},
+		{
+			name:  "wa,list valid",
+			cmd:   "wa,list",
+			parts: []string{"19.10.2026 08:30"},
+			args:  &args_wa_list{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC)},
+		},
+		{
+			name:  "wa,list named",
+			cmd:   "wa,list",
+			parts: []string{"дата=19.10.2026 08:30"},
+			args:  &args_wa_list{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC)},
+		},
+		{
+			name:  "wa,list too many args",
+			cmd:   "wa,list",
+			parts: []string{"19.10.2026 08:30", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "wa,list too few args",
+			cmd:   "wa,list",
+			parts: []string{},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "wa,list invalid дата",
+			cmd:   "wa,list",
+			parts: []string{"32.13.2026"},
+			want:  argError("Дата"),
+		},
+		{
+			name:  "sl,set valid",
+			cmd:   "sl,set",
+			parts: []string{"19.10.2026 08:30", "19.10.2026 08:30", "3", "comment"},
+			args:  &args_sl_set{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val2: 3, val3: "comment"},
+		},
+		{
+			name:  "sl,set required only",
+			cmd:   "sl,set",
+			parts: []string{"19.10.2026 08:30", "19.10.2026 08:30", "3"},
+		},
+		{
+			name:  "sl,set named",
+			cmd:   "sl,set",
+			parts: []string{"отбой=19.10.2026 08:30", "подъем=19.10.2026 08:30", "качество=3", "заметки=comment"},
+			args:  &args_sl_set{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val2: 3, val3: "comment"},
+		},
+		{
+			name:  "sl,set too many args",
+			cmd:   "sl,set",
+			parts: []string{"19.10.2026 08:30", "19.10.2026 08:30", "3", "comment", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "sl,set too few args",
+			cmd:   "sl,set",
+			parts: []string{"19.10.2026 08:30", "19.10.2026 08:30"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "sl,set invalid отбой",
+			cmd:   "sl,set",
+			parts: []string{"32.13.2026", "19.10.2026 08:30", "3", "comment"},
+			want:  argError("Отбой"),
+		},
+		{
+			name:  "sl,set invalid подъем",
+			cmd:   "sl,set",
+			parts: []string{"19.10.2026 08:30", "32.13.2026", "3", "comment"},
+			want:  argError("Подъем"),
+		},
+		{
+			name:  "sl,set invalid качество",
+			cmd:   "sl,set",
+			parts: []string{"19.10.2026 08:30", "19.10.2026 08:30", "0", "comment"},
+			want:  argError("Качество"),
+		},
+		{
+			name:  "sl,set below min качество",
+			cmd:   "sl,set",
+			parts: []string{"19.10.2026 08:30", "19.10.2026 08:30", "0", "comment"},
+			want:  argError("Качество"),
+		},
+		{
+			name:  "sl,set above max качество",
+			cmd:   "sl,set",
+			parts: []string{"19.10.2026 08:30", "19.10.2026 08:30", "6", "comment"},
+			want:  argError("Качество"),
+		},
+		{
+			name:  "sl,st valid",
+			cmd:   "sl,st",
+			parts: []string{"19.10.2026 08:30"},
+			args:  &args_sl_st{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC)},
+		},
+		{
+			name:  "sl,st named",
+			cmd:   "sl,st",
+			parts: []string{"дата_подъема=19.10.2026 08:30"},
+			args:  &args_sl_st{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC)},
+		},
+		{
+			name:  "sl,st too many args",
+			cmd:   "sl,st",
+			parts: []string{"19.10.2026 08:30", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "sl,st too few args",
+			cmd:   "sl,st",
+			parts: []string{},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "sl,st invalid дата_подъема",
+			cmd:   "sl,st",
+			parts: []string{"32.13.2026"},
+			want:  argError("Дата подъема"),
+		},
+		{
+			name:  "sl,del valid",
+			cmd:   "sl,del",
+			parts: []string{"19.10.2026 08:30"},
+			args:  &args_sl_del{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC)},
+		},
+		{
+			name:  "sl,del named",
+			cmd:   "sl,del",
+			parts: []string{"дата_подъема=19.10.2026 08:30"},
+			args:  &args_sl_del{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC)},
+		},
+		{
+			name:  "sl,del too many args",
+			cmd:   "sl,del",
+			parts: []string{"19.10.2026 08:30", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "sl,del too few args",
+			cmd:   "sl,del",
+			parts: []string{},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "sl,del invalid дата_подъема",
+			cmd:   "sl,del",
+			parts: []string{"32.13.2026"},
+			want:  argError("Дата подъема"),
+		},
+		{
+			name:  "sl,r valid",
+			cmd:   "sl,r",
+			parts: []string{"19.10.2026 08:30", "19.10.2026 08:30", "pdf"},
+			args:  &args_sl_r{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val2: storage.ReportFormatPDF},
+		},
+		{
+			name:  "sl,r required only",
+			cmd:   "sl,r",
+			parts: []string{"19.10.2026 08:30", "19.10.2026 08:30"},
+		},
+		{
+			name:  "sl,r named",
+			cmd:   "sl,r",
+			parts: []string{"с=19.10.2026 08:30", "по=19.10.2026 08:30", "формат=pdf"},
+			args:  &args_sl_r{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val2: storage.ReportFormatPDF},
+		},
+		{
+			name:  "sl,r too many args",
+			cmd:   "sl,r",
+			parts: []string{"19.10.2026 08:30", "19.10.2026 08:30", "pdf", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "sl,r too few args",
+			cmd:   "sl,r",
+			parts: []string{"19.10.2026 08:30"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "sl,r invalid с",
+			cmd:   "sl,r",
+			parts: []string{"32.13.2026", "19.10.2026 08:30", "pdf"},
+			want:  argError("С"),
+		},
+		{
+			name:  "sl,r invalid по",
+			cmd:   "sl,r",
+			parts: []string{"19.10.2026 08:30", "32.13.2026", "pdf"},
+			want:  argError("По"),
+		},
+		{
+			name:  "sl,r invalid формат",
+			cmd:   "sl,r",
+			parts: []string{"19.10.2026 08:30", "19.10.2026 08:30", "doc"},
+			want:  argError("Формат"),
+		},
+		{
+			name:  "fa,start valid",
+			cmd:   "fa,start",
+			parts: []string{"19.10.2026 08:30"},
+			args:  &args_fa_start{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC)},
+		},
+		{
+			name:  "fa,start named",
+			cmd:   "fa,start",
+			parts: []string{"начало=19.10.2026 08:30"},
+			args:  &args_fa_start{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC)},
+		},
+		{
+			name:  "fa,start too many args",
+			cmd:   "fa,start",
+			parts: []string{"19.10.2026 08:30", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "fa,start too few args",
+			cmd:   "fa,start",
+			parts: []string{},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "fa,start invalid начало",
+			cmd:   "fa,start",
+			parts: []string{"32.13.2026"},
+			want:  argError("Начало"),
+		},
+		{
+			name:  "fa,stop valid",
+			cmd:   "fa,stop",
+			parts: []string{"19.10.2026 08:30"},
+			args:  &args_fa_stop{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC)},
+		},
+		{
+			name:  "fa,stop named",
+			cmd:   "fa,stop",
+			parts: []string{"окончание=19.10.2026 08:30"},
+			args:  &args_fa_stop{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC)},
+		},
+		{
+			name:  "fa,stop too many args",
+			cmd:   "fa,stop",
+			parts: []string{"19.10.2026 08:30", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "fa,stop too few args",
+			cmd:   "fa,stop",
+			parts: []string{},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "fa,stop invalid окончание",
+			cmd:   "fa,stop",
+			parts: []string{"32.13.2026"},
+			want:  argError("Окончание"),
+		},
+		{
+			name:  "fa,del valid",
+			cmd:   "fa,del",
+			parts: []string{"19.10.2026 08:30"},
+			args:  &args_fa_del{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC)},
+		},
+		{
+			name:  "fa,del named",
+			cmd:   "fa,del",
+			parts: []string{"начало=19.10.2026 08:30"},
+			args:  &args_fa_del{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC)},
+		},
+		{
+			name:  "fa,del too many args",
+			cmd:   "fa,del",
+			parts: []string{"19.10.2026 08:30", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "fa,del too few args",
+			cmd:   "fa,del",
+			parts: []string{},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "fa,del invalid начало",
+			cmd:   "fa,del",
+			parts: []string{"32.13.2026"},
+			want:  argError("Начало"),
+		},
+		{
+			name:  "fa,r valid",
+			cmd:   "fa,r",
+			parts: []string{"19.10.2026 08:30", "19.10.2026 08:30", "1.5", "pdf"},
+			args:  &args_fa_r{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val2: 1.5, val3: storage.ReportFormatPDF},
+		},
+		{
+			name:  "fa,r required only",
+			cmd:   "fa,r",
+			parts: []string{"19.10.2026 08:30", "19.10.2026 08:30"},
+		},
+		{
+			name:  "fa,r named",
+			cmd:   "fa,r",
+			parts: []string{"с=19.10.2026 08:30", "по=19.10.2026 08:30", "окно_питания_ч=1.5", "формат=pdf"},
+			args:  &args_fa_r{val0: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val1: time.Date(2026, 10, 19, 8, 30, 0, 0, time.UTC), val2: 1.5, val3: storage.ReportFormatPDF},
+		},
+		{
+			name:  "fa,r too many args",
+			cmd:   "fa,r",
+			parts: []string{"19.10.2026 08:30", "19.10.2026 08:30", "1.5", "pdf", "extra"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "fa,r too few args",
+			cmd:   "fa,r",
+			parts: []string{"19.10.2026 08:30"},
+			want:  NewErrCmdResponse(m.MsgErrInvalidArgsCount),
+		},
+		{
+			name:  "fa,r invalid с",
+			cmd:   "fa,r",
+			parts: []string{"32.13.2026", "19.10.2026 08:30", "1.5", "pdf"},
+			want:  argError("С"),
+		},
+		{
+			name:  "fa,r invalid по",
+			cmd:   "fa,r",
+			parts: []string{"19.10.2026 08:30", "32.13.2026", "1.5", "pdf"},
+			want:  argError("По"),
+		},
+		{
+			name:  "fa,r invalid окно_питания_ч",
+			cmd:   "fa,r",
+			parts: []string{"19.10.2026 08:30", "19.10.2026 08:30", "0", "pdf"},
+			want:  argError("Окно питания, ч"),
+		},
+		{
+			name:  "fa,r above max окно_питания_ч",
+			cmd:   "fa,r",
+			parts: []string{"19.10.2026 08:30", "19.10.2026 08:30", "25", "pdf"},
+			want:  argError("Окно питания, ч"),
+		},
+		{
+			name:  "fa,r invalid формат",
+			cmd:   "fa,r",
+			parts: []string{"19.10.2026 08:30", "19.10.2026 08:30", "1.5", "doc"},
+			want:  argError("Формат"),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			args, resp := parsers[tt.cmd](tt.parts)
+			assert.Equal(t, tt.want, resp)
+			if tt.args != nil {
+				assert.Equal(t, tt.args, args)
+			}
+		})
+	}
+}
+
+func TestSplitArgs(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		cmd     string
+		want    []string
+		wantErr bool
+	}{
+		{name: "simple", cmd: "a,b,c", want: []string{"a", "b", "c"}},
+		{name: "empty cmd", cmd: "", want: []string{""}},
+		{name: "empty args", cmd: "a,,", want: []string{"a", "", ""}},
+		{name: "comma inside quotes", cmd: "a,\"b,c\",d", want: []string{"a", "\"b,c\"", "d"}},
+		{name: "escaped quote", cmd: "a,b\\\"c", want: []string{"a", "b\\\"c"}},
+		{name: "escaped quote inside quotes", cmd: "\"a\\\",b\"", want: []string{"\"a\\\",b\""}},
+		{name: "escaped comma", cmd: "a\\,b,c", want: []string{"a\\,b", "c"}},
+		{name: "unterminated quote", cmd: "a,\"b,c", wantErr: true},
+		{name: "unterminated escape", cmd: "a,b\\", wantErr: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			parts, err := splitArgs(tt.cmd, ',')
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, parts)
+		})
+	}
+}
+
+func TestUnquoteArg(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		arg  string
+		want string
+	}{
+		{name: "plain", arg: "abc", want: "abc"},
+		{name: "empty", arg: "", want: ""},
+		{name: "empty quotes", arg: "\"\"", want: ""},
+		{name: "spaces trimmed", arg: "  a b  ", want: "a b"},
+		{name: "spaces in quotes kept", arg: " \" a b \" ", want: " a b "},
+		{name: "comma in quotes", arg: "\"b,c\"", want: "b,c"},
+		{name: "escaped quote", arg: "b\\\"c", want: "b\"c"},
+		{name: "escaped backslash", arg: "b\\\\c", want: "b\\c"},
+		{name: "escaped comma", arg: "a\\,b", want: "a,b"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, unquoteArg(tt.arg))
+		})
+	}
+}
+
+func TestQuoteArg(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		arg  string
+		want string
+	}{
+		{name: "plain", arg: "abc", want: "abc"},
+		{name: "empty", arg: "", want: ""},
+		{name: "comma", arg: "a,b", want: "\"a,b\""},
+		{name: "named arg", arg: "k=v", want: "\"k=v\""},
+		{name: "quote", arg: "say \"hi\"", want: "\"say \\\"hi\\\"\""},
+		{name: "backslash", arg: "a\\b", want: "\"a\\\\b\""},
+		{name: "spaces around", arg: " a ", want: "\" a \""},
+		{name: "unicode", arg: "Яйцо куриное", want: "Яйцо куриное"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			quoted := quoteArg(tt.arg)
+			assert.Equal(t, tt.want, quoted)
+
+			// Round trip
+			parts, err := splitArgs(quoted+","+quoted, ',')
+			assert.NoError(t, err)
+			assert.Equal(t, []string{quoted, quoted}, parts)
+			for _, part := range parts {
+				assert.Equal(t, tt.arg, unquoteArg(part))
+			}
+		})
+	}
+}
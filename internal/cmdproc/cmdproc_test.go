@@ -0,0 +1,295 @@
+package cmdproc
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devldavydov/myhealth/internal/common/html"
+	"github.com/devldavydov/myhealth/internal/storage/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files of commands output")
+
+// Now of golden tests is Saturday.
+var _goldenNow = time.Date(2025, 3, 15, 12, 0, 0, 0, time.UTC)
+
+type goldenTypeAdapter struct{}
+
+type goldenFile struct {
+	buf      *bytes.Buffer
+	mime     string
+	fileName string
+}
+
+type goldenPhoto struct {
+	buf     *bytes.Buffer
+	caption string
+}
+
+type goldenOptsHTML struct{}
+
+func (goldenTypeAdapter) File(buf *bytes.Buffer, mime string, fileName string) any {
+	return goldenFile{buf: buf, mime: mime, fileName: fileName}
+}
+
+func (goldenTypeAdapter) Photo(buf *bytes.Buffer, caption string) any {
+	return goldenPhoto{buf: buf, caption: caption}
+}
+
+func (goldenTypeAdapter) OptsHTML() any {
+	return goldenOptsHTML{}
+}
+
+// goldenCmdProcess writes sent messages as text, content of binary
+// files is not written.
+type goldenCmdProcess struct {
+	sb strings.Builder
+}
+
+func (r *goldenCmdProcess) Send(what any, opts ...any) error {
+	switch v := what.(type) {
+	case string:
+		if slices.Contains(opts, any(goldenOptsHTML{})) {
+			fmt.Fprintf(&r.sb, "--- html\n%s\n", v)
+		} else {
+			fmt.Fprintf(&r.sb, "--- text\n%s\n", v)
+		}
+	case goldenFile:
+		fmt.Fprintf(&r.sb, "--- file %s %s\n", v.fileName, v.mime)
+		if v.mime == "text/html" || v.mime == "image/svg+xml" {
+			fmt.Fprintf(&r.sb, "%s\n", v.buf.String())
+		}
+	case goldenPhoto:
+		fmt.Fprintf(&r.sb, "--- photo\n%s\n", v.caption)
+	default:
+		return fmt.Errorf("unknown message %T", what)
+	}
+	return nil
+}
+
+// _goldenFixture is data of user for all golden tests.
+var _goldenFixture = []string{
+	// User settings
+	"u,set,2000",
+	"u,prof,m,01.01.1990,180,3,15,lose,0.5",
+	"u,water,2000,1",
+	"u,nut,fiber=30;sugar=50",
+	// Food
+	"f,set,egg,Яйцо,Ферма,157,12.7,11.5,0.7,,fiber=0.1",
+	"f,por,egg,egg=55g,0",
+	"f,set,milk,Молоко,Домик,60,3,3.2,4.7,Коровье",
+	"f,bev,milk,1",
+	"f,set,oat,Овсянка,,370,12,6,60,,fiber=10;sugar=1",
+	"f,set,apple,Яблоко,,52,0.3,0.2,14,,fiber=2.4;sugar=10",
+	// Bundles
+	"b,set,porridge,oat:50/milk:200",
+	"b,set,breakfast,porridge/egg:110",
+	// Weight
+	"w,set,01.03.2025,82",
+	"w,set,05.03.2025,81.6",
+	"w,set,10.03.2025,81.2",
+	"w,set,15.03.2025,80.9",
+	// Journal
+	"j,set,08.03.2025,обед,oat,450",
+	"j,set,09.03.2025,обед,oat,500",
+	"j,set,10.03.2025,обед,oat,480",
+	"j,set,11.03.2025,обед,oat,520",
+	"j,set,12.03.2025,обед,oat,470",
+	"j,set,13.03.2025 08:00,завтрак,oat,60",
+	"j,set,13.03.2025 13:00,обед,apple,150",
+	"j,set,14.03.2025 08:00,завтрак,oat,50",
+	"j,set,14.03.2025 08:00,завтрак,milk,200",
+	"j,set,15.03.2025 08:00,завтрак,oat,60",
+	"j,set,15.03.2025 08:00,завтрак,egg,2egg",
+	"j,set,15.03.2025 11:00,до обеда,apple,180",
+	"j,set,15.03.2025,обед,milk,250ml",
+	// Sport
+	"s,set,run,Бег,км,Улица",
+	"s,kind,run,distance",
+	"s,cal,run,0,60",
+	"s,set,bench,Жим лежа,кг,",
+	"s,kind,bench,reps_load",
+	"s,cal,bench,5,0",
+	"s,as,14.03.2025,run,5x27:30,Утро",
+	"s,asd,15.03.2025,bench,8x60/8x65,30,",
+	"s,as,15.03.2025,run,3x16:00,",
+	"s,wset,push,Толкай,bench=8x60/8x60,Грудь",
+	"s,pset,6,push",
+	// Medicine
+	"m,set,vitd,Витамин D,нг/мл,Анализ крови",
+	"m,is,01.03.2025,vitd,30",
+	"m,is,10.03.2025,vitd,35",
+	// Water
+	"wa,add,15.03.2025 09:00,250",
+	"wa,add,15.03.2025 11:00,500",
+	// Sleep
+	"sl,set,13.03.2025 23:30,14.03.2025 07:00,3,",
+	"sl,set,14.03.2025 23:00,15.03.2025 07:00,4,Хорошо",
+	// Fast
+	"fa,start,14.03.2025 20:00",
+	"fa,stop,15.03.2025 08:00",
+}
+
+func TestCommandsGolden(t *testing.T) {
+	timeNow = func() time.Time { return _goldenNow }
+	t.Cleanup(func() { timeNow = time.Now })
+
+	for _, tt := range []struct {
+		name string
+		cmds []string
+	}{
+		// Help
+		{name: "h", cmds: []string{"h", "w,h"}},
+		{name: "unknown", cmds: []string{"ww,set", "w,sett"}},
+		// Weight
+		{name: "w_set", cmds: []string{"w,set,16.03.2025,80.5", "w,set,bad,80", "w,set,16.03.2025,0"}},
+		{name: "w_del", cmds: []string{"w,del,15.03.2025", "w,list,01.03.2025,31.03.2025"}},
+		{name: "w_list", cmds: []string{"w,list,01.03.2025,31.03.2025", "w,list,01.01.2025,31.01.2025"}},
+		{name: "w_g", cmds: []string{"w,g", "w,g,30,svg"}},
+		// User settings
+		{name: "u_set", cmds: []string{"u,set,1800", "u,get"}},
+		{name: "u_st", cmds: []string{"u,st"}},
+		{name: "u_get", cmds: []string{"u,get"}},
+		{name: "u_prof", cmds: []string{"u,prof,f,15.06.1995,165,2,0,keep,0", "u,get"}},
+		{name: "u_water", cmds: []string{"u,water,0,0", "u,get"}},
+		{name: "u_nut", cmds: []string{"u,nut,fiber=0;salt=5", "u,get"}},
+		{name: "u_rep", cmds: []string{"u,rep,pdf", "u,get", "w,list,01.03.2025,31.03.2025"}},
+		// Food
+		{name: "f_set", cmds: []string{"f,set,rice,Рис,Мистраль,330,7,1,74,Бурый", "f,st,rice"}},
+		{name: "f_setw", cmds: []string{"f,setw,bar,Батончик,Корнишон,40,160,4,6,22,,fiber=2", "f,st,bar"}},
+		{name: "f_bev", cmds: []string{"f,bev,milk,0", "f,st,milk"}},
+		{name: "f_por", cmds: []string{"f,por,milk,cup=240ml,1.03", "f,st,milk"}},
+		{name: "f_st", cmds: []string{"f,st,egg", "f,st,unknown"}},
+		{name: "f_find", cmds: []string{"f,find,мол", "f,find,unknown"}},
+		{name: "f_calc", cmds: []string{"f,calc,egg,2egg", "f,calc,oat,45"}},
+		{name: "f_list", cmds: []string{"f,list"}},
+		{name: "f_del", cmds: []string{"f,del,egg", "f,set,rice,Рис,,330,7,1,74", "f,del,rice", "f,st,rice"}},
+		// Maintenance
+		{name: "x_backup", cmds: []string{"x,backup"}},
+		// Calculation
+		{name: "c_c", cmds: []string{"c,c", "c,c,f,60,165,30"}},
+		{name: "c_tdee", cmds: []string{"c,tdee,15.03.2025,2"}},
+		{name: "c_tdeea", cmds: []string{"c,tdeea,15.03.2025,2", "u,get"}},
+		{name: "c_tdeeh", cmds: []string{"c,tdeea,15.03.2025,2", "c,tdeeh,01.03.2025,31.03.2025"}},
+		{name: "c_tdeeauto", cmds: []string{"c,tdeeauto,1", "u,get"}},
+		// Bundle
+		{name: "b_set", cmds: []string{"b,set,snack,apple:100/milk:200", "b,st,snack", "b,set,bad,unknown:100"}},
+		{name: "b_st", cmds: []string{"b,st,breakfast"}},
+		{name: "b_list", cmds: []string{"b,list"}},
+		{name: "b_del", cmds: []string{"b,del,porridge", "b,del,breakfast", "b,list"}},
+		// Journal
+		{name: "j_set", cmds: []string{"j,set,16.03.2025,ужин,egg,110", "j,rd,16.03.2025"}},
+		{name: "j_sb", cmds: []string{"j,sb,16.03.2025,завтрак,breakfast", "j,rd,16.03.2025"}},
+		{name: "j_del", cmds: []string{"j,del,15.03.2025,обед,milk", "j,rd,15.03.2025"}},
+		{name: "j_dm", cmds: []string{"j,dm,15.03.2025,завтрак", "j,rd,15.03.2025"}},
+		{name: "j_db", cmds: []string{"j,sb,16.03.2025,завтрак,porridge", "j,db,16.03.2025,завтрак,porridge", "j,rd,16.03.2025"}},
+		{name: "j_cp", cmds: []string{"j,cp,15.03.2025,завтрак,16.03.2025,ужин", "j,rd,16.03.2025"}},
+		{name: "j_rd", cmds: []string{"j,rd,15.03.2025", "j,rd,01.01.2025"}},
+		{name: "j_rdc", cmds: []string{"j,rdc,15.03.2025"}},
+		{name: "j_tr", cmds: []string{"j,tr,13.03.2025,15.03.2025"}},
+		{name: "j_tm", cmds: []string{"j,tm,15.03.2025,завтрак"}},
+		{name: "j_sug", cmds: []string{"j,sug,16.03.2025,завтрак,7"}},
+		{name: "j_fs", cmds: []string{"j,fs,oat", "j,fs,egg"}},
+		{name: "j_sc", cmds: []string{"j,sc,15.03.2025,2500", "j,rd,15.03.2025"}},
+		{name: "j_dc", cmds: []string{"j,sc,15.03.2025,2500", "j,dc,15.03.2025", "j,rd,15.03.2025"}},
+		// Sport
+		{name: "s_set", cmds: []string{"s,set,swim,Плавание,м,Бассейн", "s,st,swim"}},
+		{name: "s_st", cmds: []string{"s,st,bench", "s,st,unknown"}},
+		{name: "s_del", cmds: []string{"s,del,run", "s,set,swim,Плавание,м,", "s,del,swim", "s,st,swim"}},
+		{name: "s_kind", cmds: []string{"s,kind,run,duration", "s,st,run", "s,kind,run,bad"}},
+		{name: "s_cal", cmds: []string{"s,cal,run,9.8,0", "s,st,run"}},
+		{name: "s_list", cmds: []string{"s,list"}},
+		{name: "s_as", cmds: []string{"s,as,16.03.2025,bench,10x50/10x55@8,Легко", "s,al,16.03.2025"}},
+		{name: "s_asd", cmds: []string{"s,asd,16.03.2025,run,2x12:00,15,", "s,al,16.03.2025"}},
+		{name: "s_al", cmds: []string{"s,al,15.03.2025", "s,al,01.01.2025"}},
+		{name: "s_ad", cmds: []string{"s,ad,15.03.2025,run", "s,al,15.03.2025"}},
+		{name: "s_adi", cmds: []string{"s,adi,1", "s,al,14.03.2025", "s,adi,1", "s,adi,100"}},
+		{name: "s_pr", cmds: []string{"s,pr,bench"}},
+		{name: "s_ar", cmds: []string{"s,ar,01.03.2025,31.03.2025"}},
+		{name: "s_wset", cmds: []string{"s,wset,legs,Ноги,run=5x27:30;bench=5x40,", "s,wst,legs"}},
+		{name: "s_wst", cmds: []string{"s,wst,push"}},
+		{name: "s_wdel", cmds: []string{"s,wdel,push", "s,pdel,6", "s,wdel,push", "s,wlist"}},
+		{name: "s_wlist", cmds: []string{"s,wlist"}},
+		{name: "s_pset", cmds: []string{"s,pset,1,push", "s,plist"}},
+		{name: "s_pdel", cmds: []string{"s,pdel,6", "s,plist"}},
+		{name: "s_plist", cmds: []string{"s,plist"}},
+		{name: "s_today", cmds: []string{"s,today"}},
+		{name: "s_pc", cmds: []string{"s,pc,09.03.2025,15.03.2025"}},
+		// Medicine
+		{name: "m_set", cmds: []string{"m,set,chol,Холестерин,ммоль/л,", "m,st,chol"}},
+		{name: "m_st", cmds: []string{"m,st,vitd"}},
+		{name: "m_del", cmds: []string{"m,del,vitd", "m,set,chol,Холестерин,ммоль/л,", "m,del,chol", "m,list"}},
+		{name: "m_list", cmds: []string{"m,list"}},
+		{name: "m_is", cmds: []string{"m,is,15.03.2025,vitd,40", "m,ir,01.03.2025,31.03.2025"}},
+		{name: "m_id", cmds: []string{"m,id,01.03.2025,vitd", "m,ir,01.03.2025,31.03.2025"}},
+		{name: "m_ir", cmds: []string{"m,ir,01.03.2025,31.03.2025"}},
+		// Water
+		{name: "wa_add", cmds: []string{"wa,add,15.03.2025 11:00,100", "wa,list,15.03.2025"}},
+		{name: "wa_del", cmds: []string{"wa,del,15.03.2025", "wa,list,15.03.2025"}},
+		{name: "wa_list", cmds: []string{"wa,list,15.03.2025", "wa,list,01.01.2025"}},
+		// Sleep
+		{name: "sl_set", cmds: []string{"sl,set,15.03.2025 23:00,16.03.2025 06:30,5,", "sl,st,16.03.2025"}},
+		{name: "sl_st", cmds: []string{"sl,st,15.03.2025", "sl,st,01.01.2025"}},
+		{name: "sl_del", cmds: []string{"sl,del,15.03.2025", "sl,st,15.03.2025"}},
+		{name: "sl_r", cmds: []string{"sl,r,01.03.2025,31.03.2025"}},
+		// Fast
+		{name: "fa_start", cmds: []string{"fa,start", "fa,cur", "fa,start"}},
+		{name: "fa_start_active", cmds: []string{"fa,start", "fa,start,15.03.2025 10:00", "fa,cur"}},
+		{name: "fa_start_conflict", cmds: []string{"fa,start,14.03.2025 20:00", "fa,start,15.03.2025 06:00", "fa,r,14.03.2025,15.03.2025"}},
+		{name: "fa_stop", cmds: []string{"fa,start,15.03.2025 08:30", "fa,stop", "fa,cur"}},
+		{name: "fa_cur", cmds: []string{"fa,cur", "fa,start,15.03.2025 09:00", "fa,cur"}},
+		{name: "fa_del", cmds: []string{"fa,del,14.03.2025", "fa,r,01.03.2025,31.03.2025"}},
+		{name: "fa_r", cmds: []string{"fa,r,01.03.2025,31.03.2025", "fa,r,01.03.2025,31.03.2025,10"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestCmdProcessor(t, _goldenFixture)
+
+			c := &goldenCmdProcess{}
+			for _, cmd := range tt.cmds {
+				fmt.Fprintf(&c.sb, "> %s\n", cmd)
+				require.NoError(t, r.Process(c, cmd, 1))
+			}
+
+			checkGolden(t, tt.name, c.sb.String())
+		})
+	}
+}
+
+// newTestCmdProcessor returns processor on memory storage with fixture
+// commands applied for user 1.
+func newTestCmdProcessor(t *testing.T, fixture []string) *CmdProcessor {
+	t.Helper()
+
+	r := NewCmdProcessor(memory.NewStorageMemory(), goldenTypeAdapter{}, time.UTC, html.Assets{}, false, zap.NewNop())
+	for _, cmd := range fixture {
+		require.False(t, isErrResponse(r.processCmd(cmd, 1)), cmd)
+	}
+	return r
+}
+
+// checkGolden compares output with golden file, it is written instead
+// with -update flag.
+func checkGolden(t *testing.T, name, output string) {
+	t.Helper()
+
+	fileName := filepath.Join("testdata", "golden", name+".golden")
+	if *updateGolden {
+		require.NoError(t, os.MkdirAll(filepath.Dir(fileName), 0o755))
+		require.NoError(t, os.WriteFile(fileName, []byte(output), 0o644))
+		return
+	}
+
+	expected, err := os.ReadFile(fileName)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), output)
+}
@@ -0,0 +1,158 @@
+package cmdproc
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/devldavydov/myhealth/internal/storage"
+	"go.uber.org/zap"
+)
+
+// Max count of returned completions.
+const _completeLimit = 20
+
+// Complete returns completions of command input by prefix of its last
+// part: command, subcommand, value of enum arg or key of food, bundle or
+// sport for args with completion. Completions are full command inputs.
+func (r *CmdProcessor) Complete(userID int64, input string) []string {
+	parts, err := splitArgs(input, ',')
+	if err != nil {
+		// Unterminated quote or escape
+		return nil
+	}
+
+	last := parts[len(parts)-1]
+	head := strings.Join(parts[:len(parts)-1], ",")
+	if head != "" {
+		head += ","
+	}
+
+	var candidates []string
+	quote := false
+	switch len(parts) {
+	case 1:
+		candidates = append(candidates, "h")
+		for _, cmd := range _commandRegistry {
+			candidates = append(candidates, cmd.Name)
+		}
+	case 2:
+		cmd, ok := findCommandInfo(parts[0])
+		if !ok {
+			return nil
+		}
+
+		candidates = append(candidates, "h")
+		for _, sub := range cmd.SubCommands {
+			candidates = append(candidates, sub.Name)
+		}
+	default:
+		cmd, ok := findCommandInfo(parts[0])
+		if !ok {
+			return nil
+		}
+
+		sub, ok := findSubCommandInfo(cmd, parts[1])
+		if !ok {
+			return nil
+		}
+
+		argIdx := len(parts) - 3
+		if argIdx >= len(sub.Args) {
+			return nil
+		}
+
+		arg := sub.Args[argIdx]
+		switch {
+		case len(arg.Values) > 0:
+			candidates = arg.Values
+		case arg.Complete != "":
+			candidates = r.completeKeys(userID, arg.Complete)
+		default:
+			return nil
+		}
+		quote = true
+	}
+
+	prefix := strings.ToLower(unquoteArg(last))
+	res := []string{}
+	for _, c := range candidates {
+		if !strings.HasPrefix(strings.ToLower(c), prefix) {
+			continue
+		}
+
+		if quote {
+			c = quoteArg(c)
+		}
+		res = append(res, head+c)
+
+		if len(res) == _completeLimit {
+			break
+		}
+	}
+
+	return res
+}
+
+func (r *CmdProcessor) completeKeys(userID int64, source string) []string {
+	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
+	defer cancel()
+
+	var keys []string
+	var err error
+	switch source {
+	case "food":
+		var lst []storage.Food
+		lst, err = r.stg.GetFoodList(ctx, userID)
+		for _, f := range lst {
+			keys = append(keys, f.Key)
+		}
+	case "bundle":
+		var lst []storage.Bundle
+		lst, err = r.stg.GetBundleList(ctx, userID)
+		for _, b := range lst {
+			keys = append(keys, b.Key)
+		}
+	case "sport":
+		var lst []storage.Sport
+		lst, err = r.stg.GetSportList(ctx, userID)
+		for _, s := range lst {
+			keys = append(keys, s.Key)
+		}
+	}
+
+	if err != nil && !errors.Is(err, storage.ErrEmptyResult) {
+		r.logger.Error(
+			"complete DB error",
+			zap.Int64("userID", userID),
+			zap.String("source", source),
+			zap.Error(err),
+		)
+
+		return nil
+	}
+
+	slices.Sort(keys)
+	return keys
+}
+
+func findCommandInfo(name string) (CommandInfo, bool) {
+	name = strings.TrimSpace(name)
+	for _, cmd := range _commandRegistry {
+		if cmd.Name == name || slices.Contains(cmd.Aliases, name) {
+			return cmd, true
+		}
+	}
+	return CommandInfo{}, false
+}
+
+func findSubCommandInfo(cmd CommandInfo, name string) (SubCommandInfo, bool) {
+	name = strings.TrimSpace(name)
+	for _, sub := range cmd.SubCommands {
+		if sub.Name == name || slices.Contains(sub.Aliases, name) {
+			return sub, true
+		}
+	}
+	return SubCommandInfo{}, false
+}
@@ -0,0 +1,55 @@
+package cmdproc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComplete(t *testing.T) {
+	r := newTestCmdProcessor(t, []string{
+		"f,set,egg,Яйцо,,157,12.7,11.5,0.7",
+		"f,set,eel,Угорь,,184,18.4,11.7,0",
+		`f,set,"milk,2",Молоко,,50,3,2,4.7`,
+		"b,set,breakfast,egg:110",
+	})
+
+	for _, tt := range []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{name: "commands", input: "s", want: []string{"s", "sl"}},
+		{name: "help", input: "h", want: []string{"h"}},
+		{name: "unknown command", input: "z", want: []string{}},
+		{name: "subcommands", input: "fa,s", want: []string{"fa,start", "fa,stop"}},
+		{name: "subcommands of alias", input: "fast,st", want: []string{"fast,start", "fast,stop"}},
+		{name: "subcommands of unknown command", input: "zz,s"},
+		{name: "food keys", input: "j,set,15.03.2025,обед,e", want: []string{
+			"j,set,15.03.2025,обед,eel",
+			"j,set,15.03.2025,обед,egg",
+		}},
+		{name: "quoted food key", input: "j,set,15.03.2025,обед,m", want: []string{
+			`j,set,15.03.2025,обед,"milk,2"`,
+		}},
+		{name: "unterminated quote", input: `j,set,15.03.2025,обед,"m`},
+		{name: "bundle keys", input: "j,sb,15.03.2025,обед,", want: []string{
+			"j,sb,15.03.2025,обед,breakfast",
+		}},
+		{name: "enum", input: "j,set,15.03.2025,до", want: []string{
+			"j,set,15.03.2025,до обеда",
+			"j,set,15.03.2025,до ужина",
+		}},
+		{name: "enum case insensitive", input: "j,set,15.03.2025,ОБ", want: []string{
+			"j,set,15.03.2025,обед",
+		}},
+		{name: "enum of values", input: "u,prof,", want: []string{"u,prof,m", "u,prof,f"}},
+		{name: "enum no match", input: "j,set,15.03.2025,x", want: []string{}},
+		{name: "arg without completion", input: "w,set,15.03.2025,8"},
+		{name: "extra arg", input: "fa,start,15.03.2025,x"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, r.Complete(1, tt.input))
+		})
+	}
+}
@@ -3,14 +3,20 @@ package main
 import (
 	"bytes"
 	"flag"
+	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"strings"
 	"text/template"
+	"unicode"
 
 	"github.com/stretchr/testify/assert/yaml"
 )
 
-var tmpl = template.Must(template.New("").Parse(`package cmdproc
+var tmpl = template.Must(template.New("").Funcs(template.FuncMap{
+	"goStrings": goStrings,
+}).Parse(`package cmdproc
 
 // Code generated by "go generate". DO NOT EDIT!
 {{ $cfg := . }}
@@ -26,26 +32,22 @@ import (
 	"go.uber.org/zap"
 )	
 
-func (r *CmdProcessor) process(c ICmdProcess, cmd string, userID int64) error {
-	cmdParts := []string{}
-	for _, part := range strings.Split(cmd, ",") {
-		cmdParts = append(cmdParts, strings.Trim(part, " "))
-	}
-
-	if len(cmdParts) == 0 {
+func (r *CmdProcessor) processCmd(cmd string, userID int64) []CmdResponse {
+	cmdParts, err := splitArgs(cmd, ',')
+	if err != nil || len(cmdParts) == 0 {
 		r.logger.Error(
 			"invalid command",
 			zap.String("command", cmd),
 			zap.Int64("userID", userID),
 		)
-		return c.Send(m.MsgErrInvalidCommand)
+		return NewErrCmdResponse(m.MsgErrInvalidCommand)
 	}
 
 	var resp []CmdResponse
 
-	switch cmdParts[0] {
+	switch strings.TrimSpace(cmdParts[0]) {
 	{{ range .Config.Commands -}}
-	case "{{ .Name }}":
+	case "{{ .Name }}"{{ range .Aliases }}, "{{ . }}"{{ end }}:
 		resp = r.process_{{ .Name }}("{{ .Name }}", cmdParts[1:], userID)
 	{{ end -}}
 	case "h":
@@ -56,22 +58,10 @@ func (r *CmdProcessor) process(c ICmdProcess, cmd string, userID int64) error {
 			zap.String("command", cmd),
 			zap.Int64("userID", userID),
 		)
-		resp = NewSingleCmdResponse(m.MsgErrInvalidCommand)
+		resp = suggestCommand(strings.TrimSpace(cmdParts[0]))
 	}	
 
-	if r.debugMode {
-		if err := c.Send("!!! ОТЛАДОЧНЫЙ РЕЖИМ !!!"); err != nil {
-			return err
-		}
-	}
-
-	for _, rItem := range resp {
-		if err := c.Send(rItem.what, rItem.opts...); err != nil {
-			return err
-		}
-	}
-
-	return nil	
+	return resp
 }
 {{ range $cfg.Config.Commands }}
 {{ with $cmd := . -}}
@@ -82,56 +72,30 @@ func (r *CmdProcessor) process_{{ $cmd.Name }}(baseCmd string, cmdParts []string
 			zap.Strings("cmdParts", cmdParts),
 			zap.Int64("userID", userID),
 		)
-		return NewSingleCmdResponse(m.MsgErrInvalidCommand)
+		return NewErrCmdResponse(m.MsgErrInvalidCommand)
 	}
 
 	var resp []CmdResponse
 
-	switch cmdParts[0] {
+	switch strings.TrimSpace(cmdParts[0]) {
 	{{ range $cmd.SubCommands -}}
-	case "{{ .Name }}":
-		{{- if (ne (len .Args) 0) }}
-		if len(cmdParts[1:]) != {{ len .Args }} {
-			return NewSingleCmdResponse(m.MsgErrInvalidArgsCount)
-		}
-		
-		cmdParts = cmdParts[1:]
-		{{ range $index, $arg := .Args }}
-		{{- if (eq $arg.Type "timestamp") }}
-		val{{ $index }}, err := parseTimestamp(r.tz, cmdParts[{{ $index }}])
-		{{ end -}}
-		{{- if (eq $arg.Type "floatG0") }}
-		val{{ $index }}, err := parseFloatG0(cmdParts[{{ $index }}])
-		{{ end -}}
-		{{- if (eq $arg.Type "floatGE0") }}
-		val{{ $index }}, err := parseFloatGE0(cmdParts[{{ $index }}])
-		{{ end -}}
-		{{- if (eq $arg.Type "stringG0") }}
-		val{{ $index }}, err := parseStringG0(cmdParts[{{ $index }}])
-		{{ end -}}
-		{{- if (eq $arg.Type "stringGE0") }}
-		val{{ $index }}, err := parseStringGE0(cmdParts[{{ $index }}])
-		{{ end -}}
-		{{- if (eq $arg.Type "gender") }}
-		val{{ $index }}, err := parseGender(cmdParts[{{ $index }}])
-		{{ end -}}
-		{{- if (eq $arg.Type "meal") }}
-		val{{ $index }}, err := parseMeal(cmdParts[{{ $index }}])
-		{{ end -}}
-		{{- if (eq $arg.Type "stringArr") }}
-		val{{ $index }}, err := parseStringArr(cmdParts[{{ $index }}])
+	case "{{ .Name }}"{{ range .Aliases }}, "{{ . }}"{{ end }}:
+		{{- if (ne .FuncNoArgs "") }}
+		if len(cmdParts[1:]) == 0 {
+			resp = r.{{ .FuncNoArgs }}(userID)
+			break
+		}
 		{{ end -}}
-		{{- if (eq $arg.Type "floatArr") }}
-		val{{ $index }}, err := parseFloatArr(cmdParts[{{ $index }}])
-		{{ end -}}			 
-		if err != nil {
-			return argError("{{ $arg.Name }}")
+		{{- if (ne (len .Args) 0) }}
+		args, errResp := r.parseArgs_{{ $cmd.Name }}_{{ .Name }}(cmdParts[1:])
+		if errResp != nil {
+			return errResp
 		}
-		{{ end }}
+
 		resp = r.{{ .Func }}(
 			userID,
 			{{ range $index, $arg := .Args -}}
-			val{{ $index }},
+			args.val{{ $index }},
 			{{ end -}}
 		)
 		{{ else }}
@@ -147,7 +111,7 @@ func (r *CmdProcessor) process_{{ $cmd.Name }}(baseCmd string, cmdParts []string
 				"{{ .Description }}",
 				"{{ .Name }}",
 				{{ range .Args -}}
-				"{{ .Name }} [{{ (index $cfg.TypesMap .Type).DescriptionShort }}]",
+				"{{ .Help (index $cfg.TypesMap .Type) }}",
 				{{ end -}}
 			).
 			{{ else -}}
@@ -156,7 +120,7 @@ func (r *CmdProcessor) process_{{ $cmd.Name }}(baseCmd string, cmdParts []string
 				"{{ .Name }}",
 				"{{ .Comment }}",
 				{{ range .Args -}}
-				"{{ .Name }} [{{ (index $cfg.TypesMap .Type).DescriptionShort }}]",
+				"{{ .Help (index $cfg.TypesMap .Type) }}",
 				{{ end -}}
 			).	
 			{{ end -}}		 
@@ -170,19 +134,76 @@ func (r *CmdProcessor) process_{{ $cmd.Name }}(baseCmd string, cmdParts []string
 			zap.Strings("cmdParts", cmdParts),
 			zap.Int64("userID", userID),
 		)
-		resp = NewSingleCmdResponse(m.MsgErrInvalidCommand)
+		resp = suggestSubCommand(baseCmd, strings.TrimSpace(cmdParts[0]))
 	}
 
 	return resp
 }
+{{ range $cmd.SubCommands -}}
+{{ if (ne (len .Args) 0) }}
+type args_{{ $cmd.Name }}_{{ .Name }} struct {
+	{{ range $index, $arg := .Args -}}
+	val{{ $index }} {{ if .Variadic }}[]{{ end }}{{ (index $cfg.TypesMap .Type).GoTypeName }}
+	{{ end -}}
+}
+
+func (r *CmdProcessor) parseArgs_{{ $cmd.Name }}_{{ .Name }}(parts []string) (*args_{{ $cmd.Name }}_{{ .Name }}, []CmdResponse) {
+	args, err := bindArgs([]argSpec{
+		{{ range .Args -}}
+		{{ .Spec }},
+		{{ end -}}
+	}, parts)
+	if err != nil {
+		return nil, NewErrCmdResponse(m.MsgErrInvalidArgsCount)
+	}
+
+	var res args_{{ $cmd.Name }}_{{ .Name }}
+	{{- range $index, $arg := .Args }}
+	{{- $type := index $cfg.TypesMap .Type }}
+	{{ if .Variadic }}
+	for _, arg := range args[{{ $index }}:] {
+		val, err := {{ $type.ParseExpr "arg" }}
+		{{- range .Checks "val" }}
+		if err == nil {
+			err = {{ . }}
+		}
+		{{- end }}
+		if err != nil {
+			return nil, argError("{{ .Name }}")
+		}
+		res.val{{ $index }} = append(res.val{{ $index }}, val)
+	}
+	{{- else }}
+	res.val{{ $index }}, err = {{ $type.ParseExpr (printf "args[%d]" $index) }}
+	{{- range .Checks (printf "res.val%d" $index) }}
+	if err == nil {
+		err = {{ . }}
+	}
+	{{- end }}
+	if err != nil {
+		return nil, argError("{{ .Name }}")
+	}
+	{{- end }}
+	{{- end }}
+
+	return &res, nil
+}
+{{ end -}}
+{{ end -}}
 {{ end -}}
 {{ end }}
 func (r *CmdProcessor) processHelp() []CmdResponse {
 	var sb strings.Builder
 	sb.WriteString("<b>Команды помощи по разделам:</b>\n")
 	{{- range $cfg.Config.Commands }}
-	sb.WriteString("<b>\u2022 {{ .Name }},h</b> - {{ .DescriptionShort }}\n")
+	sb.WriteString("<b>\u2022 {{ .Name }},h</b> - {{ .DescriptionShort }}{{ if .Aliases }} (синонимы: {{ range $i, $a := .Aliases }}{{ if $i }}, {{ end }}{{ $a }}{{ end }}){{ end }}\n")
 	{{- end }}
+	sb.WriteString("\n<b>Синтаксис:</b>\n")
+	sb.WriteString("<b>\u2022 Кавычки</b> - аргумент в двойных кавычках может содержать , и /, например \"Творог 5%, пачка\"\n")
+	sb.WriteString("<b>\u2022 Экранирование</b> - символ после \\ используется как есть, например \\, или \\\"\n")
+	sb.WriteString("<b>\u2022 Именованные аргументы</b> - после позиционных в виде имя=значение, имя - название аргумента в нижнем регистре с _ вместо пробелов и знаков препинания, например комментарий=текст или вес_г=100\n")
+	sb.WriteString("<b>\u2022 Необязательные аргументы</b> - можно не указывать, используется значение по умолчанию\n")
+	sb.WriteString("<b>\u2022 Пакет команд</b> - несколько команд по одной на строке или текстовый файл .txt, строки с # пропускаются. Первая строка !tx - выполнить все или ничего (по умолчанию), !cont - продолжать при ошибках\n")
 	sb.WriteString("\n<b>Типы данных:</b>\n")
 	{{- range $cfg.Config.Types }}
 	sb.WriteString("<b>\u2022 {{ .DescriptionShort }}</b> - {{ .Description }}\n")
@@ -190,20 +211,95 @@ func (r *CmdProcessor) processHelp() []CmdResponse {
 	return NewSingleCmdResponse(sb.String(), r.typeAdapter.OptsHTML())
 }
 
+// CommandInfo describes command in registry of commands.
+type CommandInfo struct {
+	Name             string
+	Aliases          []string
+	DescriptionShort string
+	SubCommands      []SubCommandInfo
+}
+
+// SubCommandInfo describes subcommand in registry of commands.
+type SubCommandInfo struct {
+	Name        string
+	Aliases     []string
+	Description string
+	Args        []ArgInfo
+}
+
+// ArgInfo describes subcommand arg in registry of commands, Complete is
+// source of arg values completion, Values are completions of enum arg.
+type ArgInfo struct {
+	Name     string
+	Key      string
+	Complete string
+	Values   []string
+}
+
+var _commandRegistry = []CommandInfo{
+	{{- range $cfg.Config.Commands }}
+	{
+		Name:             "{{ .Name }}",
+		{{- if .Aliases }}
+		Aliases:          {{ goStrings .Aliases }},
+		{{- end }}
+		DescriptionShort: "{{ .DescriptionShort }}",
+		SubCommands: []SubCommandInfo{
+			{{- range .SubCommands }}
+			{
+				Name:        "{{ .Name }}",
+				{{- if .Aliases }}
+				Aliases:     {{ goStrings .Aliases }},
+				{{- end }}
+				Description: "{{ .Description }}",
+				{{- if .Args }}
+				Args: []ArgInfo{
+					{{- range .Args }}
+					{Name: "{{ .Name }}", Key: "{{ .Key }}"{{ if .Complete }}, Complete: "{{ .Complete }}"{{ end }}
+						{{- with (index $cfg.TypesMap .Type).CompleteValues }}, Values: {{ goStrings . }}{{ end }}},
+					{{- end }}
+				},
+				{{- end }}
+			},
+			{{- end }}
+		},
+	},
+	{{- end }}
+}
+
+// Commands returns registry of commands.
+func Commands() []CommandInfo {
+	return _commandRegistry
+}
+
 func parseTimestamp(tz *time.Location, arg string) (time.Time, error) {
 	var t time.Time
 
+	// Arg may end with optional time of day "HH:MM" separated by space,
+	// or be the time only for current date
+	var hour, minute int
+	if i := strings.LastIndex(arg, " "); i != -1 || strings.Contains(arg, ":") {
+		timePart := arg[i+1:]
+		arg = strings.TrimSpace(arg[:max(i, 0)])
+
+		tm, err := time.Parse("15:04", timePart)
+		if err != nil {
+			return time.Time{}, err
+		}
+		hour, minute = tm.Hour(), tm.Minute()
+	}
+
 	// Arg empty string - now
 	// Arg integer - add delta to now
 	// Arg in date format
 
 	if arg == "" {
-		t = time.Now().In(tz)
+		t = timeNow().In(tz)
 	} else {
         delta, err := strconv.Atoi(arg)
 
 		if err == nil {
-			t = time.Now().In(tz)
+			t = timeNow().In(tz)
 			t = t.Add(time.Duration(delta) * 24 * time.Hour)
 		} else {
 			t, err = time.Parse("02.01.2006", arg)
@@ -213,7 +309,7 @@ func parseTimestamp(tz *time.Location, arg string) (time.Time, error) {
 		}
 	}
 
-	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, tz), nil
+	return time.Date(t.Year(), t.Month(), t.Day(), hour, minute, 0, 0, tz), nil
 }
 
 func parseFloatG0(arg string) (float64, error) {
@@ -242,6 +338,19 @@ func parseFloatGE0(arg string) (float64, error) {
 	return val, nil
 }
 
+func parseIntG0(arg string) (int, error) {
+	val, err := strconv.Atoi(arg)
+	if err != nil {
+		return 0, err
+	}
+
+	if val <= 0 {
+		return 0, fmt.Errorf("not above zero")
+	}
+
+	return val, nil
+}
+
 func parseStringG0(arg string) (string, error) {
 	if len(arg) == 0 {
 		return "", fmt.Errorf("empty string")
@@ -254,12 +363,52 @@ func parseStringGE0(arg string) (string, error) {
 	return arg, nil
 }
 
-func parseGender(arg string) (string, error) {
+func parseEnum(arg string, values []string) (string, error) {
+	for _, v := range values {
+		if arg == v {
+			return arg, nil
+		}
+	}
+	return "", fmt.Errorf("wrong enum value")
+}
+
+func checkMin(val, limit float64) error {
+	if val < limit {
+		return fmt.Errorf("less than %g", limit)
+	}
+	return nil
+}
+
+func checkMax(val, limit float64) error {
+	if val > limit {
+		return fmt.Errorf("greater than %g", limit)
+	}
+	return nil
+}
+
+func parseActivityLevel(arg string) (storage.ActivityLevel, error) {
+	return storage.NewActivityLevelFromString(arg)
+}
+
+func parseGoal(arg string) (storage.Goal, error) {
+	return storage.NewGoalFromString(arg)
+}
+
+func parseReportFormat(arg string) (storage.ReportFormat, error) {
+	if f := storage.ReportFormat(strings.ToLower(arg)); f.Validate() {
+		return f, nil
+	}
+	return "", fmt.Errorf("wrong report format")
+}
+
+func parseBool(arg string) (bool, error) {
 	switch arg {
-	case "m", "f":
-		return arg, nil
+	case "1":
+		return true, nil
+	case "0":
+		return false, nil
 	default:
-		return "", fmt.Errorf("wrong gender")
+		return false, fmt.Errorf("wrong bool")
 	}
 }
 
@@ -268,9 +417,14 @@ func parseMeal(arg string) (storage.Meal, error) {
 }
 
 func parseStringArr(arg string) ([]string, error) {
+	items, err := splitArgs(arg, '/')
+	if err != nil {
+		return nil, err
+	}
+
 	parts := []string{}
-	for _, part := range strings.Split(arg, "/") {
-		parts = append(parts, strings.Trim(part, " "))
+	for _, item := range items {
+		parts = append(parts, unquoteArg(item))
 	}
 
 	if len(parts) == 0 {
@@ -281,10 +435,14 @@ func parseStringArr(arg string) ([]string, error) {
 }
 
 func parseFloatArr(arg string) ([]float64, error) {
+	items, err := splitArgs(arg, '/')
+	if err != nil {
+		return nil, err
+	}
+
 	parts := []float64{}
-	for _, part := range strings.Split(arg, "/") {
-		part = strings.Trim(part, " ")
-		val, err := strconv.ParseFloat(part, 64)
+	for _, item := range items {
+		val, err := strconv.ParseFloat(unquoteArg(item), 64)
 		if err != nil {
 			return nil, err
 		}
@@ -299,14 +457,249 @@ func parseFloatArr(arg string) ([]float64, error) {
 	return parts, nil
 }
 
+func parseSportSets(arg string) ([]storage.SportSet, error) {
+	sets := []storage.SportSet{}
+	for _, part := range strings.Split(arg, "/") {
+		set, err := storage.ParseSportSet(part)
+		if err != nil {
+			return nil, err
+		}
+
+		sets = append(sets, set)
+	}
+
+	return sets, nil
+}
+
+func parseSportSetKind(arg string) (storage.SportSetKind, error) {
+	return storage.NewSportSetKindFromString(arg)
+}
+
+func parseWorkoutItems(arg string) ([]storage.WorkoutItem, error) {
+	items := []storage.WorkoutItem{}
+	for _, part := range strings.Split(arg, ";") {
+		key, sets, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("wrong workout item")
+		}
+
+		val, err := parseSportSets(sets)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, storage.WorkoutItem{SportKey: strings.Trim(key, " "), Sets: val})
+	}
+
+	return items, nil
+}
+
+func parseFoodAmount(arg string) (storage.FoodAmount, error) {
+	return storage.ParseFoodAmount(arg)
+}
+
+func parseFoodPortions(arg string) ([]storage.FoodPortion, error) {
+	return storage.ParseFoodPortions(arg)
+}
+
+func parseNutrients(arg string) (storage.Nutrients, error) {
+	return storage.ParseNutrients(arg)
+}
+
+func parseWeekday(arg string) (time.Weekday, error) {
+	val, err := strconv.Atoi(arg)
+	if err != nil {
+		return 0, err
+	}
+
+	if val < 1 || val > 7 {
+		return 0, fmt.Errorf("wrong weekday")
+	}
+
+	// Monday is 1, Sunday is 7
+	return time.Weekday(val % 7), nil
+}
+
+// splitArgs splits command by separator outside of double quotes and
+// escapes. Parts keep quotes and escapes, they are removed by unquoteArg.
+func splitArgs(cmd string, sep rune) ([]string, error) {
+	parts := []string{}
+	var sb strings.Builder
+	inQuotes, escaped := false, false
+	for _, c := range cmd {
+		switch {
+		case escaped:
+			escaped = false
+		case c == '\\':
+			escaped = true
+		case c == '"':
+			inQuotes = !inQuotes
+		case c == sep && !inQuotes:
+			parts = append(parts, sb.String())
+			sb.Reset()
+			continue
+		}
+		sb.WriteRune(c)
+	}
+
+	if inQuotes || escaped {
+		return nil, fmt.Errorf("unterminated quote or escape")
+	}
+
+	return append(parts, sb.String()), nil
+}
+
+// unquoteArg removes quotes and escapes from arg,
+// spaces around arg outside of quotes are trimmed.
+func unquoteArg(arg string) string {
+	var sb strings.Builder
+	inQuotes, escaped := false, false
+	// Length of value without trailing spaces to trim
+	keep := 0
+	for _, c := range arg {
+		switch {
+		case escaped:
+			escaped = false
+		case c == '\\':
+			escaped = true
+			continue
+		case c == '"':
+			inQuotes = !inQuotes
+			keep = sb.Len()
+			continue
+		case !inQuotes && c == ' ':
+			if sb.Len() != 0 {
+				sb.WriteRune(c)
+			}
+			continue
+		}
+		sb.WriteRune(c)
+		keep = sb.Len()
+	}
+
+	return sb.String()[:keep]
+}
+
+// quoteArg returns arg in double quotes, if it can't be parsed back
+// unchanged as command arg.
+func quoteArg(arg string) string {
+	return quoteArgWith(arg, ",=")
+}
+
+// quoteArrItem returns array item in double quotes, if it can't be parsed
+// back unchanged as item of array arg.
+func quoteArrItem(item string) string {
+	return quoteArgWith(item, ",=/")
+}
+
+func quoteArgWith(arg, specials string) string {
+	if !strings.ContainsAny(arg, specials+"\"\\") &&
+		strings.TrimSpace(arg) == arg {
+		return arg
+	}
+
+	r := strings.NewReplacer("\\", "\\\\", "\"", "\\\"")
+	return "\"" + r.Replace(arg) + "\""
+}
+
+// argSpec describes subcommand arg: key for named form key=value
+// and default value of omitted optional arg. Variadic arg is the last
+// one and takes all rest positional args.
+type argSpec struct {
+	key      string
+	optional bool
+	variadic bool
+	def      string
+}
+
+// bindArgs binds positional args, followed by named args key=value,
+// to specs. Omitted optional args get default value, values of variadic
+// arg are at the end of result.
+func bindArgs(specs []argSpec, parts []string) ([]string, error) {
+	args := make([]string, len(specs))
+	set := make([]bool, len(specs))
+	variadic := len(specs) > 0 && specs[len(specs)-1].variadic
+	pos, named := 0, false
+	for _, part := range parts {
+		if i := namedArgIndex(specs, part); i != -1 {
+			if set[i] {
+				return nil, fmt.Errorf("duplicate arg %s", specs[i].key)
+			}
+			_, args[i], _ = strings.Cut(part, "=")
+			set[i], named = true, true
+			continue
+		}
+
+		switch {
+		case named:
+			return nil, fmt.Errorf("positional arg after named")
+		case pos < len(specs):
+			args[pos], set[pos] = part, true
+			pos++
+		case variadic:
+			args = append(args, part)
+		default:
+			return nil, fmt.Errorf("unexpected positional arg")
+		}
+	}
+
+	for i, spec := range specs {
+		if set[i] {
+			continue
+		}
+		if !spec.optional {
+			return nil, fmt.Errorf("missing arg %s", spec.key)
+		}
+		// Omitted variadic arg without default has no values
+		if spec.variadic && spec.def == "" {
+			return args[:i], nil
+		}
+		args[i] = spec.def
+	}
+
+	return args, nil
+}
+
+func namedArgIndex(specs []argSpec, part string) int {
+	key, _, ok := strings.Cut(part, "=")
+	if !ok {
+		return -1
+	}
+
+	key = strings.ToLower(strings.TrimSpace(key))
+	for i, spec := range specs {
+		if spec.key == key && !spec.variadic {
+			return i
+		}
+	}
+
+	return -1
+}
+
 func argError(argName string) []CmdResponse {
-	return NewSingleCmdResponse(fmt.Sprintf("%s: %s", m.MsgErrInvalidArg, argName))
+	return NewErrCmdResponse(fmt.Sprintf("%s: %s", m.MsgErrInvalidArg, argName))
 }
 
 func formatTimestamp(ts time.Time) string {
 	return ts.Format("02.01.2006")
 }
 
+func formatBool(v bool) string {
+	if v {
+		return "1"
+	}
+	return "0"
+}
+
+// formatDateTime formats timestamp with time of day, timestamps without
+// time (midnight) are formatted as date only.
+func formatDateTime(ts time.Time) string {
+	if ts.Hour() == 0 && ts.Minute() == 0 {
+		return formatTimestamp(ts)
+	}
+	return ts.Format("02.01.2006 15:04")
+}
+
 type cmdHelpItem struct {
 	label   string
 	cmd     string
@@ -383,6 +776,145 @@ func (r *cmdHelpBuilder) build() string {
 }
 `))
 
+var testTmpl = template.Must(template.New("").Parse(`package cmdproc
+
+// Code generated by "go generate". DO NOT EDIT!
+
+import (
+	"testing"
+	"time"
+
+	m "github.com/devldavydov/myhealth/internal/common/messages"
+	"github.com/devldavydov/myhealth/internal/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseArgs(t *testing.T) {
+	r := &CmdProcessor{tz: time.UTC}
+
+	parsers := map[string]func([]string) (any, []CmdResponse){
+		{{- range .Parsers }}
+		"{{ .Cmd }},{{ .Sub }}": func(parts []string) (any, []CmdResponse) {
+			args, resp := r.parseArgs_{{ .Cmd }}_{{ .Sub }}(parts)
+			return args, resp
+		},
+		{{- end }}
+	}
+
+	for _, tt := range []struct {
+		name  string
+		cmd   string
+		parts []string
+		want  []CmdResponse
+		args  any
+	}{
+		{{- range .Cases }}
+		{
+			name:  {{ printf "%q" .Name }},
+			cmd:   "{{ .Cmd }}",
+			parts: {{ .PartsExpr }},
+			{{- if (ne .Want "") }}
+			want:  {{ .Want }},
+			{{- end }}
+			{{- if (ne .Args "") }}
+			args:  {{ .Args }},
+			{{- end }}
+		},
+		{{- end }}
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			args, resp := parsers[tt.cmd](tt.parts)
+			assert.Equal(t, tt.want, resp)
+			if tt.args != nil {
+				assert.Equal(t, tt.args, args)
+			}
+		})
+	}
+}
+
+func TestSplitArgs(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		cmd     string
+		want    []string
+		wantErr bool
+	}{
+		{name: "simple", cmd: "a,b,c", want: []string{"a", "b", "c"}},
+		{name: "empty cmd", cmd: "", want: []string{""}},
+		{name: "empty args", cmd: "a,,", want: []string{"a", "", ""}},
+		{name: "comma inside quotes", cmd: "a,\"b,c\",d", want: []string{"a", "\"b,c\"", "d"}},
+		{name: "escaped quote", cmd: "a,b\\\"c", want: []string{"a", "b\\\"c"}},
+		{name: "escaped quote inside quotes", cmd: "\"a\\\",b\"", want: []string{"\"a\\\",b\""}},
+		{name: "escaped comma", cmd: "a\\,b,c", want: []string{"a\\,b", "c"}},
+		{name: "unterminated quote", cmd: "a,\"b,c", wantErr: true},
+		{name: "unterminated escape", cmd: "a,b\\", wantErr: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			parts, err := splitArgs(tt.cmd, ',')
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, parts)
+		})
+	}
+}
+
+func TestUnquoteArg(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		arg  string
+		want string
+	}{
+		{name: "plain", arg: "abc", want: "abc"},
+		{name: "empty", arg: "", want: ""},
+		{name: "empty quotes", arg: "\"\"", want: ""},
+		{name: "spaces trimmed", arg: "  a b  ", want: "a b"},
+		{name: "spaces in quotes kept", arg: " \" a b \" ", want: " a b "},
+		{name: "comma in quotes", arg: "\"b,c\"", want: "b,c"},
+		{name: "escaped quote", arg: "b\\\"c", want: "b\"c"},
+		{name: "escaped backslash", arg: "b\\\\c", want: "b\\c"},
+		{name: "escaped comma", arg: "a\\,b", want: "a,b"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, unquoteArg(tt.arg))
+		})
+	}
+}
+
+func TestQuoteArg(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		arg  string
+		want string
+	}{
+		{name: "plain", arg: "abc", want: "abc"},
+		{name: "empty", arg: "", want: ""},
+		{name: "comma", arg: "a,b", want: "\"a,b\""},
+		{name: "named arg", arg: "k=v", want: "\"k=v\""},
+		{name: "quote", arg: "say \"hi\"", want: "\"say \\\"hi\\\"\""},
+		{name: "backslash", arg: "a\\b", want: "\"a\\\\b\""},
+		{name: "spaces around", arg: " a ", want: "\" a \""},
+		{name: "unicode", arg: "Яйцо куриное", want: "Яйцо куриное"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			quoted := quoteArg(tt.arg)
+			assert.Equal(t, tt.want, quoted)
+
+			// Round trip
+			parts, err := splitArgs(quoted+","+quoted, ',')
+			assert.NoError(t, err)
+			assert.Equal(t, []string{quoted, quoted}, parts)
+			for _, part := range parts {
+				assert.Equal(t, tt.arg, unquoteArg(part))
+			}
+		})
+	}
+}
+`))
+
 type CommandProcessorConfig struct {
 	Commands []Command  `yaml:"commands"`
 	Types    []DataType `yaml:"types"`
@@ -390,33 +922,351 @@ type CommandProcessorConfig struct {
 
 type Command struct {
 	Name             string       `yaml:"name"`
+	Aliases          []string     `yaml:"aliases"`
 	Description      string       `yaml:"description"`
 	DescriptionShort string       `yaml:"description_short"`
 	SubCommands      []SubCommand `yaml:"subcommands"`
 }
 
 type SubCommand struct {
-	Name        string `yaml:"name"`
-	Func        string `yaml:"func"`
-	Description string `yaml:"description"`
-	Comment     string `yaml:"comment"`
-	Args        []Arg  `yaml:"args"`
+	Name        string   `yaml:"name"`
+	Aliases     []string `yaml:"aliases"`
+	Func        string   `yaml:"func"`
+	FuncNoArgs  string   `yaml:"func_no_args"`
+	Description string   `yaml:"description"`
+	Comment     string   `yaml:"comment"`
+	Args        []Arg    `yaml:"args"`
+}
+
+func (r SubCommand) validate(typesMap map[string]DataType) error {
+	keys := map[string]bool{}
+	for i, arg := range r.Args {
+		t, ok := typesMap[arg.Type]
+		if !ok {
+			return fmt.Errorf("subcommand %s: unknown type %s of arg %s", r.Name, arg.Type, arg.Name)
+		}
+
+		if keys[arg.Key()] {
+			return fmt.Errorf("subcommand %s: duplicate arg key %s", r.Name, arg.Key())
+		}
+		keys[arg.Key()] = true
+
+		if arg.Default != "" && !arg.Optional {
+			return fmt.Errorf("subcommand %s: default of required arg %s", r.Name, arg.Name)
+		}
+
+		if arg.Variadic && i != len(r.Args)-1 {
+			return fmt.Errorf("subcommand %s: variadic arg %s is not the last", r.Name, arg.Name)
+		}
+
+		if (arg.Min != nil || arg.Max != nil) && !t.IsNumeric() {
+			return fmt.Errorf("subcommand %s: min/max of not numeric arg %s", r.Name, arg.Name)
+		}
+
+		switch arg.Complete {
+		case "", "food", "bundle", "sport":
+		default:
+			return fmt.Errorf("subcommand %s: unknown complete %s of arg %s", r.Name, arg.Complete, arg.Name)
+		}
+	}
+	return nil
+}
+
+// validateNames checks, that names and aliases of commands or subcommands
+// are unique, h is reserved for help.
+func validateNames(names map[string][]string) error {
+	seen := map[string]bool{"h": true}
+	for name, aliases := range names {
+		for _, n := range append([]string{name}, aliases...) {
+			if seen[n] {
+				return fmt.Errorf("duplicate command name or alias %s", n)
+			}
+			seen[n] = true
+		}
+	}
+	return nil
+}
+
+func goStrings(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, v := range values {
+		quoted = append(quoted, strconv.Quote(v))
+	}
+	return fmt.Sprintf("[]string{%s}", strings.Join(quoted, ", "))
+}
+
+// testCases returns parser test cases of subcommand: valid args, named
+// args, wrong args count and invalid value of every arg.
+func (r SubCommand) testCases(cmd string, typesMap map[string]DataType) []testCase {
+	valid := make([]string, 0, len(r.Args))
+	required := []string{}
+	named := []string{}
+	// Parsed values of valid args
+	values := make([]string, 0, len(r.Args))
+	hasVariadic := false
+	for i, arg := range r.Args {
+		t := typesMap[arg.Type]
+		example := t.ExampleValue()
+		valid = append(valid, example)
+		if !arg.Optional {
+			required = append(required, example)
+		}
+		if arg.Variadic {
+			hasVariadic = true
+			// Variadic arg takes several values
+			valid = append(valid, example)
+			values = append(values, fmt.Sprintf("val%d: []%s{%s, %s}", i, t.GoTypeName(), t.ExampleParsedValue(), t.ExampleParsedValue()))
+		} else {
+			named = append(named, arg.Key()+"="+example)
+			values = append(values, fmt.Sprintf("val%d: %s", i, t.ExampleParsedValue()))
+		}
+	}
+	args := fmt.Sprintf("&args_%s_%s{%s}", cmd, r.Name, strings.Join(values, ", "))
+
+	errArgsCount := "NewErrCmdResponse(m.MsgErrInvalidArgsCount)"
+	cases := []testCase{
+		{Name: "valid", Parts: valid, Args: args},
+	}
+	if len(required) != len(r.Args) {
+		cases = append(cases, testCase{Name: "required only", Parts: required})
+	}
+	if !hasVariadic {
+		cases = append(cases,
+			testCase{Name: "named", Parts: named, Args: args},
+			testCase{Name: "too many args", Parts: append(append([]string{}, valid...), "extra"), Want: errArgsCount},
+		)
+	}
+	if len(required) > 0 {
+		cases = append(cases, testCase{Name: "too few args", Parts: required[:len(required)-1], Want: errArgsCount})
+	}
+
+	invalidCase := func(i int, name, value string) testCase {
+		parts := append([]string{}, valid...)
+		parts[i] = value
+		return testCase{
+			Name:  name,
+			Parts: parts,
+			Want:  fmt.Sprintf("argError(%q)", r.Args[i].Name),
+		}
+	}
+	for i, arg := range r.Args {
+		if invalid, ok := typesMap[arg.Type].InvalidValue(); ok {
+			cases = append(cases, invalidCase(i, "invalid "+arg.Key(), invalid))
+		}
+		if arg.Min != nil {
+			cases = append(cases, invalidCase(i, "below min "+arg.Key(), strconv.FormatFloat(*arg.Min-1, 'f', -1, 64)))
+		}
+		if arg.Max != nil {
+			cases = append(cases, invalidCase(i, "above max "+arg.Key(), strconv.FormatFloat(*arg.Max+1, 'f', -1, 64)))
+		}
+	}
+
+	for i := range cases {
+		cases[i].Cmd = cmd + "," + r.Name
+		cases[i].Name = fmt.Sprintf("%s,%s %s", cmd, r.Name, cases[i].Name)
+	}
+
+	return cases
 }
 
 type Arg struct {
-	Name string `yaml:"name"`
-	Type string `yaml:"type"`
+	Name     string   `yaml:"name"`
+	Type     string   `yaml:"type"`
+	Optional bool     `yaml:"optional"`
+	Default  string   `yaml:"default"`
+	KeyName  string   `yaml:"key"`
+	Variadic bool     `yaml:"variadic"`
+	Min      *float64 `yaml:"min"`
+	Max      *float64 `yaml:"max"`
+	// Complete is source of values completion: food, bundle or sport keys
+	Complete string `yaml:"complete"`
+}
+
+// Key returns name of arg in named form key=value: set key or arg name
+// in lower case with other than letters and digits replaced by _.
+func (r Arg) Key() string {
+	if r.KeyName != "" {
+		return r.KeyName
+	}
+
+	words := strings.FieldsFunc(strings.ToLower(r.Name), func(c rune) bool {
+		return !unicode.IsLetter(c) && !unicode.IsDigit(c)
+	})
+	return strings.Join(words, "_")
 }
 
+// Spec returns argSpec literal of arg for generated code.
+func (r Arg) Spec() string {
+	spec := fmt.Sprintf("{key: %q", r.Key())
+	if r.Optional {
+		spec += fmt.Sprintf(", optional: true, def: %q", r.Default)
+	}
+	if r.Variadic {
+		spec += ", variadic: true"
+	}
+	return spec + "}"
+}
+
+// Checks returns min/max check expressions of parsed value.
+func (r Arg) Checks(val string) []string {
+	var checks []string
+	if r.Min != nil {
+		checks = append(checks, fmt.Sprintf("checkMin(float64(%s), %s)", val, strconv.FormatFloat(*r.Min, 'f', -1, 64)))
+	}
+	if r.Max != nil {
+		checks = append(checks, fmt.Sprintf("checkMax(float64(%s), %s)", val, strconv.FormatFloat(*r.Max, 'f', -1, 64)))
+	}
+	return checks
+}
+
+// Help returns arg label for help.
+func (r Arg) Help(t DataType) string {
+	label := fmt.Sprintf("%s [%s]", r.Name, t.DescriptionShort)
+
+	var notes []string
+	if r.Min != nil {
+		notes = append(notes, "от "+strconv.FormatFloat(*r.Min, 'f', -1, 64))
+	}
+	if r.Max != nil {
+		notes = append(notes, "до "+strconv.FormatFloat(*r.Max, 'f', -1, 64))
+	}
+	if r.Variadic {
+		notes = append(notes, "одно или несколько значений")
+	}
+	if r.Optional {
+		notes = append(notes, "необязательно")
+		if r.Default != "" {
+			notes = append(notes, "по умолчанию "+r.Default)
+		}
+	}
+
+	if len(notes) > 0 {
+		label += " (" + strings.Join(notes, ", ") + ")"
+	}
+	return label
+}
+
+// DataType is arg type, parsed by parser function or, for enum types,
+// by allowed values.
 type DataType struct {
-	Name             string `yaml:"name"`
-	Description      string `yaml:"description"`
-	DescriptionShort string `yaml:"description_short"`
+	Name             string   `yaml:"name"`
+	Description      string   `yaml:"description"`
+	DescriptionShort string   `yaml:"description_short"`
+	Parser           string   `yaml:"parser"`
+	GoType           string   `yaml:"go_type"`
+	Values           []string `yaml:"values"`
+	// Parser requires time zone as first arg
+	TZ bool `yaml:"tz"`
+	// Parser gets arg with quotes and escapes, i.e. to split arrays
+	Raw     bool   `yaml:"raw"`
+	Example string `yaml:"example"`
+	// ExampleParsed is Go expression of parsed example
+	ExampleParsed string  `yaml:"example_parsed"`
+	Invalid       *string `yaml:"invalid"`
+	// Completions are values of enum type, parsed by parser
+	Completions []string `yaml:"completions"`
+}
+
+func (r DataType) validate() error {
+	switch {
+	case r.Parser == "" && len(r.Values) == 0:
+		return fmt.Errorf("type %s: neither parser nor values", r.Name)
+	case r.Parser != "" && len(r.Values) != 0:
+		return fmt.Errorf("type %s: both parser and values", r.Name)
+	case r.Parser != "" && r.GoType == "":
+		return fmt.Errorf("type %s: no go type", r.Name)
+	case r.Parser != "" && r.ExampleParsed == "":
+		return fmt.Errorf("type %s: no parsed example", r.Name)
+	}
+	return nil
+}
+
+func (r DataType) GoTypeName() string {
+	if r.GoType == "" {
+		return "string"
+	}
+	return r.GoType
+}
+
+func (r DataType) IsNumeric() bool {
+	switch r.GoTypeName() {
+	case "int", "float64":
+		return true
+	}
+	return false
+}
+
+// CompleteValues returns values of enum type for completion.
+func (r DataType) CompleteValues() []string {
+	if len(r.Completions) > 0 {
+		return r.Completions
+	}
+	return r.Values
+}
+
+// ParseExpr returns expression of parsing arg for generated code.
+func (r DataType) ParseExpr(arg string) string {
+	if !r.Raw {
+		arg = fmt.Sprintf("unquoteArg(%s)", arg)
+	}
+
+	if len(r.Values) > 0 {
+		return fmt.Sprintf("parseEnum(%s, %s)", arg, goStrings(r.Values))
+	}
+
+	if r.TZ {
+		return fmt.Sprintf("%s(r.tz, %s)", r.Parser, arg)
+	}
+	return fmt.Sprintf("%s(%s)", r.Parser, arg)
+}
+
+func (r DataType) ExampleValue() string {
+	if r.Example == "" && len(r.Values) > 0 {
+		return r.Values[0]
+	}
+	return r.Example
+}
+
+// ExampleParsedValue returns Go expression of parsed example, value
+// of enum type is example itself.
+func (r DataType) ExampleParsedValue() string {
+	if r.ExampleParsed == "" && len(r.Values) > 0 {
+		return strconv.Quote(r.ExampleValue())
+	}
+	return r.ExampleParsed
+}
+
+func (r DataType) InvalidValue() (string, bool) {
+	if r.Invalid == nil && len(r.Values) > 0 {
+		return "", true
+	}
+	if r.Invalid == nil {
+		return "", false
+	}
+	return *r.Invalid, true
+}
+
+type testCase struct {
+	Name  string
+	Cmd   string
+	Parts []string
+	Want  string
+	// Args is expression of parsed args
+	Args string
+}
+
+func (r testCase) PartsExpr() string {
+	parts := make([]string, 0, len(r.Parts))
+	for _, p := range r.Parts {
+		parts = append(parts, strconv.Quote(p))
+	}
+	return fmt.Sprintf("[]string{%s}", strings.Join(parts, ", "))
 }
 
 func main() {
 	cfgFile := flag.String("in", "", "")
 	outFileName := flag.String("out", "", "")
+	testOutFileName := flag.String("test-out", "", "")
 	flag.Parse()
 
 	// Read config file
@@ -430,17 +1280,41 @@ func main() {
 		log.Fatal(err)
 	}
 
+	typesMap := map[string]DataType{}
+	for _, t := range cfg.Types {
+		if err := t.validate(); err != nil {
+			log.Fatal(err)
+		}
+		typesMap[t.Name] = t
+	}
+
+	cmdNames := map[string][]string{}
+	for _, cmd := range cfg.Commands {
+		cmdNames[cmd.Name] = cmd.Aliases
+
+		subNames := map[string][]string{}
+		for _, sub := range cmd.SubCommands {
+			if err := sub.validate(typesMap); err != nil {
+				log.Fatal(err)
+			}
+			subNames[sub.Name] = sub.Aliases
+		}
+
+		if err := validateNames(subNames); err != nil {
+			log.Fatalf("command %s: %v", cmd.Name, err)
+		}
+	}
+
+	if err := validateNames(cmdNames); err != nil {
+		log.Fatal(err)
+	}
+
 	// Generate template
 	type tmplData struct {
 		Config   *CommandProcessorConfig
 		TypesMap map[string]DataType
 	}
 
-	typesMap := map[string]DataType{}
-	for _, t := range cfg.Types {
-		typesMap[t.Name] = t
-	}
-
 	var buf bytes.Buffer
 	if err := tmpl.Execute(&buf, tmplData{
 		Config:   &cfg,
@@ -453,4 +1327,38 @@ func main() {
 	if err := os.WriteFile(*outFileName, buf.Bytes(), os.ModePerm); err != nil {
 		log.Fatal(err)
 	}
+
+	if *testOutFileName == "" {
+		return
+	}
+
+	// Generate parser tests
+	type parser struct {
+		Cmd string
+		Sub string
+	}
+	type testTmplData struct {
+		Parsers []parser
+		Cases   []testCase
+	}
+
+	var testData testTmplData
+	for _, cmd := range cfg.Commands {
+		for _, sub := range cmd.SubCommands {
+			if len(sub.Args) == 0 {
+				continue
+			}
+			testData.Parsers = append(testData.Parsers, parser{Cmd: cmd.Name, Sub: sub.Name})
+			testData.Cases = append(testData.Cases, sub.testCases(cmd.Name, typesMap)...)
+		}
+	}
+
+	buf.Reset()
+	if err := testTmpl.Execute(&buf, testData); err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(*testOutFileName, buf.Bytes(), os.ModePerm); err != nil {
+		log.Fatal(err)
+	}
 }
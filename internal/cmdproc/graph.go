@@ -1,79 +1,21 @@
 package cmdproc
 
-import (
-	"bytes"
-	"text/template"
-)
+import "github.com/devldavydov/myhealth/internal/common/html"
 
 const (
-	ChartColorRed    = "rgb(255, 99, 132)"
-	ChartColorOrange = "rgb(255, 159, 64)"
-	ChartColorYellow = "rgb(255, 205, 86)"
-	ChartColorGreen  = "rgb(75, 192, 192)"
-	ChartColorBlue   = "rgb(54, 162, 235)"
-	ChartColorPurple = "rgb(153, 102, 255)"
-	ChartColorGrey   = "rgb(201, 203, 207)"
+	ChartColorRed    = html.ChartColorRed
+	ChartColorOrange = html.ChartColorOrange
+	ChartColorYellow = html.ChartColorYellow
+	ChartColorGreen  = html.ChartColorGreen
+	ChartColorBlue   = html.ChartColorBlue
+	ChartColorPurple = html.ChartColorPurple
+	ChartColorGrey   = html.ChartColorGrey
 )
 
-type ChartData struct {
-	PlotFunc string
-	ElemID   string
-	XLabels  []string
-	Type     string
-	Datasets []ChartDataset
-}
-
-type ChartDataset struct {
-	Data  []float64
-	Label string
-	Color string
-}
-
-func GetChartSnippet(data *ChartData) (string, error) {
-	tmpl := template.Must(template.
-		New("").
-		Parse(`
-<script>
-	function {{.PlotFunc}}() {
-		const ctx = document.getElementById('{{.ElemID}}');
-
-		new Chart(ctx, {
-			type: '{{.Type}}',
-			data: {
-				labels: [
-				{{- range .XLabels }}
-					'{{- . }}',
-				{{- end }}
-				],
-				datasets: [
-				{{- range .Datasets }}
-					{
-						label: '{{.Label}}',
-						data: [
-						{{- range .Data }}
-							{{- . }},
-						{{- end }}
-						],
-						borderWidth: 2,
-						borderColor: '{{.Color}}',
-						backgroundColor: '{{.Color}}'
-					},
-				{{- end}}					
-				]
-			}
-		});		
-	}
-	plots.push({{.PlotFunc}});
-</script>
-	`))
-
-	buf := bytes.NewBuffer([]byte{})
-	if err := tmpl.Execute(buf, data); err != nil {
-		return "", err
-	}
-
-	return buf.String(), nil
-}
+type (
+	ChartData    = html.ChartData
+	ChartDataset = html.ChartDataset
+)
 
 func GetStartPlotSnippet() string {
 	return `
@@ -1,7 +1,123 @@
 package cmdproc
 
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/devldavydov/myhealth/internal/common/html"
+	m "github.com/devldavydov/myhealth/internal/common/messages"
+	"github.com/devldavydov/myhealth/internal/storage"
+	"go.uber.org/zap"
+)
+
+// Size of chart images, photo in chat is scaled to width
 const (
-	_cssBotstrapURL = "https://devldavydov.github.io/css/bootstrap/bootstrap.min.css"
-	_jsBootstrapURL = "https://devldavydov.github.io/js/bootstrap/bootstrap.bundle.min.js"
-	_jsChartURL     = "https://devldavydov.github.io/js/chartjs/chart.umd.min.js"
+	_chartImageWidth  = 1000
+	_chartImageHeight = 500
 )
+
+// periodDays returns count of days in period including both ends.
+func periodDays(from, to time.Time) int {
+	return int(math.Round(storage.DayStart(to).Sub(storage.DayStart(from)).Hours()/24)) + 1
+}
+
+// dayKey returns timestamp of start of day of timestamp, it groups records
+// with time of day by days.
+func dayKey(ts storage.Timestamp, tz *time.Location) storage.Timestamp {
+	return storage.NewTimestamp(storage.DayStart(ts.ToTime(tz)))
+}
+
+// reportResponse returns report file in format, empty format is user
+// default one.
+func (r *CmdProcessor) reportResponse(
+	userID int64,
+	htmlBuilder *html.Builder,
+	fileName string,
+	format storage.ReportFormat,
+) []CmdResponse {
+	if format == "" {
+		format = r.getUserReportFormat(userID)
+	}
+
+	if format != storage.ReportFormatPDF {
+		return NewSingleCmdResponse(r.typeAdapter.File(
+			bytes.NewBufferString(htmlBuilder.Build()),
+			"text/html",
+			fileName+".html",
+		))
+	}
+
+	data, err := htmlBuilder.BuildPDF()
+	if err != nil {
+		r.logger.Error(
+			"report PDF build error",
+			zap.Int64("userID", userID),
+			zap.String("fileName", fileName),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	return NewSingleCmdResponse(r.typeAdapter.File(
+		bytes.NewBuffer(data),
+		"application/pdf",
+		fileName+".pdf",
+	))
+}
+
+// getUserReportFormat returns user default report format, HTML if user
+// settings are not set.
+func (r *CmdProcessor) getUserReportFormat(userID int64) storage.ReportFormat {
+	ctx, cancel := context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
+	defer cancel()
+
+	us, err := r.stg.GetUserSettings(ctx, userID)
+	if err != nil {
+		if !errors.Is(err, storage.ErrUserSettingsNotFound) {
+			r.logger.Error(
+				"get user report format DB error",
+				zap.Int64("userID", userID),
+				zap.Error(err),
+			)
+		}
+
+		return storage.ReportFormatHTML
+	}
+
+	return us.ReportFormat
+}
+
+// chartResponse returns chart image: PNG as photo with caption, SVG as
+// file.
+func (r *CmdProcessor) chartResponse(
+	userID int64,
+	data *ChartData,
+	caption, fileName string,
+	format html.ChartImageFormat,
+) []CmdResponse {
+	img, err := html.RenderChart(data, format, _chartImageWidth, _chartImageHeight)
+	if err != nil {
+		r.logger.Error(
+			"chart render error",
+			zap.Int64("userID", userID),
+			zap.String("fileName", fileName),
+			zap.Error(err),
+		)
+
+		return NewErrCmdResponse(m.MsgErrInternal)
+	}
+
+	if format == html.ChartImageSVG {
+		return NewSingleCmdResponse(r.typeAdapter.File(
+			bytes.NewBuffer(img),
+			format.Mime(),
+			fileName+".svg",
+		))
+	}
+
+	return NewSingleCmdResponse(r.typeAdapter.Photo(bytes.NewBuffer(img), caption))
+}
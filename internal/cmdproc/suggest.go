@@ -0,0 +1,83 @@
+package cmdproc
+
+import (
+	"fmt"
+
+	m "github.com/devldavydov/myhealth/internal/common/messages"
+)
+
+// Max edit distance of suggested command name to unknown one.
+const _suggestMaxDistance = 2
+
+// suggestCommand returns invalid command response with the closest
+// command name, if there is one.
+func suggestCommand(name string) []CmdResponse {
+	candidates := []string{"h"}
+	for _, cmd := range _commandRegistry {
+		candidates = append(candidates, cmd.Name)
+		candidates = append(candidates, cmd.Aliases...)
+	}
+
+	return suggestResponse(name, "", candidates)
+}
+
+// suggestSubCommand returns invalid command response with the closest
+// subcommand name of command, if there is one.
+func suggestSubCommand(baseCmd, name string) []CmdResponse {
+	candidates := []string{"h"}
+	for _, cmd := range _commandRegistry {
+		if cmd.Name != baseCmd {
+			continue
+		}
+
+		for _, sub := range cmd.SubCommands {
+			candidates = append(candidates, sub.Name)
+			candidates = append(candidates, sub.Aliases...)
+		}
+	}
+
+	return suggestResponse(name, baseCmd+",", candidates)
+}
+
+func suggestResponse(name, prefix string, candidates []string) []CmdResponse {
+	best, bestDist := "", 0
+	for _, c := range candidates {
+		d := levenshtein(name, c)
+		if best == "" || d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+
+	// Distance must be less than name length, otherwise any short name
+	// is similar to any other
+	nameLen := len([]rune(name))
+	if best == "" || bestDist > _suggestMaxDistance || bestDist >= nameLen {
+		return NewErrCmdResponse(m.MsgErrInvalidCommand)
+	}
+
+	return NewErrCmdResponse(fmt.Sprintf("%s\n%s: %s%s", m.MsgErrInvalidCommand, m.MsgDidYouMean, prefix, best))
+}
+
+// levenshtein returns edit distance between strings in runes.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+
+	return prev[len(rb)]
+}
@@ -0,0 +1,79 @@
+package cmdproc
+
+import (
+	"testing"
+
+	m "github.com/devldavydov/myhealth/internal/common/messages"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLevenshtein(t *testing.T) {
+	for _, tt := range []struct {
+		a, b string
+		want int
+	}{
+		{a: "", b: "", want: 0},
+		{a: "", b: "abc", want: 3},
+		{a: "abc", b: "", want: 3},
+		{a: "abc", b: "abc", want: 0},
+		{a: "abc", b: "abd", want: 1},
+		{a: "abc", b: "ab", want: 1},
+		{a: "ab", b: "ba", want: 2},
+		{a: "kitten", b: "sitting", want: 3},
+		{a: "обед", b: "обет", want: 1},
+		{a: "ужин", b: "ужинn", want: 1},
+	} {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			assert.Equal(t, tt.want, levenshtein(tt.a, tt.b))
+			assert.Equal(t, tt.want, levenshtein(tt.b, tt.a))
+		})
+	}
+}
+
+func TestSuggestCommand(t *testing.T) {
+	didYouMean := func(cmd string) []CmdResponse {
+		return NewErrCmdResponse(m.MsgErrInvalidCommand + "\n" + m.MsgDidYouMean + ": " + cmd)
+	}
+
+	for _, tt := range []struct {
+		name string
+		cmd  string
+		want []CmdResponse
+	}{
+		{name: "alias typo", cmd: "jornal", want: didYouMean("journal")},
+		{name: "alias with extra letter", cmd: "weightt", want: didYouMean("weight")},
+		{name: "two edits", cmd: "sprot", want: didYouMean("sport")},
+		// Tie of distance 1 with wa and w: first in registry wins
+		{name: "tie", cmd: "wx", want: didYouMean("w")},
+		{name: "too far", cmd: "abcdef", want: NewErrCmdResponse(m.MsgErrInvalidCommand)},
+		// Distance of one letter name is not less than its length
+		{name: "one letter", cmd: "z", want: NewErrCmdResponse(m.MsgErrInvalidCommand)},
+		{name: "empty", cmd: "", want: NewErrCmdResponse(m.MsgErrInvalidCommand)},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, suggestCommand(tt.cmd))
+		})
+	}
+}
+
+func TestSuggestSubCommand(t *testing.T) {
+	didYouMean := func(cmd string) []CmdResponse {
+		return NewErrCmdResponse(m.MsgErrInvalidCommand + "\n" + m.MsgDidYouMean + ": " + cmd)
+	}
+
+	for _, tt := range []struct {
+		name    string
+		baseCmd string
+		cmd     string
+		want    []CmdResponse
+	}{
+		{name: "typo", baseCmd: "fa", cmd: "stopp", want: didYouMean("fa,stop")},
+		{name: "alias typo", baseCmd: "fa", cmd: "statsu", want: didYouMean("fa,status")},
+		{name: "other command subcommand", baseCmd: "w", cmd: "start", want: NewErrCmdResponse(m.MsgErrInvalidCommand)},
+		{name: "unknown command", baseCmd: "zz", cmd: "set", want: NewErrCmdResponse(m.MsgErrInvalidCommand)},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, suggestSubCommand(tt.baseCmd, tt.cmd))
+		})
+	}
+}
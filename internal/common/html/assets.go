@@ -0,0 +1,146 @@
+package html
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/devldavydov/myhealth/internal/myhealthserver/static"
+)
+
+// AssetsMode defines how report CSS and JS are referenced.
+type AssetsMode string
+
+const (
+	// AssetsCDN links assets from public CDN, report needs Internet access.
+	AssetsCDN AssetsMode = "cdn"
+	// AssetsInline puts assets content into report, report works offline.
+	AssetsInline AssetsMode = "inline"
+	// AssetsServer links assets from server base URL, see AssetsFS.
+	AssetsServer AssetsMode = "server"
+)
+
+// Asset is embedded CSS or JS file, file is path in AssetsFS.
+type Asset struct {
+	file   string
+	cdnURL string
+}
+
+var (
+	// Bootstrap is shared with static files of web UI
+	AssetBootstrapCSS = Asset{
+		file:   "bootstrap/css/bootstrap.min.css",
+		cdnURL: _cssBotstrapURL,
+	}
+	AssetBootstrapJS = Asset{
+		file:   "bootstrap/js/bootstrap.bundle.min.js",
+		cdnURL: _jsBootstrapURL,
+	}
+	// Chart.js is not shipped, offline modes use compatible subset.
+	AssetChartJS = Asset{
+		file:   "chart-lite.js",
+		cdnURL: _jsChartURL,
+	}
+)
+
+//go:embed assets/*
+var assetsFS embed.FS
+
+// unionFS opens file from the first of file systems, which has it.
+type unionFS []fs.FS
+
+func (r unionFS) Open(name string) (fs.File, error) {
+	for _, fsys := range r {
+		f, err := fsys.Open(name)
+		if err == nil || !errors.Is(err, fs.ErrNotExist) {
+			return f, err
+		}
+	}
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
+
+// AssetsFS returns embedded assets for serving in AssetsServer mode.
+func AssetsFS() fs.FS {
+	sub, err := fs.Sub(assetsFS, "assets")
+	if err != nil {
+		panic(err)
+	}
+	return unionFS{sub, static.FS}
+}
+
+// ParseAssetsMode validates assets mode string.
+func ParseAssetsMode(s string) (AssetsMode, error) {
+	switch mode := AssetsMode(s); mode {
+	case AssetsCDN, AssetsInline, AssetsServer:
+		return mode, nil
+	}
+	return "", fmt.Errorf("invalid assets mode: %s", s)
+}
+
+// Assets renders report CSS and JS in mode, zero Assets links CDN.
+type Assets struct {
+	mode    AssetsMode
+	baseURL string
+}
+
+// NewAssets returns assets of reports, baseURL is required for
+// AssetsServer mode.
+func NewAssets(mode AssetsMode, baseURL string) (Assets, error) {
+	if _, err := ParseAssetsMode(string(mode)); err != nil {
+		return Assets{}, err
+	}
+	if mode == AssetsServer && baseURL == "" {
+		return Assets{}, fmt.Errorf("empty assets base URL")
+	}
+
+	return Assets{mode: mode, baseURL: strings.TrimSuffix(baseURL, "/")}, nil
+}
+
+// Script returns script element of asset.
+func (r Assets) Script(asset Asset) IELement {
+	return &AssetScript{assets: r, asset: asset}
+}
+
+func (r Assets) url(asset Asset) string {
+	if r.mode == AssetsServer {
+		return r.baseURL + "/" + asset.file
+	}
+	return asset.cdnURL
+}
+
+func (r Assets) buildStyle(asset Asset) string {
+	if r.mode == AssetsInline {
+		return fmt.Sprintf("<style>%s</style>", asset.content())
+	}
+	return fmt.Sprintf(`<link href="%s" rel="stylesheet">`, r.url(asset))
+}
+
+func (r Assets) buildScript(asset Asset) string {
+	if r.mode == AssetsInline {
+		// Script content must not close tag
+		return fmt.Sprintf("<script>%s</script>", strings.ReplaceAll(asset.content(), "</script", `<\/script`))
+	}
+	return NewScript(r.url(asset)).Build()
+}
+
+func (r Asset) content() string {
+	data, err := fs.ReadFile(AssetsFS(), r.file)
+	if err != nil {
+		panic(err)
+	}
+	return string(data)
+}
+
+// AssetScript is script element for embedded asset.
+type AssetScript struct {
+	assets Assets
+	asset  Asset
+}
+
+var _ IELement = (*AssetScript)(nil)
+
+func (r *AssetScript) Build() string {
+	return r.assets.buildScript(r.asset)
+}
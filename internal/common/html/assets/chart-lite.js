@@ -0,0 +1,222 @@
+/*
+ * chart-lite.js - minimal offline replacement of Chart.js for myhealth
+ * reports. Supports the subset of API used by reports:
+ *
+ *   new Chart(canvas, {
+ *     type: 'line' | 'bar',
+ *     data: {
+ *       labels: [...],
+ *       datasets: [{label, data, borderWidth, borderColor, backgroundColor}]
+ *     },
+ *     options: {scales: {x: {stacked}, y: {stacked}}}
+ *   });
+ */
+(function (global) {
+  'use strict';
+
+  var PAD_LEFT = 48, PAD_RIGHT = 12, PAD_TOP = 28, PAD_BOTTOM = 40;
+  var TICKS = 5;
+  var FONT = '11px sans-serif';
+
+  function niceStep(span) {
+    var raw = span / TICKS;
+    var mag = Math.pow(10, Math.floor(Math.log10(raw)));
+    var norm = raw / mag;
+    var step = norm <= 1 ? 1 : norm <= 2 ? 2 : norm <= 5 ? 5 : 10;
+    return step * mag;
+  }
+
+  function formatTick(v, step) {
+    var digits = step >= 1 ? 0 : Math.min(4, Math.ceil(-Math.log10(step)));
+    return v.toFixed(digits);
+  }
+
+  function Chart(canvas, config) {
+    if (typeof canvas === 'string') {
+      canvas = document.getElementById(canvas);
+    }
+    this.canvas = canvas;
+    this.config = config;
+    this.draw();
+
+    var self = this;
+    global.addEventListener('resize', function () { self.draw(); });
+  }
+
+  Chart.prototype.draw = function () {
+    var canvas = this.canvas;
+    var type = this.config.type;
+    var labels = this.config.data.labels || [];
+    var datasets = this.config.data.datasets || [];
+    var scales = (this.config.options || {}).scales || {};
+    var stacked = type === 'bar' && !!(scales.y || {}).stacked;
+
+    var width = canvas.parentNode ? canvas.parentNode.clientWidth : 600;
+    if (!width) {
+      width = 600;
+    }
+    var height = Math.round(width / 2);
+    var ratio = global.devicePixelRatio || 1;
+
+    canvas.width = width * ratio;
+    canvas.height = height * ratio;
+    canvas.style.width = width + 'px';
+    canvas.style.height = height + 'px';
+
+    var ctx = canvas.getContext('2d');
+    ctx.setTransform(ratio, 0, 0, ratio, 0, 0);
+    ctx.clearRect(0, 0, width, height);
+    ctx.font = FONT;
+
+    // Value range
+    var minV = Infinity, maxV = -Infinity;
+    var pos = [], neg = [];
+    datasets.forEach(function (ds) {
+      (ds.data || []).forEach(function (v, i) {
+        if (v === null || v === undefined || isNaN(v)) {
+          return;
+        }
+        if (stacked) {
+          // Range of stacked bars is range of sums
+          if (v >= 0) {
+            pos[i] = (pos[i] || 0) + v;
+            maxV = Math.max(maxV, pos[i]);
+          } else {
+            neg[i] = (neg[i] || 0) + v;
+            minV = Math.min(minV, neg[i]);
+          }
+          minV = Math.min(minV, 0);
+          maxV = Math.max(maxV, 0);
+          return;
+        }
+        minV = Math.min(minV, v);
+        maxV = Math.max(maxV, v);
+      });
+    });
+    if (minV === Infinity) {
+      minV = 0;
+      maxV = 1;
+    }
+    if (type === 'bar') {
+      minV = Math.min(0, minV);
+      maxV = Math.max(0, maxV);
+    }
+    if (minV === maxV) {
+      minV -= 1;
+      maxV += 1;
+    }
+
+    var step = niceStep(maxV - minV);
+    minV = Math.floor(minV / step) * step;
+    maxV = Math.ceil(maxV / step) * step;
+
+    var plotW = width - PAD_LEFT - PAD_RIGHT;
+    var plotH = height - PAD_TOP - PAD_BOTTOM;
+    var n = Math.max(labels.length, 1);
+    var slot = plotW / n;
+
+    function y(v) {
+      return PAD_TOP + plotH - (v - minV) / (maxV - minV) * plotH;
+    }
+
+    function x(i) {
+      return PAD_LEFT + slot * i + slot / 2;
+    }
+
+    // Grid and Y ticks
+    ctx.strokeStyle = '#e5e5e5';
+    ctx.fillStyle = '#666';
+    ctx.lineWidth = 1;
+    ctx.textAlign = 'right';
+    ctx.textBaseline = 'middle';
+    for (var t = minV; t <= maxV + step / 2; t += step) {
+      var ty = Math.round(y(t)) + 0.5;
+      ctx.beginPath();
+      ctx.moveTo(PAD_LEFT, ty);
+      ctx.lineTo(PAD_LEFT + plotW, ty);
+      ctx.stroke();
+      ctx.fillText(formatTick(t, step), PAD_LEFT - 6, ty);
+    }
+
+    // X labels, thinned to fit
+    ctx.textAlign = 'center';
+    ctx.textBaseline = 'top';
+    var every = Math.max(1, Math.ceil(labels.length * 60 / plotW));
+    labels.forEach(function (l, i) {
+      if (i % every === 0) {
+        ctx.fillText(String(l), x(i), PAD_TOP + plotH + 6);
+      }
+    });
+
+    // Data
+    var barW = stacked ? slot * 0.8 : slot * 0.8 / Math.max(datasets.length, 1);
+    pos = [];
+    neg = [];
+    datasets.forEach(function (ds, di) {
+      var data = ds.data || [];
+      ctx.strokeStyle = ds.borderColor || '#36a2eb';
+      ctx.fillStyle = ds.backgroundColor || ds.borderColor || '#36a2eb';
+      ctx.lineWidth = ds.borderWidth || 2;
+
+      if (type === 'bar') {
+        data.forEach(function (v, i) {
+          if (v === null || v === undefined || isNaN(v)) {
+            return;
+          }
+          var bx = PAD_LEFT + slot * i + slot * 0.1;
+          var from = 0, to = v;
+          if (stacked) {
+            var acc = v >= 0 ? pos : neg;
+            from = acc[i] || 0;
+            to = acc[i] = from + v;
+          } else {
+            bx += barW * di;
+          }
+          var top = y(Math.max(from, to)), bottom = y(Math.min(from, to));
+          ctx.fillRect(bx, top, barW, Math.max(bottom - top, 1));
+        });
+        return;
+      }
+
+      ctx.beginPath();
+      var started = false;
+      data.forEach(function (v, i) {
+        if (v === null || v === undefined || isNaN(v)) {
+          started = false;
+          return;
+        }
+        if (started) {
+          ctx.lineTo(x(i), y(v));
+        } else {
+          ctx.moveTo(x(i), y(v));
+          started = true;
+        }
+      });
+      ctx.stroke();
+
+      data.forEach(function (v, i) {
+        if (v === null || v === undefined || isNaN(v)) {
+          return;
+        }
+        ctx.beginPath();
+        ctx.arc(x(i), y(v), 2.5, 0, 2 * Math.PI);
+        ctx.fill();
+      });
+    });
+
+    // Legend
+    ctx.textAlign = 'left';
+    ctx.textBaseline = 'middle';
+    var lx = PAD_LEFT;
+    datasets.forEach(function (ds) {
+      var label = String(ds.label || '');
+      ctx.fillStyle = ds.backgroundColor || ds.borderColor || '#36a2eb';
+      ctx.fillRect(lx, 8, 24, 10);
+      ctx.fillStyle = '#666';
+      ctx.fillText(label, lx + 30, 13);
+      lx += 30 + ctx.measureText(label).width + 16;
+    });
+  };
+
+  global.Chart = Chart;
+})(window);
@@ -0,0 +1,54 @@
+package html
+
+import (
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildAssets(t *testing.T) {
+	build := func(assets Assets) string {
+		return NewBuilder("test", assets).Add(assets.Script(AssetChartJS)).Build()
+	}
+
+	for _, assets := range []Assets{{}, mustNewAssets(NewAssets(AssetsCDN, ""))} {
+		out := build(assets)
+		assert.Contains(t, out, `<link href="`+_cssBotstrapURL+`" rel="stylesheet">`)
+		assert.Contains(t, out, `<script src="`+_jsChartURL+`"></script>`)
+	}
+
+	out := build(mustNewAssets(NewAssets(AssetsServer, "https://localhost:8080/static/report/")))
+	assert.Contains(t, out, `<link href="https://localhost:8080/static/report/bootstrap/css/bootstrap.min.css" rel="stylesheet">`)
+	assert.Contains(t, out, `<script src="https://localhost:8080/static/report/chart-lite.js"></script>`)
+
+	out = build(mustNewAssets(NewAssets(AssetsInline, "")))
+	assert.NotContains(t, out, `href="https://`)
+	assert.NotContains(t, out, `src="https://`)
+	assert.Contains(t, out, "<style>")
+	assert.Contains(t, out, "global.Chart = Chart;")
+}
+
+func TestNewAssetsInvalid(t *testing.T) {
+	_, err := NewAssets("local", "")
+	assert.Error(t, err)
+	_, err = NewAssets(AssetsServer, "")
+	assert.Error(t, err)
+
+	_, err = ParseAssetsMode("")
+	assert.Error(t, err)
+}
+
+func TestAssetsFS(t *testing.T) {
+	for _, asset := range []Asset{AssetBootstrapCSS, AssetBootstrapJS, AssetChartJS} {
+		_, err := fs.Stat(AssetsFS(), asset.file)
+		assert.NoError(t, err, asset.file)
+	}
+}
+
+func mustNewAssets(assets Assets, err error) Assets {
+	if err != nil {
+		panic(err)
+	}
+	return assets
+}
@@ -2,15 +2,18 @@ package html
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 )
 
 type Attrs map[string]string
 
+// String renders attributes sorted by name, so output is stable.
 func (r Attrs) String() string {
 	s := make([]string, 0, len(r))
-	for k, v := range r {
-		s = append(s, fmt.Sprintf(`%s="%s"`, k, v))
+	for _, k := range slices.Sorted(maps.Keys(r)) {
+		s = append(s, fmt.Sprintf(`%s="%s"`, k, r[k]))
 	}
 	return strings.Join(s, " ")
 }
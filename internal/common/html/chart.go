@@ -0,0 +1,99 @@
+package html
+
+import (
+	"bytes"
+	"text/template"
+)
+
+const (
+	ChartColorRed    = "rgb(255, 99, 132)"
+	ChartColorOrange = "rgb(255, 159, 64)"
+	ChartColorYellow = "rgb(255, 205, 86)"
+	ChartColorGreen  = "rgb(75, 192, 192)"
+	ChartColorBlue   = "rgb(54, 162, 235)"
+	ChartColorPurple = "rgb(153, 102, 255)"
+	ChartColorGrey   = "rgb(201, 203, 207)"
+)
+
+type ChartData struct {
+	PlotFunc string
+	ElemID   string
+	XLabels  []string
+	Type     string
+	// Stacked draws bar datasets on top of each other
+	Stacked  bool
+	Datasets []ChartDataset
+}
+
+type ChartDataset struct {
+	Data  []float64
+	Label string
+	Color string
+}
+
+var _chartTmpl = template.Must(template.
+	New("").
+	Parse(`
+<script>
+	function {{.PlotFunc}}() {
+		const ctx = document.getElementById('{{.ElemID}}');
+
+		new Chart(ctx, {
+			type: '{{.Type}}',
+			data: {
+				labels: [
+				{{- range .XLabels }}
+					'{{- . }}',
+				{{- end }}
+				],
+				datasets: [
+				{{- range .Datasets }}
+					{
+						label: '{{.Label}}',
+						data: [
+						{{- range .Data }}
+							{{- . }},
+						{{- end }}
+						],
+						borderWidth: 2,
+						borderColor: '{{.Color}}',
+						backgroundColor: '{{.Color}}'
+					},
+				{{- end}}					
+				]
+			}
+			{{- if .Stacked }},
+			options: {
+				scales: {
+					x: { stacked: true },
+					y: { stacked: true }
+				}
+			}
+			{{- end }}
+		});		
+	}
+	plots.push({{.PlotFunc}});
+</script>
+	`))
+
+// ChartScript is script drawing chart in canvas with ElemID, for PDF
+// chart is drawn from data instead.
+type ChartScript struct {
+	data    *ChartData
+	snippet string
+}
+
+var _ IELement = (*ChartScript)(nil)
+
+func NewChartScript(data *ChartData) (*ChartScript, error) {
+	buf := bytes.NewBuffer([]byte{})
+	if err := _chartTmpl.Execute(buf, data); err != nil {
+		return nil, err
+	}
+
+	return &ChartScript{data: data, snippet: buf.String()}, nil
+}
+
+func (r *ChartScript) Build() string {
+	return r.snippet
+}
@@ -0,0 +1,202 @@
+package html
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+
+	"github.com/devldavydov/myhealth/internal/common/pdf"
+)
+
+var (
+	_chartGridColor  = pdf.Color{R: 229, G: 229, B: 229}
+	_chartMutedColor = pdf.Color{R: 102, G: 102, B: 102}
+
+	_reRGB = regexp.MustCompile(`rgb\((\d+),\s*(\d+),\s*(\d+)\)`)
+)
+
+// chartCanvas is surface chart is drawn on: PDF page, PNG or SVG image.
+// Coordinates are from top left corner, text y is baseline.
+type chartCanvas interface {
+	SetFont(style pdf.FontStyle, size float64)
+	TextWidth(s string) float64
+	Text(x, y float64, s string, c pdf.Color)
+	Polyline(points []pdf.Point, width float64, c pdf.Color)
+	FillRect(x, y, w, h float64, c pdf.Color)
+}
+
+// drawChart draws chart in box at x0, y0 of width and height, paddings
+// are in font size units.
+func drawChart(c chartCanvas, data *ChartData, x0, y0, width, height, fontSize float64) {
+	isBar := data.Type == "bar"
+	stacked := isBar && data.Stacked
+
+	n := max(len(data.XLabels), 1)
+	for _, ds := range data.Datasets {
+		n = max(n, len(ds.Data))
+	}
+
+	// Value range
+	minV, maxV := math.Inf(1), math.Inf(-1)
+	if stacked {
+		for i := 0; i < n; i++ {
+			pos, neg := stackSums(data.Datasets, i)
+			minV, maxV = math.Min(minV, neg), math.Max(maxV, pos)
+		}
+	} else {
+		for _, ds := range data.Datasets {
+			for _, v := range ds.Data {
+				minV, maxV = math.Min(minV, v), math.Max(maxV, v)
+			}
+		}
+	}
+	if math.IsInf(minV, 0) {
+		minV, maxV = 0, 1
+	}
+	if isBar {
+		minV, maxV = math.Min(0, minV), math.Max(0, maxV)
+	}
+	if minV == maxV {
+		minV, maxV = minV-1, maxV+1
+	}
+	step := niceStep(maxV - minV)
+	minV, maxV = math.Floor(minV/step)*step, math.Ceil(maxV/step)*step
+
+	// Paddings fit Y ticks, X labels and legend
+	c.SetFont(pdf.Regular, fontSize)
+	var ticks []string
+	var tickW float64
+	for t := minV; t <= maxV+step/2; t += step {
+		label := strconv.FormatFloat(t, 'f', tickDigits(step), 64)
+		ticks = append(ticks, label)
+		tickW = math.Max(tickW, c.TextWidth(label))
+	}
+
+	padLeft, padRight := tickW+fontSize, fontSize
+	padTop, padBottom := fontSize*3, fontSize*3.4
+	plotW, plotH := width-padLeft-padRight, height-padTop-padBottom
+
+	slot := plotW / float64(n)
+	xAt := func(i int) float64 { return x0 + padLeft + slot*float64(i) + slot/2 }
+	yAt := func(v float64) float64 { return y0 + padTop + plotH - (v-minV)/(maxV-minV)*plotH }
+
+	// Grid and Y ticks
+	for i, label := range ticks {
+		ty := yAt(minV + step*float64(i))
+		c.Polyline([]pdf.Point{{X: x0 + padLeft, Y: ty}, {X: x0 + padLeft + plotW, Y: ty}}, fontSize/14, _chartGridColor)
+		c.Text(x0+padLeft-fontSize*0.6-c.TextWidth(label), ty+fontSize*0.35, label, _chartMutedColor)
+	}
+
+	// X labels, thinned to fit
+	var labelW float64
+	for _, l := range data.XLabels {
+		labelW = math.Max(labelW, c.TextWidth(l))
+	}
+	every := max(1, int(math.Ceil(float64(len(data.XLabels))*(labelW+fontSize)/plotW)))
+	for i, l := range data.XLabels {
+		if i%every == 0 {
+			c.Text(xAt(i)-c.TextWidth(l)/2, y0+padTop+plotH+fontSize*1.6, l, _chartMutedColor)
+		}
+	}
+
+	// Data
+	barW := slot * 0.8
+	if !stacked {
+		barW /= float64(max(len(data.Datasets), 1))
+	}
+	pos, neg := make([]float64, n), make([]float64, n)
+	for di, ds := range data.Datasets {
+		color := parseRGB(ds.Color)
+		if isBar {
+			for i, v := range ds.Data {
+				bx := x0 + padLeft + slot*float64(i) + slot*0.1
+				from, to := 0.0, v
+				if stacked {
+					if v >= 0 {
+						from, pos[i] = pos[i], pos[i]+v
+						to = pos[i]
+					} else {
+						from, neg[i] = neg[i], neg[i]+v
+						to = neg[i]
+					}
+				} else {
+					bx += barW * float64(di)
+				}
+				top, bottom := yAt(math.Max(from, to)), yAt(math.Min(from, to))
+				c.FillRect(bx, top, barW, math.Max(bottom-top, fontSize/14), color)
+			}
+			continue
+		}
+
+		points := make([]pdf.Point, 0, len(ds.Data))
+		for i, v := range ds.Data {
+			points = append(points, pdf.Point{X: xAt(i), Y: yAt(v)})
+		}
+		c.Polyline(points, fontSize/5, color)
+		for _, p := range points {
+			r := fontSize / 5
+			c.FillRect(p.X-r, p.Y-r, 2*r, 2*r, color)
+		}
+	}
+
+	// Legend
+	lx := x0 + padLeft
+	for _, ds := range data.Datasets {
+		c.FillRect(lx, y0+fontSize*0.6, fontSize*2.6, fontSize, parseRGB(ds.Color))
+		c.Text(lx+fontSize*3.1, y0+fontSize*1.5, ds.Label, _chartMutedColor)
+		lx += fontSize*4.8 + c.TextWidth(ds.Label)
+	}
+}
+
+// stackSums returns sums of positive and negative values of datasets at
+// index i.
+func stackSums(datasets []ChartDataset, i int) (float64, float64) {
+	var pos, neg float64
+	for _, ds := range datasets {
+		if i >= len(ds.Data) {
+			continue
+		}
+		if v := ds.Data[i]; v >= 0 {
+			pos += v
+		} else {
+			neg += v
+		}
+	}
+	return pos, neg
+}
+
+func niceStep(span float64) float64 {
+	raw := span / 5
+	mag := math.Pow(10, math.Floor(math.Log10(raw)))
+	switch norm := raw / mag; {
+	case norm <= 1:
+		return mag
+	case norm <= 2:
+		return 2 * mag
+	case norm <= 5:
+		return 5 * mag
+	default:
+		return 10 * mag
+	}
+}
+
+func tickDigits(step float64) int {
+	if step >= 1 {
+		return 0
+	}
+	return min(4, int(math.Ceil(-math.Log10(step))))
+}
+
+func parseRGB(s string) pdf.Color {
+	m := _reRGB.FindStringSubmatch(s)
+	if m == nil {
+		return pdf.Black
+	}
+
+	var c [3]uint8
+	for i := range c {
+		v, _ := strconv.Atoi(m[i+1])
+		c[i] = uint8(min(v, 255))
+	}
+	return pdf.Color{R: c[0], G: c[1], B: c[2]}
+}
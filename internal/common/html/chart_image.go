@@ -0,0 +1,250 @@
+package html
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	stdhtml "html"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"strings"
+	"sync"
+
+	"github.com/devldavydov/myhealth/internal/common/pdf"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
+)
+
+type ChartImageFormat string
+
+const (
+	ChartImagePNG ChartImageFormat = "png"
+	ChartImageSVG ChartImageFormat = "svg"
+)
+
+var ErrChartImageFormat = errors.New("unknown chart image format")
+
+func (r ChartImageFormat) Mime() string {
+	if r == ChartImageSVG {
+		return "image/svg+xml"
+	}
+	return "image/png"
+}
+
+// Fonts of image charts are parsed once and shared by renders
+var (
+	_chartFonts     []*opentype.Font
+	_chartFontsErr  error
+	_chartFontsOnce sync.Once
+)
+
+// RenderChart renders chart to image of width and height in pixels.
+func RenderChart(data *ChartData, format ChartImageFormat, width, height int) ([]byte, error) {
+	_chartFontsOnce.Do(loadChartFonts)
+	if _chartFontsErr != nil {
+		return nil, _chartFontsErr
+	}
+
+	fontSize := math.Max(10, float64(width)/64)
+
+	switch format {
+	case ChartImagePNG:
+		c := newPNGCanvas(width, height)
+		drawChart(c, data, 0, 0, float64(width), float64(height), fontSize)
+
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, c.img); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case ChartImageSVG:
+		c := newSVGCanvas(width, height)
+		drawChart(c, data, 0, 0, float64(width), float64(height), fontSize)
+		return c.bytes(), nil
+	default:
+		return nil, ErrChartImageFormat
+	}
+}
+
+func loadChartFonts() {
+	for _, style := range []pdf.FontStyle{pdf.Regular, pdf.Bold} {
+		data, err := pdf.FontFile(style)
+		if err != nil {
+			_chartFontsErr = err
+			return
+		}
+
+		f, err := opentype.Parse(data)
+		if err != nil {
+			_chartFontsErr = err
+			return
+		}
+		_chartFonts = append(_chartFonts, f)
+	}
+}
+
+// chartFaces measures text with fonts shared with PDF.
+type chartFaces struct {
+	faces map[[2]float64]font.Face
+	face  font.Face
+	style pdf.FontStyle
+	size  float64
+}
+
+func (r *chartFaces) SetFont(style pdf.FontStyle, size float64) {
+	key := [2]float64{float64(style), size}
+	if r.faces == nil {
+		r.faces = make(map[[2]float64]font.Face)
+	}
+
+	face, ok := r.faces[key]
+	if !ok {
+		// Options are valid, error is not possible
+		face, _ = opentype.NewFace(_chartFonts[style], &opentype.FaceOptions{
+			Size:    size,
+			DPI:     72,
+			Hinting: font.HintingFull,
+		})
+		r.faces[key] = face
+	}
+	r.face, r.style, r.size = face, style, size
+}
+
+func (r *chartFaces) TextWidth(s string) float64 {
+	return float64(font.MeasureString(r.face, s)) / 64
+}
+
+//
+// PNG
+//
+
+type pngCanvas struct {
+	chartFaces
+	img *image.RGBA
+	z   *vector.Rasterizer
+}
+
+var _ chartCanvas = (*pngCanvas)(nil)
+
+func newPNGCanvas(width, height int) *pngCanvas {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+	return &pngCanvas{img: img, z: vector.NewRasterizer(width, height)}
+}
+
+func (r *pngCanvas) Text(x, y float64, s string, c pdf.Color) {
+	d := &font.Drawer{
+		Dst:  r.img,
+		Src:  image.NewUniform(rgba(c)),
+		Face: r.face,
+		Dot:  fixed.Point26_6{X: fixed.Int26_6(x * 64), Y: fixed.Int26_6(y * 64)},
+	}
+	d.DrawString(s)
+}
+
+// Polyline draws segments as quads, joins are filled with squares.
+func (r *pngCanvas) Polyline(points []pdf.Point, width float64, c pdf.Color) {
+	hw := width / 2
+	for i := 1; i < len(points); i++ {
+		p, q := points[i-1], points[i]
+		dx, dy := q.X-p.X, q.Y-p.Y
+		l := math.Hypot(dx, dy)
+		if l == 0 {
+			continue
+		}
+		nx, ny := -dy/l*hw, dx/l*hw
+		r.fill(c,
+			pdf.Point{X: p.X + nx, Y: p.Y + ny},
+			pdf.Point{X: q.X + nx, Y: q.Y + ny},
+			pdf.Point{X: q.X - nx, Y: q.Y - ny},
+			pdf.Point{X: p.X - nx, Y: p.Y - ny})
+		if i < len(points)-1 {
+			r.FillRect(q.X-hw, q.Y-hw, width, width, c)
+		}
+	}
+}
+
+func (r *pngCanvas) FillRect(x, y, w, h float64, c pdf.Color) {
+	r.fill(c,
+		pdf.Point{X: x, Y: y},
+		pdf.Point{X: x + w, Y: y},
+		pdf.Point{X: x + w, Y: y + h},
+		pdf.Point{X: x, Y: y + h})
+}
+
+func (r *pngCanvas) fill(c pdf.Color, points ...pdf.Point) {
+	b := r.img.Bounds()
+	r.z.Reset(b.Dx(), b.Dy())
+	r.z.MoveTo(float32(points[0].X), float32(points[0].Y))
+	for _, p := range points[1:] {
+		r.z.LineTo(float32(p.X), float32(p.Y))
+	}
+	r.z.ClosePath()
+	r.z.Draw(r.img, b, image.NewUniform(rgba(c)), image.Point{})
+}
+
+func rgba(c pdf.Color) color.RGBA {
+	return color.RGBA{R: c.R, G: c.G, B: c.B, A: 255}
+}
+
+//
+// SVG
+//
+
+type svgCanvas struct {
+	chartFaces
+	buf bytes.Buffer
+}
+
+var _ chartCanvas = (*svgCanvas)(nil)
+
+func newSVGCanvas(width, height int) *svgCanvas {
+	c := &svgCanvas{}
+	fmt.Fprintf(&c.buf,
+		`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="DejaVu Sans, sans-serif">`,
+		width, height, width, height)
+	fmt.Fprintf(&c.buf, `<rect width="%d" height="%d" fill="#fff"/>`, width, height)
+	return c
+}
+
+func (r *svgCanvas) Text(x, y float64, s string, c pdf.Color) {
+	weight := ""
+	if r.style == pdf.Bold {
+		weight = ` font-weight="bold"`
+	}
+	fmt.Fprintf(&r.buf, `<text x="%s" y="%s" font-size="%s"%s fill="%s">%s</text>`,
+		svgNum(x), svgNum(y), svgNum(r.size), weight, svgColor(c), stdhtml.EscapeString(s))
+}
+
+func (r *svgCanvas) Polyline(points []pdf.Point, width float64, c pdf.Color) {
+	coords := make([]string, 0, len(points))
+	for _, p := range points {
+		coords = append(coords, svgNum(p.X)+","+svgNum(p.Y))
+	}
+	fmt.Fprintf(&r.buf, `<polyline points="%s" fill="none" stroke="%s" stroke-width="%s" stroke-linejoin="round"/>`,
+		strings.Join(coords, " "), svgColor(c), svgNum(width))
+}
+
+func (r *svgCanvas) FillRect(x, y, w, h float64, c pdf.Color) {
+	fmt.Fprintf(&r.buf, `<rect x="%s" y="%s" width="%s" height="%s" fill="%s"/>`,
+		svgNum(x), svgNum(y), svgNum(w), svgNum(h), svgColor(c))
+}
+
+func (r *svgCanvas) bytes() []byte {
+	r.buf.WriteString("</svg>")
+	return r.buf.Bytes()
+}
+
+func svgColor(c pdf.Color) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+func svgNum(v float64) string {
+	s := strings.TrimRight(fmt.Sprintf("%.2f", v), "0")
+	return strings.TrimSuffix(s, ".")
+}
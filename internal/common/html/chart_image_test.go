@@ -0,0 +1,80 @@
+package html
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderChartPNG(t *testing.T) {
+	data, err := RenderChart(&ChartData{
+		XLabels: []string{"01.01", "02.01", "03.01"},
+		Type:    "line",
+		Datasets: []ChartDataset{
+			{Data: []float64{80.5, 80.1, 79.8}, Label: "Вес", Color: ChartColorBlue},
+		},
+	}, ChartImagePNG, 800, 400)
+	require.NoError(t, err)
+
+	img, err := png.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, 800, img.Bounds().Dx())
+	assert.Equal(t, 400, img.Bounds().Dy())
+
+	// Something is drawn in dataset color
+	var found bool
+	for y := 0; y < 400 && !found; y++ {
+		for x := 0; x < 800 && !found; x++ {
+			r, g, b, _ := img.At(x, y).RGBA()
+			found = r>>8 == 54 && g>>8 == 162 && b>>8 == 235
+		}
+	}
+	assert.True(t, found)
+}
+
+func TestRenderChartSVG(t *testing.T) {
+	data, err := RenderChart(&ChartData{
+		XLabels: []string{"Пн", "Вт"},
+		Type:    "bar",
+		Stacked: true,
+		Datasets: []ChartDataset{
+			{Data: []float64{30, 40}, Label: "Кардио", Color: ChartColorRed},
+			{Data: []float64{20, 10}, Label: "Сила <1>", Color: ChartColorGreen},
+		},
+	}, ChartImageSVG, 600, 300)
+	require.NoError(t, err)
+
+	svg := string(data)
+	assert.Contains(t, svg, `<svg xmlns="http://www.w3.org/2000/svg" width="600" height="300"`)
+	assert.Contains(t, svg, ">Пн</text>")
+	assert.Contains(t, svg, ">Сила &lt;1&gt;</text>")
+	assert.Contains(t, svg, `fill="#ff6384"`)
+	// Stacked range is range of sums
+	assert.Contains(t, svg, ">50</text>")
+	assert.NotContains(t, svg, ">60</text>")
+	assert.True(t, bytes.HasSuffix(data, []byte("</svg>")))
+}
+
+func TestRenderChartInvalidFormat(t *testing.T) {
+	_, err := RenderChart(&ChartData{}, "gif", 100, 100)
+	assert.ErrorIs(t, err, ErrChartImageFormat)
+}
+
+func TestStackSums(t *testing.T) {
+	datasets := []ChartDataset{
+		{Data: []float64{1, -2}},
+		{Data: []float64{3}},
+		{Data: []float64{-4, 5}},
+	}
+
+	pos, neg := stackSums(datasets, 0)
+	assert.Equal(t, 4.0, pos)
+	assert.Equal(t, -4.0, neg)
+
+	pos, neg = stackSums(datasets, 1)
+	assert.Equal(t, 5.0, pos)
+	assert.Equal(t, -2.0, neg)
+}
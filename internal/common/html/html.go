@@ -17,11 +17,12 @@ type IELement interface {
 
 type Builder struct {
 	title    string
+	assets   Assets
 	elements []IELement
 }
 
-func NewBuilder(title string) *Builder {
-	return &Builder{title: title}
+func NewBuilder(title string, assets Assets) *Builder {
+	return &Builder{title: title, assets: assets}
 }
 
 func (r *Builder) Add(elems ...IELement) *Builder {
@@ -38,12 +39,12 @@ func (r *Builder) Build() string {
 	<head>
 	  <meta charset="utf-8">
 	  <title>%s</title>
-	  <link href="%s" rel="stylesheet">
+	  %s
 	</head>
 	<body>
 	`,
 		r.title,
-		_cssBotstrapURL))
+		r.assets.buildStyle(AssetBootstrapCSS)))
 
 	for _, elem := range r.elements {
 		sb.WriteString(elem.Build())
@@ -0,0 +1,597 @@
+package html
+
+import (
+	stdhtml "html"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/devldavydov/myhealth/internal/common/pdf"
+)
+
+const (
+	_pdfMargin      = 36.0
+	_pdfTextSize    = 10.0
+	_pdfTableSize   = 8.0
+	_pdfChartSize   = 7.0
+	_pdfLineSpacing = 1.3
+	_pdfCellPadding = 3.0
+	_pdfBlockGap    = 6.0
+)
+
+var (
+	_pdfBorderColor = pdf.Color{R: 222, G: 226, B: 230}
+	_pdfHeaderColor = pdf.Color{R: 248, G: 249, B: 250}
+	_pdfActiveColor = pdf.Color{R: 236, G: 236, B: 236}
+	_pdfClassColors = map[string]pdf.Color{
+		"text-danger":  {R: 220, G: 53, B: 69},
+		"text-success": {R: 25, G: 135, B: 84},
+	}
+)
+
+var (
+	_reScript = regexp.MustCompile(`(?is)<script.*?</script>`)
+	_reBr     = regexp.MustCompile(`(?i)<br\s*/?>`)
+	_reTag    = regexp.MustCompile(`<[^>]*>`)
+	_reSpaces = regexp.MustCompile(`[\s\x{00a0}]+`)
+	_reToken  = regexp.MustCompile(`\S+|\s+`)
+)
+
+// BuildPDF renders document as PDF: accordion items are flattened to
+// sections, charts are drawn from chart scripts data, other scripts are
+// skipped.
+func (r *Builder) BuildPDF() ([]byte, error) {
+	doc, err := pdf.NewDocument(r.title)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &pdfRenderer{doc: doc, charts: make(map[string]*ChartData)}
+	p.collectCharts(r.elements)
+	p.newPage()
+	p.heading(r.title, 1)
+	for _, elem := range r.elements {
+		p.block(elem)
+	}
+
+	return doc.Bytes()
+}
+
+type pdfRun struct {
+	text  string
+	style pdf.FontStyle
+	color pdf.Color
+}
+
+type pdfWord struct {
+	pdfRun
+	width float64
+}
+
+type pdfLine struct {
+	words []pdfWord
+	width float64
+}
+
+type pdfRenderer struct {
+	doc    *pdf.Document
+	charts map[string]*ChartData
+	y      float64
+}
+
+func (r *pdfRenderer) contentWidth() float64 {
+	return pdf.PageWidth - 2*_pdfMargin
+}
+
+func (r *pdfRenderer) bottom() float64 {
+	return pdf.PageHeight - _pdfMargin
+}
+
+func (r *pdfRenderer) newPage() {
+	r.doc.AddPage()
+	r.y = _pdfMargin
+}
+
+// ensure starts new page if height does not fit, returns true on new page.
+func (r *pdfRenderer) ensure(height float64) bool {
+	if r.y+height <= r.bottom() || r.y == _pdfMargin {
+		return false
+	}
+	r.newPage()
+	return true
+}
+
+func (r *pdfRenderer) collectCharts(elems []IELement) {
+	for _, elem := range elems {
+		switch v := elem.(type) {
+		case *ChartScript:
+			r.charts[v.data.ElemID] = v.data
+		case *Div:
+			r.collectCharts(v.elements)
+		case *Span:
+			r.collectCharts(v.elements)
+		case *Accordion:
+			for _, item := range v.items {
+				r.collectCharts([]IELement{item.body})
+			}
+		}
+	}
+}
+
+func (r *pdfRenderer) block(elem IELement) {
+	switch v := elem.(type) {
+	case *Div:
+		for _, e := range v.elements {
+			r.block(e)
+		}
+	case *Accordion:
+		for _, item := range v.items {
+			r.block(item)
+		}
+	case *AccordionItem:
+		r.heading(plainText(v.header), 3)
+		r.block(v.body)
+	case *H:
+		r.heading(plainText(v.value), v.size)
+	case *Table:
+		r.table(v)
+	case *Canvas:
+		if data, ok := r.charts[v.id]; ok {
+			r.chart(data)
+		}
+	case *Script, *AssetScript, *ChartScript:
+	default:
+		r.paragraph(inlineRuns(elem, pdf.Regular, pdf.Black))
+	}
+}
+
+func (r *pdfRenderer) heading(text string, level int) {
+	size := map[int]float64{1: 16, 2: 14, 3: 12}[level]
+	if size == 0 {
+		size = 11
+	}
+
+	lines := r.layout([]pdfRun{{text: text, style: pdf.Bold}}, r.contentWidth(), size)
+	lineHeight := size * _pdfLineSpacing
+	// Heading is kept with following content
+	r.ensure(float64(len(lines))*lineHeight + 3*lineHeight)
+	r.y += r.drawLines(lines, _pdfMargin, r.y, r.contentWidth(), "", size) + _pdfBlockGap
+}
+
+func (r *pdfRenderer) paragraph(runs []pdfRun) {
+	lines := r.layout(runs, r.contentWidth(), _pdfTextSize)
+	if len(lines) == 0 {
+		return
+	}
+
+	lineHeight := _pdfTextSize * _pdfLineSpacing
+	for _, line := range lines {
+		r.ensure(lineHeight)
+		r.y += r.drawLines([]pdfLine{line}, _pdfMargin, r.y, r.contentWidth(), "", _pdfTextSize)
+	}
+	r.y += _pdfBlockGap
+}
+
+func (r *pdfRenderer) measure(run pdfRun, size float64) float64 {
+	r.doc.SetFont(run.style, size)
+	return r.doc.TextWidth(run.text)
+}
+
+// layout wraps runs by words to lines of width, words longer than width
+// are split.
+func (r *pdfRenderer) layout(runs []pdfRun, width, size float64) []pdfLine {
+	var lines []pdfLine
+	var cur pdfLine
+	pendingSpace := false
+
+	flush := func() {
+		lines = append(lines, cur)
+		cur = pdfLine{}
+		pendingSpace = false
+	}
+
+	add := func(w pdfWord) {
+		if pendingSpace && len(cur.words) != 0 {
+			space := pdfWord{pdfRun: pdfRun{text: " ", style: w.style, color: w.color}}
+			space.width = r.measure(space.pdfRun, size)
+			if cur.width+space.width+w.width > width {
+				flush()
+			} else {
+				cur.words = append(cur.words, space)
+				cur.width += space.width
+			}
+		} else if cur.width+w.width > width && len(cur.words) != 0 {
+			flush()
+		}
+		pendingSpace = false
+		cur.words = append(cur.words, w)
+		cur.width += w.width
+	}
+
+	for _, run := range runs {
+		for i, paragraph := range strings.Split(run.text, "\n") {
+			if i > 0 {
+				flush()
+			}
+
+			for _, token := range _reToken.FindAllString(paragraph, -1) {
+				if strings.TrimSpace(token) == "" {
+					pendingSpace = true
+					continue
+				}
+
+				w := pdfWord{pdfRun: pdfRun{text: token, style: run.style, color: run.color}}
+				w.width = r.measure(w.pdfRun, size)
+				for _, part := range r.splitWord(w, width, size) {
+					add(part)
+				}
+			}
+		}
+	}
+
+	if len(cur.words) != 0 {
+		flush()
+	}
+
+	return lines
+}
+
+func (r *pdfRenderer) splitWord(w pdfWord, width, size float64) []pdfWord {
+	if w.width <= width {
+		return []pdfWord{w}
+	}
+
+	var parts []pdfWord
+	var cur []rune
+	for _, c := range w.text {
+		next := pdfRun{text: string(append(cur, c)), style: w.style, color: w.color}
+		if len(cur) != 0 && r.measure(next, size) > width {
+			part := pdfWord{pdfRun: pdfRun{text: string(cur), style: w.style, color: w.color}}
+			part.width = r.measure(part.pdfRun, size)
+			parts = append(parts, part)
+			cur = nil
+		}
+		cur = append(cur, c)
+	}
+	part := pdfWord{pdfRun: pdfRun{text: string(cur), style: w.style, color: w.color}}
+	part.width = r.measure(part.pdfRun, size)
+
+	return append(parts, part)
+}
+
+// drawLines draws lines from top y, returns height of lines.
+func (r *pdfRenderer) drawLines(lines []pdfLine, x, y, width float64, align string, size float64) float64 {
+	lineHeight := size * _pdfLineSpacing
+	for i, line := range lines {
+		lx := x
+		switch align {
+		case "center":
+			lx += (width - line.width) / 2
+		case "right":
+			lx += width - line.width
+		}
+
+		baseline := y + float64(i)*lineHeight + (lineHeight+size*0.7)/2
+		for _, w := range line.words {
+			if strings.TrimSpace(w.text) != "" {
+				r.doc.SetFont(w.style, size)
+				r.doc.Text(lx, baseline, w.text, w.color)
+			}
+			lx += w.width
+		}
+	}
+
+	return float64(len(lines)) * lineHeight
+}
+
+//
+// Tables
+//
+
+type pdfCell struct {
+	runs    []pdfRun
+	col     int
+	colspan int
+	rowspan int
+	align   string
+	fill    *pdf.Color
+	lines   []pdfLine
+}
+
+type pdfRow struct {
+	cells  []*pdfCell
+	height float64
+}
+
+func (r *pdfRenderer) table(t *Table) {
+	header := &pdfRow{}
+	for _, h := range t.header {
+		header.cells = append(header.cells, &pdfCell{
+			runs:    []pdfRun{{text: plainText(h), style: pdf.Bold}},
+			colspan: 1,
+			rowspan: 1,
+			fill:    &_pdfHeaderColor,
+		})
+	}
+
+	rows := make([]*pdfRow, 0, len(t.rows)+len(t.footer))
+	for _, tr := range t.rows {
+		rows = append(rows, tableRow(tr))
+	}
+
+	var rest []IELement
+	for _, elem := range t.footer {
+		if tr, ok := elem.(*Tr); ok {
+			rows = append(rows, tableRow(tr))
+			continue
+		}
+		rest = append(rest, elem)
+	}
+
+	all := append([]*pdfRow{header}, rows...)
+	ncols := placeCells(all)
+	if ncols == 0 {
+		return
+	}
+
+	colWidths := r.columnWidths(all, ncols)
+	colX := make([]float64, ncols+1)
+	colX[0] = _pdfMargin
+	for i, w := range colWidths {
+		colX[i+1] = colX[i] + w
+	}
+
+	// Heights of rows, cell spanned over rows extends the last row
+	for _, row := range all {
+		for _, c := range row.cells {
+			c.lines = r.layout(c.runs, colX[c.col+c.colspan]-colX[c.col]-2*_pdfCellPadding, _pdfTableSize)
+		}
+	}
+	for _, row := range all {
+		row.height = _pdfTableSize*_pdfLineSpacing + 2*_pdfCellPadding
+		for _, c := range row.cells {
+			if c.rowspan == 1 {
+				row.height = max(row.height, cellHeight(c))
+			}
+		}
+	}
+	for i, row := range all {
+		for _, c := range row.cells {
+			if c.rowspan == 1 {
+				continue
+			}
+			last := min(i+c.rowspan, len(all)) - 1
+			if h := spanHeight(all[i : last+1]); h < cellHeight(c) {
+				all[last].height += cellHeight(c) - h
+			}
+		}
+	}
+
+	drawRow := func(i int) {
+		row := all[i]
+		for _, c := range row.cells {
+			h := spanHeight(all[i:min(i+c.rowspan, len(all))])
+			x, w := colX[c.col], colX[c.col+c.colspan]-colX[c.col]
+			if c.fill != nil {
+				r.doc.FillRect(x, r.y, w, h, *c.fill)
+			}
+			r.doc.StrokeRect(x, r.y, w, h, 0.5, _pdfBorderColor)
+			r.drawLines(c.lines, x+_pdfCellPadding, r.y+_pdfCellPadding, w-2*_pdfCellPadding, c.align, _pdfTableSize)
+		}
+		r.y += row.height
+	}
+
+	// Header is kept with the first row
+	if len(t.header) != 0 {
+		r.ensure(spanHeight(all[:min(2, len(all))]))
+		drawRow(0)
+	}
+
+	// Rows joined by rowspan are kept on one page
+	for i := 1; i < len(all); {
+		end := i + 1
+		for j := i; j < end; j++ {
+			for _, c := range all[j].cells {
+				end = max(end, min(j+c.rowspan, len(all)))
+			}
+		}
+
+		if r.ensure(spanHeight(all[i:end])) && len(t.header) != 0 {
+			drawRow(0)
+		}
+		for j := i; j < end; j++ {
+			drawRow(j)
+		}
+		i = end
+	}
+
+	r.y += _pdfBlockGap
+	for _, elem := range rest {
+		r.block(elem)
+	}
+}
+
+func tableRow(tr *Tr) *pdfRow {
+	var fill *pdf.Color
+	if tr.attrs["class"] == "table-active" {
+		fill = &_pdfActiveColor
+	}
+
+	row := &pdfRow{}
+	for _, td := range tr.items {
+		color := pdfClassColor(td.attrs, pdf.Black)
+		cell := &pdfCell{
+			runs:    inlineRuns(td.val, pdf.Regular, color),
+			colspan: max(1, attrInt(td.attrs, "colspan")),
+			rowspan: max(1, attrInt(td.attrs, "rowspan")),
+			align:   td.attrs["align"],
+			fill:    fill,
+		}
+		if td.attrs["class"] == "table-active" {
+			cell.fill = &_pdfActiveColor
+		}
+		row.cells = append(row.cells, cell)
+	}
+
+	return row
+}
+
+// placeCells sets cell columns considering cells spanned from rows above,
+// returns number of columns.
+func placeCells(rows []*pdfRow) int {
+	occupied := make(map[[2]int]bool)
+	ncols := 0
+	for i, row := range rows {
+		col := 0
+		for _, c := range row.cells {
+			for occupied[[2]int{i, col}] {
+				col++
+			}
+			c.col = col
+			for dr := 0; dr < c.rowspan; dr++ {
+				for dc := 0; dc < c.colspan; dc++ {
+					occupied[[2]int{i + dr, col + dc}] = true
+				}
+			}
+			col += c.colspan
+			ncols = max(ncols, col)
+		}
+	}
+
+	return ncols
+}
+
+// columnWidths fits columns to content width: natural widths if they fit,
+// otherwise long columns are shrunk to wrap.
+func (r *pdfRenderer) columnWidths(rows []*pdfRow, ncols int) []float64 {
+	natural := make([]float64, ncols)
+	minimal := make([]float64, ncols)
+	for _, row := range rows {
+		for _, c := range row.cells {
+			if c.colspan != 1 {
+				continue
+			}
+			var full float64
+			for _, run := range c.runs {
+				full += r.measure(run, _pdfTableSize)
+				for _, word := range strings.Fields(run.text) {
+					minimal[c.col] = max(minimal[c.col], r.measure(pdfRun{text: word, style: run.style}, _pdfTableSize))
+				}
+			}
+			natural[c.col] = max(natural[c.col], full)
+		}
+	}
+
+	var sumNatural, sumMinimal float64
+	for i := range natural {
+		natural[i] += 2*_pdfCellPadding + 1
+		minimal[i] += 2*_pdfCellPadding + 1
+		sumNatural += natural[i]
+		sumMinimal += minimal[i]
+	}
+
+	avail := r.contentWidth()
+	widths := make([]float64, ncols)
+	for i := range widths {
+		switch {
+		case sumNatural <= avail:
+			widths[i] = natural[i] * avail / sumNatural
+		case sumMinimal <= avail:
+			widths[i] = minimal[i] + (avail-sumMinimal)*(natural[i]-minimal[i])/(sumNatural-sumMinimal)
+		default:
+			widths[i] = minimal[i] * avail / sumMinimal
+		}
+	}
+
+	return widths
+}
+
+func cellHeight(c *pdfCell) float64 {
+	return float64(max(1, len(c.lines)))*_pdfTableSize*_pdfLineSpacing + 2*_pdfCellPadding
+}
+
+func spanHeight(rows []*pdfRow) float64 {
+	var h float64
+	for _, row := range rows {
+		h += row.height
+	}
+	return h
+}
+
+//
+// Charts
+//
+
+func (r *pdfRenderer) chart(data *ChartData) {
+	width := r.contentWidth()
+	height := width / 2
+	r.ensure(height)
+
+	drawChart(r.doc, data, _pdfMargin, r.y, width, height, _pdfChartSize)
+	r.y += height + _pdfBlockGap
+}
+
+//
+// Text
+//
+
+// inlineRuns flattens element to text runs.
+func inlineRuns(elem IELement, style pdf.FontStyle, color pdf.Color) []pdfRun {
+	switch v := elem.(type) {
+	case *S:
+		return []pdfRun{{text: plainText(v.val), style: style, color: color}}
+	case *B:
+		return []pdfRun{{text: plainText(v.val), style: pdf.Bold, color: pdfClassColor(v.attrs, color)}}
+	case *I:
+		return []pdfRun{{text: plainText(v.val), style: style, color: pdfClassColor(v.attrs, color)}}
+	case *H:
+		return []pdfRun{{text: plainText(v.value), style: pdf.Bold, color: color}}
+	case *Span:
+		var runs []pdfRun
+		for _, e := range v.elements {
+			runs = append(runs, inlineRuns(e, style, color)...)
+		}
+		return runs
+	case *Div:
+		var runs []pdfRun
+		for i, e := range v.elements {
+			if i > 0 {
+				runs = append(runs, pdfRun{text: "\n", style: style, color: color})
+			}
+			runs = append(runs, inlineRuns(e, style, color)...)
+		}
+		return runs
+	case *Script, *AssetScript, *ChartScript, *Canvas:
+		return nil
+	default:
+		return []pdfRun{{text: plainText(elem.Build()), style: style, color: color}}
+	}
+}
+
+// plainText strips HTML markup, whitespace is collapsed and line breaks
+// are kept.
+func plainText(s string) string {
+	s = _reScript.ReplaceAllString(s, "")
+	s = _reSpaces.ReplaceAllString(s, " ")
+	s = _reBr.ReplaceAllString(s, "\n")
+	s = _reTag.ReplaceAllString(s, "")
+	s = stdhtml.UnescapeString(s)
+	return _reSpaces.ReplaceAllStringFunc(s, func(sp string) string {
+		if strings.Contains(sp, "\n") {
+			return "\n"
+		}
+		return " "
+	})
+}
+
+func pdfClassColor(attrs Attrs, def pdf.Color) pdf.Color {
+	if c, ok := _pdfClassColors[attrs["class"]]; ok {
+		return c
+	}
+	return def
+}
+
+func attrInt(attrs Attrs, key string) int {
+	v, _ := strconv.Atoi(attrs[key])
+	return v
+}
@@ -0,0 +1,107 @@
+package html
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/devldavydov/myhealth/internal/common/pdf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildPDF(t *testing.T) {
+	tbl := NewTable([]string{"Еда", "Вес", "ККал"})
+	for i := 0; i < 100; i++ {
+		tbl.AddRow(NewTr(nil).
+			AddTd(NewTd(NewS(fmt.Sprintf("Продукт %d", i)), nil)).
+			AddTd(NewTd(NewS("100.0"), nil)).
+			AddTd(NewTd(NewS("250.00"), nil)))
+	}
+	tbl.AddFooterElement(NewTr(nil).
+		AddTd(NewTd(NewSpan(NewB("Всего: ", nil), NewS("25000")), Attrs{"colspan": "3"})))
+
+	chart, err := NewChartScript(&ChartData{
+		PlotFunc: "plot",
+		ElemID:   "chart",
+		XLabels:  []string{"01.01.2026", "02.01.2026"},
+		Type:     "line",
+		Datasets: []ChartDataset{{Data: []float64{80.5, 80.1}, Label: "Вес", Color: ChartColorBlue}},
+	})
+	require.NoError(t, err)
+
+	data, err := NewBuilder("Отчет", Assets{}).Add(
+		NewContainer().Add(
+			NewAccordion("acc").
+				AddItem(HewAccordionItem("tbl", "Таблица", tbl)).
+				AddItem(HewAccordionItem("graph", "График", NewCanvas("chart"))),
+		),
+		Assets{}.Script(AssetChartJS),
+		chart,
+	).BuildPDF()
+	require.NoError(t, err)
+
+	assert.True(t, bytes.HasPrefix(data, []byte("%PDF-")))
+	// 100 rows do not fit on one page
+	assert.Contains(t, string(data), "/Count 3")
+}
+
+func TestPlainText(t *testing.T) {
+	for _, tt := range []struct {
+		in   string
+		want string
+	}{
+		{in: "Вес", want: "Вес"},
+		{in: "&nbsp;", want: " "},
+		{in: "a&amp;b", want: "a&b"},
+		{in: "\n\t<b>a</b>  b\n", want: " a b "},
+		{in: "a<br>b", want: "a\nb"},
+		{in: "<script>var plots = [];</script>", want: ""},
+	} {
+		assert.Equal(t, tt.want, plainText(tt.in), tt.in)
+	}
+}
+
+func TestPlaceCells(t *testing.T) {
+	cell := func(colspan, rowspan int) *pdfCell {
+		return &pdfCell{colspan: colspan, rowspan: rowspan}
+	}
+
+	rows := []*pdfRow{
+		{cells: []*pdfCell{cell(1, 1), cell(1, 1), cell(1, 1)}},
+		{cells: []*pdfCell{cell(1, 2), cell(1, 1), cell(1, 1)}},
+		{cells: []*pdfCell{cell(1, 1), cell(1, 1)}},
+		{cells: []*pdfCell{cell(2, 1), cell(1, 1)}},
+	}
+	assert.Equal(t, 3, placeCells(rows))
+	assert.Equal(t, 1, rows[2].cells[0].col)
+	assert.Equal(t, 2, rows[2].cells[1].col)
+	assert.Equal(t, 2, rows[3].cells[1].col)
+}
+
+func TestLayout(t *testing.T) {
+	doc, err := pdf.NewDocument("test")
+	require.NoError(t, err)
+	r := &pdfRenderer{doc: doc}
+
+	lines := r.layout([]pdfRun{
+		{text: "Всего: ", style: pdf.Bold},
+		{text: "100 ккал"},
+	}, 1000, 10)
+	require.Len(t, lines, 1)
+	assert.Equal(t, []string{"Всего:", " ", "100", " ", "ккал"}, lineWords(lines[0]))
+
+	lines = r.layout([]pdfRun{{text: "один два три\nчетыре"}}, r.measure(pdfRun{text: "один два"}, 10), 10)
+	require.Len(t, lines, 3)
+	assert.Equal(t, []string{"один", " ", "два"}, lineWords(lines[0]))
+	assert.Equal(t, []string{"три"}, lineWords(lines[1]))
+	assert.Equal(t, []string{"четыре"}, lineWords(lines[2]))
+}
+
+func lineWords(line pdfLine) []string {
+	res := make([]string, 0, len(line.words))
+	for _, w := range line.words {
+		res = append(res, w.text)
+	}
+	return res
+}
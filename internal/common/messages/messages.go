@@ -4,23 +4,37 @@ const (
 	MsgErrInvalidCommand   = "Неправильная команда (h для помощи)"
 	MsgErrInvalidArgsCount = "Неверное количество аргументов"
 	MsgErrInvalidArg       = "Неверный аргумент"
+	MsgDidYouMean          = "Возможно, имелось в виду"
 
 	MsgErrInternal    = "Внутренняя ошибка"
 	MsgErrEmptyResult = "Пустой результат"
 	MsgErrBadRequest  = "Неправильный запрос"
 
 	MsgErrSportNotFound = "Спорт не найден"
-	MsgErrSportIsUsed   = "Спорт используется в активностях"
+	MsgErrSportIsUsed   = "Спорт используется в активностях или тренировках"
+
+	MsgErrSportActivityNotFound = "Активность не найдена"
+
+	MsgErrWorkoutNotFound = "Тренировка не найдена"
+	MsgErrWorkoutIsUsed   = "Тренировка используется в плане"
 
 	MsgErrMedicineNotFound = "Медицина не найдена"
 	MsgErrMedicineIsUsed   = "Медицина используется в показателях"
 
 	MsgErrWeightNotFound = "Вес не найден"
 
+	MsgErrSleepNotFound = "Сон не найден"
+
+	MsgErrFastNotFound = "Активное голодание не найдено"
+	MsgErrFastActive   = "Голодание уже идет"
+	MsgErrFastConflict = "Голодание пересекается с существующим"
+
 	MsgErrFoodNotFound = "Еда не найдена"
 	MsgErrFoodIsUsed   = "Еда уже используется в журнале приема пищи или бандле"
 	MsgErrFoodInvalid  = "Еда задана не правильно"
 
+	MsgErrFoodPortionNotFound = "Порция еды не найдена"
+
 	MsgErrBundleDepBundleNotFound  = "Зависимый бандл не найден в базе данных"
 	MsgErrBundleDepFoodNotFound    = "Зависимая еда не найдена в базе данных"
 	MsgErrBundleDepBundleRecursive = "Зависимый бандл не может быть рекурсивным"
@@ -28,6 +42,13 @@ const (
 	MsgErrBundleIsUsed             = "Бандл уже используется в другом бандле"
 
 	MsgErrUserSettingsNotFound = "Настройки пользователя не найдены"
+	MsgErrUserProfileNotFound  = "Профиль пользователя не найден"
+
+	MsgErrNotEnoughData = "Недостаточно данных"
+
+	MsgErrBatchTooLarge = "Слишком много команд в пакете"
+
+	MsgErrPeriodTooLarge = "Слишком большой период, дней"
 
 	MsgOK = "OK"
 )
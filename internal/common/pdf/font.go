@@ -0,0 +1,427 @@
+package pdf
+
+import (
+	"bytes"
+	"embed"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+//go:embed fonts/*.ttf
+var fontsFS embed.FS
+
+var errInvalidFont = errors.New("invalid font")
+
+// Tables kept in font subset, cmap is optional but some readers need it
+var _subsetTables = []string{"cmap", "cvt ", "fpgm", "glyf", "head", "hhea", "hmtx", "loca", "maxp", "prep"}
+
+// font is parsed TrueType font, glyphs used in document are tracked to
+// embed subset only.
+type font struct {
+	name       string
+	data       []byte
+	tables     map[string][]byte
+	unitsPerEm float64
+	ascent     float64
+	descent    float64
+	bbox       [4]float64
+	numGlyphs  int
+	widths     []uint16
+	cmap       map[rune]uint16
+	loca       []uint32
+	used       map[uint16]rune
+}
+
+// Font name and file of style
+var _fontFiles = []struct{ name, file string }{
+	Regular: {"DejaVuSans", "DejaVuSans.ttf"},
+	Bold:    {"DejaVuSans-Bold", "DejaVuSans-Bold.ttf"},
+}
+
+// FontFile returns TrueType file of embedded font of style, so other
+// renderers draw text in the same font.
+func FontFile(style FontStyle) ([]byte, error) {
+	return fontsFS.ReadFile("fonts/" + _fontFiles[style].file)
+}
+
+func loadFont(style FontStyle) (*font, error) {
+	data, err := FontFile(style)
+	if err != nil {
+		return nil, err
+	}
+	return parseFont(_fontFiles[style].name, data)
+}
+
+func parseFont(name string, data []byte) (*font, error) {
+	f := &font{name: name, data: data, tables: make(map[string][]byte), used: make(map[uint16]rune)}
+
+	if len(data) < 12 {
+		return nil, errInvalidFont
+	}
+	numTables := int(binary.BigEndian.Uint16(data[4:]))
+	for i := 0; i < numTables; i++ {
+		rec := 12 + 16*i
+		if rec+16 > len(data) {
+			return nil, errInvalidFont
+		}
+		tag := string(data[rec : rec+4])
+		off := binary.BigEndian.Uint32(data[rec+8:])
+		length := binary.BigEndian.Uint32(data[rec+12:])
+		if uint64(off)+uint64(length) > uint64(len(data)) {
+			return nil, errInvalidFont
+		}
+		f.tables[tag] = data[off : off+length]
+	}
+
+	for _, tag := range []string{"head", "hhea", "maxp", "hmtx", "loca", "glyf", "cmap"} {
+		if _, ok := f.tables[tag]; !ok {
+			return nil, fmt.Errorf("%w: no %s table", errInvalidFont, tag)
+		}
+	}
+
+	head, hhea, maxp := f.tables["head"], f.tables["hhea"], f.tables["maxp"]
+	if len(head) < 54 || len(hhea) < 36 || len(maxp) < 6 {
+		return nil, errInvalidFont
+	}
+
+	f.unitsPerEm = float64(binary.BigEndian.Uint16(head[18:]))
+	for i := range f.bbox {
+		f.bbox[i] = f.scale(int16(binary.BigEndian.Uint16(head[36+2*i:])))
+	}
+	f.ascent = f.scale(int16(binary.BigEndian.Uint16(hhea[4:])))
+	f.descent = f.scale(int16(binary.BigEndian.Uint16(hhea[6:])))
+	f.numGlyphs = int(binary.BigEndian.Uint16(maxp[4:]))
+
+	if err := f.parseWidths(int(binary.BigEndian.Uint16(hhea[34:]))); err != nil {
+		return nil, err
+	}
+	if err := f.parseLoca(binary.BigEndian.Uint16(head[50:]) == 1); err != nil {
+		return nil, err
+	}
+	if err := f.parseCmap(); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// scale converts font units to 1/1000 of text space.
+func (r *font) scale(v int16) float64 {
+	return float64(v) * 1000 / r.unitsPerEm
+}
+
+func (r *font) parseWidths(numHMetrics int) error {
+	hmtx := r.tables["hmtx"]
+	if numHMetrics == 0 || len(hmtx) < 4*numHMetrics {
+		return errInvalidFont
+	}
+
+	r.widths = make([]uint16, r.numGlyphs)
+	for i := range r.widths {
+		// Glyphs after last metric have its width
+		j := min(i, numHMetrics-1)
+		r.widths[i] = binary.BigEndian.Uint16(hmtx[4*j:])
+	}
+
+	return nil
+}
+
+func (r *font) parseLoca(long bool) error {
+	loca := r.tables["loca"]
+	r.loca = make([]uint32, r.numGlyphs+1)
+	for i := range r.loca {
+		switch {
+		case long && len(loca) >= 4*(i+1):
+			r.loca[i] = binary.BigEndian.Uint32(loca[4*i:])
+		case !long && len(loca) >= 2*(i+1):
+			r.loca[i] = uint32(binary.BigEndian.Uint16(loca[2*i:])) * 2
+		default:
+			return errInvalidFont
+		}
+	}
+
+	return nil
+}
+
+// parseCmap reads unicode subtable of format 4 (BMP) or 12 (full range).
+func (r *font) parseCmap() error {
+	cmap := r.tables["cmap"]
+	if len(cmap) < 4 {
+		return errInvalidFont
+	}
+
+	var off4, off12 int
+	for i := 0; i < int(binary.BigEndian.Uint16(cmap[2:])); i++ {
+		rec := 4 + 8*i
+		if rec+8 > len(cmap) {
+			return errInvalidFont
+		}
+		platform := binary.BigEndian.Uint16(cmap[rec:])
+		encoding := binary.BigEndian.Uint16(cmap[rec+2:])
+		off := int(binary.BigEndian.Uint32(cmap[rec+4:]))
+		if off+4 > len(cmap) {
+			return errInvalidFont
+		}
+
+		switch format := binary.BigEndian.Uint16(cmap[off:]); {
+		case format == 12 && (platform == 0 || platform == 3 && encoding == 10):
+			off12 = off
+		case format == 4 && (platform == 0 || platform == 3 && encoding == 1):
+			off4 = off
+		}
+	}
+
+	r.cmap = make(map[rune]uint16)
+	switch {
+	case off12 != 0:
+		return r.parseCmap12(cmap[off12:])
+	case off4 != 0:
+		return r.parseCmap4(cmap[off4:])
+	}
+
+	return fmt.Errorf("%w: no unicode cmap", errInvalidFont)
+}
+
+func (r *font) parseCmap4(t []byte) error {
+	if len(t) < 14 {
+		return errInvalidFont
+	}
+	segCount := int(binary.BigEndian.Uint16(t[6:])) / 2
+	if len(t) < 16+8*segCount {
+		return errInvalidFont
+	}
+
+	endCodes := t[14:]
+	startCodes := t[16+2*segCount:]
+	deltas := t[16+4*segCount:]
+	rangeOffsets := t[16+6*segCount:]
+	for i := 0; i < segCount; i++ {
+		start := binary.BigEndian.Uint16(startCodes[2*i:])
+		end := binary.BigEndian.Uint16(endCodes[2*i:])
+		delta := binary.BigEndian.Uint16(deltas[2*i:])
+		rangeOffset := int(binary.BigEndian.Uint16(rangeOffsets[2*i:]))
+
+		for c := uint32(start); c <= uint32(end) && c != 0xFFFF; c++ {
+			gid := uint16(c) + delta
+			if rangeOffset != 0 {
+				pos := 16 + 6*segCount + 2*i + rangeOffset + 2*int(uint16(c)-start)
+				if pos+2 > len(t) {
+					return errInvalidFont
+				}
+				if gid = binary.BigEndian.Uint16(t[pos:]); gid != 0 {
+					gid += delta
+				}
+			}
+			if gid != 0 && int(gid) < r.numGlyphs {
+				r.cmap[rune(c)] = gid
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *font) parseCmap12(t []byte) error {
+	if len(t) < 16 {
+		return errInvalidFont
+	}
+	numGroups := int(binary.BigEndian.Uint32(t[12:]))
+	if len(t) < 16+12*numGroups {
+		return errInvalidFont
+	}
+
+	for i := 0; i < numGroups; i++ {
+		g := t[16+12*i:]
+		start := binary.BigEndian.Uint32(g)
+		end := binary.BigEndian.Uint32(g[4:])
+		gid := binary.BigEndian.Uint32(g[8:])
+		for c := start; c <= end; c++ {
+			if int(gid) < r.numGlyphs {
+				r.cmap[rune(c)] = uint16(gid)
+			}
+			gid++
+		}
+	}
+
+	return nil
+}
+
+// glyph returns glyph of rune and marks it as used, missing glyph is 0.
+func (r *font) glyph(c rune) uint16 {
+	gid := r.cmap[c]
+	if _, ok := r.used[gid]; !ok {
+		r.used[gid] = c
+	}
+	return gid
+}
+
+// width returns rune width in 1/1000 of font size.
+func (r *font) width(c rune) float64 {
+	return r.scale(int16(r.widths[r.cmap[c]]))
+}
+
+// usedGlyphs returns sorted used glyphs.
+func (r *font) usedGlyphs() []uint16 {
+	gids := make([]uint16, 0, len(r.used))
+	for gid := range r.used {
+		gids = append(gids, gid)
+	}
+	sort.Slice(gids, func(i, j int) bool { return gids[i] < gids[j] })
+	return gids
+}
+
+// subset returns font file with outlines of used glyphs only. Glyph IDs
+// are kept, so text is encoded with original IDs.
+func (r *font) subset() []byte {
+	keep := map[uint16]bool{0: true}
+	queue := r.usedGlyphs()
+	for len(queue) > 0 {
+		gid := queue[0]
+		queue = queue[1:]
+		if keep[gid] && gid != 0 {
+			continue
+		}
+		keep[gid] = true
+		// Composite glyph needs its components
+		for _, c := range r.components(gid) {
+			if !keep[c] {
+				queue = append(queue, c)
+			}
+		}
+	}
+
+	glyf := r.tables["glyf"]
+	var newGlyf bytes.Buffer
+	newLoca := make([]byte, 4*(r.numGlyphs+1))
+	for gid := 0; gid < r.numGlyphs; gid++ {
+		binary.BigEndian.PutUint32(newLoca[4*gid:], uint32(newGlyf.Len()))
+		if !keep[uint16(gid)] {
+			continue
+		}
+		newGlyf.Write(glyf[r.loca[gid]:r.loca[gid+1]])
+		// Glyphs are 4 bytes aligned
+		for newGlyf.Len()%4 != 0 {
+			newGlyf.WriteByte(0)
+		}
+	}
+	binary.BigEndian.PutUint32(newLoca[4*r.numGlyphs:], uint32(newGlyf.Len()))
+
+	head := bytes.Clone(r.tables["head"])
+	// Long loca format, checksum adjustment is not checked by readers
+	binary.BigEndian.PutUint16(head[50:], 1)
+	binary.BigEndian.PutUint32(head[8:], 0)
+
+	tables := map[string][]byte{
+		"glyf": newGlyf.Bytes(),
+		"loca": newLoca,
+		"head": head,
+	}
+	for _, tag := range _subsetTables {
+		if _, ok := tables[tag]; ok {
+			continue
+		}
+		if t, ok := r.tables[tag]; ok {
+			tables[tag] = t
+		}
+	}
+
+	return buildFontFile(tables)
+}
+
+// components returns component glyphs of composite glyph.
+func (r *font) components(gid uint16) []uint16 {
+	if int(gid) >= r.numGlyphs {
+		return nil
+	}
+	g := r.tables["glyf"][r.loca[gid]:r.loca[gid+1]]
+	if len(g) < 10 || int16(binary.BigEndian.Uint16(g)) >= 0 {
+		return nil
+	}
+
+	const (
+		argsAreWords   = 0x0001
+		haveScale      = 0x0008
+		moreComponents = 0x0020
+		haveXYScale    = 0x0040
+		have2x2        = 0x0080
+	)
+
+	var res []uint16
+	for pos := 10; pos+4 <= len(g); {
+		flags := binary.BigEndian.Uint16(g[pos:])
+		res = append(res, binary.BigEndian.Uint16(g[pos+2:]))
+		pos += 4
+
+		if flags&argsAreWords != 0 {
+			pos += 4
+		} else {
+			pos += 2
+		}
+		switch {
+		case flags&haveScale != 0:
+			pos += 2
+		case flags&haveXYScale != 0:
+			pos += 4
+		case flags&have2x2 != 0:
+			pos += 8
+		}
+
+		if flags&moreComponents == 0 {
+			break
+		}
+	}
+
+	return res
+}
+
+func buildFontFile(tables map[string][]byte) []byte {
+	tags := make([]string, 0, len(tables))
+	for tag := range tables {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	var buf bytes.Buffer
+	numTables := len(tags)
+	entrySelector := 0
+	for 1<<(entrySelector+1) <= numTables {
+		entrySelector++
+	}
+	searchRange := (1 << entrySelector) * 16
+
+	binary.Write(&buf, binary.BigEndian, []uint16{
+		0x0001, 0x0000,
+		uint16(numTables),
+		uint16(searchRange),
+		uint16(entrySelector),
+		uint16(numTables*16 - searchRange),
+	})
+
+	off := 12 + 16*numTables
+	for _, tag := range tags {
+		t := tables[tag]
+		buf.WriteString(tag)
+		binary.Write(&buf, binary.BigEndian, []uint32{checksum(t), uint32(off), uint32(len(t))})
+		off += (len(t) + 3) &^ 3
+	}
+
+	for _, tag := range tags {
+		t := tables[tag]
+		buf.Write(t)
+		buf.Write(make([]byte, ((len(t)+3)&^3)-len(t)))
+	}
+
+	return buf.Bytes()
+}
+
+func checksum(t []byte) uint32 {
+	var sum uint32
+	for i := 0; i < len(t); i += 4 {
+		var word [4]byte
+		copy(word[:], t[i:])
+		sum += binary.BigEndian.Uint32(word[:])
+	}
+	return sum
+}
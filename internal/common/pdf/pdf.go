@@ -0,0 +1,324 @@
+// Package pdf is minimal PDF writer for reports: pages of A4 size with
+// text in embedded unicode fonts, lines, rectangles and polylines.
+// Coordinates are in points from top left corner of page.
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"strings"
+)
+
+const (
+	PageWidth  = 595.28
+	PageHeight = 841.89
+)
+
+type FontStyle int
+
+const (
+	Regular FontStyle = iota
+	Bold
+)
+
+type Color struct {
+	R, G, B uint8
+}
+
+var Black = Color{}
+
+type Point struct {
+	X, Y float64
+}
+
+type Document struct {
+	title    string
+	fonts    []*font
+	pages    []*bytes.Buffer
+	page     *bytes.Buffer
+	style    FontStyle
+	fontSize float64
+}
+
+func NewDocument(title string) (*Document, error) {
+	regular, err := loadFont(Regular)
+	if err != nil {
+		return nil, err
+	}
+
+	bold, err := loadFont(Bold)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Document{
+		title:    title,
+		fonts:    []*font{Regular: regular, Bold: bold},
+		fontSize: 10,
+	}, nil
+}
+
+func (r *Document) AddPage() {
+	r.page = &bytes.Buffer{}
+	r.pages = append(r.pages, r.page)
+}
+
+func (r *Document) PageCount() int {
+	return len(r.pages)
+}
+
+func (r *Document) SetFont(style FontStyle, size float64) {
+	r.style, r.fontSize = style, size
+}
+
+// TextWidth returns width of text in current font.
+func (r *Document) TextWidth(s string) float64 {
+	f := r.fonts[r.style]
+	var w float64
+	for _, c := range s {
+		w += f.width(c)
+	}
+	return w * r.fontSize / 1000
+}
+
+// Text draws text with baseline at y.
+func (r *Document) Text(x, y float64, s string, c Color) {
+	f := r.fonts[r.style]
+
+	var hex strings.Builder
+	for _, ch := range s {
+		fmt.Fprintf(&hex, "%04X", f.glyph(ch))
+	}
+
+	fmt.Fprintf(r.page, "BT %s rg /F%d %s Tf %s %s Td <%s> Tj ET\n",
+		colorOp(c), r.style, num(r.fontSize), num(x), num(PageHeight-y), hex.String())
+}
+
+func (r *Document) Line(x1, y1, x2, y2, width float64, c Color) {
+	r.Polyline([]Point{{x1, y1}, {x2, y2}}, width, c)
+}
+
+func (r *Document) Polyline(points []Point, width float64, c Color) {
+	if len(points) < 2 {
+		return
+	}
+
+	fmt.Fprintf(r.page, "%s RG %s w 1 j ", colorOp(c), num(width))
+	for i, p := range points {
+		op := "l"
+		if i == 0 {
+			op = "m"
+		}
+		fmt.Fprintf(r.page, "%s %s %s ", num(p.X), num(PageHeight-p.Y), op)
+	}
+	r.page.WriteString("S\n")
+}
+
+func (r *Document) FillRect(x, y, w, h float64, c Color) {
+	fmt.Fprintf(r.page, "%s rg %s %s %s %s re f\n",
+		colorOp(c), num(x), num(PageHeight-y-h), num(w), num(h))
+}
+
+func (r *Document) StrokeRect(x, y, w, h, width float64, c Color) {
+	fmt.Fprintf(r.page, "%s RG %s w %s %s %s %s re S\n",
+		colorOp(c), num(width), num(x), num(PageHeight-y-h), num(w), num(h))
+}
+
+// Bytes returns PDF file content.
+func (r *Document) Bytes() ([]byte, error) {
+	if len(r.pages) == 0 {
+		r.AddPage()
+	}
+
+	w := &writer{}
+	w.buf.WriteString("%PDF-1.4\n%\xE2\xE3\xCF\xD3\n")
+
+	// Fixed object numbers: 1 catalog, 2 pages, 3 info
+	w.offsets = make([]int, 3)
+	fontRefs := make([]int, len(r.fonts))
+	for i, f := range r.fonts {
+		ref, err := w.writeFont(f)
+		if err != nil {
+			return nil, err
+		}
+		fontRefs[i] = ref
+	}
+
+	var fontsDict strings.Builder
+	for i, ref := range fontRefs {
+		fmt.Fprintf(&fontsDict, "/F%d %d 0 R ", i, ref)
+	}
+
+	kids := make([]string, 0, len(r.pages))
+	for _, page := range r.pages {
+		content, err := w.writeStream("", page.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		ref := w.writeObject(fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << %s>> >> /Contents %d 0 R >>",
+			num(PageWidth), num(PageHeight), fontsDict.String(), content))
+		kids = append(kids, fmt.Sprintf("%d 0 R", ref))
+	}
+
+	w.setObject(1, "<< /Type /Catalog /Pages 2 0 R >>")
+	w.setObject(2, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(kids)))
+	w.setObject(3, fmt.Sprintf("<< /Title %s /Producer (myhealth) >>", textString(r.title)))
+
+	return w.finish(), nil
+}
+
+type writer struct {
+	buf     bytes.Buffer
+	offsets []int
+}
+
+func (r *writer) writeObject(body string) int {
+	r.offsets = append(r.offsets, 0)
+	ref := len(r.offsets)
+	r.setObject(ref, body)
+	return ref
+}
+
+func (r *writer) setObject(ref int, body string) {
+	r.offsets[ref-1] = r.buf.Len()
+	fmt.Fprintf(&r.buf, "%d 0 obj\n%s\nendobj\n", ref, body)
+}
+
+func (r *writer) writeStream(dict string, data []byte) (int, error) {
+	var z bytes.Buffer
+	zw := zlib.NewWriter(&z)
+	if _, err := zw.Write(data); err != nil {
+		return 0, err
+	}
+	if err := zw.Close(); err != nil {
+		return 0, err
+	}
+
+	return r.writeObject(fmt.Sprintf("<< %s/Filter /FlateDecode /Length %d >>\nstream\n%s\nendstream",
+		dict, z.Len(), z.Bytes())), nil
+}
+
+// writeFont writes Type0 font with Identity-H encoding, text is encoded
+// with glyph IDs.
+func (r *writer) writeFont(f *font) (int, error) {
+	gids := f.usedGlyphs()
+	// Subset tag is required by spec for subset fonts
+	baseFont := subsetTag(gids) + "+" + f.name
+
+	data := f.subset()
+	file, err := r.writeStream(fmt.Sprintf("/Length1 %d ", len(data)), data)
+	if err != nil {
+		return 0, err
+	}
+
+	descriptor := r.writeObject(fmt.Sprintf(
+		"<< /Type /FontDescriptor /FontName /%s /Flags 32 /FontBBox [%s %s %s %s] /ItalicAngle 0 /Ascent %s /Descent %s /CapHeight %s /StemV 80 /FontFile2 %d 0 R >>",
+		baseFont, num(f.bbox[0]), num(f.bbox[1]), num(f.bbox[2]), num(f.bbox[3]),
+		num(f.ascent), num(f.descent), num(f.ascent), file))
+
+	var widths strings.Builder
+	for _, gid := range gids {
+		fmt.Fprintf(&widths, "%d [%s] ", gid, num(f.scale(int16(f.widths[gid]))))
+	}
+
+	cidFont := r.writeObject(fmt.Sprintf(
+		"<< /Type /Font /Subtype /CIDFontType2 /BaseFont /%s /CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >> /FontDescriptor %d 0 R /W [%s] /CIDToGIDMap /Identity >>",
+		baseFont, descriptor, widths.String()))
+
+	toUnicode, err := r.writeStream("", toUnicodeCMap(f, gids))
+	if err != nil {
+		return 0, err
+	}
+
+	return r.writeObject(fmt.Sprintf(
+		"<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /Identity-H /DescendantFonts [%d 0 R] /ToUnicode %d 0 R >>",
+		baseFont, cidFont, toUnicode)), nil
+}
+
+func (r *writer) finish() []byte {
+	xref := r.buf.Len()
+	fmt.Fprintf(&r.buf, "xref\n0 %d\n0000000000 65535 f \n", len(r.offsets)+1)
+	for _, off := range r.offsets {
+		fmt.Fprintf(&r.buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&r.buf, "trailer\n<< /Size %d /Root 1 0 R /Info 3 0 R >>\nstartxref\n%d\n%%%%EOF\n",
+		len(r.offsets)+1, xref)
+
+	return r.buf.Bytes()
+}
+
+// toUnicodeCMap maps glyph IDs to unicode to allow text search and copy.
+func toUnicodeCMap(f *font, gids []uint16) []byte {
+	var b bytes.Buffer
+	b.WriteString("/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n")
+	b.WriteString("/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n")
+	b.WriteString("/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n")
+	b.WriteString("1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n")
+
+	// Up to 100 entries in block by spec
+	for i := 0; i < len(gids); i += 100 {
+		block := gids[i:min(i+100, len(gids))]
+		fmt.Fprintf(&b, "%d beginbfchar\n", len(block))
+		for _, gid := range block {
+			fmt.Fprintf(&b, "<%04X> <", gid)
+			for _, u := range utf16(f.used[gid]) {
+				fmt.Fprintf(&b, "%04X", u)
+			}
+			b.WriteString(">\n")
+		}
+		b.WriteString("endbfchar\n")
+	}
+
+	b.WriteString("endcmap\nCMapName currentdict /CMap defineresource pop\nend\nend\n")
+	return b.Bytes()
+}
+
+func utf16(c rune) []uint16 {
+	if c < 0x10000 {
+		return []uint16{uint16(c)}
+	}
+	c -= 0x10000
+	return []uint16{uint16(0xD800 + c>>10), uint16(0xDC00 + c&0x3FF)}
+}
+
+func subsetTag(gids []uint16) string {
+	var h uint32 = 2166136261
+	for _, gid := range gids {
+		h = (h ^ uint32(gid)) * 16777619
+	}
+
+	tag := make([]byte, 6)
+	for i := range tag {
+		tag[i] = byte('A' + h%26)
+		h /= 26
+	}
+	return string(tag)
+}
+
+// textString encodes text as UTF-16BE string.
+func textString(s string) string {
+	var b strings.Builder
+	b.WriteString("<FEFF")
+	for _, c := range s {
+		for _, u := range utf16(c) {
+			fmt.Fprintf(&b, "%04X", u)
+		}
+	}
+	b.WriteString(">")
+	return b.String()
+}
+
+func colorOp(c Color) string {
+	return fmt.Sprintf("%s %s %s", num(float64(c.R)/255), num(float64(c.G)/255), num(float64(c.B)/255))
+}
+
+func num(v float64) string {
+	s := strings.TrimRight(fmt.Sprintf("%.3f", v), "0")
+	s = strings.TrimSuffix(s, ".")
+	if s == "-0" || s == "" {
+		return "0"
+	}
+	return s
+}
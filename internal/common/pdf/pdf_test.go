@@ -0,0 +1,99 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument(t *testing.T) {
+	doc, err := NewDocument("Отчет")
+	require.NoError(t, err)
+
+	doc.AddPage()
+	doc.SetFont(Bold, 14)
+	doc.Text(40, 60, "Вес", Black)
+	doc.SetFont(Regular, 10)
+	doc.Text(40, 80, "Hello", Color{255, 0, 0})
+	doc.Line(40, 90, 200, 90, 1, Black)
+	doc.AddPage()
+	doc.FillRect(40, 40, 100, 20, Color{200, 200, 200})
+	assert.Equal(t, 2, doc.PageCount())
+
+	data, err := doc.Bytes()
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(data, []byte("%PDF-1.4")))
+	assert.True(t, bytes.HasSuffix(data, []byte("%%EOF\n")))
+	assert.Contains(t, string(data), "/Count 2")
+
+	// Xref offsets point to objects
+	m := regexp.MustCompile(`startxref\n(\d+)`).FindSubmatch(data)
+	require.NotNil(t, m)
+	xref, err := strconv.Atoi(string(m[1]))
+	require.NoError(t, err)
+	offsets := regexp.MustCompile(`(\d{10}) 00000 n`).FindAllSubmatch(data[xref:], -1)
+	require.NotEmpty(t, offsets)
+	for i, off := range offsets {
+		pos, err := strconv.Atoi(string(off[1]))
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(data[pos:], []byte(fmt.Sprintf("%d 0 obj", i+1))), i+1)
+	}
+
+	// Page content has text in glyph IDs
+	content := streams(t, data)
+	f := doc.fonts[Bold]
+	assert.Contains(t, content, fmt.Sprintf("<%04X%04X%04X> Tj", f.cmap['В'], f.cmap['е'], f.cmap['с']))
+}
+
+func TestFontSubset(t *testing.T) {
+	f, err := loadFont(Regular)
+	require.NoError(t, err)
+
+	assert.Greater(t, f.width('Ш'), f.width('i'))
+	for _, c := range "Жук" {
+		f.glyph(c)
+	}
+
+	sub, err := parseFont("sub", f.subset())
+	require.NoError(t, err)
+	assert.Less(t, len(f.subset()), len(f.data)/10)
+	assert.Equal(t, f.numGlyphs, sub.numGlyphs)
+	assert.Equal(t, f.widths, sub.widths)
+
+	// Used glyphs are kept, others are empty
+	for _, c := range "Жук" {
+		gid := f.cmap[c]
+		assert.Equal(t,
+			f.tables["glyf"][f.loca[gid]:f.loca[gid+1]],
+			sub.tables["glyf"][sub.loca[gid]:sub.loca[gid]+f.loca[gid+1]-f.loca[gid]])
+	}
+	gid := f.cmap['Z']
+	assert.Equal(t, sub.loca[gid], sub.loca[gid+1])
+}
+
+func TestParseFontInvalid(t *testing.T) {
+	_, err := parseFont("bad", []byte("not a font"))
+	assert.ErrorIs(t, err, errInvalidFont)
+}
+
+func streams(t *testing.T, data []byte) string {
+	var res bytes.Buffer
+	for _, m := range regexp.MustCompile(`(?s)/Length (\d+) >>\nstream\n`).FindAllSubmatchIndex(data, -1) {
+		length, err := strconv.Atoi(string(data[m[2]:m[3]]))
+		require.NoError(t, err)
+
+		zr, err := zlib.NewReader(bytes.NewReader(data[m[1] : m[1]+length]))
+		require.NoError(t, err)
+		b, err := io.ReadAll(zr)
+		require.NoError(t, err)
+		res.Write(b)
+	}
+	return res.String()
+}
@@ -6,13 +6,19 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path"
 	"path/filepath"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/devldavydov/myhealth/internal/cmdproc"
+	"github.com/devldavydov/myhealth/internal/common/html"
+	m "github.com/devldavydov/myhealth/internal/common/messages"
 	s "github.com/devldavydov/myhealth/internal/storage"
-	slite "github.com/devldavydov/myhealth/internal/storage/sqlite"
+	"github.com/devldavydov/myhealth/internal/storage/open"
 	"go.uber.org/zap"
 	tele "gopkg.in/telebot.v4"
 	"gopkg.in/telebot.v4/middleware"
@@ -20,23 +26,32 @@ import (
 
 const (
 	_backupFile = "backup.json.gz"
+
+	_scriptExt     = ".txt"
+	_scriptMaxSize = 64 * 1024
 )
 
 type Service struct {
 	settings *ServiceSettings
 	cmdProc  *cmdproc.CmdProcessor
 	logger   *zap.Logger
+	wg       sync.WaitGroup
 }
 
 func NewService(settings *ServiceSettings, logger *zap.Logger) (*Service, error) {
-	stg, err := slite.NewStorageSQLite(settings.DBFilePath, logger)
+	assets, err := html.NewAssets(settings.ReportAssets, settings.ReportAssetsURL)
+	if err != nil {
+		return nil, err
+	}
+
+	stg, err := open.NewStorage(settings.DBFilePath, settings.DBDSN, logger)
 	if err != nil {
 		return nil, err
 	}
 
 	srv := &Service{
 		settings: settings,
-		cmdProc:  cmdproc.NewCmdProcessor(stg, NewBotTypeAdapter(), settings.TZ, settings.DebugMode, logger),
+		cmdProc:  cmdproc.NewCmdProcessor(stg, NewBotTypeAdapter(), settings.TZ, assets, settings.DebugMode, logger),
 		logger:   logger,
 	}
 
@@ -59,10 +74,18 @@ func (r *Service) Run(ctx context.Context) error {
 	}
 
 	r.setupRouting(b, r.settings.AllowedUserIDs)
+	r.setupCommands(b)
 	go b.Start()
 
+	r.wg.Add(1)
+	go r.tdeeAutoUpdateJob(ctx)
+
+	r.wg.Add(1)
+	go r.waterReminderJob(ctx, b)
+
 	<-ctx.Done()
 	b.Stop()
+	r.wg.Wait()
 	r.cmdProc.Stop()
 
 	return nil
@@ -74,6 +97,20 @@ func (r *Service) setupRouting(b *tele.Bot, allowedUserIDs []int64) {
 	allowedGroup := b.Group()
 	allowedGroup.Use(middleware.Whitelist(allowedUserIDs...))
 	allowedGroup.Handle(tele.OnText, r.onText)
+	allowedGroup.Handle(tele.OnDocument, r.onDocument)
+}
+
+// setupCommands registers commands menu, command from menu shows
+// help of section.
+func (r *Service) setupCommands(b *tele.Bot) {
+	commands := []tele.Command{{Text: "h", Description: "Помощь"}}
+	for _, cmd := range cmdproc.Commands() {
+		commands = append(commands, tele.Command{Text: cmd.Name, Description: cmd.DescriptionShort})
+	}
+
+	if err := b.SetCommands(commands); err != nil {
+		r.logger.Error("set commands error", zap.Error(err))
+	}
 }
 
 func (r *Service) onStart(c tele.Context) error {
@@ -87,7 +124,98 @@ func (r *Service) onStart(c tele.Context) error {
 }
 
 func (r *Service) onText(c tele.Context) error {
-	return r.cmdProc.Process(c, c.Text(), c.Sender().ID)
+	return r.cmdProc.Process(c, menuCommandToText(c.Text()), c.Sender().ID)
+}
+
+// onDocument runs uploaded text script as batch of commands.
+func (r *Service) onDocument(c tele.Context) error {
+	doc := c.Message().Document
+	if doc == nil ||
+		!strings.EqualFold(filepath.Ext(doc.FileName), _scriptExt) ||
+		doc.FileSize > _scriptMaxSize {
+		return c.Send(m.MsgErrBadRequest)
+	}
+
+	rd, err := c.Bot().File(&doc.File)
+	if err != nil {
+		r.logger.Error("script download error", zap.Int64("userID", c.Sender().ID), zap.Error(err))
+		return c.Send(m.MsgErrInternal)
+	}
+	defer rd.Close()
+
+	script, err := io.ReadAll(io.LimitReader(rd, _scriptMaxSize))
+	if err != nil {
+		r.logger.Error("script read error", zap.Int64("userID", c.Sender().ID), zap.Error(err))
+		return c.Send(m.MsgErrInternal)
+	}
+
+	return r.cmdProc.Process(c, string(script), c.Sender().ID)
+}
+
+// menuCommandToText converts command from menu, i.e. /f or /f@bot,
+// to help command of section.
+func menuCommandToText(text string) string {
+	if !strings.HasPrefix(text, "/") {
+		return text
+	}
+
+	name := strings.TrimPrefix(text, "/")
+	name, _, _ = strings.Cut(name, "@")
+	name, _, _ = strings.Cut(name, " ")
+	if name == "h" {
+		return name
+	}
+
+	return name + ",h"
+}
+
+func (r *Service) tdeeAutoUpdateJob(ctx context.Context) {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(1 * time.Hour)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.logger.Info("tdee auto update job context canceled")
+			return
+		case <-ticker.C:
+			for _, userID := range r.settings.AllowedUserIDs {
+				r.cmdProc.TDEEAutoUpdate(userID)
+			}
+		}
+	}
+}
+
+func (r *Service) waterReminderJob(ctx context.Context, b *tele.Bot) {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(1 * time.Hour)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.logger.Info("water reminder job context canceled")
+			return
+		case <-ticker.C:
+			for _, userID := range r.settings.AllowedUserIDs {
+				msg := r.cmdProc.WaterReminder(userID)
+				if msg == "" {
+					continue
+				}
+
+				if _, err := b.Send(&tele.User{ID: userID}, msg); err != nil {
+					r.logger.Error(
+						"water reminder send error",
+						zap.Int64("userID", userID),
+						zap.Error(err),
+					)
+				}
+			}
+		}
+	}
 }
 
 func (r *Service) tryRestoreFromBackup(stg s.Storage) error {
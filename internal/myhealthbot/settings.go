@@ -1,38 +1,62 @@
 package myhealthbot
 
-import "time"
+import (
+	"fmt"
+	"time"
+
+	"github.com/devldavydov/myhealth/internal/common/html"
+)
 
 type ServiceSettings struct {
-	Token          string
-	PollTimeOut    time.Duration
-	BuildCommit    string
-	DBFilePath     string
-	AllowedUserIDs []int64
-	TZ             *time.Location
-	DebugMode      bool
+	Token           string
+	PollTimeOut     time.Duration
+	BuildCommit     string
+	DBFilePath      string
+	DBDSN           string
+	AllowedUserIDs  []int64
+	TZ              *time.Location
+	DebugMode       bool
+	ReportAssets    html.AssetsMode
+	ReportAssetsURL string
 }
 
 func NewServiceSettings(
 	token string,
 	pollTimeout time.Duration,
 	dbFilePath string,
+	dbDSN string,
 	allowedUserIDs []int64,
 	stz string,
 	buildVersion string,
-	debugMode bool) (*ServiceSettings, error) {
+	debugMode bool,
+	reportAssets string,
+	reportAssetsURL string) (*ServiceSettings, error) {
 
 	tz, err := time.LoadLocation(stz)
 	if err != nil {
 		return nil, err
 	}
 
+	assetsMode, err := html.ParseAssetsMode(reportAssets)
+	if err != nil {
+		return nil, err
+	}
+
+	// Bot has no own server to serve assets
+	if assetsMode == html.AssetsServer && reportAssetsURL == "" {
+		return nil, fmt.Errorf("invalid report assets URL")
+	}
+
 	return &ServiceSettings{
-		Token:          token,
-		PollTimeOut:    pollTimeout,
-		BuildCommit:    buildVersion,
-		DBFilePath:     dbFilePath,
-		AllowedUserIDs: allowedUserIDs,
-		TZ:             tz,
-		DebugMode:      debugMode,
+		Token:           token,
+		PollTimeOut:     pollTimeout,
+		BuildCommit:     buildVersion,
+		DBFilePath:      dbFilePath,
+		DBDSN:           dbDSN,
+		AllowedUserIDs:  allowedUserIDs,
+		TZ:              tz,
+		DebugMode:       debugMode,
+		ReportAssets:    assetsMode,
+		ReportAssetsURL: reportAssetsURL,
 	}, nil
 }
@@ -23,6 +23,13 @@ func (b *BotTypeAdapter) File(buf *bytes.Buffer, mime string, fileName string) a
 	}
 }
 
+func (b *BotTypeAdapter) Photo(buf *bytes.Buffer, caption string) any {
+	return &tele.Photo{
+		File:    tele.FromReader(buf),
+		Caption: caption,
+	}
+}
+
 func (b *BotTypeAdapter) OptsHTML() any {
 	return &tele.SendOptions{ParseMode: tele.ModeHTML}
 }
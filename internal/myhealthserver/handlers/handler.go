@@ -3,28 +3,44 @@ package handlers
 import (
 	"net/http"
 	"path/filepath"
+	"time"
 
 	"github.com/devldavydov/myhealth/internal/cmdproc"
 	"github.com/devldavydov/myhealth/internal/common/messages"
 	p "github.com/devldavydov/myhealth/internal/myhealthserver/process"
+	"github.com/devldavydov/myhealth/internal/storage"
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
 )
 
 type Handler struct {
 	cmdProcessor    *cmdproc.CmdProcessor
+	stg             storage.Storage
+	tz              *time.Location
 	fileStoragePath string
 	userID          int64
+	logger          *zap.Logger
 }
 
-func NewHandler(cmdProcessor *cmdproc.CmdProcessor, fileStoragePath string, userID int64) *Handler {
+func NewHandler(
+	cmdProcessor *cmdproc.CmdProcessor,
+	stg storage.Storage,
+	tz *time.Location,
+	fileStoragePath string,
+	userID int64,
+	logger *zap.Logger,
+) *Handler {
 	return &Handler{
 		cmdProcessor:    cmdProcessor,
+		stg:             stg,
+		tz:              tz,
 		fileStoragePath: fileStoragePath,
-		userID:          userID}
+		userID:          userID,
+		logger:          logger}
 }
 
 func (r *Handler) Index(c *gin.Context) {
-	c.HTML(http.StatusOK, "index.html", nil)
+	r.renderPage(c, "index.html", "Чат", nil)
 }
 
 func (r *Handler) NotFound(c *gin.Context) {
@@ -50,6 +66,11 @@ func (r *Handler) Api(c *gin.Context) {
 	c.JSON(http.StatusOK, prc.GetResponses())
 }
 
+func (r *Handler) Complete(c *gin.Context) {
+	input, _ := c.GetQuery("cmd")
+	c.JSON(http.StatusOK, r.cmdProcessor.Complete(r.userID, input))
+}
+
 func (r *Handler) File(c *gin.Context) {
 	fileUUID, _ := c.GetQuery("fileUUID")
 	fileName, _ := c.GetQuery("fileName")
@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	m "github.com/devldavydov/myhealth/internal/common/messages"
+	"github.com/devldavydov/myhealth/internal/storage"
+	"github.com/gin-gonic/gin"
+)
+
+type foodPage struct {
+	Query string
+	Foods []storage.Food
+	Edit  *storage.Food
+}
+
+type bundleItem struct {
+	Key    string
+	Weight float64
+}
+
+type bundleView struct {
+	Key   string
+	Items []bundleItem
+	Text  string
+}
+
+type bundlePage struct {
+	Query   string
+	Bundles []bundleView
+	Edit    *bundleView
+	Foods   []storage.Food
+}
+
+func (r *Handler) FoodPage(c *gin.Context) {
+	ctx, cancel := storageCtx()
+	defer cancel()
+
+	page := foodPage{Query: strings.TrimSpace(c.Query("q"))}
+
+	var err error
+	if page.Query != "" {
+		page.Foods, err = r.stg.FindFood(ctx, r.userID, page.Query)
+	} else {
+		page.Foods, err = r.stg.GetFoodList(ctx, r.userID)
+	}
+	if err != nil && !errors.Is(err, storage.ErrEmptyResult) {
+		c.String(http.StatusInternalServerError, r.storageErr("food", err))
+		return
+	}
+
+	if key := c.Query("key"); key != "" {
+		if page.Edit, err = r.stg.GetFood(ctx, r.userID, key); err != nil {
+			r.redirect(c, "/food", nil, r.storageErr("food", err))
+			return
+		}
+	}
+
+	r.renderPage(c, "food.html", "Еда", &page)
+}
+
+// FoodSet creates or updates food, fields absent in form (nutrients,
+// portions, density) are kept.
+func (r *Handler) FoodSet(c *gin.Context) {
+	key := strings.TrimSpace(c.PostForm("key"))
+	query := url.Values{"key": {key}}
+
+	ctx, cancel := storageCtx()
+	defer cancel()
+
+	food, err := r.stg.GetFood(ctx, r.userID, key)
+	if err != nil {
+		if !errors.Is(err, storage.ErrFoodNotFound) {
+			r.redirect(c, "/food", query, r.storageErr("food", err))
+			return
+		}
+		food = &storage.Food{Key: key}
+	}
+
+	food.Name = strings.TrimSpace(c.PostForm("name"))
+	food.Brand = strings.TrimSpace(c.PostForm("brand"))
+	food.Comment = strings.TrimSpace(c.PostForm("comment"))
+	food.Beverage = c.PostForm("beverage") != ""
+
+	for _, f := range []struct {
+		name string
+		val  *float64
+	}{
+		{"cal100", &food.Cal100},
+		{"prot100", &food.Prot100},
+		{"fat100", &food.Fat100},
+		{"carb100", &food.Carb100},
+	} {
+		if *f.val, err = parseNum(c.PostForm(f.name)); err != nil {
+			r.redirect(c, "/food", query, m.MsgErrFoodInvalid)
+			return
+		}
+	}
+
+	if err := r.stg.SetFood(ctx, r.userID, food); err != nil {
+		r.redirect(c, "/food", query, r.storageErr("food", err))
+		return
+	}
+
+	r.redirect(c, "/food", url.Values{"q": {key}}, "")
+}
+
+func (r *Handler) FoodDel(c *gin.Context) {
+	ctx, cancel := storageCtx()
+	defer cancel()
+
+	if err := r.stg.DeleteFood(ctx, r.userID, c.PostForm("key")); err != nil {
+		r.redirect(c, "/food", nil, r.storageErr("food", err))
+		return
+	}
+
+	r.redirect(c, "/food", nil, "")
+}
+
+func (r *Handler) BundlePage(c *gin.Context) {
+	ctx, cancel := storageCtx()
+	defer cancel()
+
+	page := bundlePage{Query: strings.TrimSpace(c.Query("q"))}
+
+	lst, err := r.stg.GetBundleList(ctx, r.userID)
+	if err != nil && !errors.Is(err, storage.ErrEmptyResult) {
+		c.String(http.StatusInternalServerError, r.storageErr("bundle", err))
+		return
+	}
+
+	query := strings.ToUpper(page.Query)
+	for _, bndl := range lst {
+		v := newBundleView(bndl)
+		if strings.Contains(strings.ToUpper(v.Key+" "+v.Text), query) {
+			page.Bundles = append(page.Bundles, v)
+		}
+		if bndl.Key == c.Query("key") {
+			page.Edit = &v
+		}
+	}
+
+	if page.Foods, err = r.stg.GetFoodList(ctx, r.userID); err != nil && !errors.Is(err, storage.ErrEmptyResult) {
+		c.String(http.StatusInternalServerError, r.storageErr("bundle", err))
+		return
+	}
+
+	r.renderPage(c, "bundle.html", "Бандлы", &page)
+}
+
+// BundleSet creates or updates bundle, items are lines of food_key:weight
+// for food and bundle_key for dependent bundle, as in chat command.
+func (r *Handler) BundleSet(c *gin.Context) {
+	key := strings.TrimSpace(c.PostForm("key"))
+	query := url.Values{"key": {key}}
+
+	data := make(map[string]float64)
+	for _, line := range strings.Split(c.PostForm("items"), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		itemKey, sWeight, ok := strings.Cut(line, ":")
+		if !ok {
+			// Dependent bundle
+			data[line] = 0
+			continue
+		}
+
+		weight, err := parseNum(sWeight)
+		if err != nil {
+			r.redirect(c, "/bundle", query, m.MsgErrInvalidArg)
+			return
+		}
+		data[strings.TrimSpace(itemKey)] = weight
+	}
+
+	ctx, cancel := storageCtx()
+	defer cancel()
+
+	if err := r.stg.SetBundle(ctx, r.userID, &storage.Bundle{Key: key, Data: data}, true); err != nil {
+		r.redirect(c, "/bundle", query, r.storageErr("bundle", err))
+		return
+	}
+
+	r.redirect(c, "/bundle", nil, "")
+}
+
+func (r *Handler) BundleDel(c *gin.Context) {
+	ctx, cancel := storageCtx()
+	defer cancel()
+
+	if err := r.stg.DeleteBundle(ctx, r.userID, c.PostForm("key")); err != nil {
+		r.redirect(c, "/bundle", nil, r.storageErr("bundle", err))
+		return
+	}
+
+	r.redirect(c, "/bundle", nil, "")
+}
+
+func newBundleView(bndl storage.Bundle) bundleView {
+	v := bundleView{Key: bndl.Key}
+	for k, w := range bndl.Data {
+		v.Items = append(v.Items, bundleItem{Key: k, Weight: w})
+	}
+	sort.Slice(v.Items, func(i, j int) bool { return v.Items[i].Key < v.Items[j].Key })
+
+	lines := make([]string, 0, len(v.Items))
+	for _, item := range v.Items {
+		if item.Weight == 0 {
+			lines = append(lines, item.Key)
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s:%s", item.Key, formatNum(item.Weight)))
+	}
+	v.Text = strings.Join(lines, "\n")
+
+	return v
+}
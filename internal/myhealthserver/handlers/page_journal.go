@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	m "github.com/devldavydov/myhealth/internal/common/messages"
+	"github.com/devldavydov/myhealth/internal/storage"
+	"github.com/gin-gonic/gin"
+)
+
+const _mealCount = 6
+
+type journalMeal struct {
+	Meal  storage.Meal
+	Items []storage.JournalReport
+	Cal   float64
+	Prot  float64
+	Fat   float64
+	Carb  float64
+}
+
+type journalPage struct {
+	Date     string
+	PrevDate string
+	NextDate string
+	Meals    []journalMeal
+	AllMeals []storage.Meal
+	Cal      float64
+	Prot     float64
+	Fat      float64
+	Carb     float64
+	CalLimit float64
+	Foods    []storage.Food
+	Bundles  []storage.Bundle
+}
+
+func (r *Handler) JournalPage(c *gin.Context) {
+	ts, err := r.parseDate(c.Query("date"), r.today())
+	if err != nil {
+		r.redirect(c, "/journal", nil, m.MsgErrInvalidArg)
+		return
+	}
+
+	ctx, cancel := storageCtx()
+	defer cancel()
+
+	page := journalPage{
+		Date:     ts.Format(_dateInputFormat),
+		PrevDate: ts.AddDate(0, 0, -1).Format(_dateInputFormat),
+		NextDate: ts.AddDate(0, 0, 1).Format(_dateInputFormat),
+	}
+
+	for i := range _mealCount {
+		page.AllMeals = append(page.AllMeals, storage.Meal(i))
+	}
+
+	lst, err := r.stg.GetJournalReport(ctx, r.userID, storage.NewTimestamp(ts), storage.NewTimestamp(storage.DayEnd(ts)))
+	if err != nil && !errors.Is(err, storage.ErrEmptyResult) {
+		c.String(http.StatusInternalServerError, r.storageErr("journal", err))
+		return
+	}
+
+	// Keep meals grouped, when records have time of day
+	slices.SortStableFunc(lst, func(a, b storage.JournalReport) int { return int(a.Meal - b.Meal) })
+
+	for _, item := range lst {
+		if len(page.Meals) == 0 || page.Meals[len(page.Meals)-1].Meal != item.Meal {
+			page.Meals = append(page.Meals, journalMeal{Meal: item.Meal})
+		}
+
+		meal := &page.Meals[len(page.Meals)-1]
+		meal.Items = append(meal.Items, item)
+		meal.Cal += item.Cal
+		meal.Prot += item.Prot
+		meal.Fat += item.Fat
+		meal.Carb += item.Carb
+
+		page.Cal += item.Cal
+		page.Prot += item.Prot
+		page.Fat += item.Fat
+		page.Carb += item.Carb
+	}
+
+	us, err := r.stg.GetUserSettings(ctx, r.userID)
+	switch {
+	case err == nil:
+		page.CalLimit = us.CalLimit
+	case !errors.Is(err, storage.ErrUserSettingsNotFound):
+		c.String(http.StatusInternalServerError, r.storageErr("journal", err))
+		return
+	}
+
+	if page.Foods, err = r.stg.GetFoodList(ctx, r.userID); err != nil && !errors.Is(err, storage.ErrEmptyResult) {
+		c.String(http.StatusInternalServerError, r.storageErr("journal", err))
+		return
+	}
+
+	if page.Bundles, err = r.stg.GetBundleList(ctx, r.userID); err != nil && !errors.Is(err, storage.ErrEmptyResult) {
+		c.String(http.StatusInternalServerError, r.storageErr("journal", err))
+		return
+	}
+
+	r.renderPage(c, "journal.html", "Журнал", &page)
+}
+
+// JournalSet adds or updates journal item, amount is weight in grams,
+// volume or named portion of food.
+func (r *Handler) JournalSet(c *gin.Context) {
+	ts, meal, query, ok := r.journalForm(c)
+	if !ok {
+		r.redirect(c, "/journal", query, m.MsgErrInvalidArg)
+		return
+	}
+
+	ctx, cancel := storageCtx()
+	defer cancel()
+
+	// Bundle is added instead of food
+	if bndlKey := c.PostForm("bundle"); bndlKey != "" {
+		if err := r.stg.SetJournalBundle(ctx, r.userID, storage.NewTimestamp(ts), meal, bndlKey); err != nil {
+			r.redirect(c, "/journal", query, r.storageErr("journal", err))
+			return
+		}
+		r.redirect(c, "/journal", query, "")
+		return
+	}
+
+	amount, err := storage.ParseFoodAmount(c.PostForm("amount"))
+	if err != nil {
+		r.redirect(c, "/journal", query, m.MsgErrInvalidArg)
+		return
+	}
+
+	foodKey := c.PostForm("food")
+	food, err := r.stg.GetFood(ctx, r.userID, foodKey)
+	if err != nil {
+		r.redirect(c, "/journal", query, r.storageErr("journal", err))
+		return
+	}
+
+	foodWeight, err := food.Grams(amount)
+	if err != nil {
+		r.redirect(c, "/journal", query, r.storageErr("journal", err))
+		return
+	}
+
+	var portion string
+	if !amount.IsGrams() {
+		portion = amount.String()
+	}
+
+	if err := r.stg.SetJournal(ctx, r.userID, &storage.Journal{
+		Timestamp:  storage.NewTimestamp(ts),
+		Meal:       meal,
+		FoodKey:    foodKey,
+		FoodWeight: foodWeight,
+		Portion:    portion,
+	}); err != nil {
+		r.redirect(c, "/journal", query, r.storageErr("journal", err))
+		return
+	}
+
+	r.redirect(c, "/journal", query, "")
+}
+
+func (r *Handler) JournalDel(c *gin.Context) {
+	ts, meal, query, ok := r.journalForm(c)
+	if !ok {
+		r.redirect(c, "/journal", query, m.MsgErrInvalidArg)
+		return
+	}
+
+	ctx, cancel := storageCtx()
+	defer cancel()
+
+	if err := r.stg.DeleteJournal(ctx, r.userID, storage.NewTimestamp(ts), storage.NewTimestamp(ts), meal, c.PostForm("food")); err != nil {
+		r.redirect(c, "/journal", query, r.storageErr("journal", err))
+		return
+	}
+
+	r.redirect(c, "/journal", query, "")
+}
+
+// journalForm parses timestamp or date and meal of journal form, query
+// returns to page of date. Forms of records post their timestamp, add
+// form posts date.
+func (r *Handler) journalForm(c *gin.Context) (time.Time, storage.Meal, url.Values, bool) {
+	var ts time.Time
+	if val := c.PostForm("ts"); val != "" {
+		recTs, err := parseTimestamp(val)
+		if err != nil {
+			return time.Time{}, 0, nil, false
+		}
+		ts = recTs.ToTime(r.tz)
+	} else {
+		var err error
+		if ts, err = r.parseDate(c.PostForm("date"), r.today()); err != nil {
+			return time.Time{}, 0, nil, false
+		}
+	}
+	query := url.Values{"date": {ts.Format(_dateInputFormat)}}
+
+	meal, err := strconv.Atoi(c.PostForm("meal"))
+	if err != nil || meal < 0 || meal >= _mealCount {
+		return time.Time{}, 0, query, false
+	}
+
+	return ts, storage.Meal(meal), query, true
+}
@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/devldavydov/myhealth/internal/common/html"
+	m "github.com/devldavydov/myhealth/internal/common/messages"
+	"github.com/devldavydov/myhealth/internal/storage"
+	"github.com/gin-gonic/gin"
+)
+
+var _medicineChartColors = []string{
+	html.ChartColorRed,
+	html.ChartColorBlue,
+	html.ChartColorGreen,
+	html.ChartColorPurple,
+	html.ChartColorOrange,
+}
+
+type medicineIndicatorView struct {
+	MedicineKey string
+	Timestamp   storage.Timestamp
+	Value       float64
+}
+
+type medicineView struct {
+	Key        string
+	Name       string
+	Indicators []medicineIndicatorView
+	Chart      template.HTML
+}
+
+type medicinePage struct {
+	From      string
+	To        string
+	Today     string
+	Medicines []medicineView
+	All       []storage.Medicine
+}
+
+func (r *Handler) MedicinePage(c *gin.Context) {
+	from, to, err := r.parsePeriod(c)
+	if err != nil {
+		r.redirect(c, "/medicine", nil, m.MsgErrInvalidArg)
+		return
+	}
+
+	ctx, cancel := storageCtx()
+	defer cancel()
+
+	page := medicinePage{
+		From:  from.Format(_dateInputFormat),
+		To:    to.Format(_dateInputFormat),
+		Today: r.today().Format(_dateInputFormat),
+	}
+
+	if page.All, err = r.stg.GetMedicineList(ctx, r.userID); err != nil && !errors.Is(err, storage.ErrEmptyResult) {
+		c.String(http.StatusInternalServerError, r.storageErr("medicine", err))
+		return
+	}
+
+	lst, err := r.stg.GetMedicineIndicatorReport(ctx, r.userID, storage.NewTimestamp(from), storage.NewTimestamp(storage.DayEnd(to)))
+	if err != nil && !errors.Is(err, storage.ErrEmptyResult) {
+		c.String(http.StatusInternalServerError, r.storageErr("medicine", err))
+		return
+	}
+
+	// Report has medicine name with unit, key is needed to delete indicator
+	keys := make(map[string]string, len(page.All))
+	for _, med := range page.All {
+		keys[fmt.Sprintf("%s [%s]", med.Name, med.Unit)] = med.Key
+	}
+
+	for _, mi := range lst {
+		i := slices.IndexFunc(page.Medicines, func(v medicineView) bool { return v.Name == mi.MedicineName })
+		if i == -1 {
+			page.Medicines = append(page.Medicines, medicineView{Key: keys[mi.MedicineName], Name: mi.MedicineName})
+			i = len(page.Medicines) - 1
+		}
+
+		page.Medicines[i].Indicators = append(page.Medicines[i].Indicators, medicineIndicatorView{
+			MedicineKey: page.Medicines[i].Key,
+			Timestamp:   mi.Timestamp,
+			Value:       mi.Value,
+		})
+	}
+
+	slices.SortFunc(page.Medicines, func(a, b medicineView) int { return strings.Compare(a.Name, b.Name) })
+
+	for i := range page.Medicines {
+		med := &page.Medicines[i]
+		labels := make([]string, 0, len(med.Indicators))
+		values := make([]float64, 0, len(med.Indicators))
+		for _, mi := range med.Indicators {
+			labels = append(labels, mi.Timestamp.ToTime(r.tz).Format(_dateFormat))
+			values = append(values, mi.Value)
+		}
+		med.Chart = r.chart("medicine", &html.ChartData{
+			XLabels: labels,
+			Type:    "line",
+			Datasets: []html.ChartDataset{
+				{Data: values, Label: med.Name, Color: _medicineChartColors[i%len(_medicineChartColors)]},
+			},
+		})
+		slices.Reverse(med.Indicators)
+	}
+
+	r.renderPage(c, "medicine.html", "Медицина", &page)
+}
+
+func (r *Handler) MedicineIndicatorSet(c *gin.Context) {
+	ts, err := r.parseDate(c.PostForm("date"), r.today())
+	if err != nil {
+		r.redirect(c, "/medicine", nil, m.MsgErrInvalidArg)
+		return
+	}
+
+	val, err := parseNum(c.PostForm("value"))
+	if err != nil {
+		r.redirect(c, "/medicine", nil, m.MsgErrInvalidArg)
+		return
+	}
+
+	ctx, cancel := storageCtx()
+	defer cancel()
+
+	if err := r.stg.SetMedicineIndicator(ctx, r.userID, &storage.MedicineIndicator{
+		MedicineKey: c.PostForm("key"),
+		Timestamp:   storage.NewTimestamp(ts),
+		Value:       val,
+	}); err != nil {
+		r.redirect(c, "/medicine", nil, r.storageErr("medicine", err))
+		return
+	}
+
+	r.redirect(c, "/medicine", nil, "")
+}
+
+func (r *Handler) MedicineIndicatorDel(c *gin.Context) {
+	ts, err := parseTimestamp(c.PostForm("ts"))
+	if err != nil {
+		r.redirect(c, "/medicine", nil, m.MsgErrInvalidArg)
+		return
+	}
+
+	ctx, cancel := storageCtx()
+	defer cancel()
+
+	if err := r.stg.DeleteMedicineIndicator(ctx, r.userID, ts, ts, c.PostForm("key")); err != nil {
+		r.redirect(c, "/medicine", nil, r.storageErr("medicine", err))
+		return
+	}
+
+	r.redirect(c, "/medicine", nil, "")
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	m "github.com/devldavydov/myhealth/internal/common/messages"
+	"github.com/devldavydov/myhealth/internal/storage"
+	"github.com/gin-gonic/gin"
+)
+
+type settingsPage struct {
+	Settings       *storage.UserSettings
+	NutrientLimits string
+	Nutrients      []storage.Nutrient
+}
+
+func (r *Handler) SettingsPage(c *gin.Context) {
+	ctx, cancel := storageCtx()
+	defer cancel()
+
+	us, err := r.stg.GetUserSettings(ctx, r.userID)
+	if err != nil {
+		if !errors.Is(err, storage.ErrUserSettingsNotFound) {
+			c.String(http.StatusInternalServerError, r.storageErr("settings", err))
+			return
+		}
+		us = &storage.UserSettings{}
+	}
+
+	r.renderPage(c, "settings.html", "Настройки", &settingsPage{
+		Settings:       us,
+		NutrientLimits: us.NutrientLimits.String(),
+		Nutrients:      storage.AllNutrients,
+	})
+}
+
+// SettingsSet updates user settings, profile is kept as is.
+func (r *Handler) SettingsSet(c *gin.Context) {
+	ctx, cancel := storageCtx()
+	defer cancel()
+
+	us, err := r.stg.GetUserSettings(ctx, r.userID)
+	if err != nil {
+		if !errors.Is(err, storage.ErrUserSettingsNotFound) {
+			r.redirect(c, "/settings", nil, r.storageErr("settings", err))
+			return
+		}
+		us = &storage.UserSettings{}
+	}
+
+	if us.CalLimit, err = parseNum(c.PostForm("cal_limit")); err != nil {
+		r.redirect(c, "/settings", nil, m.MsgErrInvalidArg)
+		return
+	}
+
+	us.WaterGoal = 0
+	if waterGoal := c.PostForm("water_goal"); waterGoal != "" {
+		if us.WaterGoal, err = parseNum(waterGoal); err != nil {
+			r.redirect(c, "/settings", nil, m.MsgErrInvalidArg)
+			return
+		}
+	}
+
+	limits, err := storage.ParseNutrients(c.PostForm("nutrient_limits"))
+	if err != nil {
+		r.redirect(c, "/settings", nil, m.MsgErrInvalidArg)
+		return
+	}
+	// Zero limit removes nutrient
+	us.NutrientLimits = storage.Nutrients{}.Merge(limits)
+
+	us.ReportFormat = storage.ReportFormat(c.PostForm("report_format"))
+	if !us.ReportFormat.Validate() {
+		r.redirect(c, "/settings", nil, m.MsgErrInvalidArg)
+		return
+	}
+
+	us.TDEEAutoUpdate = c.PostForm("tdee_auto_update") != ""
+	us.WaterReminder = c.PostForm("water_reminder") != ""
+
+	if err := r.stg.SetUserSettings(ctx, r.userID, us); err != nil {
+		r.redirect(c, "/settings", nil, r.storageErr("settings", err))
+		return
+	}
+
+	r.redirect(c, "/settings", nil, "")
+}
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"errors"
+	"html/template"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/devldavydov/myhealth/internal/common/html"
+	m "github.com/devldavydov/myhealth/internal/common/messages"
+	"github.com/devldavydov/myhealth/internal/storage"
+	"github.com/gin-gonic/gin"
+)
+
+type sportActivityView struct {
+	ID        int64
+	Timestamp storage.Timestamp
+	SportName string
+	Sets      string
+	Duration  float64
+	Cal       float64
+	Comment   string
+}
+
+type sportTotal struct {
+	SportName string
+	Count     int
+	Cal       float64
+}
+
+type sportPage struct {
+	From       string
+	To         string
+	Activities []sportActivityView
+	Totals     []sportTotal
+	Cal        float64
+	Chart      template.HTML
+}
+
+func (r *Handler) SportPage(c *gin.Context) {
+	from, to, err := r.parsePeriod(c)
+	if err != nil {
+		r.redirect(c, "/sport", nil, m.MsgErrInvalidArg)
+		return
+	}
+
+	ctx, cancel := storageCtx()
+	defer cancel()
+
+	lst, err := r.stg.GetSportActivityReport(ctx, r.userID, storage.NewTimestamp(from), storage.NewTimestamp(storage.DayEnd(to)))
+	if err != nil && !errors.Is(err, storage.ErrEmptyResult) {
+		c.String(http.StatusInternalServerError, r.storageErr("sport", err))
+		return
+	}
+
+	page := sportPage{
+		From: from.Format(_dateInputFormat),
+		To:   to.Format(_dateInputFormat),
+	}
+
+	// Burned calories by days of period
+	days := make([]float64, int(to.Sub(from).Hours()/24)+1)
+	totals := make(map[string]*sportTotal)
+	for _, sa := range lst {
+		sets := make([]string, 0, len(sa.Sets))
+		for _, s := range sa.Sets {
+			sets = append(sets, s.String())
+		}
+
+		page.Activities = append(page.Activities, sportActivityView{
+			ID:        sa.ID,
+			Timestamp: sa.Timestamp,
+			SportName: sa.SportName,
+			Sets:      strings.Join(sets, ","),
+			Duration:  sa.Duration,
+			Cal:       sa.Cal,
+			Comment:   sa.Comment,
+		})
+
+		t, ok := totals[sa.SportName]
+		if !ok {
+			t = &sportTotal{SportName: sa.SportName}
+			totals[sa.SportName] = t
+		}
+		t.Count++
+		t.Cal += sa.Cal
+		page.Cal += sa.Cal
+
+		if day := int(sa.Timestamp.ToTime(r.tz).Sub(from).Hours() / 24); day >= 0 && day < len(days) {
+			days[day] += sa.Cal
+		}
+	}
+
+	for _, t := range totals {
+		page.Totals = append(page.Totals, *t)
+	}
+	slices.SortFunc(page.Totals, func(a, b sportTotal) int { return strings.Compare(a.SportName, b.SportName) })
+
+	if len(lst) != 0 {
+		labels := make([]string, 0, len(days))
+		for i := range days {
+			labels = append(labels, from.AddDate(0, 0, i).Format(_dateFormat))
+		}
+		page.Chart = r.chart("sport", &html.ChartData{
+			XLabels:  labels,
+			Type:     "bar",
+			Datasets: []html.ChartDataset{{Data: days, Label: "Ккал", Color: html.ChartColorOrange}},
+		})
+	}
+
+	// Table shows last activity first
+	slices.Reverse(page.Activities)
+
+	r.renderPage(c, "sport.html", "Спорт", &page)
+}
+
+func (r *Handler) SportActivityDel(c *gin.Context) {
+	id, err := strconv.ParseInt(c.PostForm("id"), 10, 64)
+	if err != nil {
+		r.redirect(c, "/sport", nil, m.MsgErrInvalidArg)
+		return
+	}
+
+	ctx, cancel := storageCtx()
+	defer cancel()
+
+	if err := r.stg.DeleteSportActivityByID(ctx, r.userID, id); err != nil {
+		r.redirect(c, "/sport", nil, r.storageErr("sport", err))
+		return
+	}
+
+	r.redirect(c, "/sport", nil, "")
+}
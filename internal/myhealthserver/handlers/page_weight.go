@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"errors"
+	"html/template"
+	"net/http"
+	"slices"
+
+	"github.com/devldavydov/myhealth/internal/common/html"
+	m "github.com/devldavydov/myhealth/internal/common/messages"
+	"github.com/devldavydov/myhealth/internal/storage"
+	"github.com/gin-gonic/gin"
+)
+
+type weightPage struct {
+	From    string
+	To      string
+	Today   string
+	Weights []storage.Weight
+	Chart   template.HTML
+	Min     float64
+	Max     float64
+	Diff    float64
+}
+
+func (r *Handler) WeightPage(c *gin.Context) {
+	from, to, err := r.parsePeriod(c)
+	if err != nil {
+		r.redirect(c, "/weight", nil, m.MsgErrInvalidArg)
+		return
+	}
+
+	ctx, cancel := storageCtx()
+	defer cancel()
+
+	lst, err := r.stg.GetWeightList(ctx, r.userID, storage.NewTimestamp(from), storage.NewTimestamp(storage.DayEnd(to)), false)
+	if err != nil && !errors.Is(err, storage.ErrEmptyResult) {
+		c.String(http.StatusInternalServerError, r.storageErr("weight", err))
+		return
+	}
+
+	page := weightPage{
+		From:  from.Format(_dateInputFormat),
+		To:    to.Format(_dateInputFormat),
+		Today: r.today().Format(_dateInputFormat),
+	}
+
+	if len(lst) != 0 {
+		labels := make([]string, 0, len(lst))
+		values := make([]float64, 0, len(lst))
+		page.Min, page.Max = lst[0].Value, lst[0].Value
+		for _, w := range lst {
+			labels = append(labels, w.Timestamp.ToTime(r.tz).Format(_dateFormat))
+			values = append(values, w.Value)
+			page.Min, page.Max = min(page.Min, w.Value), max(page.Max, w.Value)
+		}
+		page.Diff = lst[len(lst)-1].Value - lst[0].Value
+		page.Chart = r.chart("weight", &html.ChartData{
+			XLabels:  labels,
+			Type:     "line",
+			Datasets: []html.ChartDataset{{Data: values, Label: "Вес", Color: html.ChartColorBlue}},
+		})
+	}
+
+	// Table shows last weight first
+	page.Weights = slices.Clone(lst)
+	slices.Reverse(page.Weights)
+
+	r.renderPage(c, "weight.html", "Вес", &page)
+}
+
+func (r *Handler) WeightSet(c *gin.Context) {
+	ts, err := r.parseDate(c.PostForm("date"), r.today())
+	if err != nil {
+		r.redirect(c, "/weight", nil, m.MsgErrInvalidArg)
+		return
+	}
+
+	val, err := parseNum(c.PostForm("value"))
+	if err != nil {
+		r.redirect(c, "/weight", nil, m.MsgErrInvalidArg)
+		return
+	}
+
+	ctx, cancel := storageCtx()
+	defer cancel()
+
+	if err := r.stg.SetWeight(ctx, r.userID, &storage.Weight{Timestamp: storage.NewTimestamp(ts), Value: val}); err != nil {
+		r.redirect(c, "/weight", nil, r.storageErr("weight", err))
+		return
+	}
+
+	r.redirect(c, "/weight", nil, "")
+}
+
+func (r *Handler) WeightDel(c *gin.Context) {
+	ts, err := parseTimestamp(c.PostForm("ts"))
+	if err != nil {
+		r.redirect(c, "/weight", nil, m.MsgErrInvalidArg)
+		return
+	}
+
+	ctx, cancel := storageCtx()
+	defer cancel()
+
+	if err := r.stg.DeleteWeight(ctx, r.userID, ts, ts); err != nil {
+		r.redirect(c, "/weight", nil, r.storageErr("weight", err))
+		return
+	}
+
+	r.redirect(c, "/weight", nil, "")
+}
@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/devldavydov/myhealth/internal/common/html"
+	m "github.com/devldavydov/myhealth/internal/common/messages"
+	"github.com/devldavydov/myhealth/internal/storage"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	// Format of html date input
+	_dateInputFormat = "2006-01-02"
+	_dateFormat      = "02.01.2006"
+	// Default period of dashboards
+	_dashboardDays = 30
+	// Size of page charts
+	_chartWidth  = 640
+	_chartHeight = 240
+)
+
+// pageData is common data of web UI page.
+type pageData struct {
+	Title string
+	Path  string
+	Error string
+	Data  any
+}
+
+// storageErrMsgs maps storage errors to messages shown on pages.
+var storageErrMsgs = []struct {
+	err error
+	msg string
+}{
+	{storage.ErrEmptyResult, m.MsgErrEmptyResult},
+	{storage.ErrFoodNotFound, m.MsgErrFoodNotFound},
+	{storage.ErrFoodInvalid, m.MsgErrFoodInvalid},
+	{storage.ErrFoodIsUsed, m.MsgErrFoodIsUsed},
+	{storage.ErrFoodPortionNotFound, m.MsgErrFoodPortionNotFound},
+	{storage.ErrBundleNotFound, m.MsgErrBundleNotFound},
+	{storage.ErrBundleDepFoodNotFound, m.MsgErrBundleDepFoodNotFound},
+	{storage.ErrBundleDepBundleNotFound, m.MsgErrBundleDepBundleNotFound},
+	{storage.ErrBundleDepRecursive, m.MsgErrBundleDepBundleRecursive},
+	{storage.ErrBundleIsUsed, m.MsgErrBundleIsUsed},
+	{storage.ErrMedicineNotFound, m.MsgErrMedicineNotFound},
+	{storage.ErrSportActivityNotFound, m.MsgErrSportActivityNotFound},
+	{storage.ErrWeightNotFound, m.MsgErrWeightNotFound},
+	{storage.ErrBundleInvalid, m.MsgErrInvalidArg},
+	{storage.ErrJournalInvalid, m.MsgErrInvalidArg},
+	{storage.ErrWeightInvalid, m.MsgErrInvalidArg},
+	{storage.ErrMedicineIndicatorInvalid, m.MsgErrInvalidArg},
+	{storage.ErrUserSettingsInvalid, m.MsgErrInvalidArg},
+}
+
+// TemplateFuncs returns functions used by page templates.
+func TemplateFuncs(tz *time.Location) template.FuncMap {
+	return template.FuncMap{
+		"num":   formatNum,
+		"round": formatRound,
+		"date": func(ts storage.Timestamp) string {
+			return ts.ToTime(tz).Format(_dateFormat)
+		},
+		"meal": func(meal storage.Meal) string {
+			return meal.MustToString()
+		},
+	}
+}
+
+func (r *Handler) renderPage(c *gin.Context, tmpl, title string, data any) {
+	c.HTML(http.StatusOK, tmpl, &pageData{
+		Title: title,
+		Path:  c.Request.URL.Path,
+		Error: c.Query("err"),
+		Data:  data,
+	})
+}
+
+// redirect returns to page after form submit, error is shown on page.
+func (r *Handler) redirect(c *gin.Context, path string, query url.Values, errMsg string) {
+	if query == nil {
+		query = url.Values{}
+	}
+	if errMsg != "" {
+		query.Set("err", errMsg)
+	}
+
+	if len(query) != 0 {
+		path += "?" + query.Encode()
+	}
+	c.Redirect(http.StatusSeeOther, path)
+}
+
+// storageErr returns message of storage error, unknown errors are logged.
+func (r *Handler) storageErr(op string, err error) string {
+	for _, e := range storageErrMsgs {
+		if errors.Is(err, e.err) {
+			return e.msg
+		}
+	}
+
+	r.logger.Error(
+		op+" page DB error",
+		zap.Int64("userID", r.userID),
+		zap.Error(err),
+	)
+
+	return m.MsgErrInternal
+}
+
+// chart renders chart of page as inline svg, so charts of pages need no
+// scripts. Page is shown without chart on render error.
+func (r *Handler) chart(op string, data *html.ChartData) template.HTML {
+	img, err := html.RenderChart(data, html.ChartImageSVG, _chartWidth, _chartHeight)
+	if err != nil {
+		r.logger.Error(
+			op+" page chart render error",
+			zap.Int64("userID", r.userID),
+			zap.Error(err),
+		)
+		return ""
+	}
+
+	return template.HTML(img)
+}
+
+func storageCtx() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), storage.StorageOperationTimeout)
+}
+
+func (r *Handler) today() time.Time {
+	now := time.Now().In(r.tz)
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, r.tz)
+}
+
+// parseDate parses date of html date input, empty value is default.
+func (r *Handler) parseDate(val string, def time.Time) (time.Time, error) {
+	if val == "" {
+		return def, nil
+	}
+	return time.ParseInLocation(_dateInputFormat, val, r.tz)
+}
+
+// parsePeriod parses from and to query of dashboard, default period is
+// last days up to today.
+func (r *Handler) parsePeriod(c *gin.Context) (time.Time, time.Time, error) {
+	to, err := r.parseDate(c.Query("to"), r.today())
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	from, err := r.parseDate(c.Query("from"), to.AddDate(0, 0, -_dashboardDays))
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	return from, to, nil
+}
+
+// parseTimestamp parses timestamp of record in hidden form field.
+func parseTimestamp(val string) (storage.Timestamp, error) {
+	ts, err := strconv.ParseInt(val, 10, 64)
+	return storage.Timestamp(ts), err
+}
+
+// parseNum parses number of form field, comma is allowed as decimal
+// separator.
+func parseNum(val string) (float64, error) {
+	return strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(val), ",", "."), 64)
+}
+
+func formatNum(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func formatRound(v float64) string {
+	return strconv.FormatFloat(v, 'f', 1, 64)
+}
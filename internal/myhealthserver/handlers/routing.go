@@ -1,15 +1,50 @@
 package handlers
 
 import (
+	"time"
+
 	"github.com/devldavydov/myhealth/internal/cmdproc"
+	"github.com/devldavydov/myhealth/internal/storage"
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
 )
 
-func Init(router *gin.Engine, cmdProceccor *cmdproc.CmdProcessor, fileStoragePath string, userID int64) {
-	handler := NewHandler(cmdProceccor, fileStoragePath, userID)
+func Init(
+	router *gin.Engine,
+	cmdProceccor *cmdproc.CmdProcessor,
+	stg storage.Storage,
+	tz *time.Location,
+	fileStoragePath string,
+	userID int64,
+	logger *zap.Logger,
+) {
+	handler := NewHandler(cmdProceccor, stg, tz, fileStoragePath, userID, logger)
 
 	router.GET("/", handler.Index)
 	router.GET("/file", handler.File)
 	router.POST("/api", handler.Api)
+	router.GET("/api/complete", handler.Complete)
+
+	// Pages
+	router.GET("/journal", handler.JournalPage)
+	router.POST("/journal/set", handler.JournalSet)
+	router.POST("/journal/del", handler.JournalDel)
+	router.GET("/food", handler.FoodPage)
+	router.POST("/food/set", handler.FoodSet)
+	router.POST("/food/del", handler.FoodDel)
+	router.GET("/bundle", handler.BundlePage)
+	router.POST("/bundle/set", handler.BundleSet)
+	router.POST("/bundle/del", handler.BundleDel)
+	router.GET("/weight", handler.WeightPage)
+	router.POST("/weight/set", handler.WeightSet)
+	router.POST("/weight/del", handler.WeightDel)
+	router.GET("/sport", handler.SportPage)
+	router.POST("/sport/activity/del", handler.SportActivityDel)
+	router.GET("/medicine", handler.MedicinePage)
+	router.POST("/medicine/indicator/set", handler.MedicineIndicatorSet)
+	router.POST("/medicine/indicator/del", handler.MedicineIndicatorDel)
+	router.GET("/settings", handler.SettingsPage)
+	router.POST("/settings/set", handler.SettingsSet)
+
 	router.NoRoute(handler.NotFound)
 }
@@ -28,6 +28,15 @@ func (t *TypeAdapter) File(buf *bytes.Buffer, mime string, fileName string) any
 	}
 }
 
+// Photo is PNG file, web UI shows images inline, caption is not shown.
+func (t *TypeAdapter) Photo(buf *bytes.Buffer, caption string) any {
+	return &FileType{
+		Buffer: buf,
+		Mime:   "image/png",
+		Name:   "chart.png",
+	}
+}
+
 func (t *TypeAdapter) OptsHTML() any {
 	return ""
 }
@@ -10,14 +10,17 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/devldavydov/myhealth/internal/cmdproc"
+	"github.com/devldavydov/myhealth/internal/common/html"
 	"github.com/devldavydov/myhealth/internal/myhealthserver/handlers"
 	p "github.com/devldavydov/myhealth/internal/myhealthserver/process"
+	"github.com/devldavydov/myhealth/internal/myhealthserver/static"
 	"github.com/devldavydov/myhealth/internal/storage"
-	slite "github.com/devldavydov/myhealth/internal/storage/sqlite"
+	"github.com/devldavydov/myhealth/internal/storage/open"
 	"go.uber.org/zap"
 
 	"github.com/gin-contrib/gzip"
@@ -32,11 +35,16 @@ type Service struct {
 	wg           sync.WaitGroup
 }
 
-//go:embed templates/* static/*
+//go:embed templates/*
 var embedFS embed.FS
 
 func NewService(settings *ServerSettings, logger *zap.Logger) (*Service, error) {
-	stg, err := slite.NewStorageSQLite(settings.DBFilePath, logger)
+	assets, err := html.NewAssets(settings.ReportAssets, settings.ReportAssetsURL)
+	if err != nil {
+		return nil, err
+	}
+
+	stg, err := open.NewStorage(settings.DBFilePath, settings.DBDSN, logger)
 	if err != nil {
 		return nil, err
 	}
@@ -47,6 +55,7 @@ func NewService(settings *ServerSettings, logger *zap.Logger) (*Service, error)
 			stg,
 			p.NewTypeAdapter(),
 			settings.TZ,
+			assets,
 			settings.DebugMode,
 			logger),
 		stg:    stg,
@@ -63,18 +72,23 @@ func (r *Service) Run(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	router.SetFuncMap(handlers.TemplateFuncs(r.settings.TZ))
 	router.LoadHTMLFS(http.FS(embedFS), files...)
 
-	staticFS, err := fs.Sub(embedFS, "static")
-	if err != nil {
-		return err
-	}
-	router.StaticFS("/static", http.FS(staticFS))
+	router.StaticFS("/static", http.FS(staticFS{static: static.FS, report: html.AssetsFS()}))
 
-	handlers.Init(router, r.cmdProceccor, r.settings.FileStoragePath, r.settings.UserID)
+	handlers.Init(
+		router,
+		r.cmdProceccor,
+		r.stg,
+		r.settings.TZ,
+		r.settings.FileStoragePath,
+		r.settings.UserID,
+		r.logger)
 
-	r.wg.Add(1)
+	r.wg.Add(2)
 	go r.filesCleanJob(ctx)
+	go r.tdeeAutoUpdateJob(ctx)
 
 	// Start server
 	httpServer := &http.Server{
@@ -164,6 +178,40 @@ func (r *Service) filesCleanJob(ctx context.Context) {
 	}
 }
 
+func (r *Service) tdeeAutoUpdateJob(ctx context.Context) {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(1 * time.Hour)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.logger.Info("tdee auto update job context canceled")
+			return
+		case <-ticker.C:
+			r.cmdProceccor.TDEEAutoUpdate(r.settings.UserID)
+		}
+	}
+}
+
+// staticFS serves report assets under static/report, single StaticFS is
+// used because router does not allow nested catch-all routes.
+type staticFS struct {
+	static fs.FS
+	report fs.FS
+}
+
+func (r staticFS) Open(name string) (fs.File, error) {
+	if name == "report" {
+		return r.report.Open(".")
+	}
+	if rest, ok := strings.CutPrefix(name, "report/"); ok {
+		return r.report.Open(rest)
+	}
+	return r.static.Open(name)
+}
+
 func loadTemplates(root string) (files []string, err error) {
 	err = fs.WalkDir(embedFS, root, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
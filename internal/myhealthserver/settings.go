@@ -3,11 +3,17 @@ package myhealthserver
 import (
 	"net/url"
 	"time"
+
+	"github.com/devldavydov/myhealth/internal/common/html"
 )
 
+// _defaultReportAssetsURL is served by service itself, see staticFS.
+const _defaultReportAssetsURL = "/static/report"
+
 type ServerSettings struct {
 	RunAddress      *url.URL
 	DBFilePath      string
+	DBDSN           string
 	ShutdownTimeout time.Duration
 	UserID          int64
 	TLSCertFile     string
@@ -15,11 +21,14 @@ type ServerSettings struct {
 	TZ              *time.Location
 	FileStoragePath string
 	DebugMode       bool
+	ReportAssets    html.AssetsMode
+	ReportAssetsURL string
 }
 
 func NewServerSettings(
 	runAddress string,
 	dbFilePath string,
+	dbDSN string,
 	shutdownTimeout time.Duration,
 	userID int64,
 	tlsCertFile string,
@@ -27,6 +36,8 @@ func NewServerSettings(
 	stz string,
 	fileStoragePath string,
 	debugMode bool,
+	reportAssets string,
+	reportAssetsURL string,
 ) (*ServerSettings, error) {
 
 	urlRunAddress, err := url.ParseRequestURI(runAddress)
@@ -39,9 +50,19 @@ func NewServerSettings(
 		return nil, err
 	}
 
+	assetsMode, err := html.ParseAssetsMode(reportAssets)
+	if err != nil {
+		return nil, err
+	}
+
+	if reportAssetsURL == "" {
+		reportAssetsURL = _defaultReportAssetsURL
+	}
+
 	return &ServerSettings{
 		RunAddress:      urlRunAddress,
 		DBFilePath:      dbFilePath,
+		DBDSN:           dbDSN,
 		ShutdownTimeout: shutdownTimeout,
 		UserID:          userID,
 		TLSCertFile:     tlsCertFile,
@@ -49,5 +70,7 @@ func NewServerSettings(
 		TZ:              tz,
 		FileStoragePath: fileStoragePath,
 		DebugMode:       debugMode,
+		ReportAssets:    assetsMode,
+		ReportAssetsURL: reportAssetsURL,
 	}, nil
 }
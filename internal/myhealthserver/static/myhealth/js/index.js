@@ -17,6 +17,29 @@ $( document ).ready(function() {
     
         $('#messageInput').val('');
 
+        sendCommand(cmd);
+    });
+
+    // Script file is sent as batch of commands, one per line
+    $('#scriptBtn').click(function() {
+        $('#scriptInput').click();
+    });
+
+    $('#scriptInput').on('change', function() {
+        let file = this.files[0];
+        $(this).val('');
+        if (!file)
+            return;
+
+        let reader = new FileReader();
+        reader.onload = function() {
+            addMessage('sent', file.name);
+            sendCommand(reader.result);
+        };
+        reader.readAsText(file);
+    });
+
+    function sendCommand(cmd) {
         $.ajax({
             type: "POST",
             url: "/api",
@@ -34,8 +57,13 @@ $( document ).ready(function() {
                         let fileUUID = encodeURIComponent(r.fileUUID);
                         let fileName = encodeURIComponent(r.fileName);
                         let fileMime = encodeURIComponent(r.fileMime);
-                        let link = `<a href="file?fileUUID=${fileUUID}&fileName=${fileName}&fileMime=${fileMime}" target="_blank">Скачать</a>`;
-                        
+                        let href = `file?fileUUID=${fileUUID}&fileName=${fileName}&fileMime=${fileMime}`;
+                        let link = `<a href="${href}" target="_blank">Скачать</a>`;
+                        if (r.fileMime.startsWith('image/')) {
+                            // image is shown inline
+                            link = `<img class="img-fluid" src="${href}"><br>${link}`;
+                        }
+
                         addMessage('received', link);
                     } else {
                         // text
@@ -49,13 +77,35 @@ $( document ).ready(function() {
         });
 
         $chat.scrollTop($chat.prop('scrollHeight'));
-    });
+    }
 
     $('#messageInput').on('keydown', function(e) {
         if (e.key === 'Enter') {
             $('#sendBtn').click();
+        } else if (e.key === 'Tab' && completions.length > 0) {
+            // Tab applies the first completion
+            e.preventDefault();
+            $(this).val(completions[0]).trigger('input');
         }
     });
+
+    // Completion of commands and keys by prefix
+    var completions = [];
+    var completeTimer = null;
+
+    $('#messageInput').on('input', function() {
+        let cmd = $(this).val();
+        clearTimeout(completeTimer);
+        completeTimer = setTimeout(function() {
+            $.getJSON('/api/complete', {'cmd': cmd}, function(items) {
+                completions = items || [];
+                let $list = $('#completeList').empty();
+                completions.forEach((item) => {
+                    $('<option>').attr('value', item).appendTo($list);
+                });
+            });
+        }, 200);
+    });
     
     addMessage('received', 'Привет! Введи команду....')
 });
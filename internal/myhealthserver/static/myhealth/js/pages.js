@@ -0,0 +1,21 @@
+$( document ).ready(function() {
+    // Bundle item is added to items text as food:weight
+    $('#bundleAddItem').click(function() {
+        let food = $('#bundleFood').val().trim();
+        let weight = $('#bundleWeight').val().trim();
+        if (!food || !weight)
+            return;
+
+        let $items = $('#bundleItems');
+        let items = $items.val().trim();
+        $items.val((items ? items + '\n' : '') + `${food}:${weight}`);
+
+        $('#bundleFood').val('');
+        $('#bundleWeight').val('');
+    });
+
+    // Delete asks for confirmation
+    $('form[action$="/del"]').on('submit', function() {
+        return confirm('Удалить?');
+    });
+});
@@ -0,0 +1,8 @@
+// Package static contains static files of web UI, bootstrap files are
+// also used as report assets.
+package static
+
+import "embed"
+
+//go:embed bootstrap jquery myhealth favicon.ico
+var FS embed.FS
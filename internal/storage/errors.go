@@ -6,6 +6,20 @@ var (
 	// Meal
 	ErrMealWrong = errors.New("wrong meal")
 
+	// ActivityLevel
+	ErrActivityLevelWrong = errors.New("wrong activity level")
+
+	// Goal
+	ErrGoalWrong = errors.New("wrong goal")
+
+	// Nutrient
+	ErrNutrientWrong = errors.New("wrong nutrient")
+
+	// Portion
+	ErrPortionUnitWrong    = errors.New("wrong portion unit")
+	ErrFoodPortionInvalid  = errors.New("invalid food portion")
+	ErrFoodPortionNotFound = errors.New("food portion not found")
+
 	// Weight
 	ErrWeightNotFound = errors.New("weight not found")
 	ErrWeightInvalid  = errors.New("invalid weight")
@@ -31,8 +45,20 @@ var (
 	ErrSportNotFound = errors.New("sport not found")
 	ErrSportIsUsed   = errors.New("sport is used")
 
+	// SportSetKind
+	ErrSportSetKindWrong = errors.New("wrong sport set kind")
+
 	// SportActivity
-	ErrSportActivityInvalid = errors.New("invalid sport activity")
+	ErrSportActivityInvalid  = errors.New("invalid sport activity")
+	ErrSportActivityNotFound = errors.New("sport activity not found")
+
+	// Workout
+	ErrWorkoutInvalid  = errors.New("invalid workout")
+	ErrWorkoutNotFound = errors.New("workout not found")
+	ErrWorkoutIsUsed   = errors.New("workout is used")
+
+	// WorkoutPlan
+	ErrWorkoutPlanDayInvalid = errors.New("invalid workout plan day")
 
 	// Medicine
 	ErrMedicineInvalid  = errors.New("invalid medicine")
@@ -46,6 +72,23 @@ var (
 	ErrUserSettingsNotFound = errors.New("user settings not found")
 	ErrUserSettingsInvalid  = errors.New("invalid user settings")
 
+	// TDEEEstimate
+	ErrTDEEEstimateInvalid  = errors.New("invalid tdee estimate")
+	ErrTDEEEstimateNotFound = errors.New("tdee estimate not found")
+
+	// Water
+	ErrWaterInvalid = errors.New("invalid water")
+
+	// Sleep
+	ErrSleepNotFound = errors.New("sleep not found")
+	ErrSleepInvalid  = errors.New("invalid sleep")
+
+	// Fast
+	ErrFastNotFound = errors.New("fast not found")
+	ErrFastInvalid  = errors.New("invalid fast")
+	ErrFastActive   = errors.New("fast already active")
+	ErrFastConflict = errors.New("fast overlaps existing fast")
+
 	// DayTotalCal
 	ErrDayTotalCalInvalid     = errors.New("invalid day total cal")
 	ErrTotalBurnedCalNotFound = errors.New("day total cal not found")
@@ -0,0 +1,167 @@
+// Package memory is in-memory storage, it keeps data only while process
+// is running and is used as storage fake in tests.
+package memory
+
+import (
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	s "github.com/devldavydov/myhealth/internal/storage"
+)
+
+type StorageMemory struct {
+	mu *sync.Mutex
+	db *database
+	tx bool
+}
+
+var _ s.Storage = (*StorageMemory)(nil)
+
+func NewStorageMemory() *StorageMemory {
+	return &StorageMemory{mu: &sync.Mutex{}, db: newDatabase()}
+}
+
+func (r *StorageMemory) Close() error {
+	return nil
+}
+
+// lock locks storage and returns unlock func, storage bound to
+// transaction is already locked by InTx.
+func (r *StorageMemory) lock() func() {
+	if r.tx {
+		return func() {}
+	}
+
+	r.mu.Lock()
+	return r.mu.Unlock
+}
+
+// database is set of tables, stored rows are never modified in place,
+// so tables are cloned cheap for transaction.
+type database struct {
+	weight            table[s.Timestamp, s.Weight]
+	food              table[string, s.Food]
+	bundle            table[string, s.Bundle]
+	journal           table[journalKey, s.Journal]
+	sport             table[string, s.Sport]
+	sportActivity     table[int64, s.SportActivity]
+	workout           table[string, s.Workout]
+	workoutPlan       table[time.Weekday, s.WorkoutPlanDay]
+	medicine          table[string, s.Medicine]
+	medicineIndicator table[medicineIndicatorKey, s.MedicineIndicator]
+	userSettings      table[struct{}, s.UserSettings]
+	totalBurnedCal    table[s.Timestamp, float64]
+	tdeeEstimate      table[s.Timestamp, s.TDEEEstimate]
+	water             table[s.Timestamp, s.Water]
+	sleep             table[s.Timestamp, s.Sleep]
+	fast              table[s.Timestamp, s.Fast]
+}
+
+type journalKey struct {
+	timestamp s.Timestamp
+	meal      s.Meal
+	foodKey   string
+}
+
+type medicineIndicatorKey struct {
+	timestamp   s.Timestamp
+	medicineKey string
+}
+
+func newDatabase() *database {
+	return &database{
+		weight:            table[s.Timestamp, s.Weight]{},
+		food:              table[string, s.Food]{},
+		bundle:            table[string, s.Bundle]{},
+		journal:           table[journalKey, s.Journal]{},
+		sport:             table[string, s.Sport]{},
+		sportActivity:     table[int64, s.SportActivity]{},
+		workout:           table[string, s.Workout]{},
+		workoutPlan:       table[time.Weekday, s.WorkoutPlanDay]{},
+		medicine:          table[string, s.Medicine]{},
+		medicineIndicator: table[medicineIndicatorKey, s.MedicineIndicator]{},
+		userSettings:      table[struct{}, s.UserSettings]{},
+		totalBurnedCal:    table[s.Timestamp, float64]{},
+		tdeeEstimate:      table[s.Timestamp, s.TDEEEstimate]{},
+		water:             table[s.Timestamp, s.Water]{},
+		sleep:             table[s.Timestamp, s.Sleep]{},
+		fast:              table[s.Timestamp, s.Fast]{},
+	}
+}
+
+func (r *database) clone() *database {
+	return &database{
+		weight:            r.weight.clone(),
+		food:              r.food.clone(),
+		bundle:            r.bundle.clone(),
+		journal:           r.journal.clone(),
+		sport:             r.sport.clone(),
+		sportActivity:     r.sportActivity.clone(),
+		workout:           r.workout.clone(),
+		workoutPlan:       r.workoutPlan.clone(),
+		medicine:          r.medicine.clone(),
+		medicineIndicator: r.medicineIndicator.clone(),
+		userSettings:      r.userSettings.clone(),
+		totalBurnedCal:    r.totalBurnedCal.clone(),
+		tdeeEstimate:      r.tdeeEstimate.clone(),
+		water:             r.water.clone(),
+		sleep:             r.sleep.clone(),
+		fast:              r.fast.clone(),
+	}
+}
+
+// table is rows of users by primary key.
+type table[K comparable, V any] map[int64]map[K]V
+
+func (r table[K, V]) get(userID int64, key K) (V, bool) {
+	v, ok := r[userID][key]
+	return v, ok
+}
+
+func (r table[K, V]) set(userID int64, key K, v V) {
+	rows, ok := r[userID]
+	if !ok {
+		rows = map[K]V{}
+		r[userID] = rows
+	}
+	rows[key] = v
+}
+
+func (r table[K, V]) del(userID int64, key K) {
+	delete(r[userID], key)
+}
+
+func (r table[K, V]) delFunc(userID int64, del func(v V) bool) {
+	maps.DeleteFunc(r[userID], func(_ K, v V) bool { return del(v) })
+}
+
+// list returns user rows matched by filter in order of cmp.
+func (r table[K, V]) list(userID int64, filter func(v V) bool, cmp func(a, b V) int) []V {
+	list := []V{}
+	for _, v := range r[userID] {
+		if filter == nil || filter(v) {
+			list = append(list, v)
+		}
+	}
+
+	slices.SortFunc(list, cmp)
+	return list
+}
+
+func (r table[K, V]) users() []int64 {
+	return slices.Sorted(maps.Keys(r))
+}
+
+func (r table[K, V]) clone() table[K, V] {
+	res := make(table[K, V], len(r))
+	for userID, rows := range r {
+		res[userID] = maps.Clone(rows)
+	}
+	return res
+}
+
+func inRange(ts, from, to s.Timestamp) bool {
+	return ts >= from && ts <= to
+}
@@ -0,0 +1,513 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+	"time"
+
+	s "github.com/devldavydov/myhealth/internal/storage"
+)
+
+func (r *StorageMemory) Backup(ctx context.Context) (*s.Backup, error) {
+	defer r.lock()()
+
+	return &s.Backup{
+		Timestamp: s.Timestamp(time.Now().UnixMilli()),
+		Weight: backupRows(r.db.weight,
+			func(a, b s.Weight) int { return cmp.Compare(a.Timestamp, b.Timestamp) },
+			func(userID int64, w s.Weight) s.WeightBackup {
+				return s.WeightBackup{UserID: userID, Timestamp: w.Timestamp, Value: w.Value}
+			},
+		),
+		Sport: backupRows(r.db.sport,
+			func(a, b s.Sport) int { return cmp.Compare(a.Key, b.Key) },
+			func(userID int64, sp s.Sport) s.SportBackup {
+				return s.SportBackup{
+					UserID:     userID,
+					Key:        sp.Key,
+					Name:       sp.Name,
+					Unit:       sp.Unit,
+					Comment:    sp.Comment,
+					MET:        sp.MET,
+					CalPerUnit: sp.CalPerUnit,
+					SetKind:    string(sp.SetKind),
+				}
+			},
+		),
+		SportActivity: backupRows(r.db.sportActivity,
+			func(a, b s.SportActivity) int {
+				return cmp.Or(cmp.Compare(a.Timestamp, b.Timestamp), cmp.Compare(a.ID, b.ID))
+			},
+			func(userID int64, sa s.SportActivity) s.SportActivityBackup {
+				return s.SportActivityBackup{
+					UserID:    userID,
+					SportKey:  sa.SportKey,
+					Timestamp: sa.Timestamp,
+					Sets:      sportSetsToBackup(sa.Sets),
+					Duration:  sa.Duration,
+					Cal:       sa.Cal,
+				}
+			},
+		),
+		UserSettings: backupRows(r.db.userSettings,
+			// Single row of user
+			func(a, b s.UserSettings) int { return 0 },
+			func(userID int64, us s.UserSettings) s.UserSettingsBackup {
+				usb := s.UserSettingsBackup{
+					UserID:         userID,
+					CalLimit:       us.CalLimit,
+					TDEEAutoUpdate: us.TDEEAutoUpdate,
+					WaterGoal:      us.WaterGoal,
+					WaterReminder:  us.WaterReminder,
+					NutrientLimits: nutrientsToBackup(us.NutrientLimits),
+					ReportFormat:   string(us.ReportFormat),
+				}
+				if p := us.Profile; p != nil {
+					usb.Profile = &s.UserProfileBackup{
+						Sex:           string(p.Sex),
+						BirthDate:     int64(p.BirthDate),
+						Height:        p.Height,
+						ActivityLevel: int64(p.ActivityLevel),
+						BodyFat:       p.BodyFat,
+						Goal:          string(p.Goal),
+						GoalRate:      p.GoalRate,
+					}
+				}
+				return usb
+			},
+		),
+		Food: backupRows(r.db.food,
+			func(a, b s.Food) int { return cmp.Compare(a.Key, b.Key) },
+			func(userID int64, f s.Food) s.FoodBackup {
+				return s.FoodBackup{
+					UserID:    userID,
+					Key:       f.Key,
+					Name:      f.Name,
+					Brand:     f.Brand,
+					Cal100:    f.Cal100,
+					Prot100:   f.Prot100,
+					Fat100:    f.Fat100,
+					Carb100:   f.Carb100,
+					Comment:   f.Comment,
+					Beverage:  f.Beverage,
+					Nutrients: nutrientsToBackup(f.Nutrients),
+					Portions:  foodPortionsToBackup(f.Portions),
+					Density:   f.Density,
+				}
+			},
+		),
+		Bundle: backupRows(r.db.bundle,
+			func(a, b s.Bundle) int { return cmp.Compare(a.Key, b.Key) },
+			func(userID int64, b s.Bundle) s.BundleBackup {
+				return s.BundleBackup{UserID: userID, Key: b.Key, Data: maps.Clone(b.Data)}
+			},
+		),
+		Journal: backupRows(r.db.journal,
+			func(a, b s.Journal) int {
+				return cmp.Or(
+					cmp.Compare(a.Timestamp, b.Timestamp),
+					cmp.Compare(a.Meal, b.Meal),
+					cmp.Compare(a.FoodKey, b.FoodKey),
+				)
+			},
+			func(userID int64, j s.Journal) s.JournalBackup {
+				return s.JournalBackup{
+					UserID:     userID,
+					Timestamp:  j.Timestamp,
+					Meal:       j.Meal,
+					FoodKey:    j.FoodKey,
+					FoodWeight: j.FoodWeight,
+					Portion:    j.Portion,
+				}
+			},
+		),
+		Medicine: backupRows(r.db.medicine,
+			func(a, b s.Medicine) int { return cmp.Compare(a.Key, b.Key) },
+			func(userID int64, m s.Medicine) s.MedicineBackup {
+				return s.MedicineBackup{UserID: userID, Key: m.Key, Name: m.Name, Unit: m.Unit, Comment: m.Comment}
+			},
+		),
+		MedicineIndicator: backupRows(r.db.medicineIndicator,
+			func(a, b s.MedicineIndicator) int {
+				return cmp.Or(cmp.Compare(a.Timestamp, b.Timestamp), cmp.Compare(a.MedicineKey, b.MedicineKey))
+			},
+			func(userID int64, mi s.MedicineIndicator) s.MedicineIndicatorBackup {
+				return s.MedicineIndicatorBackup{
+					UserID:      userID,
+					MedicineKey: mi.MedicineKey,
+					Timestamp:   mi.Timestamp,
+					Value:       mi.Value,
+				}
+			},
+		),
+		TotalBurnedCal: backupTimestampRows(r.db.totalBurnedCal,
+			func(userID int64, ts s.Timestamp, totalCal float64) s.TotalBurnedCalBackup {
+				return s.TotalBurnedCalBackup{UserID: userID, Timestamp: ts, TotalCal: totalCal}
+			},
+		),
+		TDEEEstimate: backupRows(r.db.tdeeEstimate,
+			func(a, b s.TDEEEstimate) int { return cmp.Compare(a.Timestamp, b.Timestamp) },
+			func(userID int64, est s.TDEEEstimate) s.TDEEEstimateBackup {
+				return s.TDEEEstimateBackup{
+					UserID:    userID,
+					Timestamp: est.Timestamp,
+					Weeks:     est.Weeks,
+					TDEE:      est.TDEE,
+					ConfLow:   est.ConfLow,
+					ConfHigh:  est.ConfHigh,
+					Applied:   est.Applied,
+				}
+			},
+		),
+		Workout: backupRows(r.db.workout,
+			func(a, b s.Workout) int { return cmp.Compare(a.Key, b.Key) },
+			func(userID int64, w s.Workout) s.WorkoutBackup {
+				items := make([]s.WorkoutItemBackup, 0, len(w.Items))
+				for _, item := range w.Items {
+					items = append(items, s.WorkoutItemBackup{SportKey: item.SportKey, Sets: sportSetsToBackup(item.Sets)})
+				}
+				return s.WorkoutBackup{UserID: userID, Key: w.Key, Name: w.Name, Items: items, Comment: w.Comment}
+			},
+		),
+		WorkoutPlan: backupRows(r.db.workoutPlan,
+			func(a, b s.WorkoutPlanDay) int { return cmp.Compare(a.Weekday, b.Weekday) },
+			func(userID int64, pd s.WorkoutPlanDay) s.WorkoutPlanBackup {
+				return s.WorkoutPlanBackup{UserID: userID, Weekday: int64(pd.Weekday), WorkoutKey: pd.WorkoutKey}
+			},
+		),
+		Water: backupRows(r.db.water,
+			func(a, b s.Water) int { return cmp.Compare(a.Timestamp, b.Timestamp) },
+			func(userID int64, w s.Water) s.WaterBackup {
+				return s.WaterBackup{UserID: userID, Timestamp: w.Timestamp, Volume: w.Volume}
+			},
+		),
+		Sleep: backupRows(r.db.sleep,
+			func(a, b s.Sleep) int { return cmp.Compare(a.Timestamp, b.Timestamp) },
+			func(userID int64, sl s.Sleep) s.SleepBackup {
+				return s.SleepBackup{
+					UserID:    userID,
+					Timestamp: sl.Timestamp,
+					BedTime:   sl.BedTime,
+					WakeTime:  sl.WakeTime,
+					Quality:   sl.Quality,
+					Notes:     sl.Notes,
+				}
+			},
+		),
+		Fast: backupRows(r.db.fast,
+			func(a, b s.Fast) int { return cmp.Compare(a.Start, b.Start) },
+			func(userID int64, f s.Fast) s.FastBackup {
+				return s.FastBackup{UserID: userID, Start: f.Start, End: f.End}
+			},
+		),
+	}, nil
+}
+
+// backupRows returns rows of all users ordered by user and rowCmp.
+func backupRows[K comparable, V, B any](t table[K, V], rowCmp func(a, b V) int, conv func(userID int64, v V) B) []B {
+	res := []B{}
+	for _, userID := range t.users() {
+		for _, v := range t.list(userID, nil, rowCmp) {
+			res = append(res, conv(userID, v))
+		}
+	}
+	return res
+}
+
+// backupTimestampRows returns rows of all users, that have no timestamp
+// in value, ordered by user and timestamp.
+func backupTimestampRows[V, B any](t table[s.Timestamp, V], conv func(userID int64, ts s.Timestamp, v V) B) []B {
+	res := []B{}
+	for _, userID := range t.users() {
+		rows := t[userID]
+		for _, ts := range slices.Sorted(maps.Keys(rows)) {
+			res = append(res, conv(userID, ts, rows[ts]))
+		}
+	}
+	return res
+}
+
+func (r *StorageMemory) Restore(ctx context.Context, backup *s.Backup) error {
+	return r.update(func(tx *StorageMemory) error {
+		for _, w := range backup.Weight {
+			if err := tx.SetWeight(
+				ctx,
+				w.UserID,
+				&s.Weight{Timestamp: w.Timestamp, Value: w.Value},
+			); err != nil {
+				return err
+			}
+		}
+
+		for _, sp := range backup.Sport {
+			// Backups before typed sets have no set kind
+			setKind := s.SportSetKind(sp.SetKind)
+			if setKind == "" {
+				setKind = s.SportSetKindValue
+			}
+
+			if err := tx.SetSport(
+				ctx,
+				sp.UserID,
+				&s.Sport{
+					Key:        sp.Key,
+					Name:       sp.Name,
+					Unit:       sp.Unit,
+					Comment:    sp.Comment,
+					MET:        sp.MET,
+					CalPerUnit: sp.CalPerUnit,
+					SetKind:    setKind,
+				},
+			); err != nil {
+				return err
+			}
+		}
+
+		for _, sa := range backup.SportActivity {
+			if err := tx.SetSportActivity(ctx, sa.UserID, &s.SportActivity{
+				SportKey:  sa.SportKey,
+				Timestamp: sa.Timestamp,
+				Sets:      sportSetsFromBackup(sa.Sets),
+				Duration:  sa.Duration,
+				Cal:       sa.Cal,
+			}); err != nil {
+				return err
+			}
+		}
+
+		for _, w := range backup.Workout {
+			items := make([]s.WorkoutItem, 0, len(w.Items))
+			for _, item := range w.Items {
+				items = append(items, s.WorkoutItem{SportKey: item.SportKey, Sets: sportSetsFromBackup(item.Sets)})
+			}
+
+			if err := tx.SetWorkout(ctx, w.UserID, &s.Workout{
+				Key:     w.Key,
+				Name:    w.Name,
+				Items:   items,
+				Comment: w.Comment,
+			}); err != nil {
+				return err
+			}
+		}
+
+		for _, wp := range backup.WorkoutPlan {
+			if err := tx.SetWorkoutPlanDay(ctx, wp.UserID, &s.WorkoutPlanDay{
+				Weekday:    time.Weekday(wp.Weekday),
+				WorkoutKey: wp.WorkoutKey,
+			}); err != nil {
+				return err
+			}
+		}
+
+		for _, m := range backup.Medicine {
+			if err := tx.SetMedicine(
+				ctx,
+				m.UserID,
+				&s.Medicine{Key: m.Key, Name: m.Name, Unit: m.Unit, Comment: m.Comment},
+			); err != nil {
+				return err
+			}
+		}
+
+		for _, m := range backup.MedicineIndicator {
+			if err := tx.SetMedicineIndicator(
+				ctx,
+				m.UserID,
+				&s.MedicineIndicator{
+					MedicineKey: m.MedicineKey,
+					Timestamp:   m.Timestamp,
+					Value:       m.Value,
+				},
+			); err != nil {
+				return err
+			}
+		}
+
+		for _, us := range backup.UserSettings {
+			var p *s.UserProfile
+			if us.Profile != nil {
+				p = &s.UserProfile{
+					Sex:           s.Sex(us.Profile.Sex),
+					BirthDate:     s.Timestamp(us.Profile.BirthDate),
+					Height:        us.Profile.Height,
+					ActivityLevel: s.ActivityLevel(us.Profile.ActivityLevel),
+					BodyFat:       us.Profile.BodyFat,
+					Goal:          s.Goal(us.Profile.Goal),
+					GoalRate:      us.Profile.GoalRate,
+				}
+			}
+
+			if err := tx.SetUserSettings(
+				ctx,
+				us.UserID,
+				&s.UserSettings{
+					CalLimit:       us.CalLimit,
+					TDEEAutoUpdate: us.TDEEAutoUpdate,
+					Profile:        p,
+					WaterGoal:      us.WaterGoal,
+					WaterReminder:  us.WaterReminder,
+					NutrientLimits: nutrientsFromBackup(us.NutrientLimits),
+					ReportFormat:   s.ReportFormat(us.ReportFormat),
+				},
+			); err != nil {
+				return err
+			}
+		}
+
+		for _, f := range backup.Food {
+			if err := tx.SetFood(
+				ctx,
+				f.UserID,
+				&s.Food{
+					Key:       f.Key,
+					Name:      f.Name,
+					Brand:     f.Brand,
+					Cal100:    f.Cal100,
+					Prot100:   f.Prot100,
+					Fat100:    f.Fat100,
+					Carb100:   f.Carb100,
+					Comment:   f.Comment,
+					Beverage:  f.Beverage,
+					Nutrients: nutrientsFromBackup(f.Nutrients),
+					Portions:  foodPortionsFromBackup(f.Portions),
+					Density:   f.Density,
+				},
+			); err != nil {
+				return err
+			}
+		}
+
+		for _, b := range backup.Bundle {
+			if err := tx.SetBundle(ctx, b.UserID, &s.Bundle{
+				Key:  b.Key,
+				Data: b.Data,
+			}, false); err != nil {
+				return err
+			}
+		}
+
+		for _, j := range backup.Journal {
+			if err := tx.SetJournal(ctx, j.UserID, &s.Journal{
+				Timestamp:  j.Timestamp,
+				Meal:       j.Meal,
+				FoodKey:    j.FoodKey,
+				FoodWeight: j.FoodWeight,
+				Portion:    j.Portion,
+			}); err != nil {
+				return err
+			}
+		}
+
+		for _, t := range backup.TotalBurnedCal {
+			if err := tx.SetTotalBurnedCal(ctx, t.UserID, t.Timestamp, t.TotalCal); err != nil {
+				return err
+			}
+		}
+
+		for _, t := range backup.TDEEEstimate {
+			if err := tx.SetTDEEEstimate(ctx, t.UserID, &s.TDEEEstimate{
+				Timestamp: t.Timestamp,
+				Weeks:     t.Weeks,
+				TDEE:      t.TDEE,
+				ConfLow:   t.ConfLow,
+				ConfHigh:  t.ConfHigh,
+				Applied:   t.Applied,
+			}); err != nil {
+				return err
+			}
+		}
+
+		for _, w := range backup.Water {
+			if err := tx.AddWater(ctx, w.UserID, &s.Water{Timestamp: w.Timestamp, Volume: w.Volume}); err != nil {
+				return err
+			}
+		}
+
+		for _, sl := range backup.Sleep {
+			if err := tx.SetSleep(ctx, sl.UserID, &s.Sleep{
+				Timestamp: sl.Timestamp,
+				BedTime:   sl.BedTime,
+				WakeTime:  sl.WakeTime,
+				Quality:   sl.Quality,
+				Notes:     sl.Notes,
+			}); err != nil {
+				return err
+			}
+		}
+
+		for _, f := range backup.Fast {
+			if err := tx.AddFast(ctx, f.UserID, &s.Fast{Start: f.Start, End: f.End}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func sportSetsToBackup(sets []s.SportSet) []s.SportSetBackup {
+	res := make([]s.SportSetBackup, 0, len(sets))
+	for _, set := range sets {
+		res = append(res, s.SportSetBackup(set))
+	}
+	return res
+}
+
+func sportSetsFromBackup(sets []s.SportSetBackup) []s.SportSet {
+	res := make([]s.SportSet, 0, len(sets))
+	for _, set := range sets {
+		res = append(res, s.SportSet(set))
+	}
+	return res
+}
+
+func nutrientsToBackup(n s.Nutrients) map[string]float64 {
+	if len(n) == 0 {
+		return nil
+	}
+
+	res := make(map[string]float64, len(n))
+	for k, v := range n {
+		res[string(k)] = v
+	}
+	return res
+}
+
+func nutrientsFromBackup(n map[string]float64) s.Nutrients {
+	if len(n) == 0 {
+		return nil
+	}
+
+	res := make(s.Nutrients, len(n))
+	for k, v := range n {
+		res[s.Nutrient(k)] = v
+	}
+	return res
+}
+
+func foodPortionsToBackup(p []s.FoodPortion) []s.FoodPortionBackup {
+	if len(p) == 0 {
+		return nil
+	}
+
+	res := make([]s.FoodPortionBackup, 0, len(p))
+	for _, fp := range p {
+		res = append(res, s.FoodPortionBackup{Name: fp.Name, Amount: fp.Amount, Unit: string(fp.Unit)})
+	}
+	return res
+}
+
+func foodPortionsFromBackup(p []s.FoodPortionBackup) []s.FoodPortion {
+	if len(p) == 0 {
+		return nil
+	}
+
+	res := make([]s.FoodPortion, 0, len(p))
+	for _, fp := range p {
+		res = append(res, s.FoodPortion{Name: fp.Name, Amount: fp.Amount, Unit: s.PortionUnit(fp.Unit)})
+	}
+	return res
+}
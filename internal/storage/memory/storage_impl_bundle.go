@@ -0,0 +1,107 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"maps"
+
+	s "github.com/devldavydov/myhealth/internal/storage"
+)
+
+func (r *StorageMemory) SetBundle(ctx context.Context, userID int64, bndl *s.Bundle, checkDeps bool) error {
+	if !bndl.Validate() {
+		return s.ErrBundleInvalid
+	}
+
+	return r.update(func(tx *StorageMemory) error {
+		if checkDeps {
+			for k, v := range bndl.Data {
+				if v == 0 {
+					if k == bndl.Key {
+						return s.ErrBundleDepRecursive
+					}
+
+					_, err := tx.GetBundle(ctx, userID, k)
+					if err != nil {
+						if errors.Is(err, s.ErrBundleNotFound) {
+							return s.ErrBundleDepBundleNotFound
+						}
+
+						return err
+					}
+				} else {
+					_, err := tx.GetFood(ctx, userID, k)
+					if err != nil {
+						if errors.Is(err, s.ErrFoodNotFound) {
+							return s.ErrBundleDepFoodNotFound
+						}
+
+						return err
+					}
+				}
+			}
+		}
+
+		tx.db.bundle.set(userID, bndl.Key, copyBundle(*bndl))
+		return nil
+	})
+}
+
+func (r *StorageMemory) GetBundle(ctx context.Context, userID int64, key string) (*s.Bundle, error) {
+	defer r.lock()()
+
+	b, ok := r.db.bundle.get(userID, key)
+	if !ok {
+		return nil, s.ErrBundleNotFound
+	}
+
+	b = copyBundle(b)
+	return &b, nil
+}
+
+func (r *StorageMemory) GetBundleList(ctx context.Context, userID int64) ([]s.Bundle, error) {
+	defer r.lock()()
+
+	list := r.db.bundle.list(userID, nil, func(a, b s.Bundle) int {
+		return cmp.Compare(a.Key, b.Key)
+	})
+	if len(list) == 0 {
+		return nil, s.ErrEmptyResult
+	}
+
+	for i := range list {
+		list[i] = copyBundle(list[i])
+	}
+
+	return list, nil
+}
+
+func (r *StorageMemory) DeleteBundle(ctx context.Context, userID int64, key string) error {
+	return r.update(func(tx *StorageMemory) error {
+		bndlList, err := tx.GetBundleList(ctx, userID)
+		if err != nil {
+			if errors.Is(err, s.ErrEmptyResult) {
+				return nil
+			}
+
+			return err
+		}
+
+		for _, bndl := range bndlList {
+			for k, v := range bndl.Data {
+				if v == 0 && k == key {
+					return s.ErrBundleIsUsed
+				}
+			}
+		}
+
+		tx.db.bundle.del(userID, key)
+		return nil
+	})
+}
+
+func copyBundle(b s.Bundle) s.Bundle {
+	b.Data = maps.Clone(b.Data)
+	return b
+}
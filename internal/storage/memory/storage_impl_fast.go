@@ -0,0 +1,101 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+
+	s "github.com/devldavydov/myhealth/internal/storage"
+)
+
+func (r *StorageMemory) GetActiveFast(ctx context.Context, userID int64) (*s.Fast, error) {
+	defer r.lock()()
+
+	f, ok := r.getActiveFast(userID)
+	if !ok {
+		return nil, s.ErrFastNotFound
+	}
+
+	return &f, nil
+}
+
+func (r *StorageMemory) getActiveFast(userID int64) (s.Fast, bool) {
+	list := r.db.fast.list(
+		userID,
+		func(f s.Fast) bool { return f.Active() },
+		func(a, b s.Fast) int { return cmp.Compare(b.Start, a.Start) },
+	)
+	if len(list) == 0 {
+		return s.Fast{}, false
+	}
+
+	return list[0], true
+}
+
+func (r *StorageMemory) GetFastList(ctx context.Context, userID int64, from, to s.Timestamp) ([]s.Fast, error) {
+	defer r.lock()()
+
+	list := r.db.fast.list(
+		userID,
+		func(f s.Fast) bool { return inRange(f.Start, from, to) },
+		func(a, b s.Fast) int { return cmp.Compare(a.Start, b.Start) },
+	)
+	if len(list) == 0 {
+		return nil, s.ErrEmptyResult
+	}
+
+	return list, nil
+}
+
+func (r *StorageMemory) AddFast(ctx context.Context, userID int64, f *s.Fast) error {
+	if !f.Validate() {
+		return s.ErrFastInvalid
+	}
+
+	defer r.lock()()
+
+	// Only one active fast is allowed
+	if f.Active() {
+		if _, ok := r.getActiveFast(userID); ok {
+			return s.ErrFastActive
+		}
+	}
+
+	// Fast windows must not overlap
+	overlapped := r.db.fast.list(
+		userID,
+		func(v s.Fast) bool { return v.Overlaps(f) },
+		func(a, b s.Fast) int { return cmp.Compare(a.Start, b.Start) },
+	)
+	if len(overlapped) != 0 {
+		return s.ErrFastConflict
+	}
+
+	r.db.fast.set(userID, f.Start, *f)
+	return nil
+}
+
+func (r *StorageMemory) StopFast(ctx context.Context, userID int64, start, end s.Timestamp) error {
+	if end <= start {
+		return s.ErrFastInvalid
+	}
+
+	defer r.lock()()
+
+	f, ok := r.db.fast.get(userID, start)
+	if !ok || !f.Active() {
+		return s.ErrFastNotFound
+	}
+
+	f.End = end
+	r.db.fast.set(userID, start, f)
+	return nil
+}
+
+func (r *StorageMemory) DeleteFast(ctx context.Context, userID int64, from, to s.Timestamp) error {
+	defer r.lock()()
+
+	r.db.fast.delFunc(userID, func(f s.Fast) bool {
+		return inRange(f.Start, from, to)
+	})
+	return nil
+}
@@ -0,0 +1,111 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+
+	s "github.com/devldavydov/myhealth/internal/storage"
+)
+
+func (r *StorageMemory) GetFood(ctx context.Context, userID int64, key string) (*s.Food, error) {
+	defer r.lock()()
+
+	f, ok := r.db.food.get(userID, key)
+	if !ok {
+		return nil, s.ErrFoodNotFound
+	}
+
+	f = copyFood(f)
+	return &f, nil
+}
+
+func (r *StorageMemory) GetFoodList(ctx context.Context, userID int64) ([]s.Food, error) {
+	return r.findFood(userID, nil)
+}
+
+func (r *StorageMemory) FindFood(ctx context.Context, userID int64, pattern string) ([]s.Food, error) {
+	pattern = strings.ToUpper(pattern)
+	return r.findFood(userID, func(f s.Food) bool {
+		return strings.Contains(strings.ToUpper(f.Key), pattern) ||
+			strings.Contains(strings.ToUpper(f.Name), pattern) ||
+			strings.Contains(strings.ToUpper(f.Brand), pattern) ||
+			strings.Contains(strings.ToUpper(f.Comment), pattern)
+	})
+}
+
+func (r *StorageMemory) findFood(userID int64, filter func(f s.Food) bool) ([]s.Food, error) {
+	defer r.lock()()
+
+	list := r.db.food.list(userID, filter, func(a, b s.Food) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.Key, b.Key))
+	})
+	if len(list) == 0 {
+		return nil, s.ErrEmptyResult
+	}
+
+	for i := range list {
+		list[i] = copyFood(list[i])
+	}
+
+	return list, nil
+}
+
+func (r *StorageMemory) SetFood(ctx context.Context, userID int64, food *s.Food) error {
+	if !food.Validate() {
+		return s.ErrFoodInvalid
+	}
+
+	defer r.lock()()
+
+	r.db.food.set(userID, food.Key, copyFood(*food))
+	return nil
+}
+
+func (r *StorageMemory) DeleteFood(ctx context.Context, userID int64, key string) error {
+	return r.update(func(tx *StorageMemory) error {
+		bndlList, err := tx.GetBundleList(ctx, userID)
+		if err != nil && !errors.Is(err, s.ErrEmptyResult) {
+			return err
+		}
+
+		for _, bndl := range bndlList {
+			for k, v := range bndl.Data {
+				if v != 0 && k == key {
+					return s.ErrFoodIsUsed
+				}
+			}
+		}
+
+		for _, j := range tx.db.journal[userID] {
+			if j.FoodKey == key {
+				return s.ErrFoodIsUsed
+			}
+		}
+
+		tx.db.food.del(userID, key)
+		return nil
+	})
+}
+
+// copyFood returns deep copy of food, empty nutrients and portions are
+// stored as nil like in SQL storages.
+func copyFood(f s.Food) s.Food {
+	f.Nutrients = copyNutrients(f.Nutrients)
+	if len(f.Portions) == 0 {
+		f.Portions = nil
+	} else {
+		f.Portions = slices.Clone(f.Portions)
+	}
+	return f
+}
+
+func copyNutrients(n s.Nutrients) s.Nutrients {
+	if len(n) == 0 {
+		return nil
+	}
+	return maps.Clone(n)
+}
@@ -0,0 +1,264 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	s "github.com/devldavydov/myhealth/internal/storage"
+)
+
+const _msPerDay = 86400000.0
+
+func (r *StorageMemory) SetJournal(ctx context.Context, userID int64, journal *s.Journal) error {
+	if !journal.Validate() {
+		return s.ErrJournalInvalid
+	}
+
+	defer r.lock()()
+
+	if _, ok := r.db.food.get(userID, journal.FoodKey); !ok {
+		return s.ErrFoodNotFound
+	}
+
+	r.db.journal.set(userID, journalKey{journal.Timestamp, journal.Meal, journal.FoodKey}, *journal)
+	return nil
+}
+
+func (r *StorageMemory) SetJournalBundle(ctx context.Context, userID int64, timestamp s.Timestamp, meal s.Meal, bndlKey string) error {
+	return r.update(func(tx *StorageMemory) error {
+		foodItems, err := tx.getBundleFoodItems(userID, bndlKey)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range foodItems {
+			tx.db.journal.set(userID, journalKey{timestamp, meal, item.foodKey}, s.Journal{
+				Timestamp:  timestamp,
+				Meal:       meal,
+				FoodKey:    item.foodKey,
+				FoodWeight: item.foodWeight,
+			})
+		}
+
+		return nil
+	})
+}
+
+type bundleFoodItem struct {
+	foodKey    string
+	foodWeight float64
+}
+
+func (r *StorageMemory) getBundleFoodItems(userID int64, bndlKey string) ([]bundleFoodItem, error) {
+	foodItems := []bundleFoodItem{}
+	bndlList := []string{bndlKey}
+	i := 0
+
+	for i < len(bndlList) {
+		bndl, ok := r.db.bundle.get(userID, bndlList[i])
+		if !ok {
+			return nil, s.ErrBundleNotFound
+		}
+
+		for k, v := range bndl.Data {
+			if v == 0 {
+				bndlList = append(bndlList, k)
+				continue
+			}
+
+			if _, ok := r.db.food.get(userID, k); !ok {
+				return nil, s.ErrFoodNotFound
+			}
+
+			foodItems = append(foodItems, bundleFoodItem{foodKey: k, foodWeight: v})
+		}
+
+		i++
+	}
+
+	return foodItems, nil
+}
+
+func (r *StorageMemory) DeleteJournal(ctx context.Context, userID int64, from, to s.Timestamp, meal s.Meal, foodkey string) error {
+	defer r.lock()()
+
+	r.db.journal.delFunc(userID, func(j s.Journal) bool {
+		return inRange(j.Timestamp, from, to) && j.Meal == meal && j.FoodKey == foodkey
+	})
+	return nil
+}
+
+func (r *StorageMemory) DeleteJournalMeal(ctx context.Context, userID int64, from, to s.Timestamp, meal s.Meal) error {
+	defer r.lock()()
+
+	r.db.journal.delFunc(userID, func(j s.Journal) bool {
+		return inRange(j.Timestamp, from, to) && j.Meal == meal
+	})
+	return nil
+}
+
+func (r *StorageMemory) DelJournalBundle(ctx context.Context, userID int64, from, to s.Timestamp, meal s.Meal, bndlKey string) error {
+	return r.update(func(tx *StorageMemory) error {
+		foodItems, err := tx.getBundleFoodItems(userID, bndlKey)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range foodItems {
+			tx.db.journal.delFunc(userID, func(j s.Journal) bool {
+				return inRange(j.Timestamp, from, to) && j.Meal == meal && j.FoodKey == item.foodKey
+			})
+		}
+
+		return nil
+	})
+}
+
+func (r *StorageMemory) GetJournalReport(ctx context.Context, userID int64, from, to s.Timestamp) ([]s.JournalReport, error) {
+	defer r.lock()()
+
+	list := []s.JournalReport{}
+	for _, j := range r.db.journal[userID] {
+		if !inRange(j.Timestamp, from, to) {
+			continue
+		}
+
+		f, _ := r.db.food.get(userID, j.FoodKey)
+		jr := s.JournalReport{
+			Timestamp:    j.Timestamp,
+			Meal:         j.Meal,
+			FoodKey:      j.FoodKey,
+			FoodName:     f.Name,
+			FoodBrand:    f.Brand,
+			FoodWeight:   j.FoodWeight,
+			Cal:          j.FoodWeight / 100 * f.Cal100,
+			Prot:         j.FoodWeight / 100 * f.Prot100,
+			Fat:          j.FoodWeight / 100 * f.Fat100,
+			Carb:         j.FoodWeight / 100 * f.Carb100,
+			FoodBeverage: f.Beverage,
+			Portion:      j.Portion,
+		}
+
+		// Food nutrients are per 100g
+		if len(f.Nutrients) > 0 {
+			jr.Nutrients = f.Nutrients.Scale(jr.FoodWeight / 100)
+		}
+
+		list = append(list, jr)
+	}
+
+	if len(list) == 0 {
+		return nil, s.ErrEmptyResult
+	}
+
+	slices.SortFunc(list, func(a, b s.JournalReport) int {
+		return cmp.Or(
+			cmp.Compare(a.Timestamp, b.Timestamp),
+			cmp.Compare(a.Meal, b.Meal),
+			cmp.Compare(a.FoodName, b.FoodName),
+			cmp.Compare(a.FoodKey, b.FoodKey),
+		)
+	})
+
+	return list, nil
+}
+
+func (r *StorageMemory) CopyJournal(ctx context.Context, userID int64, from, to s.Timestamp, mealFrom s.Meal, dst s.Timestamp, mealTo s.Meal) (int, error) {
+	defer r.lock()()
+
+	list := r.db.journal.list(
+		userID,
+		func(j s.Journal) bool { return inRange(j.Timestamp, from, to) && j.Meal == mealFrom },
+		func(a, b s.Journal) int {
+			return cmp.Or(cmp.Compare(a.Timestamp, b.Timestamp), cmp.Compare(a.FoodKey, b.FoodKey))
+		},
+	)
+
+	// Records keep offset from start of period
+	for _, j := range list {
+		j.Timestamp, j.Meal = dst+j.Timestamp-from, mealTo
+		r.db.journal.set(userID, journalKey{j.Timestamp, mealTo, j.FoodKey}, j)
+	}
+
+	return len(list), nil
+}
+
+func (r *StorageMemory) GetJournalFoodStat(ctx context.Context, userID int64, foodkey string) (*s.JournalFoodStat, error) {
+	defer r.lock()()
+
+	var fs s.JournalFoodStat
+	for _, j := range r.db.journal[userID] {
+		if j.FoodKey != foodkey {
+			continue
+		}
+
+		if fs.TotalCount == 0 || j.Timestamp < fs.FirstTimestamp {
+			fs.FirstTimestamp = j.Timestamp
+		}
+		if fs.TotalCount == 0 || j.Timestamp > fs.LastTimestamp {
+			fs.LastTimestamp = j.Timestamp
+		}
+		fs.TotalWeight += j.FoodWeight
+		fs.TotalCount++
+	}
+
+	if fs.TotalCount == 0 {
+		return nil, s.ErrEmptyResult
+	}
+
+	fs.AvgWeight = fs.TotalWeight / float64(fs.TotalCount)
+	return &fs, nil
+}
+
+// GetJournalMealSuggestions returns foods most often eaten at meal in [from, to].
+// Each entry adds 1/(1+days ago) to food score, so recent entries weigh more.
+func (r *StorageMemory) GetJournalMealSuggestions(
+	ctx context.Context,
+	userID int64,
+	meal s.Meal,
+	from, to s.Timestamp,
+	limit int,
+) ([]s.JournalMealSuggestion, error) {
+	defer r.lock()()
+
+	byFood := map[string]*s.JournalMealSuggestion{}
+	for _, j := range r.db.journal[userID] {
+		if j.Meal != meal || !inRange(j.Timestamp, from, to) {
+			continue
+		}
+
+		js, ok := byFood[j.FoodKey]
+		if !ok {
+			f, _ := r.db.food.get(userID, j.FoodKey)
+			js = &s.JournalMealSuggestion{FoodKey: j.FoodKey, FoodName: f.Name, FoodBrand: f.Brand}
+			byFood[j.FoodKey] = js
+		}
+
+		js.Count++
+		js.Score += 1.0 / (1.0 + float64(to-j.Timestamp)/_msPerDay)
+	}
+
+	list := make([]s.JournalMealSuggestion, 0, len(byFood))
+	for _, js := range byFood {
+		list = append(list, *js)
+	}
+
+	slices.SortFunc(list, func(a, b s.JournalMealSuggestion) int {
+		return cmp.Or(
+			cmp.Compare(b.Score, a.Score),
+			cmp.Compare(a.FoodName, b.FoodName),
+			cmp.Compare(a.FoodKey, b.FoodKey),
+		)
+	})
+
+	if limit >= 0 && len(list) > limit {
+		list = list[:limit]
+	}
+
+	if len(list) == 0 {
+		return nil, s.ErrEmptyResult
+	}
+
+	return list, nil
+}
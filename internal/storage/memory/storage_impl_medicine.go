@@ -0,0 +1,117 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	s "github.com/devldavydov/myhealth/internal/storage"
+)
+
+//
+// Medicine.
+//
+
+func (r *StorageMemory) GetMedicine(ctx context.Context, userID int64, key string) (*s.Medicine, error) {
+	defer r.lock()()
+
+	m, ok := r.db.medicine.get(userID, key)
+	if !ok {
+		return nil, s.ErrMedicineNotFound
+	}
+
+	return &m, nil
+}
+
+func (r *StorageMemory) GetMedicineList(ctx context.Context, userID int64) ([]s.Medicine, error) {
+	defer r.lock()()
+
+	list := r.db.medicine.list(userID, nil, func(a, b s.Medicine) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.Key, b.Key))
+	})
+	if len(list) == 0 {
+		return nil, s.ErrEmptyResult
+	}
+
+	return list, nil
+}
+
+func (r *StorageMemory) SetMedicine(ctx context.Context, userID int64, m *s.Medicine) error {
+	if !m.Validate() {
+		return s.ErrMedicineInvalid
+	}
+
+	defer r.lock()()
+
+	r.db.medicine.set(userID, m.Key, *m)
+	return nil
+}
+
+func (r *StorageMemory) DeleteMedicine(ctx context.Context, userID int64, key string) error {
+	defer r.lock()()
+
+	for _, mi := range r.db.medicineIndicator[userID] {
+		if mi.MedicineKey == key {
+			return s.ErrMedicineIsUsed
+		}
+	}
+
+	r.db.medicine.del(userID, key)
+	return nil
+}
+
+//
+// MedicineIndicator.
+//
+
+func (r *StorageMemory) SetMedicineIndicator(ctx context.Context, userID int64, mi *s.MedicineIndicator) error {
+	if !mi.Validate() {
+		return s.ErrMedicineIndicatorInvalid
+	}
+
+	defer r.lock()()
+
+	if _, ok := r.db.medicine.get(userID, mi.MedicineKey); !ok {
+		return s.ErrMedicineNotFound
+	}
+
+	r.db.medicineIndicator.set(userID, medicineIndicatorKey{mi.Timestamp, mi.MedicineKey}, *mi)
+	return nil
+}
+
+func (r *StorageMemory) DeleteMedicineIndicator(ctx context.Context, userID int64, from, to s.Timestamp, medicine_key string) error {
+	defer r.lock()()
+
+	r.db.medicineIndicator.delFunc(userID, func(mi s.MedicineIndicator) bool {
+		return inRange(mi.Timestamp, from, to) && mi.MedicineKey == medicine_key
+	})
+	return nil
+}
+
+func (r *StorageMemory) GetMedicineIndicatorReport(ctx context.Context, userID int64, from, to s.Timestamp) ([]s.MedicineIndicatorReport, error) {
+	defer r.lock()()
+
+	list := []s.MedicineIndicatorReport{}
+	for _, mi := range r.db.medicineIndicator[userID] {
+		if !inRange(mi.Timestamp, from, to) {
+			continue
+		}
+
+		m, _ := r.db.medicine.get(userID, mi.MedicineKey)
+		list = append(list, s.MedicineIndicatorReport{
+			MedicineName: m.Name + " [" + m.Unit + "]",
+			Timestamp:    mi.Timestamp,
+			Value:        mi.Value,
+		})
+	}
+
+	if len(list) == 0 {
+		return nil, s.ErrEmptyResult
+	}
+
+	slices.SortFunc(list, func(a, b s.MedicineIndicatorReport) int {
+		return cmp.Or(cmp.Compare(a.Timestamp, b.Timestamp), cmp.Compare(a.MedicineName, b.MedicineName))
+	})
+
+	return list, nil
+}
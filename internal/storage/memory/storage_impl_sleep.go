@@ -0,0 +1,52 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+
+	s "github.com/devldavydov/myhealth/internal/storage"
+)
+
+func (r *StorageMemory) GetSleep(ctx context.Context, userID int64, ts s.Timestamp) (*s.Sleep, error) {
+	defer r.lock()()
+
+	sl, ok := r.db.sleep.get(userID, ts)
+	if !ok {
+		return nil, s.ErrSleepNotFound
+	}
+
+	return &sl, nil
+}
+
+func (r *StorageMemory) GetSleepList(ctx context.Context, userID int64, from, to s.Timestamp) ([]s.Sleep, error) {
+	defer r.lock()()
+
+	list := r.db.sleep.list(
+		userID,
+		func(sl s.Sleep) bool { return inRange(sl.Timestamp, from, to) },
+		func(a, b s.Sleep) int { return cmp.Compare(a.Timestamp, b.Timestamp) },
+	)
+	if len(list) == 0 {
+		return nil, s.ErrEmptyResult
+	}
+
+	return list, nil
+}
+
+func (r *StorageMemory) SetSleep(ctx context.Context, userID int64, sl *s.Sleep) error {
+	if !sl.Validate() {
+		return s.ErrSleepInvalid
+	}
+
+	defer r.lock()()
+
+	r.db.sleep.set(userID, sl.Timestamp, *sl)
+	return nil
+}
+
+func (r *StorageMemory) DeleteSleep(ctx context.Context, userID int64, timestamp s.Timestamp) error {
+	defer r.lock()()
+
+	r.db.sleep.del(userID, timestamp)
+	return nil
+}
@@ -0,0 +1,236 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+
+	s "github.com/devldavydov/myhealth/internal/storage"
+)
+
+//
+// Sport.
+//
+
+func (r *StorageMemory) GetSport(ctx context.Context, userID int64, key string) (*s.Sport, error) {
+	defer r.lock()()
+
+	sp, ok := r.db.sport.get(userID, key)
+	if !ok {
+		return nil, s.ErrSportNotFound
+	}
+
+	return &sp, nil
+}
+
+func (r *StorageMemory) GetSportList(ctx context.Context, userID int64) ([]s.Sport, error) {
+	defer r.lock()()
+
+	list := r.db.sport.list(userID, nil, func(a, b s.Sport) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.Key, b.Key))
+	})
+	if len(list) == 0 {
+		return nil, s.ErrEmptyResult
+	}
+
+	return list, nil
+}
+
+func (r *StorageMemory) SetSport(ctx context.Context, userID int64, sp *s.Sport) error {
+	if !sp.Validate() {
+		return s.ErrSportInvalid
+	}
+
+	defer r.lock()()
+
+	r.db.sport.set(userID, sp.Key, *sp)
+	return nil
+}
+
+func (r *StorageMemory) DeleteSport(ctx context.Context, userID int64, key string) error {
+	return r.update(func(tx *StorageMemory) error {
+		used, err := tx.isSportUsedInWorkout(ctx, userID, key)
+		if err != nil {
+			return err
+		}
+		if used {
+			return s.ErrSportIsUsed
+		}
+
+		for _, sa := range tx.db.sportActivity[userID] {
+			if sa.SportKey == key {
+				return s.ErrSportIsUsed
+			}
+		}
+
+		tx.db.sport.del(userID, key)
+		return nil
+	})
+}
+
+//
+// SportActivity.
+//
+
+func (r *StorageMemory) SetSportActivity(ctx context.Context, userID int64, sa *s.SportActivity) error {
+	if !sa.Validate() {
+		return s.ErrSportActivityInvalid
+	}
+
+	return r.update(func(tx *StorageMemory) error {
+		sp, err := tx.GetSport(ctx, userID, sa.SportKey)
+		if err != nil {
+			return err
+		}
+
+		sets, err := sp.SetKind.Normalize(sa.Sets)
+		if err != nil {
+			return err
+		}
+
+		// Calculate burned calories by sport coefficients, if not set explicitly
+		cal := sa.Cal
+		if cal == 0 {
+			var weight float64
+			w, err := tx.GetLastWeight(ctx, userID, sa.Timestamp)
+			switch {
+			case err == nil:
+				weight = w.Value
+			case !errors.Is(err, s.ErrWeightNotFound):
+				return err
+			}
+
+			cal = sp.ActivityCal(sets, sa.Duration, weight)
+		}
+
+		// New activity is added, existing is updated by ID
+		id := sa.ID
+		if id == 0 {
+			id = tx.nextSportActivityID()
+		} else if _, ok := tx.db.sportActivity.get(userID, id); !ok {
+			return s.ErrSportActivityNotFound
+		}
+
+		tx.db.sportActivity.set(userID, id, s.SportActivity{
+			ID:        id,
+			SportKey:  sa.SportKey,
+			Timestamp: sa.Timestamp,
+			Sets:      copySportSets(sets),
+			Duration:  sa.Duration,
+			Cal:       cal,
+			Comment:   sa.Comment,
+		})
+
+		sa.ID = id
+		return nil
+	})
+}
+
+// nextSportActivityID returns ID after max ID of all users like SQL row ID.
+func (r *StorageMemory) nextSportActivityID() int64 {
+	var id int64
+	for _, rows := range r.db.sportActivity {
+		for rowID := range rows {
+			id = max(id, rowID)
+		}
+	}
+	return id + 1
+}
+
+func (r *StorageMemory) DeleteSportActivity(ctx context.Context, userID int64, from, to s.Timestamp, sport_key string) error {
+	defer r.lock()()
+
+	r.db.sportActivity.delFunc(userID, func(sa s.SportActivity) bool {
+		return inRange(sa.Timestamp, from, to) && sa.SportKey == sport_key
+	})
+	return nil
+}
+
+func (r *StorageMemory) DeleteSportActivityByID(ctx context.Context, userID, id int64) error {
+	defer r.lock()()
+
+	if _, ok := r.db.sportActivity.get(userID, id); !ok {
+		return s.ErrSportActivityNotFound
+	}
+
+	r.db.sportActivity.del(userID, id)
+	return nil
+}
+
+func (r *StorageMemory) GetSportActivityReport(ctx context.Context, userID int64, from, to s.Timestamp) ([]s.SportActivityReport, error) {
+	defer r.lock()()
+
+	list := []s.SportActivityReport{}
+	for _, sa := range r.db.sportActivity[userID] {
+		if !inRange(sa.Timestamp, from, to) {
+			continue
+		}
+
+		sp, _ := r.db.sport.get(userID, sa.SportKey)
+		list = append(list, s.SportActivityReport{
+			ID:        sa.ID,
+			SportKey:  sa.SportKey,
+			SportName: sp.Name + " [" + sp.Unit + "]",
+			Timestamp: sa.Timestamp,
+			Sets:      copySportSets(sa.Sets),
+			Duration:  sa.Duration,
+			Cal:       sa.Cal,
+			Comment:   sa.Comment,
+		})
+	}
+
+	if len(list) == 0 {
+		return nil, s.ErrEmptyResult
+	}
+
+	slices.SortFunc(list, func(a, b s.SportActivityReport) int {
+		return cmp.Or(
+			cmp.Compare(a.Timestamp, b.Timestamp),
+			cmp.Compare(a.SportName, b.SportName),
+			cmp.Compare(a.ID, b.ID),
+		)
+	})
+
+	return list, nil
+}
+
+func (r *StorageMemory) GetSportActivityCal(ctx context.Context, userID int64, from, to s.Timestamp) (float64, error) {
+	defer r.lock()()
+
+	var cal float64
+	for _, sa := range r.db.sportActivity[userID] {
+		if inRange(sa.Timestamp, from, to) {
+			cal += sa.Cal
+		}
+	}
+
+	return cal, nil
+}
+
+func (r *StorageMemory) GetSportActivityHistory(ctx context.Context, userID int64, sportKey string) ([]s.SportActivity, error) {
+	defer r.lock()()
+
+	list := r.db.sportActivity.list(
+		userID,
+		func(sa s.SportActivity) bool { return sa.SportKey == sportKey },
+		func(a, b s.SportActivity) int {
+			return cmp.Or(cmp.Compare(a.Timestamp, b.Timestamp), cmp.Compare(a.ID, b.ID))
+		},
+	)
+	if len(list) == 0 {
+		return nil, s.ErrEmptyResult
+	}
+
+	for i := range list {
+		list[i].Sets = copySportSets(list[i].Sets)
+	}
+
+	return list, nil
+}
+
+// copySportSets returns copy of sets, it is never nil like sets
+// unmarshaled from SQL storages.
+func copySportSets(sets []s.SportSet) []s.SportSet {
+	return append([]s.SportSet{}, sets...)
+}
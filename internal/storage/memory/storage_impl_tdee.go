@@ -0,0 +1,47 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+
+	s "github.com/devldavydov/myhealth/internal/storage"
+)
+
+func (r *StorageMemory) SetTDEEEstimate(ctx context.Context, userID int64, est *s.TDEEEstimate) error {
+	if !est.Validate() {
+		return s.ErrTDEEEstimateInvalid
+	}
+
+	defer r.lock()()
+
+	r.db.tdeeEstimate.set(userID, est.Timestamp, *est)
+	return nil
+}
+
+func (r *StorageMemory) GetLastTDEEEstimate(ctx context.Context, userID int64) (*s.TDEEEstimate, error) {
+	defer r.lock()()
+
+	list := r.db.tdeeEstimate.list(userID, nil, func(a, b s.TDEEEstimate) int {
+		return cmp.Compare(b.Timestamp, a.Timestamp)
+	})
+	if len(list) == 0 {
+		return nil, s.ErrTDEEEstimateNotFound
+	}
+
+	return &list[0], nil
+}
+
+func (r *StorageMemory) GetTDEEEstimateList(ctx context.Context, userID int64, from, to s.Timestamp) ([]s.TDEEEstimate, error) {
+	defer r.lock()()
+
+	list := r.db.tdeeEstimate.list(
+		userID,
+		func(est s.TDEEEstimate) bool { return inRange(est.Timestamp, from, to) },
+		func(a, b s.TDEEEstimate) int { return cmp.Compare(a.Timestamp, b.Timestamp) },
+	)
+	if len(list) == 0 {
+		return nil, s.ErrEmptyResult
+	}
+
+	return list, nil
+}
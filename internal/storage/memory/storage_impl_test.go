@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	s "github.com/devldavydov/myhealth/internal/storage"
+	"github.com/devldavydov/myhealth/internal/storage/storagetest"
+)
+
+func TestStorageMemoryConformance(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) s.Storage {
+		return NewStorageMemory()
+	})
+}
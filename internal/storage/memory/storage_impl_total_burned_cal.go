@@ -0,0 +1,36 @@
+package memory
+
+import (
+	"context"
+
+	s "github.com/devldavydov/myhealth/internal/storage"
+)
+
+func (r *StorageMemory) GetTotalBurnedCal(ctx context.Context, userID int64, timestamp s.Timestamp) (float64, error) {
+	defer r.lock()()
+
+	totalCal, ok := r.db.totalBurnedCal.get(userID, timestamp)
+	if !ok {
+		return 0, s.ErrTotalBurnedCalNotFound
+	}
+
+	return totalCal, nil
+}
+
+func (r *StorageMemory) SetTotalBurnedCal(ctx context.Context, userID int64, timestamp s.Timestamp, totalCal float64) error {
+	if totalCal <= 0 {
+		return s.ErrDayTotalCalInvalid
+	}
+
+	defer r.lock()()
+
+	r.db.totalBurnedCal.set(userID, timestamp, totalCal)
+	return nil
+}
+
+func (r *StorageMemory) DeleteTotalBurnedCal(ctx context.Context, userID int64, timestamp s.Timestamp) error {
+	defer r.lock()()
+
+	r.db.totalBurnedCal.del(userID, timestamp)
+	return nil
+}
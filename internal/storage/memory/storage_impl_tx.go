@@ -0,0 +1,32 @@
+package memory
+
+import (
+	"context"
+
+	s "github.com/devldavydov/myhealth/internal/storage"
+)
+
+func (r *StorageMemory) InTx(ctx context.Context, fn func(stg s.Storage) error) error {
+	return r.update(func(tx *StorageMemory) error {
+		return fn(tx)
+	})
+}
+
+// update runs fn with storage bound to copy of database, copy replaces
+// database if fn returns nil. Nested calls reuse the outer copy.
+func (r *StorageMemory) update(fn func(tx *StorageMemory) error) error {
+	if r.tx {
+		return fn(r)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tx := &StorageMemory{mu: r.mu, db: r.db.clone(), tx: true}
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	r.db = tx.db
+	return nil
+}
@@ -0,0 +1,39 @@
+package memory
+
+import (
+	"context"
+
+	s "github.com/devldavydov/myhealth/internal/storage"
+)
+
+func (r *StorageMemory) GetUserSettings(ctx context.Context, userID int64) (*s.UserSettings, error) {
+	defer r.lock()()
+
+	us, ok := r.db.userSettings.get(userID, struct{}{})
+	if !ok {
+		return nil, s.ErrUserSettingsNotFound
+	}
+
+	us = copyUserSettings(us)
+	return &us, nil
+}
+
+func (r *StorageMemory) SetUserSettings(ctx context.Context, userID int64, us *s.UserSettings) error {
+	if !us.Validate() {
+		return s.ErrUserSettingsInvalid
+	}
+
+	defer r.lock()()
+
+	r.db.userSettings.set(userID, struct{}{}, copyUserSettings(*us))
+	return nil
+}
+
+func copyUserSettings(us s.UserSettings) s.UserSettings {
+	if us.Profile != nil {
+		p := *us.Profile
+		us.Profile = &p
+	}
+	us.NutrientLimits = copyNutrients(us.NutrientLimits)
+	return us
+}
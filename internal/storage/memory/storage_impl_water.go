@@ -0,0 +1,45 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+
+	s "github.com/devldavydov/myhealth/internal/storage"
+)
+
+func (r *StorageMemory) GetWaterList(ctx context.Context, userID int64, from, to s.Timestamp) ([]s.Water, error) {
+	defer r.lock()()
+
+	list := r.db.water.list(
+		userID,
+		func(w s.Water) bool { return inRange(w.Timestamp, from, to) },
+		func(a, b s.Water) int { return cmp.Compare(a.Timestamp, b.Timestamp) },
+	)
+	if len(list) == 0 {
+		return nil, s.ErrEmptyResult
+	}
+
+	return list, nil
+}
+
+func (r *StorageMemory) AddWater(ctx context.Context, userID int64, w *s.Water) error {
+	if !w.Validate() {
+		return s.ErrWaterInvalid
+	}
+
+	defer r.lock()()
+
+	// Water of same timestamp is summed up
+	cur, _ := r.db.water.get(userID, w.Timestamp)
+	r.db.water.set(userID, w.Timestamp, s.Water{Timestamp: w.Timestamp, Volume: cur.Volume + w.Volume})
+	return nil
+}
+
+func (r *StorageMemory) DeleteWater(ctx context.Context, userID int64, from, to s.Timestamp) error {
+	defer r.lock()()
+
+	r.db.water.delFunc(userID, func(w s.Water) bool {
+		return inRange(w.Timestamp, from, to)
+	})
+	return nil
+}
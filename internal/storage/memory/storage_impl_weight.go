@@ -0,0 +1,74 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+
+	s "github.com/devldavydov/myhealth/internal/storage"
+)
+
+func (r *StorageMemory) GetWeightList(ctx context.Context, userID int64, from, to s.Timestamp, desc bool) ([]s.Weight, error) {
+	defer r.lock()()
+
+	list := r.db.weight.list(
+		userID,
+		func(w s.Weight) bool { return inRange(w.Timestamp, from, to) },
+		func(a, b s.Weight) int {
+			if desc {
+				return cmp.Compare(b.Timestamp, a.Timestamp)
+			}
+			return cmp.Compare(a.Timestamp, b.Timestamp)
+		},
+	)
+	if len(list) == 0 {
+		return nil, s.ErrEmptyResult
+	}
+
+	return list, nil
+}
+
+func (r *StorageMemory) GetWeight(ctx context.Context, userID int64, ts s.Timestamp) (*s.Weight, error) {
+	defer r.lock()()
+
+	w, ok := r.db.weight.get(userID, ts)
+	if !ok {
+		return nil, s.ErrWeightNotFound
+	}
+
+	return &w, nil
+}
+
+func (r *StorageMemory) GetLastWeight(ctx context.Context, userID int64, ts s.Timestamp) (*s.Weight, error) {
+	defer r.lock()()
+
+	list := r.db.weight.list(
+		userID,
+		func(w s.Weight) bool { return w.Timestamp <= ts },
+		func(a, b s.Weight) int { return cmp.Compare(b.Timestamp, a.Timestamp) },
+	)
+	if len(list) == 0 {
+		return nil, s.ErrWeightNotFound
+	}
+
+	return &list[0], nil
+}
+
+func (r *StorageMemory) SetWeight(ctx context.Context, userID int64, weight *s.Weight) error {
+	if !weight.Validate() {
+		return s.ErrWeightInvalid
+	}
+
+	defer r.lock()()
+
+	r.db.weight.set(userID, weight.Timestamp, *weight)
+	return nil
+}
+
+func (r *StorageMemory) DeleteWeight(ctx context.Context, userID int64, from, to s.Timestamp) error {
+	defer r.lock()()
+
+	r.db.weight.delFunc(userID, func(w s.Weight) bool {
+		return inRange(w.Timestamp, from, to)
+	})
+	return nil
+}
@@ -0,0 +1,156 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"time"
+
+	s "github.com/devldavydov/myhealth/internal/storage"
+)
+
+//
+// Workout.
+//
+
+func (r *StorageMemory) GetWorkout(ctx context.Context, userID int64, key string) (*s.Workout, error) {
+	defer r.lock()()
+
+	w, ok := r.db.workout.get(userID, key)
+	if !ok {
+		return nil, s.ErrWorkoutNotFound
+	}
+
+	w.Items = copyWorkoutItems(w.Items)
+	return &w, nil
+}
+
+func (r *StorageMemory) GetWorkoutList(ctx context.Context, userID int64) ([]s.Workout, error) {
+	defer r.lock()()
+
+	list := r.db.workout.list(userID, nil, func(a, b s.Workout) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.Key, b.Key))
+	})
+	if len(list) == 0 {
+		return nil, s.ErrEmptyResult
+	}
+
+	for i := range list {
+		list[i].Items = copyWorkoutItems(list[i].Items)
+	}
+
+	return list, nil
+}
+
+func (r *StorageMemory) SetWorkout(ctx context.Context, userID int64, w *s.Workout) error {
+	if !w.Validate() {
+		return s.ErrWorkoutInvalid
+	}
+
+	return r.update(func(tx *StorageMemory) error {
+		// Sets are stored in set kind of each sport
+		items := make([]s.WorkoutItem, 0, len(w.Items))
+		for _, item := range w.Items {
+			sp, err := tx.GetSport(ctx, userID, item.SportKey)
+			if err != nil {
+				return err
+			}
+
+			sets, err := sp.SetKind.Normalize(item.Sets)
+			if err != nil {
+				return s.ErrWorkoutInvalid
+			}
+
+			items = append(items, s.WorkoutItem{SportKey: item.SportKey, Sets: copySportSets(sets)})
+		}
+
+		tx.db.workout.set(userID, w.Key, s.Workout{
+			Key:     w.Key,
+			Name:    w.Name,
+			Items:   items,
+			Comment: w.Comment,
+		})
+		return nil
+	})
+}
+
+func (r *StorageMemory) DeleteWorkout(ctx context.Context, userID int64, key string) error {
+	defer r.lock()()
+
+	for _, pd := range r.db.workoutPlan[userID] {
+		if pd.WorkoutKey == key {
+			return s.ErrWorkoutIsUsed
+		}
+	}
+
+	r.db.workout.del(userID, key)
+	return nil
+}
+
+// isSportUsedInWorkout checks workouts, they reference sports in items.
+func (r *StorageMemory) isSportUsedInWorkout(ctx context.Context, userID int64, sportKey string) (bool, error) {
+	list, err := r.GetWorkoutList(ctx, userID)
+	if err != nil {
+		if errors.Is(err, s.ErrEmptyResult) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	for _, w := range list {
+		for _, item := range w.Items {
+			if item.SportKey == sportKey {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+func copyWorkoutItems(items []s.WorkoutItem) []s.WorkoutItem {
+	res := make([]s.WorkoutItem, 0, len(items))
+	for _, item := range items {
+		res = append(res, s.WorkoutItem{SportKey: item.SportKey, Sets: copySportSets(item.Sets)})
+	}
+	return res
+}
+
+//
+// WorkoutPlan.
+//
+
+func (r *StorageMemory) GetWorkoutPlan(ctx context.Context, userID int64) ([]s.WorkoutPlanDay, error) {
+	defer r.lock()()
+
+	list := r.db.workoutPlan.list(userID, nil, func(a, b s.WorkoutPlanDay) int {
+		return cmp.Compare(a.Weekday, b.Weekday)
+	})
+	if len(list) == 0 {
+		return nil, s.ErrEmptyResult
+	}
+
+	return list, nil
+}
+
+func (r *StorageMemory) SetWorkoutPlanDay(ctx context.Context, userID int64, pd *s.WorkoutPlanDay) error {
+	if !pd.Validate() {
+		return s.ErrWorkoutPlanDayInvalid
+	}
+
+	defer r.lock()()
+
+	if _, ok := r.db.workout.get(userID, pd.WorkoutKey); !ok {
+		return s.ErrWorkoutNotFound
+	}
+
+	r.db.workoutPlan.set(userID, pd.Weekday, *pd)
+	return nil
+}
+
+func (r *StorageMemory) DeleteWorkoutPlanDay(ctx context.Context, userID int64, weekday time.Weekday) error {
+	defer r.lock()()
+
+	r.db.workoutPlan.del(userID, weekday)
+	return nil
+}
@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"slices"
 	"strings"
 	"time"
 )
@@ -16,15 +17,33 @@ func (r Timestamp) ToTime(tz *time.Location) time.Time {
 	return time.UnixMilli(int64(r)).In(tz)
 }
 
+// DayStart returns start of day of time, it is the key of date-only
+// records.
+func DayStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// DayEnd returns the last millisecond of day of time, it is used as
+// upper bound of periods to include records with time of day.
+func DayEnd(t time.Time) time.Time {
+	return DayStart(t).AddDate(0, 0, 1).Add(-time.Millisecond)
+}
+
 type Food struct {
-	Key     string
-	Name    string
-	Brand   string
-	Cal100  float64
-	Prot100 float64
-	Fat100  float64
-	Carb100 float64
-	Comment string
+	Key      string
+	Name     string
+	Brand    string
+	Cal100   float64
+	Prot100  float64
+	Fat100   float64
+	Carb100  float64
+	Comment  string
+	Beverage bool
+	// Nutrients per 100g
+	Nutrients Nutrients
+	Portions  []FoodPortion
+	// Density in g/ml, zero for default
+	Density float64
 }
 
 func (r *Food) Validate() bool {
@@ -33,7 +52,10 @@ func (r *Food) Validate() bool {
 		r.Cal100 >= 0 &&
 		r.Prot100 >= 0 &&
 		r.Fat100 >= 0 &&
-		r.Carb100 >= 0
+		r.Carb100 >= 0 &&
+		r.Nutrients.Validate() &&
+		!slices.ContainsFunc(r.Portions, func(p FoodPortion) bool { return !p.Validate() }) &&
+		r.Density >= 0
 }
 
 type Meal int
@@ -80,6 +102,8 @@ type Journal struct {
 	Meal       Meal
 	FoodKey    string
 	FoodWeight float64
+	// Portion entered by user (i.e. 2egg), empty for weight in grams
+	Portion string
 }
 
 func (r *Journal) Validate() bool {
@@ -89,16 +113,19 @@ func (r *Journal) Validate() bool {
 }
 
 type JournalReport struct {
-	Timestamp  Timestamp
-	Meal       Meal
-	FoodKey    string
-	FoodName   string
-	FoodBrand  string
-	FoodWeight float64
-	Cal        float64
-	Prot       float64
-	Fat        float64
-	Carb       float64
+	Timestamp    Timestamp
+	Meal         Meal
+	FoodKey      string
+	FoodName     string
+	FoodBrand    string
+	FoodWeight   float64
+	Cal          float64
+	Prot         float64
+	Fat          float64
+	Carb         float64
+	FoodBeverage bool
+	Nutrients    Nutrients
+	Portion      string
 }
 
 type JournalFoodStat struct {
@@ -109,6 +136,14 @@ type JournalFoodStat struct {
 	TotalCount     int64
 }
 
+type JournalMealSuggestion struct {
+	FoodKey   string
+	FoodName  string
+	FoodBrand string
+	Count     int64
+	Score     float64
+}
+
 type Weight struct {
 	Timestamp Timestamp
 	Value     float64
@@ -118,12 +153,160 @@ func (r *Weight) Validate() bool {
 	return r.Value > 0
 }
 
+type Sex string
+
+const (
+	SexMale   Sex = "m"
+	SexFemale Sex = "f"
+)
+
+type ActivityLevel int
+
+func NewActivityLevelFromString(a string) (ActivityLevel, error) {
+	switch a {
+	case "1", "2", "3", "4", "5":
+		return ActivityLevel(a[0] - '0'), nil
+	}
+	return ActivityLevel(0), ErrActivityLevelWrong
+}
+
+func (r ActivityLevel) Factor() float64 {
+	switch r {
+	case 1:
+		return 1.2
+	case 2:
+		return 1.375
+	case 3:
+		return 1.55
+	case 4:
+		return 1.725
+	case 5:
+		return 1.9
+	}
+
+	panic(ErrActivityLevelWrong)
+}
+
+func (r ActivityLevel) MustToString() string {
+	switch r {
+	case 1:
+		return "Сидячая активность"
+	case 2:
+		return "Легкая активность"
+	case 3:
+		return "Средняя активность"
+	case 4:
+		return "Полноценная активность"
+	case 5:
+		return "Супер активность"
+	}
+
+	panic(ErrActivityLevelWrong)
+}
+
+type Goal string
+
+const (
+	GoalLose     Goal = "lose"
+	GoalMaintain Goal = "keep"
+	GoalGain     Goal = "gain"
+)
+
+func NewGoalFromString(g string) (Goal, error) {
+	switch Goal(g) {
+	case GoalLose, GoalMaintain, GoalGain:
+		return Goal(g), nil
+	}
+	return Goal(""), ErrGoalWrong
+}
+
+func (r Goal) MustToString() string {
+	switch r {
+	case GoalLose:
+		return "Снижение веса"
+	case GoalMaintain:
+		return "Поддержание веса"
+	case GoalGain:
+		return "Набор веса"
+	}
+
+	panic(ErrGoalWrong)
+}
+
+// ReportFormat is file format of reports, empty format is HTML.
+type ReportFormat string
+
+const (
+	ReportFormatHTML ReportFormat = "html"
+	ReportFormatPDF  ReportFormat = "pdf"
+)
+
+func (r ReportFormat) Validate() bool {
+	return r == "" || r == ReportFormatHTML || r == ReportFormatPDF
+}
+
 type UserSettings struct {
-	CalLimit float64
+	CalLimit       float64
+	TDEEAutoUpdate bool
+	Profile        *UserProfile
+	WaterGoal      float64
+	WaterReminder  bool
+	// Daily nutrient limits
+	NutrientLimits Nutrients
+	// Default format of reports
+	ReportFormat ReportFormat
 }
 
 func (r *UserSettings) Validate() bool {
-	return r.CalLimit > 0
+	return r.CalLimit > 0 &&
+		(r.Profile == nil || r.Profile.Validate()) &&
+		r.WaterGoal >= 0 &&
+		r.NutrientLimits.Validate() &&
+		r.ReportFormat.Validate()
+}
+
+type UserProfile struct {
+	Sex           Sex
+	BirthDate     Timestamp
+	Height        float64
+	ActivityLevel ActivityLevel
+	BodyFat       float64
+	Goal          Goal
+	GoalRate      float64
+}
+
+func (r *UserProfile) Validate() bool {
+	return (r.Sex == SexMale || r.Sex == SexFemale) &&
+		r.Height > 0 &&
+		r.ActivityLevel >= 1 && r.ActivityLevel <= 5 &&
+		r.BodyFat >= 0 && r.BodyFat < 100 &&
+		(r.Goal == GoalLose || r.Goal == GoalMaintain || r.Goal == GoalGain) &&
+		r.GoalRate >= 0
+}
+
+func (r *UserProfile) Age(tz *time.Location, at time.Time) int {
+	birth := r.BirthDate.ToTime(tz)
+	age := at.Year() - birth.Year()
+	if at.Month() < birth.Month() || (at.Month() == birth.Month() && at.Day() < birth.Day()) {
+		age--
+	}
+	return age
+}
+
+type TDEEEstimate struct {
+	Timestamp Timestamp
+	Weeks     int64
+	TDEE      float64
+	ConfLow   float64
+	ConfHigh  float64
+	Applied   bool
+}
+
+func (r *TDEEEstimate) Validate() bool {
+	return r.Weeks > 0 &&
+		r.TDEE > 0 &&
+		r.ConfLow <= r.TDEE &&
+		r.TDEE <= r.ConfHigh
 }
 
 type Bundle struct {
@@ -150,40 +333,74 @@ func (r *Bundle) Validate() bool {
 }
 
 type Sport struct {
-	Key     string
-	Name    string
-	Unit    string
-	Comment string
+	Key        string
+	Name       string
+	Unit       string
+	Comment    string
+	MET        float64
+	CalPerUnit float64
+	SetKind    SportSetKind
 }
 
 func (r *Sport) Validate() bool {
+	_, errKind := NewSportSetKindFromString(string(r.SetKind))
 	return r.Key != "" &&
 		r.Name != "" &&
-		r.Unit != ""
+		r.Unit != "" &&
+		r.MET >= 0 &&
+		r.CalPerUnit >= 0 &&
+		errKind == nil
+}
+
+// ActivityCal returns burned calories for sets, duration in minutes
+// and body weight in kg. Calories per unit take precedence over MET,
+// which requires duration (of activity or sum of sets) and weight.
+func (r *Sport) ActivityCal(sets []SportSet, duration, weight float64) float64 {
+	total := NewSportSetsTotal(sets)
+	if r.CalPerUnit > 0 {
+		return total.Units() * r.CalPerUnit
+	}
+
+	if duration == 0 {
+		duration = total.Duration / 60
+	}
+
+	return r.MET * weight * duration / 60
 }
 
 type SportActivity struct {
+	ID        int64
 	SportKey  string
 	Timestamp Timestamp
-	Sets      []float64
+	Sets      []SportSet
+	Duration  float64
+	Cal       float64
 	Comment   string
 }
 
 func (r *SportActivity) Validate() bool {
 	allPositive := true
 	for _, s := range r.Sets {
-		if s <= 0 {
+		if !s.Validate() {
 			allPositive = false
 			break
 		}
 	}
-	return r.SportKey != "" && len(r.Sets) != 0 && allPositive
+	return r.SportKey != "" &&
+		len(r.Sets) != 0 &&
+		allPositive &&
+		r.Duration >= 0 &&
+		r.Cal >= 0
 }
 
 type SportActivityReport struct {
+	ID        int64
+	SportKey  string
 	SportName string
 	Timestamp Timestamp
-	Sets      []float64
+	Sets      []SportSet
+	Duration  float64
+	Cal       float64
 	Comment   string
 }
 
@@ -216,3 +433,70 @@ type MedicineIndicatorReport struct {
 	Timestamp    Timestamp
 	Value        float64
 }
+
+// Water is a volume of water drunk, in ml.
+type Water struct {
+	Timestamp Timestamp
+	Volume    float64
+}
+
+func (r *Water) Validate() bool {
+	return r.Volume > 0
+}
+
+// Sleep is a night of sleep, Timestamp is the day of wake up.
+type Sleep struct {
+	Timestamp Timestamp
+	BedTime   Timestamp
+	WakeTime  Timestamp
+	Quality   int64
+	Notes     string
+}
+
+func (r *Sleep) Validate() bool {
+	return r.BedTime < r.WakeTime &&
+		r.WakeTime-r.BedTime <= Timestamp((24*time.Hour).Milliseconds()) &&
+		r.Quality >= SleepQualityMin &&
+		r.Quality <= SleepQualityMax
+}
+
+// Duration returns sleep duration.
+func (r *Sleep) Duration() time.Duration {
+	return time.Duration(r.WakeTime-r.BedTime) * time.Millisecond
+}
+
+const (
+	SleepQualityMin = 1
+	SleepQualityMax = 5
+)
+
+// Fast is a fasting session, End is zero while fast is active.
+type Fast struct {
+	Start Timestamp
+	End   Timestamp
+}
+
+func (r *Fast) Validate() bool {
+	return r.Start > 0 &&
+		(r.End == 0 || r.End > r.Start)
+}
+
+// Active reports whether fast is not finished yet.
+func (r *Fast) Active() bool {
+	return r.End == 0
+}
+
+// Overlaps reports whether fast windows intersect, active fast is open-ended.
+func (r *Fast) Overlaps(f *Fast) bool {
+	return (r.Active() || r.End > f.Start) &&
+		(f.Active() || f.End > r.Start)
+}
+
+// Duration returns fast duration, active fast is counted up to now.
+func (r *Fast) Duration(now Timestamp) time.Duration {
+	end := r.End
+	if r.Active() {
+		end = now
+	}
+	return time.Duration(end-r.Start) * time.Millisecond
+}
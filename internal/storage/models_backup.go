@@ -1,5 +1,7 @@
 package storage
 
+import "encoding/json"
+
 type Backup struct {
 	Timestamp         Timestamp                 `json:"timestamp"`
 	Weight            []WeightBackup            `json:"weight"`
@@ -12,6 +14,12 @@ type Backup struct {
 	Medicine          []MedicineBackup          `json:"medicine"`
 	MedicineIndicator []MedicineIndicatorBackup `json:"medicine_indicator"`
 	TotalBurnedCal    []TotalBurnedCalBackup    `json:"total_burned_cal"`
+	TDEEEstimate      []TDEEEstimateBackup      `json:"tdee_estimate"`
+	Workout           []WorkoutBackup           `json:"workout"`
+	WorkoutPlan       []WorkoutPlanBackup       `json:"workout_plan"`
+	Water             []WaterBackup             `json:"water"`
+	Sleep             []SleepBackup             `json:"sleep"`
+	Fast              []FastBackup              `json:"fast"`
 }
 
 type WeightBackup struct {
@@ -21,35 +29,87 @@ type WeightBackup struct {
 }
 
 type SportBackup struct {
-	UserID  int64  `json:"user_id"`
-	Key     string `json:"key"`
-	Name    string `json:"name"`
-	Unit    string `json:"unit"`
-	Comment string `json:"comment"`
+	UserID     int64   `json:"user_id"`
+	Key        string  `json:"key"`
+	Name       string  `json:"name"`
+	Unit       string  `json:"unit"`
+	Comment    string  `json:"comment"`
+	MET        float64 `json:"met"`
+	CalPerUnit float64 `json:"cal_per_unit"`
+	SetKind    string  `json:"set_kind"`
 }
 
 type SportActivityBackup struct {
-	UserID    int64     `json:"user_id"`
-	SportKey  string    `json:"sport_key"`
-	Timestamp Timestamp `json:"timestamp"`
-	Sets      []float64 `json:"sets"`
+	UserID    int64            `json:"user_id"`
+	SportKey  string           `json:"sport_key"`
+	Timestamp Timestamp        `json:"timestamp"`
+	Sets      []SportSetBackup `json:"sets"`
+	Duration  float64          `json:"duration"`
+	Cal       float64          `json:"cal"`
+}
+
+type SportSetBackup struct {
+	Value    float64 `json:"value,omitempty"`
+	Reps     float64 `json:"reps,omitempty"`
+	Load     float64 `json:"load,omitempty"`
+	Duration float64 `json:"duration,omitempty"`
+	Distance float64 `json:"distance,omitempty"`
+	RPE      float64 `json:"rpe,omitempty"`
+}
+
+// UnmarshalJSON accepts plain number of backups made before typed sets.
+func (r *SportSetBackup) UnmarshalJSON(data []byte) error {
+	var val float64
+	if err := json.Unmarshal(data, &val); err == nil {
+		*r = SportSetBackup{Value: val}
+		return nil
+	}
+
+	type sportSetBackup SportSetBackup
+	return json.Unmarshal(data, (*sportSetBackup)(r))
 }
 
 type UserSettingsBackup struct {
-	UserID   int64   `json:"user_id"`
-	CalLimit float64 `json:"cal_limit"`
+	UserID         int64              `json:"user_id"`
+	CalLimit       float64            `json:"cal_limit"`
+	TDEEAutoUpdate bool               `json:"tdee_auto_update"`
+	Profile        *UserProfileBackup `json:"profile,omitempty"`
+	WaterGoal      float64            `json:"water_goal"`
+	WaterReminder  bool               `json:"water_reminder"`
+	NutrientLimits map[string]float64 `json:"nutrient_limits,omitempty"`
+	ReportFormat   string             `json:"report_format,omitempty"`
+}
+
+type UserProfileBackup struct {
+	Sex           string  `json:"sex"`
+	BirthDate     int64   `json:"birth_date"`
+	Height        float64 `json:"height"`
+	ActivityLevel int64   `json:"activity_level"`
+	BodyFat       float64 `json:"body_fat"`
+	Goal          string  `json:"goal"`
+	GoalRate      float64 `json:"goal_rate"`
 }
 
 type FoodBackup struct {
-	UserID  int64   `json:"user_id"`
-	Key     string  `json:"key"`
-	Name    string  `json:"name"`
-	Brand   string  `json:"brand"`
-	Cal100  float64 `json:"cal100"`
-	Prot100 float64 `json:"prot100"`
-	Fat100  float64 `json:"fat100"`
-	Carb100 float64 `json:"carb100"`
-	Comment string  `json:"comment"`
+	UserID    int64               `json:"user_id"`
+	Key       string              `json:"key"`
+	Name      string              `json:"name"`
+	Brand     string              `json:"brand"`
+	Cal100    float64             `json:"cal100"`
+	Prot100   float64             `json:"prot100"`
+	Fat100    float64             `json:"fat100"`
+	Carb100   float64             `json:"carb100"`
+	Comment   string              `json:"comment"`
+	Beverage  bool                `json:"beverage"`
+	Nutrients map[string]float64  `json:"nutrients,omitempty"`
+	Portions  []FoodPortionBackup `json:"portions,omitempty"`
+	Density   float64             `json:"density"`
+}
+
+type FoodPortionBackup struct {
+	Name   string  `json:"name"`
+	Amount float64 `json:"amount"`
+	Unit   string  `json:"unit"`
 }
 
 type BundleBackup struct {
@@ -64,6 +124,7 @@ type JournalBackup struct {
 	Meal       Meal      `json:"meal"`
 	FoodKey    string    `json:"food_key"`
 	FoodWeight float64   `json:"food_weight"`
+	Portion    string    `json:"portion"`
 }
 
 type MedicineBackup struct {
@@ -86,3 +147,53 @@ type TotalBurnedCalBackup struct {
 	Timestamp Timestamp `json:"timestamp"`
 	TotalCal  float64   `json:"totalCal"`
 }
+
+type TDEEEstimateBackup struct {
+	UserID    int64     `json:"user_id"`
+	Timestamp Timestamp `json:"timestamp"`
+	Weeks     int64     `json:"weeks"`
+	TDEE      float64   `json:"tdee"`
+	ConfLow   float64   `json:"conf_low"`
+	ConfHigh  float64   `json:"conf_high"`
+	Applied   bool      `json:"applied"`
+}
+
+type WorkoutBackup struct {
+	UserID  int64               `json:"user_id"`
+	Key     string              `json:"key"`
+	Name    string              `json:"name"`
+	Items   []WorkoutItemBackup `json:"items"`
+	Comment string              `json:"comment"`
+}
+
+type WorkoutItemBackup struct {
+	SportKey string           `json:"sport_key"`
+	Sets     []SportSetBackup `json:"sets"`
+}
+
+type WorkoutPlanBackup struct {
+	UserID     int64  `json:"user_id"`
+	Weekday    int64  `json:"weekday"`
+	WorkoutKey string `json:"workout_key"`
+}
+
+type WaterBackup struct {
+	UserID    int64     `json:"user_id"`
+	Timestamp Timestamp `json:"timestamp"`
+	Volume    float64   `json:"volume"`
+}
+
+type SleepBackup struct {
+	UserID    int64     `json:"user_id"`
+	Timestamp Timestamp `json:"timestamp"`
+	BedTime   Timestamp `json:"bed_time"`
+	WakeTime  Timestamp `json:"wake_time"`
+	Quality   int64     `json:"quality"`
+	Notes     string    `json:"notes"`
+}
+
+type FastBackup struct {
+	UserID int64     `json:"user_id"`
+	Start  Timestamp `json:"start"`
+	End    Timestamp `json:"end"`
+}
@@ -0,0 +1,141 @@
+package storage
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type Nutrient string
+
+const (
+	NutrientFiber  Nutrient = "fiber"
+	NutrientSugar  Nutrient = "sugar"
+	NutrientSalt   Nutrient = "salt"
+	NutrientSatFat Nutrient = "satfat"
+)
+
+// AllNutrients lists supported nutrients in display order.
+var AllNutrients = []Nutrient{
+	NutrientFiber,
+	NutrientSugar,
+	NutrientSalt,
+	NutrientSatFat,
+}
+
+func NewNutrientFromString(n string) (Nutrient, error) {
+	switch Nutrient(n) {
+	case NutrientFiber,
+		NutrientSugar,
+		NutrientSalt,
+		NutrientSatFat:
+		return Nutrient(n), nil
+	}
+	return Nutrient(""), ErrNutrientWrong
+}
+
+func (r Nutrient) MustToString() string {
+	switch r {
+	case NutrientFiber:
+		return "Клетчатка"
+	case NutrientSugar:
+		return "Сахар"
+	case NutrientSalt:
+		return "Соль"
+	case NutrientSatFat:
+		return "Насыщенные жиры"
+	}
+
+	panic(ErrNutrientWrong)
+}
+
+// Nutrients holds nutrient amounts in grams.
+type Nutrients map[Nutrient]float64
+
+// ParseNutrients parses key=value list separated by semicolon,
+// i.e. fiber=3;sugar=12.5. Empty string is empty list, zero values
+// are kept to remove nutrient on Merge.
+func ParseNutrients(arg string) (Nutrients, error) {
+	res := Nutrients{}
+	if strings.TrimSpace(arg) == "" {
+		return res, nil
+	}
+
+	for _, part := range strings.Split(arg, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, ErrNutrientWrong
+		}
+
+		n, err := NewNutrientFromString(strings.ToLower(strings.TrimSpace(key)))
+		if err != nil {
+			return nil, err
+		}
+
+		v, err := strconv.ParseFloat(strings.TrimSpace(val), 64)
+		if err != nil {
+			return nil, err
+		}
+
+		if v < 0 {
+			return nil, ErrNutrientWrong
+		}
+
+		res[n] = v
+	}
+
+	return res, nil
+}
+
+func (r Nutrients) Validate() bool {
+	for n, v := range r {
+		if _, err := NewNutrientFromString(string(n)); err != nil || v < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Scale returns nutrients multiplied by k.
+func (r Nutrients) Scale(k float64) Nutrients {
+	res := make(Nutrients, len(r))
+	for n, v := range r {
+		res[n] = v * k
+	}
+	return res
+}
+
+// Merge returns copy of nutrients with values from other set,
+// zero value in other removes nutrient.
+func (r Nutrients) Merge(other Nutrients) Nutrients {
+	res := make(Nutrients, len(r)+len(other))
+	for n, v := range r {
+		res[n] = v
+	}
+	for n, v := range other {
+		if v == 0 {
+			delete(res, n)
+			continue
+		}
+		res[n] = v
+	}
+	return res
+}
+
+// Add adds other nutrients to current.
+func (r Nutrients) Add(other Nutrients) {
+	for n, v := range other {
+		r[n] += v
+	}
+}
+
+// String returns nutrients in notation accepted by ParseNutrients.
+func (r Nutrients) String() string {
+	parts := []string{}
+	for _, n := range AllNutrients {
+		if v, ok := r[n]; ok && v != 0 {
+			parts = append(parts, fmt.Sprintf("%s=%s", n, strconv.FormatFloat(v, 'f', -1, 64)))
+		}
+	}
+	return strings.Join(parts, ";")
+}
@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNutrients(t *testing.T) {
+	for _, tt := range []struct {
+		arg  string
+		want Nutrients
+	}{
+		{arg: "", want: Nutrients{}},
+		{arg: "fiber=3", want: Nutrients{NutrientFiber: 3}},
+		{arg: " Sugar = 12.5 ;salt=0.4", want: Nutrients{NutrientSugar: 12.5, NutrientSalt: 0.4}},
+		{arg: "satfat=0;fiber=1", want: Nutrients{NutrientSatFat: 0, NutrientFiber: 1}},
+	} {
+		t.Run(tt.arg, func(t *testing.T) {
+			got, err := ParseNutrients(tt.arg)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	for _, arg := range []string{"fiber", "fiber=", "fiber=-1", "iron=1", "fiber=1;"} {
+		t.Run("invalid "+arg, func(t *testing.T) {
+			_, err := ParseNutrients(arg)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestNutrientsString(t *testing.T) {
+	for _, arg := range []string{"", "fiber=3", "fiber=1;sugar=12.5;salt=0.4;satfat=2"} {
+		n, err := ParseNutrients(arg)
+		require.NoError(t, err)
+		assert.Equal(t, arg, n.String())
+	}
+}
+
+func TestNutrientsMerge(t *testing.T) {
+	n := Nutrients{NutrientFiber: 2, NutrientSugar: 10}
+	assert.Equal(t,
+		Nutrients{NutrientFiber: 3, NutrientSalt: 1},
+		n.Merge(Nutrients{NutrientFiber: 3, NutrientSugar: 0, NutrientSalt: 1}))
+	assert.Equal(t, Nutrients{NutrientFiber: 2, NutrientSugar: 10}, n)
+	assert.Equal(t, Nutrients{}, Nutrients(nil).Merge(Nutrients{NutrientSalt: 0}))
+}
+
+func TestNutrientsScaleAdd(t *testing.T) {
+	n := Nutrients{NutrientFiber: 2, NutrientSugar: 10}
+	assert.Equal(t, Nutrients{NutrientFiber: 3, NutrientSugar: 15}, n.Scale(1.5))
+
+	n.Add(Nutrients{NutrientFiber: 1, NutrientSalt: 0.5})
+	assert.Equal(t, Nutrients{NutrientFiber: 3, NutrientSugar: 10, NutrientSalt: 0.5}, n)
+}
@@ -0,0 +1,188 @@
+package storage
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type PortionUnit string
+
+const (
+	PortionUnitGram PortionUnit = "g"
+	PortionUnitMl   PortionUnit = "ml"
+)
+
+// DefaultDensity is density of food without set one (water), g/ml.
+const DefaultDensity = 1.0
+
+func NewPortionUnitFromString(u string) (PortionUnit, error) {
+	switch strings.ToLower(u) {
+	case "", "g", "г":
+		return PortionUnitGram, nil
+	case "ml", "мл":
+		return PortionUnitMl, nil
+	}
+	return PortionUnit(""), ErrPortionUnitWrong
+}
+
+// FoodPortion is named food portion, i.e. egg=55g or cup=240ml.
+type FoodPortion struct {
+	Name   string
+	Amount float64
+	Unit   PortionUnit
+}
+
+func (r FoodPortion) Validate() bool {
+	_, errUnit := NewPortionUnitFromString(string(r.Unit))
+	return r.Name != "" &&
+		r.Amount > 0 &&
+		errUnit == nil
+}
+
+func (r FoodPortion) String() string {
+	return fmt.Sprintf("%s=%s%s", r.Name, strconv.FormatFloat(r.Amount, 'f', -1, 64), r.Unit)
+}
+
+// ParseFoodPortions parses portions separated by semicolon,
+// i.e. egg=55g;cup=240ml. Empty string is empty list.
+func ParseFoodPortions(arg string) ([]FoodPortion, error) {
+	res := []FoodPortion{}
+	if strings.TrimSpace(arg) == "" {
+		return res, nil
+	}
+
+	for _, part := range strings.Split(arg, ";") {
+		name, val, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, ErrFoodPortionInvalid
+		}
+
+		amount, err := ParseFoodAmount(val)
+		if err != nil {
+			return nil, err
+		}
+
+		unit, err := NewPortionUnitFromString(amount.Unit)
+		if err != nil {
+			return nil, err
+		}
+
+		p := FoodPortion{
+			Name:   strings.ToLower(strings.TrimSpace(name)),
+			Amount: amount.Value,
+			Unit:   unit,
+		}
+		if !p.Validate() || !isPortionName(p.Name) {
+			return nil, ErrFoodPortionInvalid
+		}
+
+		if slices.ContainsFunc(res, func(fp FoodPortion) bool { return fp.Name == p.Name }) {
+			return nil, ErrFoodPortionInvalid
+		}
+
+		res = append(res, p)
+	}
+
+	return res, nil
+}
+
+// FormatFoodPortions returns portions in notation accepted by ParseFoodPortions.
+func FormatFoodPortions(portions []FoodPortion) string {
+	parts := make([]string, 0, len(portions))
+	for _, p := range portions {
+		parts = append(parts, p.String())
+	}
+	return strings.Join(parts, ";")
+}
+
+// FoodAmount is amount of food in grams, ml or named portions.
+// Unit is empty for grams.
+type FoodAmount struct {
+	Value float64
+	Unit  string
+}
+
+// ParseFoodAmount parses number with optional unit suffix,
+// i.e. 150, 150g, 250ml, 2pcs, 1.5cup.
+func ParseFoodAmount(arg string) (FoodAmount, error) {
+	arg = strings.TrimSpace(arg)
+	i := strings.IndexFunc(arg, func(r rune) bool {
+		return !unicode.IsDigit(r) && r != '.'
+	})
+	if i == -1 {
+		i = len(arg)
+	}
+
+	val, err := strconv.ParseFloat(arg[:i], 64)
+	if err != nil {
+		return FoodAmount{}, err
+	}
+
+	if val <= 0 {
+		return FoodAmount{}, ErrFoodPortionInvalid
+	}
+
+	unit := strings.ToLower(strings.TrimSpace(arg[i:]))
+	if unit != "" && !isPortionName(unit) {
+		return FoodAmount{}, ErrFoodPortionInvalid
+	}
+
+	// Grams are stored without unit
+	if u, err := NewPortionUnitFromString(unit); err == nil && u == PortionUnitGram {
+		unit = ""
+	}
+
+	return FoodAmount{Value: val, Unit: unit}, nil
+}
+
+// IsGrams reports whether amount is plain weight in grams.
+func (r FoodAmount) IsGrams() bool {
+	return r.Unit == ""
+}
+
+func (r FoodAmount) String() string {
+	return strconv.FormatFloat(r.Value, 'f', -1, 64) + r.Unit
+}
+
+// Grams resolves amount to grams for food: ml are converted with food
+// density, named portions with food portions.
+func (r *Food) Grams(amount FoodAmount) (float64, error) {
+	if amount.IsGrams() {
+		return amount.Value, nil
+	}
+
+	if unit, err := NewPortionUnitFromString(amount.Unit); err == nil && unit == PortionUnitMl {
+		return amount.Value * r.DensityOrDefault(), nil
+	}
+
+	for _, p := range r.Portions {
+		if p.Name != amount.Unit {
+			continue
+		}
+
+		grams := p.Amount
+		if p.Unit == PortionUnitMl {
+			grams *= r.DensityOrDefault()
+		}
+
+		return amount.Value * grams, nil
+	}
+
+	return 0, ErrFoodPortionNotFound
+}
+
+func (r *Food) DensityOrDefault() float64 {
+	if r.Density == 0 {
+		return DefaultDensity
+	}
+	return r.Density
+}
+
+func isPortionName(name string) bool {
+	return name != "" && strings.IndexFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '_'
+	}) == -1
+}
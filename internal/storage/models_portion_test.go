@@ -0,0 +1,87 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFoodAmount(t *testing.T) {
+	for _, tt := range []struct {
+		arg  string
+		want FoodAmount
+	}{
+		{arg: "150", want: FoodAmount{Value: 150}},
+		{arg: "150g", want: FoodAmount{Value: 150}},
+		{arg: "150 г", want: FoodAmount{Value: 150}},
+		{arg: "250ml", want: FoodAmount{Value: 250, Unit: "ml"}},
+		{arg: "2pcs", want: FoodAmount{Value: 2, Unit: "pcs"}},
+		{arg: "1.5Cup", want: FoodAmount{Value: 1.5, Unit: "cup"}},
+		{arg: "2шт", want: FoodAmount{Value: 2, Unit: "шт"}},
+	} {
+		t.Run(tt.arg, func(t *testing.T) {
+			got, err := ParseFoodAmount(tt.arg)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	for _, arg := range []string{"", "0", "-1", "pcs", "2pcs1", "2 p-s"} {
+		t.Run("invalid "+arg, func(t *testing.T) {
+			_, err := ParseFoodAmount(arg)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParseFoodPortions(t *testing.T) {
+	portions, err := ParseFoodPortions("egg=55g; Cup=240ml;slice=30")
+	require.NoError(t, err)
+	assert.Equal(t, []FoodPortion{
+		{Name: "egg", Amount: 55, Unit: PortionUnitGram},
+		{Name: "cup", Amount: 240, Unit: PortionUnitMl},
+		{Name: "slice", Amount: 30, Unit: PortionUnitGram},
+	}, portions)
+	assert.Equal(t, "egg=55g;cup=240ml;slice=30g", FormatFoodPortions(portions))
+
+	portions, err = ParseFoodPortions("")
+	require.NoError(t, err)
+	assert.Empty(t, portions)
+
+	for _, arg := range []string{"egg", "egg=2pcs", "egg=0g", "=5g", "egg=5g;egg=6g", "1=5g"} {
+		t.Run("invalid "+arg, func(t *testing.T) {
+			_, err := ParseFoodPortions(arg)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestFoodGrams(t *testing.T) {
+	food := &Food{
+		Portions: []FoodPortion{
+			{Name: "egg", Amount: 55, Unit: PortionUnitGram},
+			{Name: "cup", Amount: 240, Unit: PortionUnitMl},
+		},
+	}
+
+	for _, tt := range []struct {
+		amount  FoodAmount
+		density float64
+		want    float64
+	}{
+		{amount: FoodAmount{Value: 150}, want: 150},
+		{amount: FoodAmount{Value: 2, Unit: "egg"}, want: 110},
+		{amount: FoodAmount{Value: 250, Unit: "ml"}, want: 250},
+		{amount: FoodAmount{Value: 250, Unit: "ml"}, density: 1.03, want: 257.5},
+		{amount: FoodAmount{Value: 0.5, Unit: "cup"}, density: 1.5, want: 180},
+	} {
+		food.Density = tt.density
+		got, err := food.Grams(tt.amount)
+		require.NoError(t, err)
+		assert.InDelta(t, tt.want, got, 1e-9)
+	}
+
+	_, err := food.Grams(FoodAmount{Value: 1, Unit: "slice"})
+	assert.ErrorIs(t, err, ErrFoodPortionNotFound)
+}
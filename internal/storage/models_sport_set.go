@@ -0,0 +1,286 @@
+package storage
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type SportSetKind string
+
+const (
+	SportSetKindValue    SportSetKind = "value"
+	SportSetKindReps     SportSetKind = "reps"
+	SportSetKindRepsLoad SportSetKind = "reps_load"
+	SportSetKindDuration SportSetKind = "duration"
+	SportSetKindDistance SportSetKind = "distance"
+)
+
+func NewSportSetKindFromString(k string) (SportSetKind, error) {
+	switch SportSetKind(k) {
+	case SportSetKindValue,
+		SportSetKindReps,
+		SportSetKindRepsLoad,
+		SportSetKindDuration,
+		SportSetKindDistance:
+		return SportSetKind(k), nil
+	}
+	return SportSetKind(""), ErrSportSetKindWrong
+}
+
+func (r SportSetKind) MustToString() string {
+	switch r {
+	case SportSetKindValue:
+		return "Значение"
+	case SportSetKindReps:
+		return "Повторения"
+	case SportSetKindRepsLoad:
+		return "Повторения x вес"
+	case SportSetKindDuration:
+		return "Время"
+	case SportSetKindDistance:
+		return "Дистанция x время"
+	}
+
+	panic(ErrSportSetKindWrong)
+}
+
+// Normalize converts parsed sets to sport set kind: plain value goes
+// to the field of the kind, fields not allowed for the kind are errors.
+func (r SportSetKind) Normalize(sets []SportSet) ([]SportSet, error) {
+	res := make([]SportSet, 0, len(sets))
+	for _, s := range sets {
+		switch r {
+		case SportSetKindValue:
+			if s.Reps != 0 || s.Load != 0 || s.Duration != 0 || s.Distance != 0 {
+				return nil, ErrSportActivityInvalid
+			}
+		case SportSetKindReps, SportSetKindRepsLoad:
+			s.Reps, s.Value = s.Reps+s.Value, 0
+			if s.Reps == 0 || s.Duration != 0 || s.Distance != 0 {
+				return nil, ErrSportActivityInvalid
+			}
+			if r == SportSetKindReps && s.Load != 0 {
+				return nil, ErrSportActivityInvalid
+			}
+		case SportSetKindDuration:
+			s.Duration, s.Value = s.Duration+s.Value, 0
+			if s.Duration == 0 || s.Reps != 0 || s.Load != 0 || s.Distance != 0 {
+				return nil, ErrSportActivityInvalid
+			}
+		case SportSetKindDistance:
+			s.Distance, s.Value = s.Distance+s.Value, 0
+			if s.Distance == 0 || s.Reps != 0 || s.Load != 0 {
+				return nil, ErrSportActivityInvalid
+			}
+		default:
+			return nil, ErrSportSetKindWrong
+		}
+		res = append(res, s)
+	}
+
+	return res, nil
+}
+
+// SportSet is a single set of sport activity. Duration is in seconds,
+// load and distance are in units of sport.
+type SportSet struct {
+	Value    float64
+	Reps     float64
+	Load     float64
+	Duration float64
+	Distance float64
+	RPE      float64
+}
+
+// ParseSportSet parses set notation:
+//
+//	10       - plain value (reps, seconds or distance depending on sport)
+//	8x60     - reps x load
+//	27:30    - duration (M:SS or H:MM:SS)
+//	5x27:30  - distance x duration
+//
+// Each form accepts optional RPE suffix, i.e. 8x60@8. Separator x may be
+// latin or cyrillic.
+func ParseSportSet(arg string) (SportSet, error) {
+	var s SportSet
+
+	arg = strings.TrimSpace(arg)
+	if main, rpe, ok := strings.Cut(arg, "@"); ok {
+		val, err := strconv.ParseFloat(rpe, 64)
+		if err != nil {
+			return SportSet{}, err
+		}
+		s.RPE = val
+		arg = main
+	}
+
+	// Accept both latin and cyrillic x
+	arg = strings.ReplaceAll(strings.ToLower(arg), "х", "x")
+
+	a, b, hasB := strings.Cut(arg, "x")
+	switch {
+	case hasB && strings.Contains(b, ":"):
+		dist, err := strconv.ParseFloat(a, 64)
+		if err != nil {
+			return SportSet{}, err
+		}
+		dur, err := parseSportSetDuration(b)
+		if err != nil {
+			return SportSet{}, err
+		}
+		s.Distance, s.Duration = dist, dur
+	case hasB:
+		reps, err := strconv.ParseFloat(a, 64)
+		if err != nil {
+			return SportSet{}, err
+		}
+		load, err := strconv.ParseFloat(b, 64)
+		if err != nil {
+			return SportSet{}, err
+		}
+		s.Reps, s.Load = reps, load
+	case strings.Contains(a, ":"):
+		dur, err := parseSportSetDuration(a)
+		if err != nil {
+			return SportSet{}, err
+		}
+		s.Duration = dur
+	default:
+		val, err := strconv.ParseFloat(a, 64)
+		if err != nil {
+			return SportSet{}, err
+		}
+		s.Value = val
+	}
+
+	if !s.Validate() {
+		return SportSet{}, ErrSportActivityInvalid
+	}
+
+	return s, nil
+}
+
+func (r SportSet) Validate() bool {
+	return r.Value >= 0 &&
+		r.Reps >= 0 &&
+		r.Load >= 0 &&
+		r.Duration >= 0 &&
+		r.Distance >= 0 &&
+		r.RPE >= 0 && r.RPE <= 10 &&
+		(r.Value > 0 || r.Reps > 0 || r.Duration > 0 || r.Distance > 0)
+}
+
+// String returns set in notation accepted by ParseSportSet.
+func (r SportSet) String() string {
+	var res string
+	switch {
+	case r.Distance > 0 && r.Duration > 0:
+		res = fmt.Sprintf("%sx%s", formatSportSetFloat(r.Distance), FormatSportSetDuration(r.Duration))
+	case r.Distance > 0:
+		res = formatSportSetFloat(r.Distance)
+	case r.Reps > 0 && r.Load > 0:
+		res = fmt.Sprintf("%sx%s", formatSportSetFloat(r.Reps), formatSportSetFloat(r.Load))
+	case r.Reps > 0:
+		res = formatSportSetFloat(r.Reps)
+	case r.Duration > 0:
+		res = FormatSportSetDuration(r.Duration)
+	default:
+		res = formatSportSetFloat(r.Value)
+	}
+
+	if r.RPE > 0 {
+		res += "@" + formatSportSetFloat(r.RPE)
+	}
+
+	return res
+}
+
+type SportSetsTotal struct {
+	Value    float64
+	Reps     float64
+	Tonnage  float64
+	Duration float64
+	Distance float64
+}
+
+func NewSportSetsTotal(sets []SportSet) SportSetsTotal {
+	var t SportSetsTotal
+	for _, s := range sets {
+		t.Value += s.Value
+		t.Reps += s.Reps
+		t.Tonnage += s.Reps * s.Load
+		t.Duration += s.Duration
+		t.Distance += s.Distance
+	}
+	return t
+}
+
+func (r SportSetsTotal) Add(t SportSetsTotal) SportSetsTotal {
+	return SportSetsTotal{
+		Value:    r.Value + t.Value,
+		Reps:     r.Reps + t.Reps,
+		Tonnage:  r.Tonnage + t.Tonnage,
+		Duration: r.Duration + t.Duration,
+		Distance: r.Distance + t.Distance,
+	}
+}
+
+// Units returns total amount in units of sport: value, reps or distance,
+// duration in minutes for pure timed sets.
+func (r SportSetsTotal) Units() float64 {
+	if units := r.Value + r.Reps + r.Distance; units > 0 {
+		return units
+	}
+	return r.Duration / 60
+}
+
+// Main returns the most significant total for progress tracking.
+func (r SportSetsTotal) Main() float64 {
+	switch {
+	case r.Tonnage > 0:
+		return r.Tonnage
+	case r.Distance > 0:
+		return r.Distance
+	case r.Reps > 0:
+		return r.Reps
+	case r.Value > 0:
+		return r.Value
+	default:
+		return r.Duration / 60
+	}
+}
+
+func FormatSportSetDuration(sec float64) string {
+	total := int64(sec + 0.5)
+	h, m, s := total/3600, total%3600/60, total%60
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, s)
+	}
+	return fmt.Sprintf("%d:%02d", m, s)
+}
+
+func parseSportSetDuration(arg string) (float64, error) {
+	parts := strings.Split(arg, ":")
+	if len(parts) > 3 {
+		return 0, fmt.Errorf("wrong duration")
+	}
+
+	var res float64
+	for i, p := range parts {
+		val, err := strconv.ParseFloat(p, 64)
+		if err != nil {
+			return 0, err
+		}
+		if val < 0 || (i > 0 && val >= 60) {
+			return 0, fmt.Errorf("wrong duration")
+		}
+		res = res*60 + val
+	}
+
+	return res, nil
+}
+
+func formatSportSetFloat(val float64) string {
+	return strconv.FormatFloat(val, 'g', -1, 64)
+}
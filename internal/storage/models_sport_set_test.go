@@ -0,0 +1,65 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSportSet(t *testing.T) {
+	for _, tt := range []struct {
+		arg  string
+		want SportSet
+	}{
+		{arg: "10", want: SportSet{Value: 10}},
+		{arg: " 12.5 ", want: SportSet{Value: 12.5}},
+		{arg: "8x60", want: SportSet{Reps: 8, Load: 60}},
+		{arg: "8X62.5@8.5", want: SportSet{Reps: 8, Load: 62.5, RPE: 8.5}},
+		{arg: "8х60", want: SportSet{Reps: 8, Load: 60}},
+		{arg: "1:30", want: SportSet{Duration: 90}},
+		{arg: "1:02:30", want: SportSet{Duration: 3750}},
+		{arg: "5x27:30", want: SportSet{Distance: 5, Duration: 1650}},
+		{arg: "10@7", want: SportSet{Value: 10, RPE: 7}},
+	} {
+		t.Run(tt.arg, func(t *testing.T) {
+			got, err := ParseSportSet(tt.arg)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	for _, arg := range []string{"", "0", "-1", "x60", "8x", "1:60", "1:2:3:4", "10@11", "10@", "abc"} {
+		t.Run("invalid "+arg, func(t *testing.T) {
+			_, err := ParseSportSet(arg)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestSportSetString(t *testing.T) {
+	for _, arg := range []string{"10", "8x60", "8x62.5@8", "1:30", "1:02:30", "5x27:30"} {
+		set, err := ParseSportSet(arg)
+		require.NoError(t, err)
+		assert.Equal(t, arg, set.String())
+	}
+}
+
+func TestSportSetKindNormalize(t *testing.T) {
+	sets, err := SportSetKindRepsLoad.Normalize([]SportSet{{Reps: 8, Load: 60}, {Value: 10}})
+	require.NoError(t, err)
+	assert.Equal(t, []SportSet{{Reps: 8, Load: 60}, {Reps: 10}}, sets)
+
+	sets, err = SportSetKindDistance.Normalize([]SportSet{{Value: 5}, {Distance: 5, Duration: 1650}})
+	require.NoError(t, err)
+	assert.Equal(t, []SportSet{{Distance: 5}, {Distance: 5, Duration: 1650}}, sets)
+
+	_, err = SportSetKindValue.Normalize([]SportSet{{Reps: 8, Load: 60}})
+	assert.ErrorIs(t, err, ErrSportActivityInvalid)
+
+	_, err = SportSetKindReps.Normalize([]SportSet{{Reps: 8, Load: 60}})
+	assert.ErrorIs(t, err, ErrSportActivityInvalid)
+
+	_, err = SportSetKindDuration.Normalize([]SportSet{{Distance: 5}})
+	assert.ErrorIs(t, err, ErrSportActivityInvalid)
+}
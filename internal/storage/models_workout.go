@@ -0,0 +1,56 @@
+package storage
+
+import "time"
+
+// Workout is a template of training session: list of sports with target sets.
+type Workout struct {
+	Key     string
+	Name    string
+	Items   []WorkoutItem
+	Comment string
+}
+
+func (r *Workout) Validate() bool {
+	if r.Key == "" || r.Name == "" || len(r.Items) == 0 {
+		return false
+	}
+
+	for _, item := range r.Items {
+		if !item.Validate() {
+			return false
+		}
+	}
+
+	return true
+}
+
+type WorkoutItem struct {
+	SportKey string
+	Sets     []SportSet
+}
+
+func (r *WorkoutItem) Validate() bool {
+	if r.SportKey == "" || len(r.Sets) == 0 {
+		return false
+	}
+
+	for _, s := range r.Sets {
+		if !s.Validate() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// WorkoutPlanDay is a workout assigned to weekday.
+type WorkoutPlanDay struct {
+	Weekday    time.Weekday
+	WorkoutKey string
+}
+
+func (r *WorkoutPlanDay) Validate() bool {
+	return r.Weekday >= time.Sunday &&
+		r.Weekday <= time.Saturday &&
+		r.WorkoutKey != ""
+}
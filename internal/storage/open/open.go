@@ -0,0 +1,26 @@
+// Package open opens storage of services by settings.
+package open
+
+import (
+	"github.com/devldavydov/myhealth/internal/storage"
+	pg "github.com/devldavydov/myhealth/internal/storage/postgres"
+	slite "github.com/devldavydov/myhealth/internal/storage/sqlite"
+	"go.uber.org/zap"
+)
+
+// NewStorage opens PostgreSQL storage if DSN is set, SQLite otherwise.
+func NewStorage(dbFilePath, dbDSN string, logger *zap.Logger) (storage.Storage, error) {
+	if dbDSN != "" {
+		stg, err := pg.NewStoragePostgres(dbDSN, logger)
+		if err != nil {
+			return nil, err
+		}
+		return stg, nil
+	}
+
+	stg, err := slite.NewStorageSQLite(dbFilePath, logger)
+	if err != nil {
+		return nil, err
+	}
+	return stg, nil
+}
@@ -0,0 +1,988 @@
+package postgres
+
+const (
+	//
+	// System.
+	//
+
+	_sqlCreateTableSystem = `
+	CREATE TABLE IF NOT EXISTS system (
+		migration_id BIGINT
+	);
+	`
+	_sqlGetLastMigrationID = `
+	SELECT migration_id FROM system
+	`
+	_sqlInsertInitialMigrationID = `
+	INSERT INTO system (migration_id) VALUES(0)
+	`
+	_sqlUpdateLastMigrationID = `
+	UPDATE system
+	SET migration_id = $1
+	`
+
+	//
+	// Weight.
+	//
+
+	_sqlCreateTableWeight = `
+	CREATE TABLE weight (
+        user_id   BIGINT           NOT NULL,
+        timestamp BIGINT           NOT NULL,
+        value     DOUBLE PRECISION NOT NULL,
+        PRIMARY KEY (user_id, timestamp)
+    )
+	`
+
+	_sqlGetWeight = `
+	SELECT timestamp, value
+	FROM weight
+	WHERE user_id = $1 AND timestamp = $2
+	`
+
+	_sqlGetLastWeight = `
+	SELECT timestamp, value
+	FROM weight
+	WHERE user_id = $1 AND timestamp <= $2
+	ORDER BY timestamp DESC
+	LIMIT 1
+	`
+
+	_sqlGetWeightList = `
+	SELECT timestamp, value
+    FROM weight
+    WHERE
+        user_id = $1 AND
+        timestamp >= $2 AND
+        timestamp <= $3
+    ORDER BY
+        timestamp
+	`
+
+	_sqlGetWeightListDesc = `
+	SELECT timestamp, value
+    FROM weight
+    WHERE
+        user_id = $1 AND
+        timestamp >= $2 AND
+        timestamp <= $3
+    ORDER BY
+        timestamp DESC
+	`
+
+	_sqlWeightBackup = `
+	SELECT user_id, timestamp, value
+    FROM weight
+	ORDER BY user_id, timestamp
+	`
+
+	_sqlSetWeight = `
+	INSERT INTO weight (user_id, timestamp, value)
+	VALUES ($1, $2, $3)
+	ON CONFLICT (user_id, timestamp) DO
+	UPDATE SET value = $3
+	`
+
+	_sqlDeleteWeight = `
+	DELETE
+	FROM weight
+	WHERE user_id = $1 AND timestamp >= $2 AND timestamp <= $3
+	`
+
+	//
+	// Sport.
+	//
+
+	_sqlCreateTableSport = `
+	CREATE TABLE sport (
+        user_id      BIGINT           NOT NULL,
+        key          TEXT COLLATE "C" NOT NULL,
+        name         TEXT COLLATE "C" NOT NULL,
+        comment      TEXT COLLATE "C" NOT NULL DEFAULT '',
+        unit         TEXT COLLATE "C" NOT NULL DEFAULT 'шт',
+        met          DOUBLE PRECISION NOT NULL DEFAULT 0,
+        cal_per_unit DOUBLE PRECISION NOT NULL DEFAULT 0,
+        set_kind     TEXT             NOT NULL DEFAULT 'value',
+        PRIMARY KEY (user_id, key)
+    )
+	`
+
+	_sqlGetSport = `
+	SELECT key, name, comment, unit, met, cal_per_unit, set_kind
+    FROM sport
+    WHERE user_id = $1 AND key = $2
+	`
+
+	_sqlGetSportList = `
+	SELECT key, name, comment, unit, met, cal_per_unit, set_kind
+    FROM sport
+    WHERE user_id = $1
+	ORDER BY name
+	`
+
+	_sqlSetSport = `
+	INSERT INTO sport (user_id, key, name, comment, unit, met, cal_per_unit, set_kind)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	ON CONFLICT (user_id, key) DO
+	UPDATE SET name = $3, comment = $4, unit = $5, met = $6, cal_per_unit = $7, set_kind = $8
+	`
+
+	_sqlDeleteSport = `
+	DELETE
+	FROM sport
+    WHERE user_id = $1 AND key = $2
+	`
+
+	_sqlSportBackup = `
+	SELECT user_id, key, name, comment, unit, met, cal_per_unit, set_kind
+    FROM sport
+	ORDER BY user_id, key
+	`
+
+	//
+	// SportActivity.
+	//
+
+	_sqlCreateTableSportActivity = `
+	CREATE TABLE sport_activity (
+        id        BIGSERIAL        PRIMARY KEY,
+        user_id   BIGINT           NOT NULL,
+        timestamp BIGINT           NOT NULL,
+        sport_key TEXT COLLATE "C" NOT NULL,
+        sets      TEXT             NOT NULL,
+        comment   TEXT             NOT NULL DEFAULT '',
+        duration  DOUBLE PRECISION NOT NULL DEFAULT 0,
+        cal       DOUBLE PRECISION NOT NULL DEFAULT 0,
+        FOREIGN KEY (user_id, sport_key) REFERENCES sport(user_id, key) ON DELETE RESTRICT
+    )
+	`
+
+	_sqlAddSportActivity = `
+    INSERT INTO sport_activity (
+        user_id, timestamp, sport_key, sets, comment, duration, cal
+    )
+    VALUES ($1, $2, $3, $4, $5, $6, $7)
+    RETURNING id
+	`
+
+	_sqlUpdateSportActivity = `
+    UPDATE sport_activity
+    SET
+        timestamp = $1,
+        sport_key = $2,
+        sets = $3,
+        comment = $4,
+        duration = $5,
+        cal = $6
+    WHERE
+        user_id = $7 AND
+        id = $8
+	`
+
+	_sqlDeleteSportActivity = `
+	DELETE FROM sport_activity
+    WHERE
+        user_id = $1 AND
+        timestamp >= $2 AND
+        timestamp <= $3 AND
+        sport_key = $4
+	`
+
+	_sqlDeleteSportActivityByID = `
+	DELETE FROM sport_activity
+    WHERE
+        user_id = $1 AND
+        id = $2
+	`
+
+	_sqlGetSportActivityReport = `
+    SELECT sa.timestamp, concat(s.name, ' [', s.unit, ']') as sport_name, sa.sets, sa.duration, sa.cal, sa.comment, sa.sport_key, sa.id
+    FROM
+        sport_activity sa,
+        sport s
+    WHERE
+        sa.sport_key = s.key AND	
+        s.user_id = $1 AND
+		sa.user_id = $1 AND
+        sa.timestamp >= $2 AND
+        sa.timestamp <= $3
+    ORDER BY
+        sa.timestamp,
+        sport_name,
+        sa.id
+	`
+
+	_sqlSportActivityBackup = `
+	SELECT user_id, timestamp, sport_key, sets, duration, cal
+	FROM sport_activity
+	ORDER BY user_id, timestamp, id
+	`
+
+	_sqlGetSportActivityHistory = `
+	SELECT id, timestamp, sets, duration, cal, comment
+	FROM sport_activity
+	WHERE user_id = $1 AND sport_key = $2
+	ORDER BY timestamp, id
+	`
+
+	_sqlGetSportActivityCal = `
+	SELECT coalesce(sum(cal), 0)
+	FROM sport_activity
+	WHERE user_id = $1 AND timestamp >= $2 AND timestamp <= $3
+	`
+
+	_sqlCreateTableSportActivityIndexUserIDTimestamp = `
+	CREATE INDEX sport_activity_userid_timestamp ON sport_activity(user_id, timestamp);
+	`
+
+	//
+	// Medicine.
+	//
+
+	_sqlCreateTableMedicine = `
+	CREATE TABLE medicine (
+        user_id BIGINT           NOT NULL,
+        key     TEXT COLLATE "C" NOT NULL,
+        name    TEXT COLLATE "C" NOT NULL,
+        comment TEXT COLLATE "C" NOT NULL DEFAULT '',
+        unit    TEXT COLLATE "C" NOT NULL DEFAULT 'г',
+        PRIMARY KEY (user_id, key)
+    )
+	`
+
+	_sqlGetMedicine = `
+	SELECT key, name, comment, unit
+    FROM medicine
+    WHERE user_id = $1 AND key = $2
+	`
+
+	_sqlGetMedicineList = `
+	SELECT key, name, comment, unit
+    FROM medicine
+    WHERE user_id = $1
+	ORDER BY name
+	`
+
+	_sqlSetMedicine = `
+	INSERT INTO medicine (user_id, key, name, comment, unit)
+	VALUES ($1, $2, $3, $4, $5)
+	ON CONFLICT (user_id, key) DO
+	UPDATE SET name = $3, comment = $4, unit = $5
+	`
+
+	_sqlDeleteMedicine = `
+	DELETE
+	FROM medicine
+    WHERE user_id = $1 AND key = $2
+	`
+
+	_sqlMedicineBackup = `
+	SELECT user_id, key, name, comment, unit
+    FROM medicine
+	ORDER BY user_id, key
+	`
+
+	//
+	// MedicineIndicator.
+	//
+
+	_sqlCreateTableMedicineIndicator = `
+	CREATE TABLE medicine_indicator (
+        user_id      BIGINT           NOT NULL,
+        timestamp    BIGINT           NOT NULL,
+        medicine_key TEXT COLLATE "C" NOT NULL,
+        value        DOUBLE PRECISION NOT NULL,
+        PRIMARY KEY (user_id, timestamp, medicine_key),
+        FOREIGN KEY (user_id, medicine_key) REFERENCES medicine(user_id, key) ON DELETE RESTRICT
+    )
+	`
+
+	_sqlSetMedicineIndicator = `
+    INSERT INTO medicine_indicator (
+        user_id, timestamp, medicine_key, value
+    )
+    VALUES ($1, $2, $3, $4)
+    ON CONFLICT (user_id, timestamp, medicine_key) DO
+    UPDATE SET
+        value = $4	
+	`
+
+	_sqlDeleteMedicineIndicator = `
+	DELETE FROM medicine_indicator
+    WHERE
+        user_id = $1 AND
+        timestamp >= $2 AND
+        timestamp <= $3 AND
+        medicine_key = $4
+	`
+
+	_sqlGetMedicineIndicatorReport = `
+    SELECT mi.timestamp, concat(m.name, ' [', m.unit, ']') as medicine_name, mi.value
+    FROM
+        medicine_indicator mi,
+        medicine m
+    WHERE
+        mi.medicine_key = m.key AND	
+        m.user_id = $1 AND
+		mi.user_id = $1 AND
+        mi.timestamp >= $2 AND
+        mi.timestamp <= $3
+    ORDER BY
+        mi.timestamp,
+        medicine_name	
+	`
+
+	_sqlMedicineIndicatorBackup = `
+	SELECT user_id, timestamp, medicine_key, value
+	FROM medicine_indicator
+	ORDER BY user_id, timestamp, medicine_key
+	`
+
+	//
+	// UserSettings.
+	//
+
+	_sqlCreateTableUserSettings = `
+	CREATE TABLE user_settings (
+        user_id         BIGINT           NOT NULL PRIMARY KEY,
+        cal_limit       DOUBLE PRECISION NOT NULL,
+        tdee_auto       BOOLEAN          NOT NULL DEFAULT FALSE,
+        profile         TEXT             NULL,
+        water_goal      DOUBLE PRECISION NOT NULL DEFAULT 0,
+        water_reminder  BOOLEAN          NOT NULL DEFAULT FALSE,
+        nutrient_limits TEXT             NULL,
+        report_format   TEXT             NOT NULL DEFAULT ''
+    )
+	`
+
+	_sqlGetUserSettings = `
+	SELECT cal_limit, tdee_auto, profile, water_goal, water_reminder, nutrient_limits,
+        report_format
+    FROM user_settings
+    WHERE user_id = $1
+	`
+
+	_sqlSetUserSettings = `
+	INSERT INTO user_settings (
+        user_id, cal_limit, tdee_auto, profile, water_goal, water_reminder,
+        nutrient_limits, report_format
+    )
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+    ON CONFLICT (user_id) DO
+    UPDATE SET
+        cal_limit = $2,
+        tdee_auto = $3,
+        profile = $4,
+        water_goal = $5,
+        water_reminder = $6,
+        nutrient_limits = $7,
+        report_format = $8
+	`
+
+	_sqlUserSettingsBackup = `
+	SELECT user_id, cal_limit, tdee_auto, profile, water_goal, water_reminder, nutrient_limits,
+        report_format
+    FROM user_settings
+    ORDER BY user_id
+	`
+
+	//
+	// Food.
+	//
+
+	_sqlCreateTableFood = `
+	CREATE TABLE food (
+        user_id   BIGINT           NOT NULL,
+        key       TEXT COLLATE "C" NOT NULL,
+        name      TEXT COLLATE "C" NOT NULL,
+        brand     TEXT COLLATE "C" NOT NULL DEFAULT '',
+        cal100    DOUBLE PRECISION NOT NULL,
+        prot100   DOUBLE PRECISION NOT NULL,
+        fat100    DOUBLE PRECISION NOT NULL,
+        carb100   DOUBLE PRECISION NOT NULL,
+        comment   TEXT COLLATE "C" NOT NULL DEFAULT '',
+        beverage  BOOLEAN          NOT NULL DEFAULT FALSE,
+        nutrients TEXT             NULL,
+        portions  TEXT             NULL,
+        density   DOUBLE PRECISION NOT NULL DEFAULT 0,
+        PRIMARY KEY (user_id, key)
+    )
+	`
+
+	_sqlGetFood = `
+	SELECT 
+        key, name, brand, cal100,
+        prot100, fat100, carb100, comment,
+        beverage, nutrients, portions, density
+    FROM food
+    WHERE user_id = $1 AND key = $2
+	`
+
+	_sqlGetFoodList = `
+	SELECT 
+        key, name, brand, cal100,
+        prot100, fat100, carb100, comment,
+        beverage, nutrients, portions, density
+    FROM food
+    WHERE user_id = $1
+	ORDER BY name, key
+	`
+
+	_sqlSetFood = `
+	INSERT INTO food (
+        user_id, key, name, brand, cal100,
+        prot100, fat100, carb100, comment,
+        beverage, nutrients, portions, density
+    )
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+    ON CONFLICT (user_id, key) DO
+    UPDATE SET
+        name = $3, brand = $4, cal100 = $5,
+        prot100 = $6, fat100 = $7, carb100 = $8,
+        comment = $9, beverage = $10, nutrients = $11,
+        portions = $12, density = $13
+	`
+
+	_sqlDeleteFood = `
+	DELETE FROM food
+    WHERE user_id = $1 AND key = $2
+	`
+
+	_sqlFoodBackup = `
+	SELECT 
+        user_id, key, name, brand, cal100,
+        prot100, fat100, carb100, comment,
+        beverage, nutrients, portions, density
+    FROM food
+	ORDER BY user_id, key
+	`
+
+	//
+	// Bundle.
+	//
+
+	_sqlCreateTableBundle = `
+	CREATE TABLE bundle (
+        user_id BIGINT           NOT NULL,
+        key     TEXT COLLATE "C" NOT NULL,
+        data    TEXT             NOT NULL,
+        PRIMARY KEY (user_id, key)
+    )
+	`
+
+	_sqlGetBundle = `
+	SELECT key, data
+    FROM bundle
+    WHERE user_id = $1 AND key = $2
+	`
+
+	_sqlGetBundleList = `
+	SELECT key, data
+    FROM bundle
+    WHERE user_id = $1
+	ORDER BY key
+	`
+
+	_sqlSetBundle = `
+	INSERT INTO bundle (
+        user_id, key, data
+    )
+    VALUES ($1, $2, $3)
+    ON CONFLICT (user_id, key) DO
+    UPDATE SET
+        data = $3
+	`
+
+	_sqlDeleteBundle = `
+	DELETE FROM bundle
+    WHERE user_id = $1 AND key = $2
+	`
+
+	_sqlBundleBackup = `
+	SELECT user_id, key, data
+	FROM bundle
+	ORDER BY user_id, key
+	`
+
+	//
+	// Journal.
+	//
+
+	_sqlCreateTableJournal = `
+	CREATE TABLE journal (
+        user_id    BIGINT           NOT NULL,
+        timestamp  BIGINT           NOT NULL,
+        meal       INTEGER          NOT NULL,
+        foodkey    TEXT COLLATE "C" NOT NULL,
+        foodweight DOUBLE PRECISION NOT NULL,
+        portion    TEXT             NOT NULL DEFAULT '',
+        PRIMARY KEY (user_id, timestamp, meal, foodkey),
+        FOREIGN KEY (user_id, foodkey) REFERENCES food(user_id, key) ON DELETE RESTRICT
+    )
+	`
+
+	_sqlCreateTableJournalIndexUserIDFoodKey = `
+	CREATE INDEX journal_userid_foodkey ON journal(user_id, foodkey);
+	`
+
+	_sqlSetJournal = `
+	INSERT INTO journal (
+        user_id, timestamp, meal, foodkey, foodweight, portion
+    )
+    VALUES ($1, $2, $3, $4, $5, $6)
+    ON CONFLICT (user_id, timestamp, meal, foodkey) DO
+    UPDATE SET
+        foodweight = $5,
+        portion = $6
+	`
+
+	_sqlDeleteJournal = `
+    DELETE FROM journal
+    WHERE user_id = $1 AND
+          timestamp >= $2 AND
+          timestamp <= $3 AND
+          meal = $4 AND
+          foodkey = $5
+	`
+
+	_sqlDeleteJournalMeal = `
+	DELETE FROM journal
+	WHERE user_id = $1 AND
+		timestamp >= $2 AND
+		timestamp <= $3 AND
+		meal = $4
+	`
+
+	_sqlJournalFoodStat = `
+	SELECT
+		min(timestamp) AS first_timestamp,
+		max(timestamp) AS last_timestamp,
+		sum(foodweight) AS total_weight,
+		avg(foodweight) AS avg_weight,
+		count(*) AS total_cnt
+	FROM journal j
+	WHERE
+		j.user_id = $1 AND
+		j.foodkey = $2
+	HAVING count(*) > 0
+	`
+
+	_sqlGetJournalReport = `
+    SELECT
+        j.timestamp,
+        j.meal,
+        j.foodkey,
+        f.name AS foodname,
+        f.brand AS foodbrand,
+        j.foodweight,
+        j.foodweight / 100 * f.cal100 AS cal,
+        j.foodweight / 100 * f.prot100 AS prot,
+        j.foodweight / 100 * f.fat100 AS fat,
+        j.foodweight / 100 * f.carb100 AS carb,
+        f.beverage,
+        f.nutrients,
+        j.portion
+    FROM journal j, food f
+    WHERE
+        j.foodkey = f.key AND
+		f.user_id = $1 AND
+        j.user_id = $1 AND
+        j.timestamp >= $2 AND
+        j.timestamp <= $3
+    ORDER BY
+        j.timestamp,
+        j.meal,
+        f.name
+	`
+
+	_sqlGetJournalMealSuggestions = `
+	SELECT
+		j.foodkey,
+		f.name AS foodname,
+		f.brand AS foodbrand,
+		count(*) AS cnt,
+		sum(1.0 / (1.0 + ($4 - j.timestamp) / CAST(86400000 AS DOUBLE PRECISION))) AS score
+	FROM journal j, food f
+	WHERE
+		j.foodkey = f.key AND
+		f.user_id = $1 AND
+		j.user_id = $1 AND
+		j.meal = $2 AND
+		j.timestamp >= $3 AND
+		j.timestamp <= $4
+	GROUP BY
+		j.foodkey, f.name, f.brand
+	ORDER BY
+		score DESC,
+		f.name
+	LIMIT $5
+	`
+
+	_sqlGetJournalListForCopy = `
+	SELECT timestamp, foodkey, foodweight, portion
+	FROM journal
+	WHERE user_id = $1 AND
+		timestamp >= $2 AND
+		timestamp <= $3 AND
+		meal = $4
+	`
+
+	_sqlJournalBackup = `
+	SELECT user_id, timestamp, meal, foodkey, foodweight, portion
+	FROM journal
+	ORDER BY user_id, timestamp, meal, foodkey
+	`
+
+	//
+	// DayTotalCal.
+	//
+
+	_sqlCreateTableTotalBurnedCal = `
+	CREATE TABLE total_burned_cal (
+        user_id   BIGINT           NOT NULL,
+        timestamp BIGINT           NOT NULL,
+        total_cal DOUBLE PRECISION NOT NULL,
+        PRIMARY KEY (user_id, timestamp)
+    )
+	`
+
+	_sqlGetTotalBurnedCal = `
+	SELECT total_cal
+	FROM total_burned_cal
+	WHERE user_id = $1 AND
+		timestamp = $2
+	`
+
+	_sqlSetTotalBurnedCal = `
+	INSERT INTO total_burned_cal (
+        user_id, timestamp, total_cal
+    )
+    VALUES ($1, $2, $3)
+    ON CONFLICT (user_id, timestamp) DO
+    UPDATE SET
+        total_cal = $3
+	`
+
+	_sqlDeleteTotalBurnedCal = `
+    DELETE FROM total_burned_cal
+    WHERE user_id = $1 AND
+		timestamp = $2
+	`
+
+	_sqlDayTotalBackup = `
+	SELECT user_id, timestamp, total_cal
+	FROM total_burned_cal
+	ORDER BY user_id, timestamp
+	`
+
+	//
+	// TDEEEstimate.
+	//
+
+	_sqlCreateTableTDEEEstimate = `
+	CREATE TABLE tdee_estimate (
+        user_id   BIGINT           NOT NULL,
+        timestamp BIGINT           NOT NULL,
+        weeks     BIGINT           NOT NULL,
+        tdee      DOUBLE PRECISION NOT NULL,
+        conf_low  DOUBLE PRECISION NOT NULL,
+        conf_high DOUBLE PRECISION NOT NULL,
+        applied   BOOLEAN          NOT NULL,
+        PRIMARY KEY (user_id, timestamp)
+    )
+	`
+
+	_sqlSetTDEEEstimate = `
+	INSERT INTO tdee_estimate (
+        user_id, timestamp, weeks, tdee, conf_low, conf_high, applied
+    )
+    VALUES ($1, $2, $3, $4, $5, $6, $7)
+    ON CONFLICT (user_id, timestamp) DO
+    UPDATE SET
+        weeks = $3,
+        tdee = $4,
+        conf_low = $5,
+        conf_high = $6,
+        applied = $7
+	`
+
+	_sqlGetLastTDEEEstimate = `
+	SELECT timestamp, weeks, tdee, conf_low, conf_high, applied
+	FROM tdee_estimate
+	WHERE user_id = $1
+	ORDER BY timestamp DESC
+	LIMIT 1
+	`
+
+	_sqlGetTDEEEstimateList = `
+	SELECT timestamp, weeks, tdee, conf_low, conf_high, applied
+	FROM tdee_estimate
+	WHERE
+		user_id = $1 AND
+		timestamp >= $2 AND
+		timestamp <= $3
+	ORDER BY timestamp
+	`
+
+	_sqlTDEEEstimateBackup = `
+	SELECT user_id, timestamp, weeks, tdee, conf_low, conf_high, applied
+	FROM tdee_estimate
+	ORDER BY user_id, timestamp
+	`
+
+	//
+	// Workout.
+	//
+
+	_sqlCreateTableWorkout = `
+	CREATE TABLE workout (
+        user_id BIGINT           NOT NULL,
+        key     TEXT COLLATE "C" NOT NULL,
+        name    TEXT COLLATE "C" NOT NULL,
+        items   TEXT             NOT NULL,
+        comment TEXT             NOT NULL,
+        PRIMARY KEY (user_id, key)
+    )
+	`
+
+	_sqlGetWorkout = `
+	SELECT key, name, items, comment
+	FROM workout
+	WHERE user_id = $1 AND key = $2
+	`
+
+	_sqlGetWorkoutList = `
+	SELECT key, name, items, comment
+	FROM workout
+	WHERE user_id = $1
+	ORDER BY name
+	`
+
+	_sqlSetWorkout = `
+	INSERT INTO workout (
+        user_id, key, name, items, comment
+    )
+    VALUES ($1, $2, $3, $4, $5)
+    ON CONFLICT (user_id, key) DO
+    UPDATE SET
+        name = $3,
+        items = $4,
+        comment = $5
+	`
+
+	_sqlDeleteWorkout = `
+	DELETE FROM workout
+	WHERE user_id = $1 AND key = $2
+	`
+
+	_sqlWorkoutBackup = `
+	SELECT user_id, key, name, items, comment
+	FROM workout
+	ORDER BY user_id, key
+	`
+
+	//
+	// WorkoutPlan.
+	//
+
+	_sqlCreateTableWorkoutPlan = `
+	CREATE TABLE workout_plan (
+        user_id     BIGINT           NOT NULL,
+        weekday     INTEGER          NOT NULL,
+        workout_key TEXT COLLATE "C" NOT NULL,
+        PRIMARY KEY (user_id, weekday),
+        FOREIGN KEY (user_id, workout_key) REFERENCES workout(user_id, key) ON DELETE RESTRICT
+    )
+	`
+
+	_sqlGetWorkoutPlan = `
+	SELECT weekday, workout_key
+	FROM workout_plan
+	WHERE user_id = $1
+	ORDER BY weekday
+	`
+
+	_sqlSetWorkoutPlanDay = `
+	INSERT INTO workout_plan (
+        user_id, weekday, workout_key
+    )
+    VALUES ($1, $2, $3)
+    ON CONFLICT (user_id, weekday) DO
+    UPDATE SET
+        workout_key = $3
+	`
+
+	_sqlDeleteWorkoutPlanDay = `
+	DELETE FROM workout_plan
+	WHERE user_id = $1 AND weekday = $2
+	`
+
+	_sqlWorkoutPlanBackup = `
+	SELECT user_id, weekday, workout_key
+	FROM workout_plan
+	ORDER BY user_id, weekday
+	`
+
+	//
+	// Sleep.
+	//
+
+	_sqlCreateTableSleep = `
+	CREATE TABLE sleep (
+        user_id   BIGINT  NOT NULL,
+        timestamp BIGINT  NOT NULL,
+        bed_time  BIGINT  NOT NULL,
+        wake_time BIGINT  NOT NULL,
+        quality   INTEGER NOT NULL,
+        notes     TEXT    NOT NULL,
+        PRIMARY KEY (user_id, timestamp)
+    )
+	`
+
+	_sqlGetSleep = `
+	SELECT timestamp, bed_time, wake_time, quality, notes
+	FROM sleep
+	WHERE user_id = $1 AND timestamp = $2
+	`
+
+	_sqlGetSleepList = `
+	SELECT timestamp, bed_time, wake_time, quality, notes
+	FROM sleep
+	WHERE
+		user_id = $1 AND
+		timestamp >= $2 AND
+		timestamp <= $3
+	ORDER BY timestamp
+	`
+
+	_sqlSetSleep = `
+	INSERT INTO sleep (
+        user_id, timestamp, bed_time, wake_time, quality, notes
+    )
+    VALUES ($1, $2, $3, $4, $5, $6)
+    ON CONFLICT (user_id, timestamp) DO
+    UPDATE SET
+        bed_time = $3,
+        wake_time = $4,
+        quality = $5,
+        notes = $6
+	`
+
+	_sqlDeleteSleep = `
+	DELETE FROM sleep
+	WHERE user_id = $1 AND timestamp = $2
+	`
+
+	_sqlSleepBackup = `
+	SELECT user_id, timestamp, bed_time, wake_time, quality, notes
+	FROM sleep
+	ORDER BY user_id, timestamp
+	`
+
+	//
+	// Water.
+	//
+
+	_sqlCreateTableWater = `
+	CREATE TABLE water (
+        user_id   BIGINT           NOT NULL,
+        timestamp BIGINT           NOT NULL,
+        volume    DOUBLE PRECISION NOT NULL,
+        PRIMARY KEY (user_id, timestamp)
+    )
+	`
+
+	_sqlGetWaterList = `
+	SELECT timestamp, volume
+	FROM water
+	WHERE
+		user_id = $1 AND
+		timestamp >= $2 AND
+		timestamp <= $3
+	ORDER BY timestamp
+	`
+
+	_sqlAddWater = `
+	INSERT INTO water (user_id, timestamp, volume)
+	VALUES ($1, $2, $3)
+	ON CONFLICT (user_id, timestamp) DO
+	UPDATE SET volume = water.volume + $3
+	`
+
+	_sqlDeleteWater = `
+	DELETE FROM water
+	WHERE
+		user_id = $1 AND
+		timestamp >= $2 AND
+		timestamp <= $3
+	`
+
+	_sqlWaterBackup = `
+	SELECT user_id, timestamp, volume
+	FROM water
+	ORDER BY user_id, timestamp
+	`
+
+	//
+	// Fast.
+	//
+
+	_sqlCreateTableFast = `
+	CREATE TABLE fast (
+        user_id    BIGINT NOT NULL,
+        start_time BIGINT NOT NULL,
+        end_time   BIGINT NOT NULL,
+        PRIMARY KEY (user_id, start_time)
+    )
+	`
+
+	_sqlGetActiveFast = `
+	SELECT start_time, end_time
+	FROM fast
+	WHERE user_id = $1 AND end_time = 0
+	ORDER BY start_time DESC
+	LIMIT 1
+	`
+
+	_sqlGetFastList = `
+	SELECT start_time, end_time
+	FROM fast
+	WHERE
+		user_id = $1 AND
+		start_time >= $2 AND
+		start_time <= $3
+	ORDER BY start_time
+	`
+
+	_sqlFindOverlappedFast = `
+	SELECT start_time, end_time
+	FROM fast
+	WHERE
+		user_id = $1 AND
+		(end_time = 0 OR end_time > $2) AND
+		($3::BIGINT = 0 OR start_time < $3)
+	LIMIT 1
+	`
+
+	_sqlAddFast = `
+	INSERT INTO fast (user_id, start_time, end_time)
+	VALUES ($1, $2, $3)
+	`
+
+	_sqlStopFast = `
+	UPDATE fast
+	SET end_time = $1
+	WHERE user_id = $2 AND start_time = $3 AND end_time = 0
+	`
+
+	_sqlDeleteFast = `
+	DELETE FROM fast
+	WHERE user_id = $1 AND start_time >= $2 AND start_time <= $3
+	`
+
+	_sqlFastBackup = `
+	SELECT user_id, start_time, end_time
+	FROM fast
+	ORDER BY user_id, start_time
+	`
+)
@@ -0,0 +1,769 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	s "github.com/devldavydov/myhealth/internal/storage"
+)
+
+func (r *StoragePostgres) Backup(ctx context.Context) (*s.Backup, error) {
+	backup := &s.Backup{
+		Timestamp: s.Timestamp(time.Now().UnixMilli()),
+	}
+
+	// Weight
+	{
+		rows, err := r.conn().QueryContext(ctx, _sqlWeightBackup)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		backup.Weight = []s.WeightBackup{}
+		for rows.Next() {
+			var w s.WeightBackup
+			err = rows.Scan(&w.UserID, &w.Timestamp, &w.Value)
+			if err != nil {
+				return nil, err
+			}
+
+			backup.Weight = append(backup.Weight, w)
+		}
+
+		if err = rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Sport
+	{
+		rows, err := r.conn().QueryContext(ctx, _sqlSportBackup)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		backup.Sport = []s.SportBackup{}
+		for rows.Next() {
+			var sp s.SportBackup
+			err = rows.Scan(&sp.UserID, &sp.Key, &sp.Name, &sp.Comment, &sp.Unit, &sp.MET, &sp.CalPerUnit, &sp.SetKind)
+			if err != nil {
+				return nil, err
+			}
+
+			backup.Sport = append(backup.Sport, sp)
+		}
+
+		if err = rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	// SportActivity
+	{
+		rows, err := r.conn().QueryContext(ctx, _sqlSportActivityBackup)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		backup.SportActivity = []s.SportActivityBackup{}
+		for rows.Next() {
+			var sa s.SportActivityBackup
+			var saSets string
+			err = rows.Scan(&sa.UserID, &sa.Timestamp, &sa.SportKey, &saSets, &sa.Duration, &sa.Cal)
+			if err != nil {
+				return nil, err
+			}
+
+			if err := json.Unmarshal([]byte(saSets), &sa.Sets); err != nil {
+				return nil, err
+			}
+
+			backup.SportActivity = append(backup.SportActivity, sa)
+		}
+
+		if err = rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Medicine
+	{
+		rows, err := r.conn().QueryContext(ctx, _sqlMedicineBackup)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		backup.Medicine = []s.MedicineBackup{}
+		for rows.Next() {
+			var m s.MedicineBackup
+			err = rows.Scan(&m.UserID, &m.Key, &m.Name, &m.Comment, &m.Unit)
+			if err != nil {
+				return nil, err
+			}
+
+			backup.Medicine = append(backup.Medicine, m)
+		}
+
+		if err = rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	// MedicineIndicator
+	{
+		rows, err := r.conn().QueryContext(ctx, _sqlMedicineIndicatorBackup)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		backup.MedicineIndicator = []s.MedicineIndicatorBackup{}
+		for rows.Next() {
+			var mi s.MedicineIndicatorBackup
+			err = rows.Scan(&mi.UserID, &mi.Timestamp, &mi.MedicineKey, &mi.Value)
+			if err != nil {
+				return nil, err
+			}
+
+			backup.MedicineIndicator = append(backup.MedicineIndicator, mi)
+		}
+
+		if err = rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	// UserSettings
+	{
+		rows, err := r.conn().QueryContext(ctx, _sqlUserSettingsBackup)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		backup.UserSettings = []s.UserSettingsBackup{}
+		for rows.Next() {
+			var us s.UserSettingsBackup
+			var sProfile, sNutrientLimits sql.NullString
+			err = rows.Scan(&us.UserID, &us.CalLimit, &us.TDEEAutoUpdate, &sProfile, &us.WaterGoal, &us.WaterReminder, &sNutrientLimits, &us.ReportFormat)
+			if err != nil {
+				return nil, err
+			}
+
+			nl, err := unmarshalNutrients(sNutrientLimits)
+			if err != nil {
+				return nil, err
+			}
+			us.NutrientLimits = nutrientsToBackup(nl)
+
+			p, err := unmarshalUserProfile(sProfile)
+			if err != nil {
+				return nil, err
+			}
+			if p != nil {
+				us.Profile = &s.UserProfileBackup{
+					Sex:           string(p.Sex),
+					BirthDate:     int64(p.BirthDate),
+					Height:        p.Height,
+					ActivityLevel: int64(p.ActivityLevel),
+					BodyFat:       p.BodyFat,
+					Goal:          string(p.Goal),
+					GoalRate:      p.GoalRate,
+				}
+			}
+
+			backup.UserSettings = append(backup.UserSettings, us)
+		}
+
+		if err = rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Food
+	{
+		rows, err := r.conn().QueryContext(ctx, _sqlFoodBackup)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		backup.Food = []s.FoodBackup{}
+		for rows.Next() {
+			var f s.FoodBackup
+			var sNutrients, sPortions sql.NullString
+			err = rows.Scan(
+				&f.UserID,
+				&f.Key,
+				&f.Name,
+				&f.Brand,
+				&f.Cal100,
+				&f.Prot100,
+				&f.Fat100,
+				&f.Carb100,
+				&f.Comment,
+				&f.Beverage,
+				&sNutrients,
+				&sPortions,
+				&f.Density,
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			n, err := unmarshalNutrients(sNutrients)
+			if err != nil {
+				return nil, err
+			}
+			f.Nutrients = nutrientsToBackup(n)
+
+			p, err := unmarshalFoodPortions(sPortions)
+			if err != nil {
+				return nil, err
+			}
+			f.Portions = foodPortionsToBackup(p)
+
+			backup.Food = append(backup.Food, f)
+		}
+
+		if err = rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Bundle
+	{
+		rows, err := r.conn().QueryContext(ctx, _sqlBundleBackup)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		backup.Bundle = []s.BundleBackup{}
+		for rows.Next() {
+			var b s.BundleBackup
+			var bData string
+
+			err = rows.Scan(
+				&b.UserID,
+				&b.Key,
+				&bData,
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			if err := json.Unmarshal([]byte(bData), &b.Data); err != nil {
+				return nil, err
+			}
+
+			backup.Bundle = append(backup.Bundle, b)
+		}
+
+		if err = rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Journal
+	{
+		rows, err := r.conn().QueryContext(ctx, _sqlJournalBackup)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		backup.Journal = []s.JournalBackup{}
+		for rows.Next() {
+			var j s.JournalBackup
+
+			err = rows.Scan(
+				&j.UserID,
+				&j.Timestamp,
+				&j.Meal,
+				&j.FoodKey,
+				&j.FoodWeight,
+				&j.Portion,
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			backup.Journal = append(backup.Journal, j)
+		}
+
+		if err = rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	// DayTotalCal
+	{
+		rows, err := r.conn().QueryContext(ctx, _sqlDayTotalBackup)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		backup.TotalBurnedCal = []s.TotalBurnedCalBackup{}
+		for rows.Next() {
+			var t s.TotalBurnedCalBackup
+
+			err = rows.Scan(
+				&t.UserID,
+				&t.Timestamp,
+				&t.TotalCal,
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			backup.TotalBurnedCal = append(backup.TotalBurnedCal, t)
+		}
+
+		if err = rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	// TDEEEstimate
+	{
+		rows, err := r.conn().QueryContext(ctx, _sqlTDEEEstimateBackup)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		backup.TDEEEstimate = []s.TDEEEstimateBackup{}
+		for rows.Next() {
+			var t s.TDEEEstimateBackup
+
+			err = rows.Scan(
+				&t.UserID,
+				&t.Timestamp,
+				&t.Weeks,
+				&t.TDEE,
+				&t.ConfLow,
+				&t.ConfHigh,
+				&t.Applied,
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			backup.TDEEEstimate = append(backup.TDEEEstimate, t)
+		}
+
+		if err = rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Workout
+	{
+		rows, err := r.conn().QueryContext(ctx, _sqlWorkoutBackup)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		backup.Workout = []s.WorkoutBackup{}
+		for rows.Next() {
+			var w s.WorkoutBackup
+			var wItems string
+			err = rows.Scan(&w.UserID, &w.Key, &w.Name, &wItems, &w.Comment)
+			if err != nil {
+				return nil, err
+			}
+
+			if err := json.Unmarshal([]byte(wItems), &w.Items); err != nil {
+				return nil, err
+			}
+
+			backup.Workout = append(backup.Workout, w)
+		}
+
+		if err = rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	// WorkoutPlan
+	{
+		rows, err := r.conn().QueryContext(ctx, _sqlWorkoutPlanBackup)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		backup.WorkoutPlan = []s.WorkoutPlanBackup{}
+		for rows.Next() {
+			var wp s.WorkoutPlanBackup
+			err = rows.Scan(&wp.UserID, &wp.Weekday, &wp.WorkoutKey)
+			if err != nil {
+				return nil, err
+			}
+
+			backup.WorkoutPlan = append(backup.WorkoutPlan, wp)
+		}
+
+		if err = rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Water
+	{
+		rows, err := r.conn().QueryContext(ctx, _sqlWaterBackup)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		backup.Water = []s.WaterBackup{}
+		for rows.Next() {
+			var w s.WaterBackup
+			err = rows.Scan(&w.UserID, &w.Timestamp, &w.Volume)
+			if err != nil {
+				return nil, err
+			}
+
+			backup.Water = append(backup.Water, w)
+		}
+
+		if err = rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Sleep
+	{
+		rows, err := r.conn().QueryContext(ctx, _sqlSleepBackup)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		backup.Sleep = []s.SleepBackup{}
+		for rows.Next() {
+			var sl s.SleepBackup
+			err = rows.Scan(&sl.UserID, &sl.Timestamp, &sl.BedTime, &sl.WakeTime, &sl.Quality, &sl.Notes)
+			if err != nil {
+				return nil, err
+			}
+
+			backup.Sleep = append(backup.Sleep, sl)
+		}
+
+		if err = rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Fast
+	{
+		rows, err := r.conn().QueryContext(ctx, _sqlFastBackup)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		backup.Fast = []s.FastBackup{}
+		for rows.Next() {
+			var f s.FastBackup
+			err = rows.Scan(&f.UserID, &f.Start, &f.End)
+			if err != nil {
+				return nil, err
+			}
+
+			backup.Fast = append(backup.Fast, f)
+		}
+
+		if err = rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Result
+	return backup, nil
+}
+
+func (r *StoragePostgres) Restore(ctx context.Context, backup *s.Backup) error {
+	for _, w := range backup.Weight {
+		if err := r.SetWeight(
+			ctx,
+			w.UserID,
+			&s.Weight{Timestamp: w.Timestamp, Value: w.Value},
+		); err != nil {
+			return err
+		}
+	}
+
+	for _, sp := range backup.Sport {
+		// Backups before typed sets have no set kind
+		setKind := s.SportSetKind(sp.SetKind)
+		if setKind == "" {
+			setKind = s.SportSetKindValue
+		}
+
+		if err := r.SetSport(
+			ctx,
+			sp.UserID,
+			&s.Sport{
+				Key:        sp.Key,
+				Name:       sp.Name,
+				Unit:       sp.Unit,
+				Comment:    sp.Comment,
+				MET:        sp.MET,
+				CalPerUnit: sp.CalPerUnit,
+				SetKind:    setKind,
+			},
+		); err != nil {
+			return err
+		}
+	}
+
+	for _, sa := range backup.SportActivity {
+		sets := make([]s.SportSet, 0, len(sa.Sets))
+		for _, set := range sa.Sets {
+			sets = append(sets, s.SportSet(set))
+		}
+
+		if err := r.SetSportActivity(ctx, sa.UserID, &s.SportActivity{
+			SportKey:  sa.SportKey,
+			Timestamp: sa.Timestamp,
+			Sets:      sets,
+			Duration:  sa.Duration,
+			Cal:       sa.Cal,
+		}); err != nil {
+			return err
+		}
+	}
+
+	for _, w := range backup.Workout {
+		items := make([]s.WorkoutItem, 0, len(w.Items))
+		for _, item := range w.Items {
+			sets := make([]s.SportSet, 0, len(item.Sets))
+			for _, set := range item.Sets {
+				sets = append(sets, s.SportSet(set))
+			}
+			items = append(items, s.WorkoutItem{SportKey: item.SportKey, Sets: sets})
+		}
+
+		if err := r.SetWorkout(ctx, w.UserID, &s.Workout{
+			Key:     w.Key,
+			Name:    w.Name,
+			Items:   items,
+			Comment: w.Comment,
+		}); err != nil {
+			return err
+		}
+	}
+
+	for _, wp := range backup.WorkoutPlan {
+		if err := r.SetWorkoutPlanDay(ctx, wp.UserID, &s.WorkoutPlanDay{
+			Weekday:    time.Weekday(wp.Weekday),
+			WorkoutKey: wp.WorkoutKey,
+		}); err != nil {
+			return err
+		}
+	}
+
+	for _, m := range backup.Medicine {
+		if err := r.SetMedicine(
+			ctx,
+			m.UserID,
+			&s.Medicine{Key: m.Key, Name: m.Name, Unit: m.Unit, Comment: m.Comment},
+		); err != nil {
+			return err
+		}
+	}
+
+	for _, m := range backup.MedicineIndicator {
+		if err := r.SetMedicineIndicator(
+			ctx,
+			m.UserID,
+			&s.MedicineIndicator{
+				MedicineKey: m.MedicineKey,
+				Timestamp:   m.Timestamp,
+				Value:       m.Value,
+			},
+		); err != nil {
+			return err
+		}
+	}
+
+	for _, us := range backup.UserSettings {
+		var p *s.UserProfile
+		if us.Profile != nil {
+			p = &s.UserProfile{
+				Sex:           s.Sex(us.Profile.Sex),
+				BirthDate:     s.Timestamp(us.Profile.BirthDate),
+				Height:        us.Profile.Height,
+				ActivityLevel: s.ActivityLevel(us.Profile.ActivityLevel),
+				BodyFat:       us.Profile.BodyFat,
+				Goal:          s.Goal(us.Profile.Goal),
+				GoalRate:      us.Profile.GoalRate,
+			}
+		}
+
+		if err := r.SetUserSettings(
+			ctx,
+			us.UserID,
+			&s.UserSettings{
+				CalLimit:       us.CalLimit,
+				TDEEAutoUpdate: us.TDEEAutoUpdate,
+				Profile:        p,
+				WaterGoal:      us.WaterGoal,
+				WaterReminder:  us.WaterReminder,
+				NutrientLimits: nutrientsFromBackup(us.NutrientLimits),
+				ReportFormat:   s.ReportFormat(us.ReportFormat),
+			},
+		); err != nil {
+			return err
+		}
+	}
+
+	for _, f := range backup.Food {
+		if err := r.SetFood(
+			ctx,
+			f.UserID,
+			&s.Food{
+				Key:       f.Key,
+				Name:      f.Name,
+				Brand:     f.Brand,
+				Cal100:    f.Cal100,
+				Prot100:   f.Prot100,
+				Fat100:    f.Fat100,
+				Carb100:   f.Carb100,
+				Comment:   f.Comment,
+				Beverage:  f.Beverage,
+				Nutrients: nutrientsFromBackup(f.Nutrients),
+				Portions:  foodPortionsFromBackup(f.Portions),
+				Density:   f.Density,
+			},
+		); err != nil {
+			return err
+		}
+	}
+
+	for _, b := range backup.Bundle {
+		if err := r.SetBundle(ctx, b.UserID, &s.Bundle{
+			Key:  b.Key,
+			Data: b.Data,
+		}, false); err != nil {
+			return err
+		}
+	}
+
+	for _, j := range backup.Journal {
+		if err := r.SetJournal(ctx, j.UserID, &s.Journal{
+			Timestamp:  j.Timestamp,
+			Meal:       j.Meal,
+			FoodKey:    j.FoodKey,
+			FoodWeight: j.FoodWeight,
+			Portion:    j.Portion,
+		}); err != nil {
+			return err
+		}
+	}
+
+	for _, t := range backup.TotalBurnedCal {
+		if err := r.SetTotalBurnedCal(ctx, t.UserID, t.Timestamp, t.TotalCal); err != nil {
+			return err
+		}
+	}
+
+	for _, t := range backup.TDEEEstimate {
+		if err := r.SetTDEEEstimate(ctx, t.UserID, &s.TDEEEstimate{
+			Timestamp: t.Timestamp,
+			Weeks:     t.Weeks,
+			TDEE:      t.TDEE,
+			ConfLow:   t.ConfLow,
+			ConfHigh:  t.ConfHigh,
+			Applied:   t.Applied,
+		}); err != nil {
+			return err
+		}
+	}
+
+	for _, w := range backup.Water {
+		if err := r.AddWater(ctx, w.UserID, &s.Water{Timestamp: w.Timestamp, Volume: w.Volume}); err != nil {
+			return err
+		}
+	}
+
+	for _, sl := range backup.Sleep {
+		if err := r.SetSleep(ctx, sl.UserID, &s.Sleep{
+			Timestamp: sl.Timestamp,
+			BedTime:   sl.BedTime,
+			WakeTime:  sl.WakeTime,
+			Quality:   sl.Quality,
+			Notes:     sl.Notes,
+		}); err != nil {
+			return err
+		}
+	}
+
+	for _, f := range backup.Fast {
+		if err := r.AddFast(ctx, f.UserID, &s.Fast{Start: f.Start, End: f.End}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func nutrientsToBackup(n s.Nutrients) map[string]float64 {
+	if len(n) == 0 {
+		return nil
+	}
+
+	res := make(map[string]float64, len(n))
+	for k, v := range n {
+		res[string(k)] = v
+	}
+	return res
+}
+
+func nutrientsFromBackup(n map[string]float64) s.Nutrients {
+	if len(n) == 0 {
+		return nil
+	}
+
+	res := make(s.Nutrients, len(n))
+	for k, v := range n {
+		res[s.Nutrient(k)] = v
+	}
+	return res
+}
+
+func foodPortionsToBackup(p []s.FoodPortion) []s.FoodPortionBackup {
+	if len(p) == 0 {
+		return nil
+	}
+
+	res := make([]s.FoodPortionBackup, 0, len(p))
+	for _, fp := range p {
+		res = append(res, s.FoodPortionBackup{Name: fp.Name, Amount: fp.Amount, Unit: string(fp.Unit)})
+	}
+	return res
+}
+
+func foodPortionsFromBackup(p []s.FoodPortionBackup) []s.FoodPortion {
+	if len(p) == 0 {
+		return nil
+	}
+
+	res := make([]s.FoodPortion, 0, len(p))
+	for _, fp := range p {
+		res = append(res, s.FoodPortion{Name: fp.Name, Amount: fp.Amount, Unit: s.PortionUnit(fp.Unit)})
+	}
+	return res
+}
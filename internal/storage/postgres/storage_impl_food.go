@@ -0,0 +1,227 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	s "github.com/devldavydov/myhealth/internal/storage"
+	"github.com/lib/pq"
+)
+
+func (r *StoragePostgres) GetFood(ctx context.Context, userID int64, key string) (*s.Food, error) {
+	tx, err := r.begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	food, err := getFood(ctx, tx, userID, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return food, tx.Commit()
+}
+
+func getFood(ctx context.Context, tx dbConn, userID int64, key string) (*s.Food, error) {
+	var f s.Food
+	var sNutrients, sPortions sql.NullString
+	err := tx.
+		QueryRowContext(ctx, _sqlGetFood, userID, key).
+		Scan(&f.Key, &f.Name, &f.Brand, &f.Cal100, &f.Prot100, &f.Fat100, &f.Carb100, &f.Comment, &f.Beverage, &sNutrients, &sPortions, &f.Density)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, s.ErrFoodNotFound
+		}
+		return nil, err
+	}
+
+	if f.Nutrients, err = unmarshalNutrients(sNutrients); err != nil {
+		return nil, err
+	}
+
+	if f.Portions, err = unmarshalFoodPortions(sPortions); err != nil {
+		return nil, err
+	}
+
+	return &f, nil
+}
+
+func (r *StoragePostgres) GetFoodList(ctx context.Context, userID int64) ([]s.Food, error) {
+	rows, err := r.conn().QueryContext(ctx, _sqlGetFoodList, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []s.Food{}
+	for rows.Next() {
+		var f s.Food
+		var sNutrients, sPortions sql.NullString
+		err = rows.Scan(&f.Key, &f.Name, &f.Brand, &f.Cal100, &f.Prot100, &f.Fat100, &f.Carb100, &f.Comment, &f.Beverage, &sNutrients, &sPortions, &f.Density)
+		if err != nil {
+			return nil, err
+		}
+
+		if f.Nutrients, err = unmarshalNutrients(sNutrients); err != nil {
+			return nil, err
+		}
+
+		if f.Portions, err = unmarshalFoodPortions(sPortions); err != nil {
+			return nil, err
+		}
+
+		list = append(list, f)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(list) == 0 {
+		return nil, s.ErrEmptyResult
+	}
+
+	return list, nil
+}
+
+// FindFood matches pattern in Go, because upper() of Postgres does not
+// change non ASCII letters with "C" collation of text columns.
+func (r *StoragePostgres) FindFood(ctx context.Context, userID int64, pattern string) ([]s.Food, error) {
+	foodList, err := r.GetFoodList(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	pattern = strings.ToUpper(pattern)
+	list := []s.Food{}
+	for _, f := range foodList {
+		for _, field := range []string{f.Key, f.Name, f.Brand, f.Comment} {
+			if strings.Contains(strings.ToUpper(field), pattern) {
+				list = append(list, f)
+				break
+			}
+		}
+	}
+
+	if len(list) == 0 {
+		return nil, s.ErrEmptyResult
+	}
+
+	return list, nil
+}
+
+func (r *StoragePostgres) SetFood(ctx context.Context, userID int64, food *s.Food) error {
+	if !food.Validate() {
+		return s.ErrFoodInvalid
+	}
+
+	sNutrients, err := marshalNutrients(food.Nutrients)
+	if err != nil {
+		return err
+	}
+
+	sPortions, err := marshalFoodPortions(food.Portions)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.conn().ExecContext(ctx,
+		_sqlSetFood,
+		userID,
+		food.Key,
+		food.Name,
+		food.Brand,
+		food.Cal100,
+		food.Prot100,
+		food.Fat100,
+		food.Carb100,
+		food.Comment,
+		food.Beverage,
+		sNutrients,
+		sPortions,
+		food.Density,
+	)
+	return err
+}
+
+func (r *StoragePostgres) DeleteFood(ctx context.Context, userID int64, key string) error {
+	bndlList, err := r.GetBundleList(ctx, userID)
+	if err != nil && !errors.Is(err, s.ErrEmptyResult) {
+		return err
+	}
+
+	for _, bndl := range bndlList {
+		for k, v := range bndl.Data {
+			if v != 0 && k == key {
+				return s.ErrFoodIsUsed
+			}
+		}
+	}
+
+	_, err = r.conn().ExecContext(ctx, _sqlDeleteFood, userID, key)
+	if err != nil {
+		var errPq *pq.Error
+		if errors.As(err, &errPq) && errPq.Code == _errForeignKey {
+			return s.ErrFoodIsUsed
+		}
+		return err
+	}
+
+	return nil
+}
+
+func marshalNutrients(n s.Nutrients) (sql.NullString, error) {
+	if len(n) == 0 {
+		return sql.NullString{}, nil
+	}
+
+	b, err := json.Marshal(n)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+
+	return sql.NullString{String: string(b), Valid: true}, nil
+}
+
+func unmarshalNutrients(sNutrients sql.NullString) (s.Nutrients, error) {
+	if !sNutrients.Valid {
+		return nil, nil
+	}
+
+	var n s.Nutrients
+	if err := json.Unmarshal([]byte(sNutrients.String), &n); err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
+
+func marshalFoodPortions(p []s.FoodPortion) (sql.NullString, error) {
+	if len(p) == 0 {
+		return sql.NullString{}, nil
+	}
+
+	b, err := json.Marshal(p)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+
+	return sql.NullString{String: string(b), Valid: true}, nil
+}
+
+func unmarshalFoodPortions(sPortions sql.NullString) ([]s.FoodPortion, error) {
+	if !sPortions.Valid {
+		return nil, nil
+	}
+
+	var p []s.FoodPortion
+	if err := json.Unmarshal([]byte(sPortions.String), &p); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
@@ -0,0 +1,313 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	s "github.com/devldavydov/myhealth/internal/storage"
+	"github.com/lib/pq"
+)
+
+func (r *StoragePostgres) SetJournal(ctx context.Context, userID int64, journal *s.Journal) error {
+	if !journal.Validate() {
+		return s.ErrJournalInvalid
+	}
+
+	_, err := r.conn().ExecContext(ctx,
+		_sqlSetJournal,
+		userID,
+		journal.Timestamp,
+		journal.Meal,
+		journal.FoodKey,
+		journal.FoodWeight,
+		journal.Portion,
+	)
+	if err != nil {
+		var errPq *pq.Error
+		if errors.As(err, &errPq) && errPq.Code == _errForeignKey {
+			return s.ErrFoodNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *StoragePostgres) SetJournalBundle(ctx context.Context, userID int64, timestamp s.Timestamp, meal s.Meal, bndlKey string) error {
+	tx, err := r.begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	foodItems, err := getBundleFoodItems(ctx, tx, userID, bndlKey)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range foodItems {
+		if _, err := tx.ExecContext(ctx,
+			_sqlSetJournal,
+			userID,
+			timestamp,
+			meal,
+			item.foodKey,
+			item.foodWeight,
+			"",
+		); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+type bundleFoodItem struct {
+	foodKey    string
+	foodWeight float64
+}
+
+func getBundleFoodItems(ctx context.Context, tx dbConn, userID int64, bndlKey string) ([]bundleFoodItem, error) {
+	foodItems := []bundleFoodItem{}
+	bndlList := []string{bndlKey}
+	i := 0
+
+	for i < len(bndlList) {
+		nextBndlKey := bndlList[i]
+		bndl, err := getBundle(ctx, tx, userID, nextBndlKey)
+		if err != nil {
+			return nil, err
+		}
+
+		for k, v := range bndl.Data {
+			if v == 0 {
+				bndlList = append(bndlList, k)
+				continue
+			}
+
+			_, err := getFood(ctx, tx, userID, k)
+			if err != nil {
+				return nil, err
+			}
+
+			foodItems = append(foodItems, bundleFoodItem{foodKey: k, foodWeight: v})
+		}
+
+		i++
+	}
+
+	return foodItems, nil
+}
+
+func (r *StoragePostgres) DeleteJournal(ctx context.Context, userID int64, from, to s.Timestamp, meal s.Meal, foodkey string) error {
+	_, err := r.conn().ExecContext(ctx, _sqlDeleteJournal, userID, from, to, meal, foodkey)
+	return err
+}
+
+func (r *StoragePostgres) DeleteJournalMeal(ctx context.Context, userID int64, from, to s.Timestamp, meal s.Meal) error {
+	_, err := r.conn().ExecContext(ctx, _sqlDeleteJournalMeal, userID, from, to, meal)
+	return err
+}
+
+func (r *StoragePostgres) DelJournalBundle(ctx context.Context, userID int64, from, to s.Timestamp, meal s.Meal, bndlKey string) error {
+	tx, err := r.begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	foodItems, err := getBundleFoodItems(ctx, tx, userID, bndlKey)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range foodItems {
+		if _, err := tx.ExecContext(ctx,
+			_sqlDeleteJournal,
+			userID,
+			from,
+			to,
+			meal,
+			item.foodKey,
+		); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *StoragePostgres) GetJournalReport(ctx context.Context, userID int64, from, to s.Timestamp) ([]s.JournalReport, error) {
+	rows, err := r.conn().QueryContext(ctx, _sqlGetJournalReport, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []s.JournalReport{}
+	for rows.Next() {
+		var jr s.JournalReport
+		var sNutrients sql.NullString
+		err = rows.Scan(
+			&jr.Timestamp,
+			&jr.Meal,
+			&jr.FoodKey,
+			&jr.FoodName,
+			&jr.FoodBrand,
+			&jr.FoodWeight,
+			&jr.Cal,
+			&jr.Prot,
+			&jr.Fat,
+			&jr.Carb,
+			&jr.FoodBeverage,
+			&sNutrients,
+			&jr.Portion,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		// Food nutrients are per 100g
+		nutrients, err := unmarshalNutrients(sNutrients)
+		if err != nil {
+			return nil, err
+		}
+		if len(nutrients) > 0 {
+			jr.Nutrients = nutrients.Scale(jr.FoodWeight / 100)
+		}
+
+		list = append(list, jr)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(list) == 0 {
+		return nil, s.ErrEmptyResult
+	}
+
+	return list, nil
+}
+
+func (r *StoragePostgres) CopyJournal(ctx context.Context, userID int64, from, to s.Timestamp, mealFrom s.Meal, dst s.Timestamp, mealTo s.Meal) (int, error) {
+	tx, err := r.begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	// Get journal data for mealFrom
+	type jData struct {
+		timestamp  s.Timestamp
+		foodKey    string
+		foodWeight string
+		portion    string
+	}
+
+	rows, err := r.conn().QueryContext(ctx, _sqlGetJournalListForCopy, userID, from, to, mealFrom)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	list := []jData{}
+	for rows.Next() {
+		var jd jData
+		err = rows.Scan(
+			&jd.timestamp,
+			&jd.foodKey,
+			&jd.foodWeight,
+			&jd.portion,
+		)
+		if err != nil {
+			return 0, err
+		}
+
+		list = append(list, jd)
+	}
+
+	if err = rows.Err(); err != nil {
+		return 0, err
+	}
+
+	if len(list) == 0 {
+		return 0, nil
+	}
+
+	// Save to new meal, records keep offset from start of period
+	for _, item := range list {
+		if _, err := tx.ExecContext(ctx,
+			_sqlSetJournal,
+			userID,
+			dst+item.timestamp-from,
+			mealTo,
+			item.foodKey,
+			item.foodWeight,
+			item.portion,
+		); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(list), tx.Commit()
+}
+
+func (r *StoragePostgres) GetJournalFoodStat(ctx context.Context, userID int64, foodkey string) (*s.JournalFoodStat, error) {
+	var fs s.JournalFoodStat
+	err := r.conn().
+		QueryRowContext(ctx, _sqlJournalFoodStat, userID, foodkey).
+		Scan(&fs.FirstTimestamp, &fs.LastTimestamp, &fs.TotalWeight, &fs.AvgWeight, &fs.TotalCount)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, s.ErrEmptyResult
+		}
+		return nil, err
+	}
+
+	return &fs, nil
+}
+
+// GetJournalMealSuggestions returns foods most often eaten at meal in [from, to].
+// Each entry adds 1/(1+days ago) to food score, so recent entries weigh more.
+func (r *StoragePostgres) GetJournalMealSuggestions(
+	ctx context.Context,
+	userID int64,
+	meal s.Meal,
+	from, to s.Timestamp,
+	limit int,
+) ([]s.JournalMealSuggestion, error) {
+	rows, err := r.conn().QueryContext(ctx, _sqlGetJournalMealSuggestions, userID, meal, from, to, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []s.JournalMealSuggestion{}
+	for rows.Next() {
+		var js s.JournalMealSuggestion
+		err = rows.Scan(
+			&js.FoodKey,
+			&js.FoodName,
+			&js.FoodBrand,
+			&js.Count,
+			&js.Score,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, js)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(list) == 0 {
+		return nil, s.ErrEmptyResult
+	}
+
+	return list, nil
+}
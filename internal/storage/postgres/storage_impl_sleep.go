@@ -0,0 +1,75 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	s "github.com/devldavydov/myhealth/internal/storage"
+)
+
+func (r *StoragePostgres) GetSleep(ctx context.Context, userID int64, ts s.Timestamp) (*s.Sleep, error) {
+	var sl s.Sleep
+	err := r.conn().
+		QueryRowContext(ctx, _sqlGetSleep, userID, ts).
+		Scan(&sl.Timestamp, &sl.BedTime, &sl.WakeTime, &sl.Quality, &sl.Notes)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, s.ErrSleepNotFound
+		}
+		return nil, err
+	}
+
+	return &sl, nil
+}
+
+func (r *StoragePostgres) GetSleepList(ctx context.Context, userID int64, from, to s.Timestamp) ([]s.Sleep, error) {
+	rows, err := r.conn().QueryContext(ctx, _sqlGetSleepList, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []s.Sleep{}
+	for rows.Next() {
+		var sl s.Sleep
+		err = rows.Scan(&sl.Timestamp, &sl.BedTime, &sl.WakeTime, &sl.Quality, &sl.Notes)
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, sl)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(list) == 0 {
+		return nil, s.ErrEmptyResult
+	}
+
+	return list, nil
+}
+
+func (r *StoragePostgres) SetSleep(ctx context.Context, userID int64, sl *s.Sleep) error {
+	if !sl.Validate() {
+		return s.ErrSleepInvalid
+	}
+
+	_, err := r.conn().ExecContext(ctx,
+		_sqlSetSleep,
+		userID,
+		sl.Timestamp,
+		sl.BedTime,
+		sl.WakeTime,
+		sl.Quality,
+		sl.Notes,
+	)
+
+	return err
+}
+
+func (r *StoragePostgres) DeleteSleep(ctx context.Context, userID int64, timestamp s.Timestamp) error {
+	_, err := r.conn().ExecContext(ctx, _sqlDeleteSleep, userID, timestamp)
+	return err
+}
@@ -0,0 +1,320 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	s "github.com/devldavydov/myhealth/internal/storage"
+	"github.com/lib/pq"
+)
+
+//
+// Sport.
+//
+
+func (r *StoragePostgres) GetSport(ctx context.Context, userID int64, key string) (*s.Sport, error) {
+	var sp s.Sport
+	err := r.conn().
+		QueryRowContext(ctx, _sqlGetSport, userID, key).
+		Scan(&sp.Key, &sp.Name, &sp.Comment, &sp.Unit, &sp.MET, &sp.CalPerUnit, &sp.SetKind)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, s.ErrSportNotFound
+		}
+		return nil, err
+	}
+
+	return &sp, nil
+}
+
+func (r *StoragePostgres) GetSportList(ctx context.Context, userID int64) ([]s.Sport, error) {
+	rows, err := r.conn().QueryContext(ctx, _sqlGetSportList, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []s.Sport{}
+	for rows.Next() {
+		var sp s.Sport
+		err = rows.Scan(&sp.Key, &sp.Name, &sp.Comment, &sp.Unit, &sp.MET, &sp.CalPerUnit, &sp.SetKind)
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, sp)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(list) == 0 {
+		return nil, s.ErrEmptyResult
+	}
+
+	return list, nil
+}
+
+func (r *StoragePostgres) SetSport(ctx context.Context, userID int64, sp *s.Sport) error {
+	if !sp.Validate() {
+		return s.ErrSportInvalid
+	}
+
+	_, err := r.conn().ExecContext(ctx, _sqlSetSport, userID, sp.Key, sp.Name, sp.Comment, sp.Unit, sp.MET, sp.CalPerUnit, sp.SetKind)
+	return err
+}
+
+func (r *StoragePostgres) DeleteSport(ctx context.Context, userID int64, key string) error {
+	used, err := r.isSportUsedInWorkout(ctx, userID, key)
+	if err != nil {
+		return err
+	}
+	if used {
+		return s.ErrSportIsUsed
+	}
+
+	_, err = r.conn().ExecContext(ctx, _sqlDeleteSport, userID, key)
+	if err != nil {
+		var errPq *pq.Error
+		if errors.As(err, &errPq) && errPq.Code == _errForeignKey {
+			return s.ErrSportIsUsed
+		}
+		return err
+	}
+
+	return nil
+}
+
+//
+// SportActivity.
+//
+
+func (r *StoragePostgres) SetSportActivity(ctx context.Context, userID int64, sa *s.SportActivity) error {
+	if !sa.Validate() {
+		return s.ErrSportActivityInvalid
+	}
+
+	sp, err := r.GetSport(ctx, userID, sa.SportKey)
+	if err != nil {
+		return err
+	}
+
+	sets, err := sp.SetKind.Normalize(sa.Sets)
+	if err != nil {
+		return err
+	}
+
+	sSets, err := marshalSportSets(sets)
+	if err != nil {
+		return err
+	}
+
+	// Calculate burned calories by sport coefficients, if not set explicitly
+	cal := sa.Cal
+	if cal == 0 {
+		var weight float64
+		w, err := r.GetLastWeight(ctx, userID, sa.Timestamp)
+		switch {
+		case err == nil:
+			weight = w.Value
+		case !errors.Is(err, s.ErrWeightNotFound):
+			return err
+		}
+
+		cal = sp.ActivityCal(sets, sa.Duration, weight)
+	}
+
+	// New activity is added, existing is updated by ID
+	if sa.ID == 0 {
+		err = r.conn().QueryRowContext(
+			ctx,
+			_sqlAddSportActivity,
+			userID,
+			sa.Timestamp,
+			sa.SportKey,
+			sSets,
+			sa.Comment,
+			sa.Duration,
+			cal,
+		).Scan(&sa.ID)
+		if err != nil {
+			var errPq *pq.Error
+			if errors.As(err, &errPq) && errPq.Code == _errForeignKey {
+				return s.ErrSportNotFound
+			}
+			return err
+		}
+		return nil
+	}
+
+	res, err := r.conn().ExecContext(
+		ctx,
+		_sqlUpdateSportActivity,
+		sa.Timestamp,
+		sa.SportKey,
+		sSets,
+		sa.Comment,
+		sa.Duration,
+		cal,
+		userID,
+		sa.ID,
+	)
+	if err != nil {
+		var errPq *pq.Error
+		if errors.As(err, &errPq) && errPq.Code == _errForeignKey {
+			return s.ErrSportNotFound
+		}
+		return err
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if cnt == 0 {
+		return s.ErrSportActivityNotFound
+	}
+
+	return nil
+}
+
+func (r *StoragePostgres) DeleteSportActivity(ctx context.Context, userID int64, from, to s.Timestamp, sport_key string) error {
+	_, err := r.conn().ExecContext(ctx, _sqlDeleteSportActivity, userID, from, to, sport_key)
+	return err
+}
+
+func (r *StoragePostgres) DeleteSportActivityByID(ctx context.Context, userID, id int64) error {
+	res, err := r.conn().ExecContext(ctx, _sqlDeleteSportActivityByID, userID, id)
+	if err != nil {
+		return err
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if cnt == 0 {
+		return s.ErrSportActivityNotFound
+	}
+
+	return nil
+}
+
+func (r *StoragePostgres) GetSportActivityReport(ctx context.Context, userID int64, from, to s.Timestamp) ([]s.SportActivityReport, error) {
+	rows, err := r.conn().QueryContext(ctx, _sqlGetSportActivityReport, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []s.SportActivityReport{}
+	for rows.Next() {
+		var sr s.SportActivityReport
+		var sSets string
+
+		err = rows.Scan(&sr.Timestamp, &sr.SportName, &sSets, &sr.Duration, &sr.Cal, &sr.Comment, &sr.SportKey, &sr.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if sr.Sets, err = unmarshalSportSets(sSets); err != nil {
+			return nil, err
+		}
+
+		list = append(list, sr)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(list) == 0 {
+		return nil, s.ErrEmptyResult
+	}
+
+	return list, nil
+}
+
+func (r *StoragePostgres) GetSportActivityCal(ctx context.Context, userID int64, from, to s.Timestamp) (float64, error) {
+	var cal float64
+	if err := r.conn().QueryRowContext(ctx, _sqlGetSportActivityCal, userID, from, to).Scan(&cal); err != nil {
+		return 0, err
+	}
+
+	return cal, nil
+}
+
+func (r *StoragePostgres) GetSportActivityHistory(ctx context.Context, userID int64, sportKey string) ([]s.SportActivity, error) {
+	rows, err := r.conn().QueryContext(ctx, _sqlGetSportActivityHistory, userID, sportKey)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []s.SportActivity{}
+	for rows.Next() {
+		sa := s.SportActivity{SportKey: sportKey}
+		var sSets string
+
+		err = rows.Scan(&sa.ID, &sa.Timestamp, &sSets, &sa.Duration, &sa.Cal, &sa.Comment)
+		if err != nil {
+			return nil, err
+		}
+
+		if sa.Sets, err = unmarshalSportSets(sSets); err != nil {
+			return nil, err
+		}
+
+		list = append(list, sa)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(list) == 0 {
+		return nil, s.ErrEmptyResult
+	}
+
+	return list, nil
+}
+
+type sportSetDB struct {
+	Value    float64 `json:"value,omitempty"`
+	Reps     float64 `json:"reps,omitempty"`
+	Load     float64 `json:"load,omitempty"`
+	Duration float64 `json:"duration,omitempty"`
+	Distance float64 `json:"distance,omitempty"`
+	RPE      float64 `json:"rpe,omitempty"`
+}
+
+func marshalSportSets(sets []s.SportSet) (string, error) {
+	dbSets := make([]sportSetDB, 0, len(sets))
+	for _, set := range sets {
+		dbSets = append(dbSets, sportSetDB(set))
+	}
+
+	b, err := json.Marshal(dbSets)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+func unmarshalSportSets(sSets string) ([]s.SportSet, error) {
+	var dbSets []sportSetDB
+	if err := json.Unmarshal([]byte(sSets), &dbSets); err != nil {
+		return nil, err
+	}
+
+	sets := make([]s.SportSet, 0, len(dbSets))
+	for _, set := range dbSets {
+		sets = append(sets, s.SportSet(set))
+	}
+
+	return sets, nil
+}
@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	s "github.com/devldavydov/myhealth/internal/storage"
+)
+
+func (r *StoragePostgres) GetTotalBurnedCal(ctx context.Context, userID int64, timestamp s.Timestamp) (float64, error) {
+	var totalCal float64
+	err := r.conn().
+		QueryRowContext(ctx, _sqlGetTotalBurnedCal, userID, timestamp).
+		Scan(&totalCal)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, s.ErrTotalBurnedCalNotFound
+		}
+		return 0, err
+	}
+
+	return totalCal, nil
+}
+
+func (r *StoragePostgres) SetTotalBurnedCal(ctx context.Context, userID int64, timestamp s.Timestamp, totalCal float64) error {
+	if totalCal <= 0 {
+		return s.ErrDayTotalCalInvalid
+	}
+
+	_, err := r.conn().ExecContext(ctx,
+		_sqlSetTotalBurnedCal,
+		userID,
+		timestamp,
+		totalCal,
+	)
+
+	return err
+}
+
+func (r *StoragePostgres) DeleteTotalBurnedCal(ctx context.Context, userID int64, timestamp s.Timestamp) error {
+	_, err := r.conn().ExecContext(ctx,
+		_sqlDeleteTotalBurnedCal,
+		userID,
+		timestamp,
+	)
+
+	return err
+}
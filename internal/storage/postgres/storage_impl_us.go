@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	s "github.com/devldavydov/myhealth/internal/storage"
+)
+
+func (r *StoragePostgres) GetUserSettings(ctx context.Context, userID int64) (*s.UserSettings, error) {
+	var us s.UserSettings
+	var sProfile, sNutrientLimits sql.NullString
+	err := r.conn().
+		QueryRowContext(ctx, _sqlGetUserSettings, userID).
+		Scan(&us.CalLimit, &us.TDEEAutoUpdate, &sProfile, &us.WaterGoal, &us.WaterReminder, &sNutrientLimits, &us.ReportFormat)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, s.ErrUserSettingsNotFound
+		}
+		return nil, err
+	}
+
+	if us.Profile, err = unmarshalUserProfile(sProfile); err != nil {
+		return nil, err
+	}
+
+	if us.NutrientLimits, err = unmarshalNutrients(sNutrientLimits); err != nil {
+		return nil, err
+	}
+
+	return &us, nil
+}
+
+func (r *StoragePostgres) SetUserSettings(ctx context.Context, userID int64, us *s.UserSettings) error {
+	if !us.Validate() {
+		return s.ErrUserSettingsInvalid
+	}
+
+	sProfile, err := marshalUserProfile(us.Profile)
+	if err != nil {
+		return err
+	}
+
+	sNutrientLimits, err := marshalNutrients(us.NutrientLimits)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.conn().ExecContext(ctx,
+		_sqlSetUserSettings,
+		userID,
+		us.CalLimit,
+		us.TDEEAutoUpdate,
+		sProfile,
+		us.WaterGoal,
+		us.WaterReminder,
+		sNutrientLimits,
+		us.ReportFormat,
+	)
+	return err
+}
+
+func marshalUserProfile(p *s.UserProfile) (sql.NullString, error) {
+	if p == nil {
+		return sql.NullString{}, nil
+	}
+
+	b, err := json.Marshal(p)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+
+	return sql.NullString{String: string(b), Valid: true}, nil
+}
+
+func unmarshalUserProfile(sProfile sql.NullString) (*s.UserProfile, error) {
+	if !sProfile.Valid {
+		return nil, nil
+	}
+
+	var p s.UserProfile
+	if err := json.Unmarshal([]byte(sProfile.String), &p); err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
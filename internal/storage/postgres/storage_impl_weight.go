@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	s "github.com/devldavydov/myhealth/internal/storage"
+)
+
+func (r *StoragePostgres) GetWeightList(ctx context.Context, userID int64, from, to s.Timestamp, desc bool) ([]s.Weight, error) {
+	q := _sqlGetWeightList
+	if desc {
+		q = _sqlGetWeightListDesc
+	}
+	rows, err := r.conn().QueryContext(ctx, q, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []s.Weight{}
+	for rows.Next() {
+		var w s.Weight
+		err = rows.Scan(&w.Timestamp, &w.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, w)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(list) == 0 {
+		return nil, s.ErrEmptyResult
+	}
+
+	return list, nil
+}
+
+func (r *StoragePostgres) GetWeight(ctx context.Context, userID int64, ts s.Timestamp) (*s.Weight, error) {
+	var f s.Weight
+	err := r.conn().
+		QueryRowContext(ctx, _sqlGetWeight, userID, ts).
+		Scan(&f.Timestamp, &f.Value)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, s.ErrWeightNotFound
+		}
+		return nil, err
+	}
+
+	return &f, nil
+}
+
+func (r *StoragePostgres) GetLastWeight(ctx context.Context, userID int64, ts s.Timestamp) (*s.Weight, error) {
+	var f s.Weight
+	err := r.conn().
+		QueryRowContext(ctx, _sqlGetLastWeight, userID, ts).
+		Scan(&f.Timestamp, &f.Value)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, s.ErrWeightNotFound
+		}
+		return nil, err
+	}
+
+	return &f, nil
+}
+
+func (r *StoragePostgres) SetWeight(ctx context.Context, userID int64, weight *s.Weight) error {
+	if !weight.Validate() {
+		return s.ErrWeightInvalid
+	}
+
+	_, err := r.conn().ExecContext(ctx, _sqlSetWeight, userID, weight.Timestamp, weight.Value)
+	return err
+}
+
+func (r *StoragePostgres) DeleteWeight(ctx context.Context, userID int64, from, to s.Timestamp) error {
+	_, err := r.conn().ExecContext(ctx, _sqlDeleteWeight, userID, from, to)
+	return err
+}
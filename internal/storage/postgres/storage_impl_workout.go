@@ -0,0 +1,238 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	s "github.com/devldavydov/myhealth/internal/storage"
+	"github.com/lib/pq"
+)
+
+//
+// Workout.
+//
+
+func (r *StoragePostgres) GetWorkout(ctx context.Context, userID int64, key string) (*s.Workout, error) {
+	var w s.Workout
+	var sItems string
+	err := r.conn().
+		QueryRowContext(ctx, _sqlGetWorkout, userID, key).
+		Scan(&w.Key, &w.Name, &sItems, &w.Comment)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, s.ErrWorkoutNotFound
+		}
+		return nil, err
+	}
+
+	if w.Items, err = unmarshalWorkoutItems(sItems); err != nil {
+		return nil, err
+	}
+
+	return &w, nil
+}
+
+func (r *StoragePostgres) GetWorkoutList(ctx context.Context, userID int64) ([]s.Workout, error) {
+	rows, err := r.conn().QueryContext(ctx, _sqlGetWorkoutList, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []s.Workout{}
+	for rows.Next() {
+		var w s.Workout
+		var sItems string
+		err = rows.Scan(&w.Key, &w.Name, &sItems, &w.Comment)
+		if err != nil {
+			return nil, err
+		}
+
+		if w.Items, err = unmarshalWorkoutItems(sItems); err != nil {
+			return nil, err
+		}
+
+		list = append(list, w)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(list) == 0 {
+		return nil, s.ErrEmptyResult
+	}
+
+	return list, nil
+}
+
+func (r *StoragePostgres) SetWorkout(ctx context.Context, userID int64, w *s.Workout) error {
+	if !w.Validate() {
+		return s.ErrWorkoutInvalid
+	}
+
+	// Sets are stored in set kind of each sport
+	items := make([]s.WorkoutItem, 0, len(w.Items))
+	for _, item := range w.Items {
+		sp, err := r.GetSport(ctx, userID, item.SportKey)
+		if err != nil {
+			return err
+		}
+
+		sets, err := sp.SetKind.Normalize(item.Sets)
+		if err != nil {
+			return s.ErrWorkoutInvalid
+		}
+
+		items = append(items, s.WorkoutItem{SportKey: item.SportKey, Sets: sets})
+	}
+
+	sItems, err := marshalWorkoutItems(items)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.conn().ExecContext(ctx,
+		_sqlSetWorkout,
+		userID,
+		w.Key,
+		w.Name,
+		sItems,
+		w.Comment,
+	)
+	return err
+}
+
+func (r *StoragePostgres) DeleteWorkout(ctx context.Context, userID int64, key string) error {
+	_, err := r.conn().ExecContext(ctx, _sqlDeleteWorkout, userID, key)
+	if err != nil {
+		var errPq *pq.Error
+		if errors.As(err, &errPq) && errPq.Code == _errForeignKey {
+			return s.ErrWorkoutIsUsed
+		}
+		return err
+	}
+
+	return nil
+}
+
+// isSportUsedInWorkout checks workouts, they reference sports in JSON
+// items without foreign key.
+func (r *StoragePostgres) isSportUsedInWorkout(ctx context.Context, userID int64, sportKey string) (bool, error) {
+	list, err := r.GetWorkoutList(ctx, userID)
+	if err != nil {
+		if errors.Is(err, s.ErrEmptyResult) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	for _, w := range list {
+		for _, item := range w.Items {
+			if item.SportKey == sportKey {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+type workoutItemDB struct {
+	SportKey string       `json:"sport_key"`
+	Sets     []sportSetDB `json:"sets"`
+}
+
+func marshalWorkoutItems(items []s.WorkoutItem) (string, error) {
+	dbItems := make([]workoutItemDB, 0, len(items))
+	for _, item := range items {
+		dbSets := make([]sportSetDB, 0, len(item.Sets))
+		for _, set := range item.Sets {
+			dbSets = append(dbSets, sportSetDB(set))
+		}
+		dbItems = append(dbItems, workoutItemDB{SportKey: item.SportKey, Sets: dbSets})
+	}
+
+	b, err := json.Marshal(dbItems)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+func unmarshalWorkoutItems(sItems string) ([]s.WorkoutItem, error) {
+	var dbItems []workoutItemDB
+	if err := json.Unmarshal([]byte(sItems), &dbItems); err != nil {
+		return nil, err
+	}
+
+	items := make([]s.WorkoutItem, 0, len(dbItems))
+	for _, item := range dbItems {
+		sets := make([]s.SportSet, 0, len(item.Sets))
+		for _, set := range item.Sets {
+			sets = append(sets, s.SportSet(set))
+		}
+		items = append(items, s.WorkoutItem{SportKey: item.SportKey, Sets: sets})
+	}
+
+	return items, nil
+}
+
+//
+// WorkoutPlan.
+//
+
+func (r *StoragePostgres) GetWorkoutPlan(ctx context.Context, userID int64) ([]s.WorkoutPlanDay, error) {
+	rows, err := r.conn().QueryContext(ctx, _sqlGetWorkoutPlan, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []s.WorkoutPlanDay{}
+	for rows.Next() {
+		var pd s.WorkoutPlanDay
+		err = rows.Scan(&pd.Weekday, &pd.WorkoutKey)
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, pd)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(list) == 0 {
+		return nil, s.ErrEmptyResult
+	}
+
+	return list, nil
+}
+
+func (r *StoragePostgres) SetWorkoutPlanDay(ctx context.Context, userID int64, pd *s.WorkoutPlanDay) error {
+	if !pd.Validate() {
+		return s.ErrWorkoutPlanDayInvalid
+	}
+
+	_, err := r.conn().ExecContext(ctx, _sqlSetWorkoutPlanDay, userID, pd.Weekday, pd.WorkoutKey)
+	if err != nil {
+		var errPq *pq.Error
+		if errors.As(err, &errPq) && errPq.Code == _errForeignKey {
+			return s.ErrWorkoutNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *StoragePostgres) DeleteWorkoutPlanDay(ctx context.Context, userID int64, weekday time.Weekday) error {
+	_, err := r.conn().ExecContext(ctx, _sqlDeleteWorkoutPlanDay, userID, weekday)
+	return err
+}
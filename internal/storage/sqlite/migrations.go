@@ -87,6 +87,30 @@ func getAllMigrations() []migration {
 		{13, alterTableMedicineAddUnit},
 		{14, createTableTotalBurnedCal},
 		{15, alterTablSportActivityAddComment},
+		{16, alterTableUserSettingsAddTDEEAuto},
+		{17, createTableTDEEEstimate},
+		{18, alterTableUserSettingsAddProfile},
+		{19, alterTableSportAddMET},
+		{20, alterTableSportAddCalPerUnit},
+		{21, alterTableSportActivityAddDuration},
+		{22, alterTableSportActivityAddCal},
+		{23, alterTableSportAddSetKind},
+		{24, updateSportActivityConvertSets},
+		{25, createTableWorkout},
+		{26, createTableWorkoutPlan},
+		{27, recreateTableSportActivityWithID},
+		{28, createTableSleep},
+		{29, createTableWater},
+		{30, alterTableFoodAddBeverage},
+		{31, alterTableUserSettingsAddWaterGoal},
+		{32, alterTableUserSettingsAddWaterReminder},
+		{33, alterTableFoodAddNutrients},
+		{34, alterTableUserSettingsAddNutrientLimits},
+		{35, alterTableFoodAddPortions},
+		{36, alterTableFoodAddDensity},
+		{37, alterTableJournalAddPortion},
+		{38, createTableFast},
+		{39, alterTableUserSettingsAddReportFormat},
 	}
 }
 
@@ -164,3 +188,134 @@ func alterTablSportActivityAddComment(ctx context.Context, tx *sql.Tx) error {
 	_, err := tx.ExecContext(ctx, _sqlAlterTablSportActivityAddComment)
 	return err
 }
+
+func alterTableUserSettingsAddTDEEAuto(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, _sqlAlterTableUserSettingsAddTDEEAuto)
+	return err
+}
+
+func createTableTDEEEstimate(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, _sqlCreateTableTDEEEstimate)
+	return err
+}
+
+func alterTableUserSettingsAddProfile(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, _sqlAlterTableUserSettingsAddProfile)
+	return err
+}
+
+func alterTableSportAddMET(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, _sqlAlterTableSportAddMET)
+	return err
+}
+
+func alterTableSportAddCalPerUnit(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, _sqlAlterTableSportAddCalPerUnit)
+	return err
+}
+
+func alterTableSportActivityAddDuration(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, _sqlAlterTableSportActivityAddDuration)
+	return err
+}
+
+func alterTableSportActivityAddCal(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, _sqlAlterTableSportActivityAddCal)
+	return err
+}
+
+func alterTableSportAddSetKind(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, _sqlAlterTableSportAddSetKind)
+	return err
+}
+
+func updateSportActivityConvertSets(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, _sqlUpdateSportActivityConvertSets)
+	return err
+}
+
+func createTableWorkout(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, _sqlCreateTableWorkout)
+	return err
+}
+
+func createTableWorkoutPlan(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, _sqlCreateTableWorkoutPlan)
+	return err
+}
+
+func recreateTableSportActivityWithID(ctx context.Context, tx *sql.Tx) error {
+	for _, query := range []string{
+		_sqlCreateTableSportActivityWithID,
+		_sqlCopySportActivityWithID,
+		_sqlDropTableSportActivity,
+		_sqlRenameTableSportActivityNew,
+		_sqlCreateTableSportActivityIndexUserIDTimestamp,
+	} {
+		if _, err := tx.ExecContext(ctx, query); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func createTableSleep(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, _sqlCreateTableSleep)
+	return err
+}
+
+func createTableWater(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, _sqlCreateTableWater)
+	return err
+}
+
+func alterTableFoodAddBeverage(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, _sqlAlterTableFoodAddBeverage)
+	return err
+}
+
+func alterTableUserSettingsAddWaterGoal(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, _sqlAlterTableUserSettingsAddWaterGoal)
+	return err
+}
+
+func alterTableUserSettingsAddWaterReminder(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, _sqlAlterTableUserSettingsAddWaterReminder)
+	return err
+}
+
+func alterTableFoodAddNutrients(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, _sqlAlterTableFoodAddNutrients)
+	return err
+}
+
+func alterTableUserSettingsAddNutrientLimits(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, _sqlAlterTableUserSettingsAddNutrientLimits)
+	return err
+}
+
+func alterTableUserSettingsAddReportFormat(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, _sqlAlterTableUserSettingsAddReportFormat)
+	return err
+}
+
+func alterTableFoodAddPortions(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, _sqlAlterTableFoodAddPortions)
+	return err
+}
+
+func alterTableFoodAddDensity(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, _sqlAlterTableFoodAddDensity)
+	return err
+}
+
+func alterTableJournalAddPortion(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, _sqlAlterTableJournalAddPortion)
+	return err
+}
+
+func createTableFast(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, _sqlCreateTableFast)
+	return err
+}
@@ -40,6 +40,14 @@ const (
 	WHERE user_id = $1 AND timestamp = $2
 	`
 
+	_sqlGetLastWeight = `
+	SELECT timestamp, value
+	FROM weight
+	WHERE user_id = $1 AND timestamp <= $2
+	ORDER BY timestamp DESC
+	LIMIT 1
+	`
+
 	_sqlGetWeightList = `
 	SELECT timestamp, value
     FROM weight
@@ -78,7 +86,7 @@ const (
 	_sqlDeleteWeight = `
 	DELETE
 	FROM weight
-	WHERE user_id = $1 AND timestamp = $2
+	WHERE user_id = $1 AND timestamp >= $2 AND timestamp <= $3
 	`
 
 	//
@@ -99,24 +107,36 @@ const (
 	ALTER TABLE sport ADD unit TEXT NOT NULL DEFAULT('шт')
 	`
 
+	_sqlAlterTableSportAddMET = `
+	ALTER TABLE sport ADD met REAL NOT NULL DEFAULT(0)
+	`
+
+	_sqlAlterTableSportAddCalPerUnit = `
+	ALTER TABLE sport ADD cal_per_unit REAL NOT NULL DEFAULT(0)
+	`
+
+	_sqlAlterTableSportAddSetKind = `
+	ALTER TABLE sport ADD set_kind TEXT NOT NULL DEFAULT('value')
+	`
+
 	_sqlGetSport = `
-	SELECT key, name, comment, unit
+	SELECT key, name, comment, unit, met, cal_per_unit, set_kind
     FROM sport
     WHERE user_id = $1 AND key = $2
 	`
 
 	_sqlGetSportList = `
-	SELECT key, name, comment, unit
+	SELECT key, name, comment, unit, met, cal_per_unit, set_kind
     FROM sport
     WHERE user_id = $1
 	ORDER BY name
 	`
 
 	_sqlSetSport = `
-	INSERT INTO sport (user_id, key, name, comment, unit)
-	VALUES ($1, $2, $3, $4, $5)
+	INSERT INTO sport (user_id, key, name, comment, unit, met, cal_per_unit, set_kind)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 	ON CONFLICT (user_id, key) DO
-	UPDATE SET name = $3, comment = $4, unit = $5
+	UPDATE SET name = $3, comment = $4, unit = $5, met = $6, cal_per_unit = $7, set_kind = $8
 	`
 
 	_sqlDeleteSport = `
@@ -126,7 +146,7 @@ const (
 	`
 
 	_sqlSportBackup = `
-	SELECT user_id, key, name, comment, unit
+	SELECT user_id, key, name, comment, unit, met, cal_per_unit, set_kind
     FROM sport
 	ORDER BY user_id, key
 	`
@@ -146,27 +166,45 @@ const (
     ) STRICT
 	`
 
-	_sqlSetSportActivity = `
+	_sqlAddSportActivity = `
     INSERT INTO sport_activity (
-        user_id, timestamp, sport_key, sets, comment
+        user_id, timestamp, sport_key, sets, comment, duration, cal
     )
-    VALUES ($1, $2, $3, $4, $5)
-    ON CONFLICT (user_id, timestamp, sport_key) DO
-    UPDATE SET
-        sets = $4,
-		comment = $5
+    VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_sqlUpdateSportActivity = `
+    UPDATE sport_activity
+    SET
+        timestamp = $1,
+        sport_key = $2,
+        sets = $3,
+        comment = $4,
+        duration = $5,
+        cal = $6
+    WHERE
+        user_id = $7 AND
+        id = $8
 	`
 
 	_sqlDeleteSportActivity = `
 	DELETE FROM sport_activity
     WHERE
         user_id = $1 AND
-        timestamp = $2 AND
-        sport_key = $3
+        timestamp >= $2 AND
+        timestamp <= $3 AND
+        sport_key = $4
+	`
+
+	_sqlDeleteSportActivityByID = `
+	DELETE FROM sport_activity
+    WHERE
+        user_id = $1 AND
+        id = $2
 	`
 
 	_sqlGetSportActivityReport = `
-    SELECT sa.timestamp, concat(s.name, ' [', s.unit, ']') as sport_name, sa.sets, sa.comment
+    SELECT sa.timestamp, concat(s.name, ' [', s.unit, ']') as sport_name, sa.sets, sa.duration, sa.cal, sa.comment, sa.sport_key, sa.id
     FROM
         sport_activity sa,
         sport s
@@ -178,19 +216,84 @@ const (
         sa.timestamp <= $3
     ORDER BY
         sa.timestamp,
-        sport_name	
+        sport_name,
+        sa.id
 	`
 
 	_sqlSportActivityBackup = `
-	SELECT user_id, timestamp, sport_key, sets
+	SELECT user_id, timestamp, sport_key, sets, duration, cal
 	FROM sport_activity
-	ORDER BY user_id, timestamp, sport_key
+	ORDER BY user_id, timestamp, id
+	`
+
+	_sqlGetSportActivityHistory = `
+	SELECT id, timestamp, sets, duration, cal, comment
+	FROM sport_activity
+	WHERE user_id = $1 AND sport_key = $2
+	ORDER BY timestamp, id
+	`
+
+	_sqlGetSportActivityCal = `
+	SELECT coalesce(sum(cal), 0)
+	FROM sport_activity
+	WHERE user_id = $1 AND timestamp >= $2 AND timestamp <= $3
 	`
 
 	_sqlAlterTablSportActivityAddComment = `
 	ALTER TABLE sport_activity ADD comment TEXT NOT NULL DEFAULT('')
 	`
 
+	_sqlAlterTableSportActivityAddDuration = `
+	ALTER TABLE sport_activity ADD duration REAL NOT NULL DEFAULT(0)
+	`
+
+	_sqlAlterTableSportActivityAddCal = `
+	ALTER TABLE sport_activity ADD cal REAL NOT NULL DEFAULT(0)
+	`
+
+	_sqlUpdateSportActivityConvertSets = `
+	UPDATE sport_activity
+	SET sets = (
+		SELECT json_group_array(json_object('value', j.value))
+		FROM json_each(sport_activity.sets) j
+	)
+	`
+
+	_sqlCreateTableSportActivityWithID = `
+	CREATE TABLE sport_activity_new (
+        id        INTEGER PRIMARY KEY,
+        user_id   INTEGER NOT NULL,
+        timestamp INTEGER NOT NULL,
+        sport_key TEXT NOT NULL,
+        sets      TEXT NOT NULL,
+        comment   TEXT NOT NULL DEFAULT(''),
+        duration  REAL NOT NULL DEFAULT(0),
+        cal       REAL NOT NULL DEFAULT(0),
+        FOREIGN KEY (user_id, sport_key) REFERENCES sport(user_id, key) ON DELETE RESTRICT
+    ) STRICT
+	`
+
+	_sqlCopySportActivityWithID = `
+	INSERT INTO sport_activity_new (
+        user_id, timestamp, sport_key, sets, comment, duration, cal
+    )
+	SELECT user_id, timestamp, sport_key, sets, comment, duration, cal
+	FROM sport_activity
+	ORDER BY user_id, timestamp, sport_key
+	`
+
+	_sqlDropTableSportActivity = `
+	DROP TABLE sport_activity
+	`
+
+	_sqlRenameTableSportActivityNew = `
+	ALTER TABLE sport_activity_new RENAME TO sport_activity
+	`
+
+	_sqlCreateTableSportActivityIndexUserIDTimestamp = `
+	CREATE INDEX sport_activity_userid_timestamp ON sport_activity(user_id, timestamp);
+	`
+
 	//
 	// Medicine.
 	//
@@ -270,8 +373,9 @@ const (
 	DELETE FROM medicine_indicator
     WHERE
         user_id = $1 AND
-        timestamp = $2 AND
-        medicine_key = $3
+        timestamp >= $2 AND
+        timestamp <= $3 AND
+        medicine_key = $4
 	`
 
 	_sqlGetMedicineIndicatorReport = `
@@ -307,24 +411,57 @@ const (
     ) STRICT
 	`
 
+	_sqlAlterTableUserSettingsAddTDEEAuto = `
+	ALTER TABLE user_settings ADD tdee_auto INTEGER NOT NULL DEFAULT(0)
+	`
+
+	_sqlAlterTableUserSettingsAddProfile = `
+	ALTER TABLE user_settings ADD profile TEXT
+	`
+
+	_sqlAlterTableUserSettingsAddWaterGoal = `
+	ALTER TABLE user_settings ADD water_goal REAL NOT NULL DEFAULT(0)
+	`
+
+	_sqlAlterTableUserSettingsAddWaterReminder = `
+	ALTER TABLE user_settings ADD water_reminder INTEGER NOT NULL DEFAULT(0)
+	`
+
+	_sqlAlterTableUserSettingsAddNutrientLimits = `
+	ALTER TABLE user_settings ADD nutrient_limits TEXT
+	`
+
+	_sqlAlterTableUserSettingsAddReportFormat = `
+	ALTER TABLE user_settings ADD report_format TEXT NOT NULL DEFAULT('')
+	`
+
 	_sqlGetUserSettings = `
-	SELECT cal_limit
+	SELECT cal_limit, tdee_auto, profile, water_goal, water_reminder, nutrient_limits,
+        report_format
     FROM user_settings
     WHERE user_id = $1
 	`
 
 	_sqlSetUserSettings = `
 	INSERT INTO user_settings (
-        user_id, cal_limit
+        user_id, cal_limit, tdee_auto, profile, water_goal, water_reminder,
+        nutrient_limits, report_format
     )
-    VALUES ($1, $2)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
     ON CONFLICT (user_id) DO
     UPDATE SET
-        cal_limit = $2
+        cal_limit = $2,
+        tdee_auto = $3,
+        profile = $4,
+        water_goal = $5,
+        water_reminder = $6,
+        nutrient_limits = $7,
+        report_format = $8
 	`
 
 	_sqlUserSettingsBackup = `
-	SELECT user_id, cal_limit
+	SELECT user_id, cal_limit, tdee_auto, profile, water_goal, water_reminder, nutrient_limits,
+        report_format
     FROM user_settings
     ORDER BY user_id
 	`
@@ -351,7 +488,8 @@ const (
 	_sqlGetFood = `
 	SELECT 
         key, name, brand, cal100,
-        prot100, fat100, carb100, comment
+        prot100, fat100, carb100, comment,
+        beverage, nutrients, portions, density
     FROM food
     WHERE user_id = $1 AND key = $2
 	`
@@ -359,7 +497,8 @@ const (
 	_sqlGetFoodList = `
 	SELECT 
         key, name, brand, cal100,
-        prot100, fat100, carb100, comment
+        prot100, fat100, carb100, comment,
+        beverage, nutrients, portions, density
     FROM food
     WHERE user_id = $1
 	ORDER BY name, key
@@ -368,7 +507,8 @@ const (
 	_sqlFindFood = `
 	SELECT 
         key, name, brand, cal100,
-        prot100, fat100, carb100, comment
+        prot100, fat100, carb100, comment,
+        beverage, nutrients, portions, density
     FROM food
     WHERE
 		user_id = $1 AND
@@ -384,14 +524,32 @@ const (
 	_sqlSetFood = `
 	INSERT INTO food (
         user_id, key, name, brand, cal100,
-        prot100, fat100, carb100, comment
+        prot100, fat100, carb100, comment,
+        beverage, nutrients, portions, density
     )
-    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
     ON CONFLICT (user_id, key) DO
     UPDATE SET
         name = $3, brand = $4, cal100 = $5,
         prot100 = $6, fat100 = $7, carb100 = $8,
-        comment = $9
+        comment = $9, beverage = $10, nutrients = $11,
+        portions = $12, density = $13
+	`
+
+	_sqlAlterTableFoodAddBeverage = `
+	ALTER TABLE food ADD beverage INTEGER NOT NULL DEFAULT(0)
+	`
+
+	_sqlAlterTableFoodAddNutrients = `
+	ALTER TABLE food ADD nutrients TEXT
+	`
+
+	_sqlAlterTableFoodAddPortions = `
+	ALTER TABLE food ADD portions TEXT
+	`
+
+	_sqlAlterTableFoodAddDensity = `
+	ALTER TABLE food ADD density REAL NOT NULL DEFAULT(0)
 	`
 
 	_sqlDeleteFood = `
@@ -402,7 +560,8 @@ const (
 	_sqlFoodBackup = `
 	SELECT 
         user_id, key, name, brand, cal100,
-        prot100, fat100, carb100, comment
+        prot100, fat100, carb100, comment,
+        beverage, nutrients, portions, density
     FROM food
 	ORDER BY user_id, key
 	`
@@ -476,27 +635,34 @@ const (
 
 	_sqlSetJournal = `
 	INSERT INTO journal (
-        user_id, timestamp, meal, foodkey, foodweight
+        user_id, timestamp, meal, foodkey, foodweight, portion
     )
-    VALUES ($1, $2, $3, $4, $5)
+    VALUES ($1, $2, $3, $4, $5, $6)
     ON CONFLICT (user_id, timestamp, meal, foodkey) DO
     UPDATE SET
-        foodweight = $5
+        foodweight = $5,
+        portion = $6
+	`
+
+	_sqlAlterTableJournalAddPortion = `
+	ALTER TABLE journal ADD portion TEXT NOT NULL DEFAULT('')
 	`
 
 	_sqlDeleteJournal = `
     DELETE FROM journal
     WHERE user_id = $1 AND
-          timestamp = $2 AND
-          meal = $3 AND
-          foodkey = $4
+          timestamp >= $2 AND
+          timestamp <= $3 AND
+          meal = $4 AND
+          foodkey = $5
 	`
 
 	_sqlDeleteJournalMeal = `
 	DELETE FROM journal
 	WHERE user_id = $1 AND
-		timestamp = $2 AND
-		meal = $3
+		timestamp >= $2 AND
+		timestamp <= $3 AND
+		meal = $4
 	`
 
 	_sqlJournalFoodStat = `
@@ -510,6 +676,7 @@ const (
 	WHERE
 		j.user_id = $1 AND
 		j.foodkey = $2
+	HAVING count(*) > 0
 	`
 
 	_sqlGetJournalReport = `
@@ -523,7 +690,10 @@ const (
         j.foodweight / 100 * f.cal100 AS cal,
         j.foodweight / 100 * f.prot100 AS prot,
         j.foodweight / 100 * f.fat100 AS fat,
-        j.foodweight / 100 * f.carb100 AS carb
+        j.foodweight / 100 * f.carb100 AS carb,
+        f.beverage,
+        f.nutrients,
+        j.portion
     FROM journal j, food f
     WHERE
         j.foodkey = f.key AND
@@ -537,16 +707,43 @@ const (
         f.name
 	`
 
+	_sqlGetJournalMealSuggestions = `
+	WITH p AS (
+		SELECT $1 AS user_id, $2 AS meal, $3 AS ts_from, $4 AS ts_to, $5 AS lim
+	)
+	SELECT
+		j.foodkey,
+		f.name AS foodname,
+		f.brand AS foodbrand,
+		count(*) AS cnt,
+		sum(1.0 / (1.0 + (p.ts_to - j.timestamp) / 86400000.0)) AS score
+	FROM journal j, food f, p
+	WHERE
+		j.foodkey = f.key AND
+		f.user_id = p.user_id AND
+		j.user_id = p.user_id AND
+		j.meal = p.meal AND
+		j.timestamp >= p.ts_from AND
+		j.timestamp <= p.ts_to
+	GROUP BY
+		j.foodkey, f.name, f.brand
+	ORDER BY
+		score DESC,
+		f.name
+	LIMIT (SELECT lim FROM p)
+	`
+
 	_sqlGetJournalListForCopy = `
-	SELECT foodkey, foodweight
+	SELECT timestamp, foodkey, foodweight, portion
 	FROM journal
 	WHERE user_id = $1 AND
-		timestamp = $2 AND
-		meal = $3
+		timestamp >= $2 AND
+		timestamp <= $3 AND
+		meal = $4
 	`
 
 	_sqlJournalBackup = `
-	SELECT user_id, timestamp, meal, foodkey, foodweight
+	SELECT user_id, timestamp, meal, foodkey, foodweight, portion
 	FROM journal
 	ORDER BY user_id, timestamp, meal, foodkey
 	`
@@ -592,4 +789,315 @@ const (
 	FROM total_burned_cal
 	ORDER BY user_id, timestamp
 	`
+
+	//
+	// TDEEEstimate.
+	//
+
+	_sqlCreateTableTDEEEstimate = `
+	CREATE TABLE tdee_estimate (
+        user_id    INTEGER NOT NULL,
+        timestamp  INTEGER NOT NULL,
+        weeks      INTEGER NOT NULL,
+        tdee       REAL NOT NULL,
+        conf_low   REAL NOT NULL,
+        conf_high  REAL NOT NULL,
+        applied    INTEGER NOT NULL,
+        PRIMARY KEY (user_id, timestamp)
+    ) STRICT
+	`
+
+	_sqlSetTDEEEstimate = `
+	INSERT INTO tdee_estimate (
+        user_id, timestamp, weeks, tdee, conf_low, conf_high, applied
+    )
+    VALUES ($1, $2, $3, $4, $5, $6, $7)
+    ON CONFLICT (user_id, timestamp) DO
+    UPDATE SET
+        weeks = $3,
+        tdee = $4,
+        conf_low = $5,
+        conf_high = $6,
+        applied = $7
+	`
+
+	_sqlGetLastTDEEEstimate = `
+	SELECT timestamp, weeks, tdee, conf_low, conf_high, applied
+	FROM tdee_estimate
+	WHERE user_id = $1
+	ORDER BY timestamp DESC
+	LIMIT 1
+	`
+
+	_sqlGetTDEEEstimateList = `
+	SELECT timestamp, weeks, tdee, conf_low, conf_high, applied
+	FROM tdee_estimate
+	WHERE
+		user_id = $1 AND
+		timestamp >= $2 AND
+		timestamp <= $3
+	ORDER BY timestamp
+	`
+
+	_sqlTDEEEstimateBackup = `
+	SELECT user_id, timestamp, weeks, tdee, conf_low, conf_high, applied
+	FROM tdee_estimate
+	ORDER BY user_id, timestamp
+	`
+
+	//
+	// Workout.
+	//
+
+	_sqlCreateTableWorkout = `
+	CREATE TABLE workout (
+        user_id INTEGER NOT NULL,
+        key     TEXT NOT NULL,
+        name    TEXT NOT NULL,
+        items   TEXT NOT NULL,
+        comment TEXT NOT NULL,
+        PRIMARY KEY (user_id, key)
+    ) STRICT
+	`
+
+	_sqlGetWorkout = `
+	SELECT key, name, items, comment
+	FROM workout
+	WHERE user_id = $1 AND key = $2
+	`
+
+	_sqlGetWorkoutList = `
+	SELECT key, name, items, comment
+	FROM workout
+	WHERE user_id = $1
+	ORDER BY name
+	`
+
+	_sqlSetWorkout = `
+	INSERT INTO workout (
+        user_id, key, name, items, comment
+    )
+    VALUES ($1, $2, $3, $4, $5)
+    ON CONFLICT (user_id, key) DO
+    UPDATE SET
+        name = $3,
+        items = $4,
+        comment = $5
+	`
+
+	_sqlDeleteWorkout = `
+	DELETE FROM workout
+	WHERE user_id = $1 AND key = $2
+	`
+
+	_sqlWorkoutBackup = `
+	SELECT user_id, key, name, items, comment
+	FROM workout
+	ORDER BY user_id, key
+	`
+
+	//
+	// WorkoutPlan.
+	//
+
+	_sqlCreateTableWorkoutPlan = `
+	CREATE TABLE workout_plan (
+        user_id     INTEGER NOT NULL,
+        weekday     INTEGER NOT NULL,
+        workout_key TEXT NOT NULL,
+        PRIMARY KEY (user_id, weekday),
+        FOREIGN KEY (user_id, workout_key) REFERENCES workout(user_id, key) ON DELETE RESTRICT
+    ) STRICT
+	`
+
+	_sqlGetWorkoutPlan = `
+	SELECT weekday, workout_key
+	FROM workout_plan
+	WHERE user_id = $1
+	ORDER BY weekday
+	`
+
+	_sqlSetWorkoutPlanDay = `
+	INSERT INTO workout_plan (
+        user_id, weekday, workout_key
+    )
+    VALUES ($1, $2, $3)
+    ON CONFLICT (user_id, weekday) DO
+    UPDATE SET
+        workout_key = $3
+	`
+
+	_sqlDeleteWorkoutPlanDay = `
+	DELETE FROM workout_plan
+	WHERE user_id = $1 AND weekday = $2
+	`
+
+	_sqlWorkoutPlanBackup = `
+	SELECT user_id, weekday, workout_key
+	FROM workout_plan
+	ORDER BY user_id, weekday
+	`
+
+	//
+	// Sleep.
+	//
+
+	_sqlCreateTableSleep = `
+	CREATE TABLE sleep (
+        user_id   INTEGER NOT NULL,
+        timestamp INTEGER NOT NULL,
+        bed_time  INTEGER NOT NULL,
+        wake_time INTEGER NOT NULL,
+        quality   INTEGER NOT NULL,
+        notes     TEXT NOT NULL,
+        PRIMARY KEY (user_id, timestamp)
+    ) STRICT
+	`
+
+	_sqlGetSleep = `
+	SELECT timestamp, bed_time, wake_time, quality, notes
+	FROM sleep
+	WHERE user_id = $1 AND timestamp = $2
+	`
+
+	_sqlGetSleepList = `
+	SELECT timestamp, bed_time, wake_time, quality, notes
+	FROM sleep
+	WHERE
+		user_id = $1 AND
+		timestamp >= $2 AND
+		timestamp <= $3
+	ORDER BY timestamp
+	`
+
+	_sqlSetSleep = `
+	INSERT INTO sleep (
+        user_id, timestamp, bed_time, wake_time, quality, notes
+    )
+    VALUES ($1, $2, $3, $4, $5, $6)
+    ON CONFLICT (user_id, timestamp) DO
+    UPDATE SET
+        bed_time = $3,
+        wake_time = $4,
+        quality = $5,
+        notes = $6
+	`
+
+	_sqlDeleteSleep = `
+	DELETE FROM sleep
+	WHERE user_id = $1 AND timestamp = $2
+	`
+
+	_sqlSleepBackup = `
+	SELECT user_id, timestamp, bed_time, wake_time, quality, notes
+	FROM sleep
+	ORDER BY user_id, timestamp
+	`
+
+	//
+	// Water.
+	//
+
+	_sqlCreateTableWater = `
+	CREATE TABLE water (
+        user_id   INTEGER NOT NULL,
+        timestamp INTEGER NOT NULL,
+        volume    REAL NOT NULL,
+        PRIMARY KEY (user_id, timestamp)
+    ) STRICT
+	`
+
+	_sqlGetWaterList = `
+	SELECT timestamp, volume
+	FROM water
+	WHERE
+		user_id = $1 AND
+		timestamp >= $2 AND
+		timestamp <= $3
+	ORDER BY timestamp
+	`
+
+	_sqlAddWater = `
+	INSERT INTO water (user_id, timestamp, volume)
+	VALUES ($1, $2, $3)
+	ON CONFLICT (user_id, timestamp) DO
+	UPDATE SET volume = volume + $3
+	`
+
+	_sqlDeleteWater = `
+	DELETE FROM water
+	WHERE
+		user_id = $1 AND
+		timestamp >= $2 AND
+		timestamp <= $3
+	`
+
+	_sqlWaterBackup = `
+	SELECT user_id, timestamp, volume
+	FROM water
+	ORDER BY user_id, timestamp
+	`
+
+	//
+	// Fast.
+	//
+
+	_sqlCreateTableFast = `
+	CREATE TABLE fast (
+        user_id    INTEGER NOT NULL,
+        start_time INTEGER NOT NULL,
+        end_time   INTEGER NOT NULL,
+        PRIMARY KEY (user_id, start_time)
+    ) STRICT
+	`
+
+	_sqlGetActiveFast = `
+	SELECT start_time, end_time
+	FROM fast
+	WHERE user_id = $1 AND end_time = 0
+	ORDER BY start_time DESC
+	LIMIT 1
+	`
+
+	_sqlGetFastList = `
+	SELECT start_time, end_time
+	FROM fast
+	WHERE
+		user_id = $1 AND
+		start_time >= $2 AND
+		start_time <= $3
+	ORDER BY start_time
+	`
+
+	_sqlFindOverlappedFast = `
+	SELECT start_time, end_time
+	FROM fast
+	WHERE
+		user_id = $1 AND
+		(end_time = 0 OR end_time > $2) AND
+		($3 = 0 OR start_time < $3)
+	LIMIT 1
+	`
+
+	_sqlAddFast = `
+	INSERT INTO fast (user_id, start_time, end_time)
+	VALUES ($1, $2, $3)
+	`
+
+	_sqlStopFast = `
+	UPDATE fast
+	SET end_time = $1
+	WHERE user_id = $2 AND start_time = $3 AND end_time = 0
+	`
+
+	_sqlDeleteFast = `
+	DELETE FROM fast
+	WHERE user_id = $1 AND start_time >= $2 AND start_time <= $3
+	`
+
+	_sqlFastBackup = `
+	SELECT user_id, start_time, end_time
+	FROM fast
+	ORDER BY user_id, start_time
+	`
 )
@@ -20,6 +20,7 @@ const (
 
 type StorageSQLite struct {
 	db     *sql.DB
+	tx     *sql.Tx
 	logger *zap.Logger
 }
 
@@ -66,7 +67,7 @@ func NewStorageSQLite(dbFilePath string, logger *zap.Logger) (*StorageSQLite, er
 }
 
 func (r *StorageSQLite) Close() error {
-	if r.db == nil {
+	if r.db == nil || r.tx != nil {
 		return nil
 	}
 
@@ -47,7 +47,7 @@ func (r *StorageSQLite) SetBundle(ctx context.Context, userID int64, bndl *s.Bun
 		return err
 	}
 
-	_, err = r.db.ExecContext(ctx,
+	_, err = r.conn().ExecContext(ctx,
 		_sqlSetBundle,
 		userID,
 		bndl.Key,
@@ -57,7 +57,7 @@ func (r *StorageSQLite) SetBundle(ctx context.Context, userID int64, bndl *s.Bun
 }
 
 func (r *StorageSQLite) GetBundle(ctx context.Context, userID int64, key string) (*s.Bundle, error) {
-	tx, err := r.db.Begin()
+	tx, err := r.begin()
 	if err != nil {
 		return nil, err
 	}
@@ -71,7 +71,7 @@ func (r *StorageSQLite) GetBundle(ctx context.Context, userID int64, key string)
 	return bndl, tx.Commit()
 }
 
-func getBundle(ctx context.Context, tx *sql.Tx, userID int64, key string) (*s.Bundle, error) {
+func getBundle(ctx context.Context, tx dbConn, userID int64, key string) (*s.Bundle, error) {
 	var b s.Bundle
 	var bData string
 	err := tx.
@@ -92,7 +92,7 @@ func getBundle(ctx context.Context, tx *sql.Tx, userID int64, key string) (*s.Bu
 }
 
 func (r *StorageSQLite) GetBundleList(ctx context.Context, userID int64) ([]s.Bundle, error) {
-	rows, err := r.db.QueryContext(ctx, _sqlGetBundleList, userID)
+	rows, err := r.conn().QueryContext(ctx, _sqlGetBundleList, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -143,7 +143,7 @@ func (r *StorageSQLite) DeleteBundle(ctx context.Context, userID int64, key stri
 		}
 	}
 
-	_, err = r.db.ExecContext(ctx, _sqlDeleteBundle, userID, key)
+	_, err = r.conn().ExecContext(ctx, _sqlDeleteBundle, userID, key)
 
 	return err
 }
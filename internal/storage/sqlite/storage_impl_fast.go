@@ -0,0 +1,122 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+
+	s "github.com/devldavydov/myhealth/internal/storage"
+)
+
+func (r *StorageSQLite) GetActiveFast(ctx context.Context, userID int64) (*s.Fast, error) {
+	var f s.Fast
+	err := r.conn().
+		QueryRowContext(ctx, _sqlGetActiveFast, userID).
+		Scan(&f.Start, &f.End)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, s.ErrFastNotFound
+		}
+		return nil, err
+	}
+
+	return &f, nil
+}
+
+func (r *StorageSQLite) GetFastList(ctx context.Context, userID int64, from, to s.Timestamp) ([]s.Fast, error) {
+	rows, err := r.conn().QueryContext(ctx, _sqlGetFastList, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []s.Fast{}
+	for rows.Next() {
+		var f s.Fast
+		err = rows.Scan(&f.Start, &f.End)
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, f)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(list) == 0 {
+		return nil, s.ErrEmptyResult
+	}
+
+	return list, nil
+}
+
+func (r *StorageSQLite) AddFast(ctx context.Context, userID int64, f *s.Fast) error {
+	if !f.Validate() {
+		return s.ErrFastInvalid
+	}
+
+	tx, err := r.begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Only one active fast is allowed
+	if f.Active() {
+		var active s.Fast
+		err = tx.
+			QueryRowContext(ctx, _sqlGetActiveFast, userID).
+			Scan(&active.Start, &active.End)
+		switch {
+		case err == nil:
+			return s.ErrFastActive
+		case err != sql.ErrNoRows:
+			return err
+		}
+	}
+
+	// Fast windows must not overlap
+	var overlapped s.Fast
+	err = tx.
+		QueryRowContext(ctx, _sqlFindOverlappedFast, userID, f.Start, f.End).
+		Scan(&overlapped.Start, &overlapped.End)
+	switch {
+	case err == nil:
+		return s.ErrFastConflict
+	case err != sql.ErrNoRows:
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, _sqlAddFast, userID, f.Start, f.End); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *StorageSQLite) StopFast(ctx context.Context, userID int64, start, end s.Timestamp) error {
+	if end <= start {
+		return s.ErrFastInvalid
+	}
+
+	res, err := r.conn().ExecContext(ctx, _sqlStopFast, end, userID, start)
+	if err != nil {
+		return err
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if cnt == 0 {
+		return s.ErrFastNotFound
+	}
+
+	return nil
+}
+
+func (r *StorageSQLite) DeleteFast(ctx context.Context, userID int64, from, to s.Timestamp) error {
+	_, err := r.conn().ExecContext(ctx, _sqlDeleteFast, userID, from, to)
+	return err
+}
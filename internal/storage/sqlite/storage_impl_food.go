@@ -3,6 +3,7 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"strings"
 
@@ -11,7 +12,7 @@ import (
 )
 
 func (r *StorageSQLite) GetFood(ctx context.Context, userID int64, key string) (*s.Food, error) {
-	tx, err := r.db.Begin()
+	tx, err := r.begin()
 	if err != nil {
 		return nil, err
 	}
@@ -25,11 +26,12 @@ func (r *StorageSQLite) GetFood(ctx context.Context, userID int64, key string) (
 	return food, tx.Commit()
 }
 
-func getFood(ctx context.Context, tx *sql.Tx, userID int64, key string) (*s.Food, error) {
+func getFood(ctx context.Context, tx dbConn, userID int64, key string) (*s.Food, error) {
 	var f s.Food
+	var sNutrients, sPortions sql.NullString
 	err := tx.
 		QueryRowContext(ctx, _sqlGetFood, userID, key).
-		Scan(&f.Key, &f.Name, &f.Brand, &f.Cal100, &f.Prot100, &f.Fat100, &f.Carb100, &f.Comment)
+		Scan(&f.Key, &f.Name, &f.Brand, &f.Cal100, &f.Prot100, &f.Fat100, &f.Carb100, &f.Comment, &f.Beverage, &sNutrients, &sPortions, &f.Density)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, s.ErrFoodNotFound
@@ -37,11 +39,19 @@ func getFood(ctx context.Context, tx *sql.Tx, userID int64, key string) (*s.Food
 		return nil, err
 	}
 
+	if f.Nutrients, err = unmarshalNutrients(sNutrients); err != nil {
+		return nil, err
+	}
+
+	if f.Portions, err = unmarshalFoodPortions(sPortions); err != nil {
+		return nil, err
+	}
+
 	return &f, nil
 }
 
 func (r *StorageSQLite) GetFoodList(ctx context.Context, userID int64) ([]s.Food, error) {
-	rows, err := r.db.QueryContext(ctx, _sqlGetFoodList, userID)
+	rows, err := r.conn().QueryContext(ctx, _sqlGetFoodList, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -50,11 +60,20 @@ func (r *StorageSQLite) GetFoodList(ctx context.Context, userID int64) ([]s.Food
 	list := []s.Food{}
 	for rows.Next() {
 		var f s.Food
-		err = rows.Scan(&f.Key, &f.Name, &f.Brand, &f.Cal100, &f.Prot100, &f.Fat100, &f.Carb100, &f.Comment)
+		var sNutrients, sPortions sql.NullString
+		err = rows.Scan(&f.Key, &f.Name, &f.Brand, &f.Cal100, &f.Prot100, &f.Fat100, &f.Carb100, &f.Comment, &f.Beverage, &sNutrients, &sPortions, &f.Density)
 		if err != nil {
 			return nil, err
 		}
 
+		if f.Nutrients, err = unmarshalNutrients(sNutrients); err != nil {
+			return nil, err
+		}
+
+		if f.Portions, err = unmarshalFoodPortions(sPortions); err != nil {
+			return nil, err
+		}
+
 		list = append(list, f)
 	}
 
@@ -70,7 +89,7 @@ func (r *StorageSQLite) GetFoodList(ctx context.Context, userID int64) ([]s.Food
 }
 
 func (r *StorageSQLite) FindFood(ctx context.Context, userID int64, pattern string) ([]s.Food, error) {
-	rows, err := r.db.QueryContext(ctx, _sqlFindFood, userID, strings.ToUpper(pattern))
+	rows, err := r.conn().QueryContext(ctx, _sqlFindFood, userID, strings.ToUpper(pattern))
 	if err != nil {
 		return nil, err
 	}
@@ -79,11 +98,20 @@ func (r *StorageSQLite) FindFood(ctx context.Context, userID int64, pattern stri
 	list := []s.Food{}
 	for rows.Next() {
 		var f s.Food
-		err = rows.Scan(&f.Key, &f.Name, &f.Brand, &f.Cal100, &f.Prot100, &f.Fat100, &f.Carb100, &f.Comment)
+		var sNutrients, sPortions sql.NullString
+		err = rows.Scan(&f.Key, &f.Name, &f.Brand, &f.Cal100, &f.Prot100, &f.Fat100, &f.Carb100, &f.Comment, &f.Beverage, &sNutrients, &sPortions, &f.Density)
 		if err != nil {
 			return nil, err
 		}
 
+		if f.Nutrients, err = unmarshalNutrients(sNutrients); err != nil {
+			return nil, err
+		}
+
+		if f.Portions, err = unmarshalFoodPortions(sPortions); err != nil {
+			return nil, err
+		}
+
 		list = append(list, f)
 	}
 
@@ -103,7 +131,17 @@ func (r *StorageSQLite) SetFood(ctx context.Context, userID int64, food *s.Food)
 		return s.ErrFoodInvalid
 	}
 
-	_, err := r.db.ExecContext(ctx,
+	sNutrients, err := marshalNutrients(food.Nutrients)
+	if err != nil {
+		return err
+	}
+
+	sPortions, err := marshalFoodPortions(food.Portions)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.conn().ExecContext(ctx,
 		_sqlSetFood,
 		userID,
 		food.Key,
@@ -114,6 +152,10 @@ func (r *StorageSQLite) SetFood(ctx context.Context, userID int64, food *s.Food)
 		food.Fat100,
 		food.Carb100,
 		food.Comment,
+		food.Beverage,
+		sNutrients,
+		sPortions,
+		food.Density,
 	)
 	return err
 }
@@ -132,7 +174,7 @@ func (r *StorageSQLite) DeleteFood(ctx context.Context, userID int64, key string
 		}
 	}
 
-	_, err = r.db.ExecContext(ctx, _sqlDeleteFood, userID, key)
+	_, err = r.conn().ExecContext(ctx, _sqlDeleteFood, userID, key)
 	if err != nil {
 		var errSql gsql.Error
 		if errors.As(err, &errSql) && errSql.Error() == _errForeignKey {
@@ -143,3 +185,55 @@ func (r *StorageSQLite) DeleteFood(ctx context.Context, userID int64, key string
 
 	return nil
 }
+
+func marshalNutrients(n s.Nutrients) (sql.NullString, error) {
+	if len(n) == 0 {
+		return sql.NullString{}, nil
+	}
+
+	b, err := json.Marshal(n)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+
+	return sql.NullString{String: string(b), Valid: true}, nil
+}
+
+func unmarshalNutrients(sNutrients sql.NullString) (s.Nutrients, error) {
+	if !sNutrients.Valid {
+		return nil, nil
+	}
+
+	var n s.Nutrients
+	if err := json.Unmarshal([]byte(sNutrients.String), &n); err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
+
+func marshalFoodPortions(p []s.FoodPortion) (sql.NullString, error) {
+	if len(p) == 0 {
+		return sql.NullString{}, nil
+	}
+
+	b, err := json.Marshal(p)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+
+	return sql.NullString{String: string(b), Valid: true}, nil
+}
+
+func unmarshalFoodPortions(sPortions sql.NullString) ([]s.FoodPortion, error) {
+	if !sPortions.Valid {
+		return nil, nil
+	}
+
+	var p []s.FoodPortion
+	if err := json.Unmarshal([]byte(sPortions.String), &p); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
@@ -14,13 +14,14 @@ func (r *StorageSQLite) SetJournal(ctx context.Context, userID int64, journal *s
 		return s.ErrJournalInvalid
 	}
 
-	_, err := r.db.ExecContext(ctx,
+	_, err := r.conn().ExecContext(ctx,
 		_sqlSetJournal,
 		userID,
 		journal.Timestamp,
 		journal.Meal,
 		journal.FoodKey,
 		journal.FoodWeight,
+		journal.Portion,
 	)
 	if err != nil {
 		var errSql gsql.Error
@@ -34,7 +35,7 @@ func (r *StorageSQLite) SetJournal(ctx context.Context, userID int64, journal *s
 }
 
 func (r *StorageSQLite) SetJournalBundle(ctx context.Context, userID int64, timestamp s.Timestamp, meal s.Meal, bndlKey string) error {
-	tx, err := r.db.Begin()
+	tx, err := r.begin()
 	if err != nil {
 		return err
 	}
@@ -53,6 +54,7 @@ func (r *StorageSQLite) SetJournalBundle(ctx context.Context, userID int64, time
 			meal,
 			item.foodKey,
 			item.foodWeight,
+			"",
 		); err != nil {
 			return err
 		}
@@ -66,7 +68,7 @@ type bundleFoodItem struct {
 	foodWeight float64
 }
 
-func getBundleFoodItems(ctx context.Context, tx *sql.Tx, userID int64, bndlKey string) ([]bundleFoodItem, error) {
+func getBundleFoodItems(ctx context.Context, tx dbConn, userID int64, bndlKey string) ([]bundleFoodItem, error) {
 	foodItems := []bundleFoodItem{}
 	bndlList := []string{bndlKey}
 	i := 0
@@ -98,18 +100,18 @@ func getBundleFoodItems(ctx context.Context, tx *sql.Tx, userID int64, bndlKey s
 	return foodItems, nil
 }
 
-func (r *StorageSQLite) DeleteJournal(ctx context.Context, userID int64, timestamp s.Timestamp, meal s.Meal, foodkey string) error {
-	_, err := r.db.ExecContext(ctx, _sqlDeleteJournal, userID, timestamp, meal, foodkey)
+func (r *StorageSQLite) DeleteJournal(ctx context.Context, userID int64, from, to s.Timestamp, meal s.Meal, foodkey string) error {
+	_, err := r.conn().ExecContext(ctx, _sqlDeleteJournal, userID, from, to, meal, foodkey)
 	return err
 }
 
-func (r *StorageSQLite) DeleteJournalMeal(ctx context.Context, userID int64, timestamp s.Timestamp, meal s.Meal) error {
-	_, err := r.db.ExecContext(ctx, _sqlDeleteJournalMeal, userID, timestamp, meal)
+func (r *StorageSQLite) DeleteJournalMeal(ctx context.Context, userID int64, from, to s.Timestamp, meal s.Meal) error {
+	_, err := r.conn().ExecContext(ctx, _sqlDeleteJournalMeal, userID, from, to, meal)
 	return err
 }
 
-func (r *StorageSQLite) DelJournalBundle(ctx context.Context, userID int64, timestamp s.Timestamp, meal s.Meal, bndlKey string) error {
-	tx, err := r.db.Begin()
+func (r *StorageSQLite) DelJournalBundle(ctx context.Context, userID int64, from, to s.Timestamp, meal s.Meal, bndlKey string) error {
+	tx, err := r.begin()
 	if err != nil {
 		return err
 	}
@@ -124,7 +126,8 @@ func (r *StorageSQLite) DelJournalBundle(ctx context.Context, userID int64, time
 		if _, err := tx.ExecContext(ctx,
 			_sqlDeleteJournal,
 			userID,
-			timestamp,
+			from,
+			to,
 			meal,
 			item.foodKey,
 		); err != nil {
@@ -136,7 +139,7 @@ func (r *StorageSQLite) DelJournalBundle(ctx context.Context, userID int64, time
 }
 
 func (r *StorageSQLite) GetJournalReport(ctx context.Context, userID int64, from, to s.Timestamp) ([]s.JournalReport, error) {
-	rows, err := r.db.QueryContext(ctx, _sqlGetJournalReport, userID, from, to)
+	rows, err := r.conn().QueryContext(ctx, _sqlGetJournalReport, userID, from, to)
 	if err != nil {
 		return nil, err
 	}
@@ -145,6 +148,7 @@ func (r *StorageSQLite) GetJournalReport(ctx context.Context, userID int64, from
 	list := []s.JournalReport{}
 	for rows.Next() {
 		var jr s.JournalReport
+		var sNutrients sql.NullString
 		err = rows.Scan(
 			&jr.Timestamp,
 			&jr.Meal,
@@ -156,11 +160,23 @@ func (r *StorageSQLite) GetJournalReport(ctx context.Context, userID int64, from
 			&jr.Prot,
 			&jr.Fat,
 			&jr.Carb,
+			&jr.FoodBeverage,
+			&sNutrients,
+			&jr.Portion,
 		)
 		if err != nil {
 			return nil, err
 		}
 
+		// Food nutrients are per 100g
+		nutrients, err := unmarshalNutrients(sNutrients)
+		if err != nil {
+			return nil, err
+		}
+		if len(nutrients) > 0 {
+			jr.Nutrients = nutrients.Scale(jr.FoodWeight / 100)
+		}
+
 		list = append(list, jr)
 	}
 
@@ -175,8 +191,8 @@ func (r *StorageSQLite) GetJournalReport(ctx context.Context, userID int64, from
 	return list, nil
 }
 
-func (r *StorageSQLite) CopyJournal(ctx context.Context, userID int64, from s.Timestamp, mealFrom s.Meal, to s.Timestamp, mealTo s.Meal) (int, error) {
-	tx, err := r.db.Begin()
+func (r *StorageSQLite) CopyJournal(ctx context.Context, userID int64, from, to s.Timestamp, mealFrom s.Meal, dst s.Timestamp, mealTo s.Meal) (int, error) {
+	tx, err := r.begin()
 	if err != nil {
 		return 0, err
 	}
@@ -184,11 +200,13 @@ func (r *StorageSQLite) CopyJournal(ctx context.Context, userID int64, from s.Ti
 
 	// Get journal data for mealFrom
 	type jData struct {
+		timestamp  s.Timestamp
 		foodKey    string
 		foodWeight string
+		portion    string
 	}
 
-	rows, err := r.db.QueryContext(ctx, _sqlGetJournalListForCopy, userID, from, mealFrom)
+	rows, err := r.conn().QueryContext(ctx, _sqlGetJournalListForCopy, userID, from, to, mealFrom)
 	if err != nil {
 		return 0, err
 	}
@@ -198,8 +216,10 @@ func (r *StorageSQLite) CopyJournal(ctx context.Context, userID int64, from s.Ti
 	for rows.Next() {
 		var jd jData
 		err = rows.Scan(
+			&jd.timestamp,
 			&jd.foodKey,
 			&jd.foodWeight,
+			&jd.portion,
 		)
 		if err != nil {
 			return 0, err
@@ -216,15 +236,16 @@ func (r *StorageSQLite) CopyJournal(ctx context.Context, userID int64, from s.Ti
 		return 0, nil
 	}
 
-	// Save to new meal
+	// Save to new meal, records keep offset from start of period
 	for _, item := range list {
 		if _, err := tx.ExecContext(ctx,
 			_sqlSetJournal,
 			userID,
-			to,
+			dst+item.timestamp-from,
 			mealTo,
 			item.foodKey,
 			item.foodWeight,
+			item.portion,
 		); err != nil {
 			return 0, err
 		}
@@ -235,7 +256,7 @@ func (r *StorageSQLite) CopyJournal(ctx context.Context, userID int64, from s.Ti
 
 func (r *StorageSQLite) GetJournalFoodStat(ctx context.Context, userID int64, foodkey string) (*s.JournalFoodStat, error) {
 	var fs s.JournalFoodStat
-	err := r.db.
+	err := r.conn().
 		QueryRowContext(ctx, _sqlJournalFoodStat, userID, foodkey).
 		Scan(&fs.FirstTimestamp, &fs.LastTimestamp, &fs.TotalWeight, &fs.AvgWeight, &fs.TotalCount)
 	if err != nil {
@@ -247,3 +268,46 @@ func (r *StorageSQLite) GetJournalFoodStat(ctx context.Context, userID int64, fo
 
 	return &fs, nil
 }
+
+// GetJournalMealSuggestions returns foods most often eaten at meal in [from, to].
+// Each entry adds 1/(1+days ago) to food score, so recent entries weigh more.
+func (r *StorageSQLite) GetJournalMealSuggestions(
+	ctx context.Context,
+	userID int64,
+	meal s.Meal,
+	from, to s.Timestamp,
+	limit int,
+) ([]s.JournalMealSuggestion, error) {
+	rows, err := r.conn().QueryContext(ctx, _sqlGetJournalMealSuggestions, userID, meal, from, to, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []s.JournalMealSuggestion{}
+	for rows.Next() {
+		var js s.JournalMealSuggestion
+		err = rows.Scan(
+			&js.FoodKey,
+			&js.FoodName,
+			&js.FoodBrand,
+			&js.Count,
+			&js.Score,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, js)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(list) == 0 {
+		return nil, s.ErrEmptyResult
+	}
+
+	return list, nil
+}
@@ -15,7 +15,7 @@ import (
 
 func (r *StorageSQLite) GetMedicine(ctx context.Context, userID int64, key string) (*s.Medicine, error) {
 	var m s.Medicine
-	err := r.db.
+	err := r.conn().
 		QueryRowContext(ctx, _sqlGetMedicine, userID, key).
 		Scan(&m.Key, &m.Name, &m.Comment, &m.Unit)
 	if err != nil {
@@ -29,7 +29,7 @@ func (r *StorageSQLite) GetMedicine(ctx context.Context, userID int64, key strin
 }
 
 func (r *StorageSQLite) GetMedicineList(ctx context.Context, userID int64) ([]s.Medicine, error) {
-	rows, err := r.db.QueryContext(ctx, _sqlGetMedicineList, userID)
+	rows, err := r.conn().QueryContext(ctx, _sqlGetMedicineList, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -62,12 +62,12 @@ func (r *StorageSQLite) SetMedicine(ctx context.Context, userID int64, m *s.Medi
 		return s.ErrMedicineInvalid
 	}
 
-	_, err := r.db.ExecContext(ctx, _sqlSetMedicine, userID, m.Key, m.Name, m.Comment, m.Unit)
+	_, err := r.conn().ExecContext(ctx, _sqlSetMedicine, userID, m.Key, m.Name, m.Comment, m.Unit)
 	return err
 }
 
 func (r *StorageSQLite) DeleteMedicine(ctx context.Context, userID int64, key string) error {
-	_, err := r.db.ExecContext(ctx, _sqlDeleteMedicine, userID, key)
+	_, err := r.conn().ExecContext(ctx, _sqlDeleteMedicine, userID, key)
 	if err != nil {
 		var errSql gsql.Error
 		if errors.As(err, &errSql) && errSql.Error() == _errForeignKey {
@@ -88,7 +88,7 @@ func (r *StorageSQLite) SetMedicineIndicator(ctx context.Context, userID int64,
 		return s.ErrMedicineIndicatorInvalid
 	}
 
-	_, err := r.db.ExecContext(ctx, _sqlSetMedicineIndicator, userID, mi.Timestamp, mi.MedicineKey, mi.Value)
+	_, err := r.conn().ExecContext(ctx, _sqlSetMedicineIndicator, userID, mi.Timestamp, mi.MedicineKey, mi.Value)
 	if err != nil {
 		var errSql gsql.Error
 		if errors.As(err, &errSql) && errSql.Error() == _errForeignKey {
@@ -100,13 +100,13 @@ func (r *StorageSQLite) SetMedicineIndicator(ctx context.Context, userID int64,
 	return nil
 }
 
-func (r *StorageSQLite) DeleteMedicineIndicator(ctx context.Context, userID int64, timestamp s.Timestamp, medicine_key string) error {
-	_, err := r.db.ExecContext(ctx, _sqlDeleteMedicineIndicator, userID, timestamp, medicine_key)
+func (r *StorageSQLite) DeleteMedicineIndicator(ctx context.Context, userID int64, from, to s.Timestamp, medicine_key string) error {
+	_, err := r.conn().ExecContext(ctx, _sqlDeleteMedicineIndicator, userID, from, to, medicine_key)
 	return err
 }
 
 func (r *StorageSQLite) GetMedicineIndicatorReport(ctx context.Context, userID int64, from, to s.Timestamp) ([]s.MedicineIndicatorReport, error) {
-	rows, err := r.db.QueryContext(ctx, _sqlGetMedicineIndicatorReport, userID, from, to)
+	rows, err := r.conn().QueryContext(ctx, _sqlGetMedicineIndicatorReport, userID, from, to)
 	if err != nil {
 		return nil, err
 	}
@@ -16,9 +16,9 @@ import (
 
 func (r *StorageSQLite) GetSport(ctx context.Context, userID int64, key string) (*s.Sport, error) {
 	var sp s.Sport
-	err := r.db.
+	err := r.conn().
 		QueryRowContext(ctx, _sqlGetSport, userID, key).
-		Scan(&sp.Key, &sp.Name, &sp.Comment, &sp.Unit)
+		Scan(&sp.Key, &sp.Name, &sp.Comment, &sp.Unit, &sp.MET, &sp.CalPerUnit, &sp.SetKind)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, s.ErrSportNotFound
@@ -30,7 +30,7 @@ func (r *StorageSQLite) GetSport(ctx context.Context, userID int64, key string)
 }
 
 func (r *StorageSQLite) GetSportList(ctx context.Context, userID int64) ([]s.Sport, error) {
-	rows, err := r.db.QueryContext(ctx, _sqlGetSportList, userID)
+	rows, err := r.conn().QueryContext(ctx, _sqlGetSportList, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -39,7 +39,7 @@ func (r *StorageSQLite) GetSportList(ctx context.Context, userID int64) ([]s.Spo
 	list := []s.Sport{}
 	for rows.Next() {
 		var sp s.Sport
-		err = rows.Scan(&sp.Key, &sp.Name, &sp.Comment, &sp.Unit)
+		err = rows.Scan(&sp.Key, &sp.Name, &sp.Comment, &sp.Unit, &sp.MET, &sp.CalPerUnit, &sp.SetKind)
 		if err != nil {
 			return nil, err
 		}
@@ -63,12 +63,20 @@ func (r *StorageSQLite) SetSport(ctx context.Context, userID int64, sp *s.Sport)
 		return s.ErrSportInvalid
 	}
 
-	_, err := r.db.ExecContext(ctx, _sqlSetSport, userID, sp.Key, sp.Name, sp.Comment, sp.Unit)
+	_, err := r.conn().ExecContext(ctx, _sqlSetSport, userID, sp.Key, sp.Name, sp.Comment, sp.Unit, sp.MET, sp.CalPerUnit, sp.SetKind)
 	return err
 }
 
 func (r *StorageSQLite) DeleteSport(ctx context.Context, userID int64, key string) error {
-	_, err := r.db.ExecContext(ctx, _sqlDeleteSport, userID, key)
+	used, err := r.isSportUsedInWorkout(ctx, userID, key)
+	if err != nil {
+		return err
+	}
+	if used {
+		return s.ErrSportIsUsed
+	}
+
+	_, err = r.conn().ExecContext(ctx, _sqlDeleteSport, userID, key)
 	if err != nil {
 		var errSql gsql.Error
 		if errors.As(err, &errSql) && errSql.Error() == _errForeignKey {
@@ -89,12 +97,64 @@ func (r *StorageSQLite) SetSportActivity(ctx context.Context, userID int64, sa *
 		return s.ErrSportActivityInvalid
 	}
 
-	bSets, err := json.Marshal(sa.Sets)
+	sp, err := r.GetSport(ctx, userID, sa.SportKey)
+	if err != nil {
+		return err
+	}
+
+	sets, err := sp.SetKind.Normalize(sa.Sets)
+	if err != nil {
+		return err
+	}
+
+	sSets, err := marshalSportSets(sets)
 	if err != nil {
 		return err
 	}
 
-	_, err = r.db.ExecContext(ctx, _sqlSetSportActivity, userID, sa.Timestamp, sa.SportKey, string(bSets), sa.Comment)
+	// Calculate burned calories by sport coefficients, if not set explicitly
+	cal := sa.Cal
+	if cal == 0 {
+		var weight float64
+		w, err := r.GetLastWeight(ctx, userID, sa.Timestamp)
+		switch {
+		case err == nil:
+			weight = w.Value
+		case !errors.Is(err, s.ErrWeightNotFound):
+			return err
+		}
+
+		cal = sp.ActivityCal(sets, sa.Duration, weight)
+	}
+
+	// New activity is added, existing is updated by ID
+	var res sql.Result
+	if sa.ID == 0 {
+		res, err = r.conn().ExecContext(
+			ctx,
+			_sqlAddSportActivity,
+			userID,
+			sa.Timestamp,
+			sa.SportKey,
+			sSets,
+			sa.Comment,
+			sa.Duration,
+			cal,
+		)
+	} else {
+		res, err = r.conn().ExecContext(
+			ctx,
+			_sqlUpdateSportActivity,
+			sa.Timestamp,
+			sa.SportKey,
+			sSets,
+			sa.Comment,
+			sa.Duration,
+			cal,
+			userID,
+			sa.ID,
+		)
+	}
 	if err != nil {
 		var errSql gsql.Error
 		if errors.As(err, &errSql) && errSql.Error() == _errForeignKey {
@@ -103,16 +163,45 @@ func (r *StorageSQLite) SetSportActivity(ctx context.Context, userID int64, sa *
 		return err
 	}
 
-	return nil
+	if sa.ID != 0 {
+		cnt, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if cnt == 0 {
+			return s.ErrSportActivityNotFound
+		}
+		return nil
+	}
+
+	sa.ID, err = res.LastInsertId()
+	return err
 }
 
-func (r *StorageSQLite) DeleteSportActivity(ctx context.Context, userID int64, timestamp s.Timestamp, sport_key string) error {
-	_, err := r.db.ExecContext(ctx, _sqlDeleteSportActivity, userID, timestamp, sport_key)
+func (r *StorageSQLite) DeleteSportActivity(ctx context.Context, userID int64, from, to s.Timestamp, sport_key string) error {
+	_, err := r.conn().ExecContext(ctx, _sqlDeleteSportActivity, userID, from, to, sport_key)
 	return err
 }
 
+func (r *StorageSQLite) DeleteSportActivityByID(ctx context.Context, userID, id int64) error {
+	res, err := r.conn().ExecContext(ctx, _sqlDeleteSportActivityByID, userID, id)
+	if err != nil {
+		return err
+	}
+
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if cnt == 0 {
+		return s.ErrSportActivityNotFound
+	}
+
+	return nil
+}
+
 func (r *StorageSQLite) GetSportActivityReport(ctx context.Context, userID int64, from, to s.Timestamp) ([]s.SportActivityReport, error) {
-	rows, err := r.db.QueryContext(ctx, _sqlGetSportActivityReport, userID, from, to)
+	rows, err := r.conn().QueryContext(ctx, _sqlGetSportActivityReport, userID, from, to)
 	if err != nil {
 		return nil, err
 	}
@@ -123,12 +212,12 @@ func (r *StorageSQLite) GetSportActivityReport(ctx context.Context, userID int64
 		var sr s.SportActivityReport
 		var sSets string
 
-		err = rows.Scan(&sr.Timestamp, &sr.SportName, &sSets, &sr.Comment)
+		err = rows.Scan(&sr.Timestamp, &sr.SportName, &sSets, &sr.Duration, &sr.Cal, &sr.Comment, &sr.SportKey, &sr.ID)
 		if err != nil {
 			return nil, err
 		}
 
-		if err = json.Unmarshal([]byte(sSets), &sr.Sets); err != nil {
+		if sr.Sets, err = unmarshalSportSets(sSets); err != nil {
 			return nil, err
 		}
 
@@ -145,3 +234,84 @@ func (r *StorageSQLite) GetSportActivityReport(ctx context.Context, userID int64
 
 	return list, nil
 }
+
+func (r *StorageSQLite) GetSportActivityCal(ctx context.Context, userID int64, from, to s.Timestamp) (float64, error) {
+	var cal float64
+	if err := r.conn().QueryRowContext(ctx, _sqlGetSportActivityCal, userID, from, to).Scan(&cal); err != nil {
+		return 0, err
+	}
+
+	return cal, nil
+}
+
+func (r *StorageSQLite) GetSportActivityHistory(ctx context.Context, userID int64, sportKey string) ([]s.SportActivity, error) {
+	rows, err := r.conn().QueryContext(ctx, _sqlGetSportActivityHistory, userID, sportKey)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []s.SportActivity{}
+	for rows.Next() {
+		sa := s.SportActivity{SportKey: sportKey}
+		var sSets string
+
+		err = rows.Scan(&sa.ID, &sa.Timestamp, &sSets, &sa.Duration, &sa.Cal, &sa.Comment)
+		if err != nil {
+			return nil, err
+		}
+
+		if sa.Sets, err = unmarshalSportSets(sSets); err != nil {
+			return nil, err
+		}
+
+		list = append(list, sa)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(list) == 0 {
+		return nil, s.ErrEmptyResult
+	}
+
+	return list, nil
+}
+
+type sportSetDB struct {
+	Value    float64 `json:"value,omitempty"`
+	Reps     float64 `json:"reps,omitempty"`
+	Load     float64 `json:"load,omitempty"`
+	Duration float64 `json:"duration,omitempty"`
+	Distance float64 `json:"distance,omitempty"`
+	RPE      float64 `json:"rpe,omitempty"`
+}
+
+func marshalSportSets(sets []s.SportSet) (string, error) {
+	dbSets := make([]sportSetDB, 0, len(sets))
+	for _, set := range sets {
+		dbSets = append(dbSets, sportSetDB(set))
+	}
+
+	b, err := json.Marshal(dbSets)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+func unmarshalSportSets(sSets string) ([]s.SportSet, error) {
+	var dbSets []sportSetDB
+	if err := json.Unmarshal([]byte(sSets), &dbSets); err != nil {
+		return nil, err
+	}
+
+	sets := make([]s.SportSet, 0, len(dbSets))
+	for _, set := range dbSets {
+		sets = append(sets, s.SportSet(set))
+	}
+
+	return sets, nil
+}
@@ -0,0 +1,71 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+
+	s "github.com/devldavydov/myhealth/internal/storage"
+)
+
+func (r *StorageSQLite) SetTDEEEstimate(ctx context.Context, userID int64, est *s.TDEEEstimate) error {
+	if !est.Validate() {
+		return s.ErrTDEEEstimateInvalid
+	}
+
+	_, err := r.conn().ExecContext(ctx,
+		_sqlSetTDEEEstimate,
+		userID,
+		est.Timestamp,
+		est.Weeks,
+		est.TDEE,
+		est.ConfLow,
+		est.ConfHigh,
+		est.Applied,
+	)
+
+	return err
+}
+
+func (r *StorageSQLite) GetLastTDEEEstimate(ctx context.Context, userID int64) (*s.TDEEEstimate, error) {
+	var est s.TDEEEstimate
+	err := r.conn().
+		QueryRowContext(ctx, _sqlGetLastTDEEEstimate, userID).
+		Scan(&est.Timestamp, &est.Weeks, &est.TDEE, &est.ConfLow, &est.ConfHigh, &est.Applied)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, s.ErrTDEEEstimateNotFound
+		}
+		return nil, err
+	}
+
+	return &est, nil
+}
+
+func (r *StorageSQLite) GetTDEEEstimateList(ctx context.Context, userID int64, from, to s.Timestamp) ([]s.TDEEEstimate, error) {
+	rows, err := r.conn().QueryContext(ctx, _sqlGetTDEEEstimateList, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []s.TDEEEstimate{}
+	for rows.Next() {
+		var est s.TDEEEstimate
+		err = rows.Scan(&est.Timestamp, &est.Weeks, &est.TDEE, &est.ConfLow, &est.ConfHigh, &est.Applied)
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, est)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(list) == 0 {
+		return nil, s.ErrEmptyResult
+	}
+
+	return list, nil
+}
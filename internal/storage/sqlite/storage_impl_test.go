@@ -5,6 +5,8 @@ import (
 	"os"
 	"testing"
 
+	s "github.com/devldavydov/myhealth/internal/storage"
+	"github.com/devldavydov/myhealth/internal/storage/storagetest"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
@@ -20,20 +22,12 @@ func (r *StorageSQLiteTestSuite) TestMigrations() {
 	r.Run("check last migration", func() {
 		migrationID, err := r.stg.getLastMigrationID(context.Background())
 		r.NoError(err)
-		r.Equal(int64(15), migrationID)
+		r.Equal(int64(39), migrationID)
 	})
 }
 
 func (r *StorageSQLiteTestSuite) SetupTest() {
-	var err error
-
-	f, err := os.CreateTemp("", "testdb")
-	require.NoError(r.T(), err)
-	r.dbFile = f.Name()
-	f.Close()
-
-	r.stg, err = NewStorageSQLite(r.dbFile, nil)
-	require.NoError(r.T(), err)
+	r.stg, r.dbFile = newTestStorage(r.T())
 }
 
 func (r *StorageSQLiteTestSuite) TearDownTest() {
@@ -44,3 +38,22 @@ func (r *StorageSQLiteTestSuite) TearDownTest() {
 func TestStorageSQLite(t *testing.T) {
 	suite.Run(t, new(StorageSQLiteTestSuite))
 }
+
+func TestStorageSQLiteConformance(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) s.Storage {
+		stg, dbFile := newTestStorage(t)
+		t.Cleanup(func() { require.NoError(t, os.Remove(dbFile)) })
+		return stg
+	})
+}
+
+func newTestStorage(t *testing.T) (*StorageSQLite, string) {
+	f, err := os.CreateTemp("", "testdb")
+	require.NoError(t, err)
+	f.Close()
+
+	stg, err := NewStorageSQLite(f.Name(), nil)
+	require.NoError(t, err)
+
+	return stg, f.Name()
+}
@@ -9,7 +9,7 @@ import (
 
 func (r *StorageSQLite) GetTotalBurnedCal(ctx context.Context, userID int64, timestamp s.Timestamp) (float64, error) {
 	var totalCal float64
-	err := r.db.
+	err := r.conn().
 		QueryRowContext(ctx, _sqlGetTotalBurnedCal, userID, timestamp).
 		Scan(&totalCal)
 	if err != nil {
@@ -27,7 +27,7 @@ func (r *StorageSQLite) SetTotalBurnedCal(ctx context.Context, userID int64, tim
 		return s.ErrDayTotalCalInvalid
 	}
 
-	_, err := r.db.ExecContext(ctx,
+	_, err := r.conn().ExecContext(ctx,
 		_sqlSetTotalBurnedCal,
 		userID,
 		timestamp,
@@ -38,7 +38,7 @@ func (r *StorageSQLite) SetTotalBurnedCal(ctx context.Context, userID int64, tim
 }
 
 func (r *StorageSQLite) DeleteTotalBurnedCal(ctx context.Context, userID int64, timestamp s.Timestamp) error {
-	_, err := r.db.ExecContext(ctx,
+	_, err := r.conn().ExecContext(ctx,
 		_sqlDeleteTotalBurnedCal,
 		userID,
 		timestamp,
@@ -0,0 +1,63 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+
+	s "github.com/devldavydov/myhealth/internal/storage"
+)
+
+// dbConn is common part of *sql.DB and *sql.Tx used by queries.
+type dbConn interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// dbTx is transaction used by storage methods with several queries.
+type dbTx interface {
+	dbConn
+	Commit() error
+	Rollback() error
+}
+
+// outerTx is transaction of InTx reused by storage methods, it is
+// committed or rolled back only by InTx.
+type outerTx struct {
+	*sql.Tx
+}
+
+func (outerTx) Commit() error   { return nil }
+func (outerTx) Rollback() error { return nil }
+
+func (r *StorageSQLite) InTx(ctx context.Context, fn func(stg s.Storage) error) error {
+	if r.tx != nil {
+		return fn(r)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err = fn(&StorageSQLite{db: r.db, tx: tx, logger: r.logger}); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *StorageSQLite) conn() dbConn {
+	if r.tx != nil {
+		return r.tx
+	}
+	return r.db
+}
+
+func (r *StorageSQLite) begin() (dbTx, error) {
+	if r.tx != nil {
+		return outerTx{r.tx}, nil
+	}
+	return r.db.Begin()
+}
@@ -3,15 +3,17 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 
 	s "github.com/devldavydov/myhealth/internal/storage"
 )
 
 func (r *StorageSQLite) GetUserSettings(ctx context.Context, userID int64) (*s.UserSettings, error) {
 	var us s.UserSettings
-	err := r.db.
+	var sProfile, sNutrientLimits sql.NullString
+	err := r.conn().
 		QueryRowContext(ctx, _sqlGetUserSettings, userID).
-		Scan(&us.CalLimit)
+		Scan(&us.CalLimit, &us.TDEEAutoUpdate, &sProfile, &us.WaterGoal, &us.WaterReminder, &sNutrientLimits, &us.ReportFormat)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, s.ErrUserSettingsNotFound
@@ -19,6 +21,14 @@ func (r *StorageSQLite) GetUserSettings(ctx context.Context, userID int64) (*s.U
 		return nil, err
 	}
 
+	if us.Profile, err = unmarshalUserProfile(sProfile); err != nil {
+		return nil, err
+	}
+
+	if us.NutrientLimits, err = unmarshalNutrients(sNutrientLimits); err != nil {
+		return nil, err
+	}
+
 	return &us, nil
 }
 
@@ -27,6 +37,52 @@ func (r *StorageSQLite) SetUserSettings(ctx context.Context, userID int64, us *s
 		return s.ErrUserSettingsInvalid
 	}
 
-	_, err := r.db.ExecContext(ctx, _sqlSetUserSettings, userID, us.CalLimit)
+	sProfile, err := marshalUserProfile(us.Profile)
+	if err != nil {
+		return err
+	}
+
+	sNutrientLimits, err := marshalNutrients(us.NutrientLimits)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.conn().ExecContext(ctx,
+		_sqlSetUserSettings,
+		userID,
+		us.CalLimit,
+		us.TDEEAutoUpdate,
+		sProfile,
+		us.WaterGoal,
+		us.WaterReminder,
+		sNutrientLimits,
+		us.ReportFormat,
+	)
 	return err
 }
+
+func marshalUserProfile(p *s.UserProfile) (sql.NullString, error) {
+	if p == nil {
+		return sql.NullString{}, nil
+	}
+
+	b, err := json.Marshal(p)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+
+	return sql.NullString{String: string(b), Valid: true}, nil
+}
+
+func unmarshalUserProfile(sProfile sql.NullString) (*s.UserProfile, error) {
+	if !sProfile.Valid {
+		return nil, nil
+	}
+
+	var p s.UserProfile
+	if err := json.Unmarshal([]byte(sProfile.String), &p); err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
@@ -0,0 +1,51 @@
+package sqlite
+
+import (
+	"context"
+
+	s "github.com/devldavydov/myhealth/internal/storage"
+)
+
+func (r *StorageSQLite) GetWaterList(ctx context.Context, userID int64, from, to s.Timestamp) ([]s.Water, error) {
+	rows, err := r.conn().QueryContext(ctx, _sqlGetWaterList, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []s.Water{}
+	for rows.Next() {
+		var w s.Water
+		err = rows.Scan(&w.Timestamp, &w.Volume)
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, w)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(list) == 0 {
+		return nil, s.ErrEmptyResult
+	}
+
+	return list, nil
+}
+
+// AddWater adds volume to water drunk at timestamp.
+func (r *StorageSQLite) AddWater(ctx context.Context, userID int64, w *s.Water) error {
+	if !w.Validate() {
+		return s.ErrWaterInvalid
+	}
+
+	_, err := r.conn().ExecContext(ctx, _sqlAddWater, userID, w.Timestamp, w.Volume)
+	return err
+}
+
+func (r *StorageSQLite) DeleteWater(ctx context.Context, userID int64, from, to s.Timestamp) error {
+	_, err := r.conn().ExecContext(ctx, _sqlDeleteWater, userID, from, to)
+	return err
+}
@@ -12,7 +12,7 @@ func (r *StorageSQLite) GetWeightList(ctx context.Context, userID int64, from, t
 	if desc {
 		q = _sqlGetWeightListDesc
 	}
-	rows, err := r.db.QueryContext(ctx, q, userID, from, to)
+	rows, err := r.conn().QueryContext(ctx, q, userID, from, to)
 	if err != nil {
 		return nil, err
 	}
@@ -42,7 +42,7 @@ func (r *StorageSQLite) GetWeightList(ctx context.Context, userID int64, from, t
 
 func (r *StorageSQLite) GetWeight(ctx context.Context, userID int64, ts s.Timestamp) (*s.Weight, error) {
 	var f s.Weight
-	err := r.db.
+	err := r.conn().
 		QueryRowContext(ctx, _sqlGetWeight, userID, ts).
 		Scan(&f.Timestamp, &f.Value)
 	if err != nil {
@@ -55,16 +55,31 @@ func (r *StorageSQLite) GetWeight(ctx context.Context, userID int64, ts s.Timest
 	return &f, nil
 }
 
+func (r *StorageSQLite) GetLastWeight(ctx context.Context, userID int64, ts s.Timestamp) (*s.Weight, error) {
+	var f s.Weight
+	err := r.conn().
+		QueryRowContext(ctx, _sqlGetLastWeight, userID, ts).
+		Scan(&f.Timestamp, &f.Value)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, s.ErrWeightNotFound
+		}
+		return nil, err
+	}
+
+	return &f, nil
+}
+
 func (r *StorageSQLite) SetWeight(ctx context.Context, userID int64, weight *s.Weight) error {
 	if !weight.Validate() {
 		return s.ErrWeightInvalid
 	}
 
-	_, err := r.db.ExecContext(ctx, _sqlSetWeight, userID, weight.Timestamp, weight.Value)
+	_, err := r.conn().ExecContext(ctx, _sqlSetWeight, userID, weight.Timestamp, weight.Value)
 	return err
 }
 
-func (r *StorageSQLite) DeleteWeight(ctx context.Context, userID int64, timestamp s.Timestamp) error {
-	_, err := r.db.ExecContext(ctx, _sqlDeleteWeight, userID, timestamp)
+func (r *StorageSQLite) DeleteWeight(ctx context.Context, userID int64, from, to s.Timestamp) error {
+	_, err := r.conn().ExecContext(ctx, _sqlDeleteWeight, userID, from, to)
 	return err
 }
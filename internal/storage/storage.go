@@ -8,14 +8,16 @@ import (
 const (
 	StorageOperationTimeout = 15 * time.Second
 	StorageRestoreTimeout   = 1 * time.Minute
+	StorageBatchTimeout     = 1 * time.Minute
 )
 
 type Storage interface {
 	// Weight
 	GetWeightList(ctx context.Context, userID int64, from, to Timestamp, desc bool) ([]Weight, error)
 	GetWeight(ctx context.Context, userID int64, ts Timestamp) (*Weight, error)
+	GetLastWeight(ctx context.Context, userID int64, ts Timestamp) (*Weight, error)
 	SetWeight(ctx context.Context, userID int64, weight *Weight) error
-	DeleteWeight(ctx context.Context, userID int64, timestamp Timestamp) error
+	DeleteWeight(ctx context.Context, userID int64, from, to Timestamp) error
 
 	// Food
 	GetFood(ctx context.Context, userID int64, key string) (*Food, error)
@@ -33,12 +35,15 @@ type Storage interface {
 	// Journal
 	SetJournal(ctx context.Context, userID int64, journal *Journal) error
 	SetJournalBundle(ctx context.Context, userID int64, timestamp Timestamp, meal Meal, bndlKey string) error
-	DeleteJournal(ctx context.Context, userID int64, timestamp Timestamp, meal Meal, foodkey string) error
-	DeleteJournalMeal(ctx context.Context, userID int64, timestamp Timestamp, meal Meal) error
-	DelJournalBundle(ctx context.Context, userID int64, timestamp Timestamp, meal Meal, bndlKey string) error
+	DeleteJournal(ctx context.Context, userID int64, from, to Timestamp, meal Meal, foodkey string) error
+	DeleteJournalMeal(ctx context.Context, userID int64, from, to Timestamp, meal Meal) error
+	DelJournalBundle(ctx context.Context, userID int64, from, to Timestamp, meal Meal, bndlKey string) error
 	GetJournalReport(ctx context.Context, userID int64, from, to Timestamp) ([]JournalReport, error)
-	CopyJournal(ctx context.Context, userID int64, from Timestamp, mealFrom Meal, to Timestamp, mealTo Meal) (int, error)
+	// CopyJournal copies meal records of period [from, to] to period which
+	// starts at dst, records keep their offset from start of period.
+	CopyJournal(ctx context.Context, userID int64, from, to Timestamp, mealFrom Meal, dst Timestamp, mealTo Meal) (int, error)
 	GetJournalFoodStat(ctx context.Context, userID int64, foodkey string) (*JournalFoodStat, error)
+	GetJournalMealSuggestions(ctx context.Context, userID int64, meal Meal, from, to Timestamp, limit int) ([]JournalMealSuggestion, error)
 
 	// Sport
 	GetSport(ctx context.Context, userID int64, key string) (*Sport, error)
@@ -48,8 +53,22 @@ type Storage interface {
 
 	// SportActivity
 	SetSportActivity(ctx context.Context, userID int64, sa *SportActivity) error
-	DeleteSportActivity(ctx context.Context, userID int64, timestamp Timestamp, sport_key string) error
+	DeleteSportActivity(ctx context.Context, userID int64, from, to Timestamp, sport_key string) error
+	DeleteSportActivityByID(ctx context.Context, userID, id int64) error
 	GetSportActivityReport(ctx context.Context, userID int64, from, to Timestamp) ([]SportActivityReport, error)
+	GetSportActivityCal(ctx context.Context, userID int64, from, to Timestamp) (float64, error)
+	GetSportActivityHistory(ctx context.Context, userID int64, sportKey string) ([]SportActivity, error)
+
+	// Workout
+	GetWorkout(ctx context.Context, userID int64, key string) (*Workout, error)
+	GetWorkoutList(ctx context.Context, userID int64) ([]Workout, error)
+	SetWorkout(ctx context.Context, userID int64, w *Workout) error
+	DeleteWorkout(ctx context.Context, userID int64, key string) error
+
+	// WorkoutPlan
+	GetWorkoutPlan(ctx context.Context, userID int64) ([]WorkoutPlanDay, error)
+	SetWorkoutPlanDay(ctx context.Context, userID int64, pd *WorkoutPlanDay) error
+	DeleteWorkoutPlanDay(ctx context.Context, userID int64, weekday time.Weekday) error
 
 	// Medicine
 	GetMedicine(ctx context.Context, userID int64, key string) (*Medicine, error)
@@ -59,7 +78,7 @@ type Storage interface {
 
 	// MedicineIndicator
 	SetMedicineIndicator(ctx context.Context, userID int64, mi *MedicineIndicator) error
-	DeleteMedicineIndicator(ctx context.Context, userID int64, timestamp Timestamp, medicine_key string) error
+	DeleteMedicineIndicator(ctx context.Context, userID int64, from, to Timestamp, medicine_key string) error
 	GetMedicineIndicatorReport(ctx context.Context, userID int64, from, to Timestamp) ([]MedicineIndicatorReport, error)
 
 	// UserSettings
@@ -71,9 +90,40 @@ type Storage interface {
 	SetTotalBurnedCal(ctx context.Context, userID int64, timestamp Timestamp, totalCal float64) error
 	DeleteTotalBurnedCal(ctx context.Context, userID int64, timestamp Timestamp) error
 
+	// TDEEEstimate
+	SetTDEEEstimate(ctx context.Context, userID int64, est *TDEEEstimate) error
+	GetLastTDEEEstimate(ctx context.Context, userID int64) (*TDEEEstimate, error)
+	GetTDEEEstimateList(ctx context.Context, userID int64, from, to Timestamp) ([]TDEEEstimate, error)
+
+	// Water
+	GetWaterList(ctx context.Context, userID int64, from, to Timestamp) ([]Water, error)
+	AddWater(ctx context.Context, userID int64, w *Water) error
+	DeleteWater(ctx context.Context, userID int64, from, to Timestamp) error
+
+	// Sleep
+	GetSleep(ctx context.Context, userID int64, ts Timestamp) (*Sleep, error)
+	GetSleepList(ctx context.Context, userID int64, from, to Timestamp) ([]Sleep, error)
+	SetSleep(ctx context.Context, userID int64, sl *Sleep) error
+	DeleteSleep(ctx context.Context, userID int64, timestamp Timestamp) error
+
+	// Fast
+	GetActiveFast(ctx context.Context, userID int64) (*Fast, error)
+	GetFastList(ctx context.Context, userID int64, from, to Timestamp) ([]Fast, error)
+	// AddFast adds new fast, it must not overlap existing fasts.
+	AddFast(ctx context.Context, userID int64, f *Fast) error
+	// StopFast sets end of active fast with start.
+	StopFast(ctx context.Context, userID int64, start, end Timestamp) error
+	DeleteFast(ctx context.Context, userID int64, from, to Timestamp) error
+
 	// Backup/restore
 	Backup(ctx context.Context) (*Backup, error)
 	Restore(ctx context.Context, backup *Backup) error
 
+	// Transaction
+	// InTx runs fn with storage bound to single transaction, it is committed
+	// if fn returns nil and rolled back otherwise. Nested calls reuse the
+	// outer transaction.
+	InTx(ctx context.Context, fn func(stg Storage) error) error
+
 	Close() error
 }
@@ -1,12 +1,13 @@
-package sqlite
+package storagetest
 
 import (
 	"context"
+	"time"
 
 	s "github.com/devldavydov/myhealth/internal/storage"
 )
 
-func (r *StorageSQLiteTestSuite) TestBackupRestore() {
+func (r *Suite) TestBackupRestore() {
 	backup := &s.Backup{
 		Timestamp: 1000,
 		Weight: []s.WeightBackup{
@@ -15,14 +16,14 @@ func (r *StorageSQLiteTestSuite) TestBackupRestore() {
 			{UserID: 2, Timestamp: 1000, Value: 87.8},
 		},
 		Sport: []s.SportBackup{
-			{UserID: 1, Key: "sport1 key", Name: "sport1 name", Unit: "sport1 unit", Comment: "sport1 comment"},
-			{UserID: 1, Key: "sport2 key", Name: "sport2 name", Unit: "sport2 unit", Comment: "sport2 comment"},
-			{UserID: 2, Key: "sport1 key", Name: "sport1 name", Unit: "sport1 unit", Comment: "sport1 comment"},
+			{UserID: 1, Key: "sport1 key", Name: "sport1 name", Unit: "sport1 unit", Comment: "sport1 comment", SetKind: "value"},
+			{UserID: 1, Key: "sport2 key", Name: "sport2 name", Unit: "sport2 unit", Comment: "sport2 comment", SetKind: "value", MET: 5},
+			{UserID: 2, Key: "sport1 key", Name: "sport1 name", Unit: "sport1 unit", Comment: "sport1 comment", SetKind: "value"},
 		},
 		SportActivity: []s.SportActivityBackup{
-			{UserID: 1, SportKey: "sport1 key", Timestamp: 1, Sets: []float64{1, 2, 3}},
-			{UserID: 1, SportKey: "sport2 key", Timestamp: 2, Sets: []float64{4, 5, 6}},
-			{UserID: 2, SportKey: "sport1 key", Timestamp: 1, Sets: []float64{7, 8, 9}},
+			{UserID: 1, SportKey: "sport1 key", Timestamp: 1, Sets: valueSetsBackup(1, 2, 3)},
+			{UserID: 1, SportKey: "sport2 key", Timestamp: 2, Sets: valueSetsBackup(4, 5, 6), Duration: 30, Cal: 150},
+			{UserID: 2, SportKey: "sport1 key", Timestamp: 1, Sets: valueSetsBackup(7, 8, 9)},
 		},
 		Medicine: []s.MedicineBackup{
 			{UserID: 1, Key: "med1 key", Name: "med1 name", Unit: "med1 unit", Comment: "med1 comment"},
@@ -35,17 +36,24 @@ func (r *StorageSQLiteTestSuite) TestBackupRestore() {
 			{UserID: 2, MedicineKey: "med1 key", Timestamp: 1, Value: 7.89},
 		},
 		UserSettings: []s.UserSettingsBackup{
-			{UserID: 1, CalLimit: 123.123},
-			{UserID: 2, CalLimit: 456.456},
+			{
+				UserID: 1, CalLimit: 123.123, WaterGoal: 2000, WaterReminder: true,
+				NutrientLimits: map[string]float64{"sugar": 50}, ReportFormat: "pdf",
+			},
+			{UserID: 2, CalLimit: 456.456, TDEEAutoUpdate: true, Profile: &s.UserProfileBackup{
+				Sex: "f", BirthDate: 1, Height: 170, ActivityLevel: 2, BodyFat: 25, Goal: "lose", GoalRate: 0.5,
+			}},
 		},
 		Food: []s.FoodBackup{
 			{
 				UserID: 1, Key: "food1_key", Name: "food1_name", Brand: "food1_brand",
 				Cal100: 1.1, Prot100: 2.2, Fat100: 3.3, Carb100: 4.4, Comment: "food1_comment",
+				Nutrients: map[string]float64{"fiber": 2},
 			},
 			{
 				UserID: 1, Key: "food2_key", Name: "food2_name", Brand: "food2_brand",
-				Cal100: 5.5, Prot100: 6.6, Fat100: 7.7, Carb100: 8.8, Comment: "food2_comment",
+				Cal100: 5.5, Prot100: 6.6, Fat100: 7.7, Carb100: 8.8, Comment: "food2_comment", Beverage: true,
+				Portions: []s.FoodPortionBackup{{Name: "cup", Amount: 200, Unit: "ml"}}, Density: 1.03,
 			},
 			{
 				UserID: 2, Key: "food1_key", Name: "food1_name", Brand: "food1_brand",
@@ -62,7 +70,7 @@ func (r *StorageSQLiteTestSuite) TestBackupRestore() {
 		},
 		Journal: []s.JournalBackup{
 			{UserID: 1, Timestamp: 1, Meal: s.Meal(0), FoodKey: "food1_key", FoodWeight: 100},
-			{UserID: 1, Timestamp: 1, Meal: s.Meal(1), FoodKey: "food2_key", FoodWeight: 200},
+			{UserID: 1, Timestamp: 1, Meal: s.Meal(1), FoodKey: "food2_key", FoodWeight: 200, Portion: "1cup"},
 			{UserID: 2, Timestamp: 2, Meal: s.Meal(2), FoodKey: "food1_key", FoodWeight: 100},
 		},
 		TotalBurnedCal: []s.TotalBurnedCalBackup{
@@ -70,6 +78,39 @@ func (r *StorageSQLiteTestSuite) TestBackupRestore() {
 			{UserID: 1, Timestamp: 2, TotalCal: 200},
 			{UserID: 2, Timestamp: 1, TotalCal: 300},
 		},
+		TDEEEstimate: []s.TDEEEstimateBackup{
+			{UserID: 1, Timestamp: 1, Weeks: 4, TDEE: 2500, ConfLow: 2400, ConfHigh: 2600, Applied: true},
+			{UserID: 1, Timestamp: 2, Weeks: 2, TDEE: 2450, ConfLow: 2300, ConfHigh: 2600},
+			{UserID: 2, Timestamp: 1, Weeks: 3, TDEE: 2100, ConfLow: 2000, ConfHigh: 2200},
+		},
+		Workout: []s.WorkoutBackup{
+			{UserID: 1, Key: "workout1", Name: "workout1 name", Items: []s.WorkoutItemBackup{
+				{SportKey: "sport1 key", Sets: valueSetsBackup(10, 10)},
+				{SportKey: "sport2 key", Sets: valueSetsBackup(5)},
+			}, Comment: "workout1 comment"},
+			{UserID: 2, Key: "workout1", Name: "workout1 name", Items: []s.WorkoutItemBackup{
+				{SportKey: "sport1 key", Sets: valueSetsBackup(20)},
+			}},
+		},
+		WorkoutPlan: []s.WorkoutPlanBackup{
+			{UserID: 1, Weekday: 1, WorkoutKey: "workout1"},
+			{UserID: 1, Weekday: 3, WorkoutKey: "workout1"},
+			{UserID: 2, Weekday: 0, WorkoutKey: "workout1"},
+		},
+		Water: []s.WaterBackup{
+			{UserID: 1, Timestamp: 1, Volume: 250},
+			{UserID: 1, Timestamp: 2, Volume: 500},
+			{UserID: 2, Timestamp: 1, Volume: 300},
+		},
+		Sleep: []s.SleepBackup{
+			{UserID: 1, Timestamp: 10, BedTime: 1, WakeTime: 8, Quality: 4, Notes: "notes"},
+			{UserID: 2, Timestamp: 20, BedTime: 12, WakeTime: 18, Quality: 2},
+		},
+		Fast: []s.FastBackup{
+			{UserID: 1, Start: 10, End: 20},
+			{UserID: 1, Start: 30},
+			{UserID: 2, Start: 15, End: 25},
+		},
 	}
 
 	r.Run("restore backup", func() {
@@ -98,14 +139,14 @@ func (r *StorageSQLiteTestSuite) TestBackupRestore() {
 			res, err := r.stg.GetSportList(context.Background(), 1)
 			r.NoError(err)
 			r.Equal([]s.Sport{
-				{Key: "sport1 key", Name: "sport1 name", Unit: "sport1 unit", Comment: "sport1 comment"},
-				{Key: "sport2 key", Name: "sport2 name", Unit: "sport2 unit", Comment: "sport2 comment"},
+				{Key: "sport1 key", Name: "sport1 name", Unit: "sport1 unit", Comment: "sport1 comment", SetKind: s.SportSetKindValue},
+				{Key: "sport2 key", Name: "sport2 name", Unit: "sport2 unit", Comment: "sport2 comment", SetKind: s.SportSetKindValue, MET: 5},
 			}, res)
 
 			res, err = r.stg.GetSportList(context.Background(), 2)
 			r.NoError(err)
 			r.Equal([]s.Sport{
-				{Key: "sport1 key", Name: "sport1 name", Unit: "sport1 unit", Comment: "sport1 comment"},
+				{Key: "sport1 key", Name: "sport1 name", Unit: "sport1 unit", Comment: "sport1 comment", SetKind: s.SportSetKindValue},
 			}, res)
 		}
 
@@ -114,14 +155,14 @@ func (r *StorageSQLiteTestSuite) TestBackupRestore() {
 			res, err := r.stg.GetSportActivityReport(context.Background(), 1, 1, 3)
 			r.NoError(err)
 			r.Equal([]s.SportActivityReport{
-				{SportName: "sport1 name [sport1 unit]", Timestamp: 1, Sets: []float64{1, 2, 3}},
-				{SportName: "sport2 name [sport2 unit]", Timestamp: 2, Sets: []float64{4, 5, 6}},
+				{ID: 1, SportKey: "sport1 key", SportName: "sport1 name [sport1 unit]", Timestamp: 1, Sets: valueSets(1, 2, 3)},
+				{ID: 2, SportKey: "sport2 key", SportName: "sport2 name [sport2 unit]", Timestamp: 2, Sets: valueSets(4, 5, 6), Duration: 30, Cal: 150},
 			}, res)
 
 			res, err = r.stg.GetSportActivityReport(context.Background(), 2, 1, 3)
 			r.NoError(err)
 			r.Equal([]s.SportActivityReport{
-				{SportName: "sport1 name [sport1 unit]", Timestamp: 1, Sets: []float64{7, 8, 9}},
+				{ID: 3, SportKey: "sport1 key", SportName: "sport1 name [sport1 unit]", Timestamp: 1, Sets: valueSets(7, 8, 9)},
 			}, res)
 		}
 
@@ -161,11 +202,16 @@ func (r *StorageSQLiteTestSuite) TestBackupRestore() {
 		{
 			res, err := r.stg.GetUserSettings(context.Background(), 1)
 			r.NoError(err)
-			r.Equal(&s.UserSettings{CalLimit: 123.123}, res)
+			r.Equal(&s.UserSettings{
+				CalLimit: 123.123, WaterGoal: 2000, WaterReminder: true,
+				NutrientLimits: s.Nutrients{s.NutrientSugar: 50}, ReportFormat: s.ReportFormatPDF,
+			}, res)
 
 			res, err = r.stg.GetUserSettings(context.Background(), 2)
 			r.NoError(err)
-			r.Equal(&s.UserSettings{CalLimit: 456.456}, res)
+			r.Equal(&s.UserSettings{CalLimit: 456.456, TDEEAutoUpdate: true, Profile: &s.UserProfile{
+				Sex: s.SexFemale, BirthDate: 1, Height: 170, ActivityLevel: 2, BodyFat: 25, Goal: s.GoalLose, GoalRate: 0.5,
+			}}, res)
 		}
 
 		// Food
@@ -174,24 +220,28 @@ func (r *StorageSQLiteTestSuite) TestBackupRestore() {
 			r.NoError(err)
 			r.Equal([]s.Food{
 				{
-					Key:     "food1_key",
-					Name:    "food1_name",
-					Brand:   "food1_brand",
-					Cal100:  1.1,
-					Prot100: 2.2,
-					Fat100:  3.3,
-					Carb100: 4.4,
-					Comment: "food1_comment",
+					Key:       "food1_key",
+					Name:      "food1_name",
+					Brand:     "food1_brand",
+					Cal100:    1.1,
+					Prot100:   2.2,
+					Fat100:    3.3,
+					Carb100:   4.4,
+					Comment:   "food1_comment",
+					Nutrients: s.Nutrients{s.NutrientFiber: 2},
 				},
 				{
-					Key:     "food2_key",
-					Name:    "food2_name",
-					Brand:   "food2_brand",
-					Cal100:  5.5,
-					Prot100: 6.6,
-					Fat100:  7.7,
-					Carb100: 8.8,
-					Comment: "food2_comment",
+					Key:      "food2_key",
+					Name:     "food2_name",
+					Brand:    "food2_brand",
+					Cal100:   5.5,
+					Prot100:  6.6,
+					Fat100:   7.7,
+					Carb100:  8.8,
+					Comment:  "food2_comment",
+					Beverage: true,
+					Portions: []s.FoodPortion{{Name: "cup", Amount: 200, Unit: s.PortionUnitMl}},
+					Density:  1.03,
 				},
 			}, res)
 
@@ -240,9 +290,10 @@ func (r *StorageSQLiteTestSuite) TestBackupRestore() {
 			r.NoError(err)
 			r.Equal([]s.JournalReport{
 				{Timestamp: 1, Meal: s.Meal(0), FoodKey: "food1_key", FoodName: "food1_name", FoodBrand: "food1_brand",
-					FoodWeight: 100, Cal: 1.1, Prot: 2.2, Fat: 3.3, Carb: 4.4},
+					FoodWeight: 100, Cal: 1.1, Prot: 2.2, Fat: 3.3, Carb: 4.4,
+					Nutrients: s.Nutrients{s.NutrientFiber: 2}},
 				{Timestamp: 1, Meal: s.Meal(1), FoodKey: "food2_key", FoodName: "food2_name", FoodBrand: "food2_brand",
-					FoodWeight: 200, Cal: 11, Prot: 13.2, Fat: 15.4, Carb: 17.6},
+					FoodWeight: 200, Cal: 11, Prot: 13.2, Fat: 15.4, Carb: 17.6, FoodBeverage: true, Portion: "1cup"},
 			}, rep)
 
 			rep, err = r.stg.GetJournalReport(context.Background(), 2, 1, 2)
@@ -265,6 +316,66 @@ func (r *StorageSQLiteTestSuite) TestBackupRestore() {
 				r.Equal(t.totalCal, val)
 			}
 		}
+
+		// TDEEEstimate
+		{
+			res, err := r.stg.GetTDEEEstimateList(context.Background(), 1, 1, 2)
+			r.NoError(err)
+			r.Equal([]s.TDEEEstimate{
+				{Timestamp: 1, Weeks: 4, TDEE: 2500, ConfLow: 2400, ConfHigh: 2600, Applied: true},
+				{Timestamp: 2, Weeks: 2, TDEE: 2450, ConfLow: 2300, ConfHigh: 2600},
+			}, res)
+		}
+
+		// Workout
+		{
+			res, err := r.stg.GetWorkoutList(context.Background(), 1)
+			r.NoError(err)
+			r.Equal([]s.Workout{
+				{Key: "workout1", Name: "workout1 name", Items: []s.WorkoutItem{
+					{SportKey: "sport1 key", Sets: valueSets(10, 10)},
+					{SportKey: "sport2 key", Sets: valueSets(5)},
+				}, Comment: "workout1 comment"},
+			}, res)
+		}
+
+		// WorkoutPlan
+		{
+			res, err := r.stg.GetWorkoutPlan(context.Background(), 2)
+			r.NoError(err)
+			r.Equal([]s.WorkoutPlanDay{
+				{Weekday: time.Sunday, WorkoutKey: "workout1"},
+			}, res)
+		}
+
+		// Water
+		{
+			res, err := r.stg.GetWaterList(context.Background(), 1, 1, 2)
+			r.NoError(err)
+			r.Equal([]s.Water{
+				{Timestamp: 1, Volume: 250},
+				{Timestamp: 2, Volume: 500},
+			}, res)
+		}
+
+		// Sleep
+		{
+			res, err := r.stg.GetSleepList(context.Background(), 1, 1, 20)
+			r.NoError(err)
+			r.Equal([]s.Sleep{
+				{Timestamp: 10, BedTime: 1, WakeTime: 8, Quality: 4, Notes: "notes"},
+			}, res)
+		}
+
+		// Fast
+		{
+			res, err := r.stg.GetFastList(context.Background(), 1, 1, 100)
+			r.NoError(err)
+			r.Equal([]s.Fast{
+				{Start: 10, End: 20},
+				{Start: 30},
+			}, res)
+		}
 	})
 
 	r.Run("do backup and check with initial", func() {
@@ -281,5 +392,11 @@ func (r *StorageSQLiteTestSuite) TestBackupRestore() {
 		r.Equal(backup.Bundle, backup2.Bundle)
 		r.Equal(backup.Journal, backup2.Journal)
 		r.Equal(backup.TotalBurnedCal, backup2.TotalBurnedCal)
+		r.Equal(backup.TDEEEstimate, backup2.TDEEEstimate)
+		r.Equal(backup.Workout, backup2.Workout)
+		r.Equal(backup.WorkoutPlan, backup2.WorkoutPlan)
+		r.Equal(backup.Water, backup2.Water)
+		r.Equal(backup.Sleep, backup2.Sleep)
+		r.Equal(backup.Fast, backup2.Fast)
 	})
 }
@@ -1,4 +1,4 @@
-package sqlite
+package storagetest
 
 import (
 	"context"
@@ -6,7 +6,7 @@ import (
 	s "github.com/devldavydov/myhealth/internal/storage"
 )
 
-func (r *StorageSQLiteTestSuite) TestBundleCRUD() {
+func (r *Suite) TestBundleCRUD() {
 	r.Run("get bundle empty list", func() {
 		_, err := r.stg.GetBundleList(context.Background(), 1)
 		r.ErrorIs(err, s.ErrEmptyResult)
@@ -0,0 +1,109 @@
+package storagetest
+
+import (
+	"context"
+
+	s "github.com/devldavydov/myhealth/internal/storage"
+)
+
+func (r *Suite) TestFastCRUD() {
+	r.Run("get empty", func() {
+		_, err := r.stg.GetActiveFast(context.Background(), 1)
+		r.ErrorIs(err, s.ErrFastNotFound)
+
+		_, err = r.stg.GetFastList(context.Background(), 1, 1, 100)
+		r.ErrorIs(err, s.ErrEmptyResult)
+	})
+
+	r.Run("add invalid fast", func() {
+		for _, f := range []s.Fast{
+			{},
+			{Start: 10, End: 5},
+			{Start: 10, End: 10},
+		} {
+			r.ErrorIs(r.stg.AddFast(context.Background(), 1, &f), s.ErrFastInvalid)
+		}
+	})
+
+	r.Run("add fast", func() {
+		r.NoError(r.stg.AddFast(context.Background(), 1, &s.Fast{Start: 10, End: 20}))
+		r.NoError(r.stg.AddFast(context.Background(), 1, &s.Fast{Start: 30}))
+		r.NoError(r.stg.AddFast(context.Background(), 2, &s.Fast{Start: 15}))
+	})
+
+	r.Run("add second active fast", func() {
+		r.ErrorIs(r.stg.AddFast(context.Background(), 1, &s.Fast{Start: 40}), s.ErrFastActive)
+		r.ErrorIs(r.stg.AddFast(context.Background(), 1, &s.Fast{Start: 30}), s.ErrFastActive)
+	})
+
+	r.Run("get active fast", func() {
+		res, err := r.stg.GetActiveFast(context.Background(), 1)
+		r.NoError(err)
+		r.Equal(&s.Fast{Start: 30}, res)
+	})
+
+	r.Run("stop fast", func() {
+		r.ErrorIs(r.stg.StopFast(context.Background(), 1, 30, 30), s.ErrFastInvalid)
+		r.ErrorIs(r.stg.StopFast(context.Background(), 1, 35, 50), s.ErrFastNotFound)
+		r.ErrorIs(r.stg.StopFast(context.Background(), 1, 10, 50), s.ErrFastNotFound)
+
+		r.NoError(r.stg.StopFast(context.Background(), 1, 30, 50))
+
+		_, err := r.stg.GetActiveFast(context.Background(), 1)
+		r.ErrorIs(err, s.ErrFastNotFound)
+	})
+
+	r.Run("add fast overlapping finished fast", func() {
+		for _, f := range []s.Fast{
+			// Same start restarts finished fast
+			{Start: 30},
+			{Start: 30, End: 60},
+			// Start inside window
+			{Start: 15},
+			{Start: 40, End: 45},
+			// Window covers finished fast
+			{Start: 5, End: 25},
+			{Start: 25},
+		} {
+			r.ErrorIs(r.stg.AddFast(context.Background(), 1, &f), s.ErrFastConflict, "fast %v", f)
+		}
+
+		// Finished fasts are kept
+		res, err := r.stg.GetFastList(context.Background(), 1, 1, 100)
+		r.NoError(err)
+		r.Equal([]s.Fast{
+			{Start: 10, End: 20},
+			{Start: 30, End: 50},
+		}, res)
+	})
+
+	r.Run("add fast between finished fasts", func() {
+		r.NoError(r.stg.AddFast(context.Background(), 1, &s.Fast{Start: 20, End: 30}))
+		r.NoError(r.stg.DeleteFast(context.Background(), 1, 20, 20))
+	})
+
+	r.Run("get fast list", func() {
+		res, err := r.stg.GetFastList(context.Background(), 1, 1, 100)
+		r.NoError(err)
+		r.Equal([]s.Fast{
+			{Start: 10, End: 20},
+			{Start: 30, End: 50},
+		}, res)
+
+		res, err = r.stg.GetFastList(context.Background(), 1, 25, 100)
+		r.NoError(err)
+		r.Equal([]s.Fast{
+			{Start: 30, End: 50},
+		}, res)
+	})
+
+	r.Run("delete fast", func() {
+		r.NoError(r.stg.DeleteFast(context.Background(), 1, 5, 15))
+
+		res, err := r.stg.GetFastList(context.Background(), 1, 1, 100)
+		r.NoError(err)
+		r.Equal([]s.Fast{
+			{Start: 30, End: 50},
+		}, res)
+	})
+}
@@ -1,4 +1,4 @@
-package sqlite
+package storagetest
 
 import (
 	"context"
@@ -6,7 +6,7 @@ import (
 	s "github.com/devldavydov/myhealth/internal/storage"
 )
 
-func (r *StorageSQLiteTestSuite) TestFoodCRUD() {
+func (r *Suite) TestFoodCRUD() {
 	r.Run("get food empty list", func() {
 		_, err := r.stg.GetFoodList(context.Background(), 1)
 		r.ErrorIs(err, s.ErrEmptyResult)
@@ -20,6 +20,11 @@ func (r *StorageSQLiteTestSuite) TestFoodCRUD() {
 			{Key: "key", Name: "name", Cal100: 1, Prot100: -1},
 			{Key: "key", Name: "name", Cal100: 1, Prot100: 1, Fat100: -1},
 			{Key: "key", Name: "name", Cal100: 1, Prot100: 1, Fat100: 1, Carb100: -1},
+			{Key: "key", Name: "name", Nutrients: s.Nutrients{s.NutrientFiber: -1}},
+			{Key: "key", Name: "name", Nutrients: s.Nutrients{"iron": 1}},
+			{Key: "key", Name: "name", Portions: []s.FoodPortion{{Name: "egg", Amount: 0, Unit: s.PortionUnitGram}}},
+			{Key: "key", Name: "name", Portions: []s.FoodPortion{{Name: "egg", Amount: 1, Unit: "oz"}}},
+			{Key: "key", Name: "name", Density: -1},
 		} {
 			r.ErrorIs(r.stg.SetFood(context.Background(), 1, &f), s.ErrFoodInvalid)
 		}
@@ -124,6 +129,12 @@ func (r *StorageSQLiteTestSuite) TestFoodCRUD() {
 		r.NoError(r.stg.SetFood(context.Background(), 1, &s.Food{
 			Key: "food1_key", Name: "food1_NAME", Brand: "food1_BRAND",
 			Cal100: 10.10, Prot100: 11.11, Fat100: 12.12, Carb100: 13.13, Comment: "food1_COMMENT",
+			Nutrients: s.Nutrients{s.NutrientFiber: 1.5, s.NutrientSugar: 7},
+			Portions: []s.FoodPortion{
+				{Name: "egg", Amount: 55, Unit: s.PortionUnitGram},
+				{Name: "cup", Amount: 240, Unit: s.PortionUnitMl},
+			},
+			Density: 1.03,
 		}))
 	})
 
@@ -139,6 +150,15 @@ func (r *StorageSQLiteTestSuite) TestFoodCRUD() {
 			Fat100:  12.12,
 			Carb100: 13.13,
 			Comment: "food1_COMMENT",
+			Nutrients: s.Nutrients{
+				s.NutrientFiber: 1.5,
+				s.NutrientSugar: 7,
+			},
+			Portions: []s.FoodPortion{
+				{Name: "egg", Amount: 55, Unit: s.PortionUnitGram},
+				{Name: "cup", Amount: 240, Unit: s.PortionUnitMl},
+			},
+			Density: 1.03,
 		}, res)
 	})
 
@@ -1,4 +1,4 @@
-package sqlite
+package storagetest
 
 import (
 	"context"
@@ -6,7 +6,7 @@ import (
 	s "github.com/devldavydov/myhealth/internal/storage"
 )
 
-func (r *StorageSQLiteTestSuite) TestJournalCRUD() {
+func (r *Suite) TestJournalCRUD() {
 	r.Run("set invalid journal", func() {
 		r.ErrorIs(r.stg.SetJournal(context.TODO(), 1, &s.Journal{
 			Timestamp: 1, Meal: s.Meal(-1), FoodKey: "food", FoodWeight: 100,
@@ -42,9 +42,10 @@ func (r *StorageSQLiteTestSuite) TestJournalCRUD() {
 		}))
 		r.NoError(r.stg.SetFood(context.TODO(), 2, &s.Food{
 			Key: "food_b", Name: "bbb", Brand: "brand b", Cal100: 5, Prot100: 6, Fat100: 7, Carb100: 8, Comment: "",
+			Nutrients: s.Nutrients{s.NutrientFiber: 2, s.NutrientSalt: 0.5},
 		}))
 		r.NoError(r.stg.SetFood(context.TODO(), 2, &s.Food{
-			Key: "food_c", Name: "ccc", Brand: "brand c", Cal100: 1, Prot100: 1, Fat100: 1, Carb100: 1, Comment: "ccc",
+			Key: "food_c", Name: "ccc", Brand: "brand c", Cal100: 1, Prot100: 1, Fat100: 1, Carb100: 1, Comment: "ccc", Beverage: true,
 		}))
 	})
 
@@ -130,23 +131,30 @@ func (r *StorageSQLiteTestSuite) TestJournalCRUD() {
 		}, foodStat)
 	})
 
+	r.Run("get food stat without journal", func() {
+		_, err := r.stg.GetJournalFoodStat(context.TODO(), 3, "food_a")
+		r.ErrorIs(err, s.ErrEmptyResult)
+	})
+
 	r.Run("check that user 2 gets his data", func() {
 		rep, err := r.stg.GetJournalReport(context.TODO(), 2, 1, 3)
 		r.NoError(err)
 		r.Equal([]s.JournalReport{
 			{Timestamp: 3, Meal: s.Meal(0), FoodKey: "food_b", FoodName: "bbb", FoodBrand: "brand b",
-				FoodWeight: 300, Cal: 15, Prot: 18, Fat: 21, Carb: 24},
+				FoodWeight: 300, Cal: 15, Prot: 18, Fat: 21, Carb: 24,
+				Nutrients: s.Nutrients{s.NutrientFiber: 6, s.NutrientSalt: 1.5}},
 			{Timestamp: 3, Meal: s.Meal(1), FoodKey: "food_a", FoodName: "aaa", FoodBrand: "brand a",
 				FoodWeight: 200, Cal: 2, Prot: 4, Fat: 6, Carb: 8},
 			{Timestamp: 3, Meal: s.Meal(1), FoodKey: "food_b", FoodName: "bbb", FoodBrand: "brand b",
-				FoodWeight: 400, Cal: 20, Prot: 24, Fat: 28, Carb: 32},
+				FoodWeight: 400, Cal: 20, Prot: 24, Fat: 28, Carb: 32,
+				Nutrients: s.Nutrients{s.NutrientFiber: 8, s.NutrientSalt: 2}},
 			{Timestamp: 3, Meal: s.Meal(1), FoodKey: "food_c", FoodName: "ccc", FoodBrand: "brand c",
-				FoodWeight: 100, Cal: 1, Prot: 1, Fat: 1, Carb: 1},
+				FoodWeight: 100, Cal: 1, Prot: 1, Fat: 1, Carb: 1, FoodBeverage: true},
 		}, rep)
 	})
 
 	r.Run("update and delete for user 1", func() {
-		r.NoError(r.stg.DeleteJournal(context.TODO(), 1, 1, s.Meal(0), "food_b"))
+		r.NoError(r.stg.DeleteJournal(context.TODO(), 1, 1, 1, s.Meal(0), "food_b"))
 		r.NoError(r.stg.SetJournal(context.TODO(), 1, &s.Journal{Timestamp: 1, Meal: s.Meal(1), FoodKey: "food_a", FoodWeight: 300}))
 
 		rep, err := r.stg.GetJournalReport(context.TODO(), 1, 1, 1)
@@ -168,15 +176,15 @@ func (r *StorageSQLiteTestSuite) TestJournalCRUD() {
 		r.NoError(err)
 		r.Equal(4, len(rep))
 
-		r.NoError(r.stg.DeleteJournalMeal(context.TODO(), 2, 3, s.Meal(0)))
-		r.NoError(r.stg.DeleteJournalMeal(context.TODO(), 2, 3, s.Meal(1)))
+		r.NoError(r.stg.DeleteJournalMeal(context.TODO(), 2, 3, 3, s.Meal(0)))
+		r.NoError(r.stg.DeleteJournalMeal(context.TODO(), 2, 3, 3, s.Meal(1)))
 
 		_, err = r.stg.GetJournalReport(context.TODO(), 2, 3, 3)
 		r.ErrorIs(err, s.ErrEmptyResult)
 	})
 }
 
-func (r *StorageSQLiteTestSuite) TestJournalCopy() {
+func (r *Suite) TestJournalCopy() {
 	r.Run("add food", func() {
 		r.NoError(r.stg.SetFood(context.TODO(), 1, &s.Food{
 			Key: "food_a", Name: "aaa", Brand: "brand a", Cal100: 1, Prot100: 2, Fat100: 3, Carb100: 4, Comment: "Comment",
@@ -191,7 +199,7 @@ func (r *StorageSQLiteTestSuite) TestJournalCopy() {
 
 	r.Run("set initial journal", func() {
 		r.NoError(r.stg.SetJournal(context.TODO(), 1, &s.Journal{
-			Timestamp: 1, Meal: s.Meal(0), FoodKey: "food_b", FoodWeight: 100,
+			Timestamp: 1, Meal: s.Meal(0), FoodKey: "food_b", FoodWeight: 100, Portion: "2slice",
 		}))
 		r.NoError(r.stg.SetJournal(context.TODO(), 1, &s.Journal{
 			Timestamp: 1, Meal: s.Meal(0), FoodKey: "food_a", FoodWeight: 200,
@@ -205,11 +213,11 @@ func (r *StorageSQLiteTestSuite) TestJournalCopy() {
 	})
 
 	r.Run("copy success", func() {
-		cnt, err := r.stg.CopyJournal(context.TODO(), 1, 1, s.Meal(0), 2, s.Meal(0))
+		cnt, err := r.stg.CopyJournal(context.TODO(), 1, 1, 1, s.Meal(0), 2, s.Meal(0))
 		r.NoError(err)
 		r.Equal(2, cnt)
 
-		cnt, err = r.stg.CopyJournal(context.TODO(), 1, 1, s.Meal(0), 2, s.Meal(1))
+		cnt, err = r.stg.CopyJournal(context.TODO(), 1, 1, 1, s.Meal(0), 2, s.Meal(1))
 		r.NoError(err)
 		r.Equal(2, cnt)
 
@@ -219,24 +227,112 @@ func (r *StorageSQLiteTestSuite) TestJournalCopy() {
 			{Timestamp: 2, Meal: s.Meal(0), FoodKey: "food_a", FoodName: "aaa", FoodBrand: "brand a",
 				FoodWeight: 200, Cal: 2, Prot: 4, Fat: 6, Carb: 8},
 			{Timestamp: 2, Meal: s.Meal(0), FoodKey: "food_b", FoodName: "bbb", FoodBrand: "brand b",
-				FoodWeight: 100, Cal: 5, Prot: 6, Fat: 7, Carb: 8},
+				FoodWeight: 100, Cal: 5, Prot: 6, Fat: 7, Carb: 8, Portion: "2slice"},
 			{Timestamp: 2, Meal: s.Meal(0), FoodKey: "food_c", FoodName: "ccc", FoodBrand: "brand c",
 				FoodWeight: 300, Cal: 3, Prot: 3, Fat: 3, Carb: 3},
 			{Timestamp: 2, Meal: s.Meal(1), FoodKey: "food_a", FoodName: "aaa", FoodBrand: "brand a",
 				FoodWeight: 200, Cal: 2, Prot: 4, Fat: 6, Carb: 8},
 			{Timestamp: 2, Meal: s.Meal(1), FoodKey: "food_b", FoodName: "bbb", FoodBrand: "brand b",
-				FoodWeight: 100, Cal: 5, Prot: 6, Fat: 7, Carb: 8},
+				FoodWeight: 100, Cal: 5, Prot: 6, Fat: 7, Carb: 8, Portion: "2slice"},
 		}, rep)
 	})
 
 	r.Run("copy zero", func() {
-		cnt, err := r.stg.CopyJournal(context.TODO(), 1, 10, s.Meal(0), 20, s.Meal(1))
+		cnt, err := r.stg.CopyJournal(context.TODO(), 1, 10, 10, s.Meal(0), 20, s.Meal(1))
 		r.NoError(err)
 		r.Equal(0, cnt)
 	})
 }
 
-func (r *StorageSQLiteTestSuite) TestSetJournalBundle() {
+func (r *Suite) TestJournalTimeOfDay() {
+	const (
+		day    = s.Timestamp(86400000)
+		hour   = s.Timestamp(3600000)
+		ts0800 = day + 8*hour
+		ts1930 = day + 19*hour + hour/2
+	)
+
+	r.Run("add food and bundle", func() {
+		r.NoError(r.stg.SetFood(context.TODO(), 1, &s.Food{
+			Key: "food_a", Name: "aaa", Brand: "brand a", Cal100: 1, Prot100: 2, Fat100: 3, Carb100: 4,
+		}))
+		r.NoError(r.stg.SetFood(context.TODO(), 1, &s.Food{
+			Key: "food_b", Name: "bbb", Brand: "brand b", Cal100: 5, Prot100: 6, Fat100: 7, Carb100: 8,
+		}))
+		r.NoError(r.stg.SetBundle(context.TODO(), 1, &s.Bundle{
+			Key:  "bndl",
+			Data: map[string]float64{"food_a": 100, "food_b": 200},
+		}, true))
+	})
+
+	setJournal := func() {
+		for _, ts := range []s.Timestamp{ts0800, ts1930} {
+			r.NoError(r.stg.SetJournal(context.TODO(), 1, &s.Journal{
+				Timestamp: ts, Meal: s.Meal(0), FoodKey: "food_a", FoodWeight: 100,
+			}))
+			r.NoError(r.stg.SetJournal(context.TODO(), 1, &s.Journal{
+				Timestamp: ts, Meal: s.Meal(0), FoodKey: "food_b", FoodWeight: 200,
+			}))
+		}
+	}
+
+	r.Run("copy day", func() {
+		setJournal()
+
+		cnt, err := r.stg.CopyJournal(context.TODO(), 1, day, 2*day-1, s.Meal(0), 3*day, s.Meal(1))
+		r.NoError(err)
+		r.Equal(4, cnt)
+
+		rep, err := r.stg.GetJournalReport(context.TODO(), 1, 3*day, 4*day-1)
+		r.NoError(err)
+		r.Equal([]s.JournalReport{
+			{Timestamp: 2*day + ts0800, Meal: s.Meal(1), FoodKey: "food_a", FoodName: "aaa", FoodBrand: "brand a",
+				FoodWeight: 100, Cal: 1, Prot: 2, Fat: 3, Carb: 4},
+			{Timestamp: 2*day + ts0800, Meal: s.Meal(1), FoodKey: "food_b", FoodName: "bbb", FoodBrand: "brand b",
+				FoodWeight: 200, Cal: 10, Prot: 12, Fat: 14, Carb: 16},
+			{Timestamp: 2*day + ts1930, Meal: s.Meal(1), FoodKey: "food_a", FoodName: "aaa", FoodBrand: "brand a",
+				FoodWeight: 100, Cal: 1, Prot: 2, Fat: 3, Carb: 4},
+			{Timestamp: 2*day + ts1930, Meal: s.Meal(1), FoodKey: "food_b", FoodName: "bbb", FoodBrand: "brand b",
+				FoodWeight: 200, Cal: 10, Prot: 12, Fat: 14, Carb: 16},
+		}, rep)
+	})
+
+	r.Run("delete food for day", func() {
+		r.NoError(r.stg.DeleteJournal(context.TODO(), 1, day, 2*day-1, s.Meal(0), "food_a"))
+
+		rep, err := r.stg.GetJournalReport(context.TODO(), 1, day, 2*day-1)
+		r.NoError(err)
+		r.Equal(2, len(rep))
+		for _, item := range rep {
+			r.Equal("food_b", item.FoodKey)
+		}
+	})
+
+	r.Run("delete meal for day", func() {
+		r.NoError(r.stg.DeleteJournalMeal(context.TODO(), 1, day, 2*day-1, s.Meal(0)))
+
+		_, err := r.stg.GetJournalReport(context.TODO(), 1, day, 2*day-1)
+		r.ErrorIs(err, s.ErrEmptyResult)
+	})
+
+	r.Run("delete bundle for day", func() {
+		for _, ts := range []s.Timestamp{ts0800, ts1930} {
+			r.NoError(r.stg.SetJournalBundle(context.TODO(), 1, ts, s.Meal(0), "bndl"))
+		}
+		r.NoError(r.stg.DelJournalBundle(context.TODO(), 1, day, 2*day-1, s.Meal(0), "bndl"))
+
+		_, err := r.stg.GetJournalReport(context.TODO(), 1, day, 2*day-1)
+		r.ErrorIs(err, s.ErrEmptyResult)
+	})
+
+	r.Run("copied day is kept", func() {
+		rep, err := r.stg.GetJournalReport(context.TODO(), 1, 3*day, 4*day-1)
+		r.NoError(err)
+		r.Equal(4, len(rep))
+	})
+}
+
+func (r *Suite) TestSetJournalBundle() {
 	r.Run("add food", func() {
 		r.NoError(r.stg.SetFood(context.TODO(), 1, &s.Food{
 			Key: "food_a", Name: "aaa", Brand: "brand a", Cal100: 1, Prot100: 2, Fat100: 3, Carb100: 4, Comment: "Comment",
@@ -349,10 +445,83 @@ func (r *StorageSQLiteTestSuite) TestSetJournalBundle() {
 	})
 
 	r.Run("delete journal bundle", func() {
-		r.NoError(r.stg.DelJournalBundle(context.TODO(), 1, 1, s.Meal(0), "bndl5"))
-		r.NoError(r.stg.DelJournalBundle(context.TODO(), 1, 1, s.Meal(1), "bndlC"))
+		r.NoError(r.stg.DelJournalBundle(context.TODO(), 1, 1, 1, s.Meal(0), "bndl5"))
+		r.NoError(r.stg.DelJournalBundle(context.TODO(), 1, 1, 1, s.Meal(1), "bndlC"))
 
 		_, err := r.stg.GetJournalReport(context.TODO(), 1, 1, 2)
 		r.ErrorIs(err, s.ErrEmptyResult)
 	})
 }
+
+func (r *Suite) TestJournalMealSuggestions() {
+	const day = s.Timestamp(86400000)
+
+	r.Run("get empty suggestions", func() {
+		_, err := r.stg.GetJournalMealSuggestions(context.TODO(), 1, s.Meal(0), 0, 10*day, 10)
+		r.ErrorIs(err, s.ErrEmptyResult)
+	})
+
+	r.Run("add food", func() {
+		r.NoError(r.stg.SetFood(context.TODO(), 1, &s.Food{
+			Key: "food_a", Name: "aaa", Brand: "brand a", Cal100: 1, Prot100: 2, Fat100: 3, Carb100: 4,
+		}))
+		r.NoError(r.stg.SetFood(context.TODO(), 1, &s.Food{
+			Key: "food_b", Name: "bbb", Brand: "brand b", Cal100: 5, Prot100: 6, Fat100: 7, Carb100: 8,
+		}))
+		r.NoError(r.stg.SetFood(context.TODO(), 1, &s.Food{
+			Key: "food_c", Name: "ccc", Brand: "brand c", Cal100: 1, Prot100: 1, Fat100: 1, Carb100: 1,
+		}))
+	})
+
+	r.Run("set journal", func() {
+		// food_a: old, but often
+		for _, ts := range []s.Timestamp{1 * day, 2 * day, 3 * day} {
+			r.NoError(r.stg.SetJournal(context.TODO(), 1, &s.Journal{
+				Timestamp: ts, Meal: s.Meal(0), FoodKey: "food_a", FoodWeight: 100,
+			}))
+		}
+		// food_b: recent
+		for _, ts := range []s.Timestamp{9 * day, 10 * day} {
+			r.NoError(r.stg.SetJournal(context.TODO(), 1, &s.Journal{
+				Timestamp: ts, Meal: s.Meal(0), FoodKey: "food_b", FoodWeight: 200,
+			}))
+		}
+		// food_c: other meal
+		r.NoError(r.stg.SetJournal(context.TODO(), 1, &s.Journal{
+			Timestamp: 10 * day, Meal: s.Meal(1), FoodKey: "food_c", FoodWeight: 300,
+		}))
+	})
+
+	r.Run("get suggestions", func() {
+		res, err := r.stg.GetJournalMealSuggestions(context.TODO(), 1, s.Meal(0), 0, 10*day, 10)
+		r.NoError(err)
+		r.Equal(2, len(res))
+
+		r.Equal("food_b", res[0].FoodKey)
+		r.Equal("bbb", res[0].FoodName)
+		r.Equal("brand b", res[0].FoodBrand)
+		r.Equal(int64(2), res[0].Count)
+		r.InDelta(1.5, res[0].Score, 0.0001)
+
+		r.Equal("food_a", res[1].FoodKey)
+		r.Equal(int64(3), res[1].Count)
+		r.InDelta(1.0/10+1.0/9+1.0/8, res[1].Score, 0.0001)
+	})
+
+	r.Run("get suggestions with limit and window", func() {
+		res, err := r.stg.GetJournalMealSuggestions(context.TODO(), 1, s.Meal(0), 0, 10*day, 1)
+		r.NoError(err)
+		r.Equal(1, len(res))
+		r.Equal("food_b", res[0].FoodKey)
+
+		res, err = r.stg.GetJournalMealSuggestions(context.TODO(), 1, s.Meal(0), 0, 5*day, 10)
+		r.NoError(err)
+		r.Equal(1, len(res))
+		r.Equal("food_a", res[0].FoodKey)
+	})
+
+	r.Run("get suggestions for other user", func() {
+		_, err := r.stg.GetJournalMealSuggestions(context.TODO(), 2, s.Meal(0), 0, 10*day, 10)
+		r.ErrorIs(err, s.ErrEmptyResult)
+	})
+}
@@ -1,4 +1,4 @@
-package sqlite
+package storagetest
 
 import (
 	"context"
@@ -6,7 +6,7 @@ import (
 	s "github.com/devldavydov/myhealth/internal/storage"
 )
 
-func (r *StorageSQLiteTestSuite) TestMedicineCRUD() {
+func (r *Suite) TestMedicineCRUD() {
 	r.Run("check empty medicine list for user 1", func() {
 		_, err := r.stg.GetMedicineList(context.Background(), 1)
 		r.ErrorIs(err, s.ErrEmptyResult)
@@ -100,7 +100,7 @@ func (r *StorageSQLiteTestSuite) TestMedicineCRUD() {
 	})
 }
 
-func (r *StorageSQLiteTestSuite) TestMedicineIndicatorCRUD() {
+func (r *Suite) TestMedicineIndicatorCRUD() {
 	r.Run("get empty medicine indicator report", func() {
 		_, err := r.stg.GetMedicineIndicatorReport(context.Background(), 1, 1, 3)
 		r.ErrorIs(err, s.ErrEmptyResult)
@@ -166,7 +166,7 @@ func (r *StorageSQLiteTestSuite) TestMedicineIndicatorCRUD() {
 	})
 
 	r.Run("delete medicine indicator", func() {
-		r.NoError(r.stg.DeleteMedicineIndicator(context.Background(), 1, 1, "med1 key"))
+		r.NoError(r.stg.DeleteMedicineIndicator(context.Background(), 1, 1, 1, "med1 key"))
 	})
 
 	r.Run("get medicine indicator report", func() {
@@ -198,3 +198,38 @@ func (r *StorageSQLiteTestSuite) TestMedicineIndicatorCRUD() {
 		r.ErrorIs(r.stg.DeleteMedicine(context.Background(), 1, "med2 key"), s.ErrMedicineIsUsed)
 	})
 }
+
+func (r *Suite) TestMedicineIndicatorDeleteDay() {
+	const (
+		day  = s.Timestamp(86400000)
+		hour = s.Timestamp(3600000)
+	)
+
+	r.Run("set medicine indicators with time of day", func() {
+		r.NoError(r.stg.SetMedicine(context.Background(), 1, &s.Medicine{
+			Key: "med1 key", Name: "med1 name", Unit: "med1 unit",
+		}))
+		r.NoError(r.stg.SetMedicine(context.Background(), 1, &s.Medicine{
+			Key: "med2 key", Name: "med2 name", Unit: "med2 unit",
+		}))
+		for _, ts := range []s.Timestamp{day + 8*hour, day + 19*hour + hour/2} {
+			r.NoError(r.stg.SetMedicineIndicator(context.Background(), 1, &s.MedicineIndicator{
+				MedicineKey: "med1 key", Timestamp: ts, Value: 1.1,
+			}))
+			r.NoError(r.stg.SetMedicineIndicator(context.Background(), 1, &s.MedicineIndicator{
+				MedicineKey: "med2 key", Timestamp: ts, Value: 2.2,
+			}))
+		}
+	})
+
+	r.Run("delete medicine indicator for day", func() {
+		r.NoError(r.stg.DeleteMedicineIndicator(context.Background(), 1, day, 2*day-1, "med1 key"))
+
+		res, err := r.stg.GetMedicineIndicatorReport(context.Background(), 1, day, 2*day-1)
+		r.NoError(err)
+		r.Equal([]s.MedicineIndicatorReport{
+			{MedicineName: "med2 name [med2 unit]", Timestamp: day + 8*hour, Value: 2.2},
+			{MedicineName: "med2 name [med2 unit]", Timestamp: day + 19*hour + hour/2, Value: 2.2},
+		}, res)
+	})
+}
@@ -0,0 +1,78 @@
+package storagetest
+
+import (
+	"context"
+
+	s "github.com/devldavydov/myhealth/internal/storage"
+)
+
+func (r *Suite) TestSleepCRUD() {
+	r.Run("get empty", func() {
+		_, err := r.stg.GetSleep(context.Background(), 1, 10)
+		r.ErrorIs(err, s.ErrSleepNotFound)
+
+		_, err = r.stg.GetSleepList(context.Background(), 1, 1, 100)
+		r.ErrorIs(err, s.ErrEmptyResult)
+	})
+
+	r.Run("set invalid sleep", func() {
+		for _, sl := range []s.Sleep{
+			{},
+			{Timestamp: 10, BedTime: 8, WakeTime: 1, Quality: 3},
+			{Timestamp: 10, BedTime: 1, WakeTime: 8},
+			{Timestamp: 10, BedTime: 1, WakeTime: 8, Quality: 6},
+			{Timestamp: 10, BedTime: 1, WakeTime: 1 + 25*60*60*1000, Quality: 3},
+		} {
+			r.ErrorIs(r.stg.SetSleep(context.Background(), 1, &sl), s.ErrSleepInvalid)
+		}
+	})
+
+	r.Run("set sleep", func() {
+		r.NoError(r.stg.SetSleep(context.Background(), 1, &s.Sleep{
+			Timestamp: 10, BedTime: 1, WakeTime: 8, Quality: 3, Notes: "notes",
+		}))
+		r.NoError(r.stg.SetSleep(context.Background(), 1, &s.Sleep{
+			Timestamp: 20, BedTime: 11, WakeTime: 19, Quality: 5,
+		}))
+		r.NoError(r.stg.SetSleep(context.Background(), 2, &s.Sleep{
+			Timestamp: 10, BedTime: 2, WakeTime: 9, Quality: 1,
+		}))
+	})
+
+	r.Run("update sleep", func() {
+		r.NoError(r.stg.SetSleep(context.Background(), 1, &s.Sleep{
+			Timestamp: 10, BedTime: 2, WakeTime: 9, Quality: 4, Notes: "updated",
+		}))
+	})
+
+	r.Run("get sleep", func() {
+		res, err := r.stg.GetSleep(context.Background(), 1, 10)
+		r.NoError(err)
+		r.Equal(&s.Sleep{Timestamp: 10, BedTime: 2, WakeTime: 9, Quality: 4, Notes: "updated"}, res)
+	})
+
+	r.Run("get sleep list", func() {
+		res, err := r.stg.GetSleepList(context.Background(), 1, 1, 100)
+		r.NoError(err)
+		r.Equal([]s.Sleep{
+			{Timestamp: 10, BedTime: 2, WakeTime: 9, Quality: 4, Notes: "updated"},
+			{Timestamp: 20, BedTime: 11, WakeTime: 19, Quality: 5},
+		}, res)
+
+		res, err = r.stg.GetSleepList(context.Background(), 1, 15, 100)
+		r.NoError(err)
+		r.Equal([]s.Sleep{
+			{Timestamp: 20, BedTime: 11, WakeTime: 19, Quality: 5},
+		}, res)
+	})
+
+	r.Run("delete sleep", func() {
+		r.NoError(r.stg.DeleteSleep(context.Background(), 1, 10))
+
+		res, err := r.stg.GetSleepList(context.Background(), 1, 1, 100)
+		r.NoError(err)
+		r.Equal([]s.Sleep{
+			{Timestamp: 20, BedTime: 11, WakeTime: 19, Quality: 5},
+		}, res)
+	})
+}
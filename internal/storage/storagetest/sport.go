@@ -0,0 +1,437 @@
+package storagetest
+
+import (
+	"context"
+
+	s "github.com/devldavydov/myhealth/internal/storage"
+)
+
+func (r *Suite) TestSportCRUD() {
+	r.Run("check empty sport list for user 1", func() {
+		_, err := r.stg.GetSportList(context.Background(), 1)
+		r.ErrorIs(err, s.ErrEmptyResult)
+	})
+
+	r.Run("set invalid sport", func() {
+		r.ErrorIs(r.stg.SetSport(context.Background(), 1, &s.Sport{}), s.ErrSportInvalid)
+		r.ErrorIs(r.stg.SetSport(context.Background(), 1, &s.Sport{Key: "key"}), s.ErrSportInvalid)
+		r.ErrorIs(r.stg.SetSport(context.Background(), 1, &s.Sport{Key: "key", Name: "name"}), s.ErrSportInvalid)
+		r.ErrorIs(r.stg.SetSport(context.Background(), 1, &s.Sport{Key: "key", Name: "name", Unit: "unit"}), s.ErrSportInvalid)
+	})
+
+	r.Run("set sport", func() {
+		r.NoError(r.stg.SetSport(context.Background(), 1, &s.Sport{
+			Key:     "sport1 key",
+			Name:    "sport1 name",
+			Unit:    "sport1 unit",
+			Comment: "sport1 comment",
+			SetKind: s.SportSetKindValue,
+		}))
+		r.NoError(r.stg.SetSport(context.Background(), 1, &s.Sport{
+			Key:     "sport2 key",
+			Name:    "sport2 name",
+			Unit:    "sport2 unit",
+			Comment: "sport2 comment",
+			SetKind: s.SportSetKindValue,
+		}))
+		r.NoError(r.stg.SetSport(context.Background(), 2, &s.Sport{
+			Key:     "sport1 key",
+			Name:    "sport1 name",
+			Unit:    "sport1 unit",
+			Comment: "sport1 comment",
+			SetKind: s.SportSetKindValue,
+		}))
+	})
+
+	r.Run("get sport for user 1", func() {
+		res, err := r.stg.GetSport(context.Background(), 1, "sport1 key")
+		r.NoError(err)
+		r.Equal(&s.Sport{
+			Key:     "sport1 key",
+			Name:    "sport1 name",
+			Unit:    "sport1 unit",
+			Comment: "sport1 comment",
+			SetKind: s.SportSetKindValue,
+		}, res)
+	})
+
+	r.Run("get not exists sport", func() {
+		_, err := r.stg.GetSport(context.Background(), 3, "sport1 key")
+		r.ErrorIs(err, s.ErrSportNotFound)
+	})
+
+	r.Run("get sport list for user 1", func() {
+		res, err := r.stg.GetSportList(context.Background(), 1)
+		r.NoError(err)
+		r.Equal([]s.Sport{
+			{Key: "sport1 key", Name: "sport1 name", Unit: "sport1 unit", Comment: "sport1 comment", SetKind: s.SportSetKindValue},
+			{Key: "sport2 key", Name: "sport2 name", Unit: "sport2 unit", Comment: "sport2 comment", SetKind: s.SportSetKindValue},
+		}, res)
+	})
+
+	r.Run("get sport list for user 2", func() {
+		res, err := r.stg.GetSportList(context.Background(), 2)
+		r.NoError(err)
+		r.Equal([]s.Sport{
+			{Key: "sport1 key", Name: "sport1 name", Unit: "sport1 unit", Comment: "sport1 comment", SetKind: s.SportSetKindValue},
+		}, res)
+	})
+
+	r.Run("update sport for user 1", func() {
+		r.NoError(r.stg.SetSport(context.Background(), 1, &s.Sport{
+			Key:     "sport1 key",
+			Name:    "sport1 name new",
+			Unit:    "sport1 unit new",
+			Comment: "sport1 comment new",
+			SetKind: s.SportSetKindValue,
+		}))
+	})
+
+	r.Run("get sport list for user 1", func() {
+		res, err := r.stg.GetSportList(context.Background(), 1)
+		r.NoError(err)
+		r.Equal([]s.Sport{
+			{Key: "sport1 key", Name: "sport1 name new", Unit: "sport1 unit new", Comment: "sport1 comment new", SetKind: s.SportSetKindValue},
+			{Key: "sport2 key", Name: "sport2 name", Unit: "sport2 unit", Comment: "sport2 comment", SetKind: s.SportSetKindValue},
+		}, res)
+	})
+
+	r.Run("delete sport for user 2", func() {
+		r.NoError(r.stg.DeleteSport(context.Background(), 2, "sport1 key"))
+	})
+
+	r.Run("check empty sport list for user 2", func() {
+		_, err := r.stg.GetSportList(context.Background(), 2)
+		r.ErrorIs(err, s.ErrEmptyResult)
+	})
+}
+
+func (r *Suite) TestSportActivityCRUD() {
+	r.Run("get empty sport activity report", func() {
+		_, err := r.stg.GetSportActivityReport(context.Background(), 1, 1, 3)
+		r.ErrorIs(err, s.ErrEmptyResult)
+	})
+
+	r.Run("set sport", func() {
+		r.NoError(r.stg.SetSport(context.Background(), 1, &s.Sport{
+			Key:     "sport1 key",
+			Name:    "sport1 name",
+			Unit:    "sport1 unit",
+			Comment: "sport1 comment",
+			SetKind: s.SportSetKindValue,
+		}))
+		r.NoError(r.stg.SetSport(context.Background(), 1, &s.Sport{
+			Key:     "sport2 key",
+			Name:    "sport2 name",
+			Unit:    "sport2 unit",
+			Comment: "sport2 comment",
+			SetKind: s.SportSetKindValue,
+		}))
+		r.NoError(r.stg.SetSport(context.Background(), 2, &s.Sport{
+			Key:     "sport2 key",
+			Name:    "sport2 name",
+			Unit:    "sport2 unit",
+			Comment: "sport2 comment",
+			SetKind: s.SportSetKindValue,
+		}))
+	})
+
+	r.Run("set invalid sport activity", func() {
+		r.ErrorIs(r.stg.SetSportActivity(context.Background(), 1, &s.SportActivity{}), s.ErrSportActivityInvalid)
+		r.ErrorIs(r.stg.SetSportActivity(context.Background(), 1, &s.SportActivity{SportKey: "sport1"}), s.ErrSportActivityInvalid)
+		r.ErrorIs(r.stg.SetSportActivity(context.Background(), 1, &s.SportActivity{
+			SportKey: "sport1",
+			Sets:     valueSets(0),
+		}), s.ErrSportActivityInvalid)
+		r.ErrorIs(r.stg.SetSportActivity(context.Background(), 1, &s.SportActivity{
+			SportKey: "sport1",
+			Sets:     valueSets(-1),
+		}), s.ErrSportActivityInvalid)
+	})
+
+	r.Run("set sport activity for not found sport", func() {
+		r.ErrorIs(r.stg.SetSportActivity(context.Background(), 1, &s.SportActivity{
+			SportKey:  "sport1",
+			Timestamp: 1,
+			Sets:      valueSets(1, 2, 3),
+		}), s.ErrSportNotFound)
+	})
+
+	r.Run("set sport activity", func() {
+		r.NoError(r.stg.SetSportActivity(context.Background(), 1, &s.SportActivity{
+			SportKey:  "sport1 key",
+			Timestamp: 1,
+			Sets:      valueSets(1, 2, 3),
+			Comment:   "comment1",
+		}))
+		r.NoError(r.stg.SetSportActivity(context.Background(), 1, &s.SportActivity{
+			SportKey:  "sport2 key",
+			Timestamp: 2,
+			Sets:      valueSets(4, 5, 6),
+			Comment:   "comment2",
+		}))
+	})
+
+	r.Run("get sport activity report", func() {
+		res, err := r.stg.GetSportActivityReport(context.Background(), 1, 1, 3)
+		r.NoError(err)
+		r.Equal([]s.SportActivityReport{
+			{ID: 1, SportKey: "sport1 key", SportName: "sport1 name [sport1 unit]", Timestamp: 1, Sets: valueSets(1, 2, 3), Comment: "comment1"},
+			{ID: 2, SportKey: "sport2 key", SportName: "sport2 name [sport2 unit]", Timestamp: 2, Sets: valueSets(4, 5, 6), Comment: "comment2"},
+		}, res)
+	})
+
+	r.Run("delete sport activity", func() {
+		r.NoError(r.stg.DeleteSportActivity(context.Background(), 1, 1, 1, "sport1 key"))
+	})
+
+	r.Run("get sport activity report", func() {
+		res, err := r.stg.GetSportActivityReport(context.Background(), 1, 1, 3)
+		r.NoError(err)
+		r.Equal([]s.SportActivityReport{
+			{ID: 2, SportKey: "sport2 key", SportName: "sport2 name [sport2 unit]", Timestamp: 2, Sets: valueSets(4, 5, 6), Comment: "comment2"},
+		}, res)
+	})
+
+	r.Run("update not found sport activity", func() {
+		r.ErrorIs(r.stg.SetSportActivity(context.Background(), 1, &s.SportActivity{
+			ID:        100,
+			SportKey:  "sport2 key",
+			Timestamp: 2,
+			Sets:      valueSets(1),
+		}), s.ErrSportActivityNotFound)
+	})
+
+	r.Run("update sport activity", func() {
+		r.NoError(r.stg.SetSportActivity(context.Background(), 1, &s.SportActivity{
+			ID:        2,
+			SportKey:  "sport2 key",
+			Timestamp: 2,
+			Sets:      valueSets(4, 5, 6, 7, 8, 9),
+		}))
+	})
+
+	r.Run("get sport activity report", func() {
+		res, err := r.stg.GetSportActivityReport(context.Background(), 1, 1, 3)
+		r.NoError(err)
+		r.Equal([]s.SportActivityReport{
+			{ID: 2, SportKey: "sport2 key", SportName: "sport2 name [sport2 unit]", Timestamp: 2, Sets: valueSets(4, 5, 6, 7, 8, 9), Comment: ""},
+		}, res)
+	})
+
+	r.Run("success delete sport for user 2 and error for user 1", func() {
+		r.NoError(r.stg.DeleteSport(context.Background(), 2, "sport2 key"))
+		r.ErrorIs(r.stg.DeleteSport(context.Background(), 1, "sport2 key"), s.ErrSportIsUsed)
+	})
+}
+
+func (r *Suite) TestSportActivityCal() {
+	r.Run("set invalid sport coefficients", func() {
+		r.ErrorIs(r.stg.SetSport(context.Background(), 1, &s.Sport{
+			Key: "key", Name: "name", Unit: "unit", SetKind: s.SportSetKindValue, MET: -1,
+		}), s.ErrSportInvalid)
+		r.ErrorIs(r.stg.SetSport(context.Background(), 1, &s.Sport{
+			Key: "key", Name: "name", Unit: "unit", SetKind: s.SportSetKindValue, CalPerUnit: -1,
+		}), s.ErrSportInvalid)
+	})
+
+	r.Run("set sports and weight", func() {
+		r.NoError(r.stg.SetSport(context.Background(), 1, &s.Sport{
+			Key: "run", Name: "Бег", Unit: "км", SetKind: s.SportSetKindDistance, MET: 10,
+		}))
+		r.NoError(r.stg.SetSport(context.Background(), 1, &s.Sport{
+			Key: "pushup", Name: "Отжимания", Unit: "шт", SetKind: s.SportSetKindReps, CalPerUnit: 0.5,
+		}))
+		r.NoError(r.stg.SetSport(context.Background(), 1, &s.Sport{
+			Key: "plank", Name: "Планка", Unit: "сек", SetKind: s.SportSetKindDuration,
+		}))
+		r.NoError(r.stg.SetWeight(context.Background(), 1, &s.Weight{Timestamp: 1, Value: 80}))
+	})
+
+	r.Run("get sport with coefficients", func() {
+		res, err := r.stg.GetSport(context.Background(), 1, "run")
+		r.NoError(err)
+		r.Equal(&s.Sport{Key: "run", Name: "Бег", Unit: "км", SetKind: s.SportSetKindDistance, MET: 10}, res)
+	})
+
+	r.Run("get empty activity cal", func() {
+		res, err := r.stg.GetSportActivityCal(context.Background(), 1, 2, 2)
+		r.NoError(err)
+		r.Equal(float64(0), res)
+	})
+
+	r.Run("set activities", func() {
+		r.NoError(r.stg.SetSportActivity(context.Background(), 1, &s.SportActivity{
+			SportKey: "run", Timestamp: 2, Sets: valueSets(5), Duration: 30,
+		}))
+		r.NoError(r.stg.SetSportActivity(context.Background(), 1, &s.SportActivity{
+			SportKey: "pushup", Timestamp: 2, Sets: valueSets(20, 20),
+		}))
+		r.NoError(r.stg.SetSportActivity(context.Background(), 1, &s.SportActivity{
+			SportKey: "plank", Timestamp: 2, Sets: valueSets(60),
+		}))
+		r.NoError(r.stg.SetSportActivity(context.Background(), 1, &s.SportActivity{
+			SportKey: "run", Timestamp: 3, Sets: valueSets(5), Cal: 123,
+		}))
+	})
+
+	r.Run("get activity report with cal", func() {
+		res, err := r.stg.GetSportActivityReport(context.Background(), 1, 2, 3)
+		r.NoError(err)
+		r.Equal([]s.SportActivityReport{
+			{ID: 1, SportKey: "run", SportName: "Бег [км]", Timestamp: 2, Sets: []s.SportSet{{Distance: 5}}, Duration: 30, Cal: 400},
+			{ID: 2, SportKey: "pushup", SportName: "Отжимания [шт]", Timestamp: 2, Sets: []s.SportSet{{Reps: 20}, {Reps: 20}}, Cal: 20},
+			{ID: 3, SportKey: "plank", SportName: "Планка [сек]", Timestamp: 2, Sets: []s.SportSet{{Duration: 60}}},
+			{ID: 4, SportKey: "run", SportName: "Бег [км]", Timestamp: 3, Sets: []s.SportSet{{Distance: 5}}, Cal: 123},
+		}, res)
+	})
+
+	r.Run("get activity cal", func() {
+		res, err := r.stg.GetSportActivityCal(context.Background(), 1, 2, 2)
+		r.NoError(err)
+		r.Equal(float64(420), res)
+	})
+}
+
+func (r *Suite) TestSportActivityTypedSets() {
+	r.Run("set sports", func() {
+		r.NoError(r.stg.SetSport(context.Background(), 1, &s.Sport{
+			Key: "bench", Name: "Жим", Unit: "кг", SetKind: s.SportSetKindRepsLoad,
+		}))
+		r.NoError(r.stg.SetSport(context.Background(), 1, &s.Sport{
+			Key: "run", Name: "Бег", Unit: "км", SetKind: s.SportSetKindDistance, MET: 10,
+		}))
+	})
+
+	r.Run("set activity with sets not allowed for kind", func() {
+		r.ErrorIs(r.stg.SetSportActivity(context.Background(), 1, &s.SportActivity{
+			SportKey: "bench", Timestamp: 1, Sets: []s.SportSet{{Duration: 60}},
+		}), s.ErrSportActivityInvalid)
+		r.ErrorIs(r.stg.SetSportActivity(context.Background(), 1, &s.SportActivity{
+			SportKey: "run", Timestamp: 1, Sets: []s.SportSet{{Reps: 8, Load: 60}},
+		}), s.ErrSportActivityInvalid)
+	})
+
+	r.Run("set typed activities", func() {
+		r.NoError(r.stg.SetSportActivity(context.Background(), 1, &s.SportActivity{
+			SportKey: "bench", Timestamp: 1, Sets: []s.SportSet{{Reps: 8, Load: 60, RPE: 7}, {Value: 10}},
+		}))
+		r.NoError(r.stg.SetSportActivity(context.Background(), 1, &s.SportActivity{
+			SportKey: "run", Timestamp: 1, Sets: []s.SportSet{{Distance: 5, Duration: 1650}},
+		}))
+		r.NoError(r.stg.SetWeight(context.Background(), 1, &s.Weight{Timestamp: 1, Value: 60}))
+	})
+
+	r.Run("get typed activities report", func() {
+		res, err := r.stg.GetSportActivityReport(context.Background(), 1, 1, 1)
+		r.NoError(err)
+		r.Equal([]s.SportActivityReport{
+			{ID: 2, SportKey: "run", SportName: "Бег [км]", Timestamp: 1, Sets: []s.SportSet{{Distance: 5, Duration: 1650}}},
+			{ID: 1, SportKey: "bench", SportName: "Жим [кг]", Timestamp: 1, Sets: []s.SportSet{{Reps: 8, Load: 60, RPE: 7}, {Reps: 10}}},
+		}, res)
+	})
+
+	r.Run("get activity history", func() {
+		_, err := r.stg.GetSportActivityHistory(context.Background(), 1, "unknown")
+		r.ErrorIs(err, s.ErrEmptyResult)
+
+		r.NoError(r.stg.SetSportActivity(context.Background(), 1, &s.SportActivity{
+			SportKey: "bench", Timestamp: 2, Sets: []s.SportSet{{Reps: 5, Load: 70}}, Comment: "comment",
+		}))
+
+		res, err := r.stg.GetSportActivityHistory(context.Background(), 1, "bench")
+		r.NoError(err)
+		r.Equal([]s.SportActivity{
+			{ID: 1, SportKey: "bench", Timestamp: 1, Sets: []s.SportSet{{Reps: 8, Load: 60, RPE: 7}, {Reps: 10}}},
+			{ID: 3, SportKey: "bench", Timestamp: 2, Sets: []s.SportSet{{Reps: 5, Load: 70}}, Comment: "comment"},
+		}, res)
+	})
+
+	r.Run("calc cal by sets duration", func() {
+		r.NoError(r.stg.SetSportActivity(context.Background(), 1, &s.SportActivity{
+			ID: 2, SportKey: "run", Timestamp: 1, Sets: []s.SportSet{{Distance: 5, Duration: 1800}},
+		}))
+
+		res, err := r.stg.GetSportActivityCal(context.Background(), 1, 1, 1)
+		r.NoError(err)
+		r.Equal(float64(300), res)
+	})
+}
+
+func (r *Suite) TestSportActivitySessions() {
+	r.NoError(r.stg.SetSport(context.Background(), 1, &s.Sport{
+		Key: "run", Name: "Бег", Unit: "км", SetKind: s.SportSetKindDistance,
+	}))
+
+	r.Run("add sessions of the same day", func() {
+		sa := &s.SportActivity{SportKey: "run", Timestamp: 1, Sets: valueSets(5), Comment: "morning"}
+		r.NoError(r.stg.SetSportActivity(context.Background(), 1, sa))
+		r.Equal(int64(1), sa.ID)
+
+		sa = &s.SportActivity{SportKey: "run", Timestamp: 1, Sets: valueSets(3), Comment: "evening"}
+		r.NoError(r.stg.SetSportActivity(context.Background(), 1, sa))
+		r.Equal(int64(2), sa.ID)
+
+		res, err := r.stg.GetSportActivityReport(context.Background(), 1, 1, 1)
+		r.NoError(err)
+		r.Equal([]s.SportActivityReport{
+			{ID: 1, SportKey: "run", SportName: "Бег [км]", Timestamp: 1, Sets: []s.SportSet{{Distance: 5}}, Comment: "morning"},
+			{ID: 2, SportKey: "run", SportName: "Бег [км]", Timestamp: 1, Sets: []s.SportSet{{Distance: 3}}, Comment: "evening"},
+		}, res)
+	})
+
+	r.Run("delete session by id", func() {
+		r.NoError(r.stg.DeleteSportActivityByID(context.Background(), 1, 1))
+
+		res, err := r.stg.GetSportActivityReport(context.Background(), 1, 1, 1)
+		r.NoError(err)
+		r.Equal([]s.SportActivityReport{
+			{ID: 2, SportKey: "run", SportName: "Бег [км]", Timestamp: 1, Sets: []s.SportSet{{Distance: 3}}, Comment: "evening"},
+		}, res)
+	})
+
+	r.Run("delete not found session by id", func() {
+		r.ErrorIs(r.stg.DeleteSportActivityByID(context.Background(), 1, 1), s.ErrSportActivityNotFound)
+		r.ErrorIs(r.stg.DeleteSportActivityByID(context.Background(), 2, 2), s.ErrSportActivityNotFound)
+	})
+
+	r.Run("delete sessions of day", func() {
+		r.NoError(r.stg.SetSportActivity(context.Background(), 1, &s.SportActivity{
+			SportKey: "run", Timestamp: 1, Sets: valueSets(1),
+		}))
+		r.NoError(r.stg.SetSportActivity(context.Background(), 1, &s.SportActivity{
+			SportKey: "run", Timestamp: 5, Sets: valueSets(2), Cal: 50,
+		}))
+		r.NoError(r.stg.SetSportActivity(context.Background(), 1, &s.SportActivity{
+			SportKey: "run", Timestamp: 20, Sets: valueSets(4), Cal: 70,
+		}))
+
+		cal, err := r.stg.GetSportActivityCal(context.Background(), 1, 1, 10)
+		r.NoError(err)
+		r.Equal(float64(50), cal)
+
+		r.NoError(r.stg.DeleteSportActivity(context.Background(), 1, 1, 10, "run"))
+
+		res, err := r.stg.GetSportActivityReport(context.Background(), 1, 1, 20)
+		r.NoError(err)
+		r.Equal([]s.SportActivityReport{
+			{ID: 5, SportKey: "run", SportName: "Бег [км]", Timestamp: 20, Sets: []s.SportSet{{Distance: 4}}, Cal: 70},
+		}, res)
+	})
+}
+
+func valueSets(vals ...float64) []s.SportSet {
+	sets := make([]s.SportSet, 0, len(vals))
+	for _, v := range vals {
+		sets = append(sets, s.SportSet{Value: v})
+	}
+	return sets
+}
+
+func valueSetsBackup(vals ...float64) []s.SportSetBackup {
+	sets := make([]s.SportSetBackup, 0, len(vals))
+	for _, v := range vals {
+		sets = append(sets, s.SportSetBackup{Value: v})
+	}
+	return sets
+}
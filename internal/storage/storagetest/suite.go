@@ -0,0 +1,34 @@
+// Package storagetest is conformance test suite of storage.Storage, it is
+// run by every storage backend to check that backends behave the same.
+package storagetest
+
+import (
+	"testing"
+
+	s "github.com/devldavydov/myhealth/internal/storage"
+	"github.com/stretchr/testify/suite"
+)
+
+// NewStorageFunc returns new empty storage for test, storage is closed
+// after test. Backend cleanup is registered with t.Cleanup.
+type NewStorageFunc func(t *testing.T) s.Storage
+
+type Suite struct {
+	suite.Suite
+
+	newStorage NewStorageFunc
+	stg        s.Storage
+}
+
+// Run runs conformance suite for storages created by newStorage.
+func Run(t *testing.T, newStorage NewStorageFunc) {
+	suite.Run(t, &Suite{newStorage: newStorage})
+}
+
+func (r *Suite) SetupTest() {
+	r.stg = r.newStorage(r.T())
+}
+
+func (r *Suite) TearDownTest() {
+	r.stg.Close()
+}
@@ -0,0 +1,65 @@
+package storagetest
+
+import (
+	"context"
+
+	s "github.com/devldavydov/myhealth/internal/storage"
+)
+
+func (r *Suite) TestTDEEEstimateCRUD() {
+	r.Run("get empty", func() {
+		_, err := r.stg.GetLastTDEEEstimate(context.Background(), 1)
+		r.ErrorIs(err, s.ErrTDEEEstimateNotFound)
+
+		_, err = r.stg.GetTDEEEstimateList(context.Background(), 1, 1, 10)
+		r.ErrorIs(err, s.ErrEmptyResult)
+	})
+
+	r.Run("set invalid estimate", func() {
+		r.ErrorIs(r.stg.SetTDEEEstimate(context.Background(), 1, &s.TDEEEstimate{}), s.ErrTDEEEstimateInvalid)
+		r.ErrorIs(r.stg.SetTDEEEstimate(context.Background(), 1, &s.TDEEEstimate{
+			Weeks: 1, TDEE: 2000, ConfLow: 2100, ConfHigh: 2200,
+		}), s.ErrTDEEEstimateInvalid)
+	})
+
+	r.Run("set estimates", func() {
+		r.NoError(r.stg.SetTDEEEstimate(context.Background(), 1, &s.TDEEEstimate{
+			Timestamp: 1, Weeks: 4, TDEE: 2500, ConfLow: 2400, ConfHigh: 2600,
+		}))
+		r.NoError(r.stg.SetTDEEEstimate(context.Background(), 1, &s.TDEEEstimate{
+			Timestamp: 5, Weeks: 4, TDEE: 2400, ConfLow: 2300, ConfHigh: 2500, Applied: true,
+		}))
+		r.NoError(r.stg.SetTDEEEstimate(context.Background(), 2, &s.TDEEEstimate{
+			Timestamp: 7, Weeks: 2, TDEE: 2000, ConfLow: 1900, ConfHigh: 2100,
+		}))
+	})
+
+	r.Run("get last estimate", func() {
+		res, err := r.stg.GetLastTDEEEstimate(context.Background(), 1)
+		r.NoError(err)
+		r.Equal(&s.TDEEEstimate{
+			Timestamp: 5, Weeks: 4, TDEE: 2400, ConfLow: 2300, ConfHigh: 2500, Applied: true,
+		}, res)
+	})
+
+	r.Run("update estimate", func() {
+		r.NoError(r.stg.SetTDEEEstimate(context.Background(), 1, &s.TDEEEstimate{
+			Timestamp: 1, Weeks: 2, TDEE: 2550, ConfLow: 2450, ConfHigh: 2650, Applied: true,
+		}))
+	})
+
+	r.Run("get estimate list", func() {
+		res, err := r.stg.GetTDEEEstimateList(context.Background(), 1, 1, 10)
+		r.NoError(err)
+		r.Equal([]s.TDEEEstimate{
+			{Timestamp: 1, Weeks: 2, TDEE: 2550, ConfLow: 2450, ConfHigh: 2650, Applied: true},
+			{Timestamp: 5, Weeks: 4, TDEE: 2400, ConfLow: 2300, ConfHigh: 2500, Applied: true},
+		}, res)
+
+		res, err = r.stg.GetTDEEEstimateList(context.Background(), 2, 1, 10)
+		r.NoError(err)
+		r.Equal([]s.TDEEEstimate{
+			{Timestamp: 7, Weeks: 2, TDEE: 2000, ConfLow: 1900, ConfHigh: 2100},
+		}, res)
+	})
+}
@@ -1,4 +1,4 @@
-package sqlite
+package storagetest
 
 import (
 	"context"
@@ -6,7 +6,7 @@ import (
 	s "github.com/devldavydov/myhealth/internal/storage"
 )
 
-func (r *StorageSQLiteTestSuite) TestTotalBurnedCalCRUD() {
+func (r *Suite) TestTotalBurnedCalCRUD() {
 	r.Run("set invalid day total cal", func() {
 		r.ErrorIs(r.stg.SetTotalBurnedCal(context.Background(), 1, 1, 0), s.ErrDayTotalCalInvalid)
 		r.ErrorIs(r.stg.SetTotalBurnedCal(context.Background(), 1, 1, -1), s.ErrDayTotalCalInvalid)
@@ -0,0 +1,71 @@
+package storagetest
+
+import (
+	"context"
+	"errors"
+
+	s "github.com/devldavydov/myhealth/internal/storage"
+)
+
+func (r *Suite) TestInTx() {
+	r.Run("commit on success", func() {
+		r.NoError(r.stg.InTx(context.Background(), func(stg s.Storage) error {
+			if err := stg.SetWeight(context.Background(), 1, &s.Weight{Timestamp: 1000, Value: 90}); err != nil {
+				return err
+			}
+			// Storage method with own transaction reuses outer one
+			return stg.AddFast(context.Background(), 1, &s.Fast{Start: 1000})
+		}))
+
+		_, err := r.stg.GetWeight(context.Background(), 1, 1000)
+		r.NoError(err)
+		_, err = r.stg.GetActiveFast(context.Background(), 1)
+		r.NoError(err)
+	})
+
+	r.Run("rollback on error", func() {
+		errStop := errors.New("stop")
+		r.ErrorIs(r.stg.InTx(context.Background(), func(stg s.Storage) error {
+			if err := stg.SetWeight(context.Background(), 1, &s.Weight{Timestamp: 2000, Value: 91}); err != nil {
+				return err
+			}
+			if err := stg.DeleteFast(context.Background(), 1, 1000, 1000); err != nil {
+				return err
+			}
+
+			res, err := stg.GetWeightList(context.Background(), 1, 0, 3000, false)
+			r.NoError(err)
+			r.Len(res, 2)
+
+			return errStop
+		}), errStop)
+
+		_, err := r.stg.GetWeight(context.Background(), 1, 2000)
+		r.ErrorIs(err, s.ErrWeightNotFound)
+		_, err = r.stg.GetActiveFast(context.Background(), 1)
+		r.NoError(err)
+	})
+
+	r.Run("rollback on storage error", func() {
+		r.ErrorIs(r.stg.InTx(context.Background(), func(stg s.Storage) error {
+			if err := stg.SetWeight(context.Background(), 1, &s.Weight{Timestamp: 3000, Value: 92}); err != nil {
+				return err
+			}
+			return stg.AddFast(context.Background(), 1, &s.Fast{Start: 3000})
+		}), s.ErrFastActive)
+
+		_, err := r.stg.GetWeight(context.Background(), 1, 3000)
+		r.ErrorIs(err, s.ErrWeightNotFound)
+	})
+
+	r.Run("nested transaction", func() {
+		r.NoError(r.stg.InTx(context.Background(), func(stg s.Storage) error {
+			return stg.InTx(context.Background(), func(stg s.Storage) error {
+				return stg.SetWeight(context.Background(), 1, &s.Weight{Timestamp: 4000, Value: 93})
+			})
+		}))
+
+		_, err := r.stg.GetWeight(context.Background(), 1, 4000)
+		r.NoError(err)
+	})
+}
@@ -0,0 +1,117 @@
+package storagetest
+
+import (
+	"context"
+
+	s "github.com/devldavydov/myhealth/internal/storage"
+)
+
+func (r *Suite) TestUserSettingsCRUD() {
+	r.Run("get empty user settings", func() {
+		_, err := r.stg.GetUserSettings(context.Background(), 1)
+		r.ErrorIs(err, s.ErrUserSettingsNotFound)
+	})
+
+	r.Run("set invalid settings", func() {
+		r.ErrorIs(r.stg.SetUserSettings(context.Background(), 1, &s.UserSettings{}), s.ErrUserSettingsInvalid)
+	})
+
+	r.Run("set user settings", func() {
+		r.NoError(r.stg.SetUserSettings(context.Background(), 1, &s.UserSettings{CalLimit: 123.123}))
+	})
+
+	r.Run("get user settings", func() {
+		res, err := r.stg.GetUserSettings(context.Background(), 1)
+		r.NoError(err)
+		r.Equal(&s.UserSettings{CalLimit: 123.123}, res)
+	})
+
+	r.Run("update user settings", func() {
+		r.NoError(r.stg.SetUserSettings(context.Background(), 1, &s.UserSettings{CalLimit: 456.456}))
+	})
+
+	r.Run("get updated user settings", func() {
+		res, err := r.stg.GetUserSettings(context.Background(), 1)
+		r.NoError(err)
+		r.Equal(&s.UserSettings{CalLimit: 456.456}, res)
+	})
+
+	r.Run("update tdee auto update", func() {
+		r.NoError(r.stg.SetUserSettings(context.Background(), 1, &s.UserSettings{CalLimit: 456.456, TDEEAutoUpdate: true}))
+
+		res, err := r.stg.GetUserSettings(context.Background(), 1)
+		r.NoError(err)
+		r.Equal(&s.UserSettings{CalLimit: 456.456, TDEEAutoUpdate: true}, res)
+	})
+
+	r.Run("update water goal", func() {
+		r.ErrorIs(r.stg.SetUserSettings(context.Background(), 1, &s.UserSettings{
+			CalLimit: 456.456, TDEEAutoUpdate: true, WaterGoal: -1,
+		}), s.ErrUserSettingsInvalid)
+
+		r.NoError(r.stg.SetUserSettings(context.Background(), 1, &s.UserSettings{
+			CalLimit: 456.456, TDEEAutoUpdate: true, WaterGoal: 2000, WaterReminder: true,
+		}))
+
+		res, err := r.stg.GetUserSettings(context.Background(), 1)
+		r.NoError(err)
+		r.Equal(&s.UserSettings{CalLimit: 456.456, TDEEAutoUpdate: true, WaterGoal: 2000, WaterReminder: true}, res)
+	})
+
+	r.Run("update nutrient limits", func() {
+		r.ErrorIs(r.stg.SetUserSettings(context.Background(), 1, &s.UserSettings{
+			CalLimit: 456.456, NutrientLimits: s.Nutrients{s.NutrientSugar: -1},
+		}), s.ErrUserSettingsInvalid)
+
+		r.NoError(r.stg.SetUserSettings(context.Background(), 1, &s.UserSettings{
+			CalLimit: 456.456, NutrientLimits: s.Nutrients{s.NutrientSugar: 50, s.NutrientSalt: 5},
+		}))
+
+		res, err := r.stg.GetUserSettings(context.Background(), 1)
+		r.NoError(err)
+		r.Equal(&s.UserSettings{
+			CalLimit:       456.456,
+			NutrientLimits: s.Nutrients{s.NutrientSugar: 50, s.NutrientSalt: 5},
+		}, res)
+	})
+
+	r.Run("update report format", func() {
+		r.ErrorIs(r.stg.SetUserSettings(context.Background(), 1, &s.UserSettings{
+			CalLimit: 456.456, ReportFormat: "doc",
+		}), s.ErrUserSettingsInvalid)
+
+		r.NoError(r.stg.SetUserSettings(context.Background(), 1, &s.UserSettings{
+			CalLimit: 456.456, ReportFormat: s.ReportFormatPDF,
+		}))
+
+		res, err := r.stg.GetUserSettings(context.Background(), 1)
+		r.NoError(err)
+		r.Equal(&s.UserSettings{CalLimit: 456.456, ReportFormat: s.ReportFormatPDF}, res)
+	})
+
+	r.Run("set invalid profile", func() {
+		r.ErrorIs(r.stg.SetUserSettings(context.Background(), 1, &s.UserSettings{
+			CalLimit: 456.456,
+			Profile:  &s.UserProfile{Sex: "x", Height: 180, ActivityLevel: 1, Goal: s.GoalMaintain},
+		}), s.ErrUserSettingsInvalid)
+	})
+
+	r.Run("update profile", func() {
+		us := &s.UserSettings{
+			CalLimit: 456.456,
+			Profile: &s.UserProfile{
+				Sex:           s.SexMale,
+				BirthDate:     123,
+				Height:        180,
+				ActivityLevel: 3,
+				Goal:          s.GoalGain,
+				GoalRate:      0.25,
+			},
+		}
+		r.NoError(r.stg.SetUserSettings(context.Background(), 1, us))
+
+		res, err := r.stg.GetUserSettings(context.Background(), 1)
+		r.NoError(err)
+		r.Equal(us, res)
+	})
+}
@@ -0,0 +1,46 @@
+package storagetest
+
+import (
+	"context"
+
+	s "github.com/devldavydov/myhealth/internal/storage"
+)
+
+func (r *Suite) TestWaterCRUD() {
+	r.Run("get empty list", func() {
+		_, err := r.stg.GetWaterList(context.Background(), 1, 1, 100)
+		r.ErrorIs(err, s.ErrEmptyResult)
+	})
+
+	r.Run("add invalid water", func() {
+		r.ErrorIs(r.stg.AddWater(context.Background(), 1, &s.Water{Timestamp: 1}), s.ErrWaterInvalid)
+		r.ErrorIs(r.stg.AddWater(context.Background(), 1, &s.Water{Timestamp: 1, Volume: -100}), s.ErrWaterInvalid)
+	})
+
+	r.Run("add water", func() {
+		r.NoError(r.stg.AddWater(context.Background(), 1, &s.Water{Timestamp: 1, Volume: 250}))
+		r.NoError(r.stg.AddWater(context.Background(), 1, &s.Water{Timestamp: 1, Volume: 300}))
+		r.NoError(r.stg.AddWater(context.Background(), 1, &s.Water{Timestamp: 5, Volume: 200}))
+		r.NoError(r.stg.AddWater(context.Background(), 1, &s.Water{Timestamp: 20, Volume: 500}))
+		r.NoError(r.stg.AddWater(context.Background(), 2, &s.Water{Timestamp: 1, Volume: 100}))
+	})
+
+	r.Run("get water list", func() {
+		res, err := r.stg.GetWaterList(context.Background(), 1, 1, 10)
+		r.NoError(err)
+		r.Equal([]s.Water{
+			{Timestamp: 1, Volume: 550},
+			{Timestamp: 5, Volume: 200},
+		}, res)
+	})
+
+	r.Run("delete water", func() {
+		r.NoError(r.stg.DeleteWater(context.Background(), 1, 1, 10))
+
+		res, err := r.stg.GetWaterList(context.Background(), 1, 1, 100)
+		r.NoError(err)
+		r.Equal([]s.Water{
+			{Timestamp: 20, Volume: 500},
+		}, res)
+	})
+}
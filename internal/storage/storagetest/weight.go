@@ -1,4 +1,4 @@
-package sqlite
+package storagetest
 
 import (
 	"context"
@@ -6,7 +6,7 @@ import (
 	s "github.com/devldavydov/myhealth/internal/storage"
 )
 
-func (r *StorageSQLiteTestSuite) TestWeightCRUD() {
+func (r *Suite) TestWeightCRUD() {
 	r.Run("check empty weight list", func() {
 		_, err := r.stg.GetWeightList(context.Background(), 1, 1000, 2000, false)
 		r.ErrorIs(err, s.ErrEmptyResult)
@@ -54,6 +54,15 @@ func (r *StorageSQLiteTestSuite) TestWeightCRUD() {
 		r.ErrorIs(err, s.ErrWeightNotFound)
 	})
 
+	r.Run("get last weight for user 1", func() {
+		res, err := r.stg.GetLastWeight(context.Background(), 1, 2500)
+		r.NoError(err)
+		r.Equal(&s.Weight{Timestamp: 2000, Value: 94}, res)
+
+		_, err = r.stg.GetLastWeight(context.Background(), 1, 999)
+		r.ErrorIs(err, s.ErrWeightNotFound)
+	})
+
 	r.Run("get weight for user 2", func() {
 		res, err := r.stg.GetWeightList(context.Background(), 2, 1000, 4000, false)
 		r.NoError(err)
@@ -75,7 +84,7 @@ func (r *StorageSQLiteTestSuite) TestWeightCRUD() {
 	})
 
 	r.Run("delete weight for user 2", func() {
-		r.NoError(r.stg.DeleteWeight(context.Background(), 2, 1000))
+		r.NoError(r.stg.DeleteWeight(context.Background(), 2, 1000, 1000))
 	})
 
 	r.Run("get weight empty list for user 2", func() {
@@ -83,3 +92,26 @@ func (r *StorageSQLiteTestSuite) TestWeightCRUD() {
 		r.ErrorIs(err, s.ErrEmptyResult)
 	})
 }
+
+func (r *Suite) TestWeightDeleteDay() {
+	const (
+		day  = s.Timestamp(86400000)
+		hour = s.Timestamp(3600000)
+	)
+
+	r.Run("set weight with time of day", func() {
+		r.NoError(r.stg.SetWeight(context.Background(), 1, &s.Weight{Timestamp: day + 8*hour, Value: 80.5}))
+		r.NoError(r.stg.SetWeight(context.Background(), 1, &s.Weight{Timestamp: day + 19*hour + hour/2, Value: 81}))
+		r.NoError(r.stg.SetWeight(context.Background(), 1, &s.Weight{Timestamp: 2*day + 8*hour, Value: 80}))
+	})
+
+	r.Run("delete weight for day", func() {
+		r.NoError(r.stg.DeleteWeight(context.Background(), 1, day, 2*day-1))
+
+		res, err := r.stg.GetWeightList(context.Background(), 1, 0, 3*day, false)
+		r.NoError(err)
+		r.Equal([]s.Weight{
+			{Timestamp: 2*day + 8*hour, Value: 80},
+		}, res)
+	})
+}
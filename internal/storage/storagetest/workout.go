@@ -0,0 +1,170 @@
+package storagetest
+
+import (
+	"context"
+	"time"
+
+	s "github.com/devldavydov/myhealth/internal/storage"
+)
+
+func (r *Suite) TestWorkoutCRUD() {
+	r.Run("get workout empty list", func() {
+		_, err := r.stg.GetWorkoutList(context.Background(), 1)
+		r.ErrorIs(err, s.ErrEmptyResult)
+	})
+
+	r.Run("get workout not found", func() {
+		_, err := r.stg.GetWorkout(context.Background(), 1, "workout")
+		r.ErrorIs(err, s.ErrWorkoutNotFound)
+	})
+
+	r.Run("set invalid workout", func() {
+		for _, w := range []s.Workout{
+			{},
+			{Key: "key", Name: "name"},
+			{Key: "key", Name: "name", Items: []s.WorkoutItem{{SportKey: "bench"}}},
+			{Key: "key", Name: "name", Items: []s.WorkoutItem{{SportKey: "bench", Sets: []s.SportSet{{}}}}},
+		} {
+			r.ErrorIs(r.stg.SetWorkout(context.Background(), 1, &w), s.ErrWorkoutInvalid)
+		}
+	})
+
+	r.Run("set workout with not found sport", func() {
+		r.ErrorIs(r.stg.SetWorkout(context.Background(), 1, &s.Workout{
+			Key: "upper", Name: "Верх", Items: []s.WorkoutItem{{SportKey: "bench", Sets: valueSets(8)}},
+		}), s.ErrSportNotFound)
+	})
+
+	r.Run("add sports", func() {
+		r.NoError(r.stg.SetSport(context.Background(), 1, &s.Sport{
+			Key: "bench", Name: "Жим", Unit: "кг", SetKind: s.SportSetKindRepsLoad,
+		}))
+		r.NoError(r.stg.SetSport(context.Background(), 1, &s.Sport{
+			Key: "pushup", Name: "Отжимания", Unit: "шт", SetKind: s.SportSetKindReps,
+		}))
+	})
+
+	r.Run("set workout with sets not allowed for kind", func() {
+		r.ErrorIs(r.stg.SetWorkout(context.Background(), 1, &s.Workout{
+			Key: "upper", Name: "Верх", Items: []s.WorkoutItem{{SportKey: "pushup", Sets: []s.SportSet{{Duration: 60}}}},
+		}), s.ErrWorkoutInvalid)
+	})
+
+	r.Run("set workouts", func() {
+		r.NoError(r.stg.SetWorkout(context.Background(), 1, &s.Workout{
+			Key:  "upper",
+			Name: "Верх",
+			Items: []s.WorkoutItem{
+				{SportKey: "bench", Sets: []s.SportSet{{Reps: 8, Load: 60}, {Reps: 8, Load: 60}}},
+				{SportKey: "pushup", Sets: valueSets(20)},
+			},
+			Comment: "comment",
+		}))
+		r.NoError(r.stg.SetWorkout(context.Background(), 1, &s.Workout{
+			Key: "light", Name: "Легкая", Items: []s.WorkoutItem{{SportKey: "pushup", Sets: valueSets(10)}},
+		}))
+	})
+
+	r.Run("get workout", func() {
+		res, err := r.stg.GetWorkout(context.Background(), 1, "upper")
+		r.NoError(err)
+		r.Equal(&s.Workout{
+			Key:  "upper",
+			Name: "Верх",
+			Items: []s.WorkoutItem{
+				{SportKey: "bench", Sets: []s.SportSet{{Reps: 8, Load: 60}, {Reps: 8, Load: 60}}},
+				{SportKey: "pushup", Sets: []s.SportSet{{Reps: 20}}},
+			},
+			Comment: "comment",
+		}, res)
+	})
+
+	r.Run("get workout list", func() {
+		res, err := r.stg.GetWorkoutList(context.Background(), 1)
+		r.NoError(err)
+		r.Equal([]s.Workout{
+			{Key: "upper", Name: "Верх", Items: []s.WorkoutItem{
+				{SportKey: "bench", Sets: []s.SportSet{{Reps: 8, Load: 60}, {Reps: 8, Load: 60}}},
+				{SportKey: "pushup", Sets: []s.SportSet{{Reps: 20}}},
+			}, Comment: "comment"},
+			{Key: "light", Name: "Легкая", Items: []s.WorkoutItem{
+				{SportKey: "pushup", Sets: []s.SportSet{{Reps: 10}}},
+			}},
+		}, res)
+	})
+
+	r.Run("delete sport used in workout", func() {
+		r.ErrorIs(r.stg.DeleteSport(context.Background(), 1, "bench"), s.ErrSportIsUsed)
+	})
+
+	r.Run("delete workout", func() {
+		r.NoError(r.stg.DeleteWorkout(context.Background(), 1, "light"))
+
+		_, err := r.stg.GetWorkout(context.Background(), 1, "light")
+		r.ErrorIs(err, s.ErrWorkoutNotFound)
+	})
+}
+
+func (r *Suite) TestWorkoutPlanCRUD() {
+	r.Run("get empty plan", func() {
+		_, err := r.stg.GetWorkoutPlan(context.Background(), 1)
+		r.ErrorIs(err, s.ErrEmptyResult)
+	})
+
+	r.Run("set invalid plan day", func() {
+		for _, pd := range []s.WorkoutPlanDay{
+			{Weekday: time.Monday},
+			{Weekday: -1, WorkoutKey: "upper"},
+			{Weekday: 7, WorkoutKey: "upper"},
+		} {
+			r.ErrorIs(r.stg.SetWorkoutPlanDay(context.Background(), 1, &pd), s.ErrWorkoutPlanDayInvalid)
+		}
+	})
+
+	r.Run("set plan day with not found workout", func() {
+		r.ErrorIs(r.stg.SetWorkoutPlanDay(context.Background(), 1, &s.WorkoutPlanDay{
+			Weekday: time.Monday, WorkoutKey: "upper",
+		}), s.ErrWorkoutNotFound)
+	})
+
+	r.Run("set plan days", func() {
+		r.NoError(r.stg.SetSport(context.Background(), 1, &s.Sport{
+			Key: "pushup", Name: "Отжимания", Unit: "шт", SetKind: s.SportSetKindReps,
+		}))
+		r.NoError(r.stg.SetWorkout(context.Background(), 1, &s.Workout{
+			Key: "upper", Name: "Верх", Items: []s.WorkoutItem{{SportKey: "pushup", Sets: valueSets(20)}},
+		}))
+		r.NoError(r.stg.SetWorkout(context.Background(), 1, &s.Workout{
+			Key: "light", Name: "Легкая", Items: []s.WorkoutItem{{SportKey: "pushup", Sets: valueSets(10)}},
+		}))
+
+		r.NoError(r.stg.SetWorkoutPlanDay(context.Background(), 1, &s.WorkoutPlanDay{Weekday: time.Friday, WorkoutKey: "upper"}))
+		r.NoError(r.stg.SetWorkoutPlanDay(context.Background(), 1, &s.WorkoutPlanDay{Weekday: time.Monday, WorkoutKey: "upper"}))
+		r.NoError(r.stg.SetWorkoutPlanDay(context.Background(), 1, &s.WorkoutPlanDay{Weekday: time.Friday, WorkoutKey: "light"}))
+	})
+
+	r.Run("get plan", func() {
+		res, err := r.stg.GetWorkoutPlan(context.Background(), 1)
+		r.NoError(err)
+		r.Equal([]s.WorkoutPlanDay{
+			{Weekday: time.Monday, WorkoutKey: "upper"},
+			{Weekday: time.Friday, WorkoutKey: "light"},
+		}, res)
+	})
+
+	r.Run("delete workout used in plan", func() {
+		r.ErrorIs(r.stg.DeleteWorkout(context.Background(), 1, "upper"), s.ErrWorkoutIsUsed)
+	})
+
+	r.Run("delete plan day", func() {
+		r.NoError(r.stg.DeleteWorkoutPlanDay(context.Background(), 1, time.Monday))
+
+		res, err := r.stg.GetWorkoutPlan(context.Background(), 1)
+		r.NoError(err)
+		r.Equal([]s.WorkoutPlanDay{
+			{Weekday: time.Friday, WorkoutKey: "light"},
+		}, res)
+
+		r.NoError(r.stg.DeleteWorkout(context.Background(), 1, "upper"))
+	})
+}